@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"docktui/internal/config"
+)
+
+// runConfig 执行 docktui config init|validate|path|keys：管理配置文件
+func runConfig(args []string) error {
+	usage := fmt.Errorf("usage: docktui config init [--force] | validate [file] | path | keys")
+	if len(args) == 0 {
+		return usage
+	}
+	switch args[0] {
+	case "init":
+		return runConfigInit(args[1:])
+	case "validate":
+		return runConfigValidate(args[1:])
+	case "path":
+		return runConfigPath()
+	case "keys":
+		return runConfigKeys()
+	}
+	return usage
+}
+
+// runConfigInit 写入带注释的配置文件模板，已存在时需要 --force 覆盖
+func runConfigInit(args []string) error {
+	fs := flag.NewFlagSet("config init", flag.ContinueOnError)
+	force := fs.Bool("force", false, "overwrite an existing config file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	path, err := config.FilePath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil && !*force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(config.Template()), 0600); err != nil {
+		return err
+	}
+	fmt.Printf("Config file written to %s\n", path)
+	return nil
+}
+
+// runConfigValidate 校验配置文件，逐行输出每个问题的位置
+func runConfigValidate(args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		var err error
+		if path, err = config.FilePath(); err != nil {
+			return err
+		}
+	}
+	if _, err := config.LoadFile(path); err != nil {
+		var problems interface{ Unwrap() []error }
+		if errors.As(err, &problems) {
+			for _, p := range problems.Unwrap() {
+				fmt.Fprintln(os.Stderr, p)
+			}
+			return fmt.Errorf("%s has %d problem(s)", path, len(problems.Unwrap()))
+		}
+		return err
+	}
+	fmt.Printf("✓ %s is valid\n", path)
+	return nil
+}
+
+// runConfigKeys 列出可以用环境变量和 --set 覆盖的配置项（优先级：参数 > 环境变量 > 配置文件 > 默认值）
+func runConfigKeys() error {
+	for _, key := range config.SettingKeys() {
+		fmt.Printf("%-26s %s\n", key, config.EnvName(key))
+	}
+	fmt.Println("\nOverride with --set key=value or the environment variable; flags win over env, env over the config file.")
+	return nil
+}
+
+// runConfigPath 输出使用的配置文件路径及是否存在
+func runConfigPath() error {
+	path, err := config.FilePath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		fmt.Printf("%s (not found, run docktui config init)\n", path)
+		return nil
+	}
+	fmt.Println(path)
+	return nil
+}
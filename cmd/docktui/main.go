@@ -2,21 +2,170 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"docktui/internal/audit"
+	"docktui/internal/compose"
 	"docktui/internal/config"
+	"docktui/internal/crash"
 	"docktui/internal/docker"
+	"docktui/internal/favorites"
+	"docktui/internal/fleet"
+	"docktui/internal/format"
+	"docktui/internal/launchpad"
+	"docktui/internal/notes"
+	"docktui/internal/notify"
+	"docktui/internal/plugin"
+	"docktui/internal/policy"
+	"docktui/internal/redact"
+	"docktui/internal/stack"
+	"docktui/internal/task"
+	"docktui/internal/trash"
 	"docktui/internal/ui"
+	"docktui/internal/ui/components"
+	"docktui/internal/ui/styles"
 )
 
 func main() {
+	// 子命令：logs <name> 直接打开容器日志，其余不启动 TUI，直接执行后退出
+	args := os.Args[1:]
+	var logsTarget string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		var err error
+		switch args[0] {
+		case "logs":
+			if len(args) < 2 || strings.HasPrefix(args[1], "-") {
+				err = fmt.Errorf("usage: docktui logs <container> [flags]")
+			}
+		case "snapshot":
+			err = runSnapshot(args[1:])
+		case "diff":
+			err = runDiff(args[1:])
+		case "config":
+			err = runConfig(args[1:])
+		case "version":
+			err = runVersion(args[1:])
+		default:
+			err = fmt.Errorf("unknown command %q (available: logs, snapshot, diff, config, version)", args[0])
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		if args[0] != "logs" {
+			return
+		}
+		logsTarget, args = args[1], args[2:]
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
-	_ = cfg
+
+	readOnly := flag.Bool("read-only", cfg.ReadOnly, "disable all mutating actions (inspect, logs and stats only)")
+	policyFile := flag.String("policy", cfg.PolicyFile, "policy file listing the allowed actions per resource")
+	notifyMethod := flag.String("notify", cfg.Notify, "notify on unhealthy containers, non-zero exits and finished tasks: bell, osc9 or exec")
+	notifyCommand := flag.String("notify-cmd", cfg.NotifyCommand, "command run by --notify=exec (gets DOCKTUI_NOTIFY_EVENT/TITLE/MESSAGE)")
+	notifyEvents := flag.String("notify-events", cfg.NotifyEvents, "comma separated events to notify on (unhealthy, exit, task)")
+	dockerCLI := flag.String("docker-cli", cfg.DockerCLI, "path to the docker CLI used for interactive shells (default: PATH, then Docker Desktop locations)")
+	redactFile := flag.String("redact", cfg.RedactFile, "YAML file with regex rules masking secrets in logs and log exports")
+	actionsFile := flag.String("actions", cfg.ActionsFile, "YAML file defining custom actions bound to keys")
+	homeFile := flag.String("home-config", cfg.HomeFile, "YAML file choosing the home view cards and quick actions")
+	ascii := flag.String("ascii", cfg.ASCII, "replace icons and box-drawing characters with plain text: auto, on or off")
+	startView := flag.String("view", "", "view to open on start: "+strings.Join(ui.StartupViews, ", "))
+	startFilter := flag.String("filter", "", "filter applied to the start view, e.g. running or dangling")
+	startSearch := flag.String("search", "", "search keyword applied to the start view")
+	var overrides setFlags
+	flag.Var(&overrides, "set", "override a config file key, e.g. --set theme.color=256 (repeatable; keys: "+strings.Join(config.SettingKeys(), ", ")+")")
+	flag.CommandLine.Parse(args)
+	if err := overrides.apply(cfg); err != nil {
+		log.Fatalf("Invalid --set: %v", err)
+	}
+	// 单独的 --ascii 参数比 --set theme.ascii 更具体，显式指定时优先
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "ascii" {
+			if err := cfg.Set("--ascii", "theme.ascii", *ascii); err != nil {
+				log.Fatalf("Invalid --ascii: %v", err)
+			}
+		}
+	})
+	if logsTarget != "" && (*startView != "" || *startFilter != "" || *startSearch != "") {
+		log.Fatalf("docktui logs cannot be combined with --view, --filter or --search")
+	}
+	policy.SetReadOnly(*readOnly)
+	components.SetCopyFileFallback(cfg.CopyToFile)
+	styles.SetASCII(styles.ResolveASCII(cfg.ASCII))
+	styles.SetColorMode(cfg.Color)
+	styles.SetPalette(cfg.Palette)
+	format.Configure(format.Options{Units: cfg.SizeUnits, Decimals: cfg.SizeDecimals, Locale: cfg.Locale()})
+	components.SetKeyBindings(cfg.KeyBindings)
+	if err := loadPolicy(*policyFile); err != nil {
+		log.Fatalf("Failed to load policy: %v", err)
+	}
+	if err := loadRedactRules(*redactFile); err != nil {
+		log.Fatalf("Failed to load redaction rules: %v", err)
+	}
+	customActions, err := loadCustomActions(*actionsFile)
+	if err != nil {
+		log.Fatalf("Failed to load custom actions: %v", err)
+	}
+	homeConfig, err := loadLaunchpad(*homeFile)
+	if err != nil {
+		log.Fatalf("Failed to load home config: %v", err)
+	}
+	hostsPath, hosts, err := loadHosts(cfg.HostsFile)
+	if err != nil {
+		log.Fatalf("Failed to load hosts: %v", err)
+	}
+	if len(hosts) == 0 && len(cfg.Hosts) > 0 {
+		// hosts.yaml 不存在时使用配置文件中的主机
+		hostsPath = cfg.FilePath
+		for _, h := range cfg.Hosts {
+			name := h.Name
+			if name == "" {
+				name = h.Host
+			}
+			hosts = append(hosts, fleet.Host{Name: name, Host: h.Host})
+		}
+	}
+
+	// 审计、备注和收藏文件打不开时只停用对应功能，问题在首页自检中显示
+	var configProblems []string
+	if !cfg.AuditDisabled {
+		if err := enableAudit(cfg.AuditLog); err != nil {
+			configProblems = append(configProblems, "audit log disabled: "+err.Error())
+		}
+	}
+	if err := enableNotes(cfg.NotesFile); err != nil {
+		configProblems = append(configProblems, "notes disabled: "+err.Error())
+	}
+	if err := enableFavorites(cfg.FavoritesFile); err != nil {
+		configProblems = append(configProblems, "favorites disabled: "+err.Error())
+	}
+	if err := enableStacks(cfg.StacksFile); err != nil {
+		configProblems = append(configProblems, "stacks disabled: "+err.Error())
+	}
+	if err := enableTrash(cfg.TrashDays); err != nil {
+		configProblems = append(configProblems, "trash disabled: "+err.Error())
+	}
+	// docker CLI 上下文中的端点同样出现在多主机概览中，不需要在 hosts.yaml 中重复填写
+	if contexts, err := docker.ListCLIContexts(); err != nil {
+		configProblems = append(configProblems, "docker contexts ignored: "+err.Error())
+	} else {
+		hosts = fleet.WithContexts(hosts, contexts)
+	}
+
+	notifier, err := newNotifier(*notifyMethod, *notifyCommand, *notifyEvents)
+	if err != nil {
+		log.Fatalf("Invalid notify settings: %v", err)
+	}
 
 	// 尝试连接 Docker
 	dockerClient, err := docker.NewLocalClientFromEnv()
@@ -36,22 +185,337 @@ func main() {
 		}
 	}
 
+	if cfg.TaskWorkers > 0 {
+		task.GetManager().SetWorkers(cfg.TaskWorkers)
+	}
+	if len(cfg.ResourcePresets) > 0 {
+		presets := make([]docker.ResourcePreset, 0, len(cfg.ResourcePresets))
+		for _, p := range cfg.ResourcePresets {
+			presets = append(presets, docker.ResourcePreset{Name: p.Name, CPUs: p.CPUs, Memory: int64(p.Memory)})
+		}
+		docker.SetResourcePresets(presets)
+	}
+	if len(cfg.ComposeOverrides) > 0 {
+		overrides := make([]compose.CommandOverride, 0, len(cfg.ComposeOverrides))
+		for _, o := range cfg.ComposeOverrides {
+			overrides = append(overrides, compose.CommandOverride{Project: o.Project, Host: o.Host, Command: strings.Fields(o.Command), Env: o.Env})
+		}
+		compose.SetCommandOverrides(overrides)
+	}
+	if cfg.TaskLogDir != "" {
+		task.GetManager().SetLogDir(cfg.TaskLogDir)
+	}
+
 	// 即使 Docker 连接失败，也启动 TUI 并显示错误信息
 	m := ui.NewModel(dockerClient)
+	m = ui.SetRefreshIntervals(m, ui.RefreshIntervals{
+		Services:     cfg.ServicesRefresh,
+		ComposeCache: cfg.ComposeCacheTTL,
+		Home:         cfg.HomeRefresh,
+		Containers:   cfg.ContainersPoll,
+		Stats:        cfg.StatsRefresh,
+		Processes:    cfg.ProcessRefresh,
+	})
+	m = ui.SetComposeHealthTimeout(m, cfg.HealthTimeout)
+	if cfg.RecordDir != "" {
+		m = ui.SetRecordDir(m, cfg.RecordDir)
+	}
+	if *dockerCLI != "" {
+		m = ui.SetDockerCLI(m, *dockerCLI)
+	}
 	
+	if len(customActions) > 0 {
+		m = ui.SetCustomActions(m, customActions)
+	}
+	if homeConfig != nil {
+		m = ui.SetLaunchpad(m, homeConfig)
+	}
+	if len(hosts) > 0 {
+		m = ui.SetFleetHosts(m, hostsPath, hosts)
+	}
+	if len(configProblems) > 0 {
+		m = ui.SetConfigProblems(m, configProblems)
+	}
+	m = ui.SetUpdateCheck(m, cfg.UpdateCheck)
+	
+	// 退出时取消通知等后台监听
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	// 设置 Docker 连接状态
 	if !dockerConnected {
 		m = ui.SetDockerError(m, dockerError)
+	} else {
+		if notifier != nil {
+			// 通知与固定容器共用一个 Docker 事件订阅
+			hub := docker.NewEventHub(dockerClient)
+			m = ui.SetEventHub(m, hub)
+			go notifier.Run(ctx, hub, task.GetManager())
+		}
+		if logsTarget != "" {
+			details, err := dockerClient.ContainerDetails(context.Background(), logsTarget)
+			if err != nil {
+				log.Fatalf("Container %s not found: %v", logsTarget, err)
+			}
+			m = ui.SetStartupLogs(m, details.ID, details.Name)
+		} else if m, err = ui.SetStartupView(m, *startView, *startFilter, *startSearch); err != nil {
+			log.Fatalf("Invalid start view: %v", err)
+		}
+		if cfg.ResumeTasks && !policy.ReadOnly() {
+			// 上次退出时未完成的拉取/导出/导入任务，启动后询问是否恢复
+			if pending := enableTaskState(dockerClient.GetSDKClient().DaemonHost()); len(pending) > 0 {
+				m = ui.SetPendingTasks(m, pending)
+			}
+		}
 	}
 	
+	if err := runTUI(m); err != nil {
+		log.Fatalf("Failed to start TUI: %v", err)
+	}
+}
+
+// runTUI 运行 TUI。运行期间 log 输出暂存在内存中，避免打乱界面；
+// 发生 panic 时恢复终端并写崩溃报告（附带这些日志）
+func runTUI(m ui.Model) error {
+	logs := crash.NewLogBuffer()
+	log.SetOutput(logs)
+	defer log.SetOutput(os.Stderr)
+
+	handler := crash.NewHandler(logs)
 	// 创建 TUI 程序，使用 alternate screen buffer
 	p := tea.NewProgram(
-		m,
+		handler.Wrap(m),
 		tea.WithAltScreen(),       // 使用替代屏幕缓冲区
 		tea.WithMouseCellMotion(), // 启用鼠标支持（可选）
+		tea.WithoutCatchPanics(),  // panic 由 crash.Handler 处理
 	)
-	
-	if err := p.Start(); err != nil {
-		log.Fatalf("Failed to start TUI: %v", err)
+	handler.Attach(p)
+	defer handler.Recover()
+
+	_, err := p.Run()
+	handler.Wait()
+	return err
+}
+
+// setFlags 可重复的 --set key=value 参数，按出现顺序覆盖配置项
+type setFlags []string
+
+func (s *setFlags) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *setFlags) Set(value string) error {
+	if _, _, ok := strings.Cut(value, "="); !ok {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	*s = append(*s, value)
+	return nil
+}
+
+// apply 依次覆盖配置项，优先级高于环境变量和配置文件
+func (s setFlags) apply(cfg *config.Config) error {
+	for _, kv := range s {
+		key, value, _ := strings.Cut(kv, "=")
+		if err := cfg.Set("--set", strings.TrimSpace(key), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enableTaskState 启用任务持久化，返回上次退出时在当前守护进程上未完成、可以恢复的任务
+func enableTaskState(host string) []task.Spec {
+	path, err := task.DefaultStatePath()
+	if err != nil {
+		return nil
+	}
+	manager := task.GetManager()
+	if err := manager.EnablePersistence(path, host); err != nil {
+		log.Printf("Task state disabled: %v", err)
+		return nil
+	}
+	return manager.PendingResume()
+}
+
+// loadPolicy 加载操作策略；未指定路径时使用默认路径下的策略文件（不存在则不限制）
+func loadPolicy(path string) error {
+	if path == "" {
+		var err error
+		if path, err = policy.DefaultPath(); err != nil {
+			return nil
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil
+		}
+	}
+	rules, err := policy.Load(path)
+	if err != nil {
+		return err
+	}
+	policy.SetRules(rules)
+	return nil
+}
+
+// loadRedactRules 加载日志遮盖规则；未指定路径时使用默认路径下的配置（不存在则不遮盖）
+func loadRedactRules(path string) error {
+	if path == "" {
+		var err error
+		if path, err = redact.DefaultPath(); err != nil {
+			return nil
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil
+		}
+	}
+	rules, err := redact.Load(path)
+	if err != nil {
+		return err
+	}
+	redact.SetRules(rules)
+	return nil
+}
+
+// loadCustomActions 加载自定义操作；未指定路径时使用默认路径下的配置（不存在则没有自定义操作）
+func loadCustomActions(path string) ([]plugin.Action, error) {
+	if path == "" {
+		var err error
+		if path, err = plugin.DefaultPath(); err != nil {
+			return nil, nil
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil, nil
+		}
+	}
+	return plugin.Load(path)
+}
+
+// loadLaunchpad 加载首页配置；未指定路径时使用默认路径下的配置（不存在则使用默认卡片）
+func loadLaunchpad(path string) (*launchpad.Config, error) {
+	if path == "" {
+		var err error
+		if path, err = launchpad.DefaultPath(); err != nil {
+			return nil, nil
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil, nil
+		}
+	}
+	return launchpad.Load(path)
+}
+
+// loadHosts 加载多主机概览的主机列表；未指定路径时使用默认路径下的配置文件（不存在则不加载）
+func loadHosts(path string) (string, []fleet.Host, error) {
+	if path == "" {
+		var err error
+		if path, err = fleet.DefaultPath(); err != nil {
+			return "", nil, nil
+		}
+		if _, err := os.Stat(path); err != nil {
+			return "", nil, nil
+		}
+	}
+	hosts, err := fleet.Load(path)
+	return path, hosts, err
+}
+
+// newNotifier 按参数创建通知器，未开启通知时返回 nil
+func newNotifier(method, command, events string) (*notify.Notifier, error) {
+	m, err := notify.ParseMethod(method)
+	if err != nil || m == "" {
+		return nil, err
+	}
+	if m == notify.MethodExec && command == "" {
+		return nil, fmt.Errorf("--notify=exec requires --notify-cmd or DOCKTUI_NOTIFY_CMD")
+	}
+	kinds, err := notify.ParseEvents(events)
+	if err != nil {
+		return nil, err
+	}
+	return notify.New(notify.Config{Method: m, Command: command, Events: kinds}), nil
+}
+
+// enableAudit 打开审计日志，之后所有变更操作都会追加记录
+func enableAudit(path string) error {
+	if path == "" {
+		var err error
+		if path, err = audit.DefaultPath(); err != nil {
+			return nil
+		}
+	}
+	l, err := audit.Open(path)
+	if err != nil {
+		log.Printf("Audit log disabled: %v", err)
+		return err
+	}
+	audit.SetDefault(l)
+	return nil
+}
+
+// enableNotes 打开容器/镜像备注文件，失败时备注功能不可用
+func enableNotes(path string) error {
+	if path == "" {
+		var err error
+		if path, err = notes.DefaultPath(); err != nil {
+			return nil
+		}
+	}
+	s, err := notes.Open(path)
+	if err != nil {
+		log.Printf("Notes disabled: %v", err)
+		return err
+	}
+	notes.SetDefault(s)
+	return nil
+}
+
+// enableFavorites 打开收藏和最近访问记录文件，失败时首页不显示收藏和最近访问
+func enableFavorites(path string) error {
+	if path == "" {
+		var err error
+		if path, err = favorites.DefaultPath(); err != nil {
+			return nil
+		}
+	}
+	s, err := favorites.Open(path)
+	if err != nil {
+		log.Printf("Favorites disabled: %v", err)
+		return err
+	}
+	favorites.SetDefault(s)
+	return nil
+}
+
+// enableTrash 打开已删除容器的回收站，days 为 0 时不保留
+func enableTrash(days int) error {
+	if days <= 0 {
+		return nil
+	}
+	dir, err := trash.DefaultDir()
+	if err != nil {
+		return nil
+	}
+	s, err := trash.Open(dir, days)
+	if err != nil {
+		log.Printf("Trash disabled: %v", err)
+		return err
+	}
+	trash.SetDefault(s)
+	return nil
+}
+
+// enableStacks 打开容器栈文件（未指定时使用默认路径）
+func enableStacks(path string) error {
+	if path == "" {
+		var err error
+		if path, err = stack.DefaultPath(); err != nil {
+			return nil
+		}
+	}
+	s, err := stack.Open(path)
+	if err != nil {
+		log.Printf("Stacks disabled: %v", err)
+		return err
 	}
+	stack.SetDefault(s)
+	return nil
 }
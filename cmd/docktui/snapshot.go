@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
+	"docktui/internal/snapshot"
+)
+
+var (
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	diffChangedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	diffHintStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+)
+
+// runSnapshot 执行 docktui snapshot [file]：保存当前主机状态
+func runSnapshot(args []string) error {
+	path := fmt.Sprintf("docktui-snapshot-%s.json", time.Now().Format("20060102-150405"))
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	snap, err := captureSnapshot()
+	if err != nil {
+		return err
+	}
+	if err := snapshot.Save(path, snap); err != nil {
+		return err
+	}
+
+	fmt.Printf("Snapshot saved to %s (%d containers, %d images, %d networks)\n",
+		path, len(snap.Containers), len(snap.Images), len(snap.Networks))
+	return nil
+}
+
+// runDiff 执行 docktui diff <file>：对比快照与当前主机状态
+func runDiff(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: docktui diff <snapshot.json>")
+	}
+
+	old, err := snapshot.Load(args[0])
+	if err != nil {
+		return err
+	}
+	cur, err := captureSnapshot()
+	if err != nil {
+		return err
+	}
+
+	changes := snapshot.Diff(old, cur)
+	fmt.Println(diffHintStyle.Render(fmt.Sprintf("Comparing %s (taken %s) with current state",
+		args[0], old.CreatedAt.Local().Format("2006-01-02 15:04:05"))))
+	printChanges(os.Stdout, changes)
+	return nil
+}
+
+// captureSnapshot 连接 Docker 并采集快照
+func captureSnapshot() (*snapshot.Snapshot, error) {
+	client, err := docker.NewLocalClientFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return snapshot.Capture(ctx, client)
+}
+
+// printChanges 按 +/-/~ 前缀着色输出变更
+func printChanges(w io.Writer, changes []snapshot.Change) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No differences")
+		return
+	}
+
+	var added, removed, changed int
+	for _, c := range changes {
+		var line string
+		switch c.Kind {
+		case snapshot.ChangeAdded:
+			added++
+			line = diffAddedStyle.Render(fmt.Sprintf("+ %-9s %s", c.Resource, c.Key))
+		case snapshot.ChangeRemoved:
+			removed++
+			line = diffRemovedStyle.Render(fmt.Sprintf("- %-9s %s", c.Resource, c.Key))
+		default:
+			changed++
+			line = diffChangedStyle.Render(fmt.Sprintf("~ %-9s %s", c.Resource, c.Key))
+		}
+		if len(c.Details) > 0 {
+			line += "  " + diffHintStyle.Render("("+strings.Join(c.Details, ", ")+")")
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "\n%d added, %d removed, %d changed\n", added, removed, changed)
+}
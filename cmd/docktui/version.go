@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"docktui/internal/version"
+)
+
+// versionNotes docktui version --check 输出的更新摘要条数
+const versionNotes = 10
+
+// runVersion 执行 docktui version [--check]：输出构建版本，--check 时查询 GitHub 上的最新版本
+func runVersion(args []string) error {
+	fs := flag.NewFlagSet("version", flag.ContinueOnError)
+	check := fs.Bool("check", false, "check GitHub for a newer release")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	fmt.Println(version.String())
+	if !*check {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	update, err := version.Check(ctx, true)
+	if err != nil {
+		return err
+	}
+	release := update.Latest
+	published := ""
+	if !release.PublishedAt.IsZero() {
+		published = " (" + release.PublishedAt.Format("2006-01-02") + ")"
+	}
+	switch {
+	case update.Available:
+		fmt.Printf("Update available: %s%s\n%s\n", release.Tag, published, release.URL)
+		for _, note := range release.Notes(versionNotes) {
+			fmt.Println("  • " + note)
+		}
+	case update.Current == "dev":
+		fmt.Printf("Latest release: %s%s (development build, not compared)\n", release.Tag, published)
+	default:
+		fmt.Printf("Up to date (latest release: %s%s)\n", release.Tag, published)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+// Package audit 将通过 docktui 执行的变更操作追加记录到 JSONL 审计文件
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Result 操作结果
+const (
+	ResultOK    = "ok"
+	ResultError = "error"
+)
+
+// Entry 一条审计记录
+type Entry struct {
+	Time       time.Time `json:"time"`
+	User       string    `json:"user"`
+	Host       string    `json:"host"`
+	DockerHost string    `json:"docker_host"`
+	Action     string    `json:"action"`
+	Target     string    `json:"target"`
+	Result     string    `json:"result"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Log 审计日志文件
+type Log struct {
+	path       string
+	user       string
+	host       string
+	dockerHost string
+	mu         sync.Mutex
+}
+
+var (
+	defaultLog *Log
+	defaultMu  sync.RWMutex
+)
+
+// DefaultPath 返回默认审计文件路径（用户配置目录下的 docktui/audit.jsonl）
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "audit.jsonl"), nil
+}
+
+// Open 打开（必要时创建）审计文件
+func Open(path string) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create audit directory: %w", err)
+	}
+	// 先确认文件可追加写入，避免在第一次操作时才发现权限问题
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	f.Close()
+
+	l := &Log{
+		path:       path,
+		user:       currentUser(),
+		dockerHost: os.Getenv("DOCKER_HOST"),
+	}
+	l.host, _ = os.Hostname()
+	if l.dockerHost == "" {
+		l.dockerHost = "local"
+	}
+	return l, nil
+}
+
+// Path 返回审计文件路径
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Record 追加一条记录，err 为 nil 表示成功
+func (l *Log) Record(action, target string, err error) error {
+	entry := Entry{
+		Time:       time.Now(),
+		User:       l.user,
+		Host:       l.host,
+		DockerHost: l.dockerHost,
+		Action:     action,
+		Target:     target,
+		Result:     ResultOK,
+	}
+	if err != nil {
+		entry.Result = ResultError
+		entry.Error = err.Error()
+	}
+
+	line, mErr := json.Marshal(entry)
+	if mErr != nil {
+		return mErr
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// 每次以 O_APPEND 打开，单次写入一整行，文件只追加不改写
+	f, oErr := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if oErr != nil {
+		return oErr
+	}
+	defer f.Close()
+	_, wErr := f.Write(line)
+	return wErr
+}
+
+// SetDefault 设置全局审计日志，nil 表示关闭审计
+func SetDefault(l *Log) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultLog = l
+}
+
+// Default 返回全局审计日志（未启用时为 nil）
+func Default() *Log {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultLog
+}
+
+// Record 写入全局审计日志（未启用时忽略），同时记入本次运行的操作历史
+func Record(action, target string, err error) {
+	if l := Default(); l != nil {
+		l.Record(action, target, err)
+	}
+	recordSession(action, target, err, nil)
+}
+
+// ReadRecent 读取审计文件中最近的 limit 条记录（按时间倒序），limit <= 0 表示全部
+func ReadRecent(path string, limit int) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// 跳过损坏的行（如写入中途断电）
+			continue
+		}
+		entries = append(entries, e)
+		if limit > 0 && len(entries) > limit {
+			entries = entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// ShortID 截断容器/镜像 ID 便于阅读
+func ShortID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) == 64 && !strings.ContainsAny(id, ":/") {
+		return id[:12]
+	}
+	return id
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
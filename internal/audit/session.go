@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// maxSessionEntries 本次运行的操作历史最多保留的条数
+const maxSessionEntries = 500
+
+// Undo 撤销一次操作的方法，如暂停后恢复、停止后启动、删除标签后重新打上
+type Undo struct {
+	Label string // 撤销操作的说明，如 "unpause"
+	Run   func(ctx context.Context) error
+}
+
+// SessionEntry 本次运行中执行的一次操作（无论是否启用审计文件都会记录）
+type SessionEntry struct {
+	Entry
+	Seq    int  // 本次运行内递增的编号
+	Undone bool // 已撤销
+	undo   *Undo
+}
+
+// CanUndo 操作成功、可以撤销且还没有撤销
+func (e SessionEntry) CanUndo() bool {
+	return e.undo != nil && e.Result == ResultOK && !e.Undone
+}
+
+// UndoLabel 撤销操作的说明，不可撤销时为空
+func (e SessionEntry) UndoLabel() string {
+	if e.undo == nil {
+		return ""
+	}
+	return e.undo.Label
+}
+
+var (
+	sessionMu      sync.Mutex
+	sessionEntries []SessionEntry
+	sessionSeq     int
+)
+
+// ErrNotUndoable 操作不存在、失败、不可撤销或已经撤销
+var ErrNotUndoable = errors.New("action cannot be undone")
+
+// recordSession 追加到本次运行的操作历史
+func recordSession(action, target string, err error, undo *Undo) {
+	entry := Entry{Time: time.Now(), Action: action, Target: target, Result: ResultOK}
+	if err != nil {
+		entry.Result = ResultError
+		entry.Error = err.Error()
+		undo = nil
+	}
+
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	sessionSeq++
+	sessionEntries = append(sessionEntries, SessionEntry{Entry: entry, Seq: sessionSeq, undo: undo})
+	if len(sessionEntries) > maxSessionEntries {
+		sessionEntries = sessionEntries[len(sessionEntries)-maxSessionEntries:]
+	}
+}
+
+// RecordUndoable 与 Record 相同，并记下成功时撤销操作的方法
+func RecordUndoable(action, target string, err error, undo *Undo) {
+	if l := Default(); l != nil {
+		l.Record(action, target, err)
+	}
+	recordSession(action, target, err, undo)
+}
+
+// Session 返回本次运行的操作历史（按时间倒序）
+func Session() []SessionEntry {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	entries := make([]SessionEntry, len(sessionEntries))
+	for i, e := range sessionEntries {
+		entries[len(sessionEntries)-1-i] = e
+	}
+	return entries
+}
+
+// UndoSession 撤销编号为 seq 的操作；撤销本身作为一次新操作记录在历史中
+func UndoSession(ctx context.Context, seq int) error {
+	sessionMu.Lock()
+	var undo *Undo
+	for i := range sessionEntries {
+		if sessionEntries[i].Seq == seq && sessionEntries[i].CanUndo() {
+			undo = sessionEntries[i].undo
+			sessionEntries[i].Undone = true
+			break
+		}
+	}
+	sessionMu.Unlock()
+	if undo == nil {
+		return ErrNotUndoable
+	}
+
+	if err := undo.Run(ctx); err != nil {
+		// 撤销失败时允许重试
+		sessionMu.Lock()
+		for i := range sessionEntries {
+			if sessionEntries[i].Seq == seq {
+				sessionEntries[i].Undone = false
+			}
+		}
+		sessionMu.Unlock()
+		return err
+	}
+	return nil
+}
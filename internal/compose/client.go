@@ -3,6 +3,7 @@ package compose
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os/exec"
@@ -12,6 +13,9 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"docktui/internal/audit"
+	"docktui/internal/policy"
 )
 
 // CommandType compose 命令类型
@@ -122,32 +126,39 @@ func (c *composeClient) CommandType() string {
 	}
 }
 
-// buildCommand 构建命令
+// buildCommand 构建命令；配置了覆盖设置的项目使用指定的命令和环境变量
 func (c *composeClient) buildCommand(project *Project, args ...string) *exec.Cmd {
-	var cmdArgs []string
-	
-	// 添加项目相关参数
-	projectArgs := c.buildProjectArgs(project)
-	
-	switch c.commandType {
-	case CommandDockerCompose:
+	override, ok := findCommandOverride(project)
+
+	// 添加项目相关参数（覆盖设置指定了 COMPOSE_PROJECT_NAME 时不再传 -p）
+	projectArgs := c.buildProjectArgs(project, override.Env["COMPOSE_PROJECT_NAME"] == "")
+	cmdArgs := append(projectArgs, args...)
+
+	var cmd *exec.Cmd
+	switch {
+	case ok && len(override.Command) > 0:
+		// <command> [subcommand] [project-args] [command] [args]
+		cmd = exec.Command(override.Command[0], append(append([]string{}, override.Command[1:]...), cmdArgs...)...)
+
+	case c.commandType == CommandDockerCompose:
 		// docker compose [project-args] [command] [args]
-		cmdArgs = append([]string{"compose"}, projectArgs...)
-		cmdArgs = append(cmdArgs, args...)
-		return exec.Command("docker", cmdArgs...)
-		
-	case CommandDockerComposeV1:
+		cmd = exec.Command("docker", append([]string{"compose"}, cmdArgs...)...)
+
+	case c.commandType == CommandDockerComposeV1:
 		// docker-compose [project-args] [command] [args]
-		cmdArgs = append(projectArgs, args...)
-		return exec.Command("docker-compose", cmdArgs...)
-		
+		cmd = exec.Command("docker-compose", cmdArgs...)
+
 	default:
 		return nil
 	}
+	if ok && len(override.Env) > 0 {
+		cmd.Env = override.environ()
+	}
+	return cmd
 }
 
 // buildProjectArgs 构建项目相关参数
-func (c *composeClient) buildProjectArgs(project *Project) []string {
+func (c *composeClient) buildProjectArgs(project *Project, withName bool) []string {
 	var args []string
 	
 	if project == nil {
@@ -155,7 +166,7 @@ func (c *composeClient) buildProjectArgs(project *Project) []string {
 	}
 	
 	// 项目名称
-	if project.Name != "" {
+	if withName && project.Name != "" {
 		args = append(args, "-p", project.Name)
 	}
 	
@@ -168,6 +179,11 @@ func (c *composeClient) buildProjectArgs(project *Project) []string {
 		args = append(args, "-f", filePath)
 	}
 	
+	// 启用的 profiles
+	for _, p := range project.Profiles {
+		args = append(args, "--profile", p)
+	}
+	
 	// 环境变量文件
 	for _, f := range project.EnvFiles {
 		filePath := f
@@ -226,6 +242,38 @@ func (c *composeClient) runCommand(project *Project, args ...string) (*Operation
 	return result, nil
 }
 
+// runAudited 执行会改变项目状态的命令，并写入审计日志
+func (c *composeClient) runAudited(project *Project, args ...string) (*OperationResult, error) {
+	if err := policy.Check("compose." + args[0]); err != nil {
+		audit.Record("compose."+args[0], auditTarget(project, args), err)
+		return &OperationResult{Success: false, Message: err.Error(), ExitCode: -1}, err
+	}
+	result, err := c.runCommand(project, args...)
+	audit.Record("compose."+args[0], auditTarget(project, args), err)
+	return result, err
+}
+
+// recordStream 记录流式操作的结果
+func recordStream(project *Project, args []string, result *OperationResult) {
+	var err error
+	if !result.Success {
+		err = errors.New(result.Message)
+	}
+	audit.Record("compose."+args[0], auditTarget(project, args), err)
+}
+
+// auditTarget 审计记录中的目标：项目名及参数
+func auditTarget(project *Project, args []string) string {
+	target := ""
+	if project != nil {
+		target = project.Name
+	}
+	if len(args) > 1 {
+		target += " " + strings.Join(args[1:], " ")
+	}
+	return strings.TrimSpace(target)
+}
+
 // parseErrorMessage 解析错误消息
 func (c *composeClient) parseErrorMessage(stderr string) string {
 	lines := strings.Split(stderr, "\n")
@@ -304,7 +352,7 @@ func (c *composeClient) Up(project *Project, opts UpOptions) (*OperationResult,
 	// 添加服务名称
 	args = append(args, opts.Services...)
 	
-	return c.runCommand(project, args...)
+	return c.runAudited(project, args...)
 }
 
 // Down 停止项目
@@ -324,14 +372,14 @@ func (c *composeClient) Down(project *Project, opts DownOptions) (*OperationResu
 		args = append(args, "-t", strconv.Itoa(opts.Timeout))
 	}
 	
-	return c.runCommand(project, args...)
+	return c.runAudited(project, args...)
 }
 
 // Start 启动已存在的容器
 func (c *composeClient) Start(project *Project, services []string) (*OperationResult, error) {
 	args := []string{"start"}
 	args = append(args, services...)
-	return c.runCommand(project, args...)
+	return c.runAudited(project, args...)
 }
 
 // Stop 停止容器
@@ -341,7 +389,7 @@ func (c *composeClient) Stop(project *Project, services []string, timeout int) (
 		args = append(args, "-t", strconv.Itoa(timeout))
 	}
 	args = append(args, services...)
-	return c.runCommand(project, args...)
+	return c.runAudited(project, args...)
 }
 
 // Restart 重启服务
@@ -351,21 +399,21 @@ func (c *composeClient) Restart(project *Project, services []string, timeout int
 		args = append(args, "-t", strconv.Itoa(timeout))
 	}
 	args = append(args, services...)
-	return c.runCommand(project, args...)
+	return c.runAudited(project, args...)
 }
 
 // Pause 暂停服务
 func (c *composeClient) Pause(project *Project, services []string) (*OperationResult, error) {
 	args := []string{"pause"}
 	args = append(args, services...)
-	return c.runCommand(project, args...)
+	return c.runAudited(project, args...)
 }
 
 // Unpause 恢复服务
 func (c *composeClient) Unpause(project *Project, services []string) (*OperationResult, error) {
 	args := []string{"unpause"}
 	args = append(args, services...)
-	return c.runCommand(project, args...)
+	return c.runAudited(project, args...)
 }
 
 // PS 获取服务状态
@@ -438,6 +486,7 @@ func (c *composeClient) parseJSONPS(output string) ([]Service, error) {
 		state := extractJSONField(line, "State")
 		image := extractJSONField(line, "Image")
 		containerID := extractJSONField(line, "ID")
+		health := extractJSONField(line, "Health")
 		
 		// 如果没有 Service 字段，从容器名称中提取
 		if serviceName == "" {
@@ -454,6 +503,7 @@ func (c *composeClient) parseJSONPS(output string) ([]Service, error) {
 			if state == "running" {
 				svc.Running++
 			}
+			svc.Health = worseHealth(svc.Health, health)
 		} else {
 			svc := &Service{
 				Name:       serviceName,
@@ -462,6 +512,7 @@ func (c *composeClient) parseJSONPS(output string) ([]Service, error) {
 				Containers: []string{containerID},
 				Replicas:   1,
 				Running:    0,
+				Health:     health,
 			}
 			if state == "running" {
 				svc.Running = 1
@@ -485,6 +536,15 @@ func (c *composeClient) parseJSONPS(output string) ([]Service, error) {
 	return services, nil
 }
 
+// worseHealth 汇总多个副本的健康状态：unhealthy > starting > healthy > 未配置
+func worseHealth(a, b string) string {
+	rank := map[string]int{"healthy": 1, "starting": 2, "unhealthy": 3}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
 // parseLegacyPS 解析传统格式的 ps 输出
 func (c *composeClient) parseLegacyPS(output string) ([]Service, error) {
 	var services []Service
@@ -664,13 +724,32 @@ func (c *composeClient) RunCommandStream(project *Project, args ...string) *Oper
 	logChan := make(chan string, 100)
 	doneChan := make(chan *OperationResult, 1)
 	
+	if err := policy.Check("compose." + args[0]); err != nil {
+		close(logChan)
+		result := &OperationResult{
+			Success:  false,
+			Message:  err.Error(),
+			ExitCode: -1,
+		}
+		recordStream(project, args, result)
+		doneChan <- result
+		close(doneChan)
+		return &OperationStream{
+			LogChan:  logChan,
+			DoneChan: doneChan,
+			Cancel:   func() {},
+		}
+	}
+
 	cmd := c.buildCommand(project, args...)
 	if cmd == nil {
 		close(logChan)
-		doneChan <- &OperationResult{
+		result := &OperationResult{
 			Success: false,
 			Message: "Failed to build command",
 		}
+		recordStream(project, args, result)
+		doneChan <- result
 		close(doneChan)
 		return &OperationStream{
 			LogChan:  logChan,
@@ -688,10 +767,12 @@ func (c *composeClient) RunCommandStream(project *Project, args ...string) *Oper
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		close(logChan)
-		doneChan <- &OperationResult{
+		result := &OperationResult{
 			Success: false,
 			Message: "Failed to create stdout pipe: " + err.Error(),
 		}
+		recordStream(project, args, result)
+		doneChan <- result
 		close(doneChan)
 		return &OperationStream{
 			LogChan:  logChan,
@@ -703,10 +784,12 @@ func (c *composeClient) RunCommandStream(project *Project, args ...string) *Oper
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
 		close(logChan)
-		doneChan <- &OperationResult{
+		result := &OperationResult{
 			Success: false,
 			Message: "Failed to create stderr pipe: " + err.Error(),
 		}
+		recordStream(project, args, result)
+		doneChan <- result
 		close(doneChan)
 		return &OperationStream{
 			LogChan:  logChan,
@@ -720,10 +803,12 @@ func (c *composeClient) RunCommandStream(project *Project, args ...string) *Oper
 	// 启动命令
 	if err := cmd.Start(); err != nil {
 		close(logChan)
-		doneChan <- &OperationResult{
+		result := &OperationResult{
 			Success: false,
 			Message: "Failed to start command: " + err.Error(),
 		}
+		recordStream(project, args, result)
+		doneChan <- result
 		close(doneChan)
 		return &OperationStream{
 			LogChan:  logChan,
@@ -803,6 +888,7 @@ func (c *composeClient) RunCommandStream(project *Project, args ...string) *Oper
 			result.Message = "Operation completed"
 		}
 		
+		recordStream(project, args, result)
 		doneChan <- result
 	}()
 	
@@ -906,9 +992,13 @@ func (c *composeClient) Config(project *Project) (string, error) {
 	
 	output, err := cmd.Output()
 	if err != nil {
-		var stderr bytes.Buffer
-		cmd.Stderr = &stderr
-		return "", c.wrapError(err, stderr.String())
+		// Output 会把标准错误收集到 ExitError 中
+		var stderr string
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			stderr = string(exitErr.Stderr)
+		}
+		return "", c.wrapError(err, stderr)
 	}
 	
 	return string(output), nil
@@ -928,7 +1018,7 @@ func (c *composeClient) Build(project *Project, opts BuildOptions) (*OperationRe
 	// 添加服务名称
 	args = append(args, opts.Services...)
 	
-	return c.runCommand(project, args...)
+	return c.runAudited(project, args...)
 }
 
 // Pull 拉取镜像
@@ -942,7 +1032,7 @@ func (c *composeClient) Pull(project *Project, opts PullOptions) (*OperationResu
 	// 添加服务名称
 	args = append(args, opts.Services...)
 	
-	return c.runCommand(project, args...)
+	return c.runAudited(project, args...)
 }
 
 // ReadLogs 读取日志（非流式，返回字符串）
@@ -0,0 +1,64 @@
+package compose
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CommandOverride 为匹配的项目或主机指定 compose 命令和附加的环境变量，
+// 用于仍然需要 docker-compose v1 或 podman-compose 的旧项目
+type CommandOverride struct {
+	Project string            // 项目名称，空表示所有项目
+	Host    string            // 生效的 DOCKER_HOST，空表示所有主机
+	Command []string          // 命令及子命令，如 ["docker-compose"]、["docker", "compose"]，空表示使用自动检测的命令
+	Env     map[string]string // 附加的环境变量，如 COMPOSE_PROJECT_NAME、DOCKER_HOST
+}
+
+var (
+	commandOverrides   []CommandOverride
+	commandOverridesMu sync.RWMutex
+)
+
+// SetCommandOverrides 设置按项目或主机覆盖的 compose 命令，按顺序匹配，第一条生效
+func SetCommandOverrides(overrides []CommandOverride) {
+	commandOverridesMu.Lock()
+	defer commandOverridesMu.Unlock()
+	commandOverrides = overrides
+}
+
+// findCommandOverride 查找适用于项目和当前 DOCKER_HOST 的覆盖设置
+func findCommandOverride(project *Project) (CommandOverride, bool) {
+	commandOverridesMu.RLock()
+	defer commandOverridesMu.RUnlock()
+	name := ""
+	if project != nil {
+		name = project.Name
+	}
+	host := strings.TrimSuffix(os.Getenv("DOCKER_HOST"), "/")
+	for _, o := range commandOverrides {
+		if o.Project != "" && !strings.EqualFold(o.Project, name) {
+			continue
+		}
+		if o.Host != "" && strings.TrimSuffix(o.Host, "/") != host {
+			continue
+		}
+		return o, true
+	}
+	return CommandOverride{}, false
+}
+
+// environ 在当前进程的环境变量上追加覆盖设置中的变量（同名时后者生效）
+func (o CommandOverride) environ() []string {
+	keys := make([]string, 0, len(o.Env))
+	for k := range o.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	env := os.Environ()
+	for _, k := range keys {
+		env = append(env, k+"="+o.Env[k])
+	}
+	return env
+}
@@ -0,0 +1,154 @@
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dependsOn depends_on 字段，可以写成服务名列表或以服务名为键的映射
+type dependsOn []string
+
+// UnmarshalYAML 解析列表或映射两种写法
+func (d *dependsOn) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var names []string
+		if err := node.Decode(&names); err != nil {
+			return err
+		}
+		*d = names
+	case yaml.MappingNode:
+		names := make([]string, 0, len(node.Content)/2)
+		for i := 0; i < len(node.Content); i += 2 {
+			names = append(names, node.Content[i].Value)
+		}
+		*d = names
+	default:
+		return fmt.Errorf("depends_on must be a list or a mapping")
+	}
+	return nil
+}
+
+// composeDependsFile compose 文件中与依赖相关的字段
+type composeDependsFile struct {
+	Services map[string]struct {
+		DependsOn dependsOn `yaml:"depends_on"`
+	} `yaml:"services"`
+}
+
+// ServiceDependencies 读取项目 compose 文件中各服务的 depends_on（服务 -> 它依赖的服务）
+// 多个文件中同名服务的依赖会合并（与 compose 的合并规则一致）
+func ServiceDependencies(project *Project) (map[string][]string, error) {
+	if project == nil {
+		return nil, fmt.Errorf("project not initialized")
+	}
+	result := make(map[string][]string)
+	for _, path := range composeFilePaths(project) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var file composeDependsFile
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+		}
+		for name, svc := range file.Services {
+			for _, dep := range svc.DependsOn {
+				if !containsString(result[name], dep) {
+					result[name] = append(result[name], dep)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// StartOrder 按依赖关系排序服务：被依赖的服务在前（compose up 的启动顺序）。
+// 未选中的服务也参与排序，因此经由它们的间接依赖同样生效；同一层按名称排序，循环依赖的服务排在最后
+func StartOrder(services []string, deps map[string][]string) []string {
+	nodes := make(map[string]bool)
+	for _, s := range services {
+		nodes[s] = true
+	}
+	for s, list := range deps {
+		nodes[s] = true
+		for _, d := range list {
+			nodes[d] = true
+		}
+	}
+
+	// pending[s] = s 尚未排好的依赖数
+	pending := make(map[string]int)
+	dependents := make(map[string][]string)
+	for s := range nodes {
+		for _, d := range deps[s] {
+			if d == s {
+				continue
+			}
+			pending[s]++
+			dependents[d] = append(dependents[d], s)
+		}
+	}
+
+	var ready []string
+	for s := range nodes {
+		if pending[s] == 0 {
+			ready = append(ready, s)
+		}
+	}
+	var ordered []string
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		var next []string
+		for _, s := range ready {
+			ordered = append(ordered, s)
+			for _, dep := range dependents[s] {
+				if pending[dep]--; pending[dep] == 0 {
+					next = append(next, dep)
+				}
+			}
+		}
+		ready = next
+	}
+
+	selected := make(map[string]bool)
+	for _, s := range services {
+		selected[s] = true
+	}
+	result := make([]string, 0, len(services))
+	for _, s := range ordered {
+		if selected[s] {
+			result = append(result, s)
+			delete(selected, s)
+		}
+	}
+	// 循环依赖中的服务
+	var rest []string
+	for s := range selected {
+		rest = append(rest, s)
+	}
+	sort.Strings(rest)
+	return append(result, rest...)
+}
+
+// RestartOrder 滚动重启的顺序：与启动顺序相反，依赖其他服务的先重启，被依赖的最后重启
+func RestartOrder(services []string, deps map[string][]string) []string {
+	order := StartOrder(services, deps)
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -32,22 +32,32 @@ type Discovery struct {
 	lastScan  time.Time
 }
 
+// DefaultCacheTTL 项目缓存的默认有效期
+const DefaultCacheTTL = 30 * time.Second
+
 // NewDiscovery 创建项目发现器
 func NewDiscovery(dockerCli *sdk.Client) *Discovery {
 	return &Discovery{
 		dockerCli: dockerCli,
 		cache:     make(map[string]*Project),
-		cacheTTL:  30 * time.Second,
+		cacheTTL:  DefaultCacheTTL,
 	}
 }
 
+// SetCacheTTL 设置项目缓存的有效期（由容器事件使缓存失效时可以设置得更长）
+func (d *Discovery) SetCacheTTL(ttl time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.cacheTTL = ttl
+}
+
 // DiscoverProjects 发现所有 Compose 项目（通过容器标签）
 func (d *Discovery) DiscoverProjects(ctx context.Context) ([]*Project, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	// 检查缓存是否有效
-	if time.Since(d.lastScan) < d.cacheTTL && len(d.cache) > 0 {
+	// 检查缓存是否有效（没有项目的结果同样缓存）
+	if !d.lastScan.IsZero() && time.Since(d.lastScan) < d.cacheTTL {
 		return d.cacheToSlice(), nil
 	}
 
@@ -88,6 +98,11 @@ func (d *Discovery) DiscoverProjects(ctx context.Context) ([]*Project, error) {
 			serviceMap[projectName] = make(map[string]*Service)
 		}
 
+		// 最近一次创建容器（up、重建）的时间作为项目的活跃时间
+		if created := time.Unix(c.Created, 0); created.After(project.LastActivity) {
+			project.LastActivity = created
+		}
+
 		// 获取服务名
 		serviceName := c.Labels[LabelService]
 		if serviceName == "" {
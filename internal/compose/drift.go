@@ -0,0 +1,249 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ServiceDrift 服务期望状态（compose config）与实际运行状态的差异
+type ServiceDrift struct {
+	Service string
+	Changes []string // 下次 up 会产生的变化，空表示一致
+}
+
+// InSync 是否与期望状态一致
+func (d ServiceDrift) InSync() bool {
+	return len(d.Changes) == 0
+}
+
+// desiredConfig compose config 输出中用到的字段（JSON 是 YAML 的子集，两种格式都能解析）
+type desiredConfig struct {
+	Services map[string]desiredService `yaml:"services"`
+}
+
+type desiredService struct {
+	Image       string `yaml:"image"`
+	Build       any    `yaml:"build"`
+	Environment envMap `yaml:"environment"`
+	Scale       *int   `yaml:"scale"`
+	Deploy      struct {
+		Replicas *int `yaml:"replicas"`
+	} `yaml:"deploy"`
+}
+
+// replicas 期望的副本数
+func (s desiredService) replicas() int {
+	if s.Deploy.Replicas != nil {
+		return *s.Deploy.Replicas
+	}
+	if s.Scale != nil {
+		return *s.Scale
+	}
+	return 1
+}
+
+// envMap environment 既可以是映射也可以是 KEY=VALUE 列表
+type envMap map[string]string
+
+// UnmarshalYAML 同时支持映射和列表两种写法
+func (e *envMap) UnmarshalYAML(node *yaml.Node) error {
+	result := make(envMap)
+	switch node.Kind {
+	case yaml.MappingNode:
+		var m map[string]*string
+		if err := node.Decode(&m); err != nil {
+			return err
+		}
+		for k, v := range m {
+			if v != nil {
+				result[k] = *v
+			}
+		}
+	case yaml.SequenceNode:
+		var list []string
+		if err := node.Decode(&list); err != nil {
+			return err
+		}
+		for _, item := range list {
+			if k, v, ok := strings.Cut(item, "="); ok {
+				result[k] = v
+			}
+		}
+	}
+	*e = result
+	return nil
+}
+
+// actualContainer docker inspect 输出中用到的字段
+type actualContainer struct {
+	Name   string
+	Config struct {
+		Image  string
+		Env    []string
+		Labels map[string]string
+	}
+	State struct {
+		Running bool
+	}
+}
+
+// Drift 比较 compose config 的期望状态和实际容器（镜像、环境变量、副本数），返回按服务名排序的结果
+func (c *composeClient) Drift(project *Project) ([]ServiceDrift, error) {
+	configOutput, err := c.Config(project)
+	if err != nil {
+		return nil, err
+	}
+	var desired desiredConfig
+	if err := yaml.Unmarshal([]byte(configOutput), &desired); err != nil {
+		return nil, fmt.Errorf("failed to parse compose config: %w", err)
+	}
+
+	services, err := c.PS(project)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, svc := range services {
+		ids = append(ids, svc.Containers...)
+	}
+	actual, err := inspectContainers(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareDrift(desired.Services, actual), nil
+}
+
+// ServiceImages 返回 compose config 中各服务的镜像（服务名 -> 镜像），
+// 本地构建的服务（配置了 build）无法拉取，不包含在内
+func (c *composeClient) ServiceImages(project *Project) (map[string]string, error) {
+	configOutput, err := c.Config(project)
+	if err != nil {
+		return nil, err
+	}
+	var desired desiredConfig
+	if err := yaml.Unmarshal([]byte(configOutput), &desired); err != nil {
+		return nil, fmt.Errorf("failed to parse compose config: %w", err)
+	}
+	images := make(map[string]string)
+	for name, svc := range desired.Services {
+		if svc.Image != "" && svc.Build == nil {
+			images[name] = svc.Image
+		}
+	}
+	return images, nil
+}
+
+// inspectContainers 通过 docker inspect 读取容器配置
+func inspectContainers(ids []string) ([]actualContainer, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	output, err := exec.Command("docker", append([]string{"inspect"}, ids...)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect containers: %w", err)
+	}
+	var containers []actualContainer
+	if err := json.Unmarshal(output, &containers); err != nil {
+		return nil, fmt.Errorf("failed to parse docker inspect output: %w", err)
+	}
+	return containers, nil
+}
+
+// compareDrift 逐个服务比较期望状态和实际容器
+func compareDrift(desired map[string]desiredService, actual []actualContainer) []ServiceDrift {
+	byService := make(map[string][]actualContainer)
+	for _, ctr := range actual {
+		name := ctr.Config.Labels["com.docker.compose.service"]
+		if name == "" {
+			name = extractServiceName(strings.TrimPrefix(ctr.Name, "/"))
+		}
+		byService[name] = append(byService[name], ctr)
+	}
+
+	var result []ServiceDrift
+	for name, want := range desired {
+		drift := ServiceDrift{Service: name}
+		containers := byService[name]
+		if len(containers) == 0 {
+			drift.Changes = append(drift.Changes, "not created (would create)")
+			result = append(result, drift)
+			continue
+		}
+
+		// 镜像：只构建不指定镜像名时由 compose 生成名称，无法比较
+		images := make(map[string]bool)
+		for _, ctr := range containers {
+			if want.Image != "" && !sameImage(ctr.Config.Image, want.Image) {
+				images[ctr.Config.Image] = true
+			}
+		}
+		for img := range images {
+			drift.Changes = append(drift.Changes, fmt.Sprintf("image %s → %s", img, want.Image))
+		}
+
+		// 环境变量：容器中还包含镜像自带的变量，只检查期望的变量是否一致；不显示值，避免泄露密钥
+		env := make(map[string]string)
+		for _, kv := range containers[0].Config.Env {
+			if k, v, ok := strings.Cut(kv, "="); ok {
+				env[k] = v
+			}
+		}
+		var changed []string
+		for k, v := range want.Environment {
+			if current, ok := env[k]; !ok || current != v {
+				changed = append(changed, k)
+			}
+		}
+		if len(changed) > 0 {
+			sort.Strings(changed)
+			drift.Changes = append(drift.Changes, fmt.Sprintf("%d env changed (%s)", len(changed), strings.Join(changed, ", ")))
+		}
+
+		// 副本数和运行状态
+		if want := want.replicas(); want != len(containers) {
+			drift.Changes = append(drift.Changes, fmt.Sprintf("replicas %d → %d", len(containers), want))
+		}
+		stopped := 0
+		for _, ctr := range containers {
+			if !ctr.State.Running {
+				stopped++
+			}
+		}
+		if stopped > 0 {
+			drift.Changes = append(drift.Changes, fmt.Sprintf("%d stopped (would start)", stopped))
+		}
+
+		result = append(result, drift)
+	}
+
+	// 配置中已没有、但仍有容器的服务（被删除或属于未启用的 profile）
+	for name := range byService {
+		if _, ok := desired[name]; !ok {
+			result = append(result, ServiceDrift{Service: name, Changes: []string{"not in config (orphan)"}})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Service < result[j].Service })
+	return result
+}
+
+// sameImage 比较镜像引用，未写标签时视为 latest
+func sameImage(a, b string) bool {
+	normalize := func(ref string) string {
+		ref = strings.TrimPrefix(strings.TrimPrefix(ref, "docker.io/"), "library/")
+		if strings.Contains(ref, "@") {
+			return ref
+		}
+		if i := strings.LastIndex(ref, ":"); i < 0 || strings.Contains(ref[i:], "/") {
+			ref += ":latest"
+		}
+		return ref
+	}
+	return normalize(a) == normalize(b)
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/events"
 	sdk "github.com/docker/docker/client"
+
+	"docktui/internal/metrics"
 )
 
 // Manager 整合 Docker API 和 CLI 的 Compose 管理器
@@ -391,9 +393,8 @@ func (m *Manager) GetServiceStats(ctx context.Context, projectName, serviceName
 	return stats, nil
 }
 
-// parseContainerStats 解析容器统计数据
+// parseContainerStats 解析容器统计数据（单次快照），解析失败时只保留 ID 和名称
 func parseContainerStats(containerID string, names []string, body io.Reader) *ContainerStats {
-	// 简化实现，实际需要解析 JSON 流
 	name := ""
 	if len(names) > 0 {
 		name = names[0]
@@ -402,10 +403,25 @@ func parseContainerStats(containerID string, names []string, body io.Reader) *Co
 		}
 	}
 
-	return &ContainerStats{
+	cs := &ContainerStats{
 		ContainerID:   containerID,
 		ContainerName: name,
 	}
+	frame, err := metrics.Decode(body)
+	if err != nil {
+		return cs
+	}
+	s := frame.Sample(nil)
+	cs.CPUPercent = s.CPUPercent
+	cs.MemoryUsage = s.MemoryUsage
+	cs.MemoryLimit = s.MemoryLimit
+	cs.MemoryPercent = s.MemoryPercent
+	cs.NetworkRx = s.NetworkRx
+	cs.NetworkTx = s.NetworkTx
+	cs.BlockRead = s.BlockRead
+	cs.BlockWrite = s.BlockWrite
+	cs.PIDs = s.PIDs
+	return cs
 }
 
 // ===== 事件监听 =====
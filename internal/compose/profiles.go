@@ -0,0 +1,84 @@
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// composeProfilesFile compose 文件中与 profiles 相关的字段
+type composeProfilesFile struct {
+	Services map[string]struct {
+		Profiles []string `yaml:"profiles"`
+	} `yaml:"services"`
+}
+
+// composeFilePaths 返回项目 compose 文件的绝对路径，未指定时查找默认文件名
+func composeFilePaths(project *Project) []string {
+	var paths []string
+	for _, f := range project.ComposeFiles {
+		if project.Path != "" && !filepath.IsAbs(f) {
+			f = filepath.Join(project.Path, f)
+		}
+		paths = append(paths, f)
+	}
+	if len(paths) > 0 || project.Path == "" {
+		return paths
+	}
+	for _, name := range composeFilePatterns {
+		path := filepath.Join(project.Path, name)
+		if _, err := os.Stat(path); err == nil {
+			return []string{path}
+		}
+	}
+	return nil
+}
+
+// ServiceProfiles 读取项目 compose 文件中各服务所属的 profiles
+// 多个文件中同名服务的 profiles 以后面的文件为准（与 compose 的合并规则一致），不属于任何 profile 的服务不在结果中
+func ServiceProfiles(project *Project) (map[string][]string, error) {
+	if project == nil {
+		return nil, fmt.Errorf("project not initialized")
+	}
+	result := make(map[string][]string)
+	for _, path := range composeFilePaths(project) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var file composeProfilesFile
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+		}
+		for name, svc := range file.Services {
+			if svc.Profiles != nil {
+				result[name] = svc.Profiles
+			}
+		}
+	}
+	for name, profiles := range result {
+		if len(profiles) == 0 {
+			delete(result, name)
+		}
+	}
+	return result, nil
+}
+
+// ProfileNames 返回所有服务用到的 profile 名称（去重并排序）
+func ProfileNames(serviceProfiles map[string][]string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, profiles := range serviceProfiles {
+		for _, p := range profiles {
+			if !seen[p] {
+				seen[p] = true
+				names = append(names, p)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,172 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultHealthTimeout 滚动重启时等待每个服务恢复健康的默认时长
+const DefaultHealthTimeout = 2 * time.Minute
+
+// healthPollInterval 等待服务恢复期间查询状态的间隔
+const healthPollInterval = time.Second
+
+// RollingRestartOptions 滚动重启选项
+type RollingRestartOptions struct {
+	Services      []string      // 要重启的服务，按依赖关系重新排序
+	StopTimeout   int           // 每个服务的停止超时（秒），0 表示 compose 默认值
+	HealthTimeout time.Duration // 每个服务重启后等待运行并通过健康检查的时长，0 表示 DefaultHealthTimeout
+}
+
+// RollingRestartStream 按依赖关系的逆序逐个重启服务，每个服务重启后等待其全部容器运行、
+// 配置了健康检查的还要等到 healthy 才继续下一个；任何一步失败或超时都会停止，剩余服务不再重启
+func (c *composeClient) RollingRestartStream(project *Project, opts RollingRestartOptions) *OperationStream {
+	logChan := make(chan string, 100)
+	doneChan := make(chan *OperationResult, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		defer cancel()
+		defer close(doneChan)
+		defer close(logChan)
+		doneChan <- c.rollingRestart(ctx, project, opts, logChan)
+	}()
+
+	return &OperationStream{
+		LogChan:  logChan,
+		DoneChan: doneChan,
+		Cancel:   cancel,
+	}
+}
+
+func (c *composeClient) rollingRestart(ctx context.Context, project *Project, opts RollingRestartOptions, logs chan<- string) *OperationResult {
+	start := time.Now()
+	fail := func(format string, args ...interface{}) *OperationResult {
+		msg := fmt.Sprintf(format, args...)
+		logs <- "❌ " + msg
+		return &OperationResult{Success: false, Message: msg, ExitCode: 1, Duration: time.Since(start)}
+	}
+
+	healthTimeout := opts.HealthTimeout
+	if healthTimeout <= 0 {
+		healthTimeout = DefaultHealthTimeout
+	}
+	deps, err := ServiceDependencies(project)
+	if err != nil {
+		// 读不到依赖时仍可重启，只是按名称顺序
+		logs <- fmt.Sprintf("⚠️ Could not read depends_on, restarting in name order: %v", err)
+	}
+	order := RestartOrder(opts.Services, deps)
+	if len(order) == 0 {
+		return fail("No services to restart")
+	}
+	logs <- fmt.Sprintf("📋 Restart order: %v (health timeout %s per service)", order, healthTimeout)
+
+	for i, name := range order {
+		step := fmt.Sprintf("[%d/%d] %s", i+1, len(order), name)
+		logs <- ""
+		logs <- "🔄 " + step + ": restarting"
+
+		stream := c.RestartStream(project, []string{name}, opts.StopTimeout)
+		result := c.forwardStream(ctx, stream, logs)
+		if ctx.Err() != nil {
+			return fail("Rolling restart cancelled at %s; not restarted: %v", name, order[i+1:])
+		}
+		if result == nil || !result.Success {
+			msg := "operation aborted"
+			if result != nil {
+				msg = result.Message
+			}
+			return fail("Restarting %s failed: %s; not restarted: %v", name, msg, order[i+1:])
+		}
+
+		logs <- "⏳ " + step + ": waiting for containers to be running and healthy"
+		waited, err := c.waitHealthy(ctx, project, name, healthTimeout, logs)
+		if err != nil {
+			return fail("%s: %v; not restarted: %v", name, err, order[i+1:])
+		}
+		logs <- fmt.Sprintf("✅ %s: ready after %s", step, waited.Round(time.Second))
+	}
+
+	msg := fmt.Sprintf("Rolling restart of %d service(s) completed in %s", len(order), time.Since(start).Round(time.Second))
+	logs <- ""
+	logs <- "🎉 " + msg
+	return &OperationResult{Success: true, Message: msg, Duration: time.Since(start)}
+}
+
+// forwardStream 转发一步操作的日志并返回结果，ctx 取消时取消该步
+func (c *composeClient) forwardStream(ctx context.Context, stream *OperationStream, logs chan<- string) *OperationResult {
+	logChan := stream.LogChan
+	ctxDone := ctx.Done()
+	for {
+		select {
+		case <-ctxDone:
+			stream.Cancel()
+			ctxDone = nil
+		case line, ok := <-logChan:
+			if !ok {
+				logChan = nil
+				continue
+			}
+			logs <- "   " + line
+		case result, ok := <-stream.DoneChan:
+			if !ok {
+				return nil
+			}
+			// 结果之后输出的剩余日志
+			if logChan != nil {
+				for line := range logChan {
+					logs <- "   " + line
+				}
+			}
+			return result
+		}
+	}
+}
+
+// waitHealthy 等待服务的全部容器运行，配置了健康检查时还要 healthy；返回等待的时长
+func (c *composeClient) waitHealthy(ctx context.Context, project *Project, service string, timeout time.Duration, logs chan<- string) (time.Duration, error) {
+	start := time.Now()
+	deadline := start.Add(timeout)
+	lastStatus := ""
+	for {
+		services, err := c.PS(project)
+		if err != nil {
+			return 0, fmt.Errorf("failed to query status: %w", err)
+		}
+		var svc *Service
+		for i := range services {
+			if services[i].Name == service {
+				svc = &services[i]
+			}
+		}
+
+		status := "no containers"
+		if svc != nil {
+			status = fmt.Sprintf("%d/%d running", svc.Running, svc.Replicas)
+			if svc.Health != "" {
+				status += ", " + svc.Health
+			}
+			if svc.Health == "unhealthy" {
+				return 0, fmt.Errorf("health check failed (%s)", status)
+			}
+			if svc.Replicas > 0 && svc.Running == svc.Replicas && (svc.Health == "" || svc.Health == "healthy") {
+				return time.Since(start), nil
+			}
+		}
+		if status != lastStatus {
+			logs <- "   " + status
+			lastStatus = status
+		}
+
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("not ready after %s (%s)", timeout, status)
+		}
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("cancelled while waiting")
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
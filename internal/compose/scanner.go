@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -226,6 +227,61 @@ func isMainComposeFile(name string) bool {
 	return false
 }
 
+// isComposeFileName 检查文件名是否像 compose 文件（主文件、docker-compose.*.yml 或 compose.*.yml）
+func isComposeFileName(name string) bool {
+	nameLower := strings.ToLower(name)
+	if isMainComposeFile(nameLower) {
+		return true
+	}
+	if !strings.HasSuffix(nameLower, ".yml") && !strings.HasSuffix(nameLower, ".yaml") {
+		return false
+	}
+	return strings.HasPrefix(nameLower, "docker-compose.") || strings.HasPrefix(nameLower, "compose.")
+}
+
+// ComposeFileName 将项目目录下文件的绝对路径转换为文件名，其余路径原样返回
+func ComposeFileName(project *Project, file string) string {
+	if project.Path != "" && filepath.IsAbs(file) && filepath.Dir(file) == filepath.Clean(project.Path) {
+		return filepath.Base(file)
+	}
+	return file
+}
+
+// ComposeFileCandidates 列出可作为 -f 参数的 compose 文件：项目目录中的主文件在前，其余按名称排序，
+// 已选中但不在项目目录中的文件排在最后
+func ComposeFileCandidates(project *Project) []string {
+	if project == nil {
+		return nil
+	}
+	var mains, others []string
+	seen := make(map[string]bool)
+	if project.Path != "" {
+		entries, _ := os.ReadDir(project.Path)
+		for _, entry := range entries {
+			if entry.IsDir() || !isComposeFileName(entry.Name()) {
+				continue
+			}
+			seen[entry.Name()] = true
+			if isMainComposeFile(strings.ToLower(entry.Name())) {
+				mains = append(mains, entry.Name())
+			} else {
+				others = append(others, entry.Name())
+			}
+		}
+	}
+	sort.Strings(mains)
+	sort.Strings(others)
+	candidates := append(mains, others...)
+	for _, f := range project.ComposeFiles {
+		f = ComposeFileName(project, f)
+		if !seen[f] {
+			seen[f] = true
+			candidates = append(candidates, f)
+		}
+	}
+	return candidates
+}
+
 // RefreshProject 刷新项目状态
 func (s *scanner) RefreshProject(ctx context.Context, project *Project) error {
 	if s.client == nil {
@@ -40,11 +40,13 @@ type Project struct {
 	EnvFiles     []string          // Environment variable file list
 	WorkingDir   string            // Working directory
 	Labels       map[string]string // Project labels
+	Profiles     []string          // Active profiles (--profile)
 
 	// Runtime state
-	Services    []Service     // Service list
-	Status      ProjectStatus // Project status
-	LastUpdated time.Time     // Last updated time
+	Services     []Service     // Service list
+	Status       ProjectStatus // Project status
+	LastUpdated  time.Time     // Last updated time
+	LastActivity time.Time     // Creation time of the newest container (discovered projects only)
 }
 
 // Service represents a service in a compose project
@@ -56,6 +58,8 @@ type Service struct {
 	Replicas   int      // Replica count
 	Running    int      // Running replica count
 	Ports      []string // Port mappings
+	Profiles   []string // Profiles the service belongs to (empty: always enabled)
+	Health     string   // Aggregated health: unhealthy/starting/healthy (empty: no healthcheck)
 }
 
 // PortMapping represents port mapping
@@ -166,6 +170,8 @@ type Client interface {
 	PS(project *Project) ([]Service, error)
 	Logs(project *Project, opts LogOptions) (io.ReadCloser, error)
 	Config(project *Project) (string, error)
+	Drift(project *Project) ([]ServiceDrift, error)            // Compare config with running containers
+	ServiceImages(project *Project) (map[string]string, error) // Service -> image that can be pulled
 
 	// Image operations
 	Build(project *Project, opts BuildOptions) (*OperationResult, error)
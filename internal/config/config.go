@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -10,18 +12,198 @@ import (
 type Config struct {
 	DockerHost     string        // Docker 守护进程地址，默认走环境变量
 	RequestTimeout time.Duration // 与 Docker 通信的默认超时时间
+	ResumeTasks    bool          // 是否持久化后台任务并在下次启动时恢复未完成的任务
+	RecordDir      string        // 交互式 shell 会话录制目录，为空表示不录制
+	TaskLogDir     string        // 后台任务输出日志目录，为空表示不记录
+	AuditLog       string        // 审计日志文件路径，为空表示使用默认路径
+	AuditDisabled  bool          // 是否关闭变更操作审计
+	ReadOnly       bool          // 只读模式：禁止一切变更操作，只保留查看、日志和统计
+	PolicyFile     string        // 按资源限制可用操作的策略文件，为空表示使用默认路径（存在时）
+	Notify         string        // 通知方式：bell、osc9、exec，为空表示不通知
+	NotifyCommand  string        // exec 通知方式执行的命令
+	NotifyEvents   string        // 触发通知的事件（逗号分隔：unhealthy, exit, task），为空表示全部
+	ActionsFile    string        // 自定义操作配置文件，为空表示使用默认路径（存在时）
+	TaskWorkers    int           // 后台任务内部的并行 worker 数量（如并行导出压缩），0 表示默认
+	DockerCLI      string        // docker 可执行文件路径，为空时从 PATH 和 Docker Desktop 安装位置查找
+	CopyToFile     bool          // 剪贴板不可用时是否把复制内容写入临时文件并显示路径
+	RedactFile     string        // 日志遮盖规则文件，为空表示使用默认路径（存在时）
+	NotesFile      string        // 容器/镜像备注文件，为空表示使用默认路径
+	FavoritesFile  string        // 收藏和最近访问记录文件，为空表示使用默认路径
+	StacksFile     string        // 容器栈文件，为空表示使用默认路径
+	HomeFile       string        // 首页卡片和快捷操作配置文件，为空表示使用默认路径（存在时）
+	HostsFile      string        // 多主机概览的主机列表文件，为空表示使用默认路径（存在时）
+	ASCII          string        // 图标和框线的 ASCII 回退：auto（按 TERM/locale 检测）、on、off
+
+	// 以下来自配置文件，可用 DOCKTUI_<KEY> 环境变量或 --set 覆盖（见 SettingKeys）
+	FilePath         string              // 读取的配置文件，为空表示没有配置文件
+	Hosts            []Host              // 多主机概览中的主机（HostsFile 优先）
+	Color            string              // 颜色：auto、truecolor、256、16、off
+	Palette          string              // 状态颜色：default、colorblind
+	KeyBindings      map[string][]string // 重新绑定的快捷键
+	Language         string              // 界面语言：auto、en、zh（目前影响相对时间等本地化文字）
+	ServicesRefresh  time.Duration       // Swarm 服务视图的自动刷新间隔，0 表示默认
+	HomeRefresh      time.Duration       // 首页卡片的自动刷新间隔，0 表示默认
+	ContainersPoll   time.Duration       // 事件流不可用时容器列表的轮询间隔，0 表示默认
+	StatsRefresh     time.Duration       // 资源统计的刷新间隔，0 表示默认
+	ProcessRefresh   time.Duration       // 容器进程列表的刷新间隔，0 表示默认
+	ComposeCacheTTL  time.Duration       // Compose 项目发现结果的缓存时间，0 表示默认
+	HealthTimeout    time.Duration       // Compose 滚动重启时每个服务等待恢复健康的时长，0 表示默认
+	SizeUnits        string              // 大小单位：binary、iec、si
+	SizeDecimals     int                 // 大小保留的小数位数
+	TimeLocale       string              // 相对时间的语言：auto、en、zh
+	UpdateCheck      bool                // 启动时是否在后台检查新版本
+	TrashDays        int                 // 已删除容器在回收站中保留的天数，0 表示不保留
+	ResourcePresets  []ResourcePreset    // 容器资源限制预设，为空表示使用内置预设
+	ComposeOverrides []ComposeOverride   // 按项目或主机覆盖的 compose 命令
 }
 
-// Load 从环境变量加载配置，并填充合理默认值。
+// Load 读取配置文件（存在时）和环境变量，环境变量优先，并填充合理默认值。
+// 命令行参数由调用方在之后用 Set 覆盖，优先级为：参数 > 环境变量 > 配置文件 > 默认值。
 func Load() (*Config, error) {
+	file, path, err := loadDefaultFile()
+	if err != nil {
+		return nil, err
+	}
+
 	host := os.Getenv("DOCKER_HOST")
 	if host == "" {
 		// 留空表示使用 Docker SDK 的默认行为（Unix socket / named pipe 等）
 	}
 
+	// DOCKTUI_RESUME_TASKS=0 关闭任务恢复
+	resumeTasks := true
+	switch strings.ToLower(os.Getenv("DOCKTUI_RESUME_TASKS")) {
+	case "0", "false", "no", "off":
+		resumeTasks = false
+	}
+
+	// DOCKTUI_AUDIT_LOG 指定审计文件路径，设为 off 关闭审计
+	auditLog := strings.TrimSpace(os.Getenv("DOCKTUI_AUDIT_LOG"))
+	auditDisabled := false
+	switch strings.ToLower(auditLog) {
+	case "0", "false", "no", "off":
+		auditLog = ""
+		auditDisabled = true
+	}
+
+	// DOCKTUI_READ_ONLY=1 以只读模式启动（也可用 --read-only 参数）
+	readOnly := false
+	switch strings.ToLower(os.Getenv("DOCKTUI_READ_ONLY")) {
+	case "1", "true", "yes", "on":
+		readOnly = true
+	}
+
+	// DOCKTUI_POLICY 指定操作策略文件
+	policyFile := strings.TrimSpace(os.Getenv("DOCKTUI_POLICY"))
+
+	// DOCKTUI_NOTIFY 开启通知（也可用 --notify 参数），exec 方式需要 DOCKTUI_NOTIFY_CMD
+	notify := strings.TrimSpace(os.Getenv("DOCKTUI_NOTIFY"))
+	notifyCommand := strings.TrimSpace(os.Getenv("DOCKTUI_NOTIFY_CMD"))
+	notifyEvents := strings.TrimSpace(os.Getenv("DOCKTUI_NOTIFY_EVENTS"))
+
+	// DOCKTUI_ACTIONS 指定自定义操作配置文件
+	actionsFile := strings.TrimSpace(os.Getenv("DOCKTUI_ACTIONS"))
+
+	// DOCKTUI_TASK_WORKERS 设置并行导出/压缩的 worker 数量
+	taskWorkers, _ := strconv.Atoi(strings.TrimSpace(os.Getenv("DOCKTUI_TASK_WORKERS")))
+
+	// DOCKTUI_DOCKER_CLI 指定 docker 可执行文件（交互式 shell 使用）
+	dockerCLI := strings.TrimSpace(os.Getenv("DOCKTUI_DOCKER_CLI"))
+
+	// DOCKTUI_COPY_FALLBACK=off 关闭剪贴板不可用时的临时文件回退
+	copyToFile := true
+	switch strings.ToLower(os.Getenv("DOCKTUI_COPY_FALLBACK")) {
+	case "0", "false", "no", "off":
+		copyToFile = false
+	}
+
+	// DOCKTUI_REDACT 指定日志遮盖规则文件
+	redactFile := strings.TrimSpace(os.Getenv("DOCKTUI_REDACT"))
+
+	// DOCKTUI_NOTES 指定容器/镜像备注文件
+	notesFile := strings.TrimSpace(os.Getenv("DOCKTUI_NOTES"))
+
+	// DOCKTUI_FAVORITES 指定收藏和最近访问记录文件
+	favoritesFile := strings.TrimSpace(os.Getenv("DOCKTUI_FAVORITES"))
+
+	// DOCKTUI_STACKS 指定容器栈文件
+	stacksFile := strings.TrimSpace(os.Getenv("DOCKTUI_STACKS"))
+
+	// DOCKTUI_HOME_CONFIG 指定首页卡片和快捷操作配置文件
+	homeFile := strings.TrimSpace(os.Getenv("DOCKTUI_HOME_CONFIG"))
+
+	// DOCKTUI_HOSTS 指定多主机概览的主机列表文件
+	hostsFile := strings.TrimSpace(os.Getenv("DOCKTUI_HOSTS"))
+
 	cfg := &Config{
 		DockerHost:     host,
 		RequestTimeout: 10 * time.Second,
+		ResumeTasks:    resumeTasks,
+		AuditLog:       auditLog,
+		AuditDisabled:  auditDisabled,
+		ReadOnly:       readOnly,
+		PolicyFile:     policyFile,
+		Notify:         notify,
+		NotifyCommand:  notifyCommand,
+		NotifyEvents:   notifyEvents,
+		ActionsFile:    actionsFile,
+		TaskWorkers:    taskWorkers,
+		DockerCLI:      dockerCLI,
+		CopyToFile:     copyToFile,
+		RedactFile:     redactFile,
+		NotesFile:      notesFile,
+		FavoritesFile:  favoritesFile,
+		StacksFile:     stacksFile,
+		HomeFile:       homeFile,
+		HostsFile:      hostsFile,
+
+		FilePath:         path,
+		Hosts:            file.Hosts,
+		ResourcePresets:  file.ResourcePresets,
+		ComposeOverrides: file.Compose.Overrides,
+		KeyBindings:      make(map[string][]string),
+
+		Language:     "auto",
+		SizeUnits:    "binary",
+		SizeDecimals: 1,
+		TimeLocale:   "auto",
+		TrashDays:    7,
+	}
+
+	// 配置文件中的显示、快捷键、刷新间隔和目录，可被 DOCKTUI_<KEY> 覆盖：
+	// DOCKTUI_RECORD_DIR 设置后，容器 shell 会话会录制为 cast 文件；
+	// DOCKTUI_TASK_LOG_DIR 设置后，拉取/导出/compose 等后台任务的输出会写入该目录下的日志文件；
+	// DOCKTUI_ASCII=on/off 强制开启或关闭 ASCII 回退，默认自动检测
+	cfg.applyFile(file)
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	if cfg.ASCII == "" {
+		cfg.ASCII = "auto"
 	}
 	return cfg, nil
 }
+
+// Locale 返回相对时间使用的语言：format.locale 为 auto 时跟随 language
+func (c *Config) Locale() string {
+	if c.TimeLocale == "" || c.TimeLocale == "auto" {
+		return c.Language
+	}
+	return c.TimeLocale
+}
+
+// loadDefaultFile 读取 DOCKTUI_CONFIG 或默认路径下的配置文件；默认路径下没有文件时返回空配置
+func loadDefaultFile() (*File, string, error) {
+	path, err := FilePath()
+	if err != nil {
+		return &File{}, "", nil
+	}
+	f, err := LoadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && os.Getenv("DOCKTUI_CONFIG") == "" {
+			return &File{}, "", nil
+		}
+		return nil, "", err
+	}
+	return f, path, nil
+}
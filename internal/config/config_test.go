@@ -0,0 +1,310 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile 写入测试用配置文件并通过 DOCKTUI_CONFIG 指定
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("DOCKTUI_CONFIG", path)
+}
+
+// TestLoad_Precedence 测试覆盖优先级：参数 > 环境变量 > 配置文件 > 默认值
+func TestLoad_Precedence(t *testing.T) {
+	writeConfigFile(t, "theme:\n  color: 256\n  ascii: on\n  palette: colorblind\nrefresh:\n  services: 10s\n")
+	t.Setenv("DOCKTUI_THEME_COLOR", "16")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Color != "16" {
+		t.Errorf("env should override file, got color %q", cfg.Color)
+	}
+	if cfg.ASCII != "on" {
+		t.Errorf("file should override default, got ascii %q", cfg.ASCII)
+	}
+	if cfg.Palette != "colorblind" {
+		t.Errorf("expected palette from file, got %q", cfg.Palette)
+	}
+	if cfg.ServicesRefresh != 10*time.Second {
+		t.Errorf("expected services refresh 10s, got %v", cfg.ServicesRefresh)
+	}
+	if cfg.ComposeCacheTTL != 0 {
+		t.Errorf("unset compose cache should stay default, got %v", cfg.ComposeCacheTTL)
+	}
+
+	if err := cfg.Set("--set", "theme.color", "off"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if cfg.Color != "off" {
+		t.Errorf("flag should override env, got color %q", cfg.Color)
+	}
+	if err := cfg.Set("--set", "refresh.home", "1m"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if cfg.HomeRefresh != time.Minute {
+		t.Errorf("expected home refresh 1m, got %v", cfg.HomeRefresh)
+	}
+	if cfg.UpdateCheck {
+		t.Errorf("update check should be off by default")
+	}
+	if err := cfg.Set("--set", "updates.check", "on"); err != nil || !cfg.UpdateCheck {
+		t.Errorf("expected updates.check=on to enable the check, got %v (err %v)", cfg.UpdateCheck, err)
+	}
+	if err := cfg.Set("--set", "updates.check", "daily"); err == nil {
+		t.Errorf("expected an error for updates.check=daily")
+	}
+	if cfg.TrashDays != 7 {
+		t.Errorf("expected 7 trash days by default, got %d", cfg.TrashDays)
+	}
+	if err := cfg.Set("--set", "trash.days", "0"); err != nil || cfg.TrashDays != 0 {
+		t.Errorf("expected trash.days=0 to disable the trash, got %d (err %v)", cfg.TrashDays, err)
+	}
+	if err := cfg.Set("--set", "trash.days", "400"); err == nil {
+		t.Errorf("expected an error for trash.days=400")
+	}
+}
+
+// TestLoad_DefaultsWithoutFile 测试没有配置文件时的默认值
+func TestLoad_DefaultsWithoutFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DOCKTUI_CONFIG", "")
+	t.Setenv("DOCKTUI_ASCII", "")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.FilePath != "" {
+		t.Errorf("expected no config file, got %q", cfg.FilePath)
+	}
+	if cfg.ASCII != "auto" {
+		t.Errorf("expected ascii auto, got %q", cfg.ASCII)
+	}
+}
+
+// TestLoad_LegacyEnv 测试旧环境变量名仍然有效，新名称优先
+func TestLoad_LegacyEnv(t *testing.T) {
+	writeConfigFile(t, "directories:\n  recordings: /from/file\n  task_logs: /from/file\n")
+	t.Setenv("DOCKTUI_RECORD_DIR", "/legacy")
+	t.Setenv("DOCKTUI_TASK_LOG_DIR", "/legacy")
+	t.Setenv("DOCKTUI_DIRECTORIES_TASK_LOGS", "/new")
+	t.Setenv("DOCKTUI_ASCII", "1")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.RecordDir != "/legacy" {
+		t.Errorf("expected legacy record dir, got %q", cfg.RecordDir)
+	}
+	if cfg.TaskLogDir != "/new" {
+		t.Errorf("DOCKTUI_DIRECTORIES_TASK_LOGS should win over DOCKTUI_TASK_LOG_DIR, got %q", cfg.TaskLogDir)
+	}
+	if cfg.ASCII != "on" {
+		t.Errorf("DOCKTUI_ASCII=1 should mean on, got %q", cfg.ASCII)
+	}
+}
+
+// TestConfig_SetKeyBindings 测试快捷键按逗号分隔覆盖
+func TestConfig_SetKeyBindings(t *testing.T) {
+	cfg := &Config{KeyBindings: map[string][]string{"logs_wrap": {"w"}}}
+	if err := cfg.Set("DOCKTUI_KEYBINDINGS_LOGS_FOLLOW", "keybindings.logs_follow", "f, ctrl+f"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	got := strings.Join(cfg.KeyBindings["logs_follow"], " ")
+	if got != "f ctrl+f" {
+		t.Errorf("expected [f ctrl+f], got %v", cfg.KeyBindings["logs_follow"])
+	}
+	if len(cfg.KeyBindings["logs_wrap"]) != 1 {
+		t.Errorf("other bindings should be kept, got %v", cfg.KeyBindings)
+	}
+}
+
+// TestLoad_Format 测试显示格式的默认值和配置文件中的设置（decimals: 0 不能当作未设置）
+func TestLoad_Format(t *testing.T) {
+	writeConfigFile(t, "")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.SizeUnits != "binary" || cfg.SizeDecimals != 1 || cfg.TimeLocale != "auto" {
+		t.Errorf("unexpected format defaults: %q %d %q", cfg.SizeUnits, cfg.SizeDecimals, cfg.TimeLocale)
+	}
+
+	writeConfigFile(t, "format:\n  units: SI\n  decimals: 0\n  locale: zh\n")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.SizeUnits != "si" || cfg.SizeDecimals != 0 || cfg.TimeLocale != "zh" {
+		t.Errorf("unexpected format settings: %q %d %q", cfg.SizeUnits, cfg.SizeDecimals, cfg.TimeLocale)
+	}
+}
+
+// TestLoad_ResourcePresets 测试资源预设的解析和校验
+func TestLoad_ResourcePresets(t *testing.T) {
+	writeConfigFile(t, "resource_presets:\n  - name: small\n    cpus: 0.5\n    memory: 512m\n  - name: big\n    memory: 1.5GiB\n")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := []ResourcePreset{{Name: "small", CPUs: 0.5, Memory: 512 << 20}, {Name: "big", Memory: 3 << 29}}
+	if len(cfg.ResourcePresets) != len(want) {
+		t.Fatalf("expected %d presets, got %+v", len(want), cfg.ResourcePresets)
+	}
+	for i, p := range want {
+		if cfg.ResourcePresets[i] != p {
+			t.Errorf("preset %d: expected %+v, got %+v", i, p, cfg.ResourcePresets[i])
+		}
+	}
+
+	writeConfigFile(t, "resource_presets:\n  - name: small\n    cpus: 0\n  - name: SMALL\n    memory: 1m\n  - cpus: 1\n  - name: empty\n")
+	_, err = Load()
+	for _, msg := range []string{
+		`:3:11: invalid resource_presets[0].cpus "0"`,
+		`:4:11: resource preset "SMALL" is listed twice`,
+		`:5:13: resource_presets[1].memory must be at least 6m`,
+		`:6:5: resource_presets[2]: name is required`,
+		`:7:5: resource_presets[3]: set cpus, memory or both`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("expected error containing %q, got %v", msg, err)
+		}
+	}
+}
+
+// TestLoad_ComposeOverrides 测试按项目或主机覆盖 compose 命令
+func TestLoad_ComposeOverrides(t *testing.T) {
+	writeConfigFile(t, "compose:\n  health_timeout: 1m\n  overrides:\n    - project: legacy\n      command: docker-compose\n      env:\n        COMPOSE_PROJECT_NAME: shop\n    - host: tcp://10.0.0.5:2375\n      command: podman-compose\n")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.HealthTimeout != time.Minute {
+		t.Errorf("expected health timeout 1m, got %v", cfg.HealthTimeout)
+	}
+	if len(cfg.ComposeOverrides) != 2 {
+		t.Fatalf("expected 2 overrides, got %+v", cfg.ComposeOverrides)
+	}
+	if o := cfg.ComposeOverrides[0]; o.Project != "legacy" || o.Command != "docker-compose" || o.Env["COMPOSE_PROJECT_NAME"] != "shop" {
+		t.Errorf("unexpected first override %+v", o)
+	}
+	if o := cfg.ComposeOverrides[1]; o.Host != "tcp://10.0.0.5:2375" || o.Command != "podman-compose" {
+		t.Errorf("unexpected second override %+v", o)
+	}
+
+	writeConfigFile(t, "compose:\n  overrides:\n    - project: a\n    - project: A\n      command: \"\"\n    - host: 10.0.0.5\n      env:\n        BAD NAME: x\n")
+	_, err = Load()
+	for _, msg := range []string{
+		`:3:7: compose.overrides[0]: set command, env or both`,
+		`:5:16: compose.overrides[1].command must not be empty`,
+		`:4:7: compose.overrides[1]: another override already matches the same project and host`,
+		`:6:13: invalid host "10.0.0.5"`,
+		`:8:9: invalid environment variable name "BAD NAME"`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("expected error containing %q, got %v", msg, err)
+		}
+	}
+}
+
+// TestParseMemory 测试内存大小的解析
+func TestParseMemory(t *testing.T) {
+	tests := map[string]int64{"512m": 512 << 20, "2g": 2 << 30, "1.5GiB": 3 << 29, "64 MB": 64 << 20, "1024": 1024}
+	for in, want := range tests {
+		if got, err := ParseMemory(in); err != nil || got != want {
+			t.Errorf("ParseMemory(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "m", "-1g", "2x", "0"} {
+		if _, err := ParseMemory(in); err == nil {
+			t.Errorf("ParseMemory(%q): expected an error", in)
+		}
+	}
+}
+
+// TestConfig_SetInvalid 测试无效的覆盖值和未知的键
+func TestConfig_SetInvalid(t *testing.T) {
+	cfg := &Config{KeyBindings: map[string][]string{}}
+	tests := []struct {
+		key, value, want string
+	}{
+		{"theme.color", "neon", `--set: invalid theme.color "neon"`},
+		{"refresh.services", "100ms", "--set: refresh.services must be at least 1s"},
+		{"refresh.services", "soon", `--set: invalid refresh.services "soon"`},
+		{"keybindings.logs_wrap", "w,,", "--set: keybindings.logs_wrap has an empty key"},
+		{"language", "fr", `--set: invalid language "fr"`},
+		{"format.decimals", "5", `--set: invalid format.decimals "5"`},
+		{"format.units", "metric", `--set: invalid format.units "metric"`},
+		{"theme.colour", "256", `unknown config key "theme.colour"`},
+	}
+	for _, tt := range tests {
+		err := cfg.Set("--set", tt.key, tt.value)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Set(%s=%s): expected error containing %q, got %v", tt.key, tt.value, tt.want, err)
+		}
+	}
+}
+
+// TestLoad_KeyBindingsAndLanguage 测试全局快捷键和界面语言，format.locale 未设置时跟随 language
+func TestLoad_KeyBindingsAndLanguage(t *testing.T) {
+	writeConfigFile(t, "keybindings:\n  refresh: ctrl+r\n  quit: [ctrl+q]\n  page_down: ctrl+f\nlanguage: zh\n")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for id, want := range map[string]string{"refresh": "ctrl+r", "quit": "ctrl+q", "page_down": "ctrl+f"} {
+		if got := strings.Join(cfg.KeyBindings[id], " "); got != want {
+			t.Errorf("keybindings.%s = %q, want %q", id, got, want)
+		}
+	}
+	if cfg.Language != "zh" || cfg.Locale() != "zh" {
+		t.Errorf("language = %q, locale = %q, want zh", cfg.Language, cfg.Locale())
+	}
+	if err := cfg.Set("--set", "format.locale", "en"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if cfg.Locale() != "en" {
+		t.Errorf("format.locale should win over language, got %q", cfg.Locale())
+	}
+
+	writeConfigFile(t, "language: fr\n")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), `:1:11: invalid language "fr"`) {
+		t.Errorf("expected invalid language error, got %v", err)
+	}
+}
+
+// TestLoad_InvalidEnv 测试环境变量的值无效时 Load 返回带变量名的错误
+func TestLoad_InvalidEnv(t *testing.T) {
+	writeConfigFile(t, "")
+	t.Setenv("DOCKTUI_REFRESH_COMPOSE_CACHE", "forever")
+
+	_, err := Load()
+	if err == nil || !strings.HasPrefix(err.Error(), "DOCKTUI_REFRESH_COMPOSE_CACHE: ") {
+		t.Errorf("expected error naming the variable, got %v", err)
+	}
+}
+
+// TestEnvName 测试配置项对应的环境变量名
+func TestEnvName(t *testing.T) {
+	if got := EnvName("refresh.compose_cache"); got != "DOCKTUI_REFRESH_COMPOSE_CACHE" {
+		t.Errorf("expected DOCKTUI_REFRESH_COMPOSE_CACHE, got %s", got)
+	}
+	for _, key := range SettingKeys() {
+		if findSetting(key) == nil {
+			t.Errorf("key %s is listed but cannot be set", key)
+		}
+	}
+}
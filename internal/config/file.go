@@ -0,0 +1,671 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File 配置文件（config.yaml）的内容；未设置的项使用默认值，环境变量和命令行参数优先于配置文件
+type File struct {
+	Hosts       []Host             `yaml:"hosts"`       // 多主机概览中的主机
+	Theme       Theme              `yaml:"theme"`       // 显示设置
+	KeyBindings map[string]KeyList `yaml:"keybindings"` // 可重新绑定的快捷键
+	Language    string             `yaml:"language"`    // 界面语言
+	Refresh     Refresh            `yaml:"refresh"`     // 刷新间隔
+	Directories Directories        `yaml:"directories"` // 输出目录
+	Compose     Compose            `yaml:"compose"`     // Compose 操作
+	Format      Format             `yaml:"format"`      // 大小和时间的显示格式
+	Updates     Updates            `yaml:"updates"`     // 新版本检查
+	Trash       Trash              `yaml:"trash"`       // 已删除容器的回收站
+
+	ResourcePresets []ResourcePreset `yaml:"resource_presets"` // 容器资源限制预设
+}
+
+// Host 多主机概览中的一台主机
+type Host struct {
+	Name string `yaml:"name"`
+	Host string `yaml:"host"` // 守护进程地址，与 DOCKER_HOST 格式相同
+}
+
+// Theme 显示设置
+type Theme struct {
+	ASCII   string `yaml:"ascii"`   // auto、on、off
+	Color   string `yaml:"color"`   // auto、truecolor、256、16、off
+	Palette string `yaml:"palette"` // default、colorblind
+}
+
+// Refresh 刷新间隔
+type Refresh struct {
+	Services     Duration `yaml:"services"`      // Swarm 服务视图的自动刷新间隔
+	ComposeCache Duration `yaml:"compose_cache"` // Compose 项目发现结果的缓存时间（容器事件会提前失效）
+	Home         Duration `yaml:"home"`          // 首页卡片的自动刷新间隔
+	Containers   Duration `yaml:"containers"`    // 事件流不可用时容器列表的轮询间隔
+	Stats        Duration `yaml:"stats"`         // 资源统计（容器详情、Compose 服务资源列）的刷新间隔
+	Processes    Duration `yaml:"processes"`     // 容器进程列表的刷新间隔
+}
+
+// Compose Compose 操作设置
+type Compose struct {
+	HealthTimeout Duration          `yaml:"health_timeout"` // 滚动重启时每个服务等待恢复健康的时长
+	Overrides     []ComposeOverride `yaml:"overrides"`      // 按项目或主机覆盖的 compose 命令
+}
+
+// ComposeOverride 为匹配的项目或主机指定 compose 命令和环境变量（按顺序匹配，第一条生效）
+type ComposeOverride struct {
+	Project string            `yaml:"project"` // 项目名称，空表示所有项目
+	Host    string            `yaml:"host"`    // 生效的 DOCKER_HOST，空表示所有主机
+	Command string            `yaml:"command"` // 如 docker-compose、docker compose、podman-compose
+	Env     map[string]string `yaml:"env"`     // 附加的环境变量，如 COMPOSE_PROJECT_NAME、DOCKER_HOST
+}
+
+// Format 大小和时间的显示格式
+type Format struct {
+	Units    string `yaml:"units"`    // binary（1024，KB）、iec（1024，KiB）、si（1000，kB）
+	Decimals *int   `yaml:"decimals"` // 大小保留的小数位数（0-3）
+	Locale   string `yaml:"locale"`   // 相对时间的语言：auto、en、zh
+}
+
+// Updates 新版本检查
+type Updates struct {
+	Check string `yaml:"check"` // on、off：启动时在后台查询 GitHub 上的最新版本（默认 off）
+}
+
+// Trash 已删除容器的回收站
+type Trash struct {
+	Days *int `yaml:"days"` // 保留天数（0-365），0 表示不保留
+}
+
+// ResourcePreset 容器资源限制预设，在编辑容器、复制容器和模板中按名称选择
+type ResourcePreset struct {
+	Name   string  `yaml:"name"`
+	CPUs   float64 `yaml:"cpus"`   // CPU 数量上限，如 0.5
+	Memory Memory  `yaml:"memory"` // 内存上限，如 512m、2g
+}
+
+// Directories 输出目录
+type Directories struct {
+	Recordings string `yaml:"recordings"` // shell 会话录制目录
+	TaskLogs   string `yaml:"task_logs"`  // 后台任务输出日志目录
+}
+
+// Duration 配置文件中的时间间隔（如 3s、5m）
+type Duration time.Duration
+
+// UnmarshalYAML 解析时间间隔
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	v, err := time.ParseDuration(node.Value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Memory 配置文件中的内存大小（如 512m、2g、1.5GiB），单位按 1024 换算，与 docker run --memory 相同
+type Memory int64
+
+// UnmarshalYAML 解析内存大小
+func (m *Memory) UnmarshalYAML(node *yaml.Node) error {
+	v, err := ParseMemory(node.Value)
+	if err != nil {
+		return err
+	}
+	*m = Memory(v)
+	return nil
+}
+
+// memoryUnits 内存大小的单位（不区分大小写）
+var memoryUnits = map[string]int64{
+	"": 1, "b": 1,
+	"k": 1 << 10, "kb": 1 << 10, "kib": 1 << 10,
+	"m": 1 << 20, "mb": 1 << 20, "mib": 1 << 20,
+	"g": 1 << 30, "gb": 1 << 30, "gib": 1 << 30,
+	"t": 1 << 40, "tb": 1 << 40, "tib": 1 << 40,
+}
+
+// ParseMemory 解析内存大小，如 512m、2g、1.5GiB；没有单位时按字节
+func ParseMemory(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(s)
+	}
+	value, err := strconv.ParseFloat(s[:i], 64)
+	unit, ok := memoryUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+	if err != nil || !ok || value <= 0 {
+		return 0, fmt.Errorf("invalid memory size %q (use a size such as 512m or 2g)", s)
+	}
+	return int64(value * float64(unit)), nil
+}
+
+// KeyList 一个操作绑定的按键，可以写成单个字符串或列表
+type KeyList []string
+
+// UnmarshalYAML 解析单个按键或按键列表
+func (k *KeyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*k = KeyList{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// 配置项的可选值
+var (
+	ASCIIModes    = []string{"auto", "on", "off"}
+	ColorModes    = []string{"auto", "truecolor", "256", "16", "off"}
+	Palettes      = []string{"default", "colorblind"}
+	Languages     = []string{"auto", "en", "zh"}
+	SizeUnits     = []string{"binary", "iec", "si"}
+	Locales       = []string{"auto", "en", "zh"}
+	UpdateChecks  = []string{"on", "off"}
+	KeyBindingIDs = []string{
+		"quit", "help", "refresh",
+		"up", "down", "page_up", "page_down", "top", "bottom",
+		"logs_follow", "logs_wrap", "debug_dump",
+	}
+)
+
+// fileKeys 每一节允许的键，用于指出拼写错误
+var fileKeys = map[string][]string{
+	"":                  {"hosts", "theme", "keybindings", "language", "refresh", "directories", "compose", "format", "updates", "trash", "resource_presets"},
+	"hosts":             {"name", "host"},
+	"theme":             {"ascii", "color", "palette"},
+	"keybindings":       KeyBindingIDs,
+	"refresh":           {"services", "compose_cache", "home", "containers", "stats", "processes"},
+	"directories":       {"recordings", "task_logs"},
+	"compose":           {"health_timeout", "overrides"},
+	"compose.overrides": {"project", "host", "command", "env"},
+	"format":            {"units", "decimals", "locale"},
+	"updates":           {"check"},
+	"trash":             {"days"},
+	"resource_presets":  {"name", "cpus", "memory"},
+}
+
+// Problem 配置文件中的一处错误及其位置
+type Problem struct {
+	Path   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+// Error 返回 "config.yaml:3:5: 错误描述"；环境变量和 --set 的值没有行列，返回 "DOCKTUI_THEME_COLOR: 错误描述"
+func (p *Problem) Error() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s", p.Path, p.Msg)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", p.Path, p.Line, p.Column, p.Msg)
+}
+
+// DefaultFilePath 返回默认配置文件路径（用户配置目录下的 docktui/config.yaml）
+func DefaultFilePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "config.yaml"), nil
+}
+
+// FilePath 返回使用的配置文件路径：DOCKTUI_CONFIG 或默认路径
+func FilePath() (string, error) {
+	if path := strings.TrimSpace(os.Getenv("DOCKTUI_CONFIG")); path != "" {
+		return path, nil
+	}
+	return DefaultFilePath()
+}
+
+// LoadFile 读取并校验配置文件，所有问题一并返回（每个都是带位置的 *Problem）
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFile(path, data)
+}
+
+// ParseFile 解析并校验配置文件内容，path 只用于错误信息
+func ParseFile(path string, data []byte) (*File, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	f := &File{}
+	if len(root.Content) == 0 {
+		return f, nil
+	}
+
+	v := &validator{path: path}
+	v.validate(root.Content[0])
+	if len(v.problems) > 0 {
+		sort.SliceStable(v.problems, func(i, j int) bool {
+			a, b := v.problems[i].(*Problem), v.problems[j].(*Problem)
+			return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+		})
+		return nil, errors.Join(v.problems...)
+	}
+	if err := root.Content[0].Decode(f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	f.Directories.Recordings = expandHome(f.Directories.Recordings)
+	f.Directories.TaskLogs = expandHome(f.Directories.TaskLogs)
+	return f, nil
+}
+
+// validator 按节校验配置文件的节点，记录每个问题的行列
+type validator struct {
+	path     string
+	problems []error
+}
+
+func (v *validator) fail(node *yaml.Node, format string, args ...any) {
+	v.problems = append(v.problems, &Problem{Path: v.path, Line: node.Line, Column: node.Column, Msg: fmt.Sprintf(format, args...)})
+}
+
+// mapping 校验节点是键值表且只包含允许的键，返回键对应的值节点
+func (v *validator) mapping(node *yaml.Node, section string) map[string]*yaml.Node {
+	name := section
+	if name == "" {
+		name = "the top level"
+	}
+	if node.Kind != yaml.MappingNode {
+		v.fail(node, "%s must be a mapping", name)
+		return nil
+	}
+	allowed := fileKeys[section]
+	values := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !contains(allowed, key.Value) {
+			v.fail(key, "unknown key %q in %s%s", key.Value, name, suggest(key.Value, allowed))
+			continue
+		}
+		if _, dup := values[key.Value]; dup {
+			v.fail(key, "%q is set twice", key.Value)
+			continue
+		}
+		values[key.Value] = value
+	}
+	return values
+}
+
+func (v *validator) validate(root *yaml.Node) {
+	top := v.mapping(root, "")
+	if node := top["hosts"]; node != nil {
+		v.hosts(node)
+	}
+	if node := top["theme"]; node != nil {
+		theme := v.mapping(node, "theme")
+		v.enum(theme["ascii"], "theme.ascii", ASCIIModes)
+		v.enum(theme["color"], "theme.color", ColorModes)
+		v.enum(theme["palette"], "theme.palette", Palettes)
+	}
+	if node := top["keybindings"]; node != nil {
+		for id, keys := range v.mapping(node, "keybindings") {
+			v.keys(keys, "keybindings."+id)
+		}
+	}
+	v.enum(top["language"], "language", Languages)
+	if node := top["refresh"]; node != nil {
+		for name, value := range v.mapping(node, "refresh") {
+			v.duration(value, "refresh."+name)
+		}
+	}
+	if node := top["compose"]; node != nil {
+		compose := v.mapping(node, "compose")
+		if value := compose["health_timeout"]; value != nil {
+			v.duration(value, "compose.health_timeout")
+		}
+		if value := compose["overrides"]; value != nil {
+			v.composeOverrides(value)
+		}
+	}
+	if node := top["format"]; node != nil {
+		format := v.mapping(node, "format")
+		v.enum(format["units"], "format.units", SizeUnits)
+		v.integer(format["decimals"], "format.decimals", 0, 3)
+		v.enum(format["locale"], "format.locale", Locales)
+	}
+	if node := top["updates"]; node != nil {
+		v.enum(v.mapping(node, "updates")["check"], "updates.check", UpdateChecks)
+	}
+	if node := top["trash"]; node != nil {
+		v.integer(v.mapping(node, "trash")["days"], "trash.days", 0, 365)
+	}
+	if node := top["resource_presets"]; node != nil {
+		v.resourcePresets(node)
+	}
+	if node := top["directories"]; node != nil {
+		for name, value := range v.mapping(node, "directories") {
+			v.scalar(value, "directories."+name)
+		}
+	}
+}
+
+func (v *validator) hosts(node *yaml.Node) {
+	if node.Kind != yaml.SequenceNode {
+		v.fail(node, "hosts must be a list of {name, host}")
+		return
+	}
+	seen := make(map[string]bool)
+	for i, item := range node.Content {
+		h := v.mapping(item, "hosts")
+		if h == nil {
+			continue
+		}
+		hostNode := h["host"]
+		if hostNode == nil {
+			v.fail(item, "hosts[%d]: host is required", i)
+			continue
+		}
+		if !v.scalar(hostNode, fmt.Sprintf("hosts[%d].host", i)) {
+			continue
+		}
+		if u, err := url.Parse(hostNode.Value); err != nil || u.Scheme == "" {
+			v.fail(hostNode, "invalid host %q (expected unix://, tcp:// or npipe://)", hostNode.Value)
+		}
+		name := hostNode.Value
+		if n := h["name"]; n != nil && v.scalar(n, fmt.Sprintf("hosts[%d].name", i)) && n.Value != "" {
+			name = n.Value
+		}
+		if seen[name] {
+			v.fail(item, "host %q is listed twice", name)
+		}
+		seen[name] = true
+	}
+}
+
+// resourcePresets 校验资源预设：名称必填且不重复，至少设置 cpus 或 memory 之一
+func (v *validator) resourcePresets(node *yaml.Node) {
+	if node.Kind != yaml.SequenceNode {
+		v.fail(node, "resource_presets must be a list of {name, cpus, memory}")
+		return
+	}
+	seen := make(map[string]bool)
+	for i, item := range node.Content {
+		p := v.mapping(item, "resource_presets")
+		if p == nil {
+			continue
+		}
+		nameNode := p["name"]
+		if nameNode == nil || (v.scalar(nameNode, fmt.Sprintf("resource_presets[%d].name", i)) && strings.TrimSpace(nameNode.Value) == "") {
+			v.fail(item, "resource_presets[%d]: name is required", i)
+		} else if nameNode.Kind == yaml.ScalarNode {
+			name := strings.ToLower(nameNode.Value)
+			if seen[name] {
+				v.fail(nameNode, "resource preset %q is listed twice", nameNode.Value)
+			}
+			seen[name] = true
+		}
+		if p["cpus"] == nil && p["memory"] == nil {
+			v.fail(item, "resource_presets[%d]: set cpus, memory or both", i)
+		}
+		if n := p["cpus"]; n != nil && v.scalar(n, fmt.Sprintf("resource_presets[%d].cpus", i)) {
+			if cpus, err := strconv.ParseFloat(n.Value, 64); err != nil || cpus <= 0 || cpus > 1024 {
+				v.fail(n, "invalid resource_presets[%d].cpus %q (use a number of CPUs such as 0.5 or 2)", i, n.Value)
+			}
+		}
+		if n := p["memory"]; n != nil && v.scalar(n, fmt.Sprintf("resource_presets[%d].memory", i)) {
+			if mem, err := ParseMemory(n.Value); err != nil {
+				v.fail(n, "invalid resource_presets[%d].memory %q (use a size such as 512m or 2g)", i, n.Value)
+			} else if mem < 6<<20 {
+				v.fail(n, "resource_presets[%d].memory must be at least 6m", i)
+			}
+		}
+	}
+}
+
+// composeOverrides 校验 compose 命令覆盖：设置 command 或 env，同一项目和主机只能出现一次
+func (v *validator) composeOverrides(node *yaml.Node) {
+	if node.Kind != yaml.SequenceNode {
+		v.fail(node, "compose.overrides must be a list of {project, host, command, env}")
+		return
+	}
+	seen := make(map[string]bool)
+	for i, item := range node.Content {
+		o := v.mapping(item, "compose.overrides")
+		if o == nil {
+			continue
+		}
+		name := fmt.Sprintf("compose.overrides[%d]", i)
+		project, host := "", ""
+		if n := o["project"]; n != nil && v.scalar(n, name+".project") {
+			project = strings.ToLower(n.Value)
+		}
+		if n := o["host"]; n != nil && v.scalar(n, name+".host") {
+			host = n.Value
+			if u, err := url.Parse(host); err != nil || u.Scheme == "" {
+				v.fail(n, "invalid host %q (expected unix://, tcp:// or npipe://)", host)
+			}
+		}
+		if o["command"] == nil && o["env"] == nil {
+			v.fail(item, "%s: set command, env or both", name)
+		}
+		if n := o["command"]; n != nil && v.scalar(n, name+".command") && strings.TrimSpace(n.Value) == "" {
+			v.fail(n, "%s.command must not be empty", name)
+		}
+		if n := o["env"]; n != nil {
+			v.env(n, name+".env")
+		}
+		key := project + "\x00" + host
+		if seen[key] {
+			v.fail(item, "%s: another override already matches the same project and host", name)
+		}
+		seen[key] = true
+	}
+}
+
+// env 校验环境变量表：变量名合法，值是单个值
+func (v *validator) env(node *yaml.Node, name string) {
+	if node.Kind != yaml.MappingNode {
+		v.fail(node, "%s must be a mapping of NAME: value", name)
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value == "" || strings.ContainsAny(key.Value, "= \t") {
+			v.fail(key, "invalid environment variable name %q in %s", key.Value, name)
+			continue
+		}
+		v.scalar(value, name+"."+key.Value)
+	}
+}
+
+// scalar 校验节点是单个值
+func (v *validator) scalar(node *yaml.Node, name string) bool {
+	if node.Kind != yaml.ScalarNode {
+		v.fail(node, "%s must be a single value", name)
+		return false
+	}
+	return true
+}
+
+func (v *validator) enum(node *yaml.Node, name string, values []string) {
+	if node == nil || !v.scalar(node, name) {
+		return
+	}
+	if !contains(values, strings.ToLower(node.Value)) {
+		v.fail(node, "invalid %s %q (available: %s)", name, node.Value, strings.Join(values, ", "))
+	}
+}
+
+func (v *validator) duration(node *yaml.Node, name string) {
+	if !v.scalar(node, name) {
+		return
+	}
+	d, err := time.ParseDuration(node.Value)
+	if err != nil {
+		v.fail(node, "invalid %s %q (use a duration such as 3s or 5m)", name, node.Value)
+		return
+	}
+	if d < time.Second {
+		v.fail(node, "%s must be at least 1s", name)
+	}
+}
+
+func (v *validator) integer(node *yaml.Node, name string, min, max int) {
+	if node == nil || !v.scalar(node, name) {
+		return
+	}
+	n, err := strconv.Atoi(node.Value)
+	if err != nil || n < min || n > max {
+		v.fail(node, "invalid %s %q (use a number from %d to %d)", name, node.Value, min, max)
+	}
+}
+
+func (v *validator) keys(node *yaml.Node, name string) {
+	var keys []*yaml.Node
+	switch node.Kind {
+	case yaml.ScalarNode:
+		keys = []*yaml.Node{node}
+	case yaml.SequenceNode:
+		keys = node.Content
+	default:
+		v.fail(node, "%s must be a key or a list of keys", name)
+		return
+	}
+	if len(keys) == 0 {
+		v.fail(node, "%s needs at least one key", name)
+	}
+	for _, k := range keys {
+		if v.scalar(k, name) && strings.TrimSpace(k.Value) == "" {
+			v.fail(k, "%s has an empty key", name)
+		}
+	}
+}
+
+// suggest 拼写接近时提示正确的键
+func suggest(key string, allowed []string) string {
+	k := strings.ToLower(key)
+	for _, a := range allowed {
+		if strings.ReplaceAll(a, "_", "") == strings.NewReplacer("-", "", "_", "").Replace(k) || strings.HasPrefix(a, k) || strings.HasPrefix(k, a) {
+			return fmt.Sprintf(" (did you mean %q?)", a)
+		}
+	}
+	sorted := append([]string(nil), allowed...)
+	sort.Strings(sorted)
+	return " (available: " + strings.Join(sorted, ", ") + ")"
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// expandHome 展开路径开头的 ~/
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
+
+// Template 返回 docktui config init 写入的配置文件模板（全部为注释掉的默认值）
+func Template() string {
+	return `# docktui 配置文件。环境变量（DOCKTUI_*）和命令行参数优先于这里的设置。
+# 校验：docktui config validate
+
+# 多主机概览（首页按 f）中的主机
+# hosts:
+#   - name: db1
+#     host: tcp://10.0.0.5:2376
+#   - name: local
+#     host: unix:///var/run/docker.sock
+
+# theme:
+#   ascii: auto      # auto、on、off：把图标和框线替换为纯文本
+#   color: auto      # auto、truecolor、256、16、off
+#   palette: default # default、colorblind：状态颜色改用不依赖红绿区分的配色（状态始终带 ▶ ■ ❚❚ ✖ ⚠ 图标）
+
+# 可重新绑定的快捷键（单个按键或列表）
+# quit、help、logs_follow、logs_wrap、debug_dump 替换默认按键；
+# refresh 和导航键是在默认按键之外增加的按键：默认按键在部分视图中另有用途（如 Compose 视图的 r 是重启、
+# 容器列表的 ctrl+d 是删除），因此保留。增加的按键会覆盖视图中该按键原来的功能，输入框中不生效
+# keybindings:
+#   quit: q          # ctrl+c 始终退出
+#   help: "?"
+#   refresh: ctrl+r  # 默认 r、F5
+#   up: ctrl+p       # 默认 k、↑
+#   down: ctrl+n     # 默认 j、↓
+#   page_up: ctrl+b  # 默认 ctrl+u、PgUp
+#   page_down: ctrl+f # 默认 ctrl+d、PgDn
+#   top: "<"         # 默认 g、Home
+#   bottom: ">"      # 默认 G、End
+#   logs_follow: f
+#   logs_wrap: w
+#   debug_dump: f12  # 保存当前画面和视图状态，用于提交界面问题
+
+# 界面语言：auto（按 LC_TIME/LANG 检测）、en、zh；目前用于相对时间等本地化文字，format.locale 优先
+# language: auto
+
+# 各视图的自动刷新间隔（至少 1s），视图中显示倒计时和上次更新时间
+# refresh:
+#   services: 3s         # Swarm 服务视图的自动刷新间隔
+#   compose_cache: 5m    # Compose 项目发现结果的缓存时间，compose 容器事件会使缓存提前失效
+#   home: 30s            # 首页卡片（数量和磁盘占用）的自动刷新间隔，只在首页可见时刷新
+#   containers: 5s       # 容器列表平时由 Docker 事件触发刷新，事件流不可用时按该间隔轮询
+#   stats: 1s            # 容器资源统计和 Compose 服务 CPU/内存列的刷新间隔
+#   processes: 1s        # 容器进程列表的刷新间隔
+
+# compose:
+#   health_timeout: 2m   # 滚动重启（Compose 详情按 O）时每个服务等待运行并通过健康检查的时长
+#   # 按项目或主机覆盖 compose 命令和环境变量，按顺序匹配，第一条生效；未匹配的项目使用自动检测的命令
+#   # project 和 host（DOCKER_HOST）都省略时适用于所有项目；env 设置 COMPOSE_PROJECT_NAME 时不再传 -p
+#   overrides:
+#     - project: legacy-shop
+#       command: docker-compose
+#       env:
+#         COMPOSE_PROJECT_NAME: shop
+#     - host: tcp://10.0.0.5:2375
+#       command: podman-compose
+
+# 大小和时间的显示格式
+# format:
+#   units: binary    # binary（1024，显示 KB/MB）、iec（1024，显示 KiB/MiB）、si（1000，与 docker CLI 一致）
+#   decimals: 1      # 大小保留的小数位数（0-3）
+#   locale: auto     # 相对时间（"5m ago" / "5分钟前"）的语言：auto（按 LC_TIME/LANG 检测）、en、zh
+
+# 启动时在后台查询 GitHub 上是否有新版本，有时在首页显示提示和更新摘要（结果缓存 24 小时）
+# 也可以随时运行 docktui version --check
+# updates:
+#   check: off       # on、off
+
+# 通过 docktui 删除容器时在回收站保留其 inspect 数据，首页按 d 查看或还原 docker run 命令
+# trash:
+#   days: 7          # 保留天数（0-365），0 表示不保留
+
+# 容器资源限制预设，在编辑容器（e）、复制容器（D）中选择，或在模板中用 resources: <名称> 引用
+# 未设置时使用 small（0.5 CPU / 512m）、medium（1 CPU / 1g）、large（2 CPU / 4g）
+# resource_presets:
+#   - name: small
+#     cpus: 0.5
+#     memory: 512m
+#   - name: large
+#     cpus: 2
+#     memory: 4g
+
+# directories:
+#   recordings: ~/docktui-recordings   # shell 会话录制（同 DOCKTUI_RECORD_DIR）
+#   task_logs: ~/docktui-task-logs     # 后台任务输出日志（同 DOCKTUI_TASK_LOG_DIR）
+`
+}
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// setting 配置文件中可以用环境变量和 --set 覆盖的一项
+type setting struct {
+	key   string   // 配置文件中的键，如 theme.color
+	alias []string // 兼容的旧环境变量名，优先级低于 DOCKTUI_<KEY>
+	list  bool     // 值是逗号分隔的列表（快捷键）
+	apply func(c *Config, f *File)
+}
+
+// settings 可覆盖的配置项（hosts 是列表，只能写在配置文件或 DOCKTUI_HOSTS 指定的文件中）
+var settings = buildSettings()
+
+func buildSettings() []setting {
+	s := []setting{
+		{key: "theme.ascii", alias: []string{"DOCKTUI_ASCII"}, apply: func(c *Config, f *File) {
+			c.ASCII = strings.ToLower(f.Theme.ASCII)
+		}},
+		{key: "theme.color", apply: func(c *Config, f *File) {
+			c.Color = strings.ToLower(f.Theme.Color)
+		}},
+		{key: "theme.palette", apply: func(c *Config, f *File) {
+			c.Palette = strings.ToLower(f.Theme.Palette)
+		}},
+		{key: "language", apply: func(c *Config, f *File) {
+			if f.Language != "" {
+				c.Language = strings.ToLower(f.Language)
+			}
+		}},
+		{key: "refresh.services", apply: func(c *Config, f *File) {
+			c.ServicesRefresh = time.Duration(f.Refresh.Services)
+		}},
+		{key: "refresh.compose_cache", apply: func(c *Config, f *File) {
+			c.ComposeCacheTTL = time.Duration(f.Refresh.ComposeCache)
+		}},
+		{key: "refresh.home", apply: func(c *Config, f *File) {
+			c.HomeRefresh = time.Duration(f.Refresh.Home)
+		}},
+		{key: "refresh.containers", apply: func(c *Config, f *File) {
+			c.ContainersPoll = time.Duration(f.Refresh.Containers)
+		}},
+		{key: "refresh.stats", apply: func(c *Config, f *File) {
+			c.StatsRefresh = time.Duration(f.Refresh.Stats)
+		}},
+		{key: "refresh.processes", apply: func(c *Config, f *File) {
+			c.ProcessRefresh = time.Duration(f.Refresh.Processes)
+		}},
+		{key: "compose.health_timeout", apply: func(c *Config, f *File) {
+			c.HealthTimeout = time.Duration(f.Compose.HealthTimeout)
+		}},
+		{key: "format.units", apply: func(c *Config, f *File) {
+			if f.Format.Units != "" {
+				c.SizeUnits = strings.ToLower(f.Format.Units)
+			}
+		}},
+		{key: "format.decimals", apply: func(c *Config, f *File) {
+			if f.Format.Decimals != nil {
+				c.SizeDecimals = *f.Format.Decimals
+			}
+		}},
+		{key: "format.locale", apply: func(c *Config, f *File) {
+			if f.Format.Locale != "" {
+				c.TimeLocale = strings.ToLower(f.Format.Locale)
+			}
+		}},
+		{key: "updates.check", apply: func(c *Config, f *File) {
+			c.UpdateCheck = strings.EqualFold(f.Updates.Check, "on")
+		}},
+		{key: "trash.days", apply: func(c *Config, f *File) {
+			if f.Trash.Days != nil {
+				c.TrashDays = *f.Trash.Days
+			}
+		}},
+		{key: "directories.recordings", alias: []string{"DOCKTUI_RECORD_DIR"}, apply: func(c *Config, f *File) {
+			c.RecordDir = expandHome(f.Directories.Recordings)
+		}},
+		{key: "directories.task_logs", alias: []string{"DOCKTUI_TASK_LOG_DIR"}, apply: func(c *Config, f *File) {
+			c.TaskLogDir = expandHome(f.Directories.TaskLogs)
+		}},
+	}
+	for _, id := range KeyBindingIDs {
+		id := id
+		s = append(s, setting{key: "keybindings." + id, list: true, apply: func(c *Config, f *File) {
+			if keys := f.KeyBindings[id]; len(keys) > 0 {
+				c.KeyBindings[id] = keys
+			}
+		}})
+	}
+	return s
+}
+
+// SettingKeys 返回可以用环境变量和 --set 覆盖的配置项
+func SettingKeys() []string {
+	keys := make([]string, len(settings))
+	for i, s := range settings {
+		keys[i] = s.key
+	}
+	return keys
+}
+
+// EnvName 返回覆盖配置项的环境变量名（theme.color -> DOCKTUI_THEME_COLOR）
+func EnvName(key string) string {
+	return "DOCKTUI_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func findSetting(key string) *setting {
+	for i := range settings {
+		if settings[i].key == key {
+			return &settings[i]
+		}
+	}
+	return nil
+}
+
+// Set 覆盖一个配置项，值按配置文件的规则校验；source 是值的来源（环境变量名或 --set），用于错误信息
+func (c *Config) Set(source, key, value string) error {
+	s := findSetting(key)
+	if s == nil {
+		return fmt.Errorf("%s: unknown config key %q%s", source, key, suggest(key, SettingKeys()))
+	}
+	if key == "theme.ascii" {
+		value = normalizeASCII(value)
+	}
+
+	root := overrideNode(key, strings.TrimSpace(value), s.list)
+	v := &validator{path: source}
+	v.validate(root)
+	if len(v.problems) > 0 {
+		return errors.Join(v.problems...)
+	}
+	var f File
+	if err := root.Decode(&f); err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	s.apply(c, &f)
+	return nil
+}
+
+// applyEnv 用环境变量覆盖配置项：DOCKTUI_<KEY> 优先，其次是兼容的旧名称
+func (c *Config) applyEnv() error {
+	for _, s := range settings {
+		for _, name := range append([]string{EnvName(s.key)}, s.alias...) {
+			if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+				if err := c.Set(name, s.key, value); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// applyFile 使用配置文件中的所有可覆盖项
+func (c *Config) applyFile(f *File) {
+	for _, s := range settings {
+		s.apply(c, f)
+	}
+}
+
+// overrideNode 把一个覆盖值构造成配置文件结构（{theme: {color: 256}}），以便复用配置文件的校验
+func overrideNode(key, value string, list bool) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	// 数字按整数解码（format.decimals、trash.days）
+	if _, err := strconv.Atoi(value); err == nil {
+		node.Tag = "!!int"
+	}
+	if list {
+		node = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, k := range strings.Split(value, ",") {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: strings.TrimSpace(k)})
+		}
+	}
+	parts := strings.Split(key, ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: parts[i]},
+			node,
+		}}
+	}
+	return node
+}
+
+// normalizeASCII 兼容 DOCKTUI_ASCII 原先接受的 true/1/yes、false/0/no
+func normalizeASCII(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "1", "yes":
+		return "on"
+	case "false", "0", "no":
+		return "off"
+	}
+	return value
+}
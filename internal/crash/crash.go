@@ -0,0 +1,110 @@
+package crash
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"docktui/internal/version"
+)
+
+// logLines 崩溃报告中保留的最近日志行数
+const logLines = 200
+
+// LogBuffer 保存最近的日志行。TUI 运行期间 log 包输出到这里，
+// 既不会打乱界面，崩溃时也能写进报告
+type LogBuffer struct {
+	mu      sync.Mutex
+	lines   []string
+	partial []byte
+}
+
+// NewLogBuffer 创建日志缓冲
+func NewLogBuffer() *LogBuffer {
+	return &LogBuffer{}
+}
+
+// Write 按行保存，超出 logLines 时丢弃最旧的行
+func (b *LogBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.partial = append(b.partial, p...)
+	for {
+		i := bytes.IndexByte(b.partial, '\n')
+		if i < 0 {
+			break
+		}
+		b.lines = append(b.lines, string(b.partial[:i]))
+		b.partial = b.partial[i+1:]
+	}
+	if over := len(b.lines) - logLines; over > 0 {
+		b.lines = append([]string(nil), b.lines[over:]...)
+	}
+	return len(p), nil
+}
+
+// Lines 返回保存的日志行（含未以换行结尾的最后一行）
+func (b *LogBuffer) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lines := append([]string(nil), b.lines...)
+	if len(b.partial) > 0 {
+		lines = append(lines, string(b.partial))
+	}
+	return lines
+}
+
+// DefaultDir 返回默认崩溃报告目录（用户配置目录下的 docktui/crash）
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "crash"), nil
+}
+
+// WriteReport 写入崩溃报告并返回文件路径；默认目录不可写时写到临时目录
+func WriteReport(value interface{}, stack []byte, logs []string) (string, error) {
+	now := time.Now()
+	var b strings.Builder
+	fmt.Fprintf(&b, "docktui crash report\n\n")
+	fmt.Fprintf(&b, "Time:    %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version: %s\n", version.String())
+	fmt.Fprintf(&b, "Go:      %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Args:    %s\n", strings.Join(os.Args, " "))
+	fmt.Fprintf(&b, "\nPanic: %v\n\n%s\n", value, stack)
+	fmt.Fprintf(&b, "Recent log lines (%d):\n", len(logs))
+	if len(logs) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, line := range logs {
+		b.WriteString("  " + line + "\n")
+	}
+
+	name := fmt.Sprintf("crash-%s-%d.log", now.Format("20060102-150405"), os.Getpid())
+	var dirs []string
+	if dir, err := DefaultDir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	dirs = append(dirs, os.TempDir())
+
+	var lastErr error
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			lastErr = err
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+			lastErr = err
+			continue
+		}
+		return path, nil
+	}
+	return "", fmt.Errorf("failed to write crash report: %w", lastErr)
+}
@@ -0,0 +1,110 @@
+package crash
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// restoreSequences 退出备用屏幕、显示光标、关闭鼠标上报和括号粘贴，
+// 程序还没初始化终端或 Kill 未能恢复时兜底
+const restoreSequences = "\x1b[?1049l\x1b[?25h\x1b[?1002l\x1b[?1003l\x1b[?1006l\x1b[?2004l"
+
+// Handler 捕获 TUI 运行期间的 panic：先恢复终端，再写崩溃报告并打印路径，然后以状态码 2 退出。
+// 需要配合 tea.WithoutCatchPanics 使用，否则 Bubble Tea 会先自行处理 panic
+type Handler struct {
+	logs     *LogBuffer
+	program  *tea.Program
+	crashing atomic.Bool
+	mu       sync.Mutex
+}
+
+// NewHandler 创建崩溃处理器，logs 为崩溃报告附带的最近日志
+func NewHandler(logs *LogBuffer) *Handler {
+	return &Handler{logs: logs}
+}
+
+// Attach 设置需要恢复终端的程序
+func (h *Handler) Attach(p *tea.Program) {
+	h.program = p
+}
+
+// Recover 用 defer 调用，捕获当前 goroutine 的 panic
+func (h *Handler) Recover() {
+	if r := recover(); r != nil {
+		h.crash(r, debug.Stack())
+	}
+}
+
+// Wait 其他 goroutine 正在处理崩溃时阻塞，避免主流程先退出而丢失崩溃报告
+func (h *Handler) Wait() {
+	if h.crashing.Load() {
+		h.mu.Lock()
+	}
+}
+
+// crash 只处理第一个 panic，之后的 panic 在锁上阻塞直到进程退出
+func (h *Handler) crash(value interface{}, stack []byte) {
+	h.crashing.Store(true)
+	h.mu.Lock()
+
+	if h.program != nil {
+		h.program.Kill()
+	}
+	fmt.Fprint(os.Stdout, restoreSequences)
+
+	fmt.Fprintf(os.Stderr, "docktui crashed: %v\n", value)
+	var logs []string
+	if h.logs != nil {
+		logs = h.logs.Lines()
+	}
+	path, err := WriteReport(value, stack, logs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n\n%s\n", err, stack)
+	} else {
+		fmt.Fprintf(os.Stderr, "Crash report written to %s\n", path)
+	}
+	os.Exit(2)
+}
+
+// Wrap 包装模型，使命令 goroutine 中的 panic 也交给 Handler 处理
+func (h *Handler) Wrap(m tea.Model) tea.Model {
+	return guardedModel{Model: m, handler: h}
+}
+
+type guardedModel struct {
+	tea.Model
+	handler *Handler
+}
+
+func (g guardedModel) Init() tea.Cmd {
+	return g.handler.guard(g.Model.Init())
+}
+
+func (g guardedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := g.Model.Update(msg)
+	return guardedModel{Model: next, handler: g.handler}, g.handler.guard(cmd)
+}
+
+// guard 包装命令；Batch 返回的子命令同样包装
+func (h *Handler) guard(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		defer h.Recover()
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			guarded := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				guarded[i] = h.guard(c)
+			}
+			return guarded
+		}
+		return msg
+	}
+}
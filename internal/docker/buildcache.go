@@ -0,0 +1,126 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+
+	"docktui/internal/audit"
+)
+
+// BuildCacheEntry 一条构建缓存记录（相当于 docker buildx du --verbose 的一项）
+type BuildCacheEntry struct {
+	ID          string
+	Type        string // regular、source.local、exec.cachemount、frontend 等
+	Description string // 产生该缓存的构建步骤
+	Parents     []string
+	Size        int64
+	InUse       bool // 正在被构建使用，不能清理
+	Shared      bool // 与其他记录共享数据，清理后不一定释放空间
+	CreatedAt   time.Time
+	LastUsedAt  time.Time // 从未使用过时为零值
+	UsageCount  int
+}
+
+// ShortID 返回 12 位短 ID
+func (e BuildCacheEntry) ShortID() string {
+	if len(e.ID) > 12 {
+		return e.ID[:12]
+	}
+	return e.ID
+}
+
+// Reclaimable 是否可以清理（与 docker buildx du 的统计一致：未被使用即可清理）
+func (e BuildCacheEntry) Reclaimable() bool {
+	return !e.InUse
+}
+
+// BuildCachePruneOptions 构建缓存清理选项
+type BuildCachePruneOptions struct {
+	ID    string // 只清理该记录，空表示所有未使用的缓存
+	Until string // 仅清理在此时长内未使用的缓存（Go duration，如 24h），空表示不限
+}
+
+// ListBuildCache 列出构建缓存记录，按大小从大到小排序
+func (c *LocalClient) ListBuildCache(ctx context.Context) ([]BuildCacheEntry, error) {
+	if c == nil || c.cli == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+
+	du, err := c.cli.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.BuildCacheObject}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build cache: %w", err)
+	}
+	return convertBuildCache(du.BuildCache), nil
+}
+
+// convertBuildCache 转换 API 返回的构建缓存记录并按大小排序（大小相同时最近使用的在前）
+func convertBuildCache(records []*types.BuildCache) []BuildCacheEntry {
+	entries := make([]BuildCacheEntry, 0, len(records))
+	for _, r := range records {
+		if r == nil {
+			continue
+		}
+		entry := BuildCacheEntry{
+			ID:          r.ID,
+			Type:        r.Type,
+			Description: r.Description,
+			Parents:     r.Parents,
+			Size:        r.Size,
+			InUse:       r.InUse,
+			Shared:      r.Shared,
+			CreatedAt:   r.CreatedAt,
+			UsageCount:  r.UsageCount,
+		}
+		if r.LastUsedAt != nil {
+			entry.LastUsedAt = *r.LastUsedAt
+		}
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Size != entries[j].Size {
+			return entries[i].Size > entries[j].Size
+		}
+		return entries[i].LastUsedAt.After(entries[j].LastUsedAt)
+	})
+	return entries
+}
+
+// PruneBuildCache 清理构建缓存，返回删除的记录数和释放的空间
+// 守护进程的 id 过滤只接受一个值，选择性清理时每条记录调用一次
+func (c *LocalClient) PruneBuildCache(ctx context.Context, opts BuildCachePruneOptions) (int, int64, error) {
+	if c == nil || c.cli == nil {
+		return 0, 0, fmt.Errorf("Docker client not initialized")
+	}
+	target := "build cache"
+	if opts.ID != "" {
+		target = "build cache " + audit.ShortID(opts.ID)
+	}
+	if err := checkAction("image.prune", target); err != nil {
+		return 0, 0, err
+	}
+
+	args := filters.NewArgs()
+	if opts.ID != "" {
+		args.Add("id", opts.ID)
+	}
+	if opts.Until != "" {
+		if _, err := time.ParseDuration(opts.Until); err != nil {
+			return 0, 0, fmt.Errorf("invalid until duration %q: %w", opts.Until, err)
+		}
+		args.Add("until", opts.Until)
+	}
+
+	// All 表示也清理内部和前端类型的记录；选择了具体记录时按用户的选择清理
+	report, err := c.cli.BuildCachePrune(ctx, types.BuildCachePruneOptions{All: opts.ID != "", Filters: args})
+	if err != nil {
+		audit.Record("image.prune", target, err)
+		return 0, 0, fmt.Errorf("failed to prune build cache: %w", err)
+	}
+	audit.Record("image.prune", fmt.Sprintf("%d build cache record(s)", len(report.CachesDeleted)), nil)
+	return len(report.CachesDeleted), int64(report.SpaceReclaimed), nil
+}
@@ -0,0 +1,42 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+// TestConvertBuildCache 测试构建缓存记录的转换和排序
+func TestConvertBuildCache(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	records := []*types.BuildCache{
+		{ID: "small", Size: 10, LastUsedAt: &newer},
+		nil,
+		{ID: "big-old", Size: 100, LastUsedAt: &older, Shared: true},
+		{ID: "big-new", Size: 100, LastUsedAt: &newer, InUse: true},
+		{ID: "never-used-0123456789", Size: 50},
+	}
+
+	entries := convertBuildCache(records)
+	want := []string{"big-new", "big-old", "never-used-0123456789", "small"}
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %d", len(want), len(entries))
+	}
+	for i, id := range want {
+		if entries[i].ID != id {
+			t.Errorf("entries[%d]: expected %s, got %s", i, id, entries[i].ID)
+		}
+	}
+
+	if !entries[2].LastUsedAt.IsZero() {
+		t.Errorf("Expected zero LastUsedAt for never used record, got %v", entries[2].LastUsedAt)
+	}
+	if got := entries[2].ShortID(); got != "never-used-0" {
+		t.Errorf("Expected short ID 'never-used-0', got '%s'", got)
+	}
+	if entries[0].Reclaimable() || !entries[1].Reclaimable() {
+		t.Errorf("Reclaimable: only in-use records should not be reclaimable")
+	}
+}
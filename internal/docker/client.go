@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,8 +15,11 @@ import (
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 
+	"docktui/internal/audit"
 	"docktui/internal/docker/image"
 	"docktui/internal/docker/network"
+	"docktui/internal/policy"
+	"docktui/internal/trash"
 )
 
 // Docker Endpoint 配置说明（Windows 环境）：
@@ -49,15 +54,34 @@ import (
 
 // Container 表示容器的基本信息（用于列表视图）
 type Container struct {
-	ID      string    // 容器 ID（完整）
-	ShortID string    // 容器 ID（短，12位）
-	Name    string    // 容器名称
-	Image   string    // 镜像名称
-	Command string    // 启动命令
-	Created time.Time // 创建时间
-	Status  string    // 状态描述，如 "Up 2 hours" 或 "Up 30 seconds (healthy)"
-	State   string    // 状态: running, exited, paused 等
-	Ports   string    // 端口映射
+	ID      string            // 容器 ID（完整）
+	ShortID string            // 容器 ID（短，12位）
+	Name    string            // 容器名称
+	Image   string            // 镜像名称
+	ImageID string            // 镜像 ID
+	Command string            // 启动命令
+	Created time.Time         // 创建时间
+	Status  string            // 状态描述，如 "Up 2 hours" 或 "Up 30 seconds (healthy)"
+	State   string            // 状态: running, exited, paused 等
+	Ports   string            // 端口映射
+	Labels  map[string]string // 标签
+
+	Platform string // 镜像平台（os/arch），未知时为空
+	Emulated bool   // 镜像平台与守护进程不同，容器通过仿真运行
+}
+
+// Health 从 Status 中解析健康检查状态
+// 返回 "healthy"、"unhealthy"、"starting"，未配置健康检查时返回空字符串
+func (c Container) Health() string {
+	switch {
+	case strings.Contains(c.Status, "(unhealthy)"):
+		return "unhealthy"
+	case strings.Contains(c.Status, "(healthy)"):
+		return "healthy"
+	case strings.Contains(c.Status, "(health: starting)"):
+		return "starting"
+	}
+	return ""
 }
 
 // ContainerDetails 表示容器的详细信息（用于详情视图）
@@ -68,12 +92,37 @@ type ContainerDetails struct {
 	State         string            // 状态
 	Status        string            // 状态描述
 	Created       time.Time         // 创建时间
+	StartedAt     time.Time         // 最近一次启动时间，从未启动时为零值
+	FinishedAt    time.Time         // 最近一次退出时间，从未退出时为零值
+	ExitCode      int               // 最近一次退出的退出码
+	RestartCount  int               // 守护进程按重启策略重启的次数
 	Ports         []PortMapping     // 端口映射
 	Mounts        []MountInfo       // 挂载点
 	Env           []string          // 环境变量
 	Labels        map[string]string // 标签
 	NetworkMode   string            // 网络模式
+	DNS           ContainerDNS      // 主机名、DNS 服务器、搜索域和额外的 hosts 条目
 	RestartPolicy string            // 重启策略
+	Security      ContainerSecurity // cgroup、命名空间和权限
+}
+
+// ContainerSecurity 容器的 cgroup、命名空间共享和权限配置
+// 命名空间模式为空表示私有，host 表示共享宿主机，container:<name> 表示共享其他容器
+type ContainerSecurity struct {
+	CgroupParent string   // cgroup 父路径，空表示 Docker 默认
+	CgroupnsMode string   // cgroup 命名空间: private, host
+	PidMode      string   // PID 命名空间
+	IpcMode      string   // IPC 命名空间: private, shareable, host, container:<name>
+	UTSMode      string   // UTS 命名空间
+	UsernsMode   string   // 用户命名空间
+	Privileged   bool     // 特权模式
+	CapAdd       []string // 额外添加的 capabilities
+	CapDrop      []string // 去掉的 capabilities
+	SecurityOpt  []string // seccomp、apparmor 等安全选项
+	User         string   // 运行用户，空表示镜像默认（通常是 root）
+	ReadonlyRoot bool     // 只读根文件系统
+
+	AppArmorProfile string // 实际生效的 AppArmor 配置，空表示未启用
 }
 
 // PortMapping 表示端口映射信息
@@ -107,6 +156,18 @@ type ImageDetails = image.Details
 // ImageHistory 表示镜像构建历史的一条记录
 type ImageHistory = image.History
 
+// ImagePruneOptions 镜像清理选项
+type ImagePruneOptions = image.PruneOptions
+
+// WaitCondition 等待容器达到的状态
+type WaitCondition = container.WaitCondition
+
+const (
+	WaitNotRunning = container.WaitConditionNotRunning // 容器不在运行（已停止时立即返回）
+	WaitNextExit   = container.WaitConditionNextExit   // 容器下一次退出
+	WaitRemoved    = container.WaitConditionRemoved    // 容器被删除
+)
+
 // ===== 网络类型别名（委托给 network 包）=====
 
 // Network 表示网络的基本信息（用于列表视图）
@@ -155,14 +216,18 @@ type LogOptions struct {
 	Tail       int    // 获取最后 N 行：>0=最后N行, 0=不获取历史, <0=全部
 	Timestamps bool   // 是否显示时间戳
 	Since      string // 从某个时间开始（RFC3339 格式或 Unix 时间戳）
+	Until      string // 到某个时间为止（格式同 Since），为空表示到最新
 }
 
 // ContainerEvent 表示 Docker 容器事件
 type ContainerEvent struct {
-	Action      string    // 事件类型: start, stop, die, create, destroy, rename 等
+	Action      string    // 事件类型: start, stop, die, kill, create, destroy, rename, health_status 等
 	ContainerID string    // 容器 ID
 	ContainerName string  // 容器名称
 	Timestamp   time.Time // 事件时间
+	ExitCode    int       // die 事件的退出码
+	Health      string    // health_status 事件的健康状态：healthy、unhealthy
+	Project     string    // 所属 Compose 项目（com.docker.compose.project 标签），不属于项目时为空
 }
 
 // ProcessInfo 表示容器内进程信息
@@ -209,17 +274,29 @@ type Client interface {
 	ContainerLogs(ctx context.Context, containerID string, opts LogOptions) (io.ReadCloser, error)
 
 	// ExecShell 在容器中启动交互式 shell
-	// 返回错误或 nil，实际交互通过标准输入输出进行
-	ExecShell(ctx context.Context, containerID string, shell string) error
+	// 返回错误或 nil，实际交互通过标准输入输出进行；output 为 nil 时输出到标准输出（会话录制时同时写入录制文件）
+	ExecShell(ctx context.Context, containerID string, shell string, output io.Writer) error
 
 	// GetAvailableShells 获取容器中所有可用的 shell 列表
 	GetAvailableShells(ctx context.Context, containerID string) []string
 
+	// ProbeTools 探测容器中的常用工具（shell、curl、wget、nc、ip、ps 等），结果按容器缓存
+	ProbeTools(ctx context.Context, containerID string) (*ContainerTools, error)
+
+	// ExecCommand 在容器中执行一次性命令，分开捕获标准输出和标准错误，可通过 config.Stdin 提供输入
+	ExecCommand(ctx context.Context, containerID string, config ExecConfig) (*ExecResult, error)
+
+	// ReadContainerFile 通过 API 读取容器中的文本文件（不需要 exec，停止的容器也可以读取）
+	ReadContainerFile(ctx context.Context, containerID, path string) (string, error)
+
 	// WatchEvents 监听 Docker 容器事件
 	// 返回事件通道和错误通道，调用方负责处理
 	// context 用于控制监听的生命周期
 	WatchEvents(ctx context.Context) (<-chan ContainerEvent, <-chan error)
 
+	// StreamEvents 监听容器、镜像、网络和卷的全部事件（事件视图使用）
+	StreamEvents(ctx context.Context) (<-chan Event, <-chan error)
+
 	// StartContainer 启动已停止的容器
 	StartContainer(ctx context.Context, containerID string) error
 
@@ -227,6 +304,9 @@ type Client interface {
 	// timeout: 等待容器优雅停止的超时时间（秒），0 表示立即强制停止
 	StopContainer(ctx context.Context, containerID string, timeout int) error
 
+	// WaitContainer 等待容器达到指定状态（停止、下一次退出、被删除），返回退出码
+	WaitContainer(ctx context.Context, containerID string, condition WaitCondition) (int64, error)
+
 	// RestartContainer 重启容器
 	// timeout: 等待容器停止的超时时间（秒）
 	RestartContainer(ctx context.Context, containerID string, timeout int) error
@@ -246,6 +326,34 @@ type Client interface {
 	// 支持修改：重启策略、CPU 限制、内存限制等
 	UpdateContainer(ctx context.Context, containerID string, config ContainerUpdateConfig) error
 
+	// RunContainer 创建并启动容器（本地没有镜像时先拉取），返回容器 ID
+	RunContainer(ctx context.Context, opts ContainerRunOptions) (string, error)
+
+	// DuplicateContainer 以现有容器的配置创建并启动新容器，返回新容器 ID
+	DuplicateContainer(ctx context.Context, containerID string, opts ContainerDuplicateOptions) (string, error)
+
+	// DebugContainer 以现有容器的配置和替换后的入口命令启动临时调试容器，返回新容器 ID 和名称
+	DebugContainer(ctx context.Context, containerID string, entrypoint []string) (string, string, error)
+
+	// RemoveDebugContainer 删除调试运行的临时容器
+	RemoveDebugContainer(ctx context.Context, containerID string) error
+
+	// ContainerRunCommand 根据容器配置还原近似的 docker run 命令
+	ContainerRunCommand(ctx context.Context, containerID string) (string, error)
+
+	// RunCommandFromInspect 根据保存的 inspect JSON（回收站中已删除的容器）还原 docker run 命令
+	RunCommandFromInspect(ctx context.Context, inspect []byte) (string, error)
+
+	// PruneContainers 清理所有已停止的容器（可按创建时间过滤）
+	// 返回删除的容器数量和释放的空间（字节）
+	PruneContainers(ctx context.Context, opts ContainerPruneOptions) (int, int64, error)
+
+	// PruneContainersPreview 预览 PruneContainers 将删除的容器（不执行删除）
+	PruneContainersPreview(ctx context.Context, opts ContainerPruneOptions) ([]ContainerPruneCandidate, error)
+
+	// DiskUsage 获取磁盘占用（相当于 docker system df）
+	DiskUsage(ctx context.Context) (*DiskUsage, error)
+
 	// ===== 镜像管理 =====
 
 	// ListImages 获取镜像列表
@@ -255,17 +363,34 @@ type Client interface {
 	// ImageDetails 获取指定镜像的详细信息
 	ImageDetails(ctx context.Context, imageID string) (*ImageDetails, error)
 
+	// ImageUsedBy 获取由镜像创建的容器（包括已停止的），运行中的在前
+	// imageID: 完整的镜像 ID（sha256:...）
+	ImageUsedBy(ctx context.Context, imageID string) ([]ContainerRef, error)
+
 	// InspectImageRaw 获取镜像的原始 JSON 数据
 	InspectImageRaw(ctx context.Context, imageID string) (string, error)
 
+	// ImageRegistryDigest 查询仓库中镜像引用（如 nginx:latest）当前指向的摘要，用于和本地 RepoDigests 比较
+	ImageRegistryDigest(ctx context.Context, imageRef string) (string, error)
+
 	// RemoveImage 删除镜像
 	// force: 是否强制删除（即使有容器使用）
 	// prune: 是否删除未标记的父镜像
 	RemoveImage(ctx context.Context, imageID string, force bool, prune bool) error
 
-	// PruneImages 清理悬垂镜像（无标签的镜像）
+	// PruneImages 按选项清理镜像（悬垂镜像或所有未使用镜像，可按创建时间和标签过滤）
 	// 返回删除的镜像数量和释放的空间（字节）
-	PruneImages(ctx context.Context) (int, int64, error)
+	PruneImages(ctx context.Context, opts ImagePruneOptions) (int, int64, error)
+
+	// PruneImagesPreview 预览 PruneImages 将删除的镜像（不执行删除）
+	PruneImagesPreview(ctx context.Context, opts ImagePruneOptions) ([]Image, error)
+
+	// ListBuildCache 列出构建缓存记录（相当于 docker buildx du --verbose），按大小从大到小排序
+	ListBuildCache(ctx context.Context) ([]BuildCacheEntry, error)
+
+	// PruneBuildCache 清理构建缓存（可只清理一条记录）
+	// 返回删除的记录数量和释放的空间（字节）
+	PruneBuildCache(ctx context.Context, opts BuildCachePruneOptions) (int, int64, error)
 
 	// TagImage 给镜像打标签
 	// imageID: 源镜像 ID
@@ -325,6 +450,29 @@ type Client interface {
 	// InspectNetworkRaw 获取网络的原始 JSON 数据
 	InspectNetworkRaw(ctx context.Context, networkID string) (string, error)
 
+	// ===== Swarm 服务 =====
+
+	// SwarmManager 当前守护进程是否为 Swarm 管理节点
+	SwarmManager(ctx context.Context) (bool, error)
+
+	// ListServices 获取 Swarm 服务列表
+	ListServices(ctx context.Context) ([]Service, error)
+
+	// InspectServiceRaw 获取服务的原始 JSON 数据
+	InspectServiceRaw(ctx context.Context, serviceID string) (string, error)
+
+	// ScaleService 调整服务副本数
+	ScaleService(ctx context.Context, serviceID string, replicas uint64) error
+
+	// ForceUpdateService 强制重新部署服务的所有任务
+	ForceUpdateService(ctx context.Context, serviceID string) error
+
+	// ListSecrets 获取 Swarm secret 列表（只有元数据）
+	ListSecrets(ctx context.Context) ([]SwarmObject, error)
+
+	// ListConfigs 获取 Swarm config 列表（只有元数据）
+	ListConfigs(ctx context.Context) ([]SwarmObject, error)
+
 	// Close 关闭客户端连接，释放资源
 	Close() error
 }
@@ -334,6 +482,7 @@ type LocalClient struct {
 	cli        *sdk.Client
 	imageCli   *image.Client   // 镜像操作客户端
 	networkCli *network.Client // 网络操作客户端
+	tools      toolCache       // 各容器中探测到的工具
 }
 
 // GetSDKClient 返回底层的 Docker SDK 客户端
@@ -343,10 +492,7 @@ func (c *LocalClient) GetSDKClient() *sdk.Client {
 
 // NewLocalClientFromEnv 基于环境变量创建本地 Docker 客户端，并开启 API 版本协商。
 func NewLocalClientFromEnv() (*LocalClient, error) {
-	cli, err := sdk.NewClientWithOpts(
-		sdk.FromEnv,
-		sdk.WithAPIVersionNegotiation(),
-	)
+	cli, err := sdk.NewClientWithOpts(clientOpts()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Docker client: %w", err)
 	}
@@ -357,6 +503,44 @@ func NewLocalClientFromEnv() (*LocalClient, error) {
 	}, nil
 }
 
+// NewLocalClientForHost 创建连接指定守护进程地址（如 tcp://10.0.0.5:2376、unix:///var/run/docker.sock、
+// ssh://user@host）的客户端，TLS 等其余设置仍读取环境变量
+func NewLocalClientForHost(host string) (*LocalClient, error) {
+	opts := []sdk.Opt{sdk.FromEnv, sdk.WithHost(host), sdk.WithAPIVersionNegotiation()}
+	if isSSHHost(host) {
+		sshOpts, err := sshClientOpts(host)
+		if err != nil {
+			return nil, err
+		}
+		opts = append([]sdk.Opt{sdk.FromEnv, sdk.WithAPIVersionNegotiation()}, sshOpts...)
+	}
+	return newLocalClient(host, opts...)
+}
+
+// newLocalClient 按参数创建连接 host 的客户端
+func newLocalClient(host string, opts ...sdk.Opt) (*LocalClient, error) {
+	cli, err := sdk.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client for %s: %w", host, err)
+	}
+	return &LocalClient{
+		cli:        cli,
+		imageCli:   image.NewClient(cli),
+		networkCli: network.NewClient(cli),
+	}, nil
+}
+
+// clientOpts 创建 SDK 客户端的参数：读取环境变量，Windows 上未设置 DOCKER_HOST 时使用探测到的命名管道
+func clientOpts() []sdk.Opt {
+	opts := []sdk.Opt{sdk.FromEnv, sdk.WithAPIVersionNegotiation()}
+	if os.Getenv("DOCKER_HOST") == "" {
+		if host := detectDefaultHost(); host != "" {
+			opts = append(opts, sdk.WithHost(host))
+		}
+	}
+	return opts
+}
+
 // Ping 用于验证 Docker 守护进程是否可用。
 func (c *LocalClient) Ping(ctx context.Context) error {
 	if c == nil || c.cli == nil {
@@ -378,47 +562,68 @@ func (c *LocalClient) ListContainers(ctx context.Context, showAll bool) ([]Conta
 	}
 
 	result := make([]Container, 0, len(containers))
+	imageIDs := make([]string, 0, len(containers))
 	for _, c := range containers {
-		// 容器名称
-		name := ""
-		if len(c.Names) > 0 {
-			name = c.Names[0]
-			if len(name) > 0 && name[0] == '/' {
-				name = name[1:]
+		result = append(result, convertContainerSummary(c))
+		imageIDs = append(imageIDs, c.ImageID)
+	}
+
+	// 标记镜像平台与守护进程不同（仿真运行）的容器
+	if c.imageCli != nil {
+		platforms := c.imageCli.Platforms(ctx, imageIDs)
+		daemon := c.imageCli.DaemonPlatform(ctx)
+		for i := range result {
+			if p, ok := platforms[result[i].ImageID]; ok {
+				result[i].Platform = p.String()
+				result[i].Emulated = p.Emulated(daemon)
 			}
 		}
+	}
 
-		// 短 ID（12位）
-		shortID := c.ID
-		if len(shortID) > 12 {
-			shortID = shortID[:12]
-		}
-		
-		// 命令（截断显示，和 docker ps 一样）
-		command := c.Command
-		if len(command) > 20 {
-			command = "\"" + command[:17] + "…\""
-		} else {
-			command = "\"" + command + "\""
+	return result, nil
+}
+
+// convertContainerSummary 将 SDK 容器摘要转换为列表视图使用的 Container
+func convertContainerSummary(c container.Summary) Container {
+	// 容器名称
+	name := ""
+	if len(c.Names) > 0 {
+		name = c.Names[0]
+		if len(name) > 0 && name[0] == '/' {
+			name = name[1:]
 		}
-		
-		// 端口映射（完整格式，和 docker ps 一样）
-		ports := formatPortsFull(c.Ports)
-
-		result = append(result, Container{
-			ID:      c.ID,
-			ShortID: shortID,
-			Name:    name,
-			Image:   c.Image,
-			Command: command,
-			Created: time.Unix(c.Created, 0),
-			Status:  c.Status,
-			State:   string(c.State),
-			Ports:   ports,
-		})
 	}
 
-	return result, nil
+	// 短 ID（12位）
+	shortID := c.ID
+	if len(shortID) > 12 {
+		shortID = shortID[:12]
+	}
+
+	// 命令（截断显示，和 docker ps 一样）
+	command := c.Command
+	if len(command) > 20 {
+		command = "\"" + command[:17] + "…\""
+	} else {
+		command = "\"" + command + "\""
+	}
+
+	// 端口映射（完整格式，和 docker ps 一样）
+	ports := formatPortsFull(c.Ports)
+
+	return Container{
+		ID:      c.ID,
+		ShortID: shortID,
+		Name:    name,
+		Image:   c.Image,
+		ImageID: c.ImageID,
+		Command: command,
+		Created: time.Unix(c.Created, 0),
+		Status:  c.Status,
+		State:   string(c.State),
+		Ports:   ports,
+		Labels:  c.Labels,
+	}
 }
 
 // formatPortsFull 格式化端口映射（完整格式，和 docker ps 一样）
@@ -517,9 +722,18 @@ func (c *LocalClient) ContainerDetails(ctx context.Context, containerID string)
 	// 提取状态信息
 	state := "unknown"
 	status := ""
+	var startedAt, finishedAt time.Time
+	exitCode := 0
 	if containerInfo.State != nil {
 		state = string(containerInfo.State.Status)
 		status = fmt.Sprintf("Started at: %v", containerInfo.State.StartedAt)
+		if t, err := time.Parse(time.RFC3339Nano, containerInfo.State.StartedAt); err == nil && t.Year() > 1 {
+			startedAt = t
+		}
+		if t, err := time.Parse(time.RFC3339Nano, containerInfo.State.FinishedAt); err == nil && t.Year() > 1 {
+			finishedAt = t
+		}
+		exitCode = containerInfo.State.ExitCode
 	}
 
 	// 提取镜像信息
@@ -537,6 +751,19 @@ func (c *LocalClient) ContainerDetails(ctx context.Context, containerID string)
 		networkMode = string(containerInfo.HostConfig.NetworkMode)
 	}
 
+	// 提取主机名和 DNS 配置
+	var dns ContainerDNS
+	if containerInfo.Config != nil {
+		dns.Hostname = containerInfo.Config.Hostname
+		dns.Domainname = containerInfo.Config.Domainname
+	}
+	if containerInfo.HostConfig != nil {
+		dns.Servers = containerInfo.HostConfig.DNS
+		dns.Search = containerInfo.HostConfig.DNSSearch
+		dns.Options = containerInfo.HostConfig.DNSOptions
+		dns.ExtraHosts = containerInfo.HostConfig.ExtraHosts
+	}
+
 	// 提取重启策略
 	restartPolicy := "no"
 	if containerInfo.HostConfig != nil && containerInfo.HostConfig.RestartPolicy.Name != "" {
@@ -546,6 +773,29 @@ func (c *LocalClient) ContainerDetails(ctx context.Context, containerID string)
 		}
 	}
 
+	// 提取 cgroup、命名空间和权限配置
+	var security ContainerSecurity
+	if containerInfo.HostConfig != nil {
+		hc := containerInfo.HostConfig
+		security = ContainerSecurity{
+			CgroupParent: hc.CgroupParent,
+			CgroupnsMode: string(hc.CgroupnsMode),
+			PidMode:      string(hc.PidMode),
+			IpcMode:      string(hc.IpcMode),
+			UTSMode:      string(hc.UTSMode),
+			UsernsMode:   string(hc.UsernsMode),
+			Privileged:   hc.Privileged,
+			CapAdd:       hc.CapAdd,
+			CapDrop:      hc.CapDrop,
+			SecurityOpt:  hc.SecurityOpt,
+			ReadonlyRoot: hc.ReadonlyRootfs,
+		}
+	}
+	if containerInfo.Config != nil {
+		security.User = containerInfo.Config.User
+	}
+	security.AppArmorProfile = containerInfo.AppArmorProfile
+
 	return &ContainerDetails{
 		ID:            containerInfo.ID,
 		Name:          name,
@@ -553,12 +803,18 @@ func (c *LocalClient) ContainerDetails(ctx context.Context, containerID string)
 		State:         state,
 		Status:        status,
 		Created:       created,
+		StartedAt:     startedAt,
+		FinishedAt:    finishedAt,
+		ExitCode:      exitCode,
+		RestartCount:  containerInfo.RestartCount,
 		Ports:         ports,
 		Mounts:        mounts,
 		Env:           env,
 		Labels:        labels,
 		NetworkMode:   networkMode,
+		DNS:           dns,
 		RestartPolicy: restartPolicy,
+		Security:      security,
 	}, nil
 }
 
@@ -614,6 +870,9 @@ func (c *LocalClient) ContainerLogs(ctx context.Context, containerID string, opt
 	if opts.Since != "" {
 		logOpts.Since = opts.Since
 	}
+	if opts.Until != "" {
+		logOpts.Until = opts.Until
+	}
 
 	// 调用 Docker SDK 获取日志流
 	logReader, err := c.cli.ContainerLogs(ctx, containerID, logOpts)
@@ -648,6 +907,14 @@ func (c *LocalClient) ImageDetails(ctx context.Context, imageID string) (*ImageD
 	return c.imageCli.GetDetails(ctx, imageID)
 }
 
+// ImageUsedBy 获取由镜像创建的容器（包括已停止的）
+func (c *LocalClient) ImageUsedBy(ctx context.Context, imageID string) ([]ContainerRef, error) {
+	if c == nil || c.imageCli == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+	return c.imageCli.UsedBy(ctx, imageID)
+}
+
 // InspectImageRaw 获取镜像的原始 JSON 数据
 func (c *LocalClient) InspectImageRaw(ctx context.Context, imageID string) (string, error) {
 	if c == nil || c.imageCli == nil {
@@ -656,20 +923,46 @@ func (c *LocalClient) InspectImageRaw(ctx context.Context, imageID string) (stri
 	return c.imageCli.InspectRaw(ctx, imageID)
 }
 
+// ImageRegistryDigest 查询仓库中镜像引用当前指向的摘要，使用 docker CLI 中保存的登录凭据
+func (c *LocalClient) ImageRegistryDigest(ctx context.Context, imageRef string) (string, error) {
+	if c == nil || c.imageCli == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+	return c.imageCli.RemoteDigest(ctx, imageRef, registryAuth(imageRef))
+}
+
 // RemoveImage 删除镜像
 func (c *LocalClient) RemoveImage(ctx context.Context, imageID string, force bool, prune bool) error {
 	if c == nil || c.imageCli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
-	return c.imageCli.Remove(ctx, imageID, force, prune)
+	if err := checkAction("image.remove", audit.ShortID(imageID)); err != nil {
+		return err
+	}
+	err := c.imageCli.Remove(ctx, imageID, force, prune)
+	audit.Record("image.remove", audit.ShortID(imageID), err)
+	return err
 }
 
-// PruneImages 清理悬垂镜像
-func (c *LocalClient) PruneImages(ctx context.Context) (int, int64, error) {
+// PruneImages 按选项清理镜像
+func (c *LocalClient) PruneImages(ctx context.Context, opts ImagePruneOptions) (int, int64, error) {
 	if c == nil || c.imageCli == nil {
 		return 0, 0, fmt.Errorf("Docker client not initialized")
 	}
-	return c.imageCli.Prune(ctx)
+	if err := checkAction("image.prune", ""); err != nil {
+		return 0, 0, err
+	}
+	count, reclaimed, err := c.imageCli.Prune(ctx, opts)
+	audit.Record("image.prune", fmt.Sprintf("%d image(s)", count), err)
+	return count, reclaimed, err
+}
+
+// PruneImagesPreview 预览将被清理的镜像
+func (c *LocalClient) PruneImagesPreview(ctx context.Context, opts ImagePruneOptions) ([]Image, error) {
+	if c == nil || c.imageCli == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+	return c.imageCli.PruneCandidates(ctx, opts)
 }
 
 // TagImage 给镜像打标签
@@ -677,7 +970,17 @@ func (c *LocalClient) TagImage(ctx context.Context, imageID string, repository s
 	if c == nil || c.imageCli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
-	return c.imageCli.Tag(ctx, imageID, repository, tag)
+	if err := checkAction("image.tag", audit.ShortID(imageID)+" -> "+repository+":"+tag); err != nil {
+		return err
+	}
+	err := c.imageCli.Tag(ctx, imageID, repository, tag)
+	audit.RecordUndoable("image.tag", audit.ShortID(imageID)+" -> "+repository+":"+tag, err, &audit.Undo{
+		Label: "untag " + repository + ":" + tag,
+		Run: func(ctx context.Context) error {
+			return c.UntagImage(ctx, repository+":"+tag)
+		},
+	})
+	return err
 }
 
 // UntagImage 删除镜像标签
@@ -685,7 +988,36 @@ func (c *LocalClient) UntagImage(ctx context.Context, imageRef string) error {
 	if c == nil || c.imageCli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
-	return c.imageCli.Untag(ctx, imageRef)
+	if err := checkAction("image.untag", imageRef); err != nil {
+		return err
+	}
+	// 删除前记下镜像 ID，撤销时重新打上标签（删除的是最后一个标签时镜像也被删除，无法撤销）
+	var undo *audit.Undo
+	if repository, tag, ok := splitRepoTag(imageRef); ok {
+		if inspect, iErr := c.cli.ImageInspect(ctx, imageRef); iErr == nil && len(inspect.RepoTags) > 1 {
+			imageID := inspect.ID
+			undo = &audit.Undo{
+				Label: "re-tag " + imageRef,
+				Run: func(ctx context.Context) error {
+					return c.TagImage(ctx, imageID, repository, tag)
+				},
+			}
+		}
+	}
+	err := c.imageCli.Untag(ctx, imageRef)
+	audit.RecordUndoable("image.untag", imageRef, err, undo)
+	return err
+}
+
+// splitRepoTag 把 repo:tag 拆开，未写标签时为 latest；按摘要引用时返回 false
+func splitRepoTag(ref string) (repository, tag string, ok bool) {
+	if strings.Contains(ref, "@") {
+		return "", "", false
+	}
+	if i := strings.LastIndex(ref, ":"); i >= 0 && !strings.Contains(ref[i:], "/") {
+		return ref[:i], ref[i+1:], true
+	}
+	return ref, "latest", true
 }
 
 // SaveImage 导出镜像到 tar 文件
@@ -701,7 +1033,12 @@ func (c *LocalClient) LoadImage(ctx context.Context, input io.Reader, quiet bool
 	if c == nil || c.imageCli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
-	return c.imageCli.Load(ctx, input, quiet)
+	if err := checkAction("image.load", ""); err != nil {
+		return err
+	}
+	err := c.imageCli.Load(ctx, input, quiet)
+	audit.Record("image.load", "", err)
+	return err
 }
 
 // PullImage 拉取镜像
@@ -709,15 +1046,25 @@ func (c *LocalClient) PullImage(ctx context.Context, imageRef string) (io.ReadCl
 	if c == nil || c.imageCli == nil {
 		return nil, fmt.Errorf("Docker client not initialized")
 	}
-	return c.imageCli.Pull(ctx, imageRef)
+	if err := checkAction("image.pull", imageRef); err != nil {
+		return nil, err
+	}
+	reader, err := c.imageCli.Pull(ctx, imageRef)
+	audit.Record("image.pull", imageRef, err)
+	return reader, err
 }
 
-// PushImage 推送镜像到 registry
+// PushImage 推送镜像到 registry，使用 docker CLI 中保存的登录凭据
 func (c *LocalClient) PushImage(ctx context.Context, imageRef string) (io.ReadCloser, error) {
 	if c == nil || c.imageCli == nil {
 		return nil, fmt.Errorf("Docker client not initialized")
 	}
-	return c.imageCli.Push(ctx, imageRef)
+	if err := checkAction("image.push", imageRef); err != nil {
+		return nil, err
+	}
+	reader, err := c.imageCli.Push(ctx, imageRef, registryAuth(imageRef))
+	audit.Record("image.push", imageRef, err)
+	return reader, err
 }
 
 // Close 关闭 Docker 客户端连接
@@ -771,15 +1118,21 @@ func (c *LocalClient) WatchEvents(ctx context.Context) (<-chan ContainerEvent, <
 						containerName = name
 					}
 
-					// 只关注关键事件：start, stop, die, create, destroy, rename
-					action := string(msg.Action)
-					if action == "start" || action == "stop" || action == "die" || 
-					   action == "create" || action == "destroy" || action == "rename" {
+					// 只关注关键事件：start, stop, die, kill, create, destroy, rename, health_status
+					// 健康检查事件的 Action 形如 "health_status: unhealthy"，拆分为 Action 和 Health
+					action, health, _ := strings.Cut(string(msg.Action), ":")
+					if action == "start" || action == "stop" || action == "die" || action == "kill" ||
+					   action == "create" || action == "destroy" || action == "rename" || action == "health_status" {
 						event := ContainerEvent{
 							Action:        action,
 							ContainerID:   msg.Actor.ID,
 							ContainerName: containerName,
-							Timestamp:     time.Unix(msg.Time, msg.TimeNano),
+							Timestamp:     time.Unix(0, msg.TimeNano), // TimeNano 是完整的纳秒时间戳
+							Health:        strings.TrimSpace(health),
+							Project:       msg.Actor.Attributes["com.docker.compose.project"],
+						}
+						if code, err := strconv.Atoi(msg.Actor.Attributes["exitCode"]); err == nil {
+							event.ExitCode = code
 						}
 						select {
 						case eventChan <- event:
@@ -797,13 +1150,31 @@ func (c *LocalClient) WatchEvents(ctx context.Context) (<-chan ContainerEvent, <
 	return eventChan, errorChan
 }
 
+// checkAction 执行变更操作前检查权限，被拒绝的尝试同样写入审计日志
+func checkAction(action, target string) error {
+	if err := policy.Check(action); err != nil {
+		audit.Record(action, target, err)
+		return err
+	}
+	return nil
+}
+
 // StartContainer 启动已停止的容器
 func (c *LocalClient) StartContainer(ctx context.Context, containerID string) error {
 	if c == nil || c.cli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
+	if err := checkAction("container.start", audit.ShortID(containerID)); err != nil {
+		return err
+	}
 
 	err := c.cli.ContainerStart(ctx, containerID, container.StartOptions{})
+	audit.RecordUndoable("container.start", audit.ShortID(containerID), err, &audit.Undo{
+		Label: "stop",
+		Run: func(ctx context.Context) error {
+			return c.StopContainer(ctx, containerID, 0)
+		},
+	})
 	if err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
 	}
@@ -816,6 +1187,9 @@ func (c *LocalClient) StopContainer(ctx context.Context, containerID string, tim
 	if c == nil || c.cli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
+	if err := checkAction("container.stop", audit.ShortID(containerID)); err != nil {
+		return err
+	}
 
 	// 设置超时时间
 	var timeoutPtr *int
@@ -826,6 +1200,12 @@ func (c *LocalClient) StopContainer(ctx context.Context, containerID string, tim
 	err := c.cli.ContainerStop(ctx, containerID, container.StopOptions{
 		Timeout: timeoutPtr,
 	})
+	audit.RecordUndoable("container.stop", audit.ShortID(containerID), err, &audit.Undo{
+		Label: "start",
+		Run: func(ctx context.Context) error {
+			return c.StartContainer(ctx, containerID)
+		},
+	})
 	if err != nil {
 		return fmt.Errorf("failed to stop container: %w", err)
 	}
@@ -833,11 +1213,32 @@ func (c *LocalClient) StopContainer(ctx context.Context, containerID string, tim
 	return nil
 }
 
+// WaitContainer 等待容器达到指定状态，返回退出码
+func (c *LocalClient) WaitContainer(ctx context.Context, containerID string, condition WaitCondition) (int64, error) {
+	if c == nil || c.cli == nil {
+		return 0, fmt.Errorf("Docker client not initialized")
+	}
+
+	respChan, errChan := c.cli.ContainerWait(ctx, containerID, condition)
+	select {
+	case resp := <-respChan:
+		if resp.Error != nil && resp.Error.Message != "" {
+			return resp.StatusCode, fmt.Errorf("failed to wait for container: %s", resp.Error.Message)
+		}
+		return resp.StatusCode, nil
+	case err := <-errChan:
+		return 0, fmt.Errorf("failed to wait for container: %w", err)
+	}
+}
+
 // RestartContainer 重启容器
 func (c *LocalClient) RestartContainer(ctx context.Context, containerID string, timeout int) error {
 	if c == nil || c.cli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
+	if err := checkAction("container.restart", audit.ShortID(containerID)); err != nil {
+		return err
+	}
 
 	// 设置超时时间
 	var timeoutPtr *int
@@ -848,6 +1249,7 @@ func (c *LocalClient) RestartContainer(ctx context.Context, containerID string,
 	err := c.cli.ContainerRestart(ctx, containerID, container.StopOptions{
 		Timeout: timeoutPtr,
 	})
+	audit.Record("container.restart", audit.ShortID(containerID), err)
 	if err != nil {
 		return fmt.Errorf("failed to restart container: %w", err)
 	}
@@ -860,14 +1262,24 @@ func (c *LocalClient) RemoveContainer(ctx context.Context, containerID string, f
 	if c == nil || c.cli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
+	if err := checkAction("container.remove", audit.ShortID(containerID)); err != nil {
+		return err
+	}
+
+	// 删除前保存 inspect 数据，删除成功后放入回收站（尽力而为）
+	info, raw, inspectErr := c.cli.ContainerInspectWithRaw(ctx, containerID, false)
 
 	err := c.cli.ContainerRemove(ctx, containerID, container.RemoveOptions{
 		Force:         force,
 		RemoveVolumes: removeVolumes,
 	})
+	audit.Record("container.remove", audit.ShortID(containerID), err)
 	if err != nil {
 		return fmt.Errorf("failed to remove container: %w", err)
 	}
+	if inspectErr == nil && info.Config != nil {
+		trash.Keep(info.ID, strings.TrimPrefix(info.Name, "/"), info.Config.Image, raw)
+	}
 
 	return nil
 }
@@ -877,8 +1289,17 @@ func (c *LocalClient) PauseContainer(ctx context.Context, containerID string) er
 	if c == nil || c.cli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
+	if err := checkAction("container.pause", audit.ShortID(containerID)); err != nil {
+		return err
+	}
 
 	err := c.cli.ContainerPause(ctx, containerID)
+	audit.RecordUndoable("container.pause", audit.ShortID(containerID), err, &audit.Undo{
+		Label: "unpause",
+		Run: func(ctx context.Context) error {
+			return c.UnpauseContainer(ctx, containerID)
+		},
+	})
 	if err != nil {
 		return fmt.Errorf("failed to pause container: %w", err)
 	}
@@ -891,8 +1312,17 @@ func (c *LocalClient) UnpauseContainer(ctx context.Context, containerID string)
 	if c == nil || c.cli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
+	if err := checkAction("container.unpause", audit.ShortID(containerID)); err != nil {
+		return err
+	}
 
 	err := c.cli.ContainerUnpause(ctx, containerID)
+	audit.RecordUndoable("container.unpause", audit.ShortID(containerID), err, &audit.Undo{
+		Label: "pause",
+		Run: func(ctx context.Context) error {
+			return c.PauseContainer(ctx, containerID)
+		},
+	})
 	if err != nil {
 		return fmt.Errorf("failed to unpause container: %w", err)
 	}
@@ -905,6 +1335,9 @@ func (c *LocalClient) UpdateContainer(ctx context.Context, containerID string, c
 	if c == nil || c.cli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
+	if err := checkAction("container.update", audit.ShortID(containerID)); err != nil {
+		return err
+	}
 
 	// 构建更新配置
 	updateConfig := container.UpdateConfig{
@@ -944,6 +1377,7 @@ func (c *LocalClient) UpdateContainer(ctx context.Context, containerID string, c
 
 	// 调用 Docker SDK 更新容器
 	_, err := c.cli.ContainerUpdate(ctx, containerID, updateConfig)
+	audit.Record("container.update", audit.ShortID(containerID), err)
 	if err != nil {
 		return err
 	}
@@ -974,7 +1408,12 @@ func (c *LocalClient) CreateNetwork(ctx context.Context, opts NetworkCreateOptio
 	if c == nil || c.networkCli == nil {
 		return "", fmt.Errorf("Docker client not initialized")
 	}
-	return c.networkCli.Create(ctx, opts)
+	if err := checkAction("network.create", opts.Name); err != nil {
+		return "", err
+	}
+	id, err := c.networkCli.Create(ctx, opts)
+	audit.Record("network.create", opts.Name, err)
+	return id, err
 }
 
 // RemoveNetwork 删除网络
@@ -982,7 +1421,12 @@ func (c *LocalClient) RemoveNetwork(ctx context.Context, networkID string) error
 	if c == nil || c.networkCli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
-	return c.networkCli.Remove(ctx, networkID)
+	if err := checkAction("network.remove", audit.ShortID(networkID)); err != nil {
+		return err
+	}
+	err := c.networkCli.Remove(ctx, networkID)
+	audit.Record("network.remove", audit.ShortID(networkID), err)
+	return err
 }
 
 // PruneNetworks 清理未使用的网络
@@ -990,7 +1434,12 @@ func (c *LocalClient) PruneNetworks(ctx context.Context) ([]string, error) {
 	if c == nil || c.networkCli == nil {
 		return nil, fmt.Errorf("Docker client not initialized")
 	}
-	return c.networkCli.Prune(ctx)
+	if err := checkAction("network.prune", ""); err != nil {
+		return nil, err
+	}
+	removed, err := c.networkCli.Prune(ctx)
+	audit.Record("network.prune", fmt.Sprintf("%d network(s)", len(removed)), err)
+	return removed, err
 }
 
 // ConnectNetwork 将容器连接到网络
@@ -998,7 +1447,17 @@ func (c *LocalClient) ConnectNetwork(ctx context.Context, networkID string, opts
 	if c == nil || c.networkCli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
-	return c.networkCli.Connect(ctx, networkID, opts)
+	if err := checkAction("network.connect", audit.ShortID(opts.ContainerID)+" -> "+audit.ShortID(networkID)); err != nil {
+		return err
+	}
+	err := c.networkCli.Connect(ctx, networkID, opts)
+	audit.RecordUndoable("network.connect", audit.ShortID(opts.ContainerID)+" -> "+audit.ShortID(networkID), err, &audit.Undo{
+		Label: "disconnect",
+		Run: func(ctx context.Context) error {
+			return c.DisconnectNetwork(ctx, networkID, NetworkDisconnectOptions{ContainerID: opts.ContainerID})
+		},
+	})
+	return err
 }
 
 // DisconnectNetwork 将容器从网络断开
@@ -1006,7 +1465,17 @@ func (c *LocalClient) DisconnectNetwork(ctx context.Context, networkID string, o
 	if c == nil || c.networkCli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
-	return c.networkCli.Disconnect(ctx, networkID, opts)
+	if err := checkAction("network.disconnect", audit.ShortID(opts.ContainerID)+" -x "+audit.ShortID(networkID)); err != nil {
+		return err
+	}
+	err := c.networkCli.Disconnect(ctx, networkID, opts)
+	audit.RecordUndoable("network.disconnect", audit.ShortID(opts.ContainerID)+" -x "+audit.ShortID(networkID), err, &audit.Undo{
+		Label: "reconnect",
+		Run: func(ctx context.Context) error {
+			return c.ConnectNetwork(ctx, networkID, NetworkConnectOptions{ContainerID: opts.ContainerID})
+		},
+	})
+	return err
 }
 
 // InspectNetworkRaw 获取网络的原始 JSON 数据
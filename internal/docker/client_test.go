@@ -95,7 +95,7 @@ func TestLocalClient_UnimplementedMethods(t *testing.T) {
 	}
 
 	// 测试 ExecShell
-	err = client.ExecShell(ctx, "test-id", "/bin/bash")
+	err = client.ExecShell(ctx, "test-id", "/bin/bash", nil)
 	if err == nil {
 		t.Error("Expected error for unimplemented ExecShell, got nil")
 	}
@@ -104,6 +104,28 @@ func TestLocalClient_UnimplementedMethods(t *testing.T) {
 	}
 }
 
+// TestSplitRepoTag 测试撤销删除标签时对镜像引用的拆分
+func TestSplitRepoTag(t *testing.T) {
+	tests := []struct {
+		ref        string
+		repository string
+		tag        string
+		ok         bool
+	}{
+		{"nginx:1.25", "nginx", "1.25", true},
+		{"nginx", "nginx", "latest", true},
+		{"registry.local:5000/team/app:v2", "registry.local:5000/team/app", "v2", true},
+		{"registry.local:5000/team/app", "registry.local:5000/team/app", "latest", true},
+		{"nginx@sha256:abc", "", "", false},
+	}
+	for _, tt := range tests {
+		repository, tag, ok := splitRepoTag(tt.ref)
+		if repository != tt.repository || tag != tt.tag || ok != tt.ok {
+			t.Errorf("splitRepoTag(%q) = %q, %q, %v; want %q, %q, %v", tt.ref, repository, tag, ok, tt.repository, tt.tag, tt.ok)
+		}
+	}
+}
+
 // TestLogOptions_DefaultValues 测试 LogOptions 结构的默认值处理
 func TestLogOptions_DefaultValues(t *testing.T) {
 	opts := LogOptions{}
@@ -0,0 +1,153 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	sdk "github.com/docker/docker/client"
+	"github.com/docker/go-connections/tlsconfig"
+)
+
+// CLIContext docker CLI 上下文（docker context ls 中的一项）
+type CLIContext struct {
+	Name        string
+	Description string
+	Endpoint    Endpoint
+	Current     bool // docker CLI 当前使用的上下文（DOCKER_CONTEXT 或 config.json 的 currentContext）
+}
+
+// Endpoint 守护进程地址及连接所需的 TLS 设置
+type Endpoint struct {
+	Host          string
+	TLSDir        string // 包含 ca.pem、cert.pem、key.pem（可只有其中一部分）的目录，空表示沿用环境变量
+	SkipTLSVerify bool
+}
+
+// contextMeta 上下文存储中 meta.json 的内容
+type contextMeta struct {
+	Name     string `json:"Name"`
+	Metadata struct {
+		Description string `json:"Description"`
+	} `json:"Metadata"`
+	Endpoints map[string]struct {
+		Host          string `json:"Host"`
+		SkipTLSVerify bool   `json:"SkipTLSVerify"`
+	} `json:"Endpoints"`
+}
+
+// ListCLIContexts 读取 docker CLI 的上下文存储（$DOCKER_CONFIG/contexts），按名称排序；
+// 内置的 default 上下文不在存储中，没有 docker 端点的上下文（如只有 kubernetes）跳过
+func ListCLIContexts() ([]CLIContext, error) {
+	dir, err := dockerConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	current := os.Getenv("DOCKER_CONTEXT")
+	if current == "" {
+		if cfg, err := loadDockerConfig(); err == nil {
+			current = cfg.CurrentContext
+		}
+	}
+
+	metaDirs, err := os.ReadDir(filepath.Join(dir, "contexts", "meta"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var contexts []CLIContext
+	for _, d := range metaDirs {
+		if !d.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "contexts", "meta", d.Name(), "meta.json"))
+		if err != nil {
+			continue
+		}
+		var meta contextMeta
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return nil, fmt.Errorf("context %s: %w", d.Name(), err)
+		}
+		ep, ok := meta.Endpoints["docker"]
+		if !ok || meta.Name == "" || ep.Host == "" {
+			continue
+		}
+		ctx := CLIContext{
+			Name:        meta.Name,
+			Description: meta.Metadata.Description,
+			Endpoint:    Endpoint{Host: ep.Host, SkipTLSVerify: ep.SkipTLSVerify},
+			Current:     meta.Name == current,
+		}
+		// TLS 材料保存在 contexts/tls/<sha256(名称)>/docker 下
+		tlsDir := filepath.Join(dir, "contexts", "tls", contextDirName(meta.Name), "docker")
+		if info, err := os.Stat(tlsDir); err == nil && info.IsDir() {
+			ctx.Endpoint.TLSDir = tlsDir
+		}
+		contexts = append(contexts, ctx)
+	}
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].Name < contexts[j].Name })
+	return contexts, nil
+}
+
+// contextDirName 上下文在存储中的目录名
+func contextDirName(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewLocalClientForEndpoint 创建连接指定端点的客户端；端点带 TLS 材料时使用端点的证书，否则同 NewLocalClientForHost
+func NewLocalClientForEndpoint(ep Endpoint) (*LocalClient, error) {
+	if (ep.TLSDir == "" && !ep.SkipTLSVerify) || !strings.HasPrefix(ep.Host, "tcp://") {
+		return NewLocalClientForHost(ep.Host)
+	}
+	tlsOpts := tlsconfig.Options{InsecureSkipVerify: ep.SkipTLSVerify, ExclusiveRootPools: true}
+	if ep.TLSDir != "" {
+		for file, dst := range map[string]*string{"ca.pem": &tlsOpts.CAFile, "cert.pem": &tlsOpts.CertFile, "key.pem": &tlsOpts.KeyFile} {
+			if p := filepath.Join(ep.TLSDir, file); fileExists(p) {
+				*dst = p
+			}
+		}
+	}
+	tlsCfg, err := tlsconfig.Client(tlsOpts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS material for %s: %w", ep.Host, err)
+	}
+	// 自定义的 HTTP 客户端需要在 WithHost 之前设置，WithHost 会在它的 Transport 上配置连接方式
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}, CheckRedirect: sdk.CheckRedirect}
+	return newLocalClient(ep.Host, sdk.FromEnv, sdk.WithHTTPClient(httpClient), sdk.WithHost(ep.Host), sdk.WithAPIVersionNegotiation())
+}
+
+// Environ 启动连接该端点的子进程时设置的环境变量（DOCKER_HOST 以及 TLS 设置，清除继承的 TLS 设置）
+func (ep Endpoint) Environ(env []string) []string {
+	out := make([]string, 0, len(env)+3)
+	for _, kv := range env {
+		key, _, _ := strings.Cut(kv, "=")
+		if key == "DOCKER_HOST" || key == "DOCKER_CONTEXT" || (ep.TLSDir != "" && (key == "DOCKER_CERT_PATH" || key == "DOCKER_TLS_VERIFY")) {
+			continue
+		}
+		out = append(out, kv)
+	}
+	out = append(out, "DOCKER_HOST="+ep.Host)
+	if ep.TLSDir != "" {
+		out = append(out, "DOCKER_CERT_PATH="+ep.TLSDir)
+		if !ep.SkipTLSVerify {
+			out = append(out, "DOCKER_TLS_VERIFY=1")
+		}
+	}
+	return out
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,90 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeContext 在上下文存储中写入一个上下文
+func writeContext(t *testing.T, dir, name, meta string, withTLS bool) {
+	t.Helper()
+	metaDir := filepath.Join(dir, "contexts", "meta", contextDirName(name))
+	if err := os.MkdirAll(metaDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(metaDir, "meta.json"), []byte(meta), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if withTLS {
+		tlsDir := filepath.Join(dir, "contexts", "tls", contextDirName(name), "docker")
+		if err := os.MkdirAll(tlsDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestListCLIContexts 测试读取 docker CLI 上下文存储
+func TestListCLIContexts(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+	t.Setenv("DOCKER_CONTEXT", "")
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"currentContext":"prod"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeContext(t, dir, "prod", `{"Name":"prod","Metadata":{"Description":"production"},"Endpoints":{"docker":{"Host":"tcp://10.0.0.5:2376","SkipTLSVerify":false}}}`, true)
+	writeContext(t, dir, "build", `{"Name":"build","Metadata":{},"Endpoints":{"docker":{"Host":"ssh://ci@build"}}}`, false)
+	writeContext(t, dir, "k8s", `{"Name":"k8s","Metadata":{},"Endpoints":{"kubernetes":{}}}`, false)
+
+	contexts, err := ListCLIContexts()
+	if err != nil {
+		t.Fatalf("ListCLIContexts failed: %v", err)
+	}
+	if len(contexts) != 2 {
+		t.Fatalf("Expected 2 contexts, got %d: %+v", len(contexts), contexts)
+	}
+	build, prod := contexts[0], contexts[1]
+	if build.Name != "build" || build.Endpoint.Host != "ssh://ci@build" || build.Endpoint.TLSDir != "" || build.Current {
+		t.Errorf("Unexpected build context: %+v", build)
+	}
+	if prod.Name != "prod" || prod.Description != "production" || !prod.Current {
+		t.Errorf("Unexpected prod context: %+v", prod)
+	}
+	if !strings.HasSuffix(prod.Endpoint.TLSDir, filepath.Join(contextDirName("prod"), "docker")) {
+		t.Errorf("Expected TLS dir for prod, got %q", prod.Endpoint.TLSDir)
+	}
+
+	// DOCKER_CONTEXT 优先于 config.json
+	t.Setenv("DOCKER_CONTEXT", "build")
+	contexts, _ = ListCLIContexts()
+	if !contexts[0].Current || contexts[1].Current {
+		t.Errorf("Expected DOCKER_CONTEXT to select build, got %+v", contexts)
+	}
+}
+
+// TestListCLIContexts_NoStore 没有上下文存储时返回空列表
+func TestListCLIContexts_NoStore(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	contexts, err := ListCLIContexts()
+	if err != nil || len(contexts) != 0 {
+		t.Errorf("Expected no contexts and no error, got %v, %v", contexts, err)
+	}
+}
+
+// TestEndpointEnviron 测试启动子进程时的环境变量
+func TestEndpointEnviron(t *testing.T) {
+	env := []string{"PATH=/bin", "DOCKER_HOST=unix:///var/run/docker.sock", "DOCKER_CONTEXT=old", "DOCKER_CERT_PATH=/old", "DOCKER_TLS_VERIFY=1"}
+
+	got := strings.Join(Endpoint{Host: "tcp://h:2376", TLSDir: "/tls"}.Environ(env), " ")
+	want := "PATH=/bin DOCKER_HOST=tcp://h:2376 DOCKER_CERT_PATH=/tls DOCKER_TLS_VERIFY=1"
+	if got != want {
+		t.Errorf("Environ with TLS:\n got %s\nwant %s", got, want)
+	}
+
+	got = strings.Join(Endpoint{Host: "ssh://u@h"}.Environ(env), " ")
+	want = "PATH=/bin DOCKER_CERT_PATH=/old DOCKER_TLS_VERIFY=1 DOCKER_HOST=ssh://u@h"
+	if got != want {
+		t.Errorf("Environ without TLS:\n got %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,128 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/errdefs"
+
+	"docktui/internal/audit"
+)
+
+// LabelDebugOf 调试运行的临时容器带有该标签，值为源容器名称
+const LabelDebugOf = "docktui.debug-of"
+
+// DefaultDebugEntrypoint 调试运行默认的入口命令：让容器保持运行，等待进入 shell
+var DefaultDebugEntrypoint = []string{"sleep", "infinity"}
+
+// DebugContainer 以源容器的镜像、环境变量、挂载（含匿名卷）和网络启动一个临时容器，入口命令替换为
+// entrypoint（为空时使用 DefaultDebugEntrypoint），用于进入 shell 排查启动即退出的容器。
+// 临时容器不绑定宿主机端口、不自动重启、关闭健康检查，停止后自动删除；返回新容器 ID 和名称
+func (c *LocalClient) DebugContainer(ctx context.Context, containerID string, entrypoint []string) (string, string, error) {
+	if c == nil || c.cli == nil {
+		return "", "", fmt.Errorf("Docker client not initialized")
+	}
+	if len(entrypoint) == 0 {
+		entrypoint = DefaultDebugEntrypoint
+	}
+
+	info, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+	sourceName := strings.TrimPrefix(info.Name, "/")
+	name := fmt.Sprintf("%s-debug-%d", sourceName, time.Now().Unix())
+	target := sourceName + " -> " + name
+	if err := checkAction("container.debug", target); err != nil {
+		return "", "", err
+	}
+
+	config := *info.Config
+	if strings.HasPrefix(info.ID, config.Hostname) {
+		config.Hostname = ""
+	}
+	config.Entrypoint = entrypoint
+	config.Cmd = nil
+	config.Healthcheck = &container.HealthConfig{Test: []string{"NONE"}}
+	config.ExposedPorts = nil
+	config.Labels = make(map[string]string, len(info.Config.Labels)+1)
+	for k, v := range info.Config.Labels {
+		if !strings.HasPrefix(k, "com.docker.compose.") {
+			config.Labels[k] = v
+		}
+	}
+	config.Labels[LabelDebugOf] = sourceName
+
+	// 挂载通过 volumes-from 整体继承（包括匿名卷，数据与源容器一致），只保留 tmpfs
+	hostConfig := *info.HostConfig
+	hostConfig.Binds = nil
+	hostConfig.Mounts = nil
+	for _, m := range info.HostConfig.Mounts {
+		if m.Type == mount.TypeTmpfs {
+			hostConfig.Mounts = append(hostConfig.Mounts, m)
+		}
+	}
+	hostConfig.VolumesFrom = []string{info.ID}
+	hostConfig.PortBindings = nil
+	hostConfig.PublishAllPorts = false
+	hostConfig.RestartPolicy = container.RestartPolicy{Name: container.RestartPolicyDisabled}
+	hostConfig.AutoRemove = true
+
+	resp, err := c.cli.ContainerCreate(ctx, &config, &hostConfig, nil, nil, name)
+	if err != nil {
+		audit.Record("container.debug", target, err)
+		return "", "", fmt.Errorf("failed to create debug container: %w", err)
+	}
+	if err := c.connectExtraNetworks(ctx, info, resp.ID); err != nil {
+		c.removeDebugContainer(resp.ID)
+		audit.Record("container.debug", target, err)
+		return "", "", fmt.Errorf("debug container created but %w", err)
+	}
+	if err := c.cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		c.removeDebugContainer(resp.ID)
+		audit.Record("container.debug", target, err)
+		return "", "", fmt.Errorf("failed to start debug container: %w", err)
+	}
+	audit.Record("container.debug", target, nil)
+	return resp.ID, name, nil
+}
+
+// RemoveDebugContainer 删除调试运行的临时容器；只删除带 LabelDebugOf 标签的容器，
+// 创建时已通过 container.debug 的策略检查，不再单独检查 container.remove
+func (c *LocalClient) RemoveDebugContainer(ctx context.Context, containerID string) error {
+	if c == nil || c.cli == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+	info, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		// 停止后已被自动删除
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+	if _, ok := info.Config.Labels[LabelDebugOf]; !ok {
+		return fmt.Errorf("%s is not a debug container", strings.TrimPrefix(info.Name, "/"))
+	}
+	err = c.cli.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: true})
+	// 已停止的容器正在被自动删除时返回冲突
+	if errdefs.IsNotFound(err) || errdefs.IsConflict(err) {
+		err = nil
+	}
+	audit.Record("container.remove", strings.TrimPrefix(info.Name, "/"), err)
+	if err != nil {
+		return fmt.Errorf("failed to remove debug container: %w", err)
+	}
+	return nil
+}
+
+// removeDebugContainer 创建失败时清理已创建的临时容器（尽力而为）
+func (c *LocalClient) removeDebugContainer(containerID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = c.cli.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: true})
+}
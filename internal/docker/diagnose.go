@@ -0,0 +1,300 @@
+package docker
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	sdk "github.com/docker/docker/client"
+)
+
+// DiagStatus 诊断检查结果
+type DiagStatus int
+
+const (
+	DiagOK   DiagStatus = iota // 正常
+	DiagWarn                   // 可能有问题
+	DiagFail                   // 失败
+)
+
+// Diagnostic 单项连接诊断结果
+type Diagnostic struct {
+	Check       string
+	Status      DiagStatus
+	Detail      string
+	Suggestions []string // 可执行的处理建议
+}
+
+// certExpiryWarning 证书剩余有效期少于该值时给出警告
+const certExpiryWarning = 30 * 24 * time.Hour
+
+// Diagnose 依次检查 Docker 连接的各个环节：端点、CLI 上下文、socket、网络可达性、TLS 证书和守护进程 API
+func Diagnose(ctx context.Context) []Diagnostic {
+	host := os.Getenv("DOCKER_HOST")
+	source := "DOCKER_HOST"
+	switch {
+	case host != "":
+	case detectDefaultHost() != "":
+		host = detectDefaultHost()
+		source = "detected named pipe"
+	default:
+		host = sdk.DefaultDockerHost
+		source = "default"
+	}
+	results := []Diagnostic{{Check: "Endpoint", Status: DiagOK, Detail: host + " (" + source + ")"}}
+
+	if d, ok := diagnoseContext(source); ok {
+		results = append(results, d)
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return append(results, Diagnostic{
+			Check:       "Endpoint",
+			Status:      DiagFail,
+			Detail:      fmt.Sprintf("invalid DOCKER_HOST %q: %v", host, err),
+			Suggestions: []string{"Use unix:///path/to/docker.sock, tcp://host:2376 or ssh://user@host"},
+		})
+	}
+	switch u.Scheme {
+	case "unix":
+		results = append(results, diagnoseSocket(u.Path)...)
+	case "tcp", "http", "https":
+		results = append(results, diagnoseTCP(u.Host))
+		results = append(results, diagnoseTLS(u.Port())...)
+	case "npipe":
+		if runtime.GOOS != "windows" {
+			results = append(results, Diagnostic{Check: "Named pipe", Status: DiagFail, Detail: "npipe endpoints only work on Windows",
+				Suggestions: []string{"Inside WSL use unix:///var/run/docker.sock (enable WSL integration in Docker Desktop)"}})
+		}
+	case "ssh":
+		results = append(results, Diagnostic{Check: "SSH", Status: DiagOK, Detail: "connection is made through the ssh client",
+			Suggestions: []string{"Verify with: ssh " + u.Host + " docker version"}})
+	}
+
+	return append(results, diagnoseAPI(ctx))
+}
+
+// diagnoseContext docker CLI 使用了非默认上下文而 docktui 没有设置 DOCKER_HOST 时，两者连接的可能不是同一个守护进程
+func diagnoseContext(source string) (Diagnostic, bool) {
+	cfg, err := loadDockerConfig()
+	if err != nil || cfg.CurrentContext == "" || cfg.CurrentContext == "default" || source == "DOCKER_HOST" {
+		return Diagnostic{}, false
+	}
+	return Diagnostic{
+		Check:  "CLI context",
+		Status: DiagWarn,
+		Detail: fmt.Sprintf("docker CLI uses context %q, docktui only reads DOCKER_HOST", cfg.CurrentContext),
+		Suggestions: []string{
+			"export DOCKER_HOST=$(docker context inspect -f '{{.Endpoints.docker.Host}}')",
+		},
+	}, true
+}
+
+// diagnoseSocket 检查 unix socket 是否存在以及当前用户能否连接
+func diagnoseSocket(path string) []Diagnostic {
+	info, err := os.Stat(path)
+	if err != nil {
+		d := Diagnostic{Check: "Socket", Status: DiagFail, Detail: path + " does not exist"}
+		// 常见的 rootless / Docker Desktop socket 位置
+		for _, candidate := range alternativeSockets() {
+			if candidate != path {
+				if _, err := os.Stat(candidate); err == nil {
+					d.Suggestions = append(d.Suggestions, "Found a socket at "+candidate+": export DOCKER_HOST=unix://"+candidate)
+				}
+			}
+		}
+		d.Suggestions = append(d.Suggestions, daemonStartHints()...)
+		return []Diagnostic{d}
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return []Diagnostic{{Check: "Socket", Status: DiagFail, Detail: path + " exists but is not a socket"}}
+	}
+	results := []Diagnostic{{Check: "Socket", Status: DiagOK, Detail: path + " exists"}}
+
+	conn, err := net.DialTimeout("unix", path, 2*time.Second)
+	switch {
+	case err == nil:
+		conn.Close()
+		results = append(results, Diagnostic{Check: "Permission", Status: DiagOK, Detail: "socket is accessible"})
+	case errors.Is(err, syscall.EACCES) || errors.Is(err, os.ErrPermission):
+		results = append(results, Diagnostic{
+			Check:  "Permission",
+			Status: DiagFail,
+			Detail: "permission denied on " + path,
+			Suggestions: []string{
+				"sudo usermod -aG docker $USER, then log out and in again (or run: newgrp docker)",
+				"Or use rootless Docker and point DOCKER_HOST at its socket",
+			},
+		})
+	case errors.Is(err, syscall.ECONNREFUSED):
+		results = append(results, Diagnostic{
+			Check:       "Permission",
+			Status:      DiagFail,
+			Detail:      "socket exists but no daemon is listening (stale socket)",
+			Suggestions: daemonStartHints(),
+		})
+	default:
+		results = append(results, Diagnostic{Check: "Permission", Status: DiagFail, Detail: err.Error()})
+	}
+	return results
+}
+
+// diagnoseTCP 检查远程守护进程端口是否可达
+func diagnoseTCP(address string) Diagnostic {
+	conn, err := net.DialTimeout("tcp", address, 3*time.Second)
+	if err != nil {
+		d := Diagnostic{Check: "Reachable", Status: DiagFail, Detail: err.Error()}
+		var dnsErr *net.DNSError
+		switch {
+		case errors.As(err, &dnsErr):
+			d.Suggestions = []string{"Host name does not resolve: check DOCKER_HOST spelling or DNS"}
+		case errors.Is(err, syscall.ECONNREFUSED):
+			d.Suggestions = []string{"Nothing listens on " + address + ": check that dockerd is started with -H tcp://0.0.0.0:2376"}
+		default:
+			d.Suggestions = []string{"Check firewalls / security groups between this machine and " + address}
+		}
+		return d
+	}
+	conn.Close()
+	return Diagnostic{Check: "Reachable", Status: DiagOK, Detail: address + " accepts connections"}
+}
+
+// diagnoseTLS 检查 TLS 证书文件是否存在以及有效期
+func diagnoseTLS(port string) []Diagnostic {
+	verify := os.Getenv("DOCKER_TLS_VERIFY") != ""
+	certDir := os.Getenv("DOCKER_CERT_PATH")
+	if !verify && certDir == "" {
+		if port == "2375" || port == "" {
+			return []Diagnostic{{Check: "TLS", Status: DiagWarn, Detail: "connection is not encrypted",
+				Suggestions: []string{"Anyone on the network can control this daemon; prefer TLS (port 2376) or ssh://"}}}
+		}
+		return nil
+	}
+	if certDir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			certDir = filepath.Join(home, ".docker")
+		}
+	}
+
+	var results []Diagnostic
+	for _, name := range []string{"ca.pem", "cert.pem", "key.pem"} {
+		path := filepath.Join(certDir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			results = append(results, Diagnostic{Check: "TLS " + name, Status: DiagFail, Detail: err.Error(),
+				Suggestions: []string{"Set DOCKER_CERT_PATH to the directory containing ca.pem, cert.pem and key.pem"}})
+			continue
+		}
+		if name == "key.pem" {
+			continue
+		}
+		results = append(results, certificateExpiry("TLS "+name, data, time.Now()))
+	}
+	return results
+}
+
+// certificateExpiry 检查 PEM 证书的有效期
+func certificateExpiry(check string, data []byte, now time.Time) Diagnostic {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Diagnostic{Check: check, Status: DiagFail, Detail: "not a PEM certificate"}
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return Diagnostic{Check: check, Status: DiagFail, Detail: err.Error()}
+	}
+	expiry := cert.NotAfter.Format("2006-01-02")
+	switch {
+	case now.After(cert.NotAfter):
+		return Diagnostic{Check: check, Status: DiagFail, Detail: "expired on " + expiry,
+			Suggestions: []string{"Regenerate the client certificate and restart the daemon if its certificate expired too"}}
+	case now.Before(cert.NotBefore):
+		return Diagnostic{Check: check, Status: DiagFail, Detail: "not valid before " + cert.NotBefore.Format("2006-01-02"),
+			Suggestions: []string{"Check the system clock"}}
+	case cert.NotAfter.Sub(now) < certExpiryWarning:
+		return Diagnostic{Check: check, Status: DiagWarn, Detail: "expires on " + expiry,
+			Suggestions: []string{"Renew the certificate before it expires"}}
+	}
+	return Diagnostic{Check: check, Status: DiagOK, Detail: "valid until " + expiry}
+}
+
+// diagnoseAPI 通过 API 获取守护进程版本
+func diagnoseAPI(ctx context.Context) Diagnostic {
+	cli, err := sdk.NewClientWithOpts(clientOpts()...)
+	if err != nil {
+		return Diagnostic{Check: "Daemon API", Status: DiagFail, Detail: err.Error()}
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	version, err := cli.ServerVersion(ctx)
+	if err != nil {
+		return Diagnostic{Check: "Daemon API", Status: DiagFail, Detail: err.Error(), Suggestions: daemonLogHints()}
+	}
+	return Diagnostic{
+		Check:  "Daemon API",
+		Status: DiagOK,
+		Detail: fmt.Sprintf("Docker %s (API %s, %s/%s)", version.Version, version.APIVersion, version.Os, version.Arch),
+	}
+}
+
+// alternativeSockets rootless Docker、Docker Desktop、Podman 等常见的 socket 路径
+func alternativeSockets() []string {
+	var paths []string
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		paths = append(paths, filepath.Join(dir, "docker.sock"), filepath.Join(dir, "podman", "podman.sock"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".docker", "run", "docker.sock"), filepath.Join(home, ".docker", "desktop", "docker.sock"))
+	}
+	return append(paths, "/var/run/docker.sock")
+}
+
+// daemonStartHints 守护进程未运行时的处理建议
+func daemonStartHints() []string {
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return []string{"Start Docker Desktop and wait until it reports \"Engine running\""}
+	}
+	return append([]string{"Start the daemon: sudo systemctl start docker"}, daemonLogHints()...)
+}
+
+// daemonLogHints 查看守护进程日志的方法
+func daemonLogHints() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"Daemon logs: ~/Library/Containers/com.docker.docker/Data/log/vm/dockerd.log"}
+	case "windows":
+		return []string{"Daemon logs: %LOCALAPPDATA%\\Docker\\log\\vm\\dockerd.log"}
+	}
+	return []string{"Daemon logs: journalctl -u docker.service -n 50 --no-pager", "Service status: systemctl status docker"}
+}
+
+// ConnectionHint 根据常见的连接错误给出简短的处理建议，无法识别时返回空
+func ConnectionHint(errMsg string) string {
+	msg := strings.ToLower(errMsg)
+	switch {
+	case strings.Contains(msg, "permission denied") && strings.Contains(msg, "docker"):
+		return "No permission on the Docker socket: add your user to the docker group and log in again"
+	case strings.Contains(msg, "x509") || strings.Contains(msg, "certificate"):
+		return "TLS certificate problem: check DOCKER_CERT_PATH and certificate expiry"
+	case strings.Contains(msg, "cannot connect to the docker daemon") || strings.Contains(msg, "connection refused") ||
+		strings.Contains(msg, "no such file or directory") && strings.Contains(msg, ".sock"):
+		return "The Docker daemon is not reachable: check that it is running and DOCKER_HOST is correct"
+	case strings.Contains(msg, "i/o timeout") || strings.Contains(msg, "context deadline exceeded"):
+		return "The daemon did not respond in time: check the network or the remote host's load"
+	}
+	return ""
+}
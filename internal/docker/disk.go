@@ -0,0 +1,51 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+)
+
+// DiskUsage 磁盘占用（字节）
+type DiskUsage struct {
+	Images     int64 // 镜像层（共享层只计一次）
+	Containers int64 // 容器可写层
+	Volumes    int64 // 卷
+	BuildCache int64 // 构建缓存
+}
+
+// Total 返回总占用
+func (d DiskUsage) Total() int64 {
+	return d.Images + d.Containers + d.Volumes + d.BuildCache
+}
+
+// DiskUsage 获取磁盘占用
+func (c *LocalClient) DiskUsage(ctx context.Context) (*DiskUsage, error) {
+	if c == nil || c.cli == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+
+	du, err := c.cli.DiskUsage(ctx, types.DiskUsageOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disk usage: %w", err)
+	}
+
+	usage := &DiskUsage{Images: du.LayersSize}
+	for _, ctr := range du.Containers {
+		if ctr != nil {
+			usage.Containers += ctr.SizeRw
+		}
+	}
+	for _, vol := range du.Volumes {
+		if vol != nil && vol.UsageData != nil && vol.UsageData.Size > 0 {
+			usage.Volumes += vol.UsageData.Size
+		}
+	}
+	for _, cache := range du.BuildCache {
+		if cache != nil && !cache.Shared {
+			usage.BuildCache += cache.Size
+		}
+	}
+	return usage, nil
+}
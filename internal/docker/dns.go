@@ -0,0 +1,170 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/errdefs"
+
+	"docktui/internal/policy"
+)
+
+// EmbeddedDNSServer Docker 内置 DNS 的地址（自定义网络中的容器使用）
+const EmbeddedDNSServer = "127.0.0.11"
+
+// ContainerDNS 容器的主机名和 DNS 配置（--hostname、--dns、--dns-search、--dns-option、--add-host）
+type ContainerDNS struct {
+	Hostname   string
+	Domainname string
+	Servers    []string // 为空表示使用守护进程或宿主机的默认配置
+	Search     []string
+	Options    []string
+	ExtraHosts []string // host:ip，额外写入 /etc/hosts 的条目
+}
+
+// ResolvConf /etc/resolv.conf 中的解析配置
+type ResolvConf struct {
+	Nameservers []string
+	Search      []string
+	Options     []string
+}
+
+// ParseResolvConf 解析 resolv.conf 的 nameserver、search（及旧的 domain）和 options 行
+func ParseResolvConf(content string) ResolvConf {
+	var r ResolvConf
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			r.Nameservers = append(r.Nameservers, fields[1])
+		case "search", "domain":
+			// 后出现的 search/domain 覆盖前面的
+			r.Search = fields[1:]
+		case "options":
+			r.Options = append(r.Options, fields[1:]...)
+		}
+	}
+	return r
+}
+
+// SplitExtraHost 拆分 --add-host 条目（host:ip 或 host=ip）
+func SplitExtraHost(entry string) (host, ip string) {
+	sep := strings.Index(entry, "=")
+	if sep < 0 {
+		sep = strings.Index(entry, ":")
+	}
+	if sep < 0 {
+		return entry, ""
+	}
+	return entry[:sep], entry[sep+1:]
+}
+
+// DNSFiles 容器内实际生效的 /etc/resolv.conf 和 /etc/hosts
+type DNSFiles struct {
+	ResolvConf string
+	Hosts      string
+}
+
+// maxContainerFileSize 从容器中读取的单个文件的大小上限
+const maxContainerFileSize = 1 << 20
+
+// ReadDNSFiles 在容器中执行 cat 读取 /etc/resolv.conf 和 /etc/hosts；
+// 容器未运行、策略不允许 exec 或镜像中没有 cat 时改为通过 API 复制读取
+func ReadDNSFiles(ctx context.Context, client Client, containerID string, running bool) (*DNSFiles, error) {
+	files := &DNSFiles{}
+	for _, f := range []struct {
+		path string
+		dst  *string
+	}{
+		{"/etc/resolv.conf", &files.ResolvConf},
+		{"/etc/hosts", &files.Hosts},
+	} {
+		content, err := readFileWithCat(ctx, client, containerID, f.path, running)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = content
+	}
+	return files, nil
+}
+
+// readFileWithCat 用 cat 读取容器中的文件，exec 不可用时回退到 ReadContainerFile
+func readFileWithCat(ctx context.Context, client Client, containerID, filePath string, running bool) (string, error) {
+	if !running || !policy.Allowed("container.exec") {
+		return client.ReadContainerFile(ctx, containerID, filePath)
+	}
+	out, err := client.ExecCommand(ctx, containerID, ExecConfig{Cmd: []string{"cat", filePath}, AttachStdout: true, AttachStderr: true})
+	switch {
+	case err != nil, out.ExitCode == 126 || out.ExitCode == 127:
+		// 容器刚停止，或镜像中没有 cat
+		return client.ReadContainerFile(ctx, containerID, filePath)
+	case out.ExitCode != 0:
+		return "", fmt.Errorf("cat %s: %s", filePath, strings.TrimSpace(out.Stderr+out.Stdout))
+	}
+	return out.Stdout, nil
+}
+
+// ReadContainerFile 通过 CopyFromContainer 读取容器中的文本文件（最多跟随几层符号链接），
+// 只读操作，不需要容器在运行，也不受 container.exec 策略限制
+func (c *LocalClient) ReadContainerFile(ctx context.Context, containerID, filePath string) (string, error) {
+	if c == nil || c.cli == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+	p := filePath
+	for range 5 {
+		rc, _, err := c.cli.CopyFromContainer(ctx, containerID, p)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				return "", fmt.Errorf("%s not found in container", p)
+			}
+			return "", fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		content, link, err := readTarFile(rc)
+		rc.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		if link == "" {
+			return content, nil
+		}
+		if !path.IsAbs(link) {
+			link = path.Join(path.Dir(p), link)
+		}
+		p = link
+	}
+	return "", fmt.Errorf("failed to read %s: too many symbolic links", filePath)
+}
+
+// readTarFile 读取 CopyFromContainer 返回的 tar 流中的第一个条目：普通文件返回内容，符号链接返回链接目标
+func readTarFile(r io.Reader) (content, link string, err error) {
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err != nil {
+		return "", "", err
+	}
+	switch hdr.Typeflag {
+	case tar.TypeSymlink:
+		return "", hdr.Linkname, nil
+	case tar.TypeReg:
+	default:
+		return "", "", fmt.Errorf("not a regular file")
+	}
+	if hdr.Size > maxContainerFileSize {
+		return "", "", fmt.Errorf("file too large (%d bytes)", hdr.Size)
+	}
+	data, err := io.ReadAll(tr)
+	if err != nil {
+		return "", "", err
+	}
+	return string(data), "", nil
+}
@@ -0,0 +1,179 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"docktui/internal/policy"
+)
+
+// TestParseResolvConf 测试解析 resolv.conf
+func TestParseResolvConf(t *testing.T) {
+	content := `# Generated by Docker Engine.
+nameserver 127.0.0.11
+nameserver 8.8.8.8 ; fallback
+domain old.example
+search svc.cluster.local example.com
+options ndots:0
+options timeout:2 attempts:3
+nameserver
+`
+	got := ParseResolvConf(content)
+	want := ResolvConf{
+		Nameservers: []string{"127.0.0.11", "8.8.8.8"},
+		Search:      []string{"svc.cluster.local", "example.com"},
+		Options:     []string{"ndots:0", "timeout:2", "attempts:3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseResolvConf() = %+v, want %+v", got, want)
+	}
+}
+
+// TestSplitExtraHost 测试拆分 --add-host 条目
+func TestSplitExtraHost(t *testing.T) {
+	tests := []struct {
+		entry, host, ip string
+	}{
+		{"db:10.0.0.5", "db", "10.0.0.5"},
+		{"host.docker.internal:host-gateway", "host.docker.internal", "host-gateway"},
+		{"v6:::1", "v6", "::1"},
+		{"v6=::1", "v6", "::1"},
+		{"nohost", "nohost", ""},
+	}
+	for _, tt := range tests {
+		host, ip := SplitExtraHost(tt.entry)
+		if host != tt.host || ip != tt.ip {
+			t.Errorf("SplitExtraHost(%q) = (%q, %q), want (%q, %q)", tt.entry, host, ip, tt.host, tt.ip)
+		}
+	}
+}
+
+// fakeDNSClient 只实现 ExecCommand 和 ReadContainerFile 的客户端，按文件路径返回结果，记录两种方式各读取了哪些文件
+type fakeDNSClient struct {
+	Client
+	results map[string]*ExecResult
+	execErr error
+	files   map[string]string
+	execed  []string
+	copied  []string
+}
+
+func (c *fakeDNSClient) ExecCommand(ctx context.Context, containerID string, config ExecConfig) (*ExecResult, error) {
+	path := config.Cmd[len(config.Cmd)-1]
+	c.execed = append(c.execed, path)
+	if c.execErr != nil {
+		return nil, c.execErr
+	}
+	return c.results[path], nil
+}
+
+func (c *fakeDNSClient) ReadContainerFile(ctx context.Context, containerID, path string) (string, error) {
+	c.copied = append(c.copied, path)
+	content, ok := c.files[path]
+	if !ok {
+		return "", fmt.Errorf("%s not found in container", path)
+	}
+	return content, nil
+}
+
+// TestReadDNSFiles 测试运行中的容器用 cat 读取，停止、没有 cat 或 exec 失败时改为复制读取，以及失败时的错误信息
+func TestReadDNSFiles(t *testing.T) {
+	const resolv, hosts = "nameserver 127.0.0.11\n", "127.0.0.1\tlocalhost\n"
+	newClient := func() *fakeDNSClient {
+		return &fakeDNSClient{
+			results: map[string]*ExecResult{
+				"/etc/resolv.conf": {Stdout: resolv},
+				"/etc/hosts":       {Stdout: hosts},
+			},
+			files: map[string]string{
+				"/etc/resolv.conf": resolv,
+				"/etc/hosts":       hosts,
+			},
+		}
+	}
+	tests := []struct {
+		name       string
+		running    bool
+		setup      func(c *fakeDNSClient)
+		wantExec   int
+		wantCopied int
+		wantErr    string
+	}{
+		{name: "running", running: true, wantExec: 2},
+		{name: "stopped", running: false, wantCopied: 2},
+		{name: "no cat", running: true, setup: func(c *fakeDNSClient) {
+			c.results["/etc/resolv.conf"] = &ExecResult{ExitCode: 127}
+		}, wantExec: 2, wantCopied: 1},
+		{name: "read-only", running: true, setup: func(c *fakeDNSClient) {
+			policy.SetReadOnly(true)
+		}, wantCopied: 2},
+		{name: "exec failed", running: true, setup: func(c *fakeDNSClient) {
+			c.execErr = fmt.Errorf("container abc is not running")
+		}, wantExec: 2, wantCopied: 2},
+		{name: "cat error", running: true, setup: func(c *fakeDNSClient) {
+			c.results["/etc/hosts"] = &ExecResult{ExitCode: 1, Stderr: "cat: /etc/hosts: Permission denied\n"}
+		}, wantErr: "Permission denied"},
+		{name: "copy error", running: false, setup: func(c *fakeDNSClient) {
+			delete(c.files, "/etc/hosts")
+		}, wantErr: "/etc/hosts not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() { policy.SetReadOnly(false) })
+			client := newClient()
+			if tt.setup != nil {
+				tt.setup(client)
+			}
+			files, err := ReadDNSFiles(context.Background(), client, "abc", tt.running)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ReadDNSFiles() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadDNSFiles() error = %v", err)
+			}
+			if files.ResolvConf != resolv || files.Hosts != hosts {
+				t.Errorf("ReadDNSFiles() = %+v", files)
+			}
+			if len(client.execed) != tt.wantExec || len(client.copied) != tt.wantCopied {
+				t.Errorf("exec %v, copied %v, want %d exec and %d copied", client.execed, client.copied, tt.wantExec, tt.wantCopied)
+			}
+		})
+	}
+}
+
+// TestReadTarFile 测试从 CopyFromContainer 的 tar 流中取出文件内容或链接目标
+func TestReadTarFile(t *testing.T) {
+	archive := func(hdr *tar.Header, body string) *bytes.Buffer {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		hdr.Size = int64(len(body))
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(body))
+		tw.Close()
+		return &buf
+	}
+
+	content, link, err := readTarFile(archive(&tar.Header{Name: "hosts", Typeflag: tar.TypeReg, Mode: 0644}, "127.0.0.1 localhost\n"))
+	if err != nil || content != "127.0.0.1 localhost\n" || link != "" {
+		t.Errorf("regular file = (%q, %q, %v)", content, link, err)
+	}
+
+	content, link, err = readTarFile(archive(&tar.Header{Name: "resolv.conf", Typeflag: tar.TypeSymlink, Linkname: "../run/resolv.conf"}, ""))
+	if err != nil || content != "" || link != "../run/resolv.conf" {
+		t.Errorf("symlink = (%q, %q, %v)", content, link, err)
+	}
+
+	if _, _, err := readTarFile(archive(&tar.Header{Name: "etc", Typeflag: tar.TypeDir, Mode: 0755}, "")); err == nil {
+		t.Error("directory: want error")
+	}
+}
@@ -0,0 +1,146 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Event 表示一条 Docker 事件（容器、镜像、网络、卷），用于事件视图和导出
+type Event struct {
+	Time       time.Time         `json:"time"`
+	Type       string            `json:"type"`   // container、image、network、volume
+	Action     string            `json:"action"` // 如 start、die、pull、connect
+	ActorID    string            `json:"actor_id"`
+	ActorName  string            `json:"actor_name,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// EventFocus 事件视图聚焦的单个资源（容器或镜像），零值表示不聚焦
+type EventFocus struct {
+	Type string // container、image
+	ID   string
+	Name string
+}
+
+// IsZero 是否未聚焦
+func (f EventFocus) IsZero() bool {
+	return f.ID == "" && f.Name == ""
+}
+
+// Label 聚焦资源的显示名称
+func (f EventFocus) Label() string {
+	if f.Name != "" {
+		return f.Type + " " + f.Name
+	}
+	return f.Type + " " + shortEventID(f.ID)
+}
+
+// Matches 事件是否属于聚焦的资源：按 actor ID 或名称匹配；
+// 聚焦镜像时，由该镜像创建的容器的事件同样匹配
+func (f EventFocus) Matches(e Event) bool {
+	if f.IsZero() {
+		return true
+	}
+	if f.ID != "" && (e.ActorID == f.ID || strings.TrimPrefix(e.ActorID, "sha256:") == strings.TrimPrefix(f.ID, "sha256:")) {
+		return true
+	}
+	if f.Name != "" && e.ActorName == f.Name {
+		return true
+	}
+	if f.Type == "image" && e.Type == "container" {
+		img := e.Attributes["image"]
+		return img != "" && (img == f.ID || normalizeImageRef(img) == normalizeImageRef(f.Name))
+	}
+	return false
+}
+
+// normalizeImageRef 补全镜像引用的默认 latest 标签
+func normalizeImageRef(ref string) string {
+	if ref == "" || strings.Contains(ref, "@") {
+		return ref
+	}
+	if i := strings.LastIndex(ref, ":"); i <= strings.LastIndex(ref, "/") {
+		return ref + ":latest"
+	}
+	return ref
+}
+
+// shortEventID 截断 ID 用于显示（去掉 sha256: 前缀）
+func shortEventID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// eventActorName 事件中资源的名称：容器、网络、镜像使用 name 属性，卷的 actor ID 就是名称
+func eventActorName(msg events.Message) string {
+	if name := msg.Actor.Attributes["name"]; name != "" {
+		return name
+	}
+	if msg.Type == events.VolumeEventType {
+		return msg.Actor.ID
+	}
+	return ""
+}
+
+// StreamEvents 监听容器、镜像、网络和卷的全部事件，ctx 取消后关闭通道
+func (c *LocalClient) StreamEvents(ctx context.Context) (<-chan Event, <-chan error) {
+	eventChan := make(chan Event, 64)
+	errorChan := make(chan error, 1)
+
+	go func() {
+		defer close(eventChan)
+		defer close(errorChan)
+
+		if c == nil || c.cli == nil {
+			errorChan <- fmt.Errorf("Docker client not initialized")
+			return
+		}
+
+		filterArgs := filters.NewArgs()
+		for _, t := range []events.Type{events.ContainerEventType, events.ImageEventType, events.NetworkEventType, events.VolumeEventType} {
+			filterArgs.Add("type", string(t))
+		}
+		msgChan, errChan := c.cli.Events(ctx, events.ListOptions{Filters: filterArgs})
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errChan:
+				if err != nil && ctx.Err() == nil {
+					errorChan <- fmt.Errorf("failed to watch Docker events: %w", err)
+				}
+				return
+			case msg := <-msgChan:
+				// exec_start 等动作带有完整命令，只保留动作名称；health_status 保留健康状态
+				action := string(msg.Action)
+				if strings.HasPrefix(action, "exec_") {
+					action, _, _ = strings.Cut(action, ":")
+				}
+				event := Event{
+					Time:       time.Unix(0, msg.TimeNano),
+					Type:       string(msg.Type),
+					Action:     action,
+					ActorID:    msg.Actor.ID,
+					ActorName:  eventActorName(msg),
+					Attributes: msg.Actor.Attributes,
+				}
+				select {
+				case eventChan <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return eventChan, errorChan
+}
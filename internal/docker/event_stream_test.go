@@ -0,0 +1,47 @@
+package docker
+
+import "testing"
+
+// TestEventFocusMatches 测试事件视图按资源聚焦
+func TestEventFocusMatches(t *testing.T) {
+	web := EventFocus{Type: "container", ID: "abc123", Name: "web"}
+	nginx := EventFocus{Type: "image", ID: "sha256:def456", Name: "nginx:latest"}
+
+	tests := []struct {
+		name  string
+		focus EventFocus
+		event Event
+		want  bool
+	}{
+		{"no focus", EventFocus{}, Event{Type: "network", ActorID: "n1"}, true},
+		{"container by id", web, Event{Type: "container", ActorID: "abc123", ActorName: "web-old"}, true},
+		{"container by name", web, Event{Type: "container", ActorID: "zzz", ActorName: "web"}, true},
+		{"other container", web, Event{Type: "container", ActorID: "zzz", ActorName: "db"}, false},
+		{"image by id", nginx, Event{Type: "image", ActorID: "def456"}, true},
+		{"image by name", nginx, Event{Type: "image", ActorID: "nginx:latest", ActorName: "nginx:latest"}, true},
+		{"container of image, implicit tag", nginx, Event{Type: "container", ActorID: "c1", Attributes: map[string]string{"image": "nginx"}}, true},
+		{"container of other image", nginx, Event{Type: "container", ActorID: "c1", Attributes: map[string]string{"image": "nginx:1.27"}}, false},
+		{"container focus ignores image attr", web, Event{Type: "container", ActorID: "c1", Attributes: map[string]string{"image": "web"}}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.focus.Matches(tt.event); got != tt.want {
+			t.Errorf("%s: Matches() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestNormalizeImageRef 测试补全默认标签
+func TestNormalizeImageRef(t *testing.T) {
+	tests := map[string]string{
+		"nginx":                   "nginx:latest",
+		"nginx:1.27":              "nginx:1.27",
+		"localhost:5000/app":      "localhost:5000/app:latest",
+		"localhost:5000/app:v1":   "localhost:5000/app:v1",
+		"nginx@sha256:0123456789": "nginx@sha256:0123456789",
+	}
+	for in, want := range tests {
+		if got := normalizeImageRef(in); got != want {
+			t.Errorf("normalizeImageRef(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
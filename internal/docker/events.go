@@ -0,0 +1,120 @@
+package docker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// eventHubRetryDelay 事件流断开后重新订阅的间隔
+const eventHubRetryDelay = 2 * time.Second
+
+// EventHub 共享一个 Docker 事件订阅并分发给多个订阅者
+// 第一个订阅者出现时开始监听，最后一个取消订阅后停止；事件流断开时自动重连
+type EventHub struct {
+	client Client
+
+	mu          sync.Mutex
+	subscribers map[int]chan ContainerEvent
+	nextID      int
+	cancel      context.CancelFunc
+	closed      bool // Close 之后不再接受订阅
+}
+
+// NewEventHub 创建事件分发器
+func NewEventHub(client Client) *EventHub {
+	return &EventHub{
+		client:      client,
+		subscribers: make(map[int]chan ContainerEvent),
+	}
+}
+
+// Subscribe 订阅容器事件，返回事件通道和取消订阅函数
+// 订阅者处理过慢时新事件会被丢弃，不会阻塞其他订阅者；Close 之后返回已关闭的通道
+func (h *EventHub) Subscribe() (<-chan ContainerEvent, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		ch := make(chan ContainerEvent)
+		close(ch)
+		return ch, func() {}
+	}
+
+	id := h.nextID
+	h.nextID++
+	ch := make(chan ContainerEvent, 32)
+	h.subscribers[id] = ch
+
+	if h.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		h.cancel = cancel
+		go h.run(ctx)
+	}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() { h.unsubscribe(id) })
+	}
+}
+
+// unsubscribe 移除订阅者，没有订阅者时停止监听
+func (h *EventHub) unsubscribe(id int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if ch, ok := h.subscribers[id]; ok {
+		delete(h.subscribers, id)
+		close(ch)
+	}
+	if len(h.subscribers) == 0 && h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
+
+// Close 停止监听并关闭所有订阅者的通道（退出程序时调用），之后的取消订阅不做任何事，也不能再订阅
+func (h *EventHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for id, ch := range h.subscribers {
+		delete(h.subscribers, id)
+		close(ch)
+	}
+	if h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
+
+// run 监听 Docker 事件直到 ctx 取消
+func (h *EventHub) run(ctx context.Context) {
+	for {
+		eventChan, errChan := h.client.WatchEvents(ctx)
+		for event := range eventChan {
+			h.broadcast(event)
+		}
+		<-errChan
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventHubRetryDelay):
+		}
+	}
+}
+
+// broadcast 将事件发送给所有订阅者
+func (h *EventHub) broadcast(event ContainerEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeEventClient 只实现 WatchEvents 的客户端，把 events 中的事件转发给订阅
+type fakeEventClient struct {
+	Client
+	events chan ContainerEvent
+}
+
+func newFakeEventClient() *fakeEventClient {
+	return &fakeEventClient{events: make(chan ContainerEvent, 64)}
+}
+
+func (c *fakeEventClient) WatchEvents(ctx context.Context) (<-chan ContainerEvent, <-chan error) {
+	eventChan := make(chan ContainerEvent)
+	errorChan := make(chan error, 1)
+	go func() {
+		defer close(errorChan)
+		defer close(eventChan)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-c.events:
+				select {
+				case eventChan <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return eventChan, errorChan
+}
+
+// receive 在超时前从订阅中读取 n 个事件
+func receive(t *testing.T, ch <-chan ContainerEvent, n int) []ContainerEvent {
+	t.Helper()
+	var events []ContainerEvent
+	timeout := time.After(5 * time.Second)
+	for len(events) < n {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				t.Fatalf("channel closed after %d of %d events", len(events), n)
+			}
+			events = append(events, event)
+		case <-timeout:
+			t.Fatalf("timed out after %d of %d events", len(events), n)
+		}
+	}
+	return events
+}
+
+// TestEventHubFanOut 测试每个订阅者按顺序收到全部事件，取消订阅后通道关闭、停止监听
+func TestEventHubFanOut(t *testing.T) {
+	client := newFakeEventClient()
+	hub := NewEventHub(client)
+
+	var subs []<-chan ContainerEvent
+	var unsubs []func()
+	for i := 0; i < 3; i++ {
+		ch, unsub := hub.Subscribe()
+		subs = append(subs, ch)
+		unsubs = append(unsubs, unsub)
+	}
+	for i := 0; i < 10; i++ {
+		client.events <- ContainerEvent{Action: "start", ContainerID: fmt.Sprint(i)}
+	}
+	for s, ch := range subs {
+		for i, event := range receive(t, ch, 10) {
+			if event.ContainerID != fmt.Sprint(i) {
+				t.Fatalf("subscriber %d: event %d has ID %q", s, i, event.ContainerID)
+			}
+		}
+	}
+
+	for _, unsub := range unsubs {
+		unsub()
+		unsub() // 重复取消订阅不做任何事
+	}
+	for s, ch := range subs {
+		if _, ok := <-ch; ok {
+			t.Errorf("subscriber %d: channel still open after unsubscribe", s)
+		}
+	}
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if len(hub.subscribers) != 0 || hub.cancel != nil {
+		t.Errorf("hub still watching: %d subscribers, cancel set = %v", len(hub.subscribers), hub.cancel != nil)
+	}
+}
+
+// TestEventHubStress 并发订阅、取消订阅、广播和关闭（配合 go test -race 检查数据竞争和向已关闭通道发送）
+func TestEventHubStress(t *testing.T) {
+	client := newFakeEventClient()
+	hub := NewEventHub(client)
+
+	stop := make(chan struct{})
+	var producer sync.WaitGroup
+	producer.Add(1)
+	go func() {
+		defer producer.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			case client.events <- ContainerEvent{Action: "die", ContainerID: fmt.Sprint(i)}:
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 32; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				ch, unsub := hub.Subscribe()
+				// 读几个事件（或者没有事件时跳过），有的订阅者不读，模拟处理过慢
+				for n := 0; n < i%4; n++ {
+					select {
+					case <-ch:
+					case <-time.After(time.Millisecond):
+					}
+				}
+				unsub()
+				for range ch {
+					// 取消订阅后通道必须关闭
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 关闭时仍有订阅者：通道被关闭，之后的取消订阅不做任何事
+	ch, unsub := hub.Subscribe()
+	hub.Close()
+	for range ch {
+	}
+	unsub()
+
+	// 关闭后订阅得到已关闭的通道，也不会重新开始监听
+	ch, unsub = hub.Subscribe()
+	if _, ok := <-ch; ok {
+		t.Error("channel from Subscribe after Close is open")
+	}
+	unsub()
+	hub.mu.Lock()
+	if len(hub.subscribers) != 0 || hub.cancel != nil {
+		t.Errorf("hub watching after Close: %d subscribers, cancel set = %v", len(hub.subscribers), hub.cancel != nil)
+	}
+	hub.mu.Unlock()
+
+	close(stop)
+	producer.Wait()
+}
+
+// BenchmarkEventHubBroadcast 向 1、10、100 个订阅者分发事件
+func BenchmarkEventHubBroadcast(b *testing.B) {
+	for _, n := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("subscribers=%d", n), func(b *testing.B) {
+			hub := NewEventHub(newFakeEventClient())
+			var wg sync.WaitGroup
+			for i := 0; i < n; i++ {
+				ch, _ := hub.Subscribe()
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range ch {
+					}
+				}()
+			}
+			event := ContainerEvent{Action: "start", ContainerID: "abc123", ContainerName: "web"}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				hub.broadcast(event)
+			}
+			b.StopTimer()
+			hub.Close()
+			wg.Wait()
+		})
+	}
+}
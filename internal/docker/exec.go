@@ -1,37 +1,57 @@
 package docker
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/muesli/cancelreader"
+
+	"docktui/internal/audit"
 )
 
 // ExecConfig 执行命令的配置
 type ExecConfig struct {
-	Cmd          []string // 执行的命令
-	AttachStdin  bool     // 是否附加标准输入
-	AttachStdout bool     // 是否附加标准输出
-	AttachStderr bool     // 是否附加标准错误
-	Tty          bool     // 是否分配伪终端
-	Env          []string // 环境变量
-	WorkingDir   string   // 工作目录
+	Cmd          []string  // 执行的命令
+	AttachStdin  bool      // 是否附加标准输入
+	AttachStdout bool      // 是否附加标准输出
+	AttachStderr bool      // 是否附加标准错误
+	Tty          bool      // 是否分配伪终端
+	Env          []string  // 环境变量
+	WorkingDir   string    // 工作目录
+	Stdin        io.Reader // 标准输入内容（写完后关闭输入），为 nil 且 AttachStdin 时使用进程的标准输入
 }
 
 // ExecResult exec 执行结果
 type ExecResult struct {
-	ExitCode int    // 退出码
-	Error    string // 错误信息
+	ExitCode  int    // 退出码
+	Error     string // 错误信息
+	Stdout    string // 标准输出（TTY 模式下包含全部输出）
+	Stderr    string // 标准错误
+	Truncated bool   // 输出超过 maxExecOutput 被截断
 }
 
+// maxExecOutput 单个输出流最多保留的字节数，避免大量输出占用内存
+const maxExecOutput = 1 << 20
+
 // ExecCommand 在容器中执行命令（非交互式）
-// 返回命令的输出和错误
+// 返回命令的退出码以及分开捕获的标准输出和标准错误
 func (c *LocalClient) ExecCommand(ctx context.Context, containerID string, config ExecConfig) (*ExecResult, error) {
 	if c == nil || c.cli == nil {
 		return nil, fmt.Errorf("Docker client not initialized")
 	}
+	target := audit.ShortID(containerID) + " " + strings.Join(config.Cmd, " ")
+	if err := checkAction("container.exec", target); err != nil {
+		return nil, err
+	}
+	if config.Stdin != nil {
+		config.AttachStdin = true
+	}
 
 	// 创建 exec 实例
 	execConfig := container.ExecOptions{
@@ -58,20 +78,29 @@ func (c *LocalClient) ExecCommand(ctx context.Context, containerID string, confi
 	}
 	defer execAttachResp.Close()
 
-	// 如果需要标准输入，连接到 stdin
+	// 如果需要标准输入，连接到 stdin；给定内容时写完关闭输入，命令才能读到 EOF
 	if config.AttachStdin {
 		go func() {
+			if config.Stdin != nil {
+				io.Copy(execAttachResp.Conn, config.Stdin)
+				execAttachResp.CloseWrite()
+				return
+			}
 			io.Copy(execAttachResp.Conn, os.Stdin)
 		}()
 	}
 
-	// 读取输出
+	// 读取输出：TTY 模式下输出没有多路复用头，非 TTY 模式用 stdcopy 拆分 stdout/stderr
+	stdout := &cappedBuffer{limit: maxExecOutput}
+	stderr := &cappedBuffer{limit: maxExecOutput}
 	if config.Tty {
-		// TTY 模式：直接复制输出
-		io.Copy(os.Stdout, execAttachResp.Reader)
+		_, err = io.Copy(stdout, execAttachResp.Reader)
 	} else {
-		// 非 TTY 模式：直接复制（简化处理）
-		io.Copy(os.Stdout, execAttachResp.Reader)
+		_, err = stdcopy.StdCopy(stdout, stderr, execAttachResp.Reader)
+	}
+	audit.Record("container.exec", target, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exec output: %w", err)
 	}
 
 	// 获取执行结果
@@ -81,7 +110,10 @@ func (c *LocalClient) ExecCommand(ctx context.Context, containerID string, confi
 	}
 
 	result := &ExecResult{
-		ExitCode: inspectResp.ExitCode,
+		ExitCode:  inspectResp.ExitCode,
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
 	}
 
 	if inspectResp.ExitCode != 0 {
@@ -91,12 +123,34 @@ func (c *LocalClient) ExecCommand(ctx context.Context, containerID string, confi
 	return result, nil
 }
 
-// ExecShell 在容器中启动交互式 shell
-// 这是一个简化的实现，适用于基本的交互式场景
-func (c *LocalClient) ExecShell(ctx context.Context, containerID string, shell string) error {
+// cappedBuffer 最多保留 limit 字节的缓冲区，超出部分丢弃（继续读取以免阻塞命令）
+type cappedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// ExecShell 在容器中启动交互式 shell（找不到 docker CLI 时的 SDK 模式），
+// 运行期间终端处于原始模式，Windows 控制台开启 VT 输入输出
+func (c *LocalClient) ExecShell(ctx context.Context, containerID string, shell string, output io.Writer) error {
 	if c == nil || c.cli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
+	if err := checkAction("container.exec", audit.ShortID(containerID)); err != nil {
+		return err
+	}
+	audit.Record("container.exec", audit.ShortID(containerID), nil)
 
 	// 如果未指定 shell，自动检测可用的 shell
 	if shell == "" {
@@ -113,6 +167,7 @@ func (c *LocalClient) ExecShell(ctx context.Context, containerID string, shell s
 		AttachStdout: true,
 		AttachStderr: true,
 		Tty:          true,
+		ConsoleSize:  consoleSize(),
 		Cmd:          []string{shell},
 	}
 
@@ -124,61 +179,41 @@ func (c *LocalClient) ExecShell(ctx context.Context, containerID string, shell s
 
 	// 附加到 exec
 	execAttachResp, err := c.cli.ContainerExecAttach(ctx, execCreateResp.ID, container.ExecStartOptions{
-		Tty: true,
+		Tty:         true,
+		ConsoleSize: consoleSize(),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to attach to exec: %w", err)
 	}
 	defer execAttachResp.Close()
 
-	// 用于通知输出读取完成（shell 退出）
-	outputDone := make(chan struct{})
+	// 终端尺寸变化时同步调整容器内的 TTY，shell 退出后停止
+	resizeCtx, stopResize := context.WithCancel(ctx)
+	defer stopResize()
+	c.watchExecResize(resizeCtx, execCreateResp.ID)
 
-	// 从 stdin 复制到容器（在 goroutine 中）
-	go func() {
-		defer func() {
-			recover() // 忽略可能的 panic
-		}()
-		
-		buf := make([]byte, 1024)
-		for {
-			// 先检查是否应该退出
-			select {
-			case <-outputDone:
-				// shell 已退出，停止读取 stdin
-				return
-			default:
-			}
-			
-			// 设置读取超时，避免永久阻塞
-			// 注意：os.Stdin 不支持 SetReadDeadline，所以我们用非阻塞方式
-			n, err := os.Stdin.Read(buf)
-			if err != nil {
-				return
-			}
-			if n > 0 {
-				// 再次检查是否应该退出
-				select {
-				case <-outputDone:
-					return
-				default:
-				}
-				_, err = execAttachResp.Conn.Write(buf[:n])
-				if err != nil {
-					return
-				}
-			}
-		}
-	}()
+	// 原始模式下按键直接发给容器内的 TTY（方向键、Tab 补全、Ctrl+C 等），
+	// Windows 上还需要 VT 输入/输出模式，否则 PowerShell、Windows Terminal 中无法交互
+	restoreTerminal, err := rawTerminal()
+	if err != nil {
+		return fmt.Errorf("failed to set terminal to raw mode: %w", err)
+	}
+	defer restoreTerminal()
 
-	// 从容器复制到 stdout（阻塞直到 shell 退出）
-	io.Copy(os.Stdout, execAttachResp.Reader)
+	// 可取消的标准输入：shell 退出后取消阻塞中的读取，避免吞掉回到 DockTUI 后的第一次按键
+	stdin, err := cancelreader.NewReader(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read terminal input: %w", err)
+	}
+	defer stdin.Close()
+	go io.Copy(execAttachResp.Conn, stdin)
 
-	// shell 已退出，关闭 outputDone 通知 stdin goroutine
-	close(outputDone)
-	
-	// 关闭连接，这会导致 stdin goroutine 的 Write 失败并退出
-	execAttachResp.Close()
+	// 从容器复制到 stdout（阻塞直到 shell 退出）
+	if output == nil {
+		output = os.Stdout
+	}
+	io.Copy(output, execAttachResp.Reader)
+	stdin.Cancel()
 
 	return nil
 }
@@ -240,6 +275,10 @@ func (c *LocalClient) ExecShellInteractive(ctx context.Context, containerID stri
 	if c == nil || c.cli == nil {
 		return fmt.Errorf("Docker client not initialized")
 	}
+	if err := checkAction("container.exec", audit.ShortID(containerID)); err != nil {
+		return err
+	}
+	audit.Record("container.exec", audit.ShortID(containerID), nil)
 
 	// 如果未指定 shell，自动检测可用的 shell
 	if shell == "" {
@@ -256,6 +295,7 @@ func (c *LocalClient) ExecShellInteractive(ctx context.Context, containerID stri
 		AttachStdout: true,
 		AttachStderr: true,
 		Tty:          true,
+		ConsoleSize:  consoleSize(),
 		Cmd:          []string{shell},
 	}
 
@@ -267,13 +307,19 @@ func (c *LocalClient) ExecShellInteractive(ctx context.Context, containerID stri
 
 	// 附加到 exec
 	execAttachResp, err := c.cli.ContainerExecAttach(ctx, execCreateResp.ID, container.ExecStartOptions{
-		Tty: true,
+		Tty:         true,
+		ConsoleSize: consoleSize(),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to attach to exec: %w", err)
 	}
 	defer execAttachResp.Close()
 
+	// 终端尺寸变化时同步调整容器内的 TTY，shell 退出后停止
+	resizeCtx, stopResize := context.WithCancel(ctx)
+	defer stopResize()
+	c.watchExecResize(resizeCtx, execCreateResp.ID)
+
 	// 设置终端为原始模式（如果是 TTY）
 	// 注意：这里简化处理，实际使用时应该在 TUI 层面处理终端模式
 	// 因为 Bubble Tea 已经处理了终端的释放和恢复
@@ -313,18 +359,15 @@ func (c *LocalClient) ExecShellInteractive(ctx context.Context, containerID stri
 // GetAvailableShells 获取容器中所有可用的 shell 列表
 // 用于调试和显示给用户
 func (c *LocalClient) GetAvailableShells(ctx context.Context, containerID string) []string {
-	shells := []string{
-		"/bin/bash",
-		"/bin/sh",
-		"/bin/ash",
-		"/bin/zsh",
-		"/bin/ksh",
+	tools, err := c.ProbeTools(ctx, containerID)
+	if err != nil {
+		return nil
 	}
 
 	available := []string{}
-	for _, shell := range shells {
-		if c.checkShellExists(ctx, containerID, shell) {
-			available = append(available, shell)
+	for _, shell := range []string{"bash", "sh", "ash", "zsh", "ksh"} {
+		if p := tools.Path(shell); p != "" {
+			available = append(available, p)
 		}
 	}
 
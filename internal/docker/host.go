@@ -0,0 +1,141 @@
+package docker
+
+import (
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	sdk "github.com/docker/docker/client"
+)
+
+// RemoteHost 当 DOCKER_HOST 指向远程守护进程（tcp/ssh）时返回其主机名，本地连接返回空字符串
+// 远程守护进程下，容器的 bind 挂载路径位于远程主机而非本机
+func RemoteHost() string {
+	u, err := url.Parse(os.Getenv("DOCKER_HOST"))
+	if err != nil {
+		return ""
+	}
+	switch u.Scheme {
+	case "tcp", "ssh", "http", "https":
+		switch h := u.Hostname(); h {
+		case "", "localhost", "127.0.0.1", "::1":
+			return ""
+		default:
+			return h
+		}
+	}
+	return ""
+}
+
+// DockerHost 返回实际连接的守护进程地址：DOCKER_HOST、自动探测到的命名管道或 SDK 默认值
+func DockerHost() string {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host
+	}
+	if host := detectDefaultHost(); host != "" {
+		return host
+	}
+	return sdk.DefaultDockerHost
+}
+
+// wslMountPrefixes 守护进程报告的 Windows 盘符路径前缀：WSL 自动挂载和 Docker Desktop 的宿主机挂载
+var wslMountPrefixes = []string{"/mnt/", "/run/desktop/mnt/host/", "/host_mnt/"}
+
+// WSLToWindowsPath 将 /mnt/c/Users/me 形式（以及 Docker Desktop 的 /run/desktop/mnt/host/c/...）转换为 C:\Users\me，
+// 不是这类路径时原样返回
+func WSLToWindowsPath(p string) string {
+	for _, prefix := range wslMountPrefixes {
+		rest, ok := strings.CutPrefix(p, prefix)
+		if !ok || rest == "" {
+			continue
+		}
+		drive, tail, _ := strings.Cut(rest, "/")
+		if len(drive) != 1 || !isDriveLetter(drive[0]) {
+			continue
+		}
+		return strings.ToUpper(drive) + `:\` + strings.ReplaceAll(tail, "/", `\`)
+	}
+	return p
+}
+
+// WindowsToWSLPath 将 C:\Users\me 或 C:/Users/me 转换为 /mnt/c/Users/me，不是盘符路径时原样返回
+func WindowsToWSLPath(p string) string {
+	if len(p) < 2 || p[1] != ':' || !isDriveLetter(p[0]) {
+		return p
+	}
+	tail := strings.TrimLeft(strings.ReplaceAll(p[2:], `\`, "/"), "/")
+	return "/mnt/" + strings.ToLower(p[:1]) + "/" + tail
+}
+
+// isDriveLetter 是否是盘符字母
+func isDriveLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// runningInWSL 当前进程是否运行在 WSL 中
+func runningInWSL() bool {
+	return os.Getenv("WSL_DISTRO_NAME") != ""
+}
+
+// LocalHostPath 将守护进程报告的 bind 挂载源路径转换为本机可用的形式：
+// Windows 上转换为盘符路径，WSL 中把盘符路径转换为 /mnt/<盘符>/...，其余情况原样返回
+func LocalHostPath(p string) string {
+	switch {
+	case runtime.GOOS == "windows":
+		return WSLToWindowsPath(p)
+	case runningInWSL():
+		if converted := WindowsToWSLPath(p); converted != p {
+			return converted
+		}
+		// Docker Desktop 的宿主机挂载路径在 WSL 中对应 /mnt/<盘符>
+		if win := WSLToWindowsPath(p); win != p {
+			return WindowsToWSLPath(win)
+		}
+	}
+	return p
+}
+
+// FindDockerCLI 查找 docker 可执行文件：优先使用配置的路径，其次 PATH，最后是各平台 Docker Desktop 的默认安装位置，找不到时返回空
+func FindDockerCLI(configured string) string {
+	if configured != "" {
+		if path, err := exec.LookPath(configured); err == nil {
+			return path
+		}
+		return ""
+	}
+	if path, err := exec.LookPath("docker"); err == nil {
+		return path
+	}
+	for _, p := range dockerCLICandidates() {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+// dockerCLICandidates 不在 PATH 中时尝试的安装位置
+func dockerCLICandidates() []string {
+	var candidates []string
+	switch runtime.GOOS {
+	case "windows":
+		for _, env := range []string{"ProgramFiles", "ProgramW6432", "LOCALAPPDATA"} {
+			if dir := os.Getenv(env); dir != "" {
+				candidates = append(candidates,
+					filepath.Join(dir, "Docker", "Docker", "resources", "bin", "docker.exe"),
+					filepath.Join(dir, "Docker", "Docker", "docker.exe"))
+			}
+		}
+	case "darwin":
+		candidates = append(candidates, "/Applications/Docker.app/Contents/Resources/bin/docker", "/usr/local/bin/docker", "/opt/homebrew/bin/docker")
+		if home, err := os.UserHomeDir(); err == nil {
+			candidates = append(candidates, filepath.Join(home, ".docker", "bin", "docker"))
+		}
+	default:
+		candidates = append(candidates, "/usr/bin/docker", "/usr/local/bin/docker")
+	}
+	return candidates
+}
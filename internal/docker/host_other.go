@@ -0,0 +1,8 @@
+//go:build !windows
+
+package docker
+
+// detectDefaultHost 非 Windows 平台使用 SDK 默认的 unix socket
+func detectDefaultHost() string {
+	return ""
+}
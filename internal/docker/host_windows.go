@@ -0,0 +1,20 @@
+package docker
+
+import "os"
+
+// namedPipes Docker Desktop / Docker Engine 在 Windows 上常见的命名管道，按优先级排列
+var namedPipes = []string{
+	`\\.\pipe\docker_engine`,
+	`\\.\pipe\dockerDesktopLinuxEngine`,
+	`\\.\pipe\dockerDesktopWindowsEngine`,
+}
+
+// detectDefaultHost 未设置 DOCKER_HOST 时返回第一个存在的命名管道，都不存在时返回空（使用 SDK 默认值）
+func detectDefaultHost() string {
+	for _, pipe := range namedPipes {
+		if _, err := os.Stat(pipe); err == nil {
+			return "npipe:////./pipe/" + pipe[len(`\\.\pipe\`):]
+		}
+	}
+	return ""
+}
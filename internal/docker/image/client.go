@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
@@ -17,6 +19,10 @@ import (
 // Client 镜像操作客户端
 type Client struct {
 	cli *sdk.Client
+
+	platformMu     sync.Mutex
+	platforms      map[string]Platform // 镜像 ID -> 平台
+	daemonPlatform *Platform
 }
 
 // NewClient 创建镜像客户端
@@ -58,9 +64,20 @@ func (c *Client) List(ctx context.Context, showAll bool) ([]Image, error) {
 		imageToContainers[cont.ImageID] = append(imageToContainers[cont.ImageID], cont.ID)
 	}
 
+	// 镜像平台，与守护进程不同时标记为仿真运行
+	ids := make([]string, len(images))
+	for i, img := range images {
+		ids[i] = img.ID
+	}
+	platforms := c.Platforms(ctx, ids)
+	daemon := c.DaemonPlatform(ctx)
+
 	// 转换为内部数据结构
 	result := make([]Image, 0, len(images))
 	for _, img := range images {
+		platform := platforms[img.ID]
+		emulated := platform.Emulated(daemon)
+
 		// 短 ID（12位）
 		shortID := img.ID
 		if strings.HasPrefix(shortID, "sha256:") {
@@ -107,6 +124,8 @@ func (c *Client) List(ctx context.Context, showAll bool) ([]Image, error) {
 					InUse:      inUse,
 					Dangling:   dangling,
 					Containers: containerIDs,
+					Platform:   platform,
+					Emulated:   emulated,
 				})
 			}
 		} else {
@@ -123,6 +142,8 @@ func (c *Client) List(ctx context.Context, showAll bool) ([]Image, error) {
 				InUse:      inUse,
 				Dangling:   true,
 				Containers: containerIDs,
+				Platform:   platform,
+				Emulated:   emulated,
 			})
 		}
 	}
@@ -130,6 +151,36 @@ func (c *Client) List(ctx context.Context, showAll bool) ([]Image, error) {
 	return result, nil
 }
 
+// UsedBy 获取由镜像创建的容器（包括已停止的），运行中的在前，其余按名称排序
+func (c *Client) UsedBy(ctx context.Context, imageID string) ([]ContainerRef, error) {
+	if c == nil || c.cli == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container list: %w", err)
+	}
+
+	refs := make([]ContainerRef, 0)
+	for _, cont := range containers {
+		if cont.ImageID != imageID {
+			continue
+		}
+		name := ""
+		if len(cont.Names) > 0 {
+			name = strings.TrimPrefix(cont.Names[0], "/")
+		}
+		refs = append(refs, ContainerRef{ID: cont.ID, Name: name, State: string(cont.State)})
+	}
+	sort.SliceStable(refs, func(i, j int) bool {
+		if (refs[i].State == "running") != (refs[j].State == "running") {
+			return refs[i].State == "running"
+		}
+		return refs[i].Name < refs[j].Name
+	})
+	return refs, nil
+}
+
 // GetDetails 获取指定镜像的详细信息
 func (c *Client) GetDetails(ctx context.Context, imageID string) (*Details, error) {
 	if c == nil || c.cli == nil {
@@ -172,28 +223,9 @@ func (c *Client) GetDetails(ctx context.Context, imageID string) (*Details, erro
 	}
 
 	// 获取使用此镜像的容器
-	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true})
+	containerRefs, err := c.UsedBy(ctx, inspectResp.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get container list: %w", err)
-	}
-
-	containerRefs := make([]ContainerRef, 0)
-	for _, cont := range containers {
-		if cont.ImageID == inspectResp.ID {
-			containerName := ""
-			if len(cont.Names) > 0 {
-				containerName = cont.Names[0]
-				if len(containerName) > 0 && containerName[0] == '/' {
-					containerName = containerName[1:]
-				}
-			}
-
-			containerRefs = append(containerRefs, ContainerRef{
-				ID:    cont.ID,
-				Name:  containerName,
-				State: string(cont.State),
-			})
-		}
+		return nil, err
 	}
 
 	// 提取环境变量、命令、入口点等
@@ -263,6 +295,8 @@ func (c *Client) GetDetails(ctx context.Context, imageID string) (*Details, erro
 		Size:         inspectResp.Size,
 		Created:      created,
 		Digest:       digest,
+		RepoTags:     inspectResp.RepoTags,
+		RepoDigests:  inspectResp.RepoDigests,
 		Labels:       labels,
 		Architecture: inspectResp.Architecture,
 		OS:           inspectResp.Os,
@@ -281,6 +315,18 @@ func (c *Client) GetDetails(ctx context.Context, imageID string) (*Details, erro
 	}, nil
 }
 
+// RemoteDigest 查询仓库中镜像引用当前指向的摘要（多架构镜像为 index 摘要）
+func (c *Client) RemoteDigest(ctx context.Context, imageRef string, registryAuth string) (string, error) {
+	if c == nil || c.cli == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+	resp, err := c.cli.DistributionInspect(ctx, imageRef, registryAuth)
+	if err != nil {
+		return "", fmt.Errorf("failed to query registry: %w", err)
+	}
+	return resp.Descriptor.Digest.String(), nil
+}
+
 // InspectRaw 获取镜像的原始 JSON 数据
 func (c *Client) InspectRaw(ctx context.Context, imageID string) (string, error) {
 	if c == nil || c.cli == nil {
@@ -321,15 +367,17 @@ func (c *Client) Remove(ctx context.Context, imageID string, force bool, prune b
 	return nil
 }
 
-// Prune 清理悬垂镜像
+// Prune 按选项清理镜像
 // 返回删除的镜像数量和释放的空间（字节）
-func (c *Client) Prune(ctx context.Context) (int, int64, error) {
+func (c *Client) Prune(ctx context.Context, opts PruneOptions) (int, int64, error) {
 	if c == nil || c.cli == nil {
 		return 0, 0, fmt.Errorf("Docker client not initialized")
 	}
 
-	filterArgs := filters.NewArgs()
-	filterArgs.Add("dangling", "true")
+	filterArgs, err := pruneFilters(opts)
+	if err != nil {
+		return 0, 0, err
+	}
 
 	report, err := c.cli.ImagesPrune(ctx, filterArgs)
 	if err != nil {
@@ -339,6 +387,124 @@ func (c *Client) Prune(ctx context.Context) (int, int64, error) {
 	return len(report.ImagesDeleted), int64(report.SpaceReclaimed), nil
 }
 
+// PruneCandidates 预览清理会删除的镜像（dry-run）
+// Docker API 不支持 dry-run，因此在客户端按与 ImagesPrune 相同的规则筛选
+// 每个镜像 ID 只返回一项，多个标签时取第一个
+func (c *Client) PruneCandidates(ctx context.Context, opts PruneOptions) ([]Image, error) {
+	if c == nil || c.cli == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+
+	var cutoff time.Time
+	if opts.Until != "" {
+		d, err := time.ParseDuration(opts.Until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid until duration %q: %w", opts.Until, err)
+		}
+		cutoff = time.Now().Add(-d)
+	}
+
+	images, err := c.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	result := make([]Image, 0)
+	for _, img := range images {
+		if seen[img.ID] {
+			continue
+		}
+		seen[img.ID] = true
+
+		if img.InUse {
+			continue
+		}
+		if !opts.All && !img.Dangling {
+			continue
+		}
+		if !cutoff.IsZero() && !img.Created.Before(cutoff) {
+			continue
+		}
+		if !MatchLabels(img.Labels, opts.Labels) {
+			continue
+		}
+		result = append(result, img)
+	}
+
+	// 共享层大小需要守护进程额外计算，只在预览时查询
+	// 删除镜像只会释放未被其他镜像引用的层，即 Size - SharedSize
+	shared := make(map[string]int64)
+	if summaries, err := c.cli.ImageList(ctx, dockerimage.ListOptions{All: true, SharedSize: true}); err == nil {
+		for _, s := range summaries {
+			shared[s.ID] = s.SharedSize
+		}
+	}
+	for i := range result {
+		sharedSize, ok := shared[result[i].ID]
+		if !ok {
+			sharedSize = -1
+		}
+		result[i].SharedSize = sharedSize
+	}
+
+	return result, nil
+}
+
+// pruneFilters 将清理选项转换为 Docker 过滤器
+func pruneFilters(opts PruneOptions) (filters.Args, error) {
+	filterArgs := filters.NewArgs()
+	if opts.All {
+		filterArgs.Add("dangling", "false")
+	} else {
+		filterArgs.Add("dangling", "true")
+	}
+
+	if opts.Until != "" {
+		if _, err := time.ParseDuration(opts.Until); err != nil {
+			return filterArgs, fmt.Errorf("invalid until duration %q: %w", opts.Until, err)
+		}
+		filterArgs.Add("until", opts.Until)
+	}
+
+	for _, sel := range opts.Labels {
+		sel = strings.TrimSpace(sel)
+		if sel == "" {
+			continue
+		}
+		if strings.HasPrefix(sel, "!") {
+			filterArgs.Add("label!", strings.TrimPrefix(sel, "!"))
+		} else {
+			filterArgs.Add("label", sel)
+		}
+	}
+
+	return filterArgs, nil
+}
+
+// MatchLabels 判断标签是否满足所有选择器
+// 选择器格式：key、key=value，前缀 ! 表示排除
+func MatchLabels(labels map[string]string, selectors []string) bool {
+	for _, sel := range selectors {
+		sel = strings.TrimSpace(sel)
+		if sel == "" {
+			continue
+		}
+
+		negate := strings.HasPrefix(sel, "!")
+		sel = strings.TrimPrefix(sel, "!")
+
+		key, value, hasValue := strings.Cut(sel, "=")
+		actual, exists := labels[key]
+		matched := exists && (!hasValue || actual == value)
+
+		if matched == negate {
+			return false
+		}
+	}
+	return true
+}
+
 // Tag 给镜像打标签
 func (c *Client) Tag(ctx context.Context, imageID string, repository string, tag string) error {
 	if c == nil || c.cli == nil {
@@ -419,14 +585,14 @@ func (c *Client) Pull(ctx context.Context, imageRef string) (io.ReadCloser, erro
 	return reader, nil
 }
 
-// Push 推送镜像到 registry
+// Push 推送镜像到 registry，registryAuth 为编码后的登录凭据（空表示匿名）
 // 返回 io.ReadCloser 用于读取推送进度，调用方负责关闭
-func (c *Client) Push(ctx context.Context, imageRef string) (io.ReadCloser, error) {
+func (c *Client) Push(ctx context.Context, imageRef string, registryAuth string) (io.ReadCloser, error) {
 	if c == nil || c.cli == nil {
 		return nil, fmt.Errorf("Docker client not initialized")
 	}
 
-	reader, err := c.cli.ImagePush(ctx, imageRef, dockerimage.PushOptions{})
+	reader, err := c.cli.ImagePush(ctx, imageRef, dockerimage.PushOptions{RegistryAuth: registryAuth})
 	if err != nil {
 		return nil, fmt.Errorf("failed to push image: %w", err)
 	}
@@ -0,0 +1,105 @@
+package image
+
+import (
+	"context"
+	"strings"
+)
+
+// Platform 镜像或守护进程的平台，如 linux/arm64/v8
+type Platform struct {
+	OS           string
+	Architecture string
+	Variant      string
+}
+
+// String 返回 os/arch[/variant]，未知时返回空字符串
+func (p Platform) String() string {
+	if p.Architecture == "" {
+		return ""
+	}
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	return s
+}
+
+// archAliases uname 风格的架构名 -> Go/OCI 的名称（Info 接口返回前者，镜像配置使用后者）
+var archAliases = map[string]string{
+	"x86_64":  "amd64",
+	"x86-64":  "amd64",
+	"aarch64": "arm64",
+	"armhf":   "arm",
+	"armv7l":  "arm",
+	"i386":    "386",
+	"i686":    "386",
+}
+
+func normalizeArch(arch string) string {
+	arch = strings.ToLower(arch)
+	if alias, ok := archAliases[arch]; ok {
+		return alias
+	}
+	return arch
+}
+
+// Emulated 镜像平台与守护进程不同，容器需要通过仿真（如 QEMU/Rosetta）运行；任一方未知时返回 false
+func (p Platform) Emulated(daemon Platform) bool {
+	if p.Architecture == "" || daemon.Architecture == "" {
+		return false
+	}
+	if p.OS != "" && daemon.OS != "" && !strings.EqualFold(p.OS, daemon.OS) {
+		return true
+	}
+	return normalizeArch(p.Architecture) != normalizeArch(daemon.Architecture)
+}
+
+// DaemonPlatform 返回守护进程的平台（首次查询后缓存）
+func (c *Client) DaemonPlatform(ctx context.Context) Platform {
+	c.platformMu.Lock()
+	defer c.platformMu.Unlock()
+	if c.daemonPlatform != nil {
+		return *c.daemonPlatform
+	}
+	version, err := c.cli.ServerVersion(ctx)
+	if err != nil {
+		return Platform{}
+	}
+	c.daemonPlatform = &Platform{OS: version.Os, Architecture: version.Arch}
+	return *c.daemonPlatform
+}
+
+// Platforms 返回镜像 ID 对应的平台。镜像 ID 是内容摘要，平台不会改变，
+// 所以每个镜像只 inspect 一次；查询失败的镜像不在结果中
+func (c *Client) Platforms(ctx context.Context, imageIDs []string) map[string]Platform {
+	result := make(map[string]Platform, len(imageIDs))
+	var missing []string
+	c.platformMu.Lock()
+	if c.platforms == nil {
+		c.platforms = make(map[string]Platform)
+	}
+	for _, id := range imageIDs {
+		if p, ok := c.platforms[id]; ok {
+			result[id] = p
+		} else if id != "" {
+			missing = append(missing, id)
+		}
+	}
+	c.platformMu.Unlock()
+
+	for _, id := range missing {
+		if _, done := result[id]; done {
+			continue
+		}
+		resp, _, err := c.cli.ImageInspectWithRaw(ctx, id)
+		if err != nil {
+			continue
+		}
+		p := Platform{OS: resp.Os, Architecture: resp.Architecture, Variant: resp.Variant}
+		result[id] = p
+		c.platformMu.Lock()
+		c.platforms[id] = p
+		c.platformMu.Unlock()
+	}
+	return result
+}
@@ -1,6 +1,9 @@
 package image
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Image 表示镜像的基本信息（用于列表视图）
 type Image struct {
@@ -12,6 +15,9 @@ type Image struct {
 	Created    time.Time         // 创建时间
 	Digest     string            // 摘要
 	Labels     map[string]string // 标签
+	SharedSize int64             // 与其他镜像共享的层大小（字节），仅清理预览时填充，-1 表示未知
+	Platform   Platform          // 镜像平台（os/arch），未知时为空
+	Emulated   bool              // 平台与守护进程不同，容器需要仿真运行
 
 	// 运行时状态
 	InUse      bool     // 是否被容器使用
@@ -34,6 +40,8 @@ type Details struct {
 	Size          int64             // 大小（字节）
 	Created       time.Time         // 创建时间
 	Digest        string            // 摘要
+	RepoTags      []string          // 所有标签（repo:tag）
+	RepoDigests   []string          // 所有仓库摘要（repo@sha256:...），从仓库拉取或推送后才有
 	Labels        map[string]string // 标签
 	Architecture  string            // 架构
 	OS            string            // 操作系统
@@ -59,3 +67,82 @@ type History struct {
 	Size      int64     // 大小（字节）
 	Comment   string    // 注释
 }
+
+// PruneOptions 镜像清理选项
+type PruneOptions struct {
+	All    bool     // true 清理所有未被容器使用的镜像，false 仅清理悬垂镜像
+	Until  string   // 仅清理在此时间之前创建的镜像（Go duration，如 24h、168h）
+	Labels []string // 标签选择器：key、key=value，前缀 ! 表示排除
+}
+
+// ProvenanceField 镜像来源信息的一项（来自 OCI 注解或 label-schema 标签）
+type ProvenanceField struct {
+	Name  string
+	Key   string
+	Value string
+}
+
+// provenanceLabels 描述镜像来源的标签，按显示顺序排列，同名字段只显示第一个存在的
+var provenanceLabels = []struct{ name, key string }{
+	{"Source", "org.opencontainers.image.source"},
+	{"Source", "org.label-schema.vcs-url"},
+	{"Revision", "org.opencontainers.image.revision"},
+	{"Revision", "org.label-schema.vcs-ref"},
+	{"Version", "org.opencontainers.image.version"},
+	{"Version", "org.label-schema.version"},
+	{"Built", "org.opencontainers.image.created"},
+	{"Built", "org.label-schema.build-date"},
+	{"Ref name", "org.opencontainers.image.ref.name"},
+	{"Base image", "org.opencontainers.image.base.name"},
+	{"Base digest", "org.opencontainers.image.base.digest"},
+	{"Title", "org.opencontainers.image.title"},
+	{"Vendor", "org.opencontainers.image.vendor"},
+	{"Authors", "org.opencontainers.image.authors"},
+	{"URL", "org.opencontainers.image.url"},
+	{"Docs", "org.opencontainers.image.documentation"},
+	{"Licenses", "org.opencontainers.image.licenses"},
+}
+
+// Provenance 返回镜像标签中的来源信息
+func (d *Details) Provenance() []ProvenanceField {
+	var fields []ProvenanceField
+	seen := make(map[string]bool)
+	for _, l := range provenanceLabels {
+		value := d.Labels[l.key]
+		if value == "" || seen[l.name] {
+			continue
+		}
+		seen[l.name] = true
+		fields = append(fields, ProvenanceField{Name: l.name, Key: l.key, Value: value})
+	}
+	return fields
+}
+
+// Builder 根据构建历史推断镜像的构建方式
+func (d *Details) Builder() string {
+	for _, h := range d.History {
+		if strings.HasPrefix(h.Comment, "buildkit.dockerfile") {
+			return "BuildKit (Dockerfile)"
+		}
+	}
+	if len(d.History) == 0 {
+		return "unknown"
+	}
+	if strings.Contains(d.History[0].CreatedBy, "#(nop)") {
+		return "Dockerfile (classic builder)"
+	}
+	if d.History[0].Comment != "" {
+		return d.History[0].Comment
+	}
+	return "unknown"
+}
+
+// MatchesDigest 本地镜像的仓库摘要中是否包含 digest（仓库中标签当前指向的摘要）
+func (d *Details) MatchesDigest(digest string) bool {
+	for _, rd := range d.RepoDigests {
+		if _, local, ok := strings.Cut(rd, "@"); ok && local == digest {
+			return true
+		}
+	}
+	return false
+}
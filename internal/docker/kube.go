@@ -0,0 +1,70 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KubeNode 本地 Kubernetes 工具（kind、k3d、minikube）创建的节点容器
+type KubeNode struct {
+	Tool    string // kind, k3d, minikube
+	Cluster string // 集群名称（minikube 为 profile）
+	Role    string // control-plane, worker, server, agent, loadbalancer 等，可能为空
+}
+
+// KubeNode 根据标签和镜像识别 kind/k3d/minikube 节点容器，不是时返回 nil
+func (c Container) KubeNode() *KubeNode {
+	if name := c.Labels["io.x-k8s.kind.cluster"]; name != "" {
+		return &KubeNode{Tool: "kind", Cluster: name, Role: c.Labels["io.x-k8s.kind.role"]}
+	}
+	if name := c.Labels["k3d.cluster"]; name != "" {
+		return &KubeNode{Tool: "k3d", Cluster: name, Role: c.Labels["k3d.role"]}
+	}
+	if name := c.Labels["name.minikube.sigs.k8s.io"]; name != "" {
+		return &KubeNode{Tool: "minikube", Cluster: name, Role: "control-plane"}
+	}
+
+	// 没有标签（比如旧版本创建的）时按镜像识别，集群名称无法确定时用容器名
+	switch image := c.Image; {
+	case strings.Contains(image, "kindest/node"):
+		return &KubeNode{Tool: "kind", Cluster: strings.TrimSuffix(strings.TrimSuffix(c.Name, "-control-plane"), "-worker")}
+	case strings.Contains(image, "k8s-minikube/kicbase"):
+		return &KubeNode{Tool: "minikube", Cluster: c.Name}
+	}
+	return nil
+}
+
+// Context kubectl 中该集群的上下文名称
+func (n KubeNode) Context() string {
+	switch n.Tool {
+	case "kind":
+		return "kind-" + n.Cluster
+	case "k3d":
+		return "k3d-" + n.Cluster
+	}
+	return n.Cluster
+}
+
+// KubeconfigCommand 输出或写入该集群 kubeconfig 的命令
+func (n KubeNode) KubeconfigCommand() string {
+	switch n.Tool {
+	case "kind":
+		return "kind get kubeconfig --name " + n.Cluster
+	case "k3d":
+		return "k3d kubeconfig get " + n.Cluster
+	}
+	return "minikube -p " + n.Cluster + " update-context"
+}
+
+// KubeconfigPath 本机 kubectl 使用的 kubeconfig 路径（KUBECONFIG 或 ~/.kube/config）
+func KubeconfigPath() string {
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		return env
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "~/.kube/config"
+	}
+	return filepath.Join(home, ".kube", "config")
+}
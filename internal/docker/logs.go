@@ -2,7 +2,11 @@ package docker
 
 import (
 	"bufio"
+	"fmt"
 	"io"
+	"sort"
+	"strings"
+	"time"
 )
 
 // StdType 标准流类型
@@ -110,3 +114,103 @@ func StreamLogs(reader io.Reader, lineChan chan<- string, errChan chan<- error)
 		errChan <- err
 	}
 }
+
+// LogTimeLayout 日志时间戳的统一显示格式（本地时区，精确到毫秒）
+const LogTimeLayout = "2006-01-02 15:04:05.000"
+
+// SplitLogTimestamp 拆分 Timestamps 选项下每行开头的 RFC3339Nano 时间戳，
+// 行首不是可解析的时间戳时原样返回，ok 为 false
+func SplitLogTimestamp(line string) (t time.Time, rest string, ok bool) {
+	ts, rest, _ := strings.Cut(line, " ")
+	if len(ts) < len("2006-01-02T15:04:05Z") || ts[0] < '0' || ts[0] > '9' {
+		return time.Time{}, line, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return t, rest, true
+}
+
+// FormatLogTime 按 LogTimeLayout 显示日志时间戳
+func FormatLogTime(t time.Time) string {
+	return t.Local().Format(LogTimeLayout)
+}
+
+// TimedLogLine 带时间戳的一行日志，Source 为来源（合并多个容器的日志时是容器名称）
+type TimedLogLine struct {
+	Time   time.Time // 零值表示没有时间戳
+	Source string
+	Text   string
+}
+
+// SortLogLines 按时间戳稳定排序，用于合并 stdout/stderr 或多个容器的日志；
+// 没有时间戳的行沿用同一来源上一行的时间，保持跟在它后面
+func SortLogLines(lines []TimedLogLine) {
+	last := make(map[string]time.Time)
+	for i := range lines {
+		if lines[i].Time.IsZero() {
+			lines[i].Time = last[lines[i].Source]
+			continue
+		}
+		last[lines[i].Source] = lines[i].Time
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].Time.Before(lines[j].Time) })
+}
+
+// logTimeLayouts ParseLogTime 接受的绝对时间格式（本地时区）
+var logTimeLayouts = []struct {
+	layout string
+	date   bool // 包含日期，否则为今天（晚于当前时间时为昨天）
+	year   bool // 包含年份，否则为今年
+}{
+	{"2006-01-02 15:04:05", true, true},
+	{"2006-01-02 15:04", true, true},
+	{"2006-01-02T15:04:05", true, true},
+	{"01-02 15:04:05", true, false},
+	{"01-02 15:04", true, false},
+	{"15:04:05", false, false},
+	{"15:04", false, false},
+}
+
+// ParseLogTime 解析"跳转到时间"的输入：本地时间 "15:04"、"15:04:05"、"01-02 15:04"、"2006-01-02 15:04:05"，
+// RFC3339（如告警中的 UTC 时间），或相对时间 "10m"、"-1h30m"、"10m ago"
+func ParseLogTime(input string, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return time.Time{}, fmt.Errorf("empty time")
+	}
+	if t, err := time.Parse(time.RFC3339Nano, input); err == nil {
+		return t, nil
+	}
+	rel := strings.TrimSpace(strings.TrimSuffix(input, "ago"))
+	if d, err := time.ParseDuration(strings.TrimPrefix(rel, "-")); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	local := now.Location()
+	for _, l := range logTimeLayouts {
+		t, err := time.ParseInLocation(l.layout, input, local)
+		if err != nil {
+			continue
+		}
+		switch {
+		case !l.date:
+			t = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, local)
+			if t.After(now) {
+				t = t.AddDate(0, 0, -1)
+			}
+		case !l.year:
+			t = time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, local)
+			if t.After(now) {
+				t = t.AddDate(-1, 0, 0)
+			}
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use 15:04, 01-02 15:04:05, RFC3339 or 10m ago)", input)
+}
+
+// LogWindow 以 at 为中心、前后各 span 的 Since/Until 参数
+func LogWindow(at time.Time, span time.Duration) (since, until string) {
+	return at.Add(-span).UTC().Format(time.RFC3339Nano), at.Add(span).UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,175 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSplitLogTimestamp 测试拆分行首的时间戳
+func TestSplitLogTimestamp(t *testing.T) {
+	ts, rest, ok := SplitLogTimestamp("2024-03-01T10:00:00.123456789Z GET /health 200")
+	if !ok || rest != "GET /health 200" {
+		t.Fatalf("got (%v, %q, %v)", ts, rest, ok)
+	}
+	if want := time.Date(2024, 3, 1, 10, 0, 0, 123456789, time.UTC); !ts.Equal(want) {
+		t.Errorf("time = %v, want %v", ts, want)
+	}
+
+	if _, rest, ok := SplitLogTimestamp("2024-03-01T10:00:00Z"); !ok || rest != "" {
+		t.Errorf("timestamp-only line: rest = %q, ok = %v", rest, ok)
+	}
+	for _, line := range []string{"plain log line", "2024-03-01 not a timestamp", ""} {
+		if _, rest, ok := SplitLogTimestamp(line); ok || rest != line {
+			t.Errorf("SplitLogTimestamp(%q) = (%q, %v), want unchanged", line, rest, ok)
+		}
+	}
+}
+
+// TestSortLogLines 测试按时间戳交错多个来源的日志，没有时间戳的行跟随同一来源的上一行
+func TestSortLogLines(t *testing.T) {
+	base := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+	lines := []TimedLogLine{
+		{Time: at(0), Source: "api", Text: "a1"},
+		{Time: at(30), Source: "api", Text: "a2"},
+		{Source: "api", Text: "a2 continued"},
+		{Time: at(10), Source: "db", Text: "d1"},
+		{Time: at(40), Source: "db", Text: "d2"},
+		{Time: at(30), Source: "db", Text: "d-same-time"},
+	}
+	SortLogLines(lines)
+
+	want := []string{"a1", "d1", "a2", "a2 continued", "d-same-time", "d2"}
+	for i, l := range lines {
+		if l.Text != want[i] {
+			t.Fatalf("order = %v, want %v", texts(lines), want)
+		}
+	}
+}
+
+// TestParseLogTime 测试跳转到时间的输入：当天时间、带日期的时间、RFC3339 和相对时间
+func TestParseLogTime(t *testing.T) {
+	loc := time.FixedZone("test", 8*3600)
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, loc)
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"09:30", time.Date(2024, 3, 1, 9, 30, 0, 0, loc)},
+		{"09:30:15", time.Date(2024, 3, 1, 9, 30, 15, 0, loc)},
+		{"23:00", time.Date(2024, 2, 29, 23, 0, 0, 0, loc)}, // 晚于当前时间：昨天
+		{"02-28 08:00", time.Date(2024, 2, 28, 8, 0, 0, 0, loc)},
+		{"12-31 23:59:59", time.Date(2023, 12, 31, 23, 59, 59, 0, loc)}, // 晚于当前时间：去年
+		{"2024-01-15 12:00", time.Date(2024, 1, 15, 12, 0, 0, 0, loc)},
+		{"2024-03-01T01:00:00Z", time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC)},
+		{"10m", now.Add(-10 * time.Minute)},
+		{"-1h30m", now.Add(-90 * time.Minute)},
+		{"2h ago", now.Add(-2 * time.Hour)},
+	}
+	for _, tt := range tests {
+		got, err := ParseLogTime(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseLogTime(%q): %v", tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseLogTime(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+	for _, input := range []string{"", "yesterday", "25:00", "0s"} {
+		if _, err := ParseLogTime(input, now); err == nil {
+			t.Errorf("ParseLogTime(%q) should fail", input)
+		}
+	}
+}
+
+func texts(lines []TimedLogLine) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = l.Text
+	}
+	return out
+}
+
+// muxLogStream 生成 Docker 多路复用格式的日志流：每行一帧，每 10 行有一行 stderr
+func muxLogStream(lines int) []byte {
+	var buf bytes.Buffer
+	for i := 0; i < lines; i++ {
+		payload := fmt.Sprintf("2024-03-01T10:%02d:%02d.000000000Z GET /api/items/%d 200 %dms\n", i/60%60, i%60, i, i%500)
+		header := [8]byte{byte(Stdout)}
+		if i%10 == 0 {
+			header[0] = byte(Stderr)
+		}
+		binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+		buf.Write(header[:])
+		buf.WriteString(payload)
+	}
+	return buf.Bytes()
+}
+
+// TestDemuxReader 测试去掉多路复用帧头后得到原始日志文本
+func TestDemuxReader(t *testing.T) {
+	got, err := io.ReadAll(NewDemuxReader(bytes.NewReader(muxLogStream(3))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "2024-03-01T10:00:00.000000000Z GET /api/items/0 200 0ms\n" +
+		"2024-03-01T10:00:01.000000000Z GET /api/items/1 200 1ms\n" +
+		"2024-03-01T10:00:02.000000000Z GET /api/items/2 200 2ms\n"
+	if string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// BenchmarkDemuxReader 解复用 10k 行日志
+func BenchmarkDemuxReader(b *testing.B) {
+	data := muxLogStream(10000)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := io.Copy(io.Discard, NewDemuxReader(bytes.NewReader(data))); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkStreamLogs 按行流式读取 10k 行多路复用日志
+func BenchmarkStreamLogs(b *testing.B) {
+	data := muxLogStream(10000)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		lineChan := make(chan string, 100)
+		errChan := make(chan error, 1)
+		go StreamLogs(bytes.NewReader(data), lineChan, errChan)
+		n := 0
+		for range lineChan {
+			n++
+		}
+		if err := <-errChan; err != nil || n != 10000 {
+			b.Fatalf("read %d lines, err %v", n, err)
+		}
+	}
+}
+
+// BenchmarkSortLogLines 按时间戳交错 10k 行（4 个来源）
+func BenchmarkSortLogLines(b *testing.B) {
+	base := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	lines := make([]TimedLogLine, 10000)
+	for i := range lines {
+		source := i % 4
+		lines[i] = TimedLogLine{Time: base.Add(time.Duration(i/4*10+source*3) * time.Millisecond), Source: fmt.Sprintf("svc-%d", source), Text: strings.Repeat("x", 40)}
+	}
+	work := make([]TimedLogLine, len(lines))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(work, lines)
+		SortLogLines(work)
+	}
+}
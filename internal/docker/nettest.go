@@ -0,0 +1,273 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NetTestKind 容器内网络测试的类型
+type NetTestKind int
+
+const (
+	NetTestDNS  NetTestKind = iota // 域名解析
+	NetTestTCP                     // TCP 连接 host:port
+	NetTestHTTP                    // HTTP GET
+)
+
+// String 测试类型名称
+func (k NetTestKind) String() string {
+	switch k {
+	case NetTestDNS:
+		return "DNS"
+	case NetTestTCP:
+		return "TCP"
+	case NetTestHTTP:
+		return "HTTP"
+	}
+	return "unknown"
+}
+
+// NetTestResult 一次网络测试的结果
+type NetTestResult struct {
+	Kind    NetTestKind
+	Target  string
+	Tool    string        // 容器内实际使用的工具，如 nslookup、curl；为空表示没有可用的工具
+	OK      bool          // 解析成功、连接成功或 HTTP 状态码小于 400
+	Summary string        // 一行结论，如 "200 OK"
+	Details []string      // 解析出的要点，如解析到的地址
+	Hint    string        // 无法测试时的处理建议
+	Output  string        // 工具的原始输出
+	Elapsed time.Duration // 测试耗时（HTTP 使用 curl 报告的时间，其余包含 exec 开销）
+}
+
+// netTestScripts 各类测试的脚本：依次查找镜像里已有的工具，先输出 "@tool 名称" 再执行；参数通过 $1 $2 传入，不拼接到脚本中
+var netTestScripts = map[NetTestKind]string{
+	NetTestDNS: `if command -v getent >/dev/null 2>&1; then echo "@tool getent"; getent ahosts "$1" 2>/dev/null || getent hosts "$1"; exit $?; fi
+if command -v nslookup >/dev/null 2>&1; then echo "@tool nslookup"; nslookup "$1" 2>&1; exit $?; fi
+if command -v dig >/dev/null 2>&1; then echo "@tool dig"; dig +short "$1" 2>&1; exit $?; fi
+if command -v host >/dev/null 2>&1; then echo "@tool host"; host "$1" 2>&1; exit $?; fi
+echo "@tool none"; exit 127`,
+	NetTestTCP: `if command -v nc >/dev/null 2>&1; then echo "@tool nc"; nc -z -w 5 "$1" "$2" 2>&1; exit $?; fi
+if command -v bash >/dev/null 2>&1; then echo "@tool bash"; bash -c 'exec 3<>"/dev/tcp/$0/$1"' "$1" "$2" 2>&1; exit $?; fi
+echo "@tool none"; exit 127`,
+	NetTestHTTP: `if command -v curl >/dev/null 2>&1; then echo "@tool curl"; curl -sS -L -o /dev/null -m 10 -w '\n@status %{http_code}\n@time %{time_total}\n@ip %{remote_ip}\n@redirects %{num_redirects}\n' "$1" 2>&1; exit $?; fi
+if command -v wget >/dev/null 2>&1; then echo "@tool wget"; wget -S -O /dev/null -T 10 "$1" 2>&1; exit $?; fi
+echo "@tool none"; exit 127`,
+}
+
+// netTestTools 各类测试会查找的工具（与脚本中的顺序一致）
+var netTestTools = map[NetTestKind][]string{
+	NetTestDNS:  {"getent", "nslookup", "dig", "host"},
+	NetTestTCP:  {"nc", "bash"},
+	NetTestHTTP: {"curl", "wget"},
+}
+
+// NetTestTools 测试会使用的工具
+func NetTestTools(kind NetTestKind) []string {
+	return netTestTools[kind]
+}
+
+// CanRunNetTest 容器中是否有 sh 和该测试可用的工具
+func (t *ContainerTools) CanRunNetTest(kind NetTestKind) bool {
+	return t.Has("sh") && t.HasAny(netTestTools[kind]...)
+}
+
+// netTestSidecarHint 镜像里没有 shell 或工具时，改用共享网络命名空间的调试容器
+const netTestSidecarHint = "Run the test from a debug sidecar sharing the network namespace: docker run --rm -it --network container:%s nicolaka/netshoot"
+
+// ParseNetTestTarget 校验测试目标并返回传给脚本的参数：DNS 为主机名，TCP 为 host:port，HTTP 为 URL（省略协议时补 http://）
+func ParseNetTestTarget(kind NetTestKind, target string) ([]string, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return nil, fmt.Errorf("target is empty")
+	}
+	switch kind {
+	case NetTestDNS:
+		if strings.ContainsAny(target, " /:") {
+			return nil, fmt.Errorf("expected a hostname, got %q", target)
+		}
+		return []string{target}, nil
+	case NetTestTCP:
+		host, port, err := net.SplitHostPort(target)
+		if err != nil {
+			return nil, fmt.Errorf("expected host:port, got %q", target)
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return nil, fmt.Errorf("invalid port %q", port)
+		}
+		if host == "" {
+			return nil, fmt.Errorf("expected host:port, got %q", target)
+		}
+		return []string{host, port}, nil
+	case NetTestHTTP:
+		if !strings.Contains(target, "://") {
+			target = "http://" + target
+		}
+		u, err := url.Parse(target)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, fmt.Errorf("expected an http(s) URL, got %q", target)
+		}
+		return []string{u.String()}, nil
+	}
+	return nil, fmt.Errorf("unknown test %d", kind)
+}
+
+// RunNetTest 在容器中用镜像里已有的工具执行一次网络测试并解析输出
+func RunNetTest(ctx context.Context, client Client, containerID, containerName string, kind NetTestKind, target string) (*NetTestResult, error) {
+	args, err := ParseNetTestTarget(kind, target)
+	if err != nil {
+		return nil, err
+	}
+	cmd := append([]string{"sh", "-c", netTestScripts[kind], "nettest"}, args...)
+
+	start := time.Now()
+	out, err := client.ExecCommand(ctx, containerID, ExecConfig{Cmd: cmd, AttachStdout: true, AttachStderr: true})
+	if err != nil {
+		return nil, err
+	}
+	result := &NetTestResult{Kind: kind, Target: args[0], Elapsed: time.Since(start)}
+	if kind == NetTestTCP {
+		result.Target = net.JoinHostPort(args[0], args[1])
+	}
+	parseNetTestOutput(result, out.Stdout+out.Stderr, out.ExitCode)
+	if result.Hint != "" {
+		result.Hint = fmt.Sprintf(result.Hint, containerName)
+	}
+	return result, nil
+}
+
+// parseNetTestOutput 从脚本输出中取出使用的工具，并按测试类型解析结论
+func parseNetTestOutput(r *NetTestResult, output string, exitCode int) {
+	tool, body, found := strings.Cut(output, "@tool ")
+	if !found {
+		r.Summary = "No shell (sh) in the container"
+		r.Hint = netTestSidecarHint
+		r.Output = strings.TrimSpace(tool)
+		return
+	}
+	name, rest, _ := strings.Cut(body, "\n")
+	r.Tool = strings.TrimSpace(name)
+	r.Output = strings.TrimSpace(rest)
+	if r.Tool == "none" {
+		r.Tool = ""
+		r.Summary = "No " + r.Kind.String() + " tool in the image (looked for " + strings.Join(netTestTools[r.Kind], ", ") + ")"
+		r.Hint = netTestSidecarHint
+		return
+	}
+
+	switch r.Kind {
+	case NetTestDNS:
+		parseDNSOutput(r, exitCode)
+	case NetTestTCP:
+		r.OK = exitCode == 0
+		if r.OK {
+			r.Summary = "Connected"
+		} else {
+			r.Summary = "Connection failed"
+			r.Details = lastLines(r.Output, 3)
+		}
+	case NetTestHTTP:
+		parseHTTPOutput(r, exitCode)
+	}
+}
+
+// parseDNSOutput 收集解析到的地址（nslookup 跳过开头的 DNS 服务器信息）
+func parseDNSOutput(r *NetTestResult, exitCode int) {
+	seen := make(map[string]bool)
+	answer := r.Tool != "nslookup"
+	for _, line := range strings.Split(r.Output, "\n") {
+		if !answer {
+			answer = strings.HasPrefix(strings.TrimSpace(line), "Name:")
+			continue
+		}
+		for _, field := range strings.Fields(line) {
+			field = strings.TrimSuffix(field, ",")
+			if ip := net.ParseIP(field); ip != nil && !seen[ip.String()] {
+				seen[ip.String()] = true
+				r.Details = append(r.Details, ip.String())
+			}
+		}
+	}
+	r.OK = len(r.Details) > 0
+	switch {
+	case r.OK && len(r.Details) == 1:
+		r.Summary = "Resolved to 1 address"
+	case r.OK:
+		r.Summary = fmt.Sprintf("Resolved to %d addresses", len(r.Details))
+	case exitCode == 0:
+		r.Summary = "No addresses returned"
+	default:
+		r.Summary = "Not resolved"
+		r.Details = lastLines(r.Output, 3)
+	}
+}
+
+// parseHTTPOutput 解析 curl -w 的 @ 字段，或 wget -S 输出中最后一个状态行（跟随重定向后的结果）
+func parseHTTPOutput(r *NetTestResult, exitCode int) {
+	status := 0
+	var other []string
+	for _, line := range strings.Split(r.Output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "@status "):
+			status, _ = strconv.Atoi(strings.TrimPrefix(line, "@status "))
+		case strings.HasPrefix(line, "@time "):
+			if secs, err := strconv.ParseFloat(strings.TrimPrefix(line, "@time "), 64); err == nil {
+				r.Elapsed = time.Duration(secs * float64(time.Second))
+			}
+		case strings.HasPrefix(line, "@ip"):
+			if ip := strings.TrimSpace(strings.TrimPrefix(line, "@ip")); ip != "" {
+				r.Details = append(r.Details, "Remote: "+ip)
+			}
+		case strings.HasPrefix(line, "@redirects "):
+			if n := strings.TrimPrefix(line, "@redirects "); n != "0" {
+				r.Details = append(r.Details, "Redirects: "+n)
+			}
+		case strings.HasPrefix(line, "HTTP/"), strings.Contains(line, " HTTP/"):
+			// wget -S 的状态行，busybox 报错时写在 "server returned error: HTTP/1.1 404" 中
+			fields := strings.Fields(line[strings.Index(line, "HTTP/"):])
+			if len(fields) > 1 {
+				if code, err := strconv.Atoi(fields[1]); err == nil {
+					status = code
+				}
+			}
+		case line != "":
+			other = append(other, line)
+		}
+	}
+
+	if status == 0 {
+		r.Summary = "Request failed"
+		r.Details = append(r.Details, lastStrings(other, 3)...)
+		return
+	}
+	r.OK = status < 400
+	r.Summary = fmt.Sprintf("%d %s", status, http.StatusText(status))
+	if !r.OK && exitCode != 0 && r.Tool == "curl" {
+		r.Details = append(r.Details, lastStrings(other, 3)...)
+	}
+}
+
+// lastLines 输出的最后 n 个非空行
+func lastLines(output string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lastStrings(lines, n)
+}
+
+func lastStrings(lines []string, n int) []string {
+	if len(lines) > n {
+		return lines[len(lines)-n:]
+	}
+	return lines
+}
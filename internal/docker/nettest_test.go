@@ -0,0 +1,110 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestParseNetTestTarget 测试校验网络测试目标
+func TestParseNetTestTarget(t *testing.T) {
+	tests := []struct {
+		kind    NetTestKind
+		target  string
+		want    []string
+		wantErr bool
+	}{
+		{NetTestDNS, " db ", []string{"db"}, false},
+		{NetTestDNS, "db:5432", nil, true},
+		{NetTestTCP, "db:5432", []string{"db", "5432"}, false},
+		{NetTestTCP, "[::1]:80", []string{"::1", "80"}, false},
+		{NetTestTCP, "db", nil, true},
+		{NetTestTCP, "db:0", nil, true},
+		{NetTestTCP, ":80", nil, true},
+		{NetTestHTTP, "api:8080/health", []string{"http://api:8080/health"}, false},
+		{NetTestHTTP, "https://example.com", []string{"https://example.com"}, false},
+		{NetTestHTTP, "ftp://example.com", nil, true},
+		{NetTestHTTP, "", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseNetTestTarget(tt.kind, tt.target)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseNetTestTarget(%v, %q) = (%v, %v), want %v", tt.kind, tt.target, got, err, tt.want)
+		}
+	}
+}
+
+// TestParseNetTestOutput 测试解析各工具的输出
+func TestParseNetTestOutput(t *testing.T) {
+	t.Run("nslookup skips server", func(t *testing.T) {
+		r := &NetTestResult{Kind: NetTestDNS}
+		parseNetTestOutput(r, "@tool nslookup\nServer:\t\t127.0.0.11\nAddress:\t127.0.0.11#53\n\nNon-authoritative answer:\nName:\tdb\nAddress: 172.18.0.2\nName:\tdb\nAddress: 172.18.0.2\n", 0)
+		if !r.OK || r.Tool != "nslookup" || !reflect.DeepEqual(r.Details, []string{"172.18.0.2"}) {
+			t.Errorf("got %+v", r)
+		}
+	})
+	t.Run("getent not found", func(t *testing.T) {
+		r := &NetTestResult{Kind: NetTestDNS}
+		parseNetTestOutput(r, "@tool getent\n", 2)
+		if r.OK || r.Summary != "Not resolved" {
+			t.Errorf("got %+v", r)
+		}
+	})
+	t.Run("curl", func(t *testing.T) {
+		r := &NetTestResult{Kind: NetTestHTTP}
+		parseNetTestOutput(r, "@tool curl\n\n@status 404\n@time 0.043\n@ip 172.18.0.3\n@redirects 0\n", 0)
+		if r.OK || r.Summary != "404 Not Found" || r.Elapsed != 43*time.Millisecond || !reflect.DeepEqual(r.Details, []string{"Remote: 172.18.0.3"}) {
+			t.Errorf("got %+v", r)
+		}
+	})
+	t.Run("curl connection refused", func(t *testing.T) {
+		r := &NetTestResult{Kind: NetTestHTTP}
+		parseNetTestOutput(r, "@tool curl\ncurl: (7) Failed to connect to api port 80: Connection refused\n\n@status 000\n@time 0.001\n@ip\n@redirects 0\n", 7)
+		if r.OK || r.Summary != "Request failed" || len(r.Details) != 1 {
+			t.Errorf("got %+v", r)
+		}
+	})
+	t.Run("busybox wget follows redirect", func(t *testing.T) {
+		r := &NetTestResult{Kind: NetTestHTTP}
+		parseNetTestOutput(r, "@tool wget\nConnecting to api (172.18.0.3:80)\n  HTTP/1.1 301 Moved Permanently\n  HTTP/1.1 200 OK\n", 0)
+		if !r.OK || r.Summary != "200 OK" {
+			t.Errorf("got %+v", r)
+		}
+	})
+	t.Run("no tool", func(t *testing.T) {
+		r := &NetTestResult{Kind: NetTestTCP}
+		parseNetTestOutput(r, "@tool none\n", 127)
+		if r.OK || r.Tool != "" || r.Hint == "" {
+			t.Errorf("got %+v", r)
+		}
+	})
+	t.Run("no shell", func(t *testing.T) {
+		r := &NetTestResult{Kind: NetTestTCP}
+		parseNetTestOutput(r, `OCI runtime exec failed: exec: "sh": executable file not found in $PATH`, 126)
+		if r.OK || r.Summary != "No shell (sh) in the container" || r.Hint == "" {
+			t.Errorf("got %+v", r)
+		}
+	})
+}
+
+// TestParseToolProbe 测试解析 command -v 的探测结果
+func TestParseToolProbe(t *testing.T) {
+	paths := parseToolProbe("sh=/bin/sh\nnc=/usr/bin/nc\n\nbroken\ngetent=\n")
+	want := map[string]string{"sh": "/bin/sh", "nc": "/usr/bin/nc"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("parseToolProbe = %v, want %v", paths, want)
+	}
+
+	tools := &ContainerTools{Paths: paths}
+	if !tools.CanRunNetTest(NetTestTCP) || tools.CanRunNetTest(NetTestHTTP) {
+		t.Errorf("CanRunNetTest with %v: TCP=%v HTTP=%v", paths, tools.CanRunNetTest(NetTestTCP), tools.CanRunNetTest(NetTestHTTP))
+	}
+	noShell := &ContainerTools{Paths: map[string]string{"curl": "/usr/bin/curl"}}
+	if noShell.CanRunNetTest(NetTestHTTP) {
+		t.Error("HTTP test should need sh")
+	}
+	var unknown *ContainerTools
+	if unknown.Has("sh") {
+		t.Error("nil ContainerTools should have no tools")
+	}
+}
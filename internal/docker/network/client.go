@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 	sdk "github.com/docker/docker/client"
@@ -41,6 +42,12 @@ func (c *Client) List(ctx context.Context) ([]Network, error) {
 		return nil, fmt.Errorf("failed to get network list: %w", err)
 	}
 
+	// 网络列表接口不返回容器信息，通过容器列表统计（包括已停止的容器，它们同样会阻止清理）
+	usage, err := c.containerUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]Network, 0, len(networks))
 	for _, net := range networks {
 		// 短 ID（12位）
@@ -56,11 +63,18 @@ func (c *Client) List(ctx context.Context) ([]Network, error) {
 		}
 
 		// 统计连接的容器数量
-		containerCount := len(net.Containers)
+		containerCount := usage[net.ID]
 
 		// 判断是否为内置网络
 		isBuiltIn := builtInNetworks[net.Name]
 
+		subnets := make([]string, 0, len(net.IPAM.Config))
+		for _, cfg := range net.IPAM.Config {
+			if cfg.Subnet != "" {
+				subnets = append(subnets, cfg.Subnet)
+			}
+		}
+
 		result = append(result, Network{
 			ID:             net.ID,
 			ShortID:        shortID,
@@ -73,14 +87,38 @@ func (c *Client) List(ctx context.Context) ([]Network, error) {
 			Ingress:        net.Ingress,
 			Created:        created,
 			Labels:         net.Labels,
+			Options:        net.Options,
+			Subnets:        subnets,
 			ContainerCount: containerCount,
 			IsBuiltIn:      isBuiltIn,
+			// Swarm 网络是否被服务使用在本机无法判断，不算作未使用
+			Unused: containerCount == 0 && !isBuiltIn && !net.Ingress && net.Scope != ScopeSwarm,
 		})
 	}
 
 	return result, nil
 }
 
+// containerUsage 统计每个网络（按 ID）被多少个容器引用
+func (c *Client) containerUsage(ctx context.Context) (map[string]int, error) {
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count network containers: %w", err)
+	}
+	usage := make(map[string]int)
+	for _, ctr := range containers {
+		if ctr.NetworkSettings == nil {
+			continue
+		}
+		for _, endpoint := range ctr.NetworkSettings.Networks {
+			if endpoint != nil && endpoint.NetworkID != "" {
+				usage[endpoint.NetworkID]++
+			}
+		}
+	}
+	return usage, nil
+}
+
 // GetDetails 获取网络详细信息
 func (c *Client) GetDetails(ctx context.Context, networkID string) (*Details, error) {
 	if c == nil || c.cli == nil {
@@ -91,6 +129,10 @@ func (c *Client) GetDetails(ctx context.Context, networkID string) (*Details, er
 	net, err := c.cli.NetworkInspect(ctx, networkID, network.InspectOptions{
 		Verbose: true,
 	})
+	if err != nil {
+		// 非管理节点上 Swarm 网络的详细信息（服务、任务）不可用，退回普通查询
+		net, err = c.cli.NetworkInspect(ctx, networkID, network.InspectOptions{})
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get network details: %w", err)
 	}
@@ -1,6 +1,12 @@
 package network
 
-import "time"
+import (
+	"net"
+	"time"
+)
+
+// ScopeSwarm 由 Swarm 集群管理的网络范围
+const ScopeSwarm = "swarm"
 
 // Network 表示网络的基本信息（用于列表视图）
 type Network struct {
@@ -15,10 +21,13 @@ type Network struct {
 	Ingress    bool              // 是否为 Ingress 网络
 	Created    time.Time         // 创建时间
 	Labels     map[string]string // 标签
+	Options    map[string]string // 驱动选项
+	Subnets    []string          // IPAM 子网（IPv4 和 IPv6）
 
 	// 运行时状态
 	ContainerCount int  // 连接的容器数量
 	IsBuiltIn      bool // 是否为内置网络（bridge, host, none）
+	Unused         bool // 没有容器引用的自定义网络（会被 network prune 清理）
 }
 
 // Details 表示网络的详细信息（用于详情视图）
@@ -95,3 +104,24 @@ type DisconnectOptions struct {
 	ContainerID string // 容器 ID 或名称
 	Force       bool   // 是否强制断开
 }
+
+// IsSwarmScoped 是否为 Swarm 管理的网络（只能在管理节点上通过 Swarm 修改）
+func (n Network) IsSwarmScoped() bool {
+	return n.Scope == ScopeSwarm
+}
+
+// IsSwarmScoped 是否为 Swarm 管理的网络
+func (d Details) IsSwarmScoped() bool {
+	return d.Scope == ScopeSwarm
+}
+
+// IsIPv6 子网是否为 IPv6
+func (p IPAMPoolConfig) IsIPv6() bool {
+	return IsIPv6Subnet(p.Subnet)
+}
+
+// IsIPv6Subnet 判断 CIDR 是否为 IPv6
+func IsIPv6Subnet(subnet string) bool {
+	ip, _, err := net.ParseCIDR(subnet)
+	return err == nil && ip.To4() == nil
+}
@@ -0,0 +1,92 @@
+package docker
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+
+	"docktui/internal/format"
+)
+
+// ResourcePreset 容器资源限制预设（如 small: 0.5 CPU / 512MB），用于统一团队中容器的限制
+type ResourcePreset struct {
+	Name   string
+	CPUs   float64 // CPU 数量上限（--cpus），0 表示不限制
+	Memory int64   // 内存上限（字节，--memory），0 表示不限制
+}
+
+// DefaultResourcePresets 配置文件中没有设置 resource_presets 时使用的预设
+var DefaultResourcePresets = []ResourcePreset{
+	{Name: "small", CPUs: 0.5, Memory: 512 << 20},
+	{Name: "medium", CPUs: 1, Memory: 1 << 30},
+	{Name: "large", CPUs: 2, Memory: 4 << 30},
+}
+
+var (
+	resourcePresets   = DefaultResourcePresets
+	resourcePresetsMu sync.RWMutex
+)
+
+// SetResourcePresets 设置可选的资源预设，空列表表示使用 DefaultResourcePresets
+func SetResourcePresets(presets []ResourcePreset) {
+	resourcePresetsMu.Lock()
+	defer resourcePresetsMu.Unlock()
+	if len(presets) == 0 {
+		presets = DefaultResourcePresets
+	}
+	resourcePresets = presets
+}
+
+// ResourcePresets 返回可选的资源预设
+func ResourcePresets() []ResourcePreset {
+	resourcePresetsMu.RLock()
+	defer resourcePresetsMu.RUnlock()
+	return resourcePresets
+}
+
+// FindResourcePreset 按名称查找资源预设（不区分大小写）
+func FindResourcePreset(name string) (ResourcePreset, bool) {
+	for _, p := range ResourcePresets() {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return ResourcePreset{}, false
+}
+
+// NanoCPUs CPU 上限换算为纳秒 CPU（1 CPU = 1e9）
+func (p ResourcePreset) NanoCPUs() int64 {
+	return int64(math.Round(p.CPUs * 1e9))
+}
+
+// Summary 显示限制，如 "0.5 CPU / 512 MB"
+func (p ResourcePreset) Summary() string {
+	var parts []string
+	if p.CPUs > 0 {
+		parts = append(parts, strconv.FormatFloat(p.CPUs, 'f', -1, 64)+" CPU")
+	}
+	if p.Memory > 0 {
+		parts = append(parts, format.Size(p.Memory))
+	}
+	if len(parts) == 0 {
+		return "unlimited"
+	}
+	return strings.Join(parts, " / ")
+}
+
+// applyResourceLimits 创建容器时设置 CPU 和内存上限，0 表示保持原值
+func applyResourceLimits(r *container.Resources, nanoCPUs, memory int64) {
+	if nanoCPUs > 0 {
+		// --cpus 与 CPU 周期/配额不能同时设置
+		r.NanoCPUs = nanoCPUs
+		r.CPUPeriod, r.CPUQuota = 0, 0
+	}
+	if memory > 0 {
+		// 原有的交换空间上限可能小于新的内存上限，交给 Docker 使用默认值（内存的 2 倍）
+		r.Memory = memory
+		r.MemorySwap = 0
+	}
+}
@@ -0,0 +1,93 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+
+	"docktui/internal/audit"
+)
+
+// ContainerPruneOptions 容器清理选项
+type ContainerPruneOptions struct {
+	Until string // 仅清理在此时间之前创建的容器（Go duration，如 24h、168h），空表示不限
+}
+
+// ContainerPruneCandidate 表示一个将被清理的已停止容器
+type ContainerPruneCandidate struct {
+	Container
+	SizeRw int64 // 可写层大小（字节），即删除后可释放的空间
+}
+
+// pruneableStates 会被 docker container prune 清理的容器状态
+var pruneableStates = []string{"created", "exited", "dead"}
+
+// PruneContainers 清理所有已停止的容器
+func (c *LocalClient) PruneContainers(ctx context.Context, opts ContainerPruneOptions) (int, int64, error) {
+	if c == nil || c.cli == nil {
+		return 0, 0, fmt.Errorf("Docker client not initialized")
+	}
+	if err := checkAction("container.prune", ""); err != nil {
+		return 0, 0, err
+	}
+
+	args := filters.NewArgs()
+	if opts.Until != "" {
+		if _, err := time.ParseDuration(opts.Until); err != nil {
+			return 0, 0, fmt.Errorf("invalid until duration %q: %w", opts.Until, err)
+		}
+		args.Add("until", opts.Until)
+	}
+
+	report, err := c.cli.ContainersPrune(ctx, args)
+	audit.Record("container.prune", fmt.Sprintf("%d container(s)", len(report.ContainersDeleted)), err)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to prune containers: %w", err)
+	}
+
+	return len(report.ContainersDeleted), int64(report.SpaceReclaimed), nil
+}
+
+// PruneContainersPreview 预览 PruneContainers 将删除的容器（不执行删除）
+func (c *LocalClient) PruneContainersPreview(ctx context.Context, opts ContainerPruneOptions) ([]ContainerPruneCandidate, error) {
+	if c == nil || c.cli == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+
+	var cutoff time.Time
+	if opts.Until != "" {
+		d, err := time.ParseDuration(opts.Until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid until duration %q: %w", opts.Until, err)
+		}
+		cutoff = time.Now().Add(-d)
+	}
+
+	args := filters.NewArgs()
+	for _, state := range pruneableStates {
+		args.Add("status", state)
+	}
+
+	// Size: true 会让守护进程计算可写层大小，较慢但只在预览时调用
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true, Size: true, Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container list: %w", err)
+	}
+
+	result := make([]ContainerPruneCandidate, 0, len(containers))
+	for _, summary := range containers {
+		ct := convertContainerSummary(summary)
+		if !cutoff.IsZero() && !ct.Created.Before(cutoff) {
+			continue
+		}
+		result = append(result, ContainerPruneCandidate{
+			Container: ct,
+			SizeRw:    summary.SizeRw,
+		})
+	}
+
+	return result, nil
+}
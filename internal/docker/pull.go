@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	dockerimage "github.com/docker/docker/api/types/image"
+
+	"docktui/internal/audit"
 )
 
 // pullEvent Docker 拉取事件的 JSON 结构
@@ -28,6 +30,9 @@ func (c *LocalClient) PullImageWithProgress(ctx context.Context, imageRef string
 	if c == nil || c.cli == nil {
 		return nil, ErrClientNotInitialized
 	}
+	if err := checkAction("image.pull", imageRef); err != nil {
+		return nil, err
+	}
 
 	progressChan := make(chan PullProgress, 10)
 
@@ -36,6 +41,9 @@ func (c *LocalClient) PullImageWithProgress(ctx context.Context, imageRef string
 
 		// 发送初始状态
 		progress := NewPullProgress(imageRef)
+		defer func() {
+			audit.Record("image.pull", imageRef, progress.Error)
+		}()
 		progress.Message = "Connecting..."
 		progressChan <- *progress
 
@@ -59,6 +67,11 @@ func (c *LocalClient) PullImageWithProgress(ctx context.Context, imageRef string
 
 // parsePullProgress 解析 Docker 拉取输出流
 func (c *LocalClient) parsePullProgress(ctx context.Context, reader io.Reader, progress *PullProgress, out chan<- PullProgress) {
+	c.parseProgressStream(ctx, reader, progress, out, c.handlePullEvent, "Pull completed")
+}
+
+// parseProgressStream 解析 Docker 拉取/推送的 JSON 进度流，handle 负责将单个事件合并到进度中
+func (c *LocalClient) parseProgressStream(ctx context.Context, reader io.Reader, progress *PullProgress, out chan<- PullProgress, handle func(*pullEvent, *PullProgress), doneMessage string) {
 	scanner := bufio.NewScanner(reader)
 	// 增大缓冲区以处理长行
 	buf := make([]byte, 0, 64*1024)
@@ -95,7 +108,7 @@ func (c *LocalClient) parsePullProgress(ctx context.Context, reader io.Reader, p
 		}
 
 		// 解析状态
-		c.handlePullEvent(&event, progress)
+		handle(&event, progress)
 
 		// 发送进度更新
 		out <- *progress
@@ -110,11 +123,11 @@ func (c *LocalClient) parsePullProgress(ctx context.Context, reader io.Reader, p
 		return
 	}
 
-	// 拉取完成
+	// 完成
 	if progress.Status != PullStatusError {
 		progress.Status = PullStatusComplete
 		progress.Percentage = 100
-		progress.Message = "Pull completed"
+		progress.Message = doneMessage
 		out <- *progress
 	}
 }
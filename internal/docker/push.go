@@ -0,0 +1,97 @@
+package docker
+
+import (
+	"context"
+	"strings"
+
+	"docktui/internal/audit"
+)
+
+// PushImageWithProgress 带进度的镜像推送，进度结构与拉取一致（Downloaded 表示已上传字节）
+func (c *LocalClient) PushImageWithProgress(ctx context.Context, imageRef string) (<-chan PullProgress, error) {
+	if c == nil || c.cli == nil || c.imageCli == nil {
+		return nil, ErrClientNotInitialized
+	}
+	if err := checkAction("image.push", imageRef); err != nil {
+		return nil, err
+	}
+
+	progressChan := make(chan PullProgress, 10)
+
+	go func() {
+		defer close(progressChan)
+
+		progress := NewPullProgress(imageRef)
+		defer func() {
+			audit.Record("image.push", imageRef, progress.Error)
+		}()
+		progress.Message = "Connecting..."
+		progressChan <- *progress
+
+		reader, err := c.imageCli.Push(ctx, imageRef, registryAuth(imageRef))
+		if err != nil {
+			progress.Status = PullStatusError
+			progress.Error = err
+			progress.Message = "Push failed: " + err.Error()
+			progressChan <- *progress
+			return
+		}
+		defer reader.Close()
+
+		c.parseProgressStream(ctx, reader, progress, progressChan, c.handlePushEvent, "Push completed")
+	}()
+
+	return progressChan, nil
+}
+
+// handlePushEvent 处理单个推送事件
+func (c *LocalClient) handlePushEvent(event *pullEvent, progress *PullProgress) {
+	status := strings.ToLower(event.Status)
+
+	if event.ID != "" {
+		progress.Message = event.ID + ": " + event.Status
+	} else {
+		progress.Message = event.Status
+	}
+
+	// 无层 ID 的全局状态（如 "The push refers to repository"、最终的 digest 行）
+	if event.ID == "" {
+		return
+	}
+
+	current := event.ProgressDetail.Current
+	total := event.ProgressDetail.Total
+	layerStatus := PullStatusPending
+
+	switch {
+	case strings.Contains(status, "pushing"):
+		layerStatus = PullStatusDownloading
+	case strings.Contains(status, "pushed"):
+		layerStatus = PullStatusComplete
+		if layer, exists := progress.Layers[event.ID]; exists && layer.Total > 0 {
+			total = layer.Total
+			current = total
+		}
+	case strings.Contains(status, "already exists"), strings.Contains(status, "mounted from"):
+		layerStatus = PullStatusComplete
+		current = 1
+		total = 1
+	case strings.Contains(status, "preparing"), strings.Contains(status, "waiting"):
+		layerStatus = PullStatusPending
+	default:
+		// 其他状态（如重试提示）不影响层进度
+		return
+	}
+
+	// 推送中会出现没有 total 的事件，沿用已有大小
+	if total == 0 {
+		if layer, exists := progress.Layers[event.ID]; exists {
+			total = layer.Total
+			if current == 0 {
+				current = layer.Current
+			}
+		}
+	}
+
+	progress.UpdateLayer(event.ID, layerStatus, current, total)
+}
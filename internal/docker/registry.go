@@ -0,0 +1,168 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/registry"
+)
+
+// dockerHubAuthKey Docker Hub 在 config.json 和凭据助手中使用的地址
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+// dockerConfigFile docker CLI 配置文件（~/.docker/config.json）中与仓库登录和上下文相关的字段
+type dockerConfigFile struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"`
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+	CredsStore     string            `json:"credsStore"`
+	CredHelpers    map[string]string `json:"credHelpers"`
+	CurrentContext string            `json:"currentContext"`
+}
+
+// dockerConfigDir docker CLI 配置目录，支持 DOCKER_CONFIG 指定
+func dockerConfigDir() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".docker"), nil
+}
+
+// loadDockerConfig 读取 docker CLI 配置，支持 DOCKER_CONFIG 指定目录
+func loadDockerConfig() (*dockerConfigFile, error) {
+	dir, err := dockerConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		return nil, err
+	}
+	var cfg dockerConfigFile
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// normalizeRegistryHost 将 config.json 中的地址（可能带协议和路径）转换为仓库主机名
+func normalizeRegistryHost(key string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+	if host == "index.docker.io" || host == "registry-1.docker.io" {
+		return "docker.io"
+	}
+	return host
+}
+
+// ConfiguredRegistries 返回 docker CLI 中已登录/配置了凭据的仓库主机名（按字母排序）
+func ConfiguredRegistries() []string {
+	cfg, err := loadDockerConfig()
+	if err != nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	for key := range cfg.Auths {
+		seen[normalizeRegistryHost(key)] = true
+	}
+	for key := range cfg.CredHelpers {
+		seen[normalizeRegistryHost(key)] = true
+	}
+	delete(seen, "")
+
+	hosts := make([]string, 0, len(seen))
+	for host := range seen {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// registryAuth 按 docker CLI 的规则为镜像所在仓库生成 X-Registry-Auth，找不到凭据时返回空字符串（匿名）
+func registryAuth(imageRef string) string {
+	named, err := reference.ParseNormalizedNamed(imageRef)
+	if err != nil {
+		return ""
+	}
+	cfg, err := loadDockerConfig()
+	if err != nil {
+		return ""
+	}
+
+	host := reference.Domain(named)
+	serverKey := host
+	if host == "docker.io" {
+		serverKey = dockerHubAuthKey
+	}
+
+	var auth registry.AuthConfig
+	helper := cfg.CredHelpers[host]
+	if helper == "" {
+		helper = cfg.CredsStore
+	}
+	if helper != "" {
+		auth, err = credentialHelperAuth(helper, serverKey)
+		if err != nil {
+			return ""
+		}
+	} else {
+		found := false
+		for key, entry := range cfg.Auths {
+			if normalizeRegistryHost(key) != host {
+				continue
+			}
+			found = true
+			auth.IdentityToken = entry.IdentityToken
+			if decoded, err := base64.StdEncoding.DecodeString(entry.Auth); err == nil {
+				auth.Username, auth.Password, _ = strings.Cut(string(decoded), ":")
+			}
+			break
+		}
+		if !found {
+			return ""
+		}
+	}
+	auth.ServerAddress = serverKey
+
+	encoded, err := registry.EncodeAuthConfig(auth)
+	if err != nil {
+		return ""
+	}
+	return encoded
+}
+
+// credentialHelperAuth 调用 docker-credential-<helper> get 读取凭据
+func credentialHelperAuth(helper, serverURL string) (registry.AuthConfig, error) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(serverURL)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return registry.AuthConfig{}, err
+	}
+
+	var creds struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &creds); err != nil {
+		return registry.AuthConfig{}, err
+	}
+	// 凭据助手用 <token> 作为用户名表示身份令牌
+	if creds.Username == "<token>" {
+		return registry.AuthConfig{IdentityToken: creds.Secret}, nil
+	}
+	return registry.AuthConfig{Username: creds.Username, Password: creds.Secret}, nil
+}
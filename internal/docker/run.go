@@ -0,0 +1,222 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+
+	"docktui/internal/audit"
+)
+
+// ContainerRunOptions 创建并启动容器的参数（相当于 docker run -d）
+type ContainerRunOptions struct {
+	Name          string            // 容器名称，空表示由 Docker 生成
+	Image         string            // 镜像
+	Cmd           []string          // 覆盖镜像默认命令
+	Env           []string          // KEY=VALUE
+	Ports         []string          // [ip:]hostPort:containerPort[/proto]
+	Binds         []string          // source:target[:ro]，source 可以是宿主机路径或卷名
+	Labels        map[string]string // 容器标签
+	RestartPolicy string            // no, always, on-failure, unless-stopped
+	AutoRemove    bool              // 退出后自动删除（--rm）
+	NanoCPUs      int64             // CPU 上限（1 CPU = 1e9），0 表示不限制
+	Memory        int64             // 内存上限（字节），0 表示不限制
+}
+
+// RunContainer 创建并启动容器，本地没有镜像时先拉取，返回容器 ID
+func (c *LocalClient) RunContainer(ctx context.Context, opts ContainerRunOptions) (string, error) {
+	if c == nil || c.cli == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+	target := opts.Image
+	if opts.Name != "" {
+		target = opts.Name + " (" + opts.Image + ")"
+	}
+	if err := checkAction("container.run", target); err != nil {
+		return "", err
+	}
+
+	exposed, bindings, err := nat.ParsePortSpecs(opts.Ports)
+	if err != nil {
+		return "", fmt.Errorf("invalid port mapping: %w", err)
+	}
+
+	config := &container.Config{
+		Image:        opts.Image,
+		Env:          opts.Env,
+		Labels:       opts.Labels,
+		ExposedPorts: exposed,
+	}
+	// 空命令会覆盖镜像的默认 CMD，只在指定时设置
+	if len(opts.Cmd) > 0 {
+		config.Cmd = opts.Cmd
+	}
+	hostConfig := &container.HostConfig{
+		Binds:         opts.Binds,
+		PortBindings:  bindings,
+		AutoRemove:    opts.AutoRemove,
+		RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyMode(opts.RestartPolicy)},
+	}
+	applyResourceLimits(&hostConfig.Resources, opts.NanoCPUs, opts.Memory)
+
+	resp, err := c.cli.ContainerCreate(ctx, config, hostConfig, nil, nil, opts.Name)
+	if errdefs.IsNotFound(err) {
+		if err = c.pullForRun(ctx, opts.Image); err == nil {
+			resp, err = c.cli.ContainerCreate(ctx, config, hostConfig, nil, nil, opts.Name)
+		}
+	}
+	if err != nil {
+		audit.Record("container.run", target, err)
+		return "", fmt.Errorf("failed to create container: %w", err)
+	}
+
+	err = c.cli.ContainerStart(ctx, resp.ID, container.StartOptions{})
+	audit.Record("container.run", target, err)
+	if err != nil {
+		return resp.ID, fmt.Errorf("container created but failed to start: %w", err)
+	}
+	return resp.ID, nil
+}
+
+// pullForRun 拉取运行容器所需的镜像并等待完成
+func (c *LocalClient) pullForRun(ctx context.Context, imageRef string) error {
+	if err := checkAction("image.pull", imageRef); err != nil {
+		return err
+	}
+	reader, err := c.cli.ImagePull(ctx, imageRef, image.PullOptions{})
+	if err == nil {
+		_, err = io.Copy(io.Discard, reader)
+		reader.Close()
+	}
+	audit.Record("image.pull", imageRef, err)
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", imageRef, err)
+	}
+	return nil
+}
+
+// LabelDuplicateOf 复制出的容器带有该标签，值为源容器名称
+const LabelDuplicateOf = "docktui.duplicate-of"
+
+// ContainerDuplicateOptions 复制容器的参数
+type ContainerDuplicateOptions struct {
+	Name       string // 新容器名称，空表示 <源容器名>-copy
+	PortOffset int    // 宿主机端口偏移量，0 表示不绑定宿主机端口
+	NanoCPUs   int64  // CPU 上限（1 CPU = 1e9），0 表示沿用源容器
+	Memory     int64  // 内存上限（字节），0 表示沿用源容器
+}
+
+// DuplicateContainer 以现有容器的配置（镜像、环境变量、挂载、网络）创建并启动一个新容器
+// 宿主机端口按偏移量重新映射或不绑定，Compose 标签会被去掉，避免新容器被当作项目的一部分
+func (c *LocalClient) DuplicateContainer(ctx context.Context, containerID string, opts ContainerDuplicateOptions) (string, error) {
+	if c == nil || c.cli == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+
+	info, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+	sourceName := strings.TrimPrefix(info.Name, "/")
+	name := opts.Name
+	if name == "" {
+		name = sourceName + "-copy"
+	}
+	target := sourceName + " -> " + name
+	if err := checkAction("container.duplicate", target); err != nil {
+		return "", err
+	}
+
+	config := *info.Config
+	// 主机名默认是容器 ID，交给 Docker 重新生成
+	if strings.HasPrefix(info.ID, config.Hostname) {
+		config.Hostname = ""
+	}
+	config.Labels = make(map[string]string, len(info.Config.Labels)+1)
+	for k, v := range info.Config.Labels {
+		if !strings.HasPrefix(k, "com.docker.compose.") {
+			config.Labels[k] = v
+		}
+	}
+	config.Labels[LabelDuplicateOf] = sourceName
+
+	hostConfig := *info.HostConfig
+	hostConfig.PortBindings, err = offsetPortBindings(info.HostConfig.PortBindings, opts.PortOffset)
+	if err != nil {
+		return "", err
+	}
+	if opts.PortOffset == 0 {
+		hostConfig.PublishAllPorts = false
+	}
+	applyResourceLimits(&hostConfig.Resources, opts.NanoCPUs, opts.Memory)
+
+	resp, err := c.cli.ContainerCreate(ctx, &config, &hostConfig, nil, nil, name)
+	if err != nil {
+		audit.Record("container.duplicate", target, err)
+		return "", fmt.Errorf("failed to create container: %w", err)
+	}
+
+	if err := c.connectExtraNetworks(ctx, info, resp.ID); err != nil {
+		audit.Record("container.duplicate", target, err)
+		return resp.ID, fmt.Errorf("container created but %w", err)
+	}
+
+	err = c.cli.ContainerStart(ctx, resp.ID, container.StartOptions{})
+	audit.Record("container.duplicate", target, err)
+	if err != nil {
+		return resp.ID, fmt.Errorf("container created but failed to start: %w", err)
+	}
+	return resp.ID, nil
+}
+
+// connectExtraNetworks 把新容器连接到源容器的其他网络：主网络由 NetworkMode 决定，
+// 其余网络在启动前连接（不复用固定 IP）
+func (c *LocalClient) connectExtraNetworks(ctx context.Context, info container.InspectResponse, containerID string) error {
+	if info.NetworkSettings == nil {
+		return nil
+	}
+	for netName := range info.NetworkSettings.Networks {
+		if netName == string(info.HostConfig.NetworkMode) || (netName == "bridge" && info.HostConfig.NetworkMode.IsDefault()) {
+			continue
+		}
+		if err := c.cli.NetworkConnect(ctx, netName, containerID, nil); err != nil {
+			return fmt.Errorf("failed to connect network %s: %w", netName, err)
+		}
+	}
+	return nil
+}
+
+// offsetPortBindings 将宿主机端口加上偏移量，offset 为 0 时去掉所有宿主机端口绑定
+func offsetPortBindings(bindings nat.PortMap, offset int) (nat.PortMap, error) {
+	if offset == 0 || len(bindings) == 0 {
+		return nil, nil
+	}
+	result := make(nat.PortMap, len(bindings))
+	for port, list := range bindings {
+		for _, b := range list {
+			if b.HostPort != "" {
+				start, end, err := nat.ParsePortRange(b.HostPort)
+				if err != nil {
+					return nil, fmt.Errorf("invalid host port %q: %w", b.HostPort, err)
+				}
+				if int(end)+offset > 65535 || int(start)+offset < 1 {
+					return nil, fmt.Errorf("host port %s + %d is out of range", b.HostPort, offset)
+				}
+				if start == end {
+					b.HostPort = strconv.Itoa(int(start) + offset)
+				} else {
+					b.HostPort = fmt.Sprintf("%d-%d", int(start)+offset, int(end)+offset)
+				}
+			}
+			result[port] = append(result[port], b)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,265 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+)
+
+// anonymousVolume 匿名卷的名称是 64 位十六进制
+var anonymousVolume = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ContainerRunCommand 根据 inspect 数据还原一条近似的 docker run 命令（多行，以 \ 续行）
+// 与镜像默认值相同的环境变量、标签、命令等会省略，Compose 标签也会去掉
+func (c *LocalClient) ContainerRunCommand(ctx context.Context, containerID string) (string, error) {
+	if c == nil || c.cli == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+	info, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+	return c.runCommand(ctx, info), nil
+}
+
+// RunCommandFromInspect 根据保存的 inspect JSON（如回收站中已删除的容器）还原 docker run 命令
+func (c *LocalClient) RunCommandFromInspect(ctx context.Context, inspect []byte) (string, error) {
+	if c == nil || c.cli == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+	var info container.InspectResponse
+	if err := json.Unmarshal(inspect, &info); err != nil {
+		return "", fmt.Errorf("failed to parse inspect data: %w", err)
+	}
+	if info.ContainerJSONBase == nil {
+		return "", fmt.Errorf("inspect data has no container")
+	}
+	return c.runCommand(ctx, info), nil
+}
+
+// runCommand 生成容器的 docker run 命令；镜像可能已被删除，此时无法省略镜像默认值
+func (c *LocalClient) runCommand(ctx context.Context, info container.InspectResponse) string {
+	var imageConfig *container.Config
+	if img, err := c.cli.ImageInspect(ctx, info.Image); err == nil {
+		imageConfig = img.Config
+	}
+	return FormatRunCommand(runCommandArgs(info, imageConfig))
+}
+
+// runCommandArgs 生成 docker run 的参数（不含 docker run），每个元素是一个选项及其值
+func runCommandArgs(info container.InspectResponse, image *container.Config) []string {
+	if image == nil {
+		image = &container.Config{}
+	}
+	cfg := info.Config
+	if cfg == nil {
+		cfg = &container.Config{}
+	}
+	hc := info.HostConfig
+	if hc == nil {
+		hc = &container.HostConfig{}
+	}
+
+	var args []string
+	add := func(flag string, values ...string) {
+		for _, v := range values {
+			args = append(args, flag+" "+shellQuote(v))
+		}
+	}
+
+	args = append(args, "-d")
+	if name := strings.TrimPrefix(info.Name, "/"); name != "" {
+		add("--name", name)
+	}
+	if cfg.Tty {
+		args = append(args, "-t")
+	}
+	if cfg.OpenStdin {
+		args = append(args, "-i")
+	}
+	if hc.AutoRemove {
+		args = append(args, "--rm")
+	}
+	if cfg.Hostname != "" && !strings.HasPrefix(info.ID, cfg.Hostname) {
+		add("--hostname", cfg.Hostname)
+	}
+	if cfg.User != "" && cfg.User != image.User {
+		add("--user", cfg.User)
+	}
+	if cfg.WorkingDir != "" && cfg.WorkingDir != image.WorkingDir {
+		add("--workdir", cfg.WorkingDir)
+	}
+
+	// 环境变量：只保留镜像中没有或值不同的
+	imageEnv := make(map[string]bool, len(image.Env))
+	for _, e := range image.Env {
+		imageEnv[e] = true
+	}
+	for _, e := range cfg.Env {
+		if !imageEnv[e] {
+			add("-e", e)
+		}
+	}
+
+	// 端口
+	if hc.PublishAllPorts {
+		args = append(args, "-P")
+	}
+	ports := make([]string, 0, len(hc.PortBindings))
+	for port := range hc.PortBindings {
+		ports = append(ports, string(port))
+	}
+	sort.Strings(ports)
+	for _, p := range ports {
+		containerPort := strings.TrimSuffix(p, "/tcp")
+		for _, b := range hc.PortBindings[nat.Port(p)] {
+			spec := containerPort
+			switch {
+			case b.HostIP != "" && b.HostIP != "0.0.0.0":
+				spec = b.HostIP + ":" + b.HostPort + ":" + containerPort
+			case b.HostPort != "":
+				spec = b.HostPort + ":" + containerPort
+			}
+			add("-p", spec)
+		}
+	}
+
+	// 挂载：匿名卷由镜像的 VOLUME 重新创建，不需要写出
+	for _, m := range info.Mounts {
+		mode := ""
+		if !m.RW {
+			mode = ":ro"
+		}
+		switch m.Type {
+		case mount.TypeBind:
+			source := m.Source
+			if RemoteHost() == "" {
+				// 命令在本机执行，使用本机 shell 能识别的路径格式
+				source = LocalHostPath(source)
+			}
+			add("-v", source+":"+m.Destination+mode)
+		case mount.TypeVolume:
+			if anonymousVolume.MatchString(m.Name) {
+				continue
+			}
+			add("-v", m.Name+":"+m.Destination+mode)
+		case mount.TypeTmpfs:
+			add("--tmpfs", m.Destination)
+		}
+	}
+	for dest := range hc.Tmpfs {
+		add("--tmpfs", dest)
+	}
+
+	// 网络
+	if mode := string(hc.NetworkMode); mode != "" && mode != "default" && mode != "bridge" {
+		add("--network", mode)
+	}
+	add("--add-host", hc.ExtraHosts...)
+	add("--dns", hc.DNS...)
+
+	// 重启策略和资源限制
+	if policy := string(hc.RestartPolicy.Name); policy != "" && policy != "no" {
+		if hc.RestartPolicy.MaximumRetryCount > 0 {
+			policy += ":" + strconv.Itoa(hc.RestartPolicy.MaximumRetryCount)
+		}
+		add("--restart", policy)
+	}
+	if hc.Memory > 0 {
+		add("--memory", strconv.FormatInt(hc.Memory/1024/1024, 10)+"m")
+	}
+	if hc.NanoCPUs > 0 {
+		add("--cpus", strconv.FormatFloat(float64(hc.NanoCPUs)/1e9, 'f', -1, 64))
+	}
+	if hc.CPUShares > 0 {
+		add("--cpu-shares", strconv.FormatInt(hc.CPUShares, 10))
+	}
+
+	// 权限
+	if hc.Privileged {
+		args = append(args, "--privileged")
+	}
+	add("--cap-add", hc.CapAdd...)
+	add("--cap-drop", hc.CapDrop...)
+	add("--security-opt", hc.SecurityOpt...)
+	if hc.ReadonlyRootfs {
+		args = append(args, "--read-only")
+	}
+	if hc.Init != nil && *hc.Init {
+		args = append(args, "--init")
+	}
+	for _, ns := range []struct{ flag, mode string }{{"--pid", string(hc.PidMode)}, {"--ipc", string(hc.IpcMode)}, {"--uts", string(hc.UTSMode)}} {
+		if ns.mode == "host" || strings.HasPrefix(ns.mode, "container:") {
+			add(ns.flag, ns.mode)
+		}
+	}
+
+	// 标签：去掉镜像自带的和 Compose 生成的
+	labelKeys := make([]string, 0, len(cfg.Labels))
+	for k, v := range cfg.Labels {
+		if image.Labels[k] == v || strings.HasPrefix(k, "com.docker.compose.") {
+			continue
+		}
+		labelKeys = append(labelKeys, k)
+	}
+	sort.Strings(labelKeys)
+	for _, k := range labelKeys {
+		add("--label", k+"="+cfg.Labels[k])
+	}
+
+	// 入口点只能覆盖为单个程序，其余部分并入命令参数
+	var command []string
+	if len(cfg.Entrypoint) > 0 && !equalStrings(cfg.Entrypoint, image.Entrypoint) {
+		add("--entrypoint", cfg.Entrypoint[0])
+		command = append(command, cfg.Entrypoint[1:]...)
+		command = append(command, cfg.Cmd...)
+	} else if !equalStrings(cfg.Cmd, image.Cmd) {
+		command = cfg.Cmd
+	}
+
+	args = append(args, shellQuote(cfg.Image))
+	if len(command) > 0 {
+		quoted := make([]string, len(command))
+		for i, a := range command {
+			quoted[i] = shellQuote(a)
+		}
+		args = append(args, strings.Join(quoted, " "))
+	}
+	return args
+}
+
+// FormatRunCommand 将参数格式化为多行 docker run 命令
+func FormatRunCommand(args []string) string {
+	return "docker run " + strings.Join(args, " \\\n  ")
+}
+
+// shellQuote 只在需要时为 POSIX shell 加单引号
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@,+%", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// equalStrings 比较两个字符串切片
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package docker
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SecurityLevel 安全检查结果等级
+type SecurityLevel int
+
+const (
+	SecurityOK   SecurityLevel = iota // 绿色：符合最佳实践
+	SecurityWarn                      // 黄色：值得注意
+	SecurityRisk                      // 红色：容器可能逃逸或控制宿主机
+)
+
+// SecurityFinding 一项安全检查结果
+type SecurityFinding struct {
+	Check  string        // 检查项
+	Level  SecurityLevel // 等级
+	Detail string        // 说明
+}
+
+// sensitiveHostPaths 挂载后需要提示的宿主机路径，true 表示可直接控制宿主机
+var sensitiveHostPaths = map[string]bool{
+	"/":                    true,
+	"/var/run/docker.sock": true,
+	"/run/docker.sock":     true,
+	"/run/containerd":      true,
+	"/var/lib/docker":      true,
+	"/etc":                 false,
+	"/root":                false,
+	"/proc":                false,
+	"/sys":                 false,
+	"/dev":                 false,
+	"/boot":                false,
+	"/home":                false,
+}
+
+// AnalyzeSecurity 根据 inspect 数据汇总容器的安全状况：是否以 root 运行、特权模式、
+// 敏感宿主机路径挂载、seccomp/AppArmor 配置和 no-new-privileges
+func AnalyzeSecurity(d *ContainerDetails) []SecurityFinding {
+	if d == nil {
+		return nil
+	}
+	sec := d.Security
+	var findings []SecurityFinding
+
+	// 运行用户
+	switch user, _, _ := strings.Cut(sec.User, ":"); user {
+	case "", "root", "0":
+		detail := "runs as root"
+		if sec.User == "" {
+			detail = "no user set, image default (usually root)"
+		}
+		findings = append(findings, SecurityFinding{Check: "User", Level: SecurityWarn, Detail: detail})
+	default:
+		findings = append(findings, SecurityFinding{Check: "User", Level: SecurityOK, Detail: "runs as " + sec.User})
+	}
+
+	// 特权模式
+	if sec.Privileged {
+		findings = append(findings, SecurityFinding{Check: "Privileged", Level: SecurityRisk, Detail: "all capabilities and host devices"})
+	} else {
+		findings = append(findings, SecurityFinding{Check: "Privileged", Level: SecurityOK, Detail: "no"})
+	}
+
+	// 敏感路径挂载
+	mounted := false
+	for _, m := range d.Mounts {
+		if m.Type != "bind" {
+			continue
+		}
+		source := strings.TrimSuffix(m.Source, "/")
+		if source == "" {
+			source = "/"
+		}
+		critical, ok := sensitiveHostPaths[source]
+		if !ok {
+			continue
+		}
+		mounted = true
+		level := SecurityWarn
+		if critical {
+			level = SecurityRisk
+		}
+		mode := m.Mode
+		if mode == "" {
+			mode = "rw"
+		}
+		findings = append(findings, SecurityFinding{
+			Check:  "Host mount",
+			Level:  level,
+			Detail: fmt.Sprintf("%s → %s (%s)", m.Source, m.Destination, mode),
+		})
+	}
+	if !mounted {
+		findings = append(findings, SecurityFinding{Check: "Host mount", Level: SecurityOK, Detail: "no sensitive host paths"})
+	}
+
+	// seccomp / AppArmor / no-new-privileges（安全选项可能写作 key=value 或旧的 key:value）
+	seccomp := "default profile"
+	seccompLevel := SecurityOK
+	noNewPrivs := false
+	for _, opt := range sec.SecurityOpt {
+		key, value, ok := strings.Cut(opt, "=")
+		if !ok {
+			key, value, _ = strings.Cut(opt, ":")
+		}
+		switch key {
+		case "seccomp":
+			if value == "unconfined" {
+				seccomp, seccompLevel = "unconfined", SecurityRisk
+			} else {
+				seccomp = "custom profile"
+			}
+		case "no-new-privileges":
+			noNewPrivs = value == "" || value == "true"
+		}
+	}
+	if sec.Privileged {
+		seccomp, seccompLevel = "disabled by privileged mode", SecurityRisk
+	}
+	findings = append(findings, SecurityFinding{Check: "Seccomp", Level: seccompLevel, Detail: seccomp})
+
+	switch profile := sec.AppArmorProfile; {
+	case profile == "unconfined" || sec.Privileged:
+		findings = append(findings, SecurityFinding{Check: "AppArmor", Level: SecurityWarn, Detail: "unconfined"})
+	case profile == "":
+		findings = append(findings, SecurityFinding{Check: "AppArmor", Level: SecurityWarn, Detail: "no profile applied"})
+	default:
+		findings = append(findings, SecurityFinding{Check: "AppArmor", Level: SecurityOK, Detail: profile})
+	}
+
+	if noNewPrivs {
+		findings = append(findings, SecurityFinding{Check: "No new privs", Level: SecurityOK, Detail: "enabled"})
+	} else {
+		findings = append(findings, SecurityFinding{Check: "No new privs", Level: SecurityWarn, Detail: "not set, setuid binaries can gain privileges"})
+	}
+	return findings
+}
+
+// secretKeyFragments 环境变量名包含这些片段时视为敏感值（如 DB_PASSWORD、GITHUB_TOKEN）
+var secretKeyFragments = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "CREDENTIAL", "PRIVATE"}
+
+// secretKeyWords 环境变量名按 _ - . 拆分后出现这些单词时视为敏感值（如 API_KEY、AUTH、PASS）
+var secretKeyWords = map[string]bool{"KEY": true, "APIKEY": true, "AUTH": true, "PASS": true, "PWD": true, "DSN": true, "SALT": true}
+
+// IsSecretEnv 判断环境变量是否可能包含密码/令牌等敏感值：按变量名匹配，或值是带密码的 URL
+func IsSecretEnv(key, value string) bool {
+	upper := strings.ToUpper(key)
+	// PWD 是当前工作目录，不是密码
+	if upper == "PWD" || upper == "OLDPWD" {
+		return false
+	}
+	for _, fragment := range secretKeyFragments {
+		if strings.Contains(upper, fragment) {
+			return true
+		}
+	}
+	for _, word := range strings.FieldsFunc(upper, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		if secretKeyWords[word] {
+			return true
+		}
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			return true
+		}
+	}
+	return false
+}
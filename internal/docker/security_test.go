@@ -0,0 +1,77 @@
+package docker
+
+import "testing"
+
+// TestAnalyzeSecurity 测试安全状况汇总
+func TestAnalyzeSecurity(t *testing.T) {
+	levels := func(d *ContainerDetails) map[string]SecurityLevel {
+		result := make(map[string]SecurityLevel)
+		for _, f := range AnalyzeSecurity(d) {
+			// 同一检查项有多条时取最高等级
+			if f.Level >= result[f.Check] {
+				result[f.Check] = f.Level
+			}
+		}
+		return result
+	}
+
+	hardened := levels(&ContainerDetails{
+		Security: ContainerSecurity{
+			User:            "1000:1000",
+			SecurityOpt:     []string{"no-new-privileges:true"},
+			AppArmorProfile: "docker-default",
+		},
+		Mounts: []MountInfo{{Type: "volume", Source: "/var/lib/docker/volumes/data/_data", Destination: "/data"}},
+	})
+	for check, level := range hardened {
+		if level != SecurityOK {
+			t.Errorf("hardened container: expected %s to be OK, got %d", check, level)
+		}
+	}
+
+	risky := levels(&ContainerDetails{
+		Security: ContainerSecurity{Privileged: true, SecurityOpt: []string{"seccomp=unconfined"}},
+		Mounts:   []MountInfo{{Type: "bind", Source: "/var/run/docker.sock", Destination: "/var/run/docker.sock"}},
+	})
+	expected := map[string]SecurityLevel{
+		"User":         SecurityWarn,
+		"Privileged":   SecurityRisk,
+		"Host mount":   SecurityRisk,
+		"Seccomp":      SecurityRisk,
+		"No new privs": SecurityWarn,
+	}
+	for check, level := range expected {
+		if risky[check] != level {
+			t.Errorf("risky container: expected %s level %d, got %d", check, level, risky[check])
+		}
+	}
+
+	if AnalyzeSecurity(nil) != nil {
+		t.Error("expected nil findings for nil details")
+	}
+}
+
+// TestIsSecretEnv 测试敏感环境变量识别
+func TestIsSecretEnv(t *testing.T) {
+	cases := []struct {
+		key, value string
+		secret     bool
+	}{
+		{"POSTGRES_PASSWORD", "dev", true},
+		{"GITHUB_TOKEN", "ghp_x", true},
+		{"AWS_SECRET_ACCESS_KEY", "x", true},
+		{"STRIPE_API_KEY", "sk_x", true},
+		{"REDIS_PASS", "x", true},
+		{"DATABASE_URL", "postgres://app:hunter2@db:5432/app", true},
+		{"DATABASE_URL", "postgres://db:5432/app", false},
+		{"PATH", "/usr/bin", false},
+		{"PWD", "/app", false},
+		{"KEYBOARD_LAYOUT", "us", false},
+		{"MONKEY_MODE", "on", false},
+	}
+	for _, c := range cases {
+		if got := IsSecretEnv(c.key, c.value); got != c.secret {
+			t.Errorf("IsSecretEnv(%q, %q) = %v, want %v", c.key, c.value, got, c.secret)
+		}
+	}
+}
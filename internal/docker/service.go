@@ -0,0 +1,286 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+
+	"docktui/internal/audit"
+)
+
+// Service Swarm 服务的基本信息（用于服务列表）
+type Service struct {
+	ID      string
+	ShortID string
+	Name    string
+	Image   string // 镜像（去掉 @sha256 摘要）
+	Mode    string // replicated, global, replicated-job, global-job
+
+	RunningTasks uint64 // 正在运行的任务数
+	DesiredTasks uint64 // 期望的任务数（replicated 模式即副本数）
+
+	UpdateState   string // updating, paused, completed, rollback_started...，为空表示从未更新
+	UpdateMessage string
+	Ports         string // 发布的端口，如 "8080->80/tcp"
+	UpdatedAt     time.Time
+	StackName     string // docker stack 部署时的栈名称
+}
+
+// Replicas 以 running/desired 形式显示副本数
+func (s Service) Replicas() string {
+	return fmt.Sprintf("%d/%d", s.RunningTasks, s.DesiredTasks)
+}
+
+// IsReplicated 是否可以调整副本数
+func (s Service) IsReplicated() bool {
+	return s.Mode == "replicated"
+}
+
+// SwarmManager 当前守护进程是否为 Swarm 管理节点（只有管理节点能查看和修改服务）
+func (c *LocalClient) SwarmManager(ctx context.Context) (bool, error) {
+	if c == nil || c.cli == nil {
+		return false, fmt.Errorf("Docker client not initialized")
+	}
+	info, err := c.cli.Info(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get daemon info: %w", err)
+	}
+	return info.Swarm.LocalNodeState == swarm.LocalNodeStateActive && info.Swarm.ControlAvailable, nil
+}
+
+// ListServices 获取 Swarm 服务列表（按名称排序）
+func (c *LocalClient) ListServices(ctx context.Context) ([]Service, error) {
+	if c == nil || c.cli == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+	services, err := c.cli.ServiceList(ctx, types.ServiceListOptions{Status: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	result := make([]Service, 0, len(services))
+	for _, svc := range services {
+		result = append(result, convertService(svc))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// convertService 将 SDK 的服务结构转换为列表信息
+func convertService(svc swarm.Service) Service {
+	s := Service{
+		ID:        svc.ID,
+		ShortID:   svc.ID,
+		Name:      svc.Spec.Name,
+		UpdatedAt: svc.UpdatedAt,
+		StackName: svc.Spec.Labels["com.docker.stack.namespace"],
+	}
+	if len(s.ShortID) > 12 {
+		s.ShortID = s.ShortID[:12]
+	}
+	if svc.Spec.TaskTemplate.ContainerSpec != nil {
+		s.Image, _, _ = strings.Cut(svc.Spec.TaskTemplate.ContainerSpec.Image, "@")
+	}
+
+	switch mode := svc.Spec.Mode; {
+	case mode.Replicated != nil:
+		s.Mode = "replicated"
+	case mode.Global != nil:
+		s.Mode = "global"
+	case mode.ReplicatedJob != nil:
+		s.Mode = "replicated-job"
+	case mode.GlobalJob != nil:
+		s.Mode = "global-job"
+	}
+	if svc.ServiceStatus != nil {
+		s.RunningTasks = svc.ServiceStatus.RunningTasks
+		s.DesiredTasks = svc.ServiceStatus.DesiredTasks
+	}
+	if svc.UpdateStatus != nil {
+		s.UpdateState = string(svc.UpdateStatus.State)
+		s.UpdateMessage = svc.UpdateStatus.Message
+	}
+
+	var ports []string
+	for _, p := range svc.Endpoint.Ports {
+		if p.PublishedPort != 0 {
+			ports = append(ports, fmt.Sprintf("%d->%d/%s", p.PublishedPort, p.TargetPort, p.Protocol))
+		}
+	}
+	s.Ports = strings.Join(ports, ", ")
+	return s
+}
+
+// InspectServiceRaw 获取服务的原始 JSON 数据
+func (c *LocalClient) InspectServiceRaw(ctx context.Context, serviceID string) (string, error) {
+	if c == nil || c.cli == nil {
+		return "", fmt.Errorf("Docker client not initialized")
+	}
+	svc, _, err := c.cli.ServiceInspectWithRaw(ctx, serviceID, types.ServiceInspectOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect service: %w", err)
+	}
+	data, err := json.MarshalIndent(svc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format service JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// ScaleService 调整 replicated 服务的副本数（相当于 docker service scale）
+func (c *LocalClient) ScaleService(ctx context.Context, serviceID string, replicas uint64) error {
+	if c == nil || c.cli == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+	svc, _, err := c.cli.ServiceInspectWithRaw(ctx, serviceID, types.ServiceInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to inspect service: %w", err)
+	}
+	target := fmt.Sprintf("%s=%d", svc.Spec.Name, replicas)
+	if err := checkAction("service.scale", target); err != nil {
+		return err
+	}
+	if svc.Spec.Mode.Replicated == nil {
+		return fmt.Errorf("service %s is not in replicated mode", svc.Spec.Name)
+	}
+
+	spec := svc.Spec
+	spec.Mode.Replicated.Replicas = &replicas
+	_, err = c.cli.ServiceUpdate(ctx, svc.ID, svc.Version, spec, types.ServiceUpdateOptions{})
+	audit.Record("service.scale", target, err)
+	if err != nil {
+		return fmt.Errorf("failed to scale service: %w", err)
+	}
+	return nil
+}
+
+// ForceUpdateService 强制滚动更新服务的所有任务（相当于 docker service update --force）
+func (c *LocalClient) ForceUpdateService(ctx context.Context, serviceID string) error {
+	if c == nil || c.cli == nil {
+		return fmt.Errorf("Docker client not initialized")
+	}
+	svc, _, err := c.cli.ServiceInspectWithRaw(ctx, serviceID, types.ServiceInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to inspect service: %w", err)
+	}
+	if err := checkAction("service.update", svc.Spec.Name); err != nil {
+		return err
+	}
+
+	spec := svc.Spec
+	spec.TaskTemplate.ForceUpdate++
+	_, err = c.cli.ServiceUpdate(ctx, svc.ID, svc.Version, spec, types.ServiceUpdateOptions{})
+	audit.Record("service.update", svc.Spec.Name, err)
+	if err != nil {
+		return fmt.Errorf("failed to update service: %w", err)
+	}
+	return nil
+}
+
+// SwarmObject Swarm secret 或 config 的元数据（不包含内容）
+type SwarmObject struct {
+	ID       string
+	Name     string
+	Created  time.Time
+	Updated  time.Time
+	Labels   map[string]string
+	Driver   string   // secret 的外部驱动，为空表示存储在 Swarm 中
+	Services []string // 引用它的服务名称（按名称排序）
+}
+
+// ListSecrets 获取 Swarm secret 列表及引用它们的服务
+func (c *LocalClient) ListSecrets(ctx context.Context) ([]SwarmObject, error) {
+	if c == nil || c.cli == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+	secrets, err := c.cli.SecretList(ctx, types.SecretListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	users, err := c.swarmReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]SwarmObject, 0, len(secrets))
+	for _, s := range secrets {
+		obj := SwarmObject{
+			ID:       s.ID,
+			Name:     s.Spec.Name,
+			Created:  s.CreatedAt,
+			Updated:  s.UpdatedAt,
+			Labels:   s.Spec.Labels,
+			Services: users["secret/"+s.ID],
+		}
+		if s.Spec.Driver != nil {
+			obj.Driver = s.Spec.Driver.Name
+		}
+		result = append(result, obj)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// ListConfigs 获取 Swarm config 列表及引用它们的服务
+func (c *LocalClient) ListConfigs(ctx context.Context) ([]SwarmObject, error) {
+	if c == nil || c.cli == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+	configs, err := c.cli.ConfigList(ctx, types.ConfigListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configs: %w", err)
+	}
+	users, err := c.swarmReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]SwarmObject, 0, len(configs))
+	for _, cfg := range configs {
+		result = append(result, SwarmObject{
+			ID:       cfg.ID,
+			Name:     cfg.Spec.Name,
+			Created:  cfg.CreatedAt,
+			Updated:  cfg.UpdatedAt,
+			Labels:   cfg.Spec.Labels,
+			Services: users["config/"+cfg.ID],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// swarmReferences 统计各 secret/config 被哪些服务引用，键为 "secret/<ID>" 或 "config/<ID>"
+func (c *LocalClient) swarmReferences(ctx context.Context) (map[string][]string, error) {
+	services, err := c.cli.ServiceList(ctx, types.ServiceListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	refs := make(map[string][]string)
+	for _, svc := range services {
+		spec := svc.Spec.TaskTemplate.ContainerSpec
+		if spec == nil {
+			continue
+		}
+		for _, s := range spec.Secrets {
+			if s != nil {
+				refs["secret/"+s.SecretID] = append(refs["secret/"+s.SecretID], svc.Spec.Name)
+			}
+		}
+		for _, cfg := range spec.Configs {
+			if cfg != nil {
+				refs["config/"+cfg.ConfigID] = append(refs["config/"+cfg.ConfigID], svc.Spec.Name)
+			}
+		}
+	}
+	for _, names := range refs {
+		sort.Strings(names)
+	}
+	return refs, nil
+}
@@ -0,0 +1,121 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/docker/docker/client"
+)
+
+// sshDummyHost ssh 连接时占位的 HTTP 地址，实际连接由 dialer 建立
+const sshDummyHost = "http://docker.example.com"
+
+// sshClientOpts 解析 ssh://[user@]host[:port] 地址，返回通过 ssh 在远程主机上运行 docker system dial-stdio
+// 建立连接的 SDK 参数（与 docker CLI 的 ssh:// 支持相同，需要本机有 ssh 且远程主机有 docker CLI）
+func sshClientOpts(host string) ([]sdk.Opt, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ssh host %q: %w", host, err)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid ssh host %q: missing host name", host)
+	}
+	if u.Path != "" && u.Path != "/" {
+		return nil, fmt.Errorf("invalid ssh host %q: paths are not supported", host)
+	}
+	var args []string
+	if u.User != nil {
+		args = append(args, "-l", u.User.Username())
+	}
+	if port := u.Port(); port != "" {
+		args = append(args, "-p", port)
+	}
+	args = append(args, "--", u.Hostname(), "docker", "system", "dial-stdio")
+
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dialCommand(exec.Command("ssh", args...))
+	}
+	return []sdk.Opt{sdk.WithHost(sshDummyHost), sdk.WithDialContext(dial)}, nil
+}
+
+// isSSHHost 地址是否为 ssh://
+func isSSHHost(host string) bool {
+	return strings.HasPrefix(host, "ssh://")
+}
+
+// commandConn 把子进程的标准输入输出包装成 net.Conn
+type commandConn struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr *bytes.Buffer
+
+	closeOnce sync.Once
+}
+
+// dialCommand 启动子进程并返回连接
+func dialCommand(cmd *exec.Cmd) (net.Conn, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	c := &commandConn{cmd: cmd, stdin: stdin, stdout: stdout, stderr: &bytes.Buffer{}}
+	cmd.Stderr = c.stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", cmd.Path, err)
+	}
+	return c, nil
+}
+
+func (c *commandConn) Read(p []byte) (int, error) {
+	n, err := c.stdout.Read(p)
+	if err == io.EOF && n == 0 {
+		// 进程退出时把 ssh 的报错（如认证失败、远程没有 docker）带上
+		if msg := strings.TrimSpace(c.stderr.String()); msg != "" {
+			return 0, fmt.Errorf("ssh: %s", msg)
+		}
+	}
+	return n, err
+}
+
+func (c *commandConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+// Close 关闭输入输出并结束子进程
+func (c *commandConn) Close() error {
+	c.closeOnce.Do(func() {
+		_ = c.stdin.Close()
+		if c.cmd.Process != nil {
+			_ = c.cmd.Process.Kill()
+		}
+		_ = c.cmd.Wait()
+	})
+	return nil
+}
+
+func (c *commandConn) LocalAddr() net.Addr  { return dummyAddr{} }
+func (c *commandConn) RemoteAddr() net.Addr { return dummyAddr{} }
+
+// 子进程的管道不支持超时，由调用方的 context 控制
+func (c *commandConn) SetDeadline(time.Time) error      { return nil }
+func (c *commandConn) SetReadDeadline(time.Time) error  { return nil }
+func (c *commandConn) SetWriteDeadline(time.Time) error { return nil }
+
+// dummyAddr 命令连接的地址
+type dummyAddr struct{}
+
+func (dummyAddr) Network() string { return "cmd" }
+func (dummyAddr) String() string  { return "ssh" }
@@ -0,0 +1,72 @@
+package docker
+
+import (
+	"context"
+	"os"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/docker/docker/api/types/container"
+)
+
+// TerminalSize 返回当前终端（标准输出）的列数和行数，不是终端时返回 0
+func TerminalSize() (width, height int) {
+	width, height, err := term.GetSize(os.Stdout.Fd())
+	if err != nil {
+		return 0, 0
+	}
+	return width, height
+}
+
+// rawTerminal 把标准输入切换为原始模式：按键逐个发送给容器，回显和 Ctrl+C 由容器内的 TTY 处理；
+// Windows 控制台同时开启输出的 VT 序列处理。返回恢复函数，标准输入不是终端时不做修改
+func rawTerminal() (restore func(), err error) {
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return func() {}, nil
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	restoreOutput := enableVTOutput()
+	return func() {
+		restoreOutput()
+		_ = term.Restore(fd, state)
+	}, nil
+}
+
+// consoleSize 返回 exec 的初始 TTY 尺寸（[行, 列]），不是终端时返回 nil 使用守护进程默认值
+func consoleSize() *[2]uint {
+	width, height := TerminalSize()
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	return &[2]uint{uint(height), uint(width)}
+}
+
+// resizeExec 把 exec 会话的 TTY 调整为当前终端尺寸
+func (c *LocalClient) resizeExec(ctx context.Context, execID string) {
+	width, height := TerminalSize()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	_ = c.cli.ContainerExecResize(ctx, execID, container.ResizeOptions{Width: uint(width), Height: uint(height)})
+}
+
+// watchExecResize 终端尺寸变化时同步调整 exec 会话的 TTY，直到 ctx 结束；
+// shell 运行期间 Bubble Tea 已释放终端，收不到 WindowSizeMsg，因此直接监听终端
+func (c *LocalClient) watchExecResize(ctx context.Context, execID string) {
+	c.resizeExec(ctx, execID)
+	go func() {
+		changes, stop := terminalResizes()
+		defer stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changes:
+				c.resizeExec(ctx, execID)
+			}
+		}
+	}()
+}
@@ -0,0 +1,21 @@
+//go:build !windows
+
+package docker
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// terminalResizes 返回终端尺寸变化的通知（SIGWINCH），stop 停止监听
+func terminalResizes() (<-chan os.Signal, func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGWINCH)
+	return ch, func() { signal.Stop(ch) }
+}
+
+// enableVTOutput 类 Unix 终端直接解释 VT 序列，无需设置
+func enableVTOutput() func() {
+	return func() {}
+}
@@ -0,0 +1,51 @@
+package docker
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// terminalResizePoll Windows 控制台没有 SIGWINCH，按该间隔检查尺寸变化
+const terminalResizePoll = 250 * time.Millisecond
+
+// terminalResizes 返回终端尺寸变化的通知（轮询），stop 停止检查
+func terminalResizes() (<-chan os.Signal, func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(terminalResizePoll)
+		defer ticker.Stop()
+		width, height := TerminalSize()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if w, h := TerminalSize(); w != width || h != height {
+					width, height = w, h
+					select {
+					case ch <- os.Interrupt:
+					default:
+					}
+				}
+			}
+		}
+	}()
+	return ch, func() { close(done) }
+}
+
+// enableVTOutput 开启控制台输出的 VT 序列处理（容器内 shell 的颜色、光标移动），返回恢复函数；
+// Windows Terminal 默认已开启，旧版控制台（conhost、PowerShell 窗口）需要显式设置
+func enableVTOutput() func() {
+	handle := windows.Handle(os.Stdout.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return func() {}
+	}
+	if err := windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING|windows.DISABLE_NEWLINE_AUTO_RETURN); err != nil {
+		return func() {}
+	}
+	return func() { _ = windows.SetConsoleMode(handle, mode) }
+}
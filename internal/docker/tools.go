@@ -0,0 +1,149 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// probedTools 探测的常用工具：shell 以及诊断时用到的网络和进程工具
+var probedTools = []string{
+	"bash", "sh", "ash", "zsh", "fish", "ksh",
+	"curl", "wget", "nc", "ip", "ps", "getent", "nslookup", "dig", "host",
+}
+
+// toolSearchDirs 容器没有 shell（或未运行）时逐个检查的目录
+var toolSearchDirs = []string{"/bin", "/usr/bin", "/usr/local/bin", "/sbin", "/usr/sbin"}
+
+// NoShellHint 容器中没有 sh 时的提示
+const NoShellHint = "distroless image — no shell available"
+
+// ContainerTools 容器中探测到的常用工具
+type ContainerTools struct {
+	Paths map[string]string // 工具名 -> 路径，只包含存在的工具
+}
+
+// Has 工具是否存在
+func (t *ContainerTools) Has(name string) bool {
+	return t != nil && t.Paths[name] != ""
+}
+
+// HasAny 任一工具存在
+func (t *ContainerTools) HasAny(names ...string) bool {
+	for _, name := range names {
+		if t.Has(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Path 工具的路径，不存在时为空
+func (t *ContainerTools) Path(name string) string {
+	if t == nil {
+		return ""
+	}
+	return t.Paths[name]
+}
+
+// toolCache 按容器 ID 缓存探测结果；镜像内容在容器生命周期内基本不变，重建的容器 ID 不同
+type toolCache struct {
+	mu    sync.Mutex
+	tools map[string]*ContainerTools
+}
+
+func (c *toolCache) get(containerID string) *ContainerTools {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tools[containerID]
+}
+
+func (c *toolCache) put(containerID string, tools *ContainerTools) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tools == nil {
+		c.tools = make(map[string]*ContainerTools)
+	}
+	c.tools[containerID] = tools
+}
+
+// ProbeTools 探测容器中的常用工具（每个容器只探测一次）：有 sh 时用一次 command -v 查找，
+// 否则通过 API 检查常见目录中的文件，不需要容器中有任何命令
+func (c *LocalClient) ProbeTools(ctx context.Context, containerID string) (*ContainerTools, error) {
+	if c == nil || c.cli == nil {
+		return nil, fmt.Errorf("Docker client not initialized")
+	}
+	if tools := c.tools.get(containerID); tools != nil {
+		return tools, nil
+	}
+
+	tools := &ContainerTools{Paths: make(map[string]string)}
+	if paths, ok := c.probeToolsWithShell(ctx, containerID); ok {
+		tools.Paths = paths
+	} else {
+		for _, name := range probedTools {
+			for _, dir := range toolSearchDirs {
+				p := path.Join(dir, name)
+				if _, err := c.cli.ContainerStatPath(ctx, containerID, p); err == nil {
+					tools.Paths[name] = p
+					break
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	c.tools.put(containerID, tools)
+	return tools, nil
+}
+
+// probeToolsWithShell 通过 sh 的 command -v 一次查找所有工具；容器没有 sh 或未运行时返回 false
+func (c *LocalClient) probeToolsWithShell(ctx context.Context, containerID string) (map[string]string, bool) {
+	script := `for t in "$@"; do p=$(command -v "$t" 2>/dev/null) && echo "$t=$p"; done; exit 0`
+	cmd := append([]string{"/bin/sh", "-c", script, "probe"}, probedTools...)
+	created, err := c.cli.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          cmd,
+	})
+	if err != nil {
+		return nil, false
+	}
+	attached, err := c.cli.ContainerExecAttach(ctx, created.ID, container.ExecStartOptions{})
+	if err != nil {
+		return nil, false
+	}
+	defer attached.Close()
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, attached.Reader); err != nil {
+		return nil, false
+	}
+	if inspect, err := c.cli.ContainerExecInspect(ctx, created.ID); err != nil || inspect.ExitCode != 0 {
+		return nil, false
+	}
+	paths := parseToolProbe(stdout.String())
+	if paths["sh"] == "" {
+		return nil, false
+	}
+	return paths, true
+}
+
+// parseToolProbe 解析 "名称=路径" 行；shell 内置命令（command -v 只输出名称）也算存在
+func parseToolProbe(output string) map[string]string {
+	paths := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		name, p, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if ok && name != "" && p != "" {
+			paths[name] = p
+		}
+	}
+	return paths
+}
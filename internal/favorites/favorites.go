@@ -0,0 +1,199 @@
+// Package favorites 记录收藏的资源（容器、镜像、Compose 项目）和最近访问的资源，
+// 保存在本机配置目录，首页据此提供一键跳转
+package favorites
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// 资源类型
+const (
+	KindContainer = "container"
+	KindImage     = "image"
+	KindCompose   = "compose"
+)
+
+// MaxRecent 最多保留的最近访问记录数
+const MaxRecent = 10
+
+// Ref 一个可跳转的资源
+type Ref struct {
+	Kind         string    `json:"kind"`
+	Key          string    `json:"key"`                     // 容器名称、镜像 ID 或 Compose 项目目录
+	Name         string    `json:"name"`                    // 显示名称（容器名、repo:tag、项目名）
+	ComposeFiles []string  `json:"compose_files,omitempty"` // Compose 项目的配置文件，用于重新打开项目
+	EnvFiles     []string  `json:"env_files,omitempty"`     // Compose 项目的环境变量文件
+	At           time.Time `json:"at"`
+}
+
+// Same 是否是同一个资源
+func (r Ref) Same(other Ref) bool {
+	return r.Kind == other.Kind && r.Key == other.Key
+}
+
+// state 状态文件结构
+type state struct {
+	Favorites []Ref `json:"favorites"`
+	Recent    []Ref `json:"recent"`
+}
+
+// Store 收藏和最近访问记录文件
+type Store struct {
+	path  string
+	state state
+	mu    sync.RWMutex
+}
+
+var (
+	defaultStore *Store
+	defaultMu    sync.RWMutex
+)
+
+// DefaultPath 返回默认状态文件路径（用户配置目录下的 docktui/favorites.json）
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "favorites.json"), nil
+}
+
+// Open 读取状态文件，文件不存在时视为空
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read favorites: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, fmt.Errorf("failed to parse favorites: %w", err)
+	}
+	return s, nil
+}
+
+// IsFavorite 是否已收藏
+func (s *Store) IsFavorite(kind, key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return indexOf(s.state.Favorites, Ref{Kind: kind, Key: key}) >= 0
+}
+
+// ToggleFavorite 收藏或取消收藏，返回操作后是否为收藏状态
+func (s *Store) ToggleFavorite(ref Ref) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := indexOf(s.state.Favorites, ref); i >= 0 {
+		s.state.Favorites = append(s.state.Favorites[:i], s.state.Favorites[i+1:]...)
+		return false, s.flushLocked()
+	}
+	ref.At = time.Now()
+	s.state.Favorites = append(s.state.Favorites, ref)
+	return true, s.flushLocked()
+}
+
+// Touch 记录一次访问，最近访问的排在最前
+func (s *Store) Touch(ref Ref) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := indexOf(s.state.Recent, ref); i >= 0 {
+		s.state.Recent = append(s.state.Recent[:i], s.state.Recent[i+1:]...)
+	}
+	ref.At = time.Now()
+	s.state.Recent = append([]Ref{ref}, s.state.Recent...)
+	if len(s.state.Recent) > MaxRecent {
+		s.state.Recent = s.state.Recent[:MaxRecent]
+	}
+	return s.flushLocked()
+}
+
+// Favorites 返回收藏列表（按收藏顺序）
+func (s *Store) Favorites() []Ref {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Ref(nil), s.state.Favorites...)
+}
+
+// Recent 返回最近访问列表（最近的在前）
+func (s *Store) Recent() []Ref {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Ref(nil), s.state.Recent...)
+}
+
+// flushLocked 写回状态文件（调用方需持有锁）
+func (s *Store) flushLocked() error {
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create favorites directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save favorites: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// indexOf 查找资源在列表中的位置，不存在返回 -1
+func indexOf(refs []Ref, ref Ref) int {
+	for i, r := range refs {
+		if r.Same(ref) {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetDefault 设置全局状态文件
+func SetDefault(s *Store) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultStore = s
+}
+
+// Default 返回全局状态文件，未启用时返回 nil
+func Default() *Store {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultStore
+}
+
+// IsFavorite 全局状态文件中是否已收藏，未启用时返回 false
+func IsFavorite(kind, key string) bool {
+	s := Default()
+	return s != nil && key != "" && s.IsFavorite(kind, key)
+}
+
+// Touch 在全局状态文件中记录一次访问，未启用或写入失败时忽略（只影响首页的最近列表）
+func Touch(ref Ref) {
+	if s := Default(); s != nil && ref.Key != "" {
+		_ = s.Touch(ref)
+	}
+}
+
+// Toggle 在全局状态文件中收藏或取消收藏，返回操作后是否为收藏状态
+func Toggle(ref Ref) (bool, error) {
+	s := Default()
+	if s == nil {
+		return false, fmt.Errorf("favorites are not available")
+	}
+	return s.ToggleFavorite(ref)
+}
+
+// Badge 返回列表名称后的收藏标记，未收藏时返回空字符串
+func Badge(kind, key string) string {
+	if IsFavorite(kind, key) {
+		return " ★"
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+// Package fleet 读取多主机配置（hosts.yaml），并发查询每台主机的容器数量和异常容器，
+// 用于在一个视图中查看所有主机的概况
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"docktui/internal/docker"
+)
+
+// QueryTimeout 单台主机的查询超时
+const QueryTimeout = 5 * time.Second
+
+// Host 一台配置的主机
+type Host struct {
+	Name string `yaml:"name"` // 显示名称
+	Host string `yaml:"host"` // 守护进程地址，与 DOCKER_HOST 格式相同
+
+	Context       string `yaml:"-"` // 从 docker CLI 上下文发现的主机为上下文名称
+	TLSDir        string `yaml:"-"` // 上下文保存的 TLS 证书目录
+	SkipTLSVerify bool   `yaml:"-"`
+}
+
+// Endpoint 连接该主机使用的端点
+func (h Host) Endpoint() docker.Endpoint {
+	return docker.Endpoint{Host: h.Host, TLSDir: h.TLSDir, SkipTLSVerify: h.SkipTLSVerify}
+}
+
+// Config 多主机配置
+type Config struct {
+	Hosts []Host `yaml:"hosts"`
+}
+
+// DefaultPath 返回默认配置文件路径（用户配置目录下的 docktui/hosts.yaml）
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "hosts.yaml"), nil
+}
+
+// Load 读取并校验多主机配置
+func Load(path string) ([]Host, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	seen := make(map[string]bool)
+	for i := range cfg.Hosts {
+		h := &cfg.Hosts[i]
+		h.Name = strings.TrimSpace(h.Name)
+		h.Host = strings.TrimSpace(h.Host)
+		if h.Host == "" {
+			return nil, fmt.Errorf("host #%d: host is required", i+1)
+		}
+		if h.Name == "" {
+			h.Name = h.Host
+		}
+		if seen[h.Name] {
+			return nil, fmt.Errorf("host %q is listed twice", h.Name)
+		}
+		seen[h.Name] = true
+		u, err := url.Parse(h.Host)
+		if err != nil || u.Scheme == "" {
+			return nil, fmt.Errorf("%s: invalid host %q (expected unix://, tcp://, ssh:// or npipe://)", h.Name, h.Host)
+		}
+	}
+	return cfg.Hosts, nil
+}
+
+// WithContexts 在配置的主机后追加 docker CLI 上下文（~/.docker/contexts）中的端点；
+// 名称或地址与已配置的主机相同的上下文跳过，配置文件中的设置优先
+func WithContexts(hosts []Host, contexts []docker.CLIContext) []Host {
+	names := make(map[string]bool, len(hosts))
+	addrs := make(map[string]bool, len(hosts))
+	for _, h := range hosts {
+		names[h.Name] = true
+		addrs[h.Host] = true
+	}
+	for _, c := range contexts {
+		if names[c.Name] || addrs[c.Endpoint.Host] {
+			continue
+		}
+		names[c.Name] = true
+		addrs[c.Endpoint.Host] = true
+		hosts = append(hosts, Host{
+			Name:          c.Name,
+			Host:          c.Endpoint.Host,
+			Context:       c.Name,
+			TLSDir:        c.Endpoint.TLSDir,
+			SkipTLSVerify: c.Endpoint.SkipTLSVerify,
+		})
+	}
+	return hosts
+}
+
+// Status 一台主机的查询结果
+type Status struct {
+	Host      Host
+	Total     int
+	Running   int
+	Exited    int // 非零退出码的已停止容器
+	Unhealthy int
+	Latency   time.Duration
+	Err       error
+}
+
+// Summarize 统计容器列表中运行中、异常退出和不健康的容器
+func Summarize(containers []docker.Container) Status {
+	var s Status
+	s.Total = len(containers)
+	for _, c := range containers {
+		switch c.State {
+		case "running":
+			s.Running++
+			if c.Health() == "unhealthy" {
+				s.Unhealthy++
+			}
+		case "exited", "dead":
+			// 正常停止（Exited (0)）不算异常
+			if !strings.HasPrefix(c.Status, "Exited (0)") {
+				s.Exited++
+			}
+		}
+	}
+	return s
+}
+
+// Check 查询一台主机的容器概况
+func Check(ctx context.Context, h Host) Status {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	start := time.Now()
+	client, err := docker.NewLocalClientForEndpoint(h.Endpoint())
+	if err != nil {
+		return Status{Host: h, Err: err}
+	}
+	defer client.Close()
+	containers, err := client.ListContainers(ctx, true)
+	if err != nil {
+		return Status{Host: h, Err: err}
+	}
+	s := Summarize(containers)
+	s.Host = h
+	s.Latency = time.Since(start)
+	return s
+}
@@ -0,0 +1,129 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"sync"
+)
+
+// 大小单位的配置值
+const (
+	UnitsBinary = "binary" // 1024 进制，KB/MB（默认，与之前的显示一致）
+	UnitsIEC    = "iec"    // 1024 进制，KiB/MiB
+	UnitsSI     = "si"     // 1000 进制，kB/MB（与 docker CLI 一致）
+)
+
+// 相对时间的语言
+const (
+	LocaleAuto = "auto" // 按 LC_ALL / LC_TIME / LANG 检测
+	LocaleEN   = "en"
+	LocaleZH   = "zh"
+)
+
+// DefaultDecimals 大小默认保留的小数位数
+const DefaultDecimals = 1
+
+// MaxDecimals 大小最多保留的小数位数
+const MaxDecimals = 3
+
+// Options 大小和时间的显示方式
+type Options struct {
+	Units    string // binary、iec 或 si
+	Decimals int    // 大小保留的小数位数
+	Locale   string // 相对时间的语言：en 或 zh（auto 在 Configure 时解析）
+}
+
+var (
+	mu      sync.RWMutex
+	current = Options{Units: UnitsBinary, Decimals: DefaultDecimals, Locale: LocaleEN}
+)
+
+// Configure 设置显示方式；未知的单位按 binary，小数位数超出范围时取边界值
+func Configure(opts Options) {
+	switch opts.Units = strings.ToLower(opts.Units); opts.Units {
+	case UnitsIEC, UnitsSI:
+	default:
+		opts.Units = UnitsBinary
+	}
+	if opts.Decimals < 0 {
+		opts.Decimals = 0
+	} else if opts.Decimals > MaxDecimals {
+		opts.Decimals = MaxDecimals
+	}
+	opts.Locale = ResolveLocale(opts.Locale)
+	mu.Lock()
+	current = opts
+	mu.Unlock()
+}
+
+// Current 返回当前的显示方式
+func Current() Options {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// ResolveLocale 把配置值解析为 en 或 zh；auto 或空值时按 locale 环境变量检测
+func ResolveLocale(locale string) string {
+	switch strings.ToLower(strings.TrimSpace(locale)) {
+	case LocaleZH:
+		return LocaleZH
+	case LocaleEN:
+		return LocaleEN
+	}
+	for _, name := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			if strings.HasPrefix(strings.ToLower(value), "zh") {
+				return LocaleZH
+			}
+			return LocaleEN
+		}
+	}
+	return LocaleEN
+}
+
+// unitBase 返回进制和各级单位名
+func unitBase(units string) (float64, []string) {
+	switch units {
+	case UnitsIEC:
+		return 1024, []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	case UnitsSI:
+		return 1000, []string{"kB", "MB", "GB", "TB", "PB", "EB"}
+	}
+	return 1024, []string{"KB", "MB", "GB", "TB", "PB", "EB"}
+}
+
+// scale 把字节数换算成数值和单位
+func scale(bytes float64, opts Options) (string, string) {
+	base, names := unitBase(opts.Units)
+	if math.Abs(bytes) < base {
+		return fmt.Sprintf("%.0f", bytes), "B"
+	}
+	exp := 0
+	value := bytes / base
+	for math.Abs(value) >= base && exp < len(names)-1 {
+		value /= base
+		exp++
+	}
+	return fmt.Sprintf("%.*f", opts.Decimals, value), names[exp]
+}
+
+// Size 格式化字节数，如 "1.5 GB"
+func Size(bytes int64) string {
+	value, unit := scale(float64(bytes), Current())
+	return value + " " + unit
+}
+
+// CompactSize 不带空格的字节数，如 "1.5GB"，用于统计等紧凑的列
+func CompactSize(bytes uint64) string {
+	value, unit := scale(float64(bytes), Current())
+	return value + unit
+}
+
+// Rate 格式化字节速率，如 "1.5MB/s"
+func Rate(bytesPerSec float64) string {
+	value, unit := scale(bytesPerSec, Current())
+	return value + unit + "/s"
+}
@@ -0,0 +1,169 @@
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+// withOptions 在测试期间使用指定的显示方式
+func withOptions(t *testing.T, opts Options) {
+	t.Helper()
+	prev := Current()
+	Configure(opts)
+	t.Cleanup(func() { Configure(prev) })
+}
+
+// TestSize_Units 测试三种单位和小数位数
+func TestSize_Units(t *testing.T) {
+	tests := []struct {
+		opts  Options
+		bytes int64
+		want  string
+	}{
+		{Options{Units: UnitsBinary, Decimals: 1, Locale: LocaleEN}, 512, "512 B"},
+		{Options{Units: UnitsBinary, Decimals: 1, Locale: LocaleEN}, 1536, "1.5 KB"},
+		{Options{Units: UnitsBinary, Decimals: 2, Locale: LocaleEN}, 3 << 30, "3.00 GB"},
+		{Options{Units: UnitsIEC, Decimals: 1, Locale: LocaleEN}, 1536, "1.5 KiB"},
+		{Options{Units: UnitsSI, Decimals: 0, Locale: LocaleEN}, 1500000, "2 MB"},
+		{Options{Units: UnitsSI, Decimals: 1, Locale: LocaleEN}, 999, "999 B"},
+		{Options{Units: "unknown", Decimals: 9, Locale: LocaleEN}, 1536, "1.500 KB"},
+	}
+	for _, tt := range tests {
+		withOptions(t, tt.opts)
+		if got := Size(tt.bytes); got != tt.want {
+			t.Errorf("Size(%d) with %+v = %q, want %q", tt.bytes, tt.opts, got, tt.want)
+		}
+	}
+}
+
+// TestCompactSize_Rate 测试紧凑格式和速率
+func TestCompactSize_Rate(t *testing.T) {
+	withOptions(t, Options{Units: UnitsBinary, Decimals: 1, Locale: LocaleEN})
+	if got := CompactSize(5 << 20); got != "5.0MB" {
+		t.Errorf("CompactSize = %q, want 5.0MB", got)
+	}
+	if got := Rate(100); got != "100B/s" {
+		t.Errorf("Rate = %q, want 100B/s", got)
+	}
+	if got := Rate(2048); got != "2.0KB/s" {
+		t.Errorf("Rate = %q, want 2.0KB/s", got)
+	}
+}
+
+// TestAgo_Locales 测试相对时间的两种写法和语言
+func TestAgo_Locales(t *testing.T) {
+	now := time.Now()
+	withOptions(t, Options{Locale: LocaleEN})
+	if got := Ago(now.Add(-5 * time.Minute)); got != "5m ago" {
+		t.Errorf("Ago = %q, want 5m ago", got)
+	}
+	if got := AgoLong(now.Add(-90 * time.Minute)); got != "1 hour ago" {
+		t.Errorf("AgoLong = %q, want 1 hour ago", got)
+	}
+	if got := AgoLong(now.Add(-72 * time.Hour)); got != "3 days ago" {
+		t.Errorf("AgoLong = %q, want 3 days ago", got)
+	}
+
+	withOptions(t, Options{Locale: LocaleZH})
+	if got := Ago(now.Add(-10 * time.Second)); got != "刚刚" {
+		t.Errorf("Ago = %q, want 刚刚", got)
+	}
+	if got := Ago(now.Add(-400 * 24 * time.Hour)); got != "1年前" {
+		t.Errorf("Ago = %q, want 1年前", got)
+	}
+	if got := AgoLong(now.Add(-3 * time.Hour)); got != "3 小时前" {
+		t.Errorf("AgoLong = %q, want 3 小时前", got)
+	}
+}
+
+// TestResolveLocale 测试 auto 按 locale 环境变量检测
+func TestResolveLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_TIME", "zh_CN.UTF-8")
+	t.Setenv("LANG", "en_US.UTF-8")
+	if got := ResolveLocale(LocaleAuto); got != LocaleZH {
+		t.Errorf("ResolveLocale(auto) = %q, want zh", got)
+	}
+	if got := ResolveLocale("EN"); got != LocaleEN {
+		t.Errorf("ResolveLocale(EN) = %q, want en", got)
+	}
+	t.Setenv("LC_TIME", "")
+	if got := ResolveLocale(""); got != LocaleEN {
+		t.Errorf("ResolveLocale() with LANG=en_US = %q, want en", got)
+	}
+}
+
+// TestTruncate_Width 测试按显示宽度截断：宽字符和 ANSI 样式不被切断
+func TestTruncate_Width(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"nginx", 10, "nginx"},
+		{"nginx-proxy", 6, "nginx…"},
+		{"容器名称很长", 7, "容器名…"},
+		{"abc", 0, ""},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.s, tt.width); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+
+	styled := "\x1b[31mnginx-proxy\x1b[0m"
+	if got := Width(Truncate(styled, 6)); got != 6 {
+		t.Errorf("styled truncate width = %d, want 6", got)
+	}
+	if got := Fit("\x1b[31mab\x1b[0m", 5); Width(got) != 5 {
+		t.Errorf("Fit width = %d, want 5", Width(got))
+	}
+	if got := Width("⚠ 容器"); got != 6 {
+		t.Errorf("Width = %d, want 6", got)
+	}
+}
+
+// TestDuration 测试紧凑时长
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{-time.Second, "0s"},
+		{45 * time.Second, "45s"},
+		{3*time.Minute + 12*time.Second, "3m12s"},
+		{2*time.Hour + 5*time.Minute, "2h5m"},
+		{76 * time.Hour, "3d4h"},
+	}
+	for _, tt := range tests {
+		if got := Duration(tt.d); got != tt.want {
+			t.Errorf("Duration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+// TestHighlightRanges 测试在带 ANSI 样式的文本中高亮匹配并恢复原来的样式
+func TestHighlightRanges(t *testing.T) {
+	mark := func(s string) string { return "[" + s + "]" }
+
+	if got := MatchRanges("Nginx-proxy nginx", "NGINX"); len(got) != 2 || got[0] != [2]int{0, 5} || got[1] != [2]int{12, 17} {
+		t.Errorf("MatchRanges = %v", got)
+	}
+	if got := MatchRanges("容器 web", "web"); len(got) != 1 || got[0] != [2]int{3, 6} {
+		t.Errorf("MatchRanges with wide runes = %v", got)
+	}
+	if got := MatchRanges("nginx", ""); got != nil {
+		t.Errorf("MatchRanges with empty query = %v", got)
+	}
+
+	plain := "my-nginx:latest"
+	if got := HighlightRanges(plain, MatchRanges(plain, "nginx"), mark); got != "my-[nginx]:latest" {
+		t.Errorf("plain highlight = %q", got)
+	}
+
+	styled := "\x1b[7m\x1b[31mmy-nginx\x1b[0m web"
+	want := "\x1b[7m\x1b[31mmy-\x1b[0m[nginx]\x1b[7m\x1b[31m\x1b[0m web"
+	if got := HighlightRanges(styled, MatchRanges(styled, "nginx"), mark); got != want {
+		t.Errorf("styled highlight:\n got %q\nwant %q", got, want)
+	}
+}
@@ -0,0 +1,148 @@
+package format
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ellipsis 截断时追加的省略号（占一列）
+const ellipsis = "…"
+
+// Width 返回字符串的显示宽度：忽略 ANSI 转义码，CJK 和 emoji 按两列计算
+func Width(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// Truncate 按显示宽度截断字符串，超出时以 "…" 结尾；保留 ANSI 样式，不会切断宽字符或转义码
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if Width(s) <= width {
+		return s
+	}
+	return ansi.Truncate(s, width, ellipsis)
+}
+
+// TruncateLines 对多行文本逐行截断
+func TruncateLines(s string, width int) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = Truncate(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Fit 把字符串调整为恰好 width 列：过长时截断，过短时在右侧补空格
+func Fit(s string, width int) string {
+	s = Truncate(s, width)
+	if pad := width - Width(s); pad > 0 {
+		s += strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// MatchRanges 返回 query 在 s 的可见文本中出现的位置（不区分大小写，忽略 ANSI 转义码），
+// 每项为 [起始, 结束) 的字符（rune）下标
+func MatchRanges(s, query string) [][2]int {
+	needle := []rune(strings.ToLower(query))
+	if len(needle) == 0 {
+		return nil
+	}
+	text := []rune(strings.ToLower(ansi.Strip(s)))
+	var ranges [][2]int
+	for i := 0; i+len(needle) <= len(text); i++ {
+		if string(text[i:i+len(needle)]) == string(needle) {
+			ranges = append(ranges, [2]int{i, i + len(needle)})
+			i += len(needle) - 1
+		}
+	}
+	return ranges
+}
+
+// HighlightRanges 用 render 重新渲染 s 中可见文本的指定范围（MatchRanges 的结果，按顺序且不重叠），
+// 范围之后恢复原来的 ANSI 样式，用于在已着色的表格行中高亮搜索匹配
+func HighlightRanges(s string, ranges [][2]int, render func(string) string) string {
+	if len(ranges) == 0 {
+		return s
+	}
+	var b, match strings.Builder
+	var active []string // 上次重置之后生效的 SGR 序列
+	pos, next := 0, 0
+	inMatch := false
+	flush := func() {
+		inMatch = false
+		next++
+		if len(active) > 0 {
+			b.WriteString("\x1b[0m")
+		}
+		b.WriteString(render(match.String()))
+		b.WriteString(strings.Join(active, ""))
+	}
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			seq := escapeSequence(s[i:])
+			if strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m") {
+				if seq == "\x1b[m" || seq == "\x1b[0m" {
+					active = active[:0]
+				} else {
+					active = append(active, seq)
+				}
+			}
+			// 匹配范围内的样式在范围结束后统一恢复
+			if !inMatch {
+				b.WriteString(seq)
+			}
+			i += len(seq)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !inMatch && next < len(ranges) && pos == ranges[next][0] {
+			inMatch = true
+			match.Reset()
+		}
+		if inMatch {
+			match.WriteRune(r)
+		} else {
+			b.WriteRune(r)
+		}
+		pos++
+		i += size
+		if inMatch && pos == ranges[next][1] {
+			flush()
+		}
+	}
+	if inMatch {
+		flush()
+	}
+	return b.String()
+}
+
+// escapeSequence 返回 s 开头的转义序列：CSI（ESC [ ... 结束字节）、OSC（ESC ] ... BEL 或 ST），其他情况为 ESC 加下一个字节
+func escapeSequence(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return s[:i+1]
+			}
+		}
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return s[:i+1]
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return s[:i+2]
+			}
+		}
+	default:
+		return s[:2]
+	}
+	return s
+}
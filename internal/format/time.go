@@ -0,0 +1,97 @@
+package format
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	day   = 24 * time.Hour
+	month = 30 * day
+	year  = 365 * day
+)
+
+// Ago 紧凑的相对时间，如 "5m ago"、"3d ago"（中文为 "5分钟前"），用于镜像、网络等列表
+func Ago(t time.Time) string {
+	d := time.Since(t)
+	zh := Current().Locale == LocaleZH
+	pick := func(en, cn string, n int) string {
+		if zh {
+			return fmt.Sprintf(cn, n)
+		}
+		return fmt.Sprintf(en, n)
+	}
+	switch {
+	case d < time.Minute:
+		if zh {
+			return "刚刚"
+		}
+		return "just now"
+	case d < time.Hour:
+		return pick("%dm ago", "%d分钟前", int(d.Minutes()))
+	case d < day:
+		return pick("%dh ago", "%d小时前", int(d.Hours()))
+	case d < month:
+		return pick("%dd ago", "%d天前", int(d/day))
+	case d < year:
+		return pick("%dmo ago", "%d个月前", int(d/month))
+	default:
+		return pick("%dy ago", "%d年前", int(d/year))
+	}
+}
+
+// AgoLong 完整的相对时间，如 "5 minutes ago"、"1 day ago"（中文为 "5 分钟前"），用于容器列表
+func AgoLong(t time.Time) string {
+	d := time.Since(t)
+	if Current().Locale == LocaleZH {
+		switch {
+		case d < time.Minute:
+			return fmt.Sprintf("%d 秒前", int(d.Seconds()))
+		case d < time.Hour:
+			return fmt.Sprintf("%d 分钟前", int(d.Minutes()))
+		case d < day:
+			return fmt.Sprintf("%d 小时前", int(d.Hours()))
+		case d < month:
+			return fmt.Sprintf("%d 天前", int(d/day))
+		default:
+			return fmt.Sprintf("%d 个月前", int(d/month))
+		}
+	}
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%d seconds ago", int(d.Seconds()))
+	case d < time.Hour:
+		return plural(int(d.Minutes()), "minute")
+	case d < day:
+		return plural(int(d.Hours()), "hour")
+	case d < month:
+		return plural(int(d/day), "day")
+	default:
+		return plural(int(d/month), "month")
+	}
+}
+
+// plural "1 minute ago" / "5 minutes ago"
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit + " ago"
+	}
+	return fmt.Sprintf("%d %ss ago", n, unit)
+}
+
+// Duration 紧凑的时长，如 "45s"、"3m12s"、"2h5m"、"3d4h"，用于刷新时间、任务耗时等
+func Duration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < day:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(d/day), int(d.Hours())%24)
+	}
+}
@@ -0,0 +1,197 @@
+// Package launchpad 读取首页配置：显示哪些资源卡片及其顺序，
+// 以及用户定义的快捷操作卡片（打开某个容器/镜像/Compose 项目、清理资源）
+package launchpad
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 首页资源卡片
+const (
+	CardContainers = "containers"
+	CardImages     = "images"
+	CardNetworks   = "networks"
+	CardCompose    = "compose"
+)
+
+// DefaultCards 未配置时的资源卡片及顺序
+var DefaultCards = []string{CardContainers, CardImages, CardNetworks, CardCompose}
+
+// 首页 Compose 项目卡片的数量
+const (
+	DefaultProjects = 4 // 未配置时显示最近活跃的 4 个项目
+	MaxProjects     = 5
+)
+
+// 快捷操作类型
+const (
+	KindContainer = "container"
+	KindImage     = "image"
+	KindCompose   = "compose"
+	KindPrune     = "prune"
+)
+
+// pruneTargets 快捷操作可以打开清理对话框的资源
+var pruneTargets = map[string]bool{CardContainers: true, CardImages: true}
+
+// projectNameInvalid docker compose 默认项目名中不允许的字符
+var projectNameInvalid = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// Action 一个快捷操作卡片，container、image、compose、prune 只能设置一个
+type Action struct {
+	Name      string   `yaml:"name"`      // 卡片标题
+	Icon      string   `yaml:"icon"`      // 卡片图标，为空时按操作类型选择
+	Container string   `yaml:"container"` // 打开容器详情（名称或 ID）
+	Image     string   `yaml:"image"`     // 打开镜像详情（repo:tag 或 ID）
+	Compose   string   `yaml:"compose"`   // 打开 Compose 项目（项目目录）
+	Project   string   `yaml:"project"`   // Compose 项目名，为空时按目录名推断
+	Files     []string `yaml:"files"`     // Compose 文件（相对项目目录），为空时由 docker compose 查找
+	Prune     string   `yaml:"prune"`     // 打开清理对话框：images 或 containers
+
+	kind string
+}
+
+// Kind 返回操作类型
+func (a Action) Kind() string {
+	return a.kind
+}
+
+// Target 返回卡片上显示的操作目标
+func (a Action) Target() string {
+	switch a.kind {
+	case KindContainer:
+		return a.Container
+	case KindImage:
+		return a.Image
+	case KindCompose:
+		return a.Project
+	case KindPrune:
+		return "prune " + a.Prune
+	}
+	return ""
+}
+
+// Config 首页配置
+type Config struct {
+	Cards    []string `yaml:"cards"`    // 显示的资源卡片及顺序，为空时使用 DefaultCards
+	Actions  []Action `yaml:"actions"`  // 快捷操作卡片，排在资源卡片之后
+	Projects int      `yaml:"projects"` // 显示的 Compose 项目卡片数（按最近活跃排序），0 表示不显示
+}
+
+// Default 返回未配置时的首页配置
+func Default() *Config {
+	return &Config{Cards: append([]string(nil), DefaultCards...), Projects: DefaultProjects}
+}
+
+// DefaultPath 返回默认配置文件路径（用户配置目录下的 docktui/home.yaml）
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "home.yaml"), nil
+}
+
+// Load 读取并校验首页配置
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := Config{Projects: DefaultProjects}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	if len(cfg.Cards) == 0 {
+		cfg.Cards = append([]string(nil), DefaultCards...)
+	}
+	seen := make(map[string]bool)
+	for i, card := range cfg.Cards {
+		card = strings.ToLower(strings.TrimSpace(card))
+		if !isCard(card) {
+			return nil, fmt.Errorf("unknown card %q (available: %s)", cfg.Cards[i], strings.Join(DefaultCards, ", "))
+		}
+		if seen[card] {
+			return nil, fmt.Errorf("card %q is listed twice", card)
+		}
+		seen[card] = true
+		cfg.Cards[i] = card
+	}
+
+	if cfg.Projects < 0 || cfg.Projects > MaxProjects {
+		return nil, fmt.Errorf("projects must be between 0 and %d", MaxProjects)
+	}
+
+	for i := range cfg.Actions {
+		if err := cfg.Actions[i].validate(filepath.Dir(path)); err != nil {
+			return nil, fmt.Errorf("action #%d: %w", i+1, err)
+		}
+	}
+	return &cfg, nil
+}
+
+// validate 校验快捷操作并补全默认值，相对的 Compose 目录按配置文件所在目录解析
+func (a *Action) validate(baseDir string) error {
+	a.Name = strings.TrimSpace(a.Name)
+	if a.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	var kinds []string
+	if a.Container != "" {
+		kinds = append(kinds, KindContainer)
+	}
+	if a.Image != "" {
+		kinds = append(kinds, KindImage)
+	}
+	if a.Compose != "" {
+		kinds = append(kinds, KindCompose)
+	}
+	if a.Prune != "" {
+		kinds = append(kinds, KindPrune)
+	}
+	if len(kinds) != 1 {
+		return fmt.Errorf("%s: exactly one of container, image, compose or prune is required", a.Name)
+	}
+	a.kind = kinds[0]
+
+	switch a.kind {
+	case KindCompose:
+		dir := a.Compose
+		if strings.HasPrefix(dir, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				dir = filepath.Join(home, dir[2:])
+			}
+		}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(baseDir, dir)
+		}
+		a.Compose = filepath.Clean(dir)
+		if a.Project == "" {
+			a.Project = projectNameInvalid.ReplaceAllString(strings.ToLower(filepath.Base(a.Compose)), "")
+		}
+	case KindPrune:
+		a.Prune = strings.ToLower(strings.TrimSpace(a.Prune))
+		if !pruneTargets[a.Prune] {
+			return fmt.Errorf("%s: prune must be images or containers", a.Name)
+		}
+	}
+	return nil
+}
+
+// isCard 是否是已知的资源卡片
+func isCard(card string) bool {
+	for _, c := range DefaultCards {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"testing"
+	"time"
+)
+
+// loadFrames 读取录制的 docker stats 数据帧
+func loadFrames(t *testing.T, name string) []Frame {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var frames []Frame
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var f Frame
+		if err := dec.Decode(&f); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, f)
+	}
+	return frames
+}
+
+func approx(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-6 {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+// TestFrame_StreamRates 测试流式数据的 CPU% 和网络/磁盘速率
+func TestFrame_StreamRates(t *testing.T) {
+	frames := loadFrames(t, "stream_cgroupv2.json")
+	if len(frames) != 3 {
+		t.Fatalf("expected 3 frames, got %d", len(frames))
+	}
+
+	first := frames[0].Sample(nil)
+	approx(t, "first CPUPercent", first.CPUPercent, 0)
+	approx(t, "first NetworkRxRate", first.NetworkRxRate, 0)
+	if first.NetworkRx != 1100 || first.NetworkTx != 500 {
+		t.Errorf("first network = %d/%d, want 1100/500", first.NetworkRx, first.NetworkTx)
+	}
+	if first.MemoryUsage != 94371840 {
+		t.Errorf("first MemoryUsage = %d, want 94371840 (usage minus inactive_file)", first.MemoryUsage)
+	}
+
+	second := frames[1].Sample(&first)
+	approx(t, "second CPUPercent", second.CPUPercent, 50)
+	approx(t, "second NetworkRxRate", second.NetworkRxRate, 2048)
+	approx(t, "second NetworkTxRate", second.NetworkTxRate, 1024)
+	approx(t, "second BlockReadRate", second.BlockReadRate, 4096)
+	approx(t, "second BlockWriteRate", second.BlockWriteRate, 0)
+
+	// 第三帧与第二帧间隔 2 秒
+	third := frames[2].Sample(&second)
+	approx(t, "third CPUPercent", third.CPUPercent, 5)
+	approx(t, "third NetworkRxRate", third.NetworkRxRate, 2048)
+	approx(t, "third NetworkTxRate", third.NetworkTxRate, 0)
+	approx(t, "third BlockWriteRate", third.BlockWriteRate, 4096)
+	if third.PIDs != 6 {
+		t.Errorf("third PIDs = %d, want 6", third.PIDs)
+	}
+}
+
+// TestFrame_SnapshotCgroupV1 测试 cgroup v1 快照：没有 online_cpus 时按 percpu_usage 计算 CPU 数
+func TestFrame_SnapshotCgroupV1(t *testing.T) {
+	f, err := os.Open("testdata/snapshot_cgroupv1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	frame, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := frame.Sample(nil)
+	approx(t, "CPUPercent", s.CPUPercent, 200)
+	if s.MemoryUsage != 157286400 {
+		t.Errorf("MemoryUsage = %d, want 157286400 (usage minus total_inactive_file)", s.MemoryUsage)
+	}
+	approx(t, "MemoryPercent", s.MemoryPercent, 50)
+	if s.BlockRead != 1048576 || s.BlockWrite != 2097152 {
+		t.Errorf("block I/O = %d/%d, want 1048576/2097152", s.BlockRead, s.BlockWrite)
+	}
+	if want := time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC); !s.Time.Equal(want) {
+		t.Errorf("Time = %v, want %v", s.Time, want)
+	}
+}
+
+// TestRate_CounterReset 测试计数器变小（容器重启）时速率为 0
+func TestRate_CounterReset(t *testing.T) {
+	frames := loadFrames(t, "stream_cgroupv2.json")
+	prev := frames[2].Sample(nil)
+	prev.Time = frames[0].Read.Add(-time.Second)
+	s := frames[0].Sample(&prev)
+	approx(t, "NetworkRxRate", s.NetworkRxRate, 0)
+	approx(t, "BlockWriteRate", s.BlockWriteRate, 0)
+}
+
+// TestSeries_Buckets 测试按间隔聚合、空桶沿用前值以及超出保留时长的数据被丢弃
+func TestSeries_Buckets(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	s := NewSeries(time.Minute)
+	if got := s.Buckets(now, time.Second, 4); len(got) != 0 {
+		t.Fatalf("empty series buckets = %v", got)
+	}
+
+	s.Add(now.Add(-2*time.Minute), 99)
+	s.Add(now.Add(-3500*time.Millisecond), 10)
+	s.Add(now.Add(-2500*time.Millisecond), 20)
+	s.Add(now.Add(-2200*time.Millisecond), 40)
+	s.Add(now.Add(-200*time.Millisecond), 50)
+	if s.Len() != 4 {
+		t.Errorf("Len = %d, want 4 (point older than retention dropped)", s.Len())
+	}
+
+	got := s.Buckets(now, time.Second, 4)
+	want := []float64{10, 30, 30, 50}
+	for i := range want {
+		approx(t, "bucket", got[i], want[i])
+	}
+
+	got = s.Buckets(now, time.Second, 6)
+	if got[0] != 0 || got[1] != 0 || got[2] != 10 {
+		t.Errorf("leading buckets = %v, want 0, 0, 10", got[:3])
+	}
+}
+
+// fakeSource 回放录制的数据流，release 关闭前不返回
+type fakeSource struct {
+	data    []byte
+	opened  chan string
+	release chan struct{}
+}
+
+func (f *fakeSource) ContainerStatsStream(ctx context.Context, containerID string) (io.ReadCloser, error) {
+	f.opened <- containerID
+	<-f.release
+	return io.NopCloser(bytes.NewReader(f.data)), nil
+}
+
+// TestPoller_SharedStream 测试同一容器的订阅共用一个数据流，流结束后发送错误并关闭通道
+func TestPoller_SharedStream(t *testing.T) {
+	data, err := os.ReadFile("testdata/stream_cgroupv2.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := &fakeSource{data: data, opened: make(chan string, 4), release: make(chan struct{})}
+	p := NewPoller(src)
+
+	a := p.Subscribe("web")
+	b := p.Subscribe("web")
+	if id := <-src.opened; id != "web" {
+		t.Fatalf("opened stream for %q", id)
+	}
+	close(src.release)
+
+	for _, ch := range []<-chan Sample{a, b} {
+		var final error
+		for s := range ch {
+			if s.ContainerID != "web" {
+				t.Errorf("ContainerID = %q", s.ContainerID)
+			}
+			final = s.Err
+		}
+		if !errors.Is(final, ErrStreamEnded) {
+			t.Errorf("last sample error = %v, want ErrStreamEnded", final)
+		}
+	}
+	select {
+	case id := <-src.opened:
+		t.Errorf("second stream opened for %q", id)
+	default:
+	}
+
+	// 已关闭的通道取消订阅不应 panic
+	p.Unsubscribe(a)
+}
+
+// TestPoller_Unsubscribe 测试取消订阅会关闭通道并停止数据流
+func TestPoller_Unsubscribe(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	src := &pipeSource{r: pr, opened: make(chan struct{}, 1)}
+	p := NewPoller(src)
+	ch := p.Subscribe("db")
+	<-src.opened
+	p.Unsubscribe(ch)
+	if _, ok := <-ch; ok {
+		t.Error("channel not closed after Unsubscribe")
+	}
+	p.mu.Lock()
+	n := len(p.streams)
+	p.mu.Unlock()
+	if n != 0 {
+		t.Errorf("%d streams left after last Unsubscribe", n)
+	}
+}
+
+type pipeSource struct {
+	r      *io.PipeReader
+	opened chan struct{}
+}
+
+func (s *pipeSource) ContainerStatsStream(ctx context.Context, containerID string) (io.ReadCloser, error) {
+	go func() {
+		<-ctx.Done()
+		s.r.CloseWithError(ctx.Err())
+	}()
+	s.opened <- struct{}{}
+	return s.r, nil
+}
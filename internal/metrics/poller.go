@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrStreamEnded 统计数据流正常结束（通常是容器已停止）
+var ErrStreamEnded = errors.New("stats stream ended")
+
+// Source 提供容器的统计数据流（Docker stats API stream=true 的 JSON 帧序列）
+type Source interface {
+	ContainerStatsStream(ctx context.Context, containerID string) (io.ReadCloser, error)
+}
+
+// Poller 按容器共享统计数据流，把每一帧计算成 Sample 推送给所有订阅者
+type Poller struct {
+	source  Source
+	mu      sync.Mutex
+	streams map[string]*stream
+	subs    map[<-chan Sample]*stream
+}
+
+type stream struct {
+	containerID string
+	cancel      context.CancelFunc
+	subs        map[chan Sample]struct{}
+}
+
+// NewPoller 创建统计数据轮询器
+func NewPoller(source Source) *Poller {
+	return &Poller{
+		source:  source,
+		streams: make(map[string]*stream),
+		subs:    make(map[<-chan Sample]*stream),
+	}
+}
+
+// Subscribe 订阅容器的采样。通道只保留最新的一个采样（消费慢时丢弃旧采样）；
+// 数据流出错或结束时先发送一个带 Err 的采样再关闭通道
+func (p *Poller) Subscribe(containerID string) <-chan Sample {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.streams[containerID]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		s = &stream{containerID: containerID, cancel: cancel, subs: make(map[chan Sample]struct{})}
+		p.streams[containerID] = s
+		go p.run(ctx, s)
+	}
+	ch := make(chan Sample, 1)
+	s.subs[ch] = struct{}{}
+	p.subs[ch] = s
+	return ch
+}
+
+// Unsubscribe 取消订阅并关闭通道，容器的最后一个订阅取消后停止数据流；对已关闭的通道无效果
+func (p *Poller) Unsubscribe(ch <-chan Sample) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.subs[ch]
+	if !ok {
+		return
+	}
+	delete(p.subs, ch)
+	for c := range s.subs {
+		if c == ch {
+			delete(s.subs, c)
+			close(c)
+		}
+	}
+	if len(s.subs) == 0 {
+		s.cancel()
+		delete(p.streams, s.containerID)
+	}
+}
+
+// run 读取数据流直到出错、结束或被取消
+func (p *Poller) run(ctx context.Context, s *stream) {
+	body, err := p.source.ContainerStatsStream(ctx, s.containerID)
+	if err != nil {
+		p.finish(s, err)
+		return
+	}
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	var prev *Sample
+	for {
+		var f Frame
+		if err := dec.Decode(&f); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if errors.Is(err, io.EOF) {
+				err = ErrStreamEnded
+			}
+			p.finish(s, err)
+			return
+		}
+		sample := f.Sample(prev)
+		sample.ContainerID = s.containerID
+		prev = &sample
+
+		p.mu.Lock()
+		for c := range s.subs {
+			send(c, sample)
+		}
+		p.mu.Unlock()
+	}
+}
+
+// finish 向订阅者发送错误并关闭它们的通道
+func (p *Poller) finish(s *stream, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.streams[s.containerID] == s {
+		delete(p.streams, s.containerID)
+	}
+	for c := range s.subs {
+		send(c, Sample{ContainerID: s.containerID, Err: err})
+		close(c)
+		delete(p.subs, c)
+	}
+	s.subs = nil
+	s.cancel()
+}
+
+// send 非阻塞发送，通道已满时用新采样替换旧采样
+func send(c chan Sample, sample Sample) {
+	select {
+	case <-c:
+	default:
+	}
+	c <- sample
+}
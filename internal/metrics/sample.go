@@ -0,0 +1,153 @@
+// Package metrics 解析 Docker stats API 的数据帧，计算 CPU% 和网络、磁盘速率，
+// 并按容器共享统计数据流向界面推送采样
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Frame Docker stats API 返回的一帧数据（stream=true 时每秒一帧）
+type Frame struct {
+	Read        time.Time `json:"read"`
+	PreRead     time.Time `json:"preread"`
+	CPUStats    cpuStats  `json:"cpu_stats"`
+	PreCPUStats cpuStats  `json:"precpu_stats"`
+	MemoryStats struct {
+		Usage uint64            `json:"usage"`
+		Limit uint64            `json:"limit"`
+		Stats map[string]uint64 `json:"stats"`
+	} `json:"memory_stats"`
+	Networks map[string]struct {
+		RxBytes uint64 `json:"rx_bytes"`
+		TxBytes uint64 `json:"tx_bytes"`
+	} `json:"networks"`
+	BlkioStats struct {
+		IoServiceBytesRecursive []struct {
+			Op    string `json:"op"`
+			Value uint64 `json:"value"`
+		} `json:"io_service_bytes_recursive"`
+	} `json:"blkio_stats"`
+	PidsStats struct {
+		Current uint64 `json:"current"`
+	} `json:"pids_stats"`
+}
+
+type cpuStats struct {
+	CPUUsage struct {
+		TotalUsage  uint64   `json:"total_usage"`
+		PercpuUsage []uint64 `json:"percpu_usage"`
+	} `json:"cpu_usage"`
+	SystemCPUUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs     uint64 `json:"online_cpus"`
+}
+
+// Sample 一次采样：瞬时值来自一帧数据，速率来自与上一帧的差值
+type Sample struct {
+	ContainerID   string
+	Time          time.Time
+	CPUPercent    float64
+	MemoryUsage   uint64 // 不含页缓存（与 docker stats 一致）
+	MemoryLimit   uint64
+	MemoryPercent float64
+	NetworkRx     uint64 // 累计字节数
+	NetworkTx     uint64
+	BlockRead     uint64
+	BlockWrite    uint64
+	PIDs          uint64
+
+	NetworkRxRate  float64 // 字节/秒，第一帧为 0
+	NetworkTxRate  float64
+	BlockReadRate  float64
+	BlockWriteRate float64
+
+	Err error // 数据流出错或结束，之后订阅通道会被关闭
+}
+
+// Decode 解析一帧（stream=false 的单次快照）
+func Decode(r io.Reader) (*Frame, error) {
+	var f Frame
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Sample 计算这一帧的采样，prev 为同一容器的上一次采样（用于速率），第一帧传 nil
+func (f *Frame) Sample(prev *Sample) Sample {
+	s := Sample{
+		Time:        f.Read,
+		CPUPercent:  f.CPUPercent(),
+		MemoryUsage: f.MemoryUsage(),
+		MemoryLimit: f.MemoryStats.Limit,
+		PIDs:        f.PidsStats.Current,
+	}
+	if s.Time.IsZero() {
+		s.Time = time.Now()
+	}
+	if s.MemoryLimit > 0 {
+		s.MemoryPercent = float64(s.MemoryUsage) / float64(s.MemoryLimit) * 100
+	}
+	for _, n := range f.Networks {
+		s.NetworkRx += n.RxBytes
+		s.NetworkTx += n.TxBytes
+	}
+	for _, bio := range f.BlkioStats.IoServiceBytesRecursive {
+		switch bio.Op {
+		case "Read", "read":
+			s.BlockRead += bio.Value
+		case "Write", "write":
+			s.BlockWrite += bio.Value
+		}
+	}
+
+	if prev != nil {
+		if elapsed := s.Time.Sub(prev.Time).Seconds(); elapsed > 0 {
+			s.NetworkRxRate = rate(prev.NetworkRx, s.NetworkRx, elapsed)
+			s.NetworkTxRate = rate(prev.NetworkTx, s.NetworkTx, elapsed)
+			s.BlockReadRate = rate(prev.BlockRead, s.BlockRead, elapsed)
+			s.BlockWriteRate = rate(prev.BlockWrite, s.BlockWrite, elapsed)
+		}
+	}
+	return s
+}
+
+// CPUPercent 按 docker stats 的算法计算 CPU 使用率：容器 CPU 时间增量 / 系统 CPU 时间增量 × CPU 数；
+// 没有上一帧（流的第一帧 precpu 为空）时返回 0
+func (f *Frame) CPUPercent() float64 {
+	if f.PreCPUStats.SystemCPUUsage == 0 {
+		return 0
+	}
+	cpuDelta := float64(f.CPUStats.CPUUsage.TotalUsage) - float64(f.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(f.CPUStats.SystemCPUUsage) - float64(f.PreCPUStats.SystemCPUUsage)
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+	cpus := float64(f.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(f.CPUStats.CPUUsage.PercpuUsage))
+	}
+	return cpuDelta / systemDelta * cpus * 100
+}
+
+// MemoryUsage 内存使用量减去可回收的页缓存（cgroup v2 为 inactive_file，v1 为 total_inactive_file）
+func (f *Frame) MemoryUsage() uint64 {
+	usage := f.MemoryStats.Usage
+	cache, ok := f.MemoryStats.Stats["inactive_file"]
+	if !ok {
+		cache = f.MemoryStats.Stats["total_inactive_file"]
+	}
+	if cache < usage {
+		return usage - cache
+	}
+	return usage
+}
+
+// rate 计数器增量除以时间，计数器变小（容器重启）时为 0
+func rate(prev, cur uint64, elapsed float64) float64 {
+	if cur < prev {
+		return 0
+	}
+	return float64(cur-prev) / elapsed
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import "time"
+
+// Point 一个时间点的值
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series 保留最近一段时间的采样值，按固定间隔聚合成图表数据
+type Series struct {
+	retention time.Duration
+	points    []Point
+}
+
+// NewSeries 创建保留 retention 时长数据的序列
+func NewSeries(retention time.Duration) *Series {
+	return &Series{retention: retention}
+}
+
+// Add 追加一个值并丢弃超出保留时长的数据
+func (s *Series) Add(t time.Time, value float64) {
+	s.points = append(s.points, Point{Time: t, Value: value})
+	cutoff := t.Add(-s.retention)
+	i := 0
+	for i < len(s.points) && !s.points[i].Time.After(cutoff) {
+		i++
+	}
+	s.points = s.points[i:]
+}
+
+// Len 返回保留的数据点数
+func (s *Series) Len() int {
+	return len(s.points)
+}
+
+// Reset 清空数据
+func (s *Series) Reset() {
+	s.points = nil
+}
+
+// Buckets 把截至 now 的最近 n 个 interval 聚合为 n 个值（桶内取平均）；
+// 空桶沿用前一个桶的值，最前面的空桶为 0；没有数据时返回空切片
+func (s *Series) Buckets(now time.Time, interval time.Duration, n int) []float64 {
+	if len(s.points) == 0 {
+		return []float64{}
+	}
+	sums := make([]float64, n)
+	counts := make([]int, n)
+	start := now.Add(-time.Duration(n) * interval)
+	for _, p := range s.points {
+		if p.Time.Before(start) || p.Time.After(now) {
+			continue
+		}
+		i := int(p.Time.Sub(start) / interval)
+		if i >= n {
+			i = n - 1
+		}
+		sums[i] += p.Value
+		counts[i]++
+	}
+
+	result := make([]float64, n)
+	for i := range result {
+		switch {
+		case counts[i] > 0:
+			result[i] = sums[i] / float64(counts[i])
+		case i > 0:
+			result[i] = result[i-1]
+		}
+	}
+	return result
+}
@@ -0,0 +1,174 @@
+// Package notes 在本机保存用户给容器和镜像添加的备注和颜色标记，
+// 容器按名称索引（重建后仍然保留），镜像按 ID 索引（重新打标签后仍然保留）
+package notes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// 备注对应的资源类型
+const (
+	KindContainer = "container"
+	KindImage     = "image"
+)
+
+// Colors 可用的颜色标记（按显示顺序）
+var Colors = []string{"red", "orange", "yellow", "green", "blue", "purple"}
+
+// colorBadges 颜色标记在列表中显示的符号，不依赖终端配色，也不影响行样式
+var colorBadges = map[string]string{
+	"red":    "🔴",
+	"orange": "🟠",
+	"yellow": "🟡",
+	"green":  "🟢",
+	"blue":   "🔵",
+	"purple": "🟣",
+}
+
+// Note 一条备注
+type Note struct {
+	Text        string    `json:"text,omitempty"`
+	Color       string    `json:"color,omitempty"`
+	StopTimeout int       `json:"stop_timeout,omitempty"` // 停止/重启容器时等待退出的秒数，0 表示默认值
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Empty 没有文字、颜色和停止超时
+func (n Note) Empty() bool {
+	return n.Text == "" && n.Color == "" && n.StopTimeout == 0
+}
+
+// Badge 返回列表名称后的标记（颜色、📝），没有备注时返回空字符串
+func (n Note) Badge() string {
+	badge := colorBadges[n.Color]
+	if n.Text != "" {
+		badge += "📝"
+	}
+	if badge == "" {
+		return ""
+	}
+	return " " + badge
+}
+
+// StopTimeoutOr 返回容器的停止超时，未设置时返回 def
+func (n Note) StopTimeoutOr(def int) int {
+	if n.StopTimeout > 0 {
+		return n.StopTimeout
+	}
+	return def
+}
+
+// ColorBadge 返回颜色对应的符号，未知颜色返回空字符串
+func ColorBadge(color string) string {
+	return colorBadges[color]
+}
+
+// Store 备注文件
+type Store struct {
+	path  string
+	notes map[string]Note // <kind>/<key> -> 备注
+	mu    sync.RWMutex
+}
+
+var (
+	defaultStore *Store
+	defaultMu    sync.RWMutex
+)
+
+// DefaultPath 返回默认备注文件路径（用户配置目录下的 docktui/notes.json）
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "notes.json"), nil
+}
+
+// Open 读取备注文件，文件不存在时视为空
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, notes: make(map[string]Note)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read notes: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.notes); err != nil {
+		return nil, fmt.Errorf("failed to parse notes: %w", err)
+	}
+	return s, nil
+}
+
+// Get 返回资源的备注
+func (s *Store) Get(kind, key string) (Note, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	n, ok := s.notes[kind+"/"+key]
+	return n, ok
+}
+
+// Set 保存资源的备注，空备注表示删除
+func (s *Store) Set(kind, key string, n Note) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := kind + "/" + key
+	if n.Empty() {
+		delete(s.notes, id)
+	} else {
+		n.UpdatedAt = time.Now()
+		s.notes[id] = n
+	}
+
+	data, err := json.MarshalIndent(s.notes, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create notes directory: %w", err)
+	}
+	// 先写临时文件再重命名，避免写到一半时丢失全部备注
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save notes: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to save notes: %w", err)
+	}
+	return nil
+}
+
+// SetDefault 设置全局备注文件
+func SetDefault(s *Store) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultStore = s
+}
+
+// Lookup 从全局备注文件读取备注，未启用时返回空备注
+func Lookup(kind, key string) Note {
+	defaultMu.RLock()
+	s := defaultStore
+	defaultMu.RUnlock()
+	if s == nil || key == "" {
+		return Note{}
+	}
+	n, _ := s.Get(kind, key)
+	return n
+}
+
+// Save 保存到全局备注文件
+func Save(kind, key string, n Note) error {
+	defaultMu.RLock()
+	s := defaultStore
+	defaultMu.RUnlock()
+	if s == nil {
+		return fmt.Errorf("notes are not available")
+	}
+	return s.Set(kind, key, n)
+}
@@ -0,0 +1,232 @@
+// Package notify 在后台事件发生时提醒用户：终端响铃、OSC 9 桌面通知或执行自定义命令
+// 这样在长时间拉取/推送时切到别的窗口，也能知道任务何时结束
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"docktui/internal/docker"
+	"docktui/internal/task"
+)
+
+// Method 通知方式
+type Method string
+
+const (
+	MethodBell Method = "bell" // 终端响铃（BEL）
+	MethodOSC9 Method = "osc9" // OSC 9 转义序列，iTerm2、WezTerm、Windows Terminal 等会显示为桌面通知
+	MethodExec Method = "exec" // 执行用户命令，通知内容通过环境变量传入
+)
+
+// Kind 触发通知的事件
+type Kind string
+
+const (
+	KindUnhealthy Kind = "unhealthy" // 容器健康检查变为 unhealthy
+	KindExit      Kind = "exit"      // 容器以非零退出码退出（docker stop/kill 引起的退出除外）
+	KindTask      Kind = "task"      // 后台任务完成或失败
+)
+
+// Kinds 全部事件，未指定时默认启用
+var Kinds = []Kind{KindUnhealthy, KindExit, KindTask}
+
+// killGrace kill 事件之后多久内的 die 视为主动停止
+const killGrace = 30 * time.Second
+
+// Config 通知配置
+type Config struct {
+	Method  Method
+	Command string        // exec 方式执行的命令（经 shell 解释）
+	Events  map[Kind]bool // 启用的事件
+}
+
+// ParseMethod 解析通知方式，空字符串或 off 表示关闭
+func ParseMethod(s string) (Method, error) {
+	switch m := Method(strings.ToLower(strings.TrimSpace(s))); m {
+	case "", "off", "none":
+		return "", nil
+	case MethodBell, MethodOSC9, MethodExec:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown notify method %q (available: bell, osc9, exec)", s)
+	}
+}
+
+// ParseEvents 解析逗号分隔的事件列表，空字符串表示全部事件
+func ParseEvents(s string) (map[Kind]bool, error) {
+	events := make(map[Kind]bool)
+	if strings.TrimSpace(s) == "" {
+		for _, k := range Kinds {
+			events[k] = true
+		}
+		return events, nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			continue
+		}
+		valid := false
+		for _, k := range Kinds {
+			if Kind(item) == k {
+				valid = true
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown notify event %q (available: unhealthy, exit, task)", item)
+		}
+		events[Kind(item)] = true
+	}
+	return events, nil
+}
+
+// Notifier 根据配置发送通知
+type Notifier struct {
+	cfg Config
+	out io.Writer
+
+	mu     sync.Mutex
+	killed map[string]time.Time // 最近收到 kill 事件的容器
+}
+
+// New 创建通知器，响铃和 OSC 9 写入标准输出（即 TUI 所在终端）
+// 这两种序列不占用显示位置，不会打乱界面
+func New(cfg Config) *Notifier {
+	return &Notifier{
+		cfg:    cfg,
+		out:    os.Stdout,
+		killed: make(map[string]time.Time),
+	}
+}
+
+// Notify 发送一条通知
+func (n *Notifier) Notify(kind Kind, title, message string) {
+	if !n.cfg.Events[kind] {
+		return
+	}
+	switch n.cfg.Method {
+	case MethodBell:
+		n.write("\a")
+	case MethodOSC9:
+		// 序列内不能出现控制字符
+		text := strings.Map(func(r rune) rune {
+			if r < 0x20 || r == 0x7f {
+				return ' '
+			}
+			return r
+		}, title+": "+message)
+		n.write("\x1b]9;" + text + "\x07")
+	case MethodExec:
+		go n.run(kind, title, message)
+	}
+}
+
+func (n *Notifier) write(seq string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, _ = io.WriteString(n.out, seq)
+}
+
+// run 执行用户命令，通过 DOCKTUI_NOTIFY_EVENT/TITLE/MESSAGE 环境变量传入通知内容
+func (n *Notifier) run(kind Kind, title, message string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", n.cfg.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", n.cfg.Command)
+	}
+	cmd.Env = append(os.Environ(),
+		"DOCKTUI_NOTIFY_EVENT="+string(kind),
+		"DOCKTUI_NOTIFY_TITLE="+title,
+		"DOCKTUI_NOTIFY_MESSAGE="+message,
+	)
+	// 输出会破坏界面，丢弃
+	_ = cmd.Run()
+}
+
+// Run 监听容器事件和后台任务事件并发送通知，直到 ctx 取消
+func (n *Notifier) Run(ctx context.Context, hub *docker.EventHub, tasks *task.Manager) {
+	var containerEvents <-chan docker.ContainerEvent
+	if n.cfg.Events[KindUnhealthy] || n.cfg.Events[KindExit] {
+		var unsubscribe func()
+		containerEvents, unsubscribe = hub.Subscribe()
+		defer unsubscribe()
+	}
+	var taskEvents <-chan task.Event
+	if n.cfg.Events[KindTask] {
+		taskEvents = tasks.Subscribe()
+		defer tasks.Unsubscribe(taskEvents)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-containerEvents:
+			if !ok {
+				containerEvents = nil
+				continue
+			}
+			n.handleContainerEvent(event)
+		case event, ok := <-taskEvents:
+			if !ok {
+				taskEvents = nil
+				continue
+			}
+			n.handleTaskEvent(event)
+		}
+	}
+}
+
+// handleContainerEvent 处理容器事件
+func (n *Notifier) handleContainerEvent(event docker.ContainerEvent) {
+	name := event.ContainerName
+	if name == "" && len(event.ContainerID) >= 12 {
+		name = event.ContainerID[:12]
+	}
+
+	switch event.Action {
+	case "kill":
+		n.mu.Lock()
+		n.killed[event.ContainerID] = event.Timestamp
+		n.mu.Unlock()
+	case "die":
+		n.mu.Lock()
+		killedAt, killed := n.killed[event.ContainerID]
+		delete(n.killed, event.ContainerID)
+		n.mu.Unlock()
+		if event.ExitCode == 0 || (killed && event.Timestamp.Sub(killedAt) < killGrace) {
+			return
+		}
+		n.Notify(KindExit, "Container exited", fmt.Sprintf("%s exited with code %d", name, event.ExitCode))
+	case "health_status":
+		if event.Health == "unhealthy" {
+			n.Notify(KindUnhealthy, "Container unhealthy", name+" is unhealthy")
+		}
+	}
+}
+
+// handleTaskEvent 处理后台任务事件
+func (n *Notifier) handleTaskEvent(event task.Event) {
+	switch event.Type {
+	case task.EventCompleted:
+		n.Notify(KindTask, "Task completed", event.TaskName)
+	case task.EventFailed:
+		message := event.TaskName + " failed"
+		if event.Error != nil {
+			message += ": " + event.Error.Error()
+		}
+		n.Notify(KindTask, "Task failed", message)
+	}
+}
@@ -0,0 +1,196 @@
+// Package plugin 读取用户在配置文件中定义的自定义操作：按键 + 资源类型 + 命令模板，
+// 用于接入团队内部工具而无需修改 docktui
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 自定义操作适用的资源类型
+const (
+	ResourceContainer = "container"
+	ResourceImage     = "image"
+	ResourceNetwork   = "network"
+	ResourceCompose   = "compose"
+)
+
+// reservedKeys 自定义操作所在视图中内置快捷键已使用的组合键和功能键
+var reservedKeys = map[string]string{
+	"ctrl+c": "quit",
+	"ctrl+z": "action history",
+	"ctrl+d": "remove / scroll down",
+	"ctrl+u": "scroll up",
+	"f5":     "refresh",
+	"f12":    "debug dump",
+}
+
+// Action 一个自定义操作
+type Action struct {
+	Name     string `yaml:"name"`      // 显示名称
+	Key      string `yaml:"key"`       // 触发按键，如 "ctrl+g"、"alt+d"
+	Resource string `yaml:"resource"`  // container, image, network, compose
+	Command  string `yaml:"command"`   // 命令模板，如 "lazydocker --container {{.ContainerID}}"
+	Pause    bool   `yaml:"pause"`     // 命令结束后等待回车再返回界面（用于只输出结果的命令）
+	ReadOnly bool   `yaml:"read_only"` // 命令不修改任何资源，只读模式下仍可使用
+
+	tmpl *template.Template
+}
+
+// Context 命令模板可用的字段，未选中的资源对应字段为空
+type Context struct {
+	ContainerID   string
+	ContainerName string
+	Image         string // 镜像引用（repo:tag）
+	ImageID       string
+	NetworkID     string
+	NetworkName   string
+	ProjectName   string // compose 项目名
+	ProjectDir    string // compose 项目目录
+	ServiceName   string // compose 服务名（项目详情中选中的服务）
+}
+
+// file 配置文件结构
+type file struct {
+	Actions []Action `yaml:"actions"`
+}
+
+// DefaultPath 返回默认配置文件路径（用户配置目录下的 docktui/actions.yaml）
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "actions.yaml"), nil
+}
+
+// Load 读取并校验自定义操作
+func Load(path string) ([]Action, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	seen := make(map[string]string)
+	for i := range f.Actions {
+		a := &f.Actions[i]
+		if a.Name == "" || a.Key == "" || a.Command == "" {
+			return nil, fmt.Errorf("action #%d: name, key and command are required", i+1)
+		}
+		a.Resource = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(a.Resource), "s"))
+		switch a.Resource {
+		case ResourceContainer, ResourceImage, ResourceNetwork, ResourceCompose:
+		default:
+			return nil, fmt.Errorf("action %q: unknown resource %q (available: container, image, network, compose)", a.Name, a.Resource)
+		}
+		if err := checkKey(a.Key); err != nil {
+			return nil, fmt.Errorf("action %q: %w", a.Name, err)
+		}
+		id := a.Resource + "/" + a.Key
+		if other, ok := seen[id]; ok {
+			return nil, fmt.Errorf("action %q: key %s is already used by %q", a.Name, a.Key, other)
+		}
+		seen[id] = a.Name
+
+		tmpl, err := template.New(a.Name).Funcs(template.FuncMap{"quote": shellQuote}).Parse(a.Command)
+		if err != nil {
+			return nil, fmt.Errorf("action %q: %w", a.Name, err)
+		}
+		a.tmpl = tmpl
+		// 用空上下文试渲染一次，提前发现拼错的字段名
+		if _, err := a.Render(Context{}); err != nil {
+			return nil, err
+		}
+	}
+	return f.Actions, nil
+}
+
+// checkKey 检查按键不会遮盖内置快捷键：单个字母、符号和方向键几乎都已被各视图使用，
+// 因此只接受 ctrl+/alt+ 组合键和功能键，并排除内置已使用的组合
+func checkKey(k string) error {
+	if use, ok := reservedKeys[k]; ok {
+		return fmt.Errorf("key %s is used by docktui (%s)", k, use)
+	}
+	if strings.HasPrefix(k, "ctrl+") || strings.HasPrefix(k, "alt+") {
+		return nil
+	}
+	if num, ok := strings.CutPrefix(k, "f"); ok {
+		if n, err := strconv.Atoi(num); err == nil && n >= 1 && n <= 20 {
+			return nil
+		}
+	}
+	return fmt.Errorf("key %s would shadow built-in shortcuts, use a ctrl+ or alt+ combination or a function key", k)
+}
+
+// Find 查找资源类型和按键对应的操作
+func Find(actions []Action, resource, key string) *Action {
+	for i := range actions {
+		if actions[i].Resource == resource && actions[i].Key == key {
+			return &actions[i]
+		}
+	}
+	return nil
+}
+
+// Render 用上下文展开命令模板
+func (a *Action) Render(ctx Context) (string, error) {
+	var buf bytes.Buffer
+	if err := a.tmpl.Execute(&buf, ctx); err != nil {
+		return "", fmt.Errorf("action %q: %w", a.Name, err)
+	}
+	return buf.String(), nil
+}
+
+// Cmd 生成经 shell 执行的命令，上下文同时以 DOCKTUI_* 环境变量传入
+func (a *Action) Cmd(ctx Context) (*exec.Cmd, error) {
+	command, err := a.Render(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		if a.Pause {
+			command += " & pause"
+		}
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		if a.Pause {
+			command += `; printf '\nPress Enter to return to docktui...'; read _`
+		}
+		cmd = exec.Command("sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(),
+		"DOCKTUI_CONTAINER_ID="+ctx.ContainerID,
+		"DOCKTUI_CONTAINER_NAME="+ctx.ContainerName,
+		"DOCKTUI_IMAGE="+ctx.Image,
+		"DOCKTUI_IMAGE_ID="+ctx.ImageID,
+		"DOCKTUI_NETWORK_ID="+ctx.NetworkID,
+		"DOCKTUI_NETWORK_NAME="+ctx.NetworkName,
+		"DOCKTUI_PROJECT_NAME="+ctx.ProjectName,
+		"DOCKTUI_PROJECT_DIR="+ctx.ProjectDir,
+		"DOCKTUI_SERVICE_NAME="+ctx.ServiceName,
+	)
+	if ctx.ProjectDir != "" {
+		cmd.Dir = ctx.ProjectDir
+	}
+	return cmd, nil
+}
+
+// shellQuote 为 POSIX shell 加单引号，供模板中 {{quote .ProjectDir}} 使用
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
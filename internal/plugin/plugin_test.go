@@ -0,0 +1,160 @@
+package plugin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeActions 写入测试用自定义操作文件并返回路径
+func writeActions(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "actions.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("write actions: %v", err)
+	}
+	return path
+}
+
+// TestLoad 测试读取自定义操作并规范化资源类型
+func TestLoad(t *testing.T) {
+	path := writeActions(t, `actions:
+  - name: Dive into image
+    resource: Images
+    key: alt+d
+    command: dive {{.ImageID}}
+    read_only: true
+  - name: Shell
+    resource: container
+    key: f2
+    command: docker exec -it {{.ContainerID}} bash
+  - name: Disk usage
+    resource: compose
+    key: ctrl+g
+    command: du -sh {{quote .ProjectDir}}
+    pause: true
+`)
+	actions, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(actions) != 3 {
+		t.Fatalf("expected 3 actions, got %d", len(actions))
+	}
+	if actions[0].Resource != ResourceImage || !actions[0].ReadOnly {
+		t.Errorf("unexpected first action %+v", actions[0])
+	}
+	if a := Find(actions, ResourceCompose, "ctrl+g"); a == nil || a.Name != "Disk usage" || !a.Pause {
+		t.Errorf("Find(compose, ctrl+g) = %+v", a)
+	}
+	if a := Find(actions, ResourceImage, "f2"); a != nil {
+		t.Errorf("Find should match the resource type, got %q", a.Name)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); !os.IsNotExist(err) {
+		t.Errorf("expected not-exist error for a missing file, got %v", err)
+	}
+}
+
+// TestLoad_Invalid 测试缺少字段、未知资源、重复或遮盖内置快捷键的按键和错误模板
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		wantErr string
+	}{
+		{"missing command", "name: A\n    resource: container\n    key: alt+a", "name, key and command are required"},
+		{"unknown resource", "name: A\n    resource: volume\n    key: alt+a\n    command: echo", `unknown resource "volume"`},
+		{"plain key", "name: A\n    resource: container\n    key: q\n    command: echo", "key q would shadow built-in shortcuts"},
+		{"navigation key", "name: A\n    resource: image\n    key: up\n    command: echo", "key up would shadow built-in shortcuts"},
+		{"function key out of range", "name: A\n    resource: image\n    key: f25\n    command: echo", "key f25 would shadow built-in shortcuts"},
+		{"reserved combination", "name: A\n    resource: container\n    key: ctrl+d\n    command: echo", "key ctrl+d is used by docktui (remove / scroll down)"},
+		{"refresh key", "name: A\n    resource: network\n    key: f5\n    command: echo", "key f5 is used by docktui (refresh)"},
+		{"template syntax", "name: A\n    resource: container\n    key: alt+a\n    command: echo {{.ContainerID", `action "A"`},
+		{"unknown field", "name: A\n    resource: container\n    key: alt+a\n    command: echo {{.Container}}", "can't evaluate field Container"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeActions(t, "actions:\n  - "+tt.action+"\n"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	dup := `actions:
+  - name: A
+    resource: container
+    key: alt+a
+    command: echo a
+  - name: B
+    resource: image
+    key: alt+a
+    command: echo b
+  - name: C
+    resource: containers
+    key: alt+a
+    command: echo c
+`
+	if _, err := Load(writeActions(t, dup)); err == nil || !strings.Contains(err.Error(), `action "C": key alt+a is already used by "A"`) {
+		t.Errorf("expected duplicate key error, got %v", err)
+	}
+}
+
+// TestRender 测试模板展开和 quote 转义
+func TestRender(t *testing.T) {
+	path := writeActions(t, `actions:
+  - name: Inspect
+    resource: compose
+    key: alt+i
+    command: cd {{quote .ProjectDir}} && docker compose logs {{.ServiceName}}
+`)
+	actions, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	got, err := actions[0].Render(Context{ProjectDir: "/srv/it's here", ServiceName: "web"})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	want := `cd '/srv/it'\''s here' && docker compose logs web`
+	if got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+}
+
+// TestShellQuote 测试加引号后的参数经 shell 解析仍是原字符串
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", "''"},
+		{"plain", "'plain'"},
+		{"with space", "'with space'"},
+		{"it's", `'it'\''s'`},
+		{"''", `''\'''\'''`},
+	}
+	for _, tt := range tests {
+		if got := shellQuote(tt.in); got != tt.want {
+			t.Errorf("shellQuote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+	for _, in := range []string{"$(touch pwned)", "`id`; rm -rf /", `a"b\c`, "line\nbreak", "it's"} {
+		cmd := exec.Command(sh, "-c", "printf %s "+shellQuote(in))
+		cmd.Dir = t.TempDir()
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("sh: %v", err)
+		}
+		if string(out) != in {
+			t.Errorf("sh parsed %s as %q, want %q", shellQuote(in), out, in)
+		}
+	}
+}
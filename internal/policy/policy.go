@@ -0,0 +1,256 @@
+// Package policy 决定当前是否允许执行某个变更操作（只读模式、按资源配置的操作白名单）
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrReadOnly 只读模式下拒绝变更操作时返回的错误
+var ErrReadOnly = errors.New("docktui is running in read-only mode, mutating actions are disabled")
+
+// ErrNotPermitted 策略文件未允许该操作时返回的错误（会附带策略文件路径）
+var ErrNotPermitted = errors.New("action not permitted by policy")
+
+// Actions 各资源可由策略控制的操作，操作名为 <资源>.<操作>
+var Actions = map[string][]string{
+	"container": {"start", "stop", "restart", "remove", "pause", "unpause", "update", "prune", "exec", "run", "duplicate", "debug"},
+	"image":     {"remove", "prune", "tag", "untag", "load", "pull", "push", "transfer"},
+	"network":   {"create", "remove", "prune", "connect", "disconnect"},
+	"compose":   {"up", "down", "start", "stop", "restart", "pause", "unpause", "build", "pull"},
+	"service":   {"scale", "update"},
+}
+
+// readActions 查看类操作始终可用，允许出现在策略文件中但不做限制
+var readActions = map[string]bool{"list": true, "inspect": true, "logs": true, "stats": true}
+
+var readOnly atomic.Bool
+
+// Rules 按资源划分的允许操作列表
+type Rules struct {
+	Path    string
+	allowed map[string]map[string]bool // 资源 -> 允许的操作
+
+	defaultAllow bool // 未出现在文件中的资源是否允许（default: allow），默认全部禁止
+}
+
+var (
+	rules   *Rules
+	rulesMu sync.RWMutex
+)
+
+// SetReadOnly 开启或关闭只读模式
+func SetReadOnly(enabled bool) {
+	readOnly.Store(enabled)
+}
+
+// ReadOnly 是否处于只读模式
+func ReadOnly() bool {
+	return readOnly.Load()
+}
+
+// SetRules 设置操作策略，nil 表示不限制
+func SetRules(r *Rules) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules = r
+}
+
+// Restricted 是否加载了限制操作的策略文件
+func Restricted() bool {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	return rules != nil
+}
+
+// Check 检查是否允许执行变更操作 action（如 container.stop），不允许时返回错误
+func Check(action string) error {
+	if readOnly.Load() {
+		return ErrReadOnly
+	}
+	rulesMu.RLock()
+	r := rules
+	rulesMu.RUnlock()
+	if r != nil && !r.allows(action) {
+		return fmt.Errorf("%w (see %s)", ErrNotPermitted, r.Path)
+	}
+	return nil
+}
+
+// Allowed 是否允许执行变更操作 action
+func Allowed(action string) bool {
+	return Check(action) == nil
+}
+
+func (r *Rules) allows(action string) bool {
+	resource, op, _ := strings.Cut(action, ".")
+	allowed, listed := r.allowed[resource]
+	if !listed {
+		return r.defaultAllow
+	}
+	return allowed[op]
+}
+
+// DefaultPath 返回默认策略文件路径（用户配置目录下的 docktui/policy.yaml）
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "policy.yaml"), nil
+}
+
+// file 策略文件结构：default 决定未列出的资源是否允许，其余的键为资源名
+type file struct {
+	Default   string                `yaml:"default"`
+	Resources map[string]actionList `yaml:",inline"`
+}
+
+// actionList 资源允许的操作，可以写成逗号分隔的字符串或列表
+type actionList struct {
+	items []string
+	nodes []*yaml.Node // 每个操作所在的节点，用于报错位置
+}
+
+// UnmarshalYAML 解析 "start, stop" 或 [start, stop] / "- start" 形式的操作列表
+func (l *actionList) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		for _, item := range strings.Split(node.Value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				l.items = append(l.items, item)
+				l.nodes = append(l.nodes, node)
+			}
+		}
+		return nil
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			if n.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: expected an action name", n.Line)
+			}
+			l.items = append(l.items, n.Value)
+			l.nodes = append(l.nodes, n)
+		}
+		return nil
+	}
+	return fmt.Errorf("line %d: expected a list of actions", node.Line)
+}
+
+// Load 读取策略文件，每个资源列出允许的操作：
+//
+//	default: deny        # 未列出的资源：deny（默认）全部禁止，allow 不受限制
+//	containers: start, stop, logs
+//	images: none
+//	compose: [up, down]
+//
+// 值可以是逗号分隔的字符串或列表，none 表示全部禁止，all 表示全部允许；
+// 错误信息带有 <文件>:<行>:<列> 位置
+func Load(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	r := &Rules{Path: path, allowed: make(map[string]map[string]bool)}
+	if len(root.Content) == 0 {
+		// 空文件：全部禁止
+		return r, nil
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s:%d:%d: expected \"resource: actions\" entries", path, doc.Line, doc.Column)
+	}
+	var f file
+	if err := doc.Decode(&f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	// 按文件中的顺序处理，报错位置和重复资源的判断才稳定
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		keyNode := doc.Content[i]
+		key := keyNode.Value
+		fail := func(node *yaml.Node, format string, args ...any) error {
+			return fmt.Errorf("%s:%d:%d: %s", path, node.Line, node.Column, fmt.Sprintf(format, args...))
+		}
+
+		if key == "default" {
+			switch strings.ToLower(f.Default) {
+			case "", "deny":
+			case "allow":
+				r.defaultAllow = true
+			default:
+				return nil, fail(doc.Content[i+1], "default must be allow or deny, got %q", f.Default)
+			}
+			continue
+		}
+
+		resource, err := normalizeResource(key)
+		if err != nil {
+			return nil, fail(keyNode, "%v", err)
+		}
+		if _, dup := r.allowed[resource]; dup {
+			return nil, fail(keyNode, "duplicate resource %q", key)
+		}
+		r.allowed[resource] = make(map[string]bool)
+		list := f.Resources[key]
+		for n, item := range list.items {
+			if err := r.add(resource, item); err != nil {
+				return nil, fail(list.nodes[n], "%v", err)
+			}
+		}
+	}
+	return r, nil
+}
+
+// add 为资源添加一个允许的操作
+func (r *Rules) add(resource, op string) error {
+	op = strings.ToLower(strings.Trim(op, `"'`))
+	switch {
+	case op == "none":
+		return nil
+	case op == "all" || op == "*":
+		for _, a := range Actions[resource] {
+			r.allowed[resource][a] = true
+		}
+		return nil
+	case readActions[op]:
+		return nil
+	}
+	for _, a := range Actions[resource] {
+		if a == op {
+			r.allowed[resource][op] = true
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown %s action %q (available: %s)", resource, op, strings.Join(Actions[resource], ", "))
+}
+
+// normalizeResource 将 containers/images 等写法统一为资源名
+func normalizeResource(key string) (string, error) {
+	key = strings.ToLower(key)
+	if _, ok := Actions[key]; ok {
+		return key, nil
+	}
+	if singular := strings.TrimSuffix(key, "s"); singular != key {
+		if _, ok := Actions[singular]; ok {
+			return singular, nil
+		}
+	}
+	names := make([]string, 0, len(Actions))
+	for name := range Actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("unknown resource %q (available: %s)", key, strings.Join(names, ", "))
+}
@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writePolicy 把策略内容写入临时文件并返回路径
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestLoad 测试各种写法的策略文件及允许的操作
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		allow   []string
+		deny    []string
+	}{
+		{
+			name:    "comma list",
+			content: "containers: start, stop, logs # 查看日志始终可用\n",
+			allow:   []string{"container.start", "container.stop"},
+			deny:    []string{"container.remove", "image.pull", "compose.up"},
+		},
+		{
+			name:    "flow and block sequences",
+			content: "compose: [up, down]\nimages:\n  - pull\n  - tag\n",
+			allow:   []string{"compose.up", "compose.down", "image.pull", "image.tag"},
+			deny:    []string{"compose.build", "image.remove", "container.start"},
+		},
+		{
+			name:    "none and all",
+			content: "images: none\nnetworks: all\n",
+			allow:   []string{"network.create", "network.disconnect"},
+			deny:    []string{"image.pull", "container.exec"},
+		},
+		{
+			name:    "empty value denies everything",
+			content: "images:\n",
+			deny:    []string{"image.pull", "container.start"},
+		},
+		{
+			name:    "default allow",
+			content: "default: allow\nimages: pull\n",
+			allow:   []string{"image.pull", "container.remove", "compose.down"},
+			deny:    []string{"image.remove"},
+		},
+		{
+			name:    "default deny",
+			content: "default: deny\ncontainer: [all]\n",
+			allow:   []string{"container.remove"},
+			deny:    []string{"service.scale"},
+		},
+		{
+			name:    "empty file",
+			content: "# nothing allowed\n",
+			deny:    []string{"container.start", "image.pull"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Load(writePolicy(t, tt.content))
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			for _, action := range tt.allow {
+				if !r.allows(action) {
+					t.Errorf("%s denied, want allowed", action)
+				}
+			}
+			for _, action := range tt.deny {
+				if r.allows(action) {
+					t.Errorf("%s allowed, want denied", action)
+				}
+			}
+		})
+	}
+}
+
+// TestLoad_Errors 测试错误信息及其位置
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown resource", "containers: start\nvolumes: remove\n", ":2:1: unknown resource \"volumes\""},
+		{"unknown action", "images: pull\ncontainers: [start, nuke]\n", ":2:21: unknown container action \"nuke\""},
+		{"unknown action in block list", "images:\n  - pull\n  - burn\n", ":3:5: unknown image action \"burn\""},
+		{"duplicate resource", "container: start\ncontainers: stop\n", ":2:1: duplicate resource \"containers\""},
+		{"bad default", "default: maybe\n", ":1:10: default must be allow or deny"},
+		{"not a mapping", "- start\n", ":1:1: expected \"resource: actions\""},
+		{"nested mapping", "containers:\n  start: true\n", "line 2: expected a list of actions"},
+		{"syntax error", "containers: [start\n", "yaml:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writePolicy(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestCheck 测试只读模式和策略的组合
+func TestCheck(t *testing.T) {
+	r, err := Load(writePolicy(t, "containers: start\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		SetRules(nil)
+		SetReadOnly(false)
+	})
+
+	tests := []struct {
+		name     string
+		rules    *Rules
+		readOnly bool
+		action   string
+		wantErr  error
+	}{
+		{"no policy", nil, false, "container.remove", nil},
+		{"allowed", r, false, "container.start", nil},
+		{"not listed action", r, false, "container.remove", ErrNotPermitted},
+		{"not listed resource", r, false, "image.pull", ErrNotPermitted},
+		{"read-only wins", r, true, "container.start", ErrReadOnly},
+		{"read-only without policy", nil, true, "image.pull", ErrReadOnly},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetRules(tt.rules)
+			SetReadOnly(tt.readOnly)
+			err := Check(tt.action)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Errorf("Check(%q) = %v, want %v", tt.action, err, tt.wantErr)
+			}
+			if got := Allowed(tt.action); got != (tt.wantErr == nil) {
+				t.Errorf("Allowed(%q) = %v, want %v", tt.action, got, !got)
+			}
+			if err != nil && errors.Is(err, ErrNotPermitted) && !strings.Contains(err.Error(), r.Path) {
+				t.Errorf("Check(%q) = %v, want policy path in error", tt.action, err)
+			}
+		})
+	}
+}
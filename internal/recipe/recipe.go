@@ -0,0 +1,138 @@
+// Package recipe 读取用户定义的容器模板（配置目录下的 YAML 文件），用于一键启动常用的单容器工具
+package recipe
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"docktui/internal/docker"
+)
+
+// LabelRecipe 由模板启动的容器带有该标签，值为模板名称
+const LabelRecipe = "docktui.recipe"
+
+// Recipe 一个容器模板
+type Recipe struct {
+	Name          string            `yaml:"name"`           // 模板名称，默认取文件名
+	Description   string            `yaml:"description"`    // 说明
+	Image         string            `yaml:"image"`          // 镜像（必填）
+	ContainerName string            `yaml:"container_name"` // 容器名称，空表示由 Docker 生成
+	Command       []string          `yaml:"command"`        // 覆盖镜像默认命令
+	Ports         []string          `yaml:"ports"`          // 端口映射，如 "5432:5432"
+	Env           map[string]string `yaml:"env"`            // 环境变量
+	Volumes       []string          `yaml:"volumes"`        // 挂载，如 "pgdata:/var/lib/postgresql/data"、"./data:/data:ro"
+	Restart       string            `yaml:"restart"`        // 重启策略
+	Remove        bool              `yaml:"rm"`             // 退出后自动删除
+	Resources     string            `yaml:"resources"`      // 资源限制预设名称（config.yaml 中的 resource_presets）
+
+	Path string `yaml:"-"` // 模板文件路径
+}
+
+// DefaultDir 返回默认模板目录（用户配置目录下的 docktui/recipes）
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "recipes"), nil
+}
+
+// LoadDir 读取目录下所有 .yaml/.yml 模板，按名称排序
+// 单个文件解析失败不影响其他模板，错误一并返回
+func LoadDir(dir string) ([]Recipe, []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []error{err}
+	}
+
+	var recipes []Recipe
+	var errs []error
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		r, err := Load(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		recipes = append(recipes, *r)
+	}
+	sort.Slice(recipes, func(i, j int) bool { return recipes[i].Name < recipes[j].Name })
+	return recipes, errs
+}
+
+// Load 读取单个模板文件
+func Load(path string) (*Recipe, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Recipe
+	if err := yaml.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	r.Path = path
+	if r.Name == "" {
+		r.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if r.Image == "" {
+		return nil, fmt.Errorf("%s: image is required", filepath.Base(path))
+	}
+	if _, ok := docker.FindResourcePreset(r.Resources); r.Resources != "" && !ok {
+		return nil, fmt.Errorf("%s: unknown resources preset %q", filepath.Base(path), r.Resources)
+	}
+	return &r, nil
+}
+
+// EnvList 返回按键排序的 KEY=VALUE 列表
+func (r Recipe) EnvList() []string {
+	keys := make([]string, 0, len(r.Env))
+	for k := range r.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	env := make([]string, 0, len(keys))
+	for _, k := range keys {
+		env = append(env, k+"="+r.Env[k])
+	}
+	return env
+}
+
+// RunOptions 转换为创建容器的参数，相对路径的挂载以模板文件所在目录为基准
+func (r Recipe) RunOptions() docker.ContainerRunOptions {
+	binds := make([]string, 0, len(r.Volumes))
+	for _, v := range r.Volumes {
+		if strings.HasPrefix(v, "./") || strings.HasPrefix(v, "../") {
+			v = filepath.Join(filepath.Dir(r.Path), v)
+		} else if strings.HasPrefix(v, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				v = filepath.Join(home, v[2:])
+			}
+		}
+		binds = append(binds, v)
+	}
+	preset, _ := docker.FindResourcePreset(r.Resources)
+	return docker.ContainerRunOptions{
+		Name:          r.ContainerName,
+		Image:         r.Image,
+		Cmd:           r.Command,
+		Env:           r.EnvList(),
+		Ports:         r.Ports,
+		Binds:         binds,
+		Labels:        map[string]string{LabelRecipe: r.Name},
+		RestartPolicy: r.Restart,
+		AutoRemove:    r.Remove,
+		NanoCPUs:      preset.NanoCPUs(),
+		Memory:        preset.Memory,
+	}
+}
@@ -0,0 +1,118 @@
+// Package recording 将交互式 shell 会话录制为 asciinema v2 格式的 cast 文件
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// castHeader asciicast v2 文件头
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder 会话录制器，实现 io.Writer，写入的内容作为输出事件记录
+type Recorder struct {
+	file  *os.File
+	enc   *json.Encoder
+	start time.Time
+	path  string
+	// pending 保存被截断在两次写入之间的不完整 UTF-8 字符
+	pending []byte
+	mu      sync.Mutex
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// New 在 dir 下创建 <name>-<时间戳>.cast 文件并写入文件头
+func New(dir, name string, width, height int) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	if width <= 0 {
+		width = 80
+	}
+	if height <= 0 {
+		height = 24
+	}
+
+	start := time.Now()
+	safeName := unsafeNameChars.ReplaceAllString(name, "_")
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.cast", safeName, start.Format("20060102-150405")))
+
+	// 录制内容可能包含敏感信息，仅允许当前用户读取
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording file: %w", err)
+	}
+
+	r := &Recorder{
+		file:  file,
+		enc:   json.NewEncoder(file),
+		start: start,
+		path:  path,
+	}
+	header := castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: start.Unix(),
+		Title:     name,
+		Env:       map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	}
+	if err := r.enc.Encode(header); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write recording header: %w", err)
+	}
+	return r, nil
+}
+
+// Write 记录一段输出
+func (r *Recorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data := append(r.pending, p...)
+	cut := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				cut = i
+			}
+			break
+		}
+	}
+	r.pending = append([]byte(nil), data[cut:]...)
+	if cut == 0 {
+		return len(p), nil
+	}
+
+	elapsed := time.Since(r.start).Seconds()
+	if err := r.enc.Encode([]interface{}{elapsed, "o", string(data[:cut])}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Path 返回录制文件路径
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+// Close 关闭录制文件
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
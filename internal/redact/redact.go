@@ -0,0 +1,97 @@
+// Package redact 按用户配置的正则规则遮盖日志中的敏感内容（令牌、邮箱等），
+// 日志视图和日志导出都经过同一组规则
+package redact
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultReplacement 规则未指定 replace 时使用的替换文本
+const defaultReplacement = "***"
+
+// Rule 一条遮盖规则：匹配 pattern 的内容替换为 replace（支持 $1 等分组引用）
+type Rule struct {
+	Name    string `yaml:"name"`    // 规则名称，仅用于报错和展示
+	Pattern string `yaml:"pattern"` // Go 正则表达式
+	Replace string `yaml:"replace"` // 替换文本，为空时使用 ***
+
+	re *regexp.Regexp
+}
+
+// file 配置文件结构
+type file struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+var (
+	rules   []Rule
+	rulesMu sync.RWMutex
+)
+
+// DefaultPath 返回默认配置文件路径（用户配置目录下的 docktui/redact.yaml）
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "redact.yaml"), nil
+}
+
+// Load 读取并编译遮盖规则
+func Load(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	for i := range f.Rules {
+		r := &f.Rules[i]
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("#%d", i+1)
+		}
+		if r.Pattern == "" {
+			return nil, fmt.Errorf("rule %s: pattern is required", r.Name)
+		}
+		if r.re, err = regexp.Compile(r.Pattern); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", r.Name, err)
+		}
+		if r.Replace == "" {
+			r.Replace = defaultReplacement
+		}
+	}
+	return f.Rules, nil
+}
+
+// SetRules 设置生效的遮盖规则，nil 表示不遮盖
+func SetRules(r []Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules = r
+}
+
+// Count 返回生效的规则数量
+func Count() int {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	return len(rules)
+}
+
+// Apply 依次应用所有规则，返回遮盖后的文本
+func Apply(s string) string {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	for _, r := range rules {
+		s = r.re.ReplaceAllString(s, r.Replace)
+	}
+	return s
+}
@@ -0,0 +1,147 @@
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ChangeKind 变更类型
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change 单个资源的变更
+type Change struct {
+	Resource string     // container, image, network
+	Kind     ChangeKind // 变更类型
+	Key      string     // 资源标识（容器名、镜像引用、网络名）
+	Details  []string   // 变更明细，如 "state: running -> exited"
+}
+
+// Diff 对比旧快照与当前快照，返回按资源类型和名称排序的变更列表
+func Diff(old, cur *Snapshot) []Change {
+	var changes []Change
+
+	oldContainers := make(map[string]ContainerEntry, len(old.Containers))
+	for _, c := range old.Containers {
+		oldContainers[c.Name] = c
+	}
+	curContainers := make(map[string]ContainerEntry, len(cur.Containers))
+	for _, c := range cur.Containers {
+		curContainers[c.Name] = c
+	}
+	for name, c := range curContainers {
+		prev, ok := oldContainers[name]
+		if !ok {
+			changes = append(changes, Change{Resource: "container", Kind: ChangeAdded, Key: name, Details: []string{"image: " + c.Image}})
+			continue
+		}
+		var details []string
+		details = appendIfChanged(details, "id", shortID(prev.ID), shortID(c.ID))
+		details = appendIfChanged(details, "image", prev.Image, c.Image)
+		details = appendIfChanged(details, "image id", shortID(prev.ImageID), shortID(c.ImageID))
+		details = appendIfChanged(details, "state", prev.State, c.State)
+		if len(details) > 0 {
+			changes = append(changes, Change{Resource: "container", Kind: ChangeChanged, Key: name, Details: details})
+		}
+	}
+	for name, c := range oldContainers {
+		if _, ok := curContainers[name]; !ok {
+			changes = append(changes, Change{Resource: "container", Kind: ChangeRemoved, Key: name, Details: []string{"image: " + c.Image}})
+		}
+	}
+
+	oldImages := make(map[string]ImageEntry, len(old.Images))
+	for _, img := range old.Images {
+		oldImages[imageKey(img.ID, img.Repository, img.Tag)] = img
+	}
+	curImages := make(map[string]ImageEntry, len(cur.Images))
+	for _, img := range cur.Images {
+		curImages[imageKey(img.ID, img.Repository, img.Tag)] = img
+	}
+	for key, img := range curImages {
+		prev, ok := oldImages[key]
+		if !ok {
+			changes = append(changes, Change{Resource: "image", Kind: ChangeAdded, Key: key, Details: []string{"id: " + shortID(img.ID)}})
+			continue
+		}
+		var details []string
+		details = appendIfChanged(details, "id", shortID(prev.ID), shortID(img.ID))
+		details = appendIfChanged(details, "digest", prev.Digest, img.Digest)
+		if len(details) > 0 {
+			changes = append(changes, Change{Resource: "image", Kind: ChangeChanged, Key: key, Details: details})
+		}
+	}
+	for key, img := range oldImages {
+		if _, ok := curImages[key]; !ok {
+			changes = append(changes, Change{Resource: "image", Kind: ChangeRemoved, Key: key, Details: []string{"id: " + shortID(img.ID)}})
+		}
+	}
+
+	oldNetworks := make(map[string]NetworkEntry, len(old.Networks))
+	for _, n := range old.Networks {
+		oldNetworks[n.Name] = n
+	}
+	curNetworks := make(map[string]NetworkEntry, len(cur.Networks))
+	for _, n := range cur.Networks {
+		curNetworks[n.Name] = n
+	}
+	for name, n := range curNetworks {
+		prev, ok := oldNetworks[name]
+		if !ok {
+			changes = append(changes, Change{Resource: "network", Kind: ChangeAdded, Key: name, Details: []string{"driver: " + n.Driver}})
+			continue
+		}
+		var details []string
+		details = appendIfChanged(details, "id", shortID(prev.ID), shortID(n.ID))
+		details = appendIfChanged(details, "driver", prev.Driver, n.Driver)
+		details = appendIfChanged(details, "scope", prev.Scope, n.Scope)
+		if len(details) > 0 {
+			changes = append(changes, Change{Resource: "network", Kind: ChangeChanged, Key: name, Details: details})
+		}
+	}
+	for name, n := range oldNetworks {
+		if _, ok := curNetworks[name]; !ok {
+			changes = append(changes, Change{Resource: "network", Kind: ChangeRemoved, Key: name, Details: []string{"driver: " + n.Driver}})
+		}
+	}
+
+	resourceOrder := map[string]int{"container": 0, "image": 1, "network": 2}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Resource != changes[j].Resource {
+			return resourceOrder[changes[i].Resource] < resourceOrder[changes[j].Resource]
+		}
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// appendIfChanged 字段值不同时追加一条变更明细
+func appendIfChanged(details []string, field, before, after string) []string {
+	if before == after {
+		return details
+	}
+	return append(details, fmt.Sprintf("%s: %s -> %s", field, orNone(before), orNone(after)))
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+// shortID 截取 ID 前 12 位（去掉 sha256: 前缀）
+func shortID(id string) string {
+	if len(id) > 7 && id[:7] == "sha256:" {
+		id = id[7:]
+	}
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
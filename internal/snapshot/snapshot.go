@@ -0,0 +1,151 @@
+// Package snapshot 记录 Docker 主机上的容器、镜像和网络状态，并支持与当前状态对比。
+// 典型用法：部署前保存快照，部署后对比查看哪些资源被新增、删除或修改。
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"docktui/internal/docker"
+)
+
+// formatVersion 快照文件格式版本
+const formatVersion = 1
+
+// Snapshot 主机状态快照
+type Snapshot struct {
+	Version    int              `json:"version"`
+	CreatedAt  time.Time        `json:"created_at"`
+	Host       string           `json:"host,omitempty"`
+	Containers []ContainerEntry `json:"containers"`
+	Images     []ImageEntry     `json:"images"`
+	Networks   []NetworkEntry   `json:"networks"`
+}
+
+// ContainerEntry 快照中的容器记录
+type ContainerEntry struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Image   string `json:"image"`
+	ImageID string `json:"image_id"`
+	State   string `json:"state"`
+}
+
+// ImageEntry 快照中的镜像记录
+type ImageEntry struct {
+	ID         string `json:"id"`
+	Repository string `json:"repository"`
+	Tag        string `json:"tag"`
+	Digest     string `json:"digest,omitempty"`
+}
+
+// NetworkEntry 快照中的网络记录
+type NetworkEntry struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Driver string `json:"driver"`
+	Scope  string `json:"scope"`
+}
+
+// Capture 读取当前主机状态生成快照
+func Capture(ctx context.Context, client docker.Client) (*Snapshot, error) {
+	containers, err := client.ListContainers(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	images, err := client.ListImages(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	networks, err := client.ListNetworks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &Snapshot{
+		Version:   formatVersion,
+		CreatedAt: time.Now(),
+		Host:      os.Getenv("DOCKER_HOST"),
+	}
+	for _, c := range containers {
+		snap.Containers = append(snap.Containers, ContainerEntry{
+			ID:      c.ID,
+			Name:    c.Name,
+			Image:   c.Image,
+			ImageID: c.ImageID,
+			State:   c.State,
+		})
+	}
+	seen := make(map[string]bool)
+	for _, img := range images {
+		// ListImages 会为每个标签各返回一条记录，这里按引用去重
+		key := imageKey(img.ID, img.Repository, img.Tag)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		snap.Images = append(snap.Images, ImageEntry{
+			ID:         img.ID,
+			Repository: img.Repository,
+			Tag:        img.Tag,
+			Digest:     img.Digest,
+		})
+	}
+	for _, n := range networks {
+		snap.Networks = append(snap.Networks, NetworkEntry{
+			ID:     n.ID,
+			Name:   n.Name,
+			Driver: n.Driver,
+			Scope:  n.Scope,
+		})
+	}
+
+	sort.Slice(snap.Containers, func(i, j int) bool { return snap.Containers[i].Name < snap.Containers[j].Name })
+	sort.Slice(snap.Images, func(i, j int) bool {
+		return imageKey(snap.Images[i].ID, snap.Images[i].Repository, snap.Images[i].Tag) <
+			imageKey(snap.Images[j].ID, snap.Images[j].Repository, snap.Images[j].Tag)
+	})
+	sort.Slice(snap.Networks, func(i, j int) bool { return snap.Networks[i].Name < snap.Networks[j].Name })
+
+	return snap, nil
+}
+
+// Save 将快照写入 JSON 文件
+func Save(path string, snap *Snapshot) error {
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load 从 JSON 文件读取快照
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	if snap.Version > formatVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", snap.Version)
+	}
+	return &snap, nil
+}
+
+// imageKey 镜像的比较键：有标签时用 repo:tag，悬垂镜像用 ID
+func imageKey(id, repository, tag string) string {
+	if repository == "" || repository == "<none>" {
+		return id
+	}
+	return repository + ":" + tag
+}
@@ -0,0 +1,219 @@
+// Package stack 把任意容器（没有用 compose 管理的旧主机应用）组织成命名的栈，
+// 按保存的顺序和间隔一键启动、按相反顺序停止；栈保存在本机配置目录
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxDelay 成员启动后等待时长的上限
+const MaxDelay = 10 * time.Minute
+
+// Member 栈中的一个容器
+type Member struct {
+	Container    string `json:"container"`               // 容器名称
+	DelaySeconds int    `json:"delay_seconds,omitempty"` // 启动该容器后等待多久再启动下一个
+}
+
+// Delay 启动该容器后的等待时长
+func (m Member) Delay() time.Duration {
+	return time.Duration(m.DelaySeconds) * time.Second
+}
+
+// Stack 按顺序启动/停止的一组容器
+type Stack struct {
+	Name    string   `json:"name"`
+	Members []Member `json:"members"` // 启动顺序，停止时相反
+}
+
+// StartOrder 启动顺序
+func (s Stack) StartOrder() []Member {
+	return append([]Member(nil), s.Members...)
+}
+
+// StopOrder 停止顺序：与启动顺序相反，先启动的（通常是被依赖的数据库等）最后停止
+func (s Stack) StopOrder() []Member {
+	order := s.StartOrder()
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
+// Has 栈中是否已包含该容器
+func (s Stack) Has(container string) bool {
+	for _, m := range s.Members {
+		if m.Container == container {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate 检查名称、成员和等待时长
+func (s Stack) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("stack name is required")
+	}
+	if len(s.Members) == 0 {
+		return fmt.Errorf("stack %s has no containers", s.Name)
+	}
+	seen := make(map[string]bool, len(s.Members))
+	for _, m := range s.Members {
+		if m.Container == "" {
+			return fmt.Errorf("stack %s has a member without a container name", s.Name)
+		}
+		if seen[m.Container] {
+			return fmt.Errorf("container %s is listed twice in stack %s", m.Container, s.Name)
+		}
+		seen[m.Container] = true
+		if m.DelaySeconds < 0 || m.Delay() > MaxDelay {
+			return fmt.Errorf("delay for %s must be between 0 and %s", m.Container, MaxDelay)
+		}
+	}
+	return nil
+}
+
+// state 栈文件结构
+type state struct {
+	Stacks []Stack `json:"stacks"`
+}
+
+// Store 栈文件
+type Store struct {
+	path  string
+	state state
+	mu    sync.RWMutex
+}
+
+var (
+	defaultStore *Store
+	defaultMu    sync.RWMutex
+)
+
+// DefaultPath 返回默认栈文件路径（用户配置目录下的 docktui/stacks.json）
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "stacks.json"), nil
+}
+
+// Open 读取栈文件，文件不存在时视为空
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read stacks: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, fmt.Errorf("failed to parse stacks: %w", err)
+	}
+	return s, nil
+}
+
+// Path 返回栈文件路径
+func (s *Store) Path() string {
+	return s.path
+}
+
+// List 返回所有栈（按名称排序）
+func (s *Store) List() []Stack {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stacks := make([]Stack, len(s.state.Stacks))
+	for i, st := range s.state.Stacks {
+		st.Members = append([]Member(nil), st.Members...)
+		stacks[i] = st
+	}
+	sort.Slice(stacks, func(i, j int) bool { return stacks[i].Name < stacks[j].Name })
+	return stacks
+}
+
+// Get 按名称查找栈
+func (s *Store) Get(name string) (Stack, bool) {
+	for _, st := range s.List() {
+		if st.Name == name {
+			return st, true
+		}
+	}
+	return Stack{}, false
+}
+
+// Save 新建或替换同名的栈
+func (s *Store) Save(st Stack) error {
+	st.Name = strings.TrimSpace(st.Name)
+	if err := st.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.indexLocked(st.Name); i >= 0 {
+		s.state.Stacks[i] = st
+	} else {
+		s.state.Stacks = append(s.state.Stacks, st)
+	}
+	return s.flushLocked()
+}
+
+// Delete 删除栈，不存在时不报错
+func (s *Store) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexLocked(name)
+	if i < 0 {
+		return nil
+	}
+	s.state.Stacks = append(s.state.Stacks[:i], s.state.Stacks[i+1:]...)
+	return s.flushLocked()
+}
+
+func (s *Store) indexLocked(name string) int {
+	for i, st := range s.state.Stacks {
+		if st.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// flushLocked 写回栈文件（调用方需持有锁）
+func (s *Store) flushLocked() error {
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create stacks directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save stacks: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// SetDefault 设置全局栈文件
+func SetDefault(s *Store) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultStore = s
+}
+
+// Default 返回全局栈文件，未启用时返回 nil
+func Default() *Store {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultStore
+}
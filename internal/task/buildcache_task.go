@@ -0,0 +1,71 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"docktui/internal/docker"
+	"docktui/internal/format"
+)
+
+// BuildCachePruneTask 清理选中的构建缓存记录，完成后报告释放的空间
+type BuildCachePruneTask struct {
+	*BaseTask
+	ids          []string
+	dockerClient docker.Client
+}
+
+// NewBuildCachePruneTask 创建构建缓存清理任务
+func NewBuildCachePruneTask(client docker.Client, ids []string) *BuildCachePruneTask {
+	return &BuildCachePruneTask{
+		BaseTask:     NewBaseTask(GenerateTaskID(), fmt.Sprintf("Prune build cache (%d records)", len(ids))),
+		ids:          ids,
+		dockerClient: client,
+	}
+}
+
+// Run 逐条清理：已被其他记录清理时连带删除的记录不再计数，个别记录失败（如正在使用）不影响其余记录
+func (t *BuildCachePruneTask) Run(ctx context.Context) error {
+	t.SetStatus(StatusRunning)
+	manager := GetManager()
+
+	var deleted, failed int
+	var reclaimed int64
+	var lastErr error
+	for i, id := range t.ids {
+		if ctx.Err() != nil {
+			t.SetStatus(StatusCancelled)
+			t.SetMessage(fmt.Sprintf("Cancelled after freeing %s", format.Size(reclaimed)))
+			return ctx.Err()
+		}
+
+		progress := float64(i) / float64(len(t.ids)) * 100
+		t.SetProgress(progress)
+		t.SetMessage(fmt.Sprintf("[%d/%d] Pruning %s...", i+1, len(t.ids), shortImageID(id)))
+		manager.EmitProgress(t.ID(), t.Name(), progress, t.Message())
+
+		count, space, err := t.dockerClient.PruneBuildCache(ctx, docker.BuildCachePruneOptions{ID: id})
+		if err != nil {
+			failed++
+			lastErr = err
+			continue
+		}
+		deleted += count
+		reclaimed += space
+	}
+
+	if failed == len(t.ids) && lastErr != nil {
+		t.SetStatus(StatusFailed)
+		t.SetError(lastErr)
+		t.SetMessage(lastErr.Error())
+		return lastErr
+	}
+	msg := fmt.Sprintf("Pruned %d build cache records, freed %s", deleted, format.Size(reclaimed))
+	if failed > 0 {
+		msg += fmt.Sprintf(" (%d failed: %v)", failed, lastErr)
+	}
+	t.SetProgress(100)
+	t.SetStatus(StatusCompleted)
+	t.SetMessage(msg)
+	return nil
+}
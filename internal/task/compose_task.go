@@ -0,0 +1,121 @@
+package task
+
+import (
+	"context"
+	"errors"
+
+	"docktui/internal/compose"
+)
+
+// ComposeTask Compose 项目操作任务（up/down 等）
+type ComposeTask struct {
+	*BaseTask
+	start func() *compose.OperationStream
+	logs  chan string
+	done  chan *compose.OperationResult
+}
+
+// NewComposeTask 创建 Compose 操作任务，start 在任务运行时启动实际的操作流
+func NewComposeTask(name string, start func() *compose.OperationStream) *ComposeTask {
+	return &ComposeTask{
+		BaseTask: NewBaseTask(GenerateTaskID(), name),
+		start:    start,
+		logs:     make(chan string, 100),
+		done:     make(chan *compose.OperationResult, 1),
+	}
+}
+
+// Stream 返回供界面消费的操作流，取消会经由任务管理器执行
+func (t *ComposeTask) Stream() *compose.OperationStream {
+	return &compose.OperationStream{
+		LogChan:  t.logs,
+		DoneChan: t.done,
+		Cancel: func() {
+			GetManager().Cancel(t.ID())
+		},
+	}
+}
+
+// Run 执行 Compose 操作，日志同时转发给界面和任务栏
+func (t *ComposeTask) Run(ctx context.Context) error {
+	t.SetStatus(StatusRunning)
+	t.SetMessage("Starting...")
+
+	manager := GetManager()
+	stream := t.start()
+
+	ctxDone := ctx.Done()
+	var result *compose.OperationResult
+	for result == nil {
+		select {
+		case <-ctxDone:
+			stream.Cancel()
+			ctxDone = nil
+		case line, ok := <-stream.LogChan:
+			if !ok {
+				// 日志已结束，只等待结果
+				stream.LogChan = nil
+				continue
+			}
+			t.forward(ctx, line)
+			// compose 不输出总进度，按日志行数逐步逼近 95%
+			progress := t.Progress()
+			progress += (95 - progress) * 0.1
+			t.SetProgress(progress)
+			t.SetMessage(line)
+			manager.EmitProgress(t.ID(), t.Name(), progress, line)
+		case r, ok := <-stream.DoneChan:
+			if !ok {
+				r = &compose.OperationResult{Success: false, Message: "Operation aborted"}
+			}
+			result = r
+		}
+	}
+
+	// 结果之后输出的剩余日志
+	if stream.LogChan != nil {
+		for line := range stream.LogChan {
+			t.forward(ctx, line)
+			t.writeLog(line)
+		}
+	}
+
+	// 先关闭日志通道再发送结果，界面读完全部日志后才会收到结果
+	close(t.logs)
+	t.done <- result
+	close(t.done)
+
+	if ctx.Err() != nil {
+		t.SetMessage("Cancelled")
+		return ctx.Err()
+	}
+	if !result.Success {
+		err := errors.New(result.Message)
+		t.SetStatus(StatusFailed)
+		t.SetError(err)
+		t.SetMessage(result.Message)
+		return err
+	}
+
+	t.SetStatus(StatusCompleted)
+	t.SetProgress(100)
+	t.SetMessage(result.Message)
+	manager.EmitProgress(t.ID(), t.Name(), 100, t.Message())
+	return nil
+}
+
+// CancelWarning 中途取消 compose 操作时项目可能只完成了一部分
+func (t *ComposeTask) CancelWarning() string {
+	if t.Status() != StatusRunning {
+		return ""
+	}
+	return "The project may be left partly applied"
+}
+
+// forward 转发日志行，界面读取较慢时等待，保证操作日志完整；任务取消后不再转发
+func (t *ComposeTask) forward(ctx context.Context, line string) {
+	select {
+	case t.logs <- line:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,89 @@
+package task
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Compression 导出压缩格式
+type Compression string
+
+const (
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd" // 需要 PATH 中有 zstd 命令
+)
+
+var (
+	compressionOnce     sync.Once
+	detectedCompression Compression
+)
+
+// DetectCompression 返回可用的最佳压缩格式：PATH 中有 zstd 命令时用 zstd，否则用 gzip
+func DetectCompression() Compression {
+	compressionOnce.Do(func() {
+		detectedCompression = CompressionGzip
+		if _, err := exec.LookPath("zstd"); err == nil {
+			detectedCompression = CompressionZstd
+		}
+	})
+	return detectedCompression
+}
+
+// Ext 压缩文件扩展名（含 .tar）
+func (c Compression) Ext() string {
+	if c == CompressionZstd {
+		return ".tar.zst"
+	}
+	return ".tar.gz"
+}
+
+// newCompressor 创建流式压缩写入器，数据边写边压缩，不在内存中缓存整个镜像
+// threads 仅对 zstd 生效（gzip 标准库为单线程）
+func newCompressor(w io.Writer, c Compression, threads int) (io.WriteCloser, error) {
+	if c != CompressionZstd {
+		return gzip.NewWriter(w), nil
+	}
+	if threads < 1 {
+		threads = 1
+	}
+	cmd := exec.Command("zstd", "-q", "-c", "-T"+strconv.Itoa(threads))
+	cmd.Stdout = w
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start zstd: %w", err)
+	}
+	return &zstdWriter{stdin: stdin, cmd: cmd, stderr: &stderr}, nil
+}
+
+// zstdWriter 通过 zstd 子进程压缩，Close 时等待压缩完成
+type zstdWriter struct {
+	stdin  io.WriteCloser
+	cmd    *exec.Cmd
+	stderr *bytes.Buffer
+}
+
+func (z *zstdWriter) Write(p []byte) (int, error) {
+	return z.stdin.Write(p)
+}
+
+func (z *zstdWriter) Close() error {
+	z.stdin.Close()
+	if err := z.cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(z.stderr.String()); msg != "" {
+			return fmt.Errorf("zstd failed: %s", msg)
+		}
+		return fmt.Errorf("zstd failed: %w", err)
+	}
+	return nil
+}
@@ -1,14 +1,20 @@
 package task
 
 import (
-	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"docktui/internal/docker"
+	"docktui/internal/format"
 )
 
 // ExportMode 导出模式
@@ -24,20 +30,45 @@ type ExportImageInfo struct {
 	ID         string
 	Repository string
 	Tag        string
+	Digest     string // 仓库摘要（repo@sha256:...），可能为空
+}
+
+// ManifestFileName 导出多个镜像时和 tar 文件一起写入的清单文件名
+const ManifestFileName = "docktui-manifest.json"
+
+// ExportManifest 导出清单，用于离线环境校验和批量导入
+type ExportManifest struct {
+	Created time.Time             `json:"created"`
+	Images  []ExportManifestEntry `json:"images"`
+}
+
+// ExportManifestEntry 清单中的一个镜像，单文件模式下多个镜像共用同一个文件
+type ExportManifestEntry struct {
+	Name   string `json:"name"`
+	Tag    string `json:"tag"`
+	ID     string `json:"id"`
+	Digest string `json:"digest,omitempty"`
+	File   string `json:"file"`   // 相对于清单所在目录的文件名
+	SHA256 string `json:"sha256"` // 文件（压缩后）的 sha256
+	Size   int64  `json:"size"`   // 文件大小（字节）
 }
 
 // ExportTask 镜像导出任务
 type ExportTask struct {
 	*BaseTask
+	pauseGate
 	dockerClient docker.Client
 	images       []ExportImageInfo
 	exportDir    string
 	exportMode   ExportMode
 	compress     bool
+	compression  Compression
 	
 	// 导出结果
-	exportedFiles []string
-	totalSize     int64
+	exportedFiles  []string
+	totalSize      int64 // 未压缩大小
+	compressedSize int64 // 压缩后大小（未压缩时为 0）
+	manifest       []ExportManifestEntry
 }
 
 // NewExportTask 创建镜像导出任务
@@ -59,7 +90,44 @@ func NewExportTask(client docker.Client, images []ExportImageInfo, dir string, m
 		exportDir:    dir,
 		exportMode:   mode,
 		compress:     compress,
+		compression:  DetectCompression(),
+	}
+}
+
+// exportTaskKind 导出任务的持久化类型
+const exportTaskKind = "export"
+
+// exportTaskParams 导出任务的持久化参数
+type exportTaskParams struct {
+	Images   []ExportImageInfo `json:"images"`
+	Dir      string            `json:"dir"`
+	Mode     ExportMode        `json:"mode"`
+	Compress bool              `json:"compress"`
+}
+
+func init() {
+	RegisterResumer(exportTaskKind, func(client docker.Client, spec Spec) (Task, error) {
+		var params exportTaskParams
+		if err := json.Unmarshal(spec.Params, &params); err != nil {
+			return nil, err
+		}
+		// 导出流无法断点续传，重新导出会覆盖上次写了一半的文件
+		return NewExportTask(client, params.Images, params.Dir, params.Mode, params.Compress), nil
+	})
+}
+
+// Spec 返回导出任务的持久化描述
+func (t *ExportTask) Spec() (Spec, error) {
+	params, err := json.Marshal(exportTaskParams{
+		Images:   t.images,
+		Dir:      t.exportDir,
+		Mode:     t.exportMode,
+		Compress: t.compress,
+	})
+	if err != nil {
+		return Spec{}, err
 	}
+	return Spec{Kind: exportTaskKind, Params: params}, nil
 }
 
 // Run 执行导出任务
@@ -99,139 +167,198 @@ func (t *ExportTask) exportSingleFile(ctx context.Context, manager *Manager) err
 	if len(t.images) == 1 {
 		filename = t.generateFilename(t.images[0])
 	}
+	filename += t.ext()
 
-	ext := ".tar"
-	if t.compress {
-		ext = ".tar.gz"
-	}
-	filepath := t.exportDir + "/" + filename + ext
-
-	t.SetMessage(fmt.Sprintf("Exporting to %s...", filename+ext))
+	t.SetMessage(fmt.Sprintf("Exporting to %s...", filename))
 	manager.EmitProgress(t.ID(), t.Name(), 10, t.Message())
 
-	// 调用 Docker API 导出
-	reader, err := t.dockerClient.SaveImage(ctx, imageIDs)
+	// 单个文件只有一个压缩流，zstd 可以用满所有 worker 线程
+	result, err := t.writeArchive(ctx, imageIDs, filename, manager.Workers())
 	if err != nil {
 		t.SetStatus(StatusFailed)
 		t.SetError(err)
 		t.SetMessage("Export failed: " + err.Error())
 		return err
 	}
-	defer reader.Close()
 
-	// 创建输出文件
-	file, err := os.Create(filepath)
-	if err != nil {
-		t.SetStatus(StatusFailed)
-		t.SetError(err)
-		t.SetMessage("Create file failed: " + err.Error())
-		return err
-	}
-	defer file.Close()
-
-	var writer io.Writer = file
-	var gzWriter *gzip.Writer
-	if t.compress {
-		gzWriter = gzip.NewWriter(file)
-		defer gzWriter.Close()
-		writer = gzWriter
+	t.totalSize = result.original
+	t.compressedSize = result.size
+	t.exportedFiles = append(t.exportedFiles, filepath.Join(t.exportDir, filename))
+	for _, img := range t.images {
+		t.addManifestEntry(img, filename, result.checksum, result.size)
 	}
-
-	t.SetProgress(30)
-	t.SetMessage("Writing file...")
-	manager.EmitProgress(t.ID(), t.Name(), 30, t.Message())
-
-	// 写入文件
-	written, err := io.Copy(writer, reader)
-	if err != nil {
+	if err := t.writeManifest(); err != nil {
 		t.SetStatus(StatusFailed)
 		t.SetError(err)
-		t.SetMessage("Write failed: " + err.Error())
+		t.SetMessage("Write manifest failed: " + err.Error())
 		return err
 	}
 
-	t.totalSize = written
-	t.exportedFiles = append(t.exportedFiles, filepath)
-
 	t.SetStatus(StatusCompleted)
 	t.SetProgress(100)
-	t.SetMessage(fmt.Sprintf("Export completed: %s (%s)", filename+ext, formatBytes(written)))
+	t.SetMessage(fmt.Sprintf("Export completed: %s (%s)", filename, t.sizeSummary()))
 	manager.EmitProgress(t.ID(), t.Name(), 100, t.Message())
 
 	return nil
 }
 
-// exportMultipleFiles 导出为多个文件
+// exportMultipleFiles 导出为多个文件，按 worker 数量并行导出和压缩
 func (t *ExportTask) exportMultipleFiles(ctx context.Context, manager *Manager) error {
 	total := len(t.images)
-	
-	for i, img := range t.images {
+	workers := manager.Workers()
+	if workers > total {
+		workers = total
+	}
+
+	// 结果按镜像顺序保存，清单顺序和选择顺序一致
+	results := make([]*archiveResult, total)
+	jobs := make(chan int)
+	var mu sync.Mutex
+	done := 0
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				img := t.images[i]
+				filename := t.generateFilename(img) + t.ext()
+				// 单个失败不中断整体，继续下一个
+				result, err := t.writeArchive(ctx, []string{img.ID}, filename, 1)
+
+				mu.Lock()
+				done++
+				if err == nil {
+					results[i] = result
+				}
+				progress := float64(done) / float64(total) * 100
+				t.SetProgress(progress)
+				t.SetMessage(fmt.Sprintf("[%d/%d] Exported %s", done, total, filename))
+				manager.EmitProgress(t.ID(), t.Name(), progress, t.Message())
+				mu.Unlock()
+			}
+		}()
+	}
+
+	t.SetMessage(fmt.Sprintf("Exporting %d images with %d workers...", total, workers))
+	manager.EmitProgress(t.ID(), t.Name(), 0, t.Message())
+dispatch:
+	for i := range t.images {
 		select {
 		case <-ctx.Done():
-			t.SetStatus(StatusCancelled)
-			t.SetMessage("Cancelled")
-			return ctx.Err()
-		default:
+			break dispatch
+		case jobs <- i:
 		}
+	}
+	close(jobs)
+	wg.Wait()
 
-		// 计算进度
-		progress := float64(i) / float64(total) * 100
-		
-		// 生成文件名
-		filename := t.generateFilename(img)
-		ext := ".tar"
-		if t.compress {
-			ext = ".tar.gz"
-		}
-		filepath := t.exportDir + "/" + filename + ext
-
-		t.SetProgress(progress)
-		t.SetMessage(fmt.Sprintf("[%d/%d] Exporting %s...", i+1, total, filename))
-		manager.EmitProgress(t.ID(), t.Name(), progress, t.Message())
+	if ctx.Err() != nil {
+		t.SetStatus(StatusCancelled)
+		t.SetMessage("Cancelled")
+		return ctx.Err()
+	}
 
-		// 调用 Docker API 导出
-		reader, err := t.dockerClient.SaveImage(ctx, []string{img.ID})
-		if err != nil {
-			// 单个失败不中断整体，继续下一个
+	for i, result := range results {
+		if result == nil {
 			continue
 		}
+		t.totalSize += result.original
+		t.compressedSize += result.size
+		t.exportedFiles = append(t.exportedFiles, filepath.Join(t.exportDir, result.file))
+		t.addManifestEntry(t.images[i], result.file, result.checksum, result.size)
+	}
 
-		// 创建输出文件
-		file, err := os.Create(filepath)
-		if err != nil {
-			reader.Close()
-			continue
-		}
+	if err := t.writeManifest(); err != nil {
+		t.SetStatus(StatusFailed)
+		t.SetError(err)
+		t.SetMessage("Write manifest failed: " + err.Error())
+		return err
+	}
 
-		var writer io.Writer = file
-		var gzWriter *gzip.Writer
-		if t.compress {
-			gzWriter = gzip.NewWriter(file)
-			writer = gzWriter
-		}
+	t.SetStatus(StatusCompleted)
+	t.SetProgress(100)
+	t.SetMessage(fmt.Sprintf("Export completed: %d files (%s)", len(t.exportedFiles), t.sizeSummary()))
+	manager.EmitProgress(t.ID(), t.Name(), 100, t.Message())
+
+	return nil
+}
+
+// archiveResult 写入一个导出文件的结果
+type archiveResult struct {
+	file     string
+	original int64  // 未压缩的 tar 大小
+	size     int64  // 文件大小（压缩时为压缩后大小）
+	checksum string // 文件的 sha256
+}
 
-		// 写入文件
-		written, err := io.Copy(writer, reader)
-		
-		// 关闭资源
-		if gzWriter != nil {
-			gzWriter.Close()
+// writeArchive 将镜像流式写入导出目录下的文件，压缩时边读边压缩，同时计算文件的 sha256
+func (t *ExportTask) writeArchive(ctx context.Context, imageIDs []string, filename string, threads int) (*archiveResult, error) {
+	reader, err := t.dockerClient.SaveImage(ctx, imageIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	path := filepath.Join(t.exportDir, filename)
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create file failed: %w", err)
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	counter := &countingWriter{}
+	var writer io.Writer = io.MultiWriter(file, hasher, counter)
+	var compressor io.WriteCloser
+	if t.compress {
+		if compressor, err = newCompressor(writer, t.compression, threads); err != nil {
+			return nil, err
 		}
-		file.Close()
-		reader.Close()
+		writer = compressor
+	}
 
-		if err == nil {
-			t.totalSize += written
-			t.exportedFiles = append(t.exportedFiles, filepath)
+	original, err := io.Copy(writer, t.reader(ctx, reader))
+	// 压缩器关闭后文件内容才完整，校验和必须在此之后计算
+	if compressor != nil {
+		if closeErr := compressor.Close(); err == nil {
+			err = closeErr
 		}
 	}
+	if err != nil {
+		return nil, fmt.Errorf("write failed: %w", err)
+	}
+	return &archiveResult{
+		file:     filename,
+		original: original,
+		size:     counter.n,
+		checksum: hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
+}
 
-	t.SetStatus(StatusCompleted)
-	t.SetProgress(100)
-	t.SetMessage(fmt.Sprintf("Export completed: %d files (%s)", len(t.exportedFiles), formatBytes(t.totalSize)))
-	manager.EmitProgress(t.ID(), t.Name(), 100, t.Message())
+// CancelWarning 导出写到一半时取消会留下不完整的文件
+func (t *ExportTask) CancelWarning() string {
+	if t.Status() != StatusRunning {
+		return ""
+	}
+	return "Files being written in " + t.exportDir + " will be left incomplete"
+}
 
-	return nil
+// ext 导出文件扩展名
+func (t *ExportTask) ext() string {
+	if t.compress {
+		return t.compression.Ext()
+	}
+	return ".tar"
+}
+
+// sizeSummary 导出大小说明，压缩时显示原始大小和压缩后大小
+func (t *ExportTask) sizeSummary() string {
+	if !t.compress || t.totalSize == 0 {
+		return format.Size(t.totalSize)
+	}
+	return fmt.Sprintf("%s → %s %s, %.0f%%", format.Size(t.totalSize), format.Size(t.compressedSize),
+		t.compression, float64(t.compressedSize)/float64(t.totalSize)*100)
 }
 
 // generateFilename 生成文件名
@@ -248,26 +375,52 @@ func (t *ExportTask) generateFilename(img ExportImageInfo) string {
 	return name
 }
 
+// addManifestEntry 记录一个已导出的镜像
+func (t *ExportTask) addManifestEntry(img ExportImageInfo, file, checksum string, size int64) {
+	t.manifest = append(t.manifest, ExportManifestEntry{
+		Name:   img.Repository,
+		Tag:    img.Tag,
+		ID:     img.ID,
+		Digest: img.Digest,
+		File:   file,
+		SHA256: checksum,
+		Size:   size,
+	})
+}
+
+// writeManifest 导出多个镜像时在导出目录写入清单，单个镜像不写
+func (t *ExportTask) writeManifest() error {
+	if len(t.images) < 2 || len(t.manifest) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(ExportManifest{Created: time.Now(), Images: t.manifest}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(t.exportDir, ManifestFileName), append(data, '\n'), 0644)
+}
+
+// countingWriter 统计写入的字节数
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
 // GetExportedFiles 获取导出的文件列表
 func (t *ExportTask) GetExportedFiles() []string {
 	return t.exportedFiles
 }
 
-// GetTotalSize 获取导出的总大小
-func (t *ExportTask) GetTotalSize() int64 {
-	return t.totalSize
+// GetCompressedSize 获取压缩后的总大小，未压缩时为 0
+func (t *ExportTask) GetCompressedSize() int64 {
+	return t.compressedSize
 }
 
-// formatBytes 格式化字节大小
-func formatBytes(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+// GetTotalSize 获取导出的总大小（未压缩）
+func (t *ExportTask) GetTotalSize() int64 {
+	return t.totalSize
 }
@@ -0,0 +1,202 @@
+package task
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"docktui/internal/docker"
+)
+
+// ImportTask 按导出清单批量导入镜像的任务，导入前校验每个文件的 sha256
+type ImportTask struct {
+	*BaseTask
+	pauseGate
+	dockerClient docker.Client
+	manifestPath string
+
+	// 导入结果
+	loadedFiles int
+	imageCount  int
+}
+
+// NewImportTask 创建按清单导入镜像的任务，path 可以是清单文件或其所在目录
+func NewImportTask(client docker.Client, path string) *ImportTask {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, ManifestFileName)
+	}
+	return &ImportTask{
+		BaseTask:     NewBaseTask(GenerateTaskID(), fmt.Sprintf("Import %s", filepath.Base(filepath.Dir(path)))),
+		dockerClient: client,
+		manifestPath: path,
+	}
+}
+
+// importTaskKind 导入任务的持久化类型
+const importTaskKind = "import"
+
+// importTaskParams 导入任务的持久化参数
+type importTaskParams struct {
+	Manifest string `json:"manifest"`
+}
+
+func init() {
+	RegisterResumer(importTaskKind, func(client docker.Client, spec Spec) (Task, error) {
+		var params importTaskParams
+		if err := json.Unmarshal(spec.Params, &params); err != nil {
+			return nil, err
+		}
+		// 重复导入已存在的镜像只会更新标签
+		return NewImportTask(client, params.Manifest), nil
+	})
+}
+
+// Spec 返回导入任务的持久化描述
+func (t *ImportTask) Spec() (Spec, error) {
+	params, err := json.Marshal(importTaskParams{Manifest: t.manifestPath})
+	if err != nil {
+		return Spec{}, err
+	}
+	return Spec{Kind: importTaskKind, Params: params}, nil
+}
+
+// ReadManifest 读取导出清单
+func ReadManifest(path string) (*ExportManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest ExportManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	if len(manifest.Images) == 0 {
+		return nil, fmt.Errorf("manifest %s lists no images", path)
+	}
+	return &manifest, nil
+}
+
+// Run 执行导入任务：先校验所有文件，全部通过后再依次加载，避免只导入一部分
+func (t *ImportTask) Run(ctx context.Context) error {
+	t.SetStatus(StatusRunning)
+	t.SetMessage("Reading manifest...")
+	manager := GetManager()
+
+	fail := func(err error) error {
+		t.SetStatus(StatusFailed)
+		t.SetError(err)
+		t.SetMessage(err.Error())
+		manager.EmitProgress(t.ID(), t.Name(), t.Progress(), t.Message())
+		return err
+	}
+
+	manifest, err := ReadManifest(t.manifestPath)
+	if err != nil {
+		return fail(err)
+	}
+
+	// 单文件模式下多个镜像共用一个文件，只需加载一次
+	dir := filepath.Dir(t.manifestPath)
+	var files []string
+	checksums := make(map[string]string)
+	for _, entry := range manifest.Images {
+		if _, ok := checksums[entry.File]; ok {
+			continue
+		}
+		if filepath.Base(entry.File) != entry.File {
+			return fail(fmt.Errorf("manifest entry %q must be a file name in the manifest directory", entry.File))
+		}
+		checksums[entry.File] = entry.SHA256
+		files = append(files, entry.File)
+	}
+
+	// 校验阶段占 0-50%，加载阶段占 50-100%
+	for i, file := range files {
+		progress := float64(i) / float64(len(files)) * 50
+		t.SetProgress(progress)
+		t.SetMessage(fmt.Sprintf("[%d/%d] Verifying %s...", i+1, len(files), file))
+		manager.EmitProgress(t.ID(), t.Name(), progress, t.Message())
+
+		sum, err := t.fileSHA256(ctx, filepath.Join(dir, file))
+		if err != nil {
+			return fail(fmt.Errorf("verify %s failed: %w", file, err))
+		}
+		if checksums[file] != "" && sum != checksums[file] {
+			return fail(fmt.Errorf("checksum mismatch for %s: expected %s, got %s", file, checksums[file], sum))
+		}
+	}
+
+	for i, file := range files {
+		select {
+		case <-ctx.Done():
+			t.SetStatus(StatusCancelled)
+			t.SetMessage("Cancelled")
+			return ctx.Err()
+		default:
+		}
+
+		progress := 50 + float64(i)/float64(len(files))*50
+		t.SetProgress(progress)
+		t.SetMessage(fmt.Sprintf("[%d/%d] Loading %s...", i+1, len(files), file))
+		manager.EmitProgress(t.ID(), t.Name(), progress, t.Message())
+
+		// Docker 会自动识别 gzip 压缩的归档
+		f, err := os.Open(filepath.Join(dir, file))
+		if err != nil {
+			return fail(fmt.Errorf("open %s failed: %w", file, err))
+		}
+		err = t.dockerClient.LoadImage(ctx, t.reader(ctx, f), true)
+		f.Close()
+		if err != nil {
+			return fail(fmt.Errorf("load %s failed: %w", file, err))
+		}
+		t.loadedFiles++
+	}
+	t.imageCount = len(manifest.Images)
+
+	t.SetStatus(StatusCompleted)
+	t.SetProgress(100)
+	t.SetMessage(fmt.Sprintf("Import completed: %d images from %d files", t.imageCount, t.loadedFiles))
+	manager.EmitProgress(t.ID(), t.Name(), 100, t.Message())
+	return nil
+}
+
+// CancelWarning 已经加载了部分文件时取消，这些镜像会留在本机
+func (t *ImportTask) CancelWarning() string {
+	if t.Status() != StatusRunning || t.loadedFiles == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d files are already loaded and will stay; the rest will not be imported", t.loadedFiles)
+}
+
+// fileSHA256 计算文件的 sha256，可通过 ctx 取消，暂停时停止读取
+func (t *ImportTask) fileSHA256(ctx context.Context, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	r := t.reader(ctx, f)
+	hasher := sha256.New()
+	buf := make([]byte, 1<<20)
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		n, err := r.Read(buf)
+		hasher.Write(buf[:n])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,137 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// taskLog 任务输出日志文件：记录任务的每条进度消息和输出行，结束后写入最终状态
+type taskLog struct {
+	path string
+	f    *os.File
+	last string // 上一条写入的内容，拉取进度等重复消息只记一次
+	mu   sync.Mutex
+}
+
+// logTarget 可以记录输出日志的任务（嵌入 BaseTask 的任务都支持）
+type logTarget interface {
+	attachLog(l *taskLog)
+}
+
+// SetLogDir 设置任务输出日志目录，每个任务的输出写入该目录下的单独文件；为空表示不记录
+func (m *Manager) SetLogDir(dir string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logDir = dir
+}
+
+// LogDir 返回任务输出日志目录
+func (m *Manager) LogDir() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.logDir
+}
+
+// openLog 为任务创建日志文件，未设置目录或创建失败时返回 nil（不影响任务执行）
+func (m *Manager) openLog(task Task) *taskLog {
+	dir := m.LogDir()
+	target, ok := task.(logTarget)
+	if dir == "" || !ok {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil
+	}
+	name := fmt.Sprintf("%s-%s-%s.log", time.Now().Format("20060102-150405"), task.ID(), logFileSlug(task.Name()))
+	path := filepath.Join(dir, name)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil
+	}
+	l := &taskLog{path: path, f: f}
+	fmt.Fprintf(f, "# %s (task %s) started %s\n", task.Name(), task.ID(), time.Now().Format(time.RFC3339))
+	target.attachLog(l)
+	return l
+}
+
+// logFileSlug 把任务名称转换为文件名的一部分（如 "Pull nginx:latest" -> "pull-nginx-latest"）
+func logFileSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() >= 48 {
+			break
+		}
+	}
+	return strings.TrimRight(b.String(), "-")
+}
+
+// write 写入一行带时间戳的输出，与上一行相同时跳过
+func (l *taskLog) write(line string) {
+	if l == nil {
+		return
+	}
+	line = strings.TrimRight(line, "\r\n")
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil || line == "" || line == l.last {
+		return
+	}
+	l.last = line
+	fmt.Fprintf(l.f, "%s %s\n", time.Now().Format("15:04:05"), line)
+}
+
+// close 写入任务的最终状态并关闭文件
+func (l *taskLog) close(task Task) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return
+	}
+	result := task.Status().String()
+	if err := task.Error(); err != nil && task.Status() == StatusFailed {
+		result += ": " + err.Error()
+	}
+	fmt.Fprintf(l.f, "# %s at %s\n", result, time.Now().Format(time.RFC3339))
+	l.f.Close()
+	l.f = nil
+}
+
+// attachLog 关联日志文件，此后的进度消息同时写入文件
+func (t *BaseTask) attachLog(l *taskLog) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.log = l
+}
+
+// writeLog 只写入日志文件，不改变当前消息（如 compose 在结束后输出的剩余日志）
+func (t *BaseTask) writeLog(line string) {
+	t.mu.RLock()
+	l := t.log
+	t.mu.RUnlock()
+	l.write(line)
+}
+
+// LogPath 返回任务输出日志文件路径，未记录时返回空字符串
+func (t *BaseTask) LogPath() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.log == nil {
+		return ""
+	}
+	return t.log.path
+}
@@ -2,10 +2,13 @@ package task
 
 import (
 	"context"
+	"runtime"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+
+	"docktui/internal/docker"
 )
 
 // EventType 事件类型
@@ -37,6 +40,20 @@ type Manager struct {
 	eventChan  chan Event
 	subscribers []chan Event
 	subMu      sync.RWMutex
+	store      *store // 任务状态持久化（为 nil 时不持久化）
+	host       string // 当前连接的守护进程地址，记录到持久化的任务中
+	workers    int    // 单个任务内部可并行的 worker 数量（如并行导出压缩）
+	logDir     string // 任务输出日志目录（为空时不记录）
+	history    []string // 按提交顺序记录的任务 ID
+}
+
+// defaultWorkers 默认 worker 数量：CPU 核数，最多 4 个，避免同时导出太多镜像拖慢 Docker
+func defaultWorkers() int {
+	n := runtime.NumCPU()
+	if n > 4 {
+		n = 4
+	}
+	return n
 }
 
 var (
@@ -51,6 +68,7 @@ func GetManager() *Manager {
 			tasks:       make(map[string]Task),
 			eventChan:   make(chan Event, 100),
 			subscribers: make([]chan Event, 0),
+			workers:     defaultWorkers(),
 		}
 		go globalManager.dispatchEvents()
 	})
@@ -72,12 +90,46 @@ func (m *Manager) dispatchEvents() {
 	}
 }
 
+// SetWorkers 设置单个任务内部可并行的 worker 数量，小于 1 时恢复默认值
+func (m *Manager) SetWorkers(n int) {
+	if n < 1 {
+		n = defaultWorkers()
+	}
+	m.mu.Lock()
+	m.workers = n
+	m.mu.Unlock()
+}
+
+// Workers 返回单个任务内部可并行的 worker 数量
+func (m *Manager) Workers() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.workers
+}
+
 // Submit 提交任务
 func (m *Manager) Submit(task Task) string {
 	m.mu.Lock()
 	m.tasks[task.ID()] = task
+	m.history = append(m.history, task.ID())
+	st := m.store
 	m.mu.Unlock()
 
+	// 记录可恢复任务，进程中途退出后下次启动可继续
+	if st != nil {
+		if r, ok := task.(Resumable); ok {
+			if spec, err := r.Spec(); err == nil {
+				spec.ID = task.ID()
+				spec.Name = task.Name()
+				spec.SubmittedAt = time.Now()
+				m.mu.RLock()
+				spec.Host = m.host
+				m.mu.RUnlock()
+				st.put(spec)
+			}
+		}
+	}
+
 	// 发送开始事件
 	m.emitEvent(Event{
 		TaskID:   task.ID(),
@@ -102,7 +154,9 @@ func (m *Manager) runTask(task Task) {
 		cancellable.SetCancelFunc(cancel)
 	}
 
+	log := m.openLog(task)
 	err := task.Run(ctx)
+	log.close(task)
 
 	// 发送完成/失败事件
 	if err != nil {
@@ -136,6 +190,100 @@ func (m *Manager) runTask(task Task) {
 	}
 
 	cancel() // 清理 context
+
+	// 任务已结束，不再需要恢复
+	m.mu.RLock()
+	st := m.store
+	m.mu.RUnlock()
+	if st != nil {
+		st.remove(task.ID())
+	}
+}
+
+// EnablePersistence 启用任务状态持久化
+// path 为状态文件路径，通常由 DefaultStatePath 给出；host 为当前连接的守护进程地址，
+// 新任务记录该地址，恢复时只处理同一地址上的记录（其他地址的记录保留，等连接该地址时再恢复）
+func (m *Manager) EnablePersistence(path, host string) error {
+	st, err := newStore(path)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.store = st
+	m.host = host
+	m.mu.Unlock()
+	return nil
+}
+
+// PendingResume 返回上次退出时在当前守护进程上未完成、可以恢复的任务（按提交时间排序）
+func (m *Manager) PendingResume() []Spec {
+	m.mu.RLock()
+	st, host := m.store, m.host
+	m.mu.RUnlock()
+	if st == nil {
+		return nil
+	}
+	var specs []Spec
+	for _, spec := range st.pending() {
+		if _, ok := resumerFor(spec.Kind); ok && spec.Host != "" && spec.Host == host {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+// ResumePending 重新提交上次退出时在当前守护进程上未完成的任务，由界面在用户确认后调用
+// 返回成功恢复的任务数量；重建失败的记录被丢弃，其他守护进程的记录保留
+func (m *Manager) ResumePending(client docker.Client) int {
+	m.mu.RLock()
+	st := m.store
+	m.mu.RUnlock()
+	if st == nil {
+		return 0
+	}
+
+	m.dropStale()
+	resumed := 0
+	for _, spec := range m.PendingResume() {
+		st.remove(spec.ID)
+		resumer, _ := resumerFor(spec.Kind)
+		task, err := resumer(client, spec)
+		if err != nil {
+			continue
+		}
+		m.Submit(task)
+		resumed++
+	}
+	return resumed
+}
+
+// DiscardPending 丢弃上次退出时在当前守护进程上未完成的任务，返回丢弃的数量
+func (m *Manager) DiscardPending() int {
+	m.mu.RLock()
+	st := m.store
+	m.mu.RUnlock()
+	if st == nil {
+		return 0
+	}
+
+	m.dropStale()
+	specs := m.PendingResume()
+	for _, spec := range specs {
+		st.remove(spec.ID)
+	}
+	return len(specs)
+}
+
+// dropStale 移除无法恢复的记录：未知类型，或旧版本写入、没有守护进程地址的记录
+func (m *Manager) dropStale() {
+	m.mu.RLock()
+	st := m.store
+	m.mu.RUnlock()
+	for _, spec := range st.pending() {
+		if _, ok := resumerFor(spec.Kind); !ok || spec.Host == "" {
+			st.remove(spec.ID)
+		}
+	}
 }
 
 // emitEvent 发送事件
@@ -173,6 +321,45 @@ func (m *Manager) Cancel(taskID string) error {
 	return nil
 }
 
+// SetPaused 暂停或继续任务，任务不存在或不支持暂停时返回 false
+func (m *Manager) SetPaused(taskID string, paused bool) bool {
+	m.mu.RLock()
+	task, exists := m.tasks[taskID]
+	m.mu.RUnlock()
+
+	p, ok := task.(Pausable)
+	if !exists || !ok {
+		return false
+	}
+	message := "Resumed"
+	if paused {
+		p.Pause()
+		message = "Paused"
+	} else {
+		p.Resume()
+	}
+	m.EmitProgress(task.ID(), task.Name(), task.Progress(), message)
+	return true
+}
+
+// CancelAll 取消所有未结束的任务，返回取消的数量
+func (m *Manager) CancelAll() int {
+	active := m.ListActiveTasks()
+	for _, task := range active {
+		task.Cancel()
+	}
+	return len(active)
+}
+
+// WillResume 任务在程序退出后是否会在下次启动时恢复（已启用持久化且任务可恢复）
+func (m *Manager) WillResume(task Task) bool {
+	m.mu.RLock()
+	st := m.store
+	m.mu.RUnlock()
+	_, ok := task.(Resumable)
+	return st != nil && ok
+}
+
 // GetTask 获取任务
 func (m *Manager) GetTask(taskID string) Task {
 	m.mu.RLock()
@@ -195,6 +382,20 @@ func (m *Manager) ListActiveTasks() []Task {
 	return active
 }
 
+// ListHistory 按提交时间倒序列出所有任务（包括已结束的）
+func (m *Manager) ListHistory() []Task {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	history := make([]Task, 0, len(m.history))
+	for i := len(m.history) - 1; i >= 0; i-- {
+		if task, ok := m.tasks[m.history[i]]; ok {
+			history = append(history, task)
+		}
+	}
+	return history
+}
+
 // ListAllTasks 列出所有任务
 func (m *Manager) ListAllTasks() []Task {
 	m.mu.RLock()
@@ -241,6 +442,13 @@ func (m *Manager) CleanupCompleted() {
 			delete(m.tasks, id)
 		}
 	}
+	history := m.history[:0]
+	for _, id := range m.history {
+		if _, ok := m.tasks[id]; ok {
+			history = append(history, id)
+		}
+	}
+	m.history = history
 }
 
 // GenerateTaskID 生成任务 ID
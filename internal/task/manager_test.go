@@ -0,0 +1,290 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"docktui/internal/docker"
+)
+
+// testTaskKind 测试任务的持久化类型
+const testTaskKind = "test"
+
+func init() {
+	RegisterResumer(testTaskKind, func(client docker.Client, spec Spec) (Task, error) {
+		return newBlockingTask(spec.Name), nil
+	})
+}
+
+// blockingTask 一直运行到 release 关闭或被取消的可恢复任务
+type blockingTask struct {
+	*BaseTask
+	release chan struct{}
+}
+
+func newBlockingTask(name string) *blockingTask {
+	return &blockingTask{BaseTask: NewBaseTask(uuid.NewString(), name), release: make(chan struct{})}
+}
+
+func (t *blockingTask) Run(ctx context.Context) error {
+	t.SetStatus(StatusRunning)
+	select {
+	case <-t.release:
+		t.SetStatus(StatusCompleted)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *blockingTask) Spec() (Spec, error) {
+	return Spec{Kind: testTaskKind, Params: json.RawMessage(`{}`)}, nil
+}
+
+// newTestManager 创建独立于全局单例的管理器
+func newTestManager() *Manager {
+	return &Manager{tasks: make(map[string]Task), eventChan: make(chan Event, 100), workers: 1}
+}
+
+// waitFor 等待条件成立，超时则失败
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// writeState 写入任务状态文件并返回路径
+func writeState(t *testing.T, specs []Spec) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	data, err := json.Marshal(specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// readState 读取任务状态文件中的记录 ID
+func readState(t *testing.T, path string) []string {
+	t.Helper()
+	st, err := newStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, spec := range st.pending() {
+		ids = append(ids, spec.ID)
+	}
+	return ids
+}
+
+// TestStore 测试状态文件的读写、排序和错误处理
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "tasks.json")
+	st, err := newStore(path)
+	if err != nil {
+		t.Fatalf("newStore() on missing file error = %v", err)
+	}
+	now := time.Now()
+	st.put(Spec{ID: "b", Kind: testTaskKind, SubmittedAt: now})
+	st.put(Spec{ID: "a", Kind: testTaskKind, SubmittedAt: now.Add(-time.Minute)})
+	st.put(Spec{ID: "c", Kind: testTaskKind, SubmittedAt: now.Add(time.Minute)})
+	st.remove("c")
+	st.remove("missing")
+
+	if got := strings.Join(readState(t, path), ","); got != "a,b" {
+		t.Errorf("reloaded state = %s, want a,b (oldest first)", got)
+	}
+
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newStore(path); err == nil || !strings.Contains(err.Error(), "failed to parse task state") {
+		t.Errorf("newStore() on corrupt file error = %v", err)
+	}
+}
+
+// TestManager_SubmitPersists 测试可恢复任务提交时记录守护进程地址，结束后移除记录
+func TestManager_SubmitPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	m := newTestManager()
+	if err := m.EnablePersistence(path, "unix:///var/run/docker.sock"); err != nil {
+		t.Fatal(err)
+	}
+
+	task := newBlockingTask("pull nginx")
+	m.Submit(task)
+	st, err := newStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs := st.pending()
+	if len(specs) != 1 || specs[0].ID != task.ID() || specs[0].Name != "pull nginx" || specs[0].Host != "unix:///var/run/docker.sock" {
+		t.Fatalf("persisted specs = %+v", specs)
+	}
+	if !m.WillResume(task) {
+		t.Error("WillResume() = false, want true")
+	}
+
+	close(task.release)
+	waitFor(t, "record removal", func() bool { return len(readState(t, path)) == 0 })
+}
+
+// TestManager_ResumePending 测试只恢复同一守护进程上的记录，丢弃无法恢复的记录，保留其他守护进程的记录
+func TestManager_ResumePending(t *testing.T) {
+	now := time.Now()
+	path := writeState(t, []Spec{
+		{ID: "here-2", Kind: testTaskKind, Name: "export app", Host: "tcp://a:2376", SubmittedAt: now},
+		{ID: "here-1", Kind: testTaskKind, Name: "pull nginx", Host: "tcp://a:2376", SubmittedAt: now.Add(-time.Minute)},
+		{ID: "other", Kind: testTaskKind, Name: "export db", Host: "tcp://b:2376", SubmittedAt: now},
+		{ID: "legacy", Kind: testTaskKind, Name: "pull redis", SubmittedAt: now},
+		{ID: "unknown", Kind: "nope", Host: "tcp://a:2376", SubmittedAt: now},
+	})
+	m := newTestManager()
+	if err := m.EnablePersistence(path, "tcp://a:2376"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		// 任务开始运行后才能通过 ctx 取消
+		for _, task := range m.ListActiveTasks() {
+			waitFor(t, task.Name()+" to start", func() bool { return task.Status() == StatusRunning })
+		}
+		m.CancelAll()
+		waitFor(t, "cancelled tasks to leave the state file", func() bool { return len(readState(t, path)) == 1 })
+	})
+
+	var names []string
+	for _, spec := range m.PendingResume() {
+		names = append(names, spec.Name)
+	}
+	if got := strings.Join(names, ","); got != "pull nginx,export app" {
+		t.Fatalf("PendingResume() = %s, want pull nginx,export app", got)
+	}
+	if got := strings.Join(readState(t, path), ","); !strings.Contains(got, "legacy") {
+		t.Errorf("PendingResume() should not modify the state file, got %s", got)
+	}
+
+	if n := m.ResumePending(nil); n != 2 {
+		t.Errorf("ResumePending() = %d, want 2", n)
+	}
+	if n := len(m.ListActiveTasks()); n != 2 {
+		t.Errorf("active tasks = %d, want 2", n)
+	}
+	// 重新提交的任务以新 ID 记录，其他守护进程的记录保留
+	ids := readState(t, path)
+	if len(ids) != 3 || !strings.Contains(strings.Join(ids, ","), "other") {
+		t.Errorf("state after resume = %v, want other plus the 2 resubmitted tasks", ids)
+	}
+	for _, id := range ids {
+		if id == "here-1" || id == "here-2" || id == "legacy" || id == "unknown" {
+			t.Errorf("record %s should have been removed", id)
+		}
+	}
+}
+
+// TestManager_DiscardPending 测试丢弃同一守护进程上的记录而不提交
+func TestManager_DiscardPending(t *testing.T) {
+	now := time.Now()
+	path := writeState(t, []Spec{
+		{ID: "here", Kind: testTaskKind, Host: "tcp://a:2376", SubmittedAt: now},
+		{ID: "other", Kind: testTaskKind, Host: "tcp://b:2376", SubmittedAt: now},
+	})
+	m := newTestManager()
+	if err := m.EnablePersistence(path, "tcp://a:2376"); err != nil {
+		t.Fatal(err)
+	}
+	if n := m.DiscardPending(); n != 1 {
+		t.Errorf("DiscardPending() = %d, want 1", n)
+	}
+	if n := len(m.ListAllTasks()); n != 0 {
+		t.Errorf("tasks = %d, want none submitted", n)
+	}
+	if got := strings.Join(readState(t, path), ","); got != "other" {
+		t.Errorf("state after discard = %s, want other", got)
+	}
+	if n := newTestManager().ResumePending(nil); n != 0 {
+		t.Errorf("ResumePending() without persistence = %d, want 0", n)
+	}
+}
+
+// TestManager_CancelAll 测试取消所有未结束的任务
+func TestManager_CancelAll(t *testing.T) {
+	m := newTestManager()
+	done := newBlockingTask("done")
+	close(done.release)
+	m.Submit(done)
+	waitFor(t, "task completion", func() bool { return done.Status() == StatusCompleted })
+
+	running := []*blockingTask{newBlockingTask("a"), newBlockingTask("b")}
+	for _, task := range running {
+		m.Submit(task)
+		waitFor(t, task.Name()+" to start", func() bool { return task.Status() == StatusRunning })
+	}
+	if n := m.CancelAll(); n != 2 {
+		t.Errorf("CancelAll() = %d, want 2", n)
+	}
+	for _, task := range running {
+		if task.Status() != StatusCancelled {
+			t.Errorf("%s status = %v, want Cancelled", task.Name(), task.Status())
+		}
+	}
+	if n := len(m.ListActiveTasks()); n != 0 {
+		t.Errorf("active tasks after CancelAll = %d, want 0", n)
+	}
+}
+
+// TestPauseGate 测试暂停期间读取阻塞，继续或取消后返回
+func TestPauseGate(t *testing.T) {
+	var g pauseGate
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := g.reader(ctx, strings.NewReader("payload"))
+
+	g.Pause()
+	g.Pause()
+	if !g.Paused() {
+		t.Fatal("Paused() = false after Pause")
+	}
+	read := make(chan string, 1)
+	go func() {
+		data, _ := io.ReadAll(r)
+		read <- string(data)
+	}()
+	select {
+	case <-read:
+		t.Fatal("read finished while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+	g.Resume()
+	g.Resume()
+	select {
+	case data := <-read:
+		if data != "payload" {
+			t.Errorf("read %q, want payload", data)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("read still blocked after Resume")
+	}
+
+	g.Pause()
+	cancel()
+	if _, err := r.Read(make([]byte, 1)); err != context.Canceled {
+		t.Errorf("Read() after cancel error = %v, want context.Canceled", err)
+	}
+}
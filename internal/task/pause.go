@@ -0,0 +1,88 @@
+package task
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// Pausable 支持暂停的任务（按字节流读写的导出、导入、传输）
+type Pausable interface {
+	Pause()
+	Resume()
+	Paused() bool
+}
+
+// CancelWarner 取消后会留下不完整结果的任务，返回非空说明时界面先确认再取消
+type CancelWarner interface {
+	CancelWarning() string
+}
+
+// pauseGate 任务的暂停开关，嵌入到支持暂停的任务中；暂停期间通过 reader 读取的流停在下一次 Read
+type pauseGate struct {
+	mu     sync.Mutex
+	paused bool
+	resume chan struct{} // 暂停期间有效，继续时关闭
+}
+
+// Pause 暂停任务，已暂停时无效果
+func (g *pauseGate) Pause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.paused {
+		g.paused = true
+		g.resume = make(chan struct{})
+	}
+}
+
+// Resume 继续已暂停的任务
+func (g *pauseGate) Resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.paused {
+		g.paused = false
+		close(g.resume)
+	}
+}
+
+// Paused 任务是否已暂停
+func (g *pauseGate) Paused() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.paused
+}
+
+// wait 暂停期间阻塞，直到继续或 ctx 取消
+func (g *pauseGate) wait(ctx context.Context) error {
+	g.mu.Lock()
+	resume := g.resume
+	paused := g.paused
+	g.mu.Unlock()
+	if !paused {
+		return nil
+	}
+	select {
+	case <-resume:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reader 包装 r，每次 Read 前等待暂停结束
+func (g *pauseGate) reader(ctx context.Context, r io.Reader) io.Reader {
+	return &pausableReader{ctx: ctx, gate: g, r: r}
+}
+
+type pausableReader struct {
+	ctx  context.Context
+	gate *pauseGate
+	r    io.Reader
+}
+
+func (p *pausableReader) Read(b []byte) (int, error) {
+	if err := p.gate.wait(p.ctx); err != nil {
+		return 0, err
+	}
+	return p.r.Read(b)
+}
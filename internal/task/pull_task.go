@@ -2,6 +2,7 @@ package task
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"docktui/internal/docker"
@@ -30,6 +31,34 @@ func NewPullTask(client docker.Client, imageRef string) *PullTask {
 	}
 }
 
+// pullTaskKind 拉取任务的持久化类型
+const pullTaskKind = "pull"
+
+// pullTaskParams 拉取任务的持久化参数
+type pullTaskParams struct {
+	ImageRef string `json:"image_ref"`
+}
+
+func init() {
+	RegisterResumer(pullTaskKind, func(client docker.Client, spec Spec) (Task, error) {
+		var params pullTaskParams
+		if err := json.Unmarshal(spec.Params, &params); err != nil {
+			return nil, err
+		}
+		// 已下载的层由 Docker 缓存，重新拉取会跳过这些层
+		return NewPullTask(client, params.ImageRef), nil
+	})
+}
+
+// Spec 返回拉取任务的持久化描述
+func (t *PullTask) Spec() (Spec, error) {
+	params, err := json.Marshal(pullTaskParams{ImageRef: t.imageRef})
+	if err != nil {
+		return Spec{}, err
+	}
+	return Spec{Kind: pullTaskKind, Params: params}, nil
+}
+
 // ImageRef 返回镜像引用
 func (t *PullTask) ImageRef() string {
 	return t.imageRef
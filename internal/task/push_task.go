@@ -0,0 +1,139 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/errdefs"
+
+	"docktui/internal/docker"
+)
+
+// RetagPushTask 打标签并推送的串联任务：tag → push → （可选）删除中间标签
+type RetagPushTask struct {
+	*BaseTask
+	sourceID     string
+	targetRef    string
+	deleteTag    bool
+	createdTag   bool // 目标标签由本任务创建（打标签前不存在），只有这种标签才会被删除
+	dockerClient docker.Client
+}
+
+// NewRetagPushTask 创建打标签并推送任务
+func NewRetagPushTask(client docker.Client, sourceID, targetRef string, deleteTag bool) *RetagPushTask {
+	return &RetagPushTask{
+		BaseTask:     NewBaseTask(GenerateTaskID(), fmt.Sprintf("Push %s", targetRef)),
+		sourceID:     sourceID,
+		targetRef:    targetRef,
+		deleteTag:    deleteTag,
+		dockerClient: client,
+	}
+}
+
+// 各阶段在总进度中的占比
+const (
+	retagPushTagDone  = 5.0
+	retagPushPushDone = 95.0
+)
+
+// retagPushCleanupTimeout 失败或取消后删除中间标签的超时时间
+const retagPushCleanupTimeout = 30 * time.Second
+
+// Run 执行任务
+func (t *RetagPushTask) Run(ctx context.Context) error {
+	t.SetStatus(StatusRunning)
+	manager := GetManager()
+	report := func(percent float64, message string) {
+		t.SetProgress(percent)
+		t.SetMessage(message)
+		manager.EmitProgress(t.ID(), t.Name(), percent, message)
+	}
+	fail := func(err error) error {
+		t.SetStatus(StatusFailed)
+		t.SetError(err)
+		t.SetMessage(err.Error())
+		return err
+	}
+
+	pushClient, ok := t.dockerClient.(*docker.LocalClient)
+	if !ok {
+		return fail(fmt.Errorf("client does not support progress push"))
+	}
+
+	// 1. 打标签
+	named, err := reference.ParseNormalizedNamed(t.targetRef)
+	if err != nil {
+		return fail(fmt.Errorf("invalid target %q: %w", t.targetRef, err))
+	}
+	named = reference.TagNameOnly(named)
+	tag := ""
+	if tagged, ok := named.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	}
+	report(0, "Tagging "+t.targetRef+"...")
+	// 目标标签可能原本就存在（镜像自身的引用或其他镜像的标签），删除它会删掉用户的标签甚至镜像
+	if _, err := t.dockerClient.InspectImageRaw(ctx, named.String()); errdefs.IsNotFound(err) {
+		t.createdTag = true
+	}
+	if err := t.dockerClient.TagImage(ctx, t.sourceID, named.Name(), tag); err != nil {
+		return fail(fmt.Errorf("tag failed: %w", err))
+	}
+	report(retagPushTagDone, "Tagged "+t.targetRef)
+
+	// 2. 推送，推送进度映射到 5%~95%
+	progressChan, err := pushClient.PushImageWithProgress(ctx, t.targetRef)
+	if err != nil {
+		return fail(t.cleanup(fmt.Errorf("push failed: %w", err)))
+	}
+	for progress := range progressChan {
+		select {
+		case <-ctx.Done():
+			t.cleanup(nil)
+			t.SetStatus(StatusCancelled)
+			t.SetMessage("Cancelled")
+			return ctx.Err()
+		default:
+		}
+		if progress.Error != nil {
+			return fail(t.cleanup(fmt.Errorf("push failed: %w", progress.Error)))
+		}
+		report(retagPushTagDone+progress.Percentage*(retagPushPushDone-retagPushTagDone)/100, progress.Message)
+	}
+	if ctx.Err() != nil {
+		t.cleanup(nil)
+		t.SetStatus(StatusCancelled)
+		t.SetMessage("Cancelled")
+		return ctx.Err()
+	}
+
+	// 3. 删除中间标签
+	message := "Pushed " + t.targetRef
+	switch {
+	case t.deleteTag && t.createdTag:
+		report(retagPushPushDone, "Removing tag "+t.targetRef+"...")
+		if err := t.dockerClient.UntagImage(ctx, t.targetRef); err != nil {
+			message += " (failed to remove tag: " + err.Error() + ")"
+		} else {
+			message += ", local tag removed"
+		}
+	case t.deleteTag:
+		message += ", local tag existed before and was kept"
+	}
+
+	t.SetStatus(StatusCompleted)
+	report(100, message)
+	return nil
+}
+
+// cleanup 推送失败或取消时按需删除本任务打的中间标签，返回原错误；
+// 取消时任务的 ctx 已结束，使用单独的限时 ctx
+func (t *RetagPushTask) cleanup(err error) error {
+	if t.deleteTag && t.createdTag {
+		ctx, cancel := context.WithTimeout(context.Background(), retagPushCleanupTimeout)
+		defer cancel()
+		_ = t.dockerClient.UntagImage(ctx, t.targetRef)
+	}
+	return err
+}
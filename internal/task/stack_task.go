@@ -0,0 +1,137 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"docktui/internal/docker"
+	"docktui/internal/stack"
+)
+
+// StackAction 栈任务的操作
+type StackAction string
+
+const (
+	StackStart StackAction = "start"
+	StackStop  StackAction = "stop"
+)
+
+// stackStopTimeout 停止每个容器的超时（秒）
+const stackStopTimeout = 10
+
+// StackTask 按栈的顺序逐个启动（或按相反顺序停止）容器的任务
+type StackTask struct {
+	*BaseTask
+	stack        stack.Stack
+	action       StackAction
+	dockerClient docker.Client
+}
+
+// NewStackTask 创建栈任务
+func NewStackTask(client docker.Client, st stack.Stack, action StackAction) *StackTask {
+	verb := "Start"
+	if action == StackStop {
+		verb = "Stop"
+	}
+	return &StackTask{
+		BaseTask:     NewBaseTask(GenerateTaskID(), fmt.Sprintf("%s stack %s", verb, st.Name)),
+		stack:        st,
+		action:       action,
+		dockerClient: client,
+	}
+}
+
+// Run 执行任务：已处于目标状态的容器跳过；找不到容器或某一步失败时停止，后面的容器不再处理
+func (t *StackTask) Run(ctx context.Context) error {
+	t.SetStatus(StatusRunning)
+	members := t.stack.StartOrder()
+	if t.action == StackStop {
+		members = t.stack.StopOrder()
+	}
+
+	fail := func(err error) error {
+		if ctx.Err() != nil {
+			t.SetStatus(StatusCancelled)
+			t.SetMessage("Cancelled")
+			return ctx.Err()
+		}
+		t.SetStatus(StatusFailed)
+		t.SetError(err)
+		t.SetMessage(err.Error())
+		return err
+	}
+
+	containers, err := t.dockerClient.ListContainers(ctx, true)
+	if err != nil {
+		return fail(fmt.Errorf("failed to list containers: %w", err))
+	}
+	states := make(map[string]string, len(containers))
+	for _, c := range containers {
+		states[c.Name] = c.State
+	}
+
+	for i, m := range members {
+		step := fmt.Sprintf("[%d/%d] %s", i+1, len(members), m.Container)
+		state, ok := states[m.Container]
+		if !ok {
+			return fail(fmt.Errorf("%s: container not found", step))
+		}
+
+		switch t.action {
+		case StackStop:
+			if state != "running" && state != "paused" && state != "restarting" {
+				t.report(step + " already stopped")
+				break
+			}
+			t.report(step + " stopping...")
+			if err := t.dockerClient.StopContainer(ctx, m.Container, stackStopTimeout); err != nil {
+				return fail(fmt.Errorf("%s: %w", step, err))
+			}
+		default:
+			if state == "running" {
+				t.report(step + " already running")
+				break
+			}
+			t.report(step + " starting...")
+			if err := t.dockerClient.StartContainer(ctx, m.Container); err != nil {
+				return fail(fmt.Errorf("%s: %w", step, err))
+			}
+			// 最后一个容器之后不必等待
+			if delay := m.Delay(); delay > 0 && i < len(members)-1 {
+				t.report(fmt.Sprintf("%s started, waiting %s", step, delay))
+				select {
+				case <-ctx.Done():
+					return fail(ctx.Err())
+				case <-time.After(delay):
+				}
+			}
+		}
+		t.SetProgress(float64(i+1) / float64(len(members)) * 100)
+	}
+
+	verb := "Started"
+	if t.action == StackStop {
+		verb = "Stopped"
+	}
+	t.SetStatus(StatusCompleted)
+	t.SetMessage(fmt.Sprintf("%s stack %s (%d containers)", verb, t.stack.Name, len(members)))
+	return nil
+}
+
+// CancelWarning 中途取消时已处理的容器保持新状态，栈只启动（或停止）了一部分
+func (t *StackTask) CancelWarning() string {
+	if t.Status() != StatusRunning || t.Progress() == 0 {
+		return ""
+	}
+	if t.action == StackStop {
+		return "Stack " + t.stack.Name + " will be left partly stopped"
+	}
+	return "Stack " + t.stack.Name + " will be left partly started"
+}
+
+// report 更新任务消息并通知任务栏
+func (t *StackTask) report(message string) {
+	t.SetMessage(message)
+	GetManager().EmitProgress(t.ID(), t.Name(), t.Progress(), message)
+}
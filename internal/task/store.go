@@ -0,0 +1,144 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"docktui/internal/docker"
+)
+
+// Spec 可持久化的任务描述
+// 任务提交时写入状态文件，结束（完成/失败/取消）后移除；
+// 进程退出时仍在执行的任务会保留下来，下次连接同一守护进程启动时经用户确认后按 Kind 重建并重新提交
+type Spec struct {
+	ID          string          `json:"id"`
+	Kind        string          `json:"kind"`
+	Name        string          `json:"name"`
+	Host        string          `json:"host"` // 任务执行时连接的守护进程地址，只在同一地址上恢复
+	Params      json.RawMessage `json:"params"`
+	SubmittedAt time.Time       `json:"submitted_at"`
+}
+
+// Resumable 可在重启后恢复的任务
+type Resumable interface {
+	// Spec 返回任务的持久化描述（ID、Name、SubmittedAt 由管理器填充）
+	Spec() (Spec, error)
+}
+
+// Resumer 根据持久化描述重建任务
+type Resumer func(client docker.Client, spec Spec) (Task, error)
+
+// resumerFor 返回某类任务的重建函数
+func resumerFor(kind string) (Resumer, bool) {
+	resumersMu.RLock()
+	defer resumersMu.RUnlock()
+	r, ok := resumers[kind]
+	return r, ok
+}
+
+var (
+	resumers   = make(map[string]Resumer)
+	resumersMu sync.RWMutex
+)
+
+// RegisterResumer 注册某类任务的重建函数
+func RegisterResumer(kind string, r Resumer) {
+	resumersMu.Lock()
+	defer resumersMu.Unlock()
+	resumers[kind] = r
+}
+
+// DefaultStatePath 返回默认的任务状态文件路径
+func DefaultStatePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "tasks.json"), nil
+}
+
+// store 任务状态文件
+type store struct {
+	path  string
+	specs map[string]Spec
+	mu    sync.Mutex
+}
+
+// newStore 打开状态文件，文件不存在时视为空
+func newStore(path string) (*store, error) {
+	s := &store{path: path, specs: make(map[string]Spec)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read task state: %w", err)
+	}
+
+	var specs []Spec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return nil, fmt.Errorf("failed to parse task state: %w", err)
+	}
+	for _, spec := range specs {
+		s.specs[spec.ID] = spec
+	}
+	return s, nil
+}
+
+// put 记录任务
+func (s *store) put(spec Spec) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.specs[spec.ID] = spec
+	s.flushLocked()
+}
+
+// remove 移除任务记录
+func (s *store) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.specs[id]; !ok {
+		return
+	}
+	delete(s.specs, id)
+	s.flushLocked()
+}
+
+// pending 返回所有残留的任务记录（按提交时间排序）
+func (s *store) pending() []Spec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	specs := make([]Spec, 0, len(s.specs))
+	for _, spec := range s.specs {
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].SubmittedAt.Before(specs[j].SubmittedAt) })
+	return specs
+}
+
+// flushLocked 写回状态文件（调用方需持有锁）
+// 写入失败只影响下次启动时的恢复，不影响任务本身，因此忽略错误
+func (s *store) flushLocked() {
+	specs := make([]Spec, 0, len(s.specs))
+	for _, spec := range s.specs {
+		specs = append(specs, spec)
+	}
+	data, err := json.MarshalIndent(specs, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, s.path)
+}
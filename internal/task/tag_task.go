@@ -0,0 +1,100 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/distribution/reference"
+
+	"docktui/internal/docker"
+)
+
+// TagTask 给镜像打标签的任务（多选镜像批量打标签时每个镜像一个任务）
+type TagTask struct {
+	*BaseTask
+	sourceID     string
+	targetRef    string
+	dockerClient docker.Client
+}
+
+// NewTagTask 创建打标签任务，targetRef 为完整的目标引用（repo:tag）
+func NewTagTask(client docker.Client, sourceID, targetRef string) *TagTask {
+	return &TagTask{
+		BaseTask:     NewBaseTask(GenerateTaskID(), fmt.Sprintf("Tag %s", targetRef)),
+		sourceID:     sourceID,
+		targetRef:    targetRef,
+		dockerClient: client,
+	}
+}
+
+// Run 执行任务
+func (t *TagTask) Run(ctx context.Context) error {
+	t.SetStatus(StatusRunning)
+	t.SetMessage("Tagging " + t.targetRef + "...")
+	named, err := reference.ParseNormalizedNamed(t.targetRef)
+	if err == nil {
+		named = reference.TagNameOnly(named)
+		tag := ""
+		if tagged, ok := named.(reference.Tagged); ok {
+			tag = tagged.Tag()
+		}
+		err = t.dockerClient.TagImage(ctx, t.sourceID, named.Name(), tag)
+	}
+	if err != nil {
+		t.SetStatus(StatusFailed)
+		t.SetError(err)
+		t.SetMessage(err.Error())
+		return err
+	}
+	t.SetProgress(100)
+	t.SetStatus(StatusCompleted)
+	t.SetMessage("Tagged " + t.targetRef)
+	return nil
+}
+
+// ImageTargetPlaceholders 批量打标签/推送的目标模板中可用的占位符
+var ImageTargetPlaceholders = []string{"{{repo}}", "{{name}}", "{{tag}}", "{{id}}"}
+
+// RenderImageTarget 按模板生成一个镜像的目标引用：
+// {{repo}} 仓库（如 library/nginx 写作 nginx）、{{name}} 仓库最后一段、{{tag}} 标签、{{id}} 12 位短 ID；
+// 结果需要是带标签的有效引用（未写标签时补 latest）
+func RenderImageTarget(template, repository, tag, id string) (string, error) {
+	if repository == "" || repository == "<none>" {
+		return "", fmt.Errorf("image %s has no repository", shortImageID(id))
+	}
+	if tag == "" || tag == "<none>" {
+		tag = "latest"
+	}
+	name := repository
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	target := strings.NewReplacer(
+		"{{repo}}", repository,
+		"{{name}}", name,
+		"{{tag}}", tag,
+		"{{id}}", shortImageID(id),
+	).Replace(strings.TrimSpace(template))
+
+	named, err := reference.ParseNormalizedNamed(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid target %q: %w", target, err)
+	}
+	if _, digested := named.(reference.Digested); digested {
+		return "", fmt.Errorf("target %q must be a tag, not a digest", target)
+	}
+	if _, tagged := named.(reference.Tagged); !tagged {
+		target += ":latest"
+	}
+	return target, nil
+}
+
+// shortImageID 去掉 sha256: 前缀后取前 12 位
+func shortImageID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	return id
+}
@@ -66,6 +66,7 @@ type BaseTask struct {
 	startTime time.Time
 	endTime   time.Time
 	cancelFn  context.CancelFunc
+	log       *taskLog // 输出日志文件（未启用时为 nil）
 	mu        sync.RWMutex
 }
 
@@ -129,11 +130,13 @@ func (t *BaseTask) Message() string {
 	return t.message
 }
 
-// SetMessage 设置消息
+// SetMessage 设置消息，启用任务日志时同时写入日志文件
 func (t *BaseTask) SetMessage(message string) {
 	t.mu.Lock()
-	defer t.mu.Unlock()
 	t.message = message
+	l := t.log
+	t.mu.Unlock()
+	l.write(message)
 }
 
 // Error 返回错误
@@ -184,3 +187,10 @@ func (t *BaseTask) Duration() time.Duration {
 	}
 	return t.endTime.Sub(t.startTime)
 }
+
+// StartTime 返回任务开始执行的时间，未开始时为零值
+func (t *BaseTask) StartTime() time.Time {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.startTime
+}
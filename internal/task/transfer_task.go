@@ -0,0 +1,183 @@
+package task
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"docktui/internal/audit"
+	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/policy"
+)
+
+// TransferImageInfo 传输的镜像
+type TransferImageInfo struct {
+	ID   string
+	Ref  string // repo:tag，为空（悬垂镜像）时按 ID 传输，目标主机上不带标签
+	Size int64  // 镜像大小，用于估算进度
+}
+
+// name 传输时使用的镜像引用，带标签时目标主机上保留标签
+func (i TransferImageInfo) name() string {
+	if i.Ref != "" {
+		return i.Ref
+	}
+	return i.ID
+}
+
+// TransferTask 把当前主机 docker save 的输出直接流式写入另一台主机的 docker load，不落盘；
+// 传输过程中计算流的 sha256，完成后在目标主机上逐个核对镜像 ID（镜像 ID 是内容摘要）
+type TransferTask struct {
+	*BaseTask
+	pauseGate
+	dockerClient docker.Client
+	images       []TransferImageInfo
+	targetName   string
+	target       docker.Endpoint
+
+	// 传输结果
+	transferred int64
+	checksum    string
+}
+
+// NewTransferTask 创建镜像传输任务，target 为目标守护进程的端点（tcp://、ssh:// 等）
+func NewTransferTask(client docker.Client, images []TransferImageInfo, targetName string, target docker.Endpoint) *TransferTask {
+	name := fmt.Sprintf("Transfer %d images to %s", len(images), targetName)
+	if len(images) == 1 {
+		name = fmt.Sprintf("Transfer %s to %s", images[0].name(), targetName)
+	}
+	return &TransferTask{
+		BaseTask:     NewBaseTask(GenerateTaskID(), name),
+		dockerClient: client,
+		images:       images,
+		targetName:   targetName,
+		target:       target,
+	}
+}
+
+// Run 执行传输：连接目标主机、边导出边导入、核对镜像
+func (t *TransferTask) Run(ctx context.Context) error {
+	t.SetStatus(StatusRunning)
+	manager := GetManager()
+
+	fail := func(prefix string, err error) error {
+		if ctx.Err() != nil {
+			t.SetStatus(StatusCancelled)
+			t.SetMessage("Cancelled")
+			return ctx.Err()
+		}
+		t.SetStatus(StatusFailed)
+		t.SetError(err)
+		t.SetMessage(prefix + ": " + err.Error())
+		return err
+	}
+
+	if err := policy.Check("image.transfer"); err != nil {
+		audit.Record("image.transfer", t.targetName, err)
+		return fail("Transfer failed", err)
+	}
+
+	t.report(fmt.Sprintf("Connecting to %s...", t.targetName), 0)
+	target, err := docker.NewLocalClientForEndpoint(t.target)
+	if err != nil {
+		return fail("Connect failed", err)
+	}
+	defer target.Close()
+	if err := target.Ping(ctx); err != nil {
+		return fail("Connect failed", fmt.Errorf("%s: %w", t.targetName, err))
+	}
+
+	var refs []string
+	var total int64
+	for _, img := range t.images {
+		refs = append(refs, img.name())
+		total += img.Size
+	}
+	reader, err := t.dockerClient.SaveImage(ctx, refs)
+	if err != nil {
+		return fail("Export failed", err)
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	progress := &transferProgress{task: t, total: total}
+	err = target.LoadImage(ctx, io.TeeReader(t.reader(ctx, reader), io.MultiWriter(hasher, progress)), true)
+	t.transferred = progress.n
+	t.checksum = hex.EncodeToString(hasher.Sum(nil))
+	if err != nil {
+		audit.Record("image.transfer", t.targetName, err)
+		return fail("Load failed", err)
+	}
+
+	t.report("Verifying images on "+t.targetName+"...", 99)
+	if err := t.verify(ctx, target); err != nil {
+		audit.Record("image.transfer", t.targetName, err)
+		return fail("Verify failed", err)
+	}
+	audit.Record("image.transfer", t.targetName, nil)
+
+	t.SetStatus(StatusCompleted)
+	t.SetProgress(100)
+	t.SetMessage(fmt.Sprintf("Transferred %d images to %s (%s, sha256 %s, verified)",
+		len(t.images), t.targetName, format.Size(t.transferred), t.checksum[:12]))
+	manager.EmitProgress(t.ID(), t.Name(), 100, t.Message())
+	return nil
+}
+
+// verify 核对目标主机上的镜像：按引用查到的镜像 ID 必须与源镜像一致
+func (t *TransferTask) verify(ctx context.Context, target docker.Client) error {
+	for _, img := range t.images {
+		details, err := target.ImageDetails(ctx, img.name())
+		if err != nil {
+			return fmt.Errorf("%s not found on %s after load: %w", img.name(), t.targetName, err)
+		}
+		if trimDigest(details.ID) != trimDigest(img.ID) {
+			return fmt.Errorf("%s on %s has ID %.12s, expected %.12s", img.name(), t.targetName,
+				trimDigest(details.ID), trimDigest(img.ID))
+		}
+	}
+	return nil
+}
+
+// report 更新任务消息和进度并通知任务栏
+func (t *TransferTask) report(message string, progress float64) {
+	t.SetMessage(message)
+	t.SetProgress(progress)
+	GetManager().EmitProgress(t.ID(), t.Name(), progress, message)
+}
+
+// trimDigest 去掉 sha256: 前缀
+func trimDigest(id string) string {
+	return strings.TrimPrefix(id, "sha256:")
+}
+
+// GetChecksum 获取传输流的 sha256
+func (t *TransferTask) GetChecksum() string {
+	return t.checksum
+}
+
+// transferProgress 统计已传输的字节，按镜像大小估算进度（tar 流略大于镜像大小，最多显示到 99%）
+type transferProgress struct {
+	task    *TransferTask
+	total   int64
+	n       int64
+	percent int
+}
+
+func (p *transferProgress) Write(b []byte) (int, error) {
+	p.n += int64(len(b))
+	percent := 0
+	if p.total > 0 {
+		percent = min(99, int(p.n*100/p.total))
+	}
+	// 每增加 1% 通知一次，避免事件过多
+	if percent > p.percent {
+		p.percent = percent
+		p.task.report(fmt.Sprintf("Streaming to %s: %s / %s", p.task.targetName, format.Size(p.n), format.Size(p.total)), float64(percent))
+	}
+	return len(b), nil
+}
@@ -0,0 +1,173 @@
+// Package trash 回收站：通过 docktui 删除容器时，在本机保留其 inspect 数据一段时间，
+// 误删配置复杂的容器后可以查看原配置或还原 docker run 命令
+package trash
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDays 默认保留天数
+const DefaultDays = 7
+
+// Entry 回收站中的一个已删除容器
+type Entry struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Image     string          `json:"image"`
+	RemovedAt time.Time       `json:"removed_at"`
+	Inspect   json.RawMessage `json:"inspect"` // 删除前 docker inspect 的完整输出
+
+	file string
+}
+
+// ExpiresAt 记录被自动清除的时间
+func (e Entry) ExpiresAt(days int) time.Time {
+	return e.RemovedAt.AddDate(0, 0, days)
+}
+
+// Store 回收站目录，每个已删除容器一个 JSON 文件
+type Store struct {
+	dir  string
+	days int
+	mu   sync.Mutex
+}
+
+var (
+	defaultStore *Store
+	defaultMu    sync.RWMutex
+)
+
+// unsafeChars 文件名中需要替换的字符
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// DefaultDir 返回默认回收站目录（用户配置目录下的 docktui/trash）
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docktui", "trash"), nil
+}
+
+// Open 打开回收站目录（不存在时创建），并清除超过 days 天的记录
+func Open(dir string, days int) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create trash directory: %w", err)
+	}
+	s := &Store{dir: dir, days: days}
+	if _, err := s.List(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Dir 回收站目录
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Days 保留天数
+func (s *Store) Days() int {
+	return s.days
+}
+
+// Add 保存一个已删除容器的 inspect 数据
+func (s *Store) Add(id, name, image string, inspect []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := Entry{ID: id, Name: name, Image: image, RemovedAt: time.Now(), Inspect: inspect}
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return err
+	}
+	shortID := id
+	if len(shortID) > 12 {
+		shortID = shortID[:12]
+	}
+	file := fmt.Sprintf("%s_%s_%s.json", e.RemovedAt.Format("20060102-150405"), unsafeChars.ReplaceAllString(name, "-"), shortID)
+	if err := os.WriteFile(filepath.Join(s.dir, file), data, 0600); err != nil {
+		return fmt.Errorf("failed to save to trash: %w", err)
+	}
+	return nil
+}
+
+// List 返回回收站中的记录（最近删除的在前），同时删除过期的记录；无法解析的文件会跳过
+func (s *Store) List() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+	cutoff := time.Now().AddDate(0, 0, -s.days)
+	var entries []Entry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(s.dir, f.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var e Entry
+		if json.Unmarshal(data, &e) != nil {
+			continue
+		}
+		if e.RemovedAt.Before(cutoff) {
+			os.Remove(path)
+			continue
+		}
+		e.file = path
+		entries = append(entries, e)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].RemovedAt.After(entries[j].RemovedAt)
+	})
+	return entries, nil
+}
+
+// Delete 从回收站永久删除一条记录
+func (s *Store) Delete(e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e.file == "" {
+		return fmt.Errorf("entry is not in the trash")
+	}
+	if err := os.Remove(e.file); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete from trash: %w", err)
+	}
+	return nil
+}
+
+// SetDefault 设置全局回收站，nil 表示不保留已删除的容器
+func SetDefault(s *Store) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultStore = s
+}
+
+// Default 返回全局回收站，未启用时返回 nil
+func Default() *Store {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultStore
+}
+
+// Keep 把已删除容器的 inspect 数据放入全局回收站，未启用时不做任何事
+func Keep(id, name, image string, inspect []byte) error {
+	if s := Default(); s != nil {
+		return s.Add(id, name, image, inspect)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/audit"
+	"docktui/internal/format"
+)
+
+// auditViewLimit 审计视图最多加载的记录数
+const auditViewLimit = 1000
+
+var (
+	auditTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("220"))
+
+	auditHeaderStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("252"))
+
+	auditMutedStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("245"))
+
+	auditOKStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("82"))
+
+	auditErrorStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
+
+	auditKeyStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("81"))
+)
+
+// auditEntriesMsg 审计记录加载结果
+type auditEntriesMsg struct {
+	entries []audit.Entry
+	err     error
+}
+
+// AuditView 审计日志查看视图（只读）
+type AuditView struct {
+	width  int
+	height int
+
+	entries   []audit.Entry
+	err       error
+	loading   bool
+	scrollPos int
+	failsOnly bool // 只显示失败的操作
+}
+
+// NewAuditView 创建审计日志视图
+func NewAuditView() *AuditView {
+	return &AuditView{}
+}
+
+// Init 加载审计记录
+func (v *AuditView) Init() tea.Cmd {
+	v.loading = true
+	return v.loadEntries
+}
+
+func (v *AuditView) loadEntries() tea.Msg {
+	l := audit.Default()
+	if l == nil {
+		return auditEntriesMsg{}
+	}
+	entries, err := audit.ReadRecent(l.Path(), auditViewLimit)
+	return auditEntriesMsg{entries: entries, err: err}
+}
+
+// Update 处理消息
+func (v *AuditView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case auditEntriesMsg:
+		v.loading = false
+		v.entries = msg.entries
+		v.err = msg.err
+		v.scrollPos = 0
+		return v, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "b":
+			return v, func() tea.Msg { return GoBackMsg{} }
+		case "j", "down":
+			v.scroll(1)
+		case "k", "up":
+			v.scroll(-1)
+		case "ctrl+d", "pgdown":
+			v.scroll(10)
+		case "ctrl+u", "pgup":
+			v.scroll(-10)
+		case "g":
+			v.scrollPos = 0
+		case "G":
+			v.scroll(len(v.entries))
+		case "f":
+			v.failsOnly = !v.failsOnly
+			v.scrollPos = 0
+		case "r", "f5":
+			return v, v.Init()
+		}
+	}
+	return v, nil
+}
+
+// SetSize 设置视图尺寸
+func (v *AuditView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+func (v *AuditView) visibleEntries() []audit.Entry {
+	if !v.failsOnly {
+		return v.entries
+	}
+	var out []audit.Entry
+	for _, e := range v.entries {
+		if e.Result != audit.ResultOK {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (v *AuditView) pageSize() int {
+	n := v.height - 8
+	if n < 5 {
+		n = 5
+	}
+	return n
+}
+
+func (v *AuditView) scroll(delta int) {
+	maxPos := len(v.visibleEntries()) - v.pageSize()
+	if maxPos < 0 {
+		maxPos = 0
+	}
+	v.scrollPos += delta
+	if v.scrollPos > maxPos {
+		v.scrollPos = maxPos
+	}
+	if v.scrollPos < 0 {
+		v.scrollPos = 0
+	}
+}
+
+// View 渲染视图
+func (v *AuditView) View() string {
+	var b strings.Builder
+
+	b.WriteString("\n  " + auditTitleStyle.Render("🛡️  Audit Log"))
+	if l := audit.Default(); l != nil {
+		b.WriteString("  " + auditMutedStyle.Render(l.Path()))
+	}
+	b.WriteString("\n\n")
+
+	entries := v.visibleEntries()
+	switch {
+	case audit.Default() == nil:
+		b.WriteString("  " + auditMutedStyle.Render("Audit logging is disabled (DOCKTUI_AUDIT_LOG=off)") + "\n")
+	case v.loading:
+		b.WriteString("  " + auditMutedStyle.Render("⏳ Loading...") + "\n")
+	case v.err != nil:
+		b.WriteString("  " + auditErrorStyle.Render("❌ "+v.err.Error()) + "\n")
+	case len(entries) == 0:
+		b.WriteString("  " + auditMutedStyle.Render("No recorded operations") + "\n")
+	default:
+		header := fmt.Sprintf("  %-19s  %-12s  %-20s  %-6s  %s", "TIME", "USER", "ACTION", "RESULT", "TARGET")
+		b.WriteString(auditHeaderStyle.Render(header) + "\n")
+
+		end := v.scrollPos + v.pageSize()
+		if end > len(entries) {
+			end = len(entries)
+		}
+		for _, e := range entries[v.scrollPos:end] {
+			result := auditOKStyle.Render(fmt.Sprintf("%-6s", e.Result))
+			detail := e.Target
+			if e.Result != audit.ResultOK {
+				result = auditErrorStyle.Render(fmt.Sprintf("%-6s", e.Result))
+				if e.Error != "" {
+					detail += "  " + auditErrorStyle.Render(e.Error)
+				}
+			}
+			line := fmt.Sprintf("  %-19s  %-12s  %-20s  %s  %s",
+				e.Time.Local().Format("2006-01-02 15:04:05"),
+				format.Truncate(e.User, 12),
+				format.Truncate(e.Action, 20),
+				result,
+				detail,
+			)
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n  " + auditMutedStyle.Render(fmt.Sprintf("%d-%d of %d", v.scrollPos+1, end, len(entries))))
+		if v.failsOnly {
+			b.WriteString(auditMutedStyle.Render("  (failures only)"))
+		}
+		b.WriteString("\n")
+	}
+
+	keys := []string{
+		auditKeyStyle.Render("j/k") + " Scroll",
+		auditKeyStyle.Render("g/G") + " Top/Bottom",
+		auditKeyStyle.Render("f") + " Failures only",
+		auditKeyStyle.Render("r") + " Reload",
+		auditKeyStyle.Render("Esc") + " Back",
+	}
+	b.WriteString("\n  " + auditMutedStyle.Render(strings.Join(keys, "  ")))
+	return b.String()
+}
@@ -0,0 +1,21 @@
+package components
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/policy"
+)
+
+// ActionDeniedMsg 变更操作被只读模式或策略拒绝，由顶层显示说明弹窗
+type ActionDeniedMsg struct {
+	Action string // 操作名，如 container.stop
+}
+
+// RunAction 视图中变更操作的统一入口：策略允许 action 时执行 run（打开对话框或发起操作），
+// 否则不执行并返回 ActionDeniedMsg。各视图的操作键，以及容器列表用 . 重复和 @ 回放的按键都经过这里
+func RunAction(action string, run func() tea.Cmd) tea.Cmd {
+	if !policy.Allowed(action) {
+		return func() tea.Msg { return ActionDeniedMsg{Action: action} }
+	}
+	return run()
+}
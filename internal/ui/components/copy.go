@@ -0,0 +1,58 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+)
+
+// fileFallback 剪贴板不可用时（如没有 X11/Wayland 的 SSH 会话）是否把内容写入临时文件
+var fileFallback = true
+
+// SetCopyFileFallback 设置剪贴板不可用时是否写入临时文件
+func SetCopyFileFallback(enabled bool) {
+	fileFallback = enabled
+}
+
+// CopyResult 复制操作的结果
+type CopyResult struct {
+	Copied bool   // 已复制到剪贴板
+	File   string // 剪贴板不可用时写入的临时文件
+	Err    error  // 剪贴板和临时文件都不可用时的错误
+}
+
+// CopyText 复制文本到剪贴板，剪贴板不可用时按配置写入临时文件；name 用于临时文件名
+func CopyText(name, text string) CopyResult {
+	if err := clipboard.WriteAll(text); err == nil {
+		return CopyResult{Copied: true}
+	} else if !fileFallback {
+		return CopyResult{Err: fmt.Errorf("clipboard unavailable: %w", err)}
+	}
+
+	f, err := os.CreateTemp("", "docktui-"+name+"-*.txt")
+	if err != nil {
+		return CopyResult{Err: fmt.Errorf("clipboard unavailable and failed to create file: %w", err)}
+	}
+	defer f.Close()
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if _, err := f.WriteString(text); err != nil {
+		return CopyResult{Err: fmt.Errorf("clipboard unavailable and failed to write %s: %w", f.Name(), err)}
+	}
+	return CopyResult{File: f.Name()}
+}
+
+// Message 返回状态栏提示，what 描述复制的内容
+func (r CopyResult) Message(what string) string {
+	switch {
+	case r.Copied:
+		return "📋 Copied " + what
+	case r.File != "":
+		return "📄 Clipboard unavailable, saved " + what + " to " + r.File
+	default:
+		return "⚠️ " + r.Err.Error()
+	}
+}
@@ -5,6 +5,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
 )
 
 // 错误弹窗样式
@@ -122,6 +124,10 @@ func (d *ErrorDialog) View() string {
 	for _, line := range msgLines {
 		contentParts = append(contentParts, errorDialogMsgStyle.Render(line))
 	}
+	// 连接类错误附带处理建议
+	if hint := docker.ConnectionHint(d.message); hint != "" {
+		contentParts = append(contentParts, "", errorDialogHintStyle.Width(contentWidth).Render("💡 "+hint+" (press T on the home screen to troubleshoot)"))
+	}
 	contentParts = append(contentParts, "")
 	contentParts = append(contentParts, errorDialogHintStyle.Render("[Esc/Enter] Close"))
 
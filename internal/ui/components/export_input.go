@@ -5,6 +5,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/task"
 )
 
 var (
@@ -62,6 +64,7 @@ type ExportImageInfo struct {
 	ID         string
 	Repository string
 	Tag        string
+	Digest     string
 }
 
 // NewExportInputView 创建导出输入视图
@@ -257,13 +260,13 @@ func (v *ExportInputView) View() string {
 	}
 	s.WriteString("\n\n")
 
-	compressLabel := exportInputLabelStyle.Render("Gzip compress:")
+	compressLabel := exportInputLabelStyle.Render("Compress:")
 	compressValue := "No"
 	if v.compress {
-		compressValue = "Yes"
+		compressValue = "Yes (" + string(task.DetectCompression()) + ")"
 	}
 	if v.focusField == 2 {
-		compressLabel = exportInputSelectedStyle.Render("▶ Gzip compress:")
+		compressLabel = exportInputSelectedStyle.Render("▶ Compress:")
 	}
 	s.WriteString(compressLabel + " " + exportInputValueStyle.Render(compressValue))
 	if v.focusField == 2 {
@@ -0,0 +1,384 @@
+package components
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSON 查看器的查询语法（jq 的子集，兼容 JSONPath 的 $ 和 [*]）：
+//
+//	.                    整个文档
+//	.Config.Env          对象字段（字段名区分大小写，找不到时再忽略大小写匹配）
+//	.Labels."a.b.c"      含特殊字符的字段名，也可写作 .Labels["a.b.c"]
+//	.Mounts[0]  .[-1]    数组下标，负数从末尾数
+//	.Mounts[].Source     遍历数组元素或对象的值
+//	.Config | keys       用 | 连接多段，内置函数 keys、length
+//	$.Mounts[*].Source   JSONPath 写法
+
+type queryStepKind int
+
+const (
+	stepKey queryStepKind = iota
+	stepIndex
+	stepIterate
+)
+
+// queryStep 路径中的一步
+type queryStep struct {
+	kind  queryStepKind
+	key   string
+	index int
+}
+
+// queryStage | 分隔的一段：路径或内置函数
+type queryStage struct {
+	steps   []queryStep
+	builtin string
+}
+
+// jsonQuery 解析后的查询
+type jsonQuery []queryStage
+
+// queryBuiltins 支持的内置函数
+var queryBuiltins = map[string]bool{"keys": true, "length": true}
+
+// parseJSONQuery 解析查询表达式
+func parseJSONQuery(expr string) (jsonQuery, error) {
+	parts, err := splitPipes(expr)
+	if err != nil {
+		return nil, err
+	}
+	q := make(jsonQuery, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("empty expression around |")
+		}
+		if queryBuiltins[part] {
+			q = append(q, queryStage{builtin: part})
+			continue
+		}
+		steps, err := parsePath(part)
+		if err != nil {
+			return nil, err
+		}
+		q = append(q, queryStage{steps: steps})
+	}
+	return q, nil
+}
+
+// splitPipes 按不在引号和方括号内的 | 切分
+func splitPipes(expr string) ([]string, error) {
+	var parts []string
+	depth, start := 0, 0
+	inQuote := false
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case inQuote:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inQuote = false
+			}
+		case c == '"':
+			inQuote = true
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '|' && depth == 0:
+			parts = append(parts, expr[start:i])
+			start = i + 1
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated string")
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced brackets")
+	}
+	return append(parts, expr[start:]), nil
+}
+
+// parsePath 解析一段路径，如 .Mounts[0].Source
+func parsePath(s string) ([]queryStep, error) {
+	s = strings.TrimPrefix(s, "$")
+	if s == "" {
+		return nil, nil
+	}
+	if s[0] != '.' && s[0] != '[' {
+		return nil, fmt.Errorf("expression must start with . (got %q)", s)
+	}
+	var steps []queryStep
+	i := 0
+	for i < len(s) {
+		switch s[i] {
+		case '.':
+			i++
+			if i >= len(s) || s[i] == '[' || s[i] == '.' {
+				if i < len(s) && s[i] == '.' {
+					return nil, fmt.Errorf("recursive descent (..) is not supported")
+				}
+				continue
+			}
+			if s[i] == '"' {
+				key, n, err := readQuoted(s[i:])
+				if err != nil {
+					return nil, err
+				}
+				steps = append(steps, queryStep{kind: stepKey, key: key})
+				i += n
+				continue
+			}
+			j := i
+			for j < len(s) && isIdentChar(s[j]) {
+				j++
+			}
+			if j == i {
+				return nil, fmt.Errorf("unexpected %q after .", s[i])
+			}
+			steps = append(steps, queryStep{kind: stepKey, key: s[i:j]})
+			i = j
+		case '[':
+			j := i + 1
+			for j < len(s) && s[j] == ' ' {
+				j++
+			}
+			if j < len(s) && s[j] == '"' {
+				// 字符串下标可能含 ]，读完引号再找 ]
+				key, n, err := readQuoted(s[j:])
+				if err != nil {
+					return nil, err
+				}
+				j += n
+				for j < len(s) && s[j] == ' ' {
+					j++
+				}
+				if j >= len(s) || s[j] != ']' {
+					return nil, fmt.Errorf("expected ] after %q", key)
+				}
+				steps = append(steps, queryStep{kind: stepKey, key: key})
+				i = j + 1
+				continue
+			}
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing ]")
+			}
+			inner := strings.TrimSpace(s[i+1 : i+end])
+			if inner == "" || inner == "*" {
+				steps = append(steps, queryStep{kind: stepIterate})
+			} else {
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index [%s]", inner)
+				}
+				steps = append(steps, queryStep{kind: stepIndex, index: n})
+			}
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", s[i], s)
+		}
+	}
+	return steps, nil
+}
+
+// readQuoted 读取以 " 开头的 JSON 字符串，返回内容和消耗的字节数
+func readQuoted(s string) (string, int, error) {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == '"' {
+			key, err := strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", 0, fmt.Errorf("invalid string %s", s[:i+1])
+			}
+			return key, i + 1, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// eval 对文档执行查询，返回所有结果
+func (q jsonQuery) eval(doc interface{}) ([]interface{}, error) {
+	values := []interface{}{doc}
+	for _, stage := range q {
+		var next []interface{}
+		for _, v := range values {
+			out, err := stage.eval(v)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, out...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func (st queryStage) eval(v interface{}) ([]interface{}, error) {
+	if st.builtin != "" {
+		out, err := evalBuiltin(st.builtin, v)
+		return []interface{}{out}, err
+	}
+	values := []interface{}{v}
+	for _, step := range st.steps {
+		var next []interface{}
+		for _, v := range values {
+			out, err := step.eval(v)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, out...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func (step queryStep) eval(v interface{}) ([]interface{}, error) {
+	switch step.kind {
+	case stepKey:
+		switch val := v.(type) {
+		case nil:
+			return []interface{}{nil}, nil
+		case map[string]interface{}:
+			if field, ok := val[step.key]; ok {
+				return []interface{}{field}, nil
+			}
+			for k, field := range val {
+				if strings.EqualFold(k, step.key) {
+					return []interface{}{field}, nil
+				}
+			}
+			return []interface{}{nil}, nil
+		}
+		return nil, fmt.Errorf("cannot index %s with %q", jsonTypeName(v), step.key)
+	case stepIndex:
+		switch val := v.(type) {
+		case nil:
+			return []interface{}{nil}, nil
+		case []interface{}:
+			i := step.index
+			if i < 0 {
+				i += len(val)
+			}
+			if i < 0 || i >= len(val) {
+				return []interface{}{nil}, nil
+			}
+			return []interface{}{val[i]}, nil
+		}
+		return nil, fmt.Errorf("cannot index %s with number", jsonTypeName(v))
+	default:
+		switch val := v.(type) {
+		case []interface{}:
+			return val, nil
+		case map[string]interface{}:
+			keys := sortedKeys(val)
+			out := make([]interface{}, len(keys))
+			for i, k := range keys {
+				out[i] = val[k]
+			}
+			return out, nil
+		}
+		return nil, fmt.Errorf("cannot iterate over %s", jsonTypeName(v))
+	}
+}
+
+func evalBuiltin(name string, v interface{}) (interface{}, error) {
+	switch name {
+	case "keys":
+		switch val := v.(type) {
+		case map[string]interface{}:
+			keys := sortedKeys(val)
+			out := make([]interface{}, len(keys))
+			for i, k := range keys {
+				out[i] = k
+			}
+			return out, nil
+		case []interface{}:
+			out := make([]interface{}, len(val))
+			for i := range val {
+				out[i] = json.Number(strconv.Itoa(i))
+			}
+			return out, nil
+		}
+		return nil, fmt.Errorf("%s has no keys", jsonTypeName(v))
+	default: // length
+		switch val := v.(type) {
+		case nil:
+			return json.Number("0"), nil
+		case string:
+			return json.Number(strconv.Itoa(len([]rune(val)))), nil
+		case []interface{}:
+			return json.Number(strconv.Itoa(len(val))), nil
+		case map[string]interface{}:
+			return json.Number(strconv.Itoa(len(val))), nil
+		case json.Number:
+			f, _ := val.Float64()
+			return json.Number(strconv.FormatFloat(math.Abs(f), 'f', -1, 64)), nil
+		}
+		return nil, fmt.Errorf("%s has no length", jsonTypeName(v))
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number, float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+// parseJSONDocument 解析查看器中的 JSON 内容（数字保留原样）
+func parseJSONDocument(content string) (interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(content))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("content is not JSON")
+	}
+	return doc, nil
+}
+
+// formatQueryResults 将查询结果格式化为缩进的 JSON，每个结果依次排列
+func formatQueryResults(values []interface{}) ([]string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	for _, v := range values {
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"), nil
+}
@@ -81,6 +81,13 @@ type JSONViewer struct {
 	isSearching bool
 	searchInput string
 
+	// 查询栏（. 打开）：按 jq 风格的路径过滤显示的文档
+	isQuerying bool
+	queryInput string
+	query      string // 当前生效的查询，空表示显示完整文档
+	queryErr   string
+	queryCount int
+
 	onClose func()
 }
 
@@ -103,6 +110,10 @@ func (v *JSONViewer) Show(title, content string) {
 	v.isSearching = false
 	v.searchInput = ""
 	v.searcher.Clear()
+	v.isQuerying = false
+	v.queryInput = ""
+	v.query = ""
+	v.queryErr = ""
 	v.updateMaxScroll()
 }
 
@@ -110,6 +121,7 @@ func (v *JSONViewer) Show(title, content string) {
 func (v *JSONViewer) Hide() {
 	v.visible = false
 	v.isSearching = false
+	v.isQuerying = false
 	v.searcher.Clear()
 }
 
@@ -179,6 +191,9 @@ func (v *JSONViewer) Update(msg tea.KeyMsg) bool {
 	if v.isSearching {
 		return v.handleSearchInput(msg)
 	}
+	if v.isQuerying {
+		return v.handleQueryInput(msg)
+	}
 
 	switch msg.String() {
 	case "esc", "q", "i":
@@ -187,6 +202,10 @@ func (v *JSONViewer) Update(msg tea.KeyMsg) bool {
 			v.searchInput = ""
 			return true
 		}
+		if v.query != "" || v.queryErr != "" {
+			v.applyQuery("")
+			return true
+		}
 		v.Hide()
 		if v.onClose != nil {
 			v.onClose()
@@ -196,6 +215,13 @@ func (v *JSONViewer) Update(msg tea.KeyMsg) bool {
 		v.isSearching = true
 		v.searchInput = ""
 		return true
+	case ".":
+		v.isQuerying = true
+		v.queryInput = v.query
+		if v.queryInput == "" {
+			v.queryInput = "."
+		}
+		return true
 	case "n":
 		if match := v.searcher.Next(); match != nil {
 			v.scrollToLine(match.Line)
@@ -282,6 +308,73 @@ func (v *JSONViewer) handleSearchInput(msg tea.KeyMsg) bool {
 	return true
 }
 
+func (v *JSONViewer) handleQueryInput(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyEsc:
+		v.isQuerying = false
+		return true
+	case tea.KeyEnter:
+		v.isQuerying = false
+		v.applyQuery(v.queryInput)
+		return true
+	case tea.KeyBackspace:
+		if len(v.queryInput) > 0 {
+			v.queryInput = v.queryInput[:len(v.queryInput)-1]
+		}
+		return true
+	case tea.KeyRunes:
+		v.queryInput += string(msg.Runes)
+		return true
+	case tea.KeySpace:
+		v.queryInput += " "
+		return true
+	}
+	return true
+}
+
+// applyQuery 用查询结果替换显示的内容，空查询或 . 恢复完整文档；查询出错时保留当前内容并显示错误
+func (v *JSONViewer) applyQuery(expr string) {
+	expr = strings.TrimSpace(expr)
+	v.queryErr = ""
+	lines := strings.Split(v.content, "\n")
+	count := 0
+	if expr != "" && expr != "." {
+		results, err := v.evalQuery(expr)
+		if err != nil {
+			v.queryErr = err.Error()
+			v.queryInput = expr
+			return
+		}
+		count = len(results)
+		if lines, err = formatQueryResults(results); err != nil {
+			v.queryErr = err.Error()
+			return
+		}
+	} else {
+		expr = ""
+	}
+	v.query = expr
+	v.queryCount = count
+	v.lines = lines
+	v.scrollY = 0
+	v.scrollX = 0
+	v.searcher.Clear()
+	v.searchInput = ""
+	v.updateMaxScroll()
+}
+
+func (v *JSONViewer) evalQuery(expr string) ([]interface{}, error) {
+	q, err := parseJSONQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseJSONDocument(v.content)
+	if err != nil {
+		return nil, err
+	}
+	return q.eval(doc)
+}
+
 // View 渲染视图
 func (v *JSONViewer) View() string {
 	if !v.visible {
@@ -343,6 +436,13 @@ func (v *JSONViewer) renderStatusBar() string {
 		cursor := lipgloss.NewStyle().Reverse(true).Render(" ")
 		status = "  " + jsonSearchPromptStyle.Render("/") + v.searchInput + cursor +
 			"  " + jsonSearchInfoStyle.Render("[Enter=Confirm ESC=Cancel]") + "\n"
+	} else if v.isQuerying {
+		cursor := lipgloss.NewStyle().Reverse(true).Render(" ")
+		status = "  " + jsonSearchPromptStyle.Render("jq ") + v.queryInput + cursor +
+			"  " + jsonSearchInfoStyle.Render("[Enter=Apply ESC=Cancel  e.g. .Mounts[].Source  .Config.Env  .Config | keys]") + "\n"
+	} else if v.queryErr != "" {
+		status = "  " + jsonSearchNoMatchStyle.Render("Query error: "+v.queryErr) +
+			"  " + jsonViewerHintStyle.Render(".=Edit ESC=Clear") + "\n"
 	} else if v.searcher.HasMatches() {
 		matchInfo := jsonSearchInfoStyle.Render(
 			"[" + strconv.Itoa(v.searcher.CurrentIndex()) + "/" +
@@ -361,7 +461,12 @@ func (v *JSONViewer) renderStatusBar() string {
 			}
 			scrollInfo = jsonViewerHintStyle.Render(strconv.Itoa(percent) + "%")
 		}
-		hints := jsonViewerHintStyle.Render("j/k=Up/Down  g/G=Top/Bottom  /=Search  n/N=Jump  ESC/q=Close")
+		hints := jsonViewerHintStyle.Render("j/k=Up/Down  g/G=Top/Bottom  /=Search  n/N=Jump  .=Query  ESC/q=Close")
+		if v.query != "" {
+			queryInfo := jsonSearchPromptStyle.Render("jq "+v.query) + " " +
+				jsonSearchInfoStyle.Render("["+strconv.Itoa(v.queryCount)+" results]")
+			hints = queryInfo + "  " + jsonViewerHintStyle.Render(".=Edit  /=Search  ESC=Show all")
+		}
 		status = "  " + hints + "  " + scrollInfo + "\n"
 	}
 
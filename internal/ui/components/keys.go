@@ -1,6 +1,80 @@
 package components
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"slices"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyOverrides 配置文件中重新绑定的快捷键（keybindings 的键 -> 按键）
+var (
+	keyOverrides   map[string][]string
+	keyOverridesMu sync.RWMutex
+)
+
+// SetKeyBindings 设置重新绑定的快捷键，之后创建的 KeyMap 使用这些按键；
+// quit、help、logs_follow、logs_wrap、debug_dump 替换默认按键，refresh 和导航键增加按键（见 TranslateKey）
+func SetKeyBindings(bindings map[string][]string) {
+	keyOverridesMu.Lock()
+	defer keyOverridesMu.Unlock()
+	keyOverrides = bindings
+}
+
+// rebind 按配置替换快捷键的按键，帮助文字显示第一个按键
+func rebind(b key.Binding, id string) key.Binding {
+	keyOverridesMu.RLock()
+	keys := keyOverrides[id]
+	keyOverridesMu.RUnlock()
+	if len(keys) == 0 {
+		return b
+	}
+	b.SetKeys(keys...)
+	b.SetHelp(keys[0], b.Help().Desc)
+	return b
+}
+
+// extend 在默认按键之外增加配置的按键，帮助文字不变
+func extend(b key.Binding, id string) key.Binding {
+	keyOverridesMu.RLock()
+	keys := keyOverrides[id]
+	keyOverridesMu.RUnlock()
+	if len(keys) == 0 {
+		return b
+	}
+	b.SetKeys(append(slices.Clone(b.Keys()), keys...)...)
+	return b
+}
+
+// aliasKeys 只能增加按键的快捷键及各视图中处理该操作的内置按键：
+// 默认按键在部分视图中另有用途（如 Compose 视图的 r 是重启、容器列表的 ctrl+d 是删除），不能替换
+var aliasKeys = []struct {
+	id  string
+	key tea.KeyMsg
+}{
+	{"refresh", tea.KeyMsg{Type: tea.KeyF5}},
+	{"up", tea.KeyMsg{Type: tea.KeyUp}},
+	{"down", tea.KeyMsg{Type: tea.KeyDown}},
+	{"page_up", tea.KeyMsg{Type: tea.KeyPgUp}},
+	{"page_down", tea.KeyMsg{Type: tea.KeyPgDown}},
+	{"top", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}}},
+	{"bottom", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}}},
+}
+
+// TranslateKey 把为 refresh 和导航键配置的按键换成视图处理的内置按键（F5、方向键、PgUp/PgDn、g/G），
+// 其他按键原样返回；由顶层在按键交给视图前调用，输入框接收按键时不应调用
+func TranslateKey(msg tea.KeyMsg) tea.KeyMsg {
+	keyOverridesMu.RLock()
+	defer keyOverridesMu.RUnlock()
+	pressed := msg.String()
+	for _, a := range aliasKeys {
+		if slices.Contains(keyOverrides[a.id], pressed) {
+			return a.key
+		}
+	}
+	return msg
+}
 
 // KeyMap 定义全局快捷键映射（使用 bubbles/key 管理）
 type KeyMap struct {
@@ -27,10 +101,31 @@ type KeyMap struct {
 	// 日志视图快捷键
 	ToggleFollow key.Binding
 	ToggleWrap   key.Binding
+
+	// 调试：保存当前画面和视图状态
+	DebugDump key.Binding
 }
 
-// DefaultKeyMap 返回默认的快捷键映射
+// DefaultKeyMap 返回默认的快捷键映射（应用配置文件中重新绑定的按键）
 func DefaultKeyMap() KeyMap {
+	k := defaultKeyMap()
+	k.Quit = rebind(k.Quit, "quit")
+	k.Help = rebind(k.Help, "help")
+	k.Refresh = extend(k.Refresh, "refresh")
+	k.Up = extend(k.Up, "up")
+	k.Down = extend(k.Down, "down")
+	k.PageUp = extend(k.PageUp, "page_up")
+	k.PageDown = extend(k.PageDown, "page_down")
+	k.Home = extend(k.Home, "top")
+	k.End = extend(k.End, "bottom")
+	k.ToggleFollow = rebind(k.ToggleFollow, "logs_follow")
+	k.ToggleWrap = rebind(k.ToggleWrap, "logs_wrap")
+	k.DebugDump = rebind(k.DebugDump, "debug_dump")
+	return k
+}
+
+// defaultKeyMap 内置的快捷键映射
+func defaultKeyMap() KeyMap {
 	return KeyMap{
 		// 全局快捷键
 		Quit: key.NewBinding(
@@ -78,7 +173,7 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("enter", "View Details"),
 		),
 		Refresh: key.NewBinding(
-			key.WithKeys("r"),
+			key.WithKeys("r", "f5"),
 			key.WithHelp("r", "Refresh"),
 		),
 		ViewLogs: key.NewBinding(
@@ -103,6 +198,11 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("w"),
 			key.WithHelp("w", "Toggle Wrap"),
 		),
+
+		DebugDump: key.NewBinding(
+			key.WithKeys("f12"),
+			key.WithHelp("F12", "Dump screen and state"),
+		),
 	}
 }
 
@@ -0,0 +1,399 @@
+package components
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ListExportFormat 列表导出格式
+type ListExportFormat int
+
+const (
+	ListExportCSV ListExportFormat = iota
+	ListExportJSON
+)
+
+// Ext 返回格式对应的文件扩展名
+func (f ListExportFormat) Ext() string {
+	if f == ListExportJSON {
+		return ".json"
+	}
+	return ".csv"
+}
+
+// String 返回格式名称
+func (f ListExportFormat) String() string {
+	if f == ListExportJSON {
+		return "JSON"
+	}
+	return "CSV"
+}
+
+// ListExportResultMsg 列表导出完成消息
+type ListExportResultMsg struct {
+	Path string
+	Rows int
+	Err  error
+}
+
+// WriteListExport 将表格数据写入文件
+// CSV 第一行为列名；JSON 为对象数组，键为列名并保持列的顺序，数值和布尔值保留类型
+func WriteListExport(path string, format ListExportFormat, columns []string, rows [][]any) error {
+	if dir := filepath.Dir(path); dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	if format == ListExportJSON {
+		records := make([]listExportRecord, 0, len(rows))
+		for _, row := range rows {
+			records = append(records, listExportRecord{columns: columns, values: row})
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(records); err != nil {
+			return fmt.Errorf("failed to write JSON: %w", err)
+		}
+		return nil
+	}
+
+	w := csv.NewWriter(f)
+	if err := w.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, value := range row {
+			cells[i] = csvCell(value)
+		}
+		if err := w.Write(cells); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// listExportRecord 导出的一行，按列的顺序编码为 JSON 对象（map 会按键排序）
+type listExportRecord struct {
+	columns []string
+	values  []any
+}
+
+// MarshalJSON 按列顺序输出键值
+func (r listExportRecord) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, col := range r.columns {
+		if i >= len(r.values) {
+			break
+		}
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(col)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(r.values[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// csvCell 将单元格值转换为 CSV 文本，列表用空格连接
+func csvCell(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, " ")
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// ListExportCmd 异步写入导出文件，完成后返回 ListExportResultMsg
+func ListExportCmd(path string, format ListExportFormat, columns []string, rows [][]any) tea.Cmd {
+	return func() tea.Msg {
+		err := WriteListExport(path, format, columns, rows)
+		return ListExportResultMsg{Path: path, Rows: len(rows), Err: err}
+	}
+}
+
+var (
+	listExportBoxStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("81")).
+				Padding(1, 2)
+
+	listExportTitleStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("220")).
+				Bold(true)
+
+	listExportLabelStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("252")).
+				Width(10)
+
+	listExportHintStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245"))
+
+	listExportValueStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("81"))
+
+	listExportErrorStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("196"))
+)
+
+// ListExportView 列表导出对话框（选择格式和路径）
+type ListExportView struct {
+	kind      string
+	rowCount  int
+	format    ListExportFormat
+	pathInput textinput.Model
+
+	visible    bool
+	width      int
+	focusIndex int // 0=格式, 1=路径, 2=取消, 3=确认
+	errorMsg   string
+}
+
+// NewListExportView 创建列表导出对话框
+func NewListExportView() *ListExportView {
+	pathInput := textinput.New()
+	pathInput.CharLimit = 256
+	pathInput.Width = 40
+	pathInput.Prompt = ""
+
+	return &ListExportView{
+		pathInput: pathInput,
+	}
+}
+
+// Show 显示对话框
+// kind: 资源类型（如 containers），用于默认文件名；rowCount: 将导出的行数
+func (v *ListExportView) Show(kind string, rowCount int) {
+	v.visible = true
+	v.kind = kind
+	v.rowCount = rowCount
+	v.errorMsg = ""
+	v.pathInput.SetValue(fmt.Sprintf("docktui-%s-%s%s", kind, time.Now().Format("20060102-150405"), v.format.Ext()))
+	v.pathInput.CursorEnd()
+	v.focusIndex = 1
+	v.updateInputFocus()
+}
+
+// Hide 隐藏对话框
+func (v *ListExportView) Hide() {
+	v.visible = false
+	v.pathInput.Blur()
+}
+
+// IsVisible 是否可见
+func (v *ListExportView) IsVisible() bool {
+	return v.visible
+}
+
+// SetWidth 设置宽度
+func (v *ListExportView) SetWidth(width int) {
+	v.width = width
+	inputWidth := width - 30
+	if inputWidth < 30 {
+		inputWidth = 30
+	}
+	if inputWidth > 50 {
+		inputWidth = 50
+	}
+	v.pathInput.Width = inputWidth
+}
+
+// GetValues 获取导出路径和格式
+func (v *ListExportView) GetValues() (string, ListExportFormat) {
+	return strings.TrimSpace(v.pathInput.Value()), v.format
+}
+
+// Update 处理输入
+// 返回值: (confirmed bool, handled bool, cmd tea.Cmd)
+func (v *ListExportView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
+	if !v.visible {
+		return false, false, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, false, nil
+	}
+
+	keyStr := keyMsg.String()
+	switch {
+	case keyMsg.Type == tea.KeyEsc:
+		v.Hide()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyEnter:
+		if v.focusIndex == 3 {
+			if path, _ := v.GetValues(); path == "" {
+				v.errorMsg = "Path cannot be empty"
+				return false, true, nil
+			}
+			return true, true, nil
+		}
+		if v.focusIndex == 2 {
+			v.Hide()
+			return false, true, nil
+		}
+		if v.focusIndex == 1 {
+			v.focusIndex = 3
+			v.updateInputFocus()
+			return false, true, nil
+		}
+		v.nextFocus()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyTab || keyMsg.Type == tea.KeyDown:
+		v.nextFocus()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyShiftTab || keyMsg.Type == tea.KeyUp:
+		v.prevFocus()
+		return false, true, nil
+	}
+
+	if v.focusIndex == 0 {
+		if keyMsg.Type == tea.KeyLeft || keyMsg.Type == tea.KeyRight || keyStr == "h" || keyStr == "l" || keyStr == " " {
+			v.toggleFormat()
+		}
+		return false, true, nil
+	}
+
+	if v.focusIndex >= 2 {
+		if keyMsg.Type == tea.KeyLeft || keyStr == "h" {
+			v.focusIndex = 2
+		} else if keyMsg.Type == tea.KeyRight || keyStr == "l" {
+			v.focusIndex = 3
+		}
+		return false, true, nil
+	}
+
+	var cmd tea.Cmd
+	v.pathInput, cmd = v.pathInput.Update(msg)
+	return false, true, cmd
+}
+
+// toggleFormat 切换导出格式，并同步替换路径中的扩展名
+func (v *ListExportView) toggleFormat() {
+	oldExt := v.format.Ext()
+	if v.format == ListExportCSV {
+		v.format = ListExportJSON
+	} else {
+		v.format = ListExportCSV
+	}
+	if path := v.pathInput.Value(); strings.HasSuffix(path, oldExt) {
+		v.pathInput.SetValue(strings.TrimSuffix(path, oldExt) + v.format.Ext())
+		v.pathInput.CursorEnd()
+	}
+}
+
+func (v *ListExportView) nextFocus() {
+	v.focusIndex = (v.focusIndex + 1) % 4
+	v.updateInputFocus()
+}
+
+func (v *ListExportView) prevFocus() {
+	v.focusIndex = (v.focusIndex + 3) % 4
+	v.updateInputFocus()
+}
+
+func (v *ListExportView) updateInputFocus() {
+	if v.focusIndex == 1 {
+		v.pathInput.Focus()
+	} else {
+		v.pathInput.Blur()
+	}
+}
+
+// View 渲染对话框
+func (v *ListExportView) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	title := listExportTitleStyle.Render(fmt.Sprintf("📄 Export %s list (%d rows)", v.kind, v.rowCount))
+
+	var formatOptions []string
+	for _, f := range []ListExportFormat{ListExportCSV, ListExportJSON} {
+		if f == v.format {
+			style := listExportValueStyle
+			if v.focusIndex == 0 {
+				style = style.Bold(true)
+			}
+			formatOptions = append(formatOptions, style.Render("["+f.String()+"]"))
+		} else {
+			formatOptions = append(formatOptions, listExportHintStyle.Render(" "+f.String()+" "))
+		}
+	}
+	formatLine := listExportLabelStyle.Render("Format:") + " " + strings.Join(formatOptions, " ")
+
+	pathStyle := lipgloss.NewStyle()
+	if v.focusIndex == 1 {
+		pathStyle = pathStyle.Foreground(lipgloss.Color("81"))
+	}
+	pathLine := listExportLabelStyle.Render("Path:") + " " + pathStyle.Render(v.pathInput.View())
+
+	contentParts := []string{title, "", listExportHintStyle.Render("Exports the rows currently shown (filters and search applied)"), "", formatLine, pathLine}
+
+	if v.errorMsg != "" {
+		contentParts = append(contentParts, "", listExportErrorStyle.Render("❌ "+v.errorMsg))
+	}
+
+	cancelBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	okBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	if v.focusIndex == 2 {
+		cancelBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	if v.focusIndex == 3 {
+		okBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	buttons := cancelBtnStyle.Render("< Cancel >") + "    " + okBtnStyle.Render("< Export >")
+
+	hints := listExportHintStyle.Render("[Tab/↑↓=Switch] [←→=Format] [Enter=Confirm] [Esc=Cancel]")
+	contentParts = append(contentParts, "", buttons, "", hints)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, contentParts...)
+
+	boxWidth := v.width - 10
+	if boxWidth < 60 {
+		boxWidth = 60
+	}
+	if boxWidth > 75 {
+		boxWidth = 75
+	}
+	return listExportBoxStyle.Width(boxWidth).Render(content)
+}
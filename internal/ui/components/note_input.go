@@ -0,0 +1,292 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/notes"
+)
+
+// 备注对话框的焦点
+const (
+	noteFocusText = iota
+	noteFocusColor
+	noteFocusTimeout // 仅容器
+	noteFocusCancel
+	noteFocusSave
+	noteFocusCount
+)
+
+// maxStopTimeout 停止超时上限（秒）
+const maxStopTimeout = 3600
+
+// NoteInputView 编辑容器/镜像备注、颜色标记和容器停止超时的对话框
+type NoteInputView struct {
+	textInput    textinput.Model
+	timeoutInput textinput.Model // 停止超时（秒），留空表示默认值
+	color        int             // 0=无颜色，其余对应 notes.Colors[color-1]
+	err          string
+
+	kind    string // notes.KindContainer / notes.KindImage
+	key     string // 备注的索引（容器名称或镜像 ID）
+	target  string // 显示用的资源名称
+	visible bool
+	width   int
+	focus   int // noteFocus*
+}
+
+// NewNoteInputView 创建备注对话框
+func NewNoteInputView() *NoteInputView {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. do not touch, Bob's experiment"
+	ti.CharLimit = 200
+	ti.Width = 50
+	ti.Prompt = ""
+
+	timeout := textinput.New()
+	timeout.Placeholder = "default (10)"
+	timeout.CharLimit = 4
+	timeout.Width = 14
+	timeout.Prompt = ""
+	return &NoteInputView{textInput: ti, timeoutInput: timeout}
+}
+
+// Show 显示对话框，预填已有备注
+func (v *NoteInputView) Show(kind, key, target string) {
+	note := notes.Lookup(kind, key)
+	v.kind, v.key, v.target = kind, key, target
+	v.visible = true
+	v.focus = noteFocusText
+	v.err = ""
+	v.color = 0
+	for i, c := range notes.Colors {
+		if c == note.Color {
+			v.color = i + 1
+		}
+	}
+	v.textInput.SetValue(note.Text)
+	v.textInput.CursorEnd()
+	v.textInput.Focus()
+	v.timeoutInput.SetValue("")
+	if note.StopTimeout > 0 {
+		v.timeoutInput.SetValue(strconv.Itoa(note.StopTimeout))
+	}
+	v.timeoutInput.Blur()
+}
+
+// Hide 隐藏对话框
+func (v *NoteInputView) Hide() {
+	v.visible = false
+	v.textInput.Blur()
+	v.timeoutInput.Blur()
+}
+
+// IsVisible 是否可见
+func (v *NoteInputView) IsVisible() bool {
+	return v.visible
+}
+
+// SetWidth 设置宽度
+func (v *NoteInputView) SetWidth(width int) {
+	v.width = width
+}
+
+// Target 返回资源类型、索引和显示名称
+func (v *NoteInputView) Target() (kind, key, name string) {
+	return v.kind, v.key, v.target
+}
+
+// Note 返回编辑后的备注（文字、颜色和停止超时都为空表示删除）
+func (v *NoteInputView) Note() notes.Note {
+	n := notes.Note{Text: strings.TrimSpace(v.textInput.Value())}
+	if v.color > 0 {
+		n.Color = notes.Colors[v.color-1]
+	}
+	n.StopTimeout, _ = v.stopTimeout()
+	return n
+}
+
+// hasTimeout 是否可以设置停止超时（仅容器）
+func (v *NoteInputView) hasTimeout() bool {
+	return v.kind == notes.KindContainer
+}
+
+// stopTimeout 解析停止超时，留空返回 0
+func (v *NoteInputView) stopTimeout() (int, error) {
+	value := strings.TrimSpace(v.timeoutInput.Value())
+	if value == "" || !v.hasTimeout() {
+		return 0, nil
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 || seconds > maxStopTimeout {
+		return 0, fmt.Errorf("stop timeout must be 0-%d seconds", maxStopTimeout)
+	}
+	return seconds, nil
+}
+
+// Update 处理输入
+// 返回值: (confirmed bool, handled bool, cmd tea.Cmd)
+func (v *NoteInputView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
+	if !v.visible {
+		return false, false, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, false, nil
+	}
+
+	keyStr := keyMsg.String()
+	switch {
+	case keyMsg.Type == tea.KeyEsc:
+		v.Hide()
+		return false, true, nil
+	case keyMsg.Type == tea.KeyEnter:
+		if v.focus == noteFocusCancel {
+			v.Hide()
+			return false, true, nil
+		}
+		if _, err := v.stopTimeout(); err != nil {
+			v.err = err.Error()
+			v.setFocus(noteFocusTimeout)
+			return false, true, nil
+		}
+		return true, true, nil
+	case keyMsg.Type == tea.KeyTab || keyMsg.Type == tea.KeyDown:
+		v.moveFocus(1)
+		return false, true, nil
+	case keyMsg.Type == tea.KeyShiftTab || keyMsg.Type == tea.KeyUp:
+		v.moveFocus(noteFocusCount - 1)
+		return false, true, nil
+	}
+
+	switch v.focus {
+	case noteFocusText:
+		var cmd tea.Cmd
+		v.textInput, cmd = v.textInput.Update(msg)
+		return false, true, cmd
+	case noteFocusTimeout:
+		var cmd tea.Cmd
+		v.timeoutInput, cmd = v.timeoutInput.Update(msg)
+		v.err = ""
+		return false, true, cmd
+	case noteFocusColor:
+		if keyMsg.Type == tea.KeyLeft || keyStr == "h" {
+			v.color = (v.color + len(notes.Colors)) % (len(notes.Colors) + 1)
+		} else if keyMsg.Type == tea.KeyRight || keyStr == "l" || keyStr == " " {
+			v.color = (v.color + 1) % (len(notes.Colors) + 1)
+		}
+	default:
+		if keyMsg.Type == tea.KeyLeft || keyStr == "h" {
+			v.focus = noteFocusCancel
+		} else if keyMsg.Type == tea.KeyRight || keyStr == "l" {
+			v.focus = noteFocusSave
+		}
+	}
+	return false, true, nil
+}
+
+// moveFocus 按 step 循环切换焦点，镜像没有停止超时输入框
+func (v *NoteInputView) moveFocus(step int) {
+	focus := (v.focus + step) % noteFocusCount
+	if focus == noteFocusTimeout && !v.hasTimeout() {
+		focus = (focus + step) % noteFocusCount
+	}
+	v.setFocus(focus)
+}
+
+// setFocus 切换焦点
+func (v *NoteInputView) setFocus(focus int) {
+	v.focus = focus
+	if focus == noteFocusText {
+		v.textInput.Focus()
+	} else {
+		v.textInput.Blur()
+	}
+	if focus == noteFocusTimeout {
+		v.timeoutInput.Focus()
+	} else {
+		v.timeoutInput.Blur()
+	}
+}
+
+// View 渲染对话框
+func (v *NoteInputView) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	focusedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Bold(true)
+
+	noteLine := tagInputLabelStyle.Render("Note:") + " "
+	if v.focus == noteFocusText {
+		noteLine += focusedStyle.Render(v.textInput.View())
+	} else {
+		noteLine += v.textInput.View()
+	}
+
+	options := []string{"none"}
+	for _, c := range notes.Colors {
+		options = append(options, notes.ColorBadge(c)+" "+c)
+	}
+	var colorParts []string
+	for i, option := range options {
+		switch {
+		case i == v.color && v.focus == noteFocusColor:
+			colorParts = append(colorParts, selectedStyle.Render("["+option+"]"))
+		case i == v.color:
+			colorParts = append(colorParts, tagInputSourceStyle.Render("["+option+"]"))
+		default:
+			colorParts = append(colorParts, tagInputHintStyle.Render(" "+option+" "))
+		}
+	}
+	colorLine := tagInputLabelStyle.Render("Color:") + " " + strings.Join(colorParts, "")
+
+	lines := []string{tagInputTitleStyle.Render("📝 Note: " + v.target), "", noteLine, colorLine}
+	if v.hasTimeout() {
+		timeoutLine := tagInputLabelStyle.Render("Stop timeout:") + " "
+		if v.focus == noteFocusTimeout {
+			timeoutLine += focusedStyle.Render(v.timeoutInput.View())
+		} else {
+			timeoutLine += v.timeoutInput.View()
+		}
+		lines = append(lines, timeoutLine+tagInputHintStyle.Render(" seconds, used by stop and restart"))
+	}
+	if v.err != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+v.err))
+	}
+
+	cancelStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	saveStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	if v.focus == noteFocusCancel {
+		cancelStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	if v.focus == noteFocusSave {
+		saveStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	buttons := cancelStyle.Render("< Cancel >") + "    " + saveStyle.Render("< Save >")
+
+	lines = append(lines,
+		"",
+		tagInputHintStyle.Render("Stored locally; clear all fields to remove the note"),
+		"",
+		buttons,
+		"",
+		tagInputHintStyle.Render("[Tab/↑↓=Switch] [←→=Color] [Enter=Save] [Esc=Cancel]"),
+	)
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
+	boxWidth := v.width - 10
+	if boxWidth < 70 {
+		boxWidth = 70
+	}
+	if boxWidth > 90 {
+		boxWidth = 90
+	}
+	return tagInputBoxStyle.Width(boxWidth).Render(content)
+}
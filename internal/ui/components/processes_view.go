@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"docktui/internal/docker"
+	"docktui/internal/format"
 )
 
 // ProcessesView 进程列表视图组件
@@ -21,6 +22,7 @@ type ProcessesView struct {
 	loading       bool
 	errorMsg      string
 	active        bool
+	refresh       *RefreshTimer
 }
 
 // NewProcessesView 创建进程列表视图
@@ -28,6 +30,7 @@ func NewProcessesView(dockerClient docker.Client) *ProcessesView {
 	return &ProcessesView{
 		dockerClient: dockerClient,
 		processes:    make([]docker.ProcessInfo, 0),
+		refresh:      NewRefreshTimer(processesRefreshInterval),
 	}
 }
 
@@ -48,6 +51,8 @@ func (v *ProcessesView) SetSize(width, height int) {
 func (v *ProcessesView) Start() tea.Cmd {
 	v.active = true
 	v.loading = true
+	v.refresh.SetInterval(processesRefreshInterval)
+	v.refresh.Start()
 	return v.fetchProcesses
 }
 
@@ -76,6 +81,7 @@ func (v *ProcessesView) Update(msg tea.Msg) tea.Cmd {
 		v.loading = false
 		v.errorMsg = ""
 		v.processes = msg.Processes
+		v.refresh.Mark()
 		if v.active {
 			return v.scheduleRefresh()
 		}
@@ -83,15 +89,21 @@ func (v *ProcessesView) Update(msg tea.Msg) tea.Cmd {
 	case ProcessesErrorMsg:
 		v.loading = false
 		v.errorMsg = msg.Err.Error()
+		v.refresh.Mark()
 		if v.active {
 			return v.scheduleRefresh()
 		}
 		return nil
 	case ProcessesRefreshMsg:
-		if v.active {
-			return v.fetchProcesses
+		if !v.active {
+			return nil
 		}
-		return nil
+		// 未到期的 tick 只用于更新倒计时
+		if !v.refresh.Due() {
+			return v.scheduleRefresh()
+		}
+		v.refresh.Start()
+		return v.fetchProcesses
 	}
 	return nil
 }
@@ -145,13 +157,13 @@ func (v *ProcessesView) renderProcessTable() string {
 		pidStr := pidStyle.Render(fmt.Sprintf("%-10s", p.PID))
 		ppidStr := fmt.Sprintf("%-10s", p.PPID)
 		line := pidStr + valueStyle.Render(fmt.Sprintf("%-10s %-10s %-6s %-10s %s",
-			ppidStr, truncateStr(p.User, 10), p.CPU, p.Time, p.Command))
+			ppidStr, format.Truncate(p.User, 10), p.CPU, p.Time, p.Command))
 		lines = append(lines, line)
 	}
 
 	// 刷新提示
 	lines = append(lines, "")
-	lines = append(lines, hintStyle.Render(fmt.Sprintf("Total %d processes | PID/PPID are host process IDs | Auto-refresh every second", len(v.processes))))
+	lines = append(lines, hintStyle.Render(fmt.Sprintf("Total %d processes | PID/PPID are host process IDs | ", len(v.processes)))+v.refresh.View())
 
 	return "\n" + WrapInBox(fmt.Sprintf("Process List (%d)", len(v.processes)), strings.Join(lines, "\n"), boxWidth)
 }
@@ -197,17 +209,9 @@ func (v *ProcessesView) fetchProcesses() tea.Msg {
 	return ProcessesLoadedMsg{Processes: processes}
 }
 
-// scheduleRefresh 安排下次刷新（每秒刷新）
+// scheduleRefresh 安排下一次 tick（到期时刷新，否则更新倒计时）
 func (v *ProcessesView) scheduleRefresh() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+	return tea.Tick(v.refresh.Step(), func(t time.Time) tea.Msg {
 		return ProcessesRefreshMsg{}
 	})
 }
-
-// truncateStr 截断字符串
-func truncateStr(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-1] + "…"
-}
@@ -14,6 +14,9 @@ type PullInputView struct {
 	visible   bool
 	width     int
 	selection int
+
+	title string
+	label string
 }
 
 var (
@@ -37,16 +40,25 @@ var (
 func NewPullInputView() *PullInputView {
 	ti := textinput.New()
 	ti.Placeholder = "nginx:latest"
-	ti.CharLimit = 128
+	ti.CharLimit = 256
 	ti.Width = 40
 	ti.Prompt = ""
 
 	return &PullInputView{
 		input:   ti,
 		visible: false,
+		title:   "📥 Pull Image",
+		label:   "Image name: ",
 	}
 }
 
+// SetPrompt 修改标题、输入提示和占位符，用于复用输入框（如导入清单路径）
+func (v *PullInputView) SetPrompt(title, label, placeholder string) {
+	v.title = title
+	v.label = label
+	v.input.Placeholder = placeholder
+}
+
 // Show 显示输入框
 func (v *PullInputView) Show() {
 	v.visible = true
@@ -137,8 +149,8 @@ func (v *PullInputView) View() string {
 		return ""
 	}
 
-	title := pullInputTitleStyle.Render("📥 Pull Image")
-	label := pullInputLabelStyle.Render("Image name: ")
+	title := pullInputTitleStyle.Render(v.title)
+	label := pullInputLabelStyle.Render(v.label)
 	inputLine := label + v.input.View()
 
 	cancelBtnStyle := lipgloss.NewStyle().Padding(0, 2)
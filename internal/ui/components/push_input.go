@@ -0,0 +1,350 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/distribution/reference"
+
+	"docktui/internal/docker"
+	"docktui/internal/task"
+)
+
+// PushInputView 打标签并推送对话框：输入目标仓库/标签（可从已登录仓库补全），可选推送后删除中间标签
+type PushInputView struct {
+	targetInput   textinput.Model
+	sourceImage   string
+	SourceImageID string
+	deleteTag     bool
+	visible       bool
+	width         int
+	focusIndex    int // 0=目标, 1=删除中间标签, 2=取消, 3=确认
+	errorMsg      string
+
+	// 批量模式：目标是模板，为多选的每个镜像生成目标（预览使用第一个镜像）
+	batch   int
+	example [3]string // 仓库、标签、ID
+}
+
+// NewPushInputView 创建推送对话框
+func NewPushInputView() *PushInputView {
+	targetInput := textinput.New()
+	targetInput.Placeholder = "registry.example.com/team/image:tag"
+	targetInput.CharLimit = 256
+	targetInput.Width = 45
+	targetInput.Prompt = ""
+	targetInput.ShowSuggestions = true
+	// ↑↓ 用于切换焦点，补全候选改用 Ctrl+N/Ctrl+P 切换
+	targetInput.KeyMap.NextSuggestion = key.NewBinding(key.WithKeys("ctrl+n"))
+	targetInput.KeyMap.PrevSuggestion = key.NewBinding(key.WithKeys("ctrl+p"))
+
+	return &PushInputView{targetInput: targetInput}
+}
+
+// Show 显示对话框，候选目标由 docker CLI 中已配置的仓库和源镜像路径组合而成
+func (v *PushInputView) Show(sourceImage, sourceImageID, sourceRepo, sourceTag string) {
+	v.visible = true
+	v.sourceImage = sourceImage
+	v.SourceImageID = sourceImageID
+	v.deleteTag = false
+	v.errorMsg = ""
+	v.focusIndex = 0
+
+	path := ""
+	if named, err := reference.ParseNormalizedNamed(sourceRepo); err == nil && sourceRepo != "<none>" {
+		path = reference.Path(named)
+	}
+	if sourceTag == "" || sourceTag == "<none>" {
+		sourceTag = "latest"
+	}
+	var suggestions []string
+	for _, host := range docker.ConfiguredRegistries() {
+		if path == "" {
+			suggestions = append(suggestions, host+"/")
+		} else {
+			suggestions = append(suggestions, host+"/"+path+":"+sourceTag)
+		}
+	}
+	v.targetInput.SetSuggestions(suggestions)
+	v.targetInput.SetValue("")
+	v.targetInput.Placeholder = "registry.example.com/team/image:tag"
+	v.batch = 0
+	v.updateInputFocus()
+}
+
+// ShowBatch 显示批量推送对话框，目标是一个模板（如 myreg.local/{{name}}:{{tag}}），
+// 候选为已配置的仓库加上 {{name}}:{{tag}}
+func (v *PushInputView) ShowBatch(count int, exampleRepo, exampleTag, exampleID string) {
+	v.Show(exampleRepo+":"+exampleTag, exampleID, "", "")
+	v.batch = count
+	v.example = [3]string{exampleRepo, exampleTag, exampleID}
+	var suggestions []string
+	for _, host := range docker.ConfiguredRegistries() {
+		suggestions = append(suggestions, host+"/{{name}}:{{tag}}")
+	}
+	v.targetInput.SetSuggestions(suggestions)
+	v.targetInput.Placeholder = "myreg.local/{{repo}}:{{tag}}"
+}
+
+// IsBatch 是否为批量模式
+func (v *PushInputView) IsBatch() bool {
+	return v.batch > 0
+}
+
+// Template 返回批量模式下输入的目标模板
+func (v *PushInputView) Template() string {
+	return strings.TrimSpace(v.targetInput.Value())
+}
+
+// SetError 显示错误，对话框保持打开
+func (v *PushInputView) SetError(msg string) {
+	v.errorMsg = msg
+}
+
+// Hide 隐藏对话框
+func (v *PushInputView) Hide() {
+	v.visible = false
+	v.targetInput.Blur()
+}
+
+// IsVisible 是否可见
+func (v *PushInputView) IsVisible() bool {
+	return v.visible
+}
+
+// GetValues 获取目标镜像引用（未写标签时补 latest）和是否删除中间标签
+func (v *PushInputView) GetValues() (targetRef string, deleteTag bool) {
+	targetRef = strings.TrimSpace(v.targetInput.Value())
+	if named, err := reference.ParseNormalizedNamed(targetRef); err == nil {
+		if _, tagged := named.(reference.Tagged); !tagged {
+			targetRef += ":latest"
+		}
+	}
+	return targetRef, v.deleteTag
+}
+
+// validate 校验目标镜像引用
+func (v *PushInputView) validate() bool {
+	target := strings.TrimSpace(v.targetInput.Value())
+	if target == "" {
+		v.errorMsg = "target is required"
+		return false
+	}
+	if v.IsBatch() {
+		// 逐个镜像的目标在提交前由调用方校验，这里只校验预览用的镜像
+		if _, err := task.RenderImageTarget(target, v.example[0], v.example[1], v.example[2]); err != nil {
+			v.errorMsg = err.Error()
+			return false
+		}
+		v.errorMsg = ""
+		return true
+	}
+	named, err := reference.ParseNormalizedNamed(target)
+	if err != nil {
+		v.errorMsg = "invalid reference: " + err.Error()
+		return false
+	}
+	if _, digested := named.(reference.Digested); digested {
+		v.errorMsg = "target must be a tag, not a digest"
+		return false
+	}
+	v.errorMsg = ""
+	return true
+}
+
+// SetWidth 设置宽度
+func (v *PushInputView) SetWidth(width int) {
+	v.width = width
+	inputWidth := width - 30
+	if inputWidth < 30 {
+		inputWidth = 30
+	}
+	if inputWidth > 55 {
+		inputWidth = 55
+	}
+	v.targetInput.Width = inputWidth
+}
+
+// Update 处理输入
+// 返回值: (confirmed bool, handled bool, cmd tea.Cmd)
+func (v *PushInputView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
+	if !v.visible {
+		return false, false, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, false, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEsc:
+		v.Hide()
+		return false, true, nil
+
+	case tea.KeyEnter:
+		switch v.focusIndex {
+		case 2:
+			v.Hide()
+			return false, true, nil
+		case 0, 3:
+			return v.validate(), true, nil
+		}
+		v.nextFocus()
+		return false, true, nil
+
+	case tea.KeyTab:
+		// 目标输入框有可接受的补全时，Tab 用于补全
+		if v.focusIndex == 0 && v.canComplete() {
+			var cmd tea.Cmd
+			v.targetInput, cmd = v.targetInput.Update(msg)
+			return false, true, cmd
+		}
+		v.nextFocus()
+		return false, true, nil
+
+	case tea.KeyShiftTab, tea.KeyUp:
+		v.prevFocus()
+		return false, true, nil
+
+	case tea.KeyDown:
+		v.nextFocus()
+		return false, true, nil
+	}
+
+	keyStr := keyMsg.String()
+	switch v.focusIndex {
+	case 1:
+		if keyStr == " " || keyStr == "x" {
+			v.deleteTag = !v.deleteTag
+		}
+		return false, true, nil
+	case 2, 3:
+		if keyMsg.Type == tea.KeyLeft || keyStr == "h" {
+			v.focusIndex = 2
+		} else if keyMsg.Type == tea.KeyRight || keyStr == "l" {
+			v.focusIndex = 3
+		}
+		return false, true, nil
+	}
+
+	var cmd tea.Cmd
+	v.targetInput, cmd = v.targetInput.Update(msg)
+	v.errorMsg = ""
+	return false, true, cmd
+}
+
+// canComplete 当前候选是否能补全输入内容
+func (v *PushInputView) canComplete() bool {
+	suggestion := v.targetInput.CurrentSuggestion()
+	return suggestion != "" && suggestion != v.targetInput.Value()
+}
+
+func (v *PushInputView) nextFocus() {
+	v.focusIndex = (v.focusIndex + 1) % 4
+	v.updateInputFocus()
+}
+
+func (v *PushInputView) prevFocus() {
+	v.focusIndex = (v.focusIndex + 3) % 4
+	v.updateInputFocus()
+}
+
+func (v *PushInputView) updateInputFocus() {
+	if v.focusIndex == 0 {
+		v.targetInput.Focus()
+	} else {
+		v.targetInput.Blur()
+	}
+}
+
+// View 渲染对话框
+func (v *PushInputView) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	title := tagInputTitleStyle.Render("📤 Tag & Push Image")
+	if v.IsBatch() {
+		title = tagInputTitleStyle.Render(fmt.Sprintf("📤 Tag & Push %d Images", v.batch))
+	}
+
+	shortID := v.SourceImageID
+	if len(shortID) > 12 {
+		shortID = shortID[:12]
+	}
+	sourceInfo := tagInputLabelStyle.Render("Source:") + " " +
+		tagInputSourceStyle.Render(v.sourceImage) +
+		tagInputHintStyle.Render(" ("+shortID+")")
+
+	targetStyle := lipgloss.NewStyle()
+	if v.focusIndex == 0 {
+		targetStyle = targetStyle.Foreground(lipgloss.Color("81"))
+	}
+	targetLine := tagInputLabelStyle.Render("Target:") + " " + targetStyle.Render(v.targetInput.View())
+
+	checkbox := "[ ]"
+	if v.deleteTag {
+		checkbox = "[x]"
+	}
+	checkStyle := tagInputHintStyle
+	if v.focusIndex == 1 {
+		checkStyle = tagInputSourceStyle.Bold(true)
+	}
+	deleteLine := tagInputLabelStyle.Render("") + " " + checkStyle.Render(checkbox+" Delete local tag after push")
+
+	if v.IsBatch() {
+		sourceInfo = tagInputLabelStyle.Render("Source:") + " " + tagInputSourceStyle.Render(fmt.Sprintf("%d selected images", v.batch))
+	}
+	contentParts := []string{title, "", sourceInfo, "", targetLine}
+	if registries := v.targetInput.AvailableSuggestions(); len(registries) > 0 {
+		contentParts = append(contentParts, tagInputLabelStyle.Render("")+" "+
+			tagInputHintStyle.Render("Tab to complete, Ctrl+N/P for other registries"))
+	}
+	if v.IsBatch() {
+		contentParts = append(contentParts, tagInputLabelStyle.Render("")+" "+
+			tagInputHintStyle.Render(strings.Join(task.ImageTargetPlaceholders, " ")))
+		if tmpl := v.Template(); tmpl != "" && v.errorMsg == "" {
+			if target, err := task.RenderImageTarget(tmpl, v.example[0], v.example[1], v.example[2]); err == nil {
+				contentParts = append(contentParts, "", tagInputHintStyle.Render("e.g. ")+
+					tagInputSourceStyle.Render(v.sourceImage+" → "+target))
+			}
+		}
+	} else if target, _ := v.GetValues(); target != "" && v.errorMsg == "" {
+		contentParts = append(contentParts, "", tagInputHintStyle.Render("Steps: ")+
+			tagInputSourceStyle.Render("tag → push "+target))
+	}
+	contentParts = append(contentParts, "", deleteLine)
+
+	if v.errorMsg != "" {
+		contentParts = append(contentParts, "", lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+v.errorMsg))
+	}
+
+	cancelBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	okBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	if v.focusIndex == 2 {
+		cancelBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	if v.focusIndex == 3 {
+		okBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	buttons := cancelBtnStyle.Render("< Cancel >") + "    " + okBtnStyle.Render("< Push >")
+
+	hints := tagInputHintStyle.Render("[Tab/↑↓=Switch] [Space=Toggle] [Enter=Confirm] [Esc=Cancel]")
+	contentParts = append(contentParts, "", buttons, "", hints)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, contentParts...)
+
+	boxWidth := v.width - 10
+	if boxWidth < 60 {
+		boxWidth = 60
+	}
+	if boxWidth > 75 {
+		boxWidth = 75
+	}
+	return tagInputBoxStyle.Width(boxWidth).Render(content)
+}
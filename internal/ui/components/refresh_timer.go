@@ -0,0 +1,112 @@
+package components
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/format"
+)
+
+// 容器统计和进程列表的刷新间隔（配置文件 refresh 节），见 SetRefreshIntervals
+var (
+	statsRefreshInterval     = time.Second
+	processesRefreshInterval = time.Second
+)
+
+// SetRefreshIntervals 设置资源统计和进程列表的刷新间隔，为 0 的保持默认值
+func SetRefreshIntervals(stats, processes time.Duration) {
+	if stats > 0 {
+		statsRefreshInterval = stats
+	}
+	if processes > 0 {
+		processesRefreshInterval = processes
+	}
+}
+
+// StatsRefreshInterval 资源统计的刷新间隔，Compose 服务表格的资源列也按该间隔刷新
+func StatsRefreshInterval() time.Duration {
+	return statsRefreshInterval
+}
+
+// refreshSlack 距下次刷新不足该时长时视为已到期，避免与数据推送或 tick 的节奏错开一拍
+const refreshSlack = 250 * time.Millisecond
+
+var refreshTimerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+// RefreshTimer 定时刷新的视图共用：记录上次更新时间，判断是否到期，并渲染倒计时提示。
+// 视图每 Step() 触发一次 tick，Due 时重新加载，其余 tick 只用于更新倒计时
+type RefreshTimer struct {
+	interval time.Duration // 0 表示不定时刷新（数据由事件或数据流推送），只显示上次更新时间
+	updated  time.Time
+	loading  bool
+}
+
+// NewRefreshTimer 创建刷新计时器
+func NewRefreshTimer(interval time.Duration) *RefreshTimer {
+	return &RefreshTimer{interval: interval}
+}
+
+// SetInterval 设置刷新间隔
+func (t *RefreshTimer) SetInterval(interval time.Duration) {
+	t.interval = interval
+}
+
+// Interval 刷新间隔
+func (t *RefreshTimer) Interval() time.Duration {
+	return t.interval
+}
+
+// Updated 上次更新的时间，尚未更新时为零值
+func (t *RefreshTimer) Updated() time.Time {
+	return t.updated
+}
+
+// Start 标记开始加载，加载期间不会再次到期
+func (t *RefreshTimer) Start() {
+	t.loading = true
+}
+
+// Mark 记录一次更新完成（成功或失败），倒计时从现在开始
+func (t *RefreshTimer) Mark() {
+	t.loading = false
+	t.updated = time.Now()
+}
+
+// Reset 清除上次更新时间，下一次 tick 立即到期
+func (t *RefreshTimer) Reset() {
+	t.loading = false
+	t.updated = time.Time{}
+}
+
+// Due 是否应该重新加载
+func (t *RefreshTimer) Due() bool {
+	return t.interval > 0 && !t.loading && t.remaining() <= refreshSlack
+}
+
+// Step 下一次 tick 的间隔：每秒一次以更新倒计时，刷新间隔更短时按刷新间隔
+func (t *RefreshTimer) Step() time.Duration {
+	if t.interval > 0 && t.interval < time.Second {
+		return t.interval
+	}
+	return time.Second
+}
+
+func (t *RefreshTimer) remaining() time.Duration {
+	return time.Until(t.updated.Add(t.interval))
+}
+
+// View 渲染提示，如 "↻ 2s · updated 14:03:05"；加载中显示 "↻ refreshing"
+func (t *RefreshTimer) View() string {
+	switch {
+	case t.loading:
+		return refreshTimerStyle.Render("↻ refreshing")
+	case t.updated.IsZero():
+		return ""
+	case t.interval <= 0:
+		return refreshTimerStyle.Render("updated " + t.updated.Format("15:04:05"))
+	}
+	// 向上取整，倒计时从间隔开始数到 1s
+	remaining := (t.remaining() + time.Second - 1).Truncate(time.Second)
+	return refreshTimerStyle.Render("↻ " + format.Duration(remaining) + " · updated " + t.updated.Format("15:04:05"))
+}
@@ -0,0 +1,498 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"docktui/internal/docker"
+	"docktui/internal/ui/styles"
+)
+
+// PickerItem 选择器中的一项
+type PickerItem struct {
+	ID     string // 选择结果使用的标识，如容器 ID、Shell 路径
+	Name   string // 显示名称
+	Detail string // 名称后的说明，如镜像和状态描述
+	State  string // 容器状态（running/exited/paused 等），决定状态点颜色；为空时不显示
+
+	Disabled bool // 显示为灰色且不能选择，如容器中不存在的 Shell
+}
+
+// ContainerPickerItems 把容器列表转换为选择器的项
+func ContainerPickerItems(containers []docker.Container) []PickerItem {
+	items := make([]PickerItem, 0, len(containers))
+	for _, c := range containers {
+		detail := c.Image
+		if c.Status != "" {
+			detail += " · " + c.Status
+		}
+		items = append(items, PickerItem{ID: c.ID, Name: c.Name, Detail: detail, State: c.State})
+	}
+	return items
+}
+
+// pickerMaxVisible 列表最多显示的行数（终端较矮时更少）
+const pickerMaxVisible = 10
+
+// ResourcePicker 可搜索、可多选的资源选择对话框，Shell 选择、容器选择等共用
+type ResourcePicker struct {
+	title    string
+	subtitle string
+	notice   string // 副标题下方的额外提示（已渲染）
+	multi    bool
+
+	items    []PickerItem
+	filtered []int           // 匹配搜索的项在 items 中的下标
+	checked  map[string]bool // 多选模式下已勾选的项（按 ID）
+	cursor   int             // 光标在 filtered 中的位置
+	offset   int             // 列表滚动偏移
+
+	searchInput textinput.Model
+	searching   bool
+
+	loading     bool
+	loadingText string
+	errorMsg    string
+
+	width  int
+	height int
+}
+
+// NewResourcePicker 创建选择器，multi 为 true 时可用空格勾选多项
+func NewResourcePicker(title string, multi bool) *ResourcePicker {
+	ti := textinput.New()
+	ti.Placeholder = "type to filter"
+	ti.CharLimit = 64
+	ti.Width = 30
+	ti.Prompt = "🔍 "
+	return &ResourcePicker{
+		title:       title,
+		multi:       multi,
+		checked:     make(map[string]bool),
+		searchInput: ti,
+		width:       60,
+		height:      20,
+	}
+}
+
+// SetTitle 设置标题和副标题
+func (p *ResourcePicker) SetTitle(title, subtitle string) {
+	p.title = title
+	p.subtitle = subtitle
+}
+
+// SetNotice 设置副标题下方的提示，为空时不显示
+func (p *ResourcePicker) SetNotice(notice string) {
+	p.notice = notice
+}
+
+// SetSize 设置尺寸
+func (p *ResourcePicker) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	p.clampOffset()
+}
+
+// SetLoading 显示加载状态，清空已有的项、勾选和搜索
+func (p *ResourcePicker) SetLoading(text string) {
+	p.loading = true
+	p.loadingText = text
+	p.errorMsg = ""
+	p.items = nil
+	p.filtered = nil
+	p.checked = make(map[string]bool)
+	p.cursor, p.offset = 0, 0
+	p.searching = false
+	p.searchInput.SetValue("")
+	p.searchInput.Blur()
+}
+
+// SetItems 设置可选的项并结束加载状态
+func (p *ResourcePicker) SetItems(items []PickerItem) {
+	p.loading = false
+	p.errorMsg = ""
+	p.items = items
+	p.applyFilter()
+}
+
+// SetError 显示错误并结束加载状态
+func (p *ResourcePicker) SetError(msg string) {
+	p.loading = false
+	p.errorMsg = msg
+}
+
+// IsLoading 是否正在加载
+func (p *ResourcePicker) IsLoading() bool {
+	return p.loading
+}
+
+// HasError 是否有错误
+func (p *ResourcePicker) HasError() bool {
+	return p.errorMsg != ""
+}
+
+// Current 返回光标所在的项，没有可选项时返回 nil
+func (p *ResourcePicker) Current() *PickerItem {
+	if p.cursor < 0 || p.cursor >= len(p.filtered) {
+		return nil
+	}
+	return &p.items[p.filtered[p.cursor]]
+}
+
+// Selected 返回选择结果：多选模式下为已勾选的项（按列表顺序，没有勾选时为光标所在项），否则为光标所在项
+func (p *ResourcePicker) Selected() []PickerItem {
+	var out []PickerItem
+	if p.multi {
+		for _, item := range p.items {
+			if p.checked[item.ID] && !item.Disabled {
+				out = append(out, item)
+			}
+		}
+		if len(out) > 0 {
+			return out
+		}
+	}
+	if cur := p.Current(); cur != nil && !cur.Disabled {
+		out = append(out, *cur)
+	}
+	return out
+}
+
+// Update 处理按键
+// 返回值: (confirmed bool, cancelled bool, cmd tea.Cmd)
+func (p *ResourcePicker) Update(msg tea.KeyMsg) (bool, bool, tea.Cmd) {
+	if p.searching {
+		switch msg.Type {
+		case tea.KeyEsc:
+			p.searching = false
+			p.searchInput.Blur()
+			p.searchInput.SetValue("")
+			p.applyFilter()
+			return false, false, nil
+		case tea.KeyEnter:
+			p.searching = false
+			p.searchInput.Blur()
+			return false, false, nil
+		case tea.KeyUp, tea.KeyDown:
+			// 搜索时也可以直接移动光标
+		default:
+			var cmd tea.Cmd
+			p.searchInput, cmd = p.searchInput.Update(msg)
+			p.applyFilter()
+			return false, false, cmd
+		}
+	}
+
+	if p.loading {
+		if msg.Type == tea.KeyEsc || msg.String() == "q" {
+			return false, true, nil
+		}
+		return false, false, nil
+	}
+
+	switch key := msg.String(); key {
+	case "up", "k":
+		p.moveCursor(-1)
+	case "down", "j":
+		p.moveCursor(1)
+	case "pgup":
+		p.moveCursor(-p.visibleRows())
+	case "pgdown":
+		p.moveCursor(p.visibleRows())
+	case "home", "g":
+		p.moveCursor(-len(p.filtered))
+	case "end", "G":
+		p.moveCursor(len(p.filtered))
+	case "/":
+		if p.errorMsg == "" {
+			p.searching = true
+			return false, false, p.searchInput.Focus()
+		}
+	case " ":
+		if cur := p.Current(); p.multi && cur != nil && !cur.Disabled {
+			p.checked[cur.ID] = !p.checked[cur.ID]
+			if !p.checked[cur.ID] {
+				delete(p.checked, cur.ID)
+			}
+			p.moveCursor(1)
+		}
+	case "a":
+		if p.multi {
+			p.toggleAllVisible()
+		}
+	case "enter":
+		if len(p.Selected()) > 0 {
+			return true, false, nil
+		}
+	case "esc", "q":
+		// 有搜索条件时先清除搜索
+		if key == "esc" && p.searchInput.Value() != "" {
+			p.searchInput.SetValue("")
+			p.applyFilter()
+			return false, false, nil
+		}
+		return false, true, nil
+	default:
+		// 单选模式下数字快捷键直接选择
+		if !p.multi && len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+			idx := int(key[0] - '1')
+			if idx < len(p.filtered) {
+				p.cursor = idx
+				p.clampOffset()
+				return !p.items[p.filtered[idx]].Disabled, false, nil
+			}
+		}
+	}
+	return false, false, nil
+}
+
+// toggleAllVisible 全部勾选当前匹配的项；已全部勾选时取消勾选
+func (p *ResourcePicker) toggleAllVisible() {
+	all := len(p.filtered) > 0
+	for _, i := range p.filtered {
+		if !p.checked[p.items[i].ID] {
+			all = false
+			break
+		}
+	}
+	for _, i := range p.filtered {
+		if all {
+			delete(p.checked, p.items[i].ID)
+		} else {
+			p.checked[p.items[i].ID] = true
+		}
+	}
+}
+
+// applyFilter 按搜索词（名称、说明或 ID，忽略大小写）过滤，尽量保持光标在原来的项上
+func (p *ResourcePicker) applyFilter() {
+	var currentID string
+	if cur := p.Current(); cur != nil {
+		currentID = cur.ID
+	}
+	query := strings.ToLower(strings.TrimSpace(p.searchInput.Value()))
+	p.filtered = p.filtered[:0]
+	p.cursor = 0
+	for i, item := range p.items {
+		if query != "" &&
+			!strings.Contains(strings.ToLower(item.Name), query) &&
+			!strings.Contains(strings.ToLower(item.Detail), query) &&
+			!strings.HasPrefix(strings.ToLower(item.ID), query) {
+			continue
+		}
+		if item.ID == currentID {
+			p.cursor = len(p.filtered)
+		}
+		p.filtered = append(p.filtered, i)
+	}
+	p.clampOffset()
+}
+
+func (p *ResourcePicker) moveCursor(delta int) {
+	p.cursor += delta
+	if p.cursor >= len(p.filtered) {
+		p.cursor = len(p.filtered) - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+	p.clampOffset()
+}
+
+// visibleRows 列表可显示的行数
+func (p *ResourcePicker) visibleRows() int {
+	rows := p.height - 14
+	if rows > pickerMaxVisible {
+		rows = pickerMaxVisible
+	}
+	if rows < 3 {
+		rows = 3
+	}
+	return rows
+}
+
+// clampOffset 调整滚动偏移，使光标可见
+func (p *ResourcePicker) clampOffset() {
+	rows := p.visibleRows()
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+rows {
+		p.offset = p.cursor - rows + 1
+	}
+	if max := len(p.filtered) - rows; p.offset > max {
+		p.offset = max
+	}
+	if p.offset < 0 {
+		p.offset = 0
+	}
+}
+
+// View 渲染居中的对话框
+func (p *ResourcePicker) View() string {
+	dialogWidth := 64
+	if p.width-4 < dialogWidth {
+		dialogWidth = p.width - 4
+	}
+	if dialogWidth < 40 {
+		dialogWidth = 40
+	}
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63")).
+		Padding(1, 2).
+		Width(dialogWidth)
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+	subtitleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(p.title))
+	content.WriteString("\n")
+	if p.subtitle != "" {
+		content.WriteString(subtitleStyle.Render(p.subtitle))
+		content.WriteString("\n")
+	}
+	if p.notice != "" {
+		content.WriteString(p.notice)
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	switch {
+	case p.loading:
+		content.WriteString(subtitleStyle.Render("⏳ " + p.loadingText))
+	case p.errorMsg != "":
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+		content.WriteString(errorStyle.Render("❌ " + p.errorMsg))
+		content.WriteString("\n\n")
+		content.WriteString(subtitleStyle.Render("Press Esc to go back"))
+	default:
+		if p.searching || p.searchInput.Value() != "" {
+			content.WriteString(p.searchInput.View())
+			content.WriteString(subtitleStyle.Render(fmt.Sprintf("  %d/%d", len(p.filtered), len(p.items))))
+			content.WriteString("\n\n")
+		}
+		content.WriteString(p.renderItems(dialogWidth - 6))
+		content.WriteString("\n")
+
+		hints := []string{
+			keyStyle.Render("↑/↓") + subtitleStyle.Render(" Select"),
+		}
+		if p.multi {
+			hints = append(hints,
+				keyStyle.Render("Space")+subtitleStyle.Render(" Toggle"),
+				keyStyle.Render("a")+subtitleStyle.Render(" All"),
+			)
+		} else if n := len(p.filtered); n > 0 {
+			if n > 9 {
+				n = 9
+			}
+			hints = append(hints, keyStyle.Render(fmt.Sprintf("1-%d", n))+subtitleStyle.Render(" Quick select"))
+		}
+		hints = append(hints,
+			keyStyle.Render("/")+subtitleStyle.Render(" Search"),
+			keyStyle.Render("Enter")+subtitleStyle.Render(" Confirm"),
+			keyStyle.Render("Esc")+subtitleStyle.Render(" Cancel"),
+		)
+		content.WriteString(joinHints(hints, dialogWidth-6))
+	}
+
+	return lipgloss.Place(p.width, p.height, lipgloss.Center, lipgloss.Center, dialogStyle.Render(content.String()))
+}
+
+// joinHints 用两个空格连接提示，超出宽度时换行（不在提示中间断开）
+func joinHints(hints []string, width int) string {
+	var lines []string
+	line := ""
+	for _, h := range hints {
+		if line != "" && lipgloss.Width(line)+2+lipgloss.Width(h) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += "  "
+		}
+		line += h
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
+// renderItems 渲染当前可见的项
+func (p *ResourcePicker) renderItems(width int) string {
+	subtitleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	if len(p.filtered) == 0 {
+		if len(p.items) == 0 {
+			return subtitleStyle.Render("Nothing to choose from") + "\n"
+		}
+		return subtitleStyle.Render("No matches") + "\n"
+	}
+
+	numStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+
+	var b strings.Builder
+	end := p.offset + p.visibleRows()
+	if end > len(p.filtered) {
+		end = len(p.filtered)
+	}
+	if p.offset > 0 {
+		b.WriteString(subtitleStyle.Render(fmt.Sprintf("  ↑ %d more", p.offset)) + "\n")
+	}
+	for pos := p.offset; pos < end; pos++ {
+		item := p.items[p.filtered[pos]]
+		var prefix string
+		if p.multi {
+			box := "[ ]"
+			if p.checked[item.ID] {
+				box = "[x]"
+			}
+			prefix = numStyle.Render(box)
+		} else {
+			label := "   "
+			if pos < 9 {
+				label = fmt.Sprintf("[%d]", pos+1)
+			}
+			prefix = numStyle.Render(label)
+		}
+
+		cursor := " "
+		name, detail := nameStyle.Render(item.Name), subtitleStyle
+		if pos == p.cursor {
+			cursor = selectedStyle.Render("▶")
+			name = selectedStyle.Render(item.Name)
+			detail = nameStyle
+		}
+		if item.Disabled {
+			disabledStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+			name, detail = disabledStyle.Render(item.Name), disabledStyle
+		}
+		state := ""
+		if item.State != "" {
+			st := styles.ContainerStatus(item.State, "")
+			state = styles.StatusStyle(st).Render(styles.StatusGlyph(st)) + " "
+		}
+		line := fmt.Sprintf("%s %s %s%s", prefix, cursor, state, name)
+		if item.Detail != "" {
+			desc := item.Detail
+			if avail := width - lipgloss.Width(line) - 3; avail > 0 {
+				desc = ansi.Truncate(desc, avail, "…")
+				line += " " + detail.Render("("+desc+")")
+			}
+		}
+		b.WriteString(line + "\n")
+	}
+	if rest := len(p.filtered) - end; rest > 0 {
+		b.WriteString(subtitleStyle.Render(fmt.Sprintf("  ↓ %d more", rest)) + "\n")
+	}
+	if p.multi {
+		b.WriteString("\n" + subtitleStyle.Render(fmt.Sprintf("%d selected", len(p.checked))) + "\n")
+	}
+	return b.String()
+}
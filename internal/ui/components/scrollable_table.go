@@ -1,9 +1,14 @@
 package components
 
 import (
+	"maps"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"docktui/internal/format"
 )
 
 // 主题颜色定义
@@ -29,6 +34,27 @@ type ScrollableTable struct {
 	height           int
 	focused          bool
 	styles           TableStyles
+
+	highlight        string // 搜索关键字，在 highlightColumns 列中高亮匹配
+	highlightColumns map[int]bool
+
+	// 渲染缓存：数据、尺寸、光标和滚动位置都没变时直接复用上次的结果，
+	// 光标移动时也只重新渲染选中行，其余行复用已渲染的字符串
+	version  uint64    // 行、列或样式每次变化时递增
+	viewKey  renderKey // lastView 对应的状态
+	lastView string
+	lineKey  renderKey // lines 对应的状态（只含影响非选中行的字段）
+	lines    []string  // 各行非选中状态的渲染结果，空字符串表示尚未渲染
+}
+
+// renderKey 决定表格渲染结果的状态
+type renderKey struct {
+	version uint64
+	width   int
+	height  int
+	cursor  int
+	offset  int
+	focused bool
 }
 
 // TableColumn 表格列定义
@@ -47,6 +73,7 @@ type TableStyles struct {
 	Selected        lipgloss.Style
 	Border          lipgloss.Style
 	ScrollIndicator lipgloss.Style
+	Match           lipgloss.Style // 搜索匹配
 }
 
 // DefaultTableStyles 默认表格样式
@@ -62,6 +89,10 @@ func DefaultTableStyles() TableStyles {
 		ScrollIndicator: lipgloss.NewStyle().
 			Foreground(ThemeHighlight).
 			Bold(true),
+		Match: lipgloss.NewStyle().
+			Background(ThemeWarning).
+			Foreground(lipgloss.Color("16")).
+			Bold(true),
 	}
 }
 
@@ -80,13 +111,20 @@ func NewScrollableTable(columns []TableColumn) *ScrollableTable {
 	}
 }
 
-// SetColumns 设置列定义
+// SetColumns 设置列定义；水平偏移保持不变，只在列变窄后超出可滚动范围时收回
 func (t *ScrollableTable) SetColumns(columns []TableColumn) {
 	t.columns = columns
+	t.version++
+	if maxOffset := max(0, t.getTotalWidth()-t.width+10); t.horizontalOffset > maxOffset {
+		t.horizontalOffset = maxOffset
+	}
 }
 
-// SetRows 设置行数据
+// SetRows 设置行数据，内容与当前相同时保留渲染缓存（列表定时刷新时多数情况下数据没有变化）
 func (t *ScrollableTable) SetRows(rows []TableRow) {
+	if !sameRows(t.rows, rows) {
+		t.version++
+	}
 	t.rows = rows
 	if t.cursor >= len(rows) {
 		t.cursor = len(rows) - 1
@@ -96,6 +134,20 @@ func (t *ScrollableTable) SetRows(rows []TableRow) {
 	}
 }
 
+// SetHighlight 设置搜索关键字，在指定列中高亮匹配的部分；query 为空时取消高亮
+func (t *ScrollableTable) SetHighlight(query string, columns ...int) {
+	cols := make(map[int]bool, len(columns))
+	for _, c := range columns {
+		cols[c] = true
+	}
+	if query == t.highlight && maps.Equal(cols, t.highlightColumns) {
+		return
+	}
+	t.highlight = query
+	t.highlightColumns = cols
+	t.version++
+}
+
 // SetSize 设置可见区域大小
 func (t *ScrollableTable) SetSize(width, height int) {
 	t.width = width
@@ -115,6 +167,7 @@ func (t *ScrollableTable) SetFocused(focused bool) {
 // SetStyles 设置样式
 func (t *ScrollableTable) SetStyles(styles TableStyles) {
 	t.styles = styles
+	t.version++
 }
 
 // Cursor 获取当前选中行索引
@@ -122,6 +175,14 @@ func (t *ScrollableTable) Cursor() int {
 	return t.cursor
 }
 
+// SetCursor 设置选中行，超出范围时选中最近的行
+func (t *ScrollableTable) SetCursor(index int) {
+	t.cursor = min(index, len(t.rows)-1)
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+}
+
 // MoveUp 向上移动
 func (t *ScrollableTable) MoveUp(n int) {
 	t.cursor -= n
@@ -192,6 +253,17 @@ func (t *ScrollableTable) View() string {
 		return ""
 	}
 
+	key := renderKey{version: t.version, width: t.width, height: t.height, cursor: t.cursor, offset: t.horizontalOffset, focused: t.focused}
+	if key == t.viewKey && t.lastView != "" {
+		return t.lastView
+	}
+	t.viewKey = key
+	t.lastView = t.render()
+	return t.lastView
+}
+
+// render 渲染表格（不使用整体缓存）
+func (t *ScrollableTable) render() string {
 	var b strings.Builder
 
 	visibleWidth := t.width - 4
@@ -235,9 +307,22 @@ func (t *ScrollableTable) View() string {
 		endRow = len(t.rows)
 	}
 
+	// 非选中行的渲染结果只与数据、宽度和水平偏移有关
+	lineKey := renderKey{version: t.version, width: t.width, offset: t.horizontalOffset}
+	if lineKey != t.lineKey || len(t.lines) != len(t.rows) {
+		t.lineKey = lineKey
+		t.lines = make([]string, len(t.rows))
+	}
+
 	for i := startRow; i < endRow; i++ {
-		rowLine := t.renderRow(i)
-		b.WriteString(t.applyHorizontalScroll(rowLine, visibleWidth))
+		if i == t.cursor && t.focused {
+			b.WriteString(t.applyHorizontalScroll(t.renderRow(i), visibleWidth))
+		} else {
+			if t.lines[i] == "" {
+				t.lines[i] = t.applyHorizontalScroll(t.renderRow(i), visibleWidth)
+			}
+			b.WriteString(t.lines[i])
+		}
 		if i < endRow-1 {
 			b.WriteString("\n")
 		}
@@ -246,6 +331,24 @@ func (t *ScrollableTable) View() string {
 	return b.String()
 }
 
+// sameRows 两组行数据是否完全相同
+func sameRows(a, b []TableRow) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if a[i][j] != b[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func (t *ScrollableTable) renderScrollIndicator(canLeft, canRight bool) string {
 	leftArrow := "  "
 	rightArrow := "  "
@@ -307,87 +410,35 @@ func (t *ScrollableTable) renderRow(index int) string {
 	isSelected := index == t.cursor && t.focused
 
 	var rowContent strings.Builder
+	var matches [][2]int // 搜索匹配在整行可见文本中的位置
+	offset := 0
 	for i, col := range t.columns {
 		cellValue := ""
 		if i < len(row) {
 			cellValue = row[i]
 		}
 		cell := t.padOrTruncate(cellValue, col.Width)
+		if t.highlight != "" && t.highlightColumns[i] {
+			for _, m := range format.MatchRanges(cell, t.highlight) {
+				matches = append(matches, [2]int{offset + 1 + m[0], offset + 1 + m[1]})
+			}
+		}
+		offset += utf8.RuneCountInString(ansi.Strip(cell)) + 2
 		rowContent.WriteString(" ")
 		rowContent.WriteString(cell)
 		rowContent.WriteString(" ")
 	}
 
+	// 在整行渲染之后高亮，匹配结束后能恢复选中行的样式
+	line := t.styles.Cell.Render(rowContent.String())
 	if isSelected {
-		return t.styles.Selected.Render(rowContent.String())
+		line = t.styles.Selected.Render(rowContent.String())
 	}
-	return t.styles.Cell.Render(rowContent.String())
+	return format.HighlightRanges(line, matches, func(s string) string { return t.styles.Match.Render(s) })
 }
 
 func (t *ScrollableTable) padOrTruncate(s string, width int) string {
-	visibleLen := t.visibleLength(s)
-
-	if visibleLen > width {
-		return t.truncateWithAnsi(s, width-3) + "..."
-	}
-
-	padding := width - visibleLen
-	if padding > 0 {
-		return s + strings.Repeat(" ", padding)
-	}
-	return s
-}
-
-func (t *ScrollableTable) visibleLength(s string) int {
-	inEscape := false
-	length := 0
-	for _, r := range s {
-		if r == '\x1b' {
-			inEscape = true
-			continue
-		}
-		if inEscape {
-			if r == 'm' {
-				inEscape = false
-			}
-			continue
-		}
-		length++
-	}
-	return length
-}
-
-func (t *ScrollableTable) truncateWithAnsi(s string, maxLen int) string {
-	if maxLen <= 0 {
-		return ""
-	}
-
-	var result strings.Builder
-	inEscape := false
-	visibleCount := 0
-
-	for _, r := range s {
-		if r == '\x1b' {
-			inEscape = true
-			result.WriteRune(r)
-			continue
-		}
-		if inEscape {
-			result.WriteRune(r)
-			if r == 'm' {
-				inEscape = false
-			}
-			continue
-		}
-		if visibleCount >= maxLen {
-			break
-		}
-		result.WriteRune(r)
-		visibleCount++
-	}
-
-	result.WriteString("\x1b[0m")
-	return result.String()
+	return format.Fit(s, width)
 }
 
 func (t *ScrollableTable) applyHorizontalScroll(line string, visibleWidth int) string {
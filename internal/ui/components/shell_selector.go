@@ -3,7 +3,6 @@ package components
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -30,21 +29,14 @@ var knownShells = []ShellInfo{
 	{Path: "/bin/ksh", Name: "ksh", Description: "Korn Shell"},
 }
 
-// ShellSelector Shell 选择器组件
+// ShellSelector Shell 选择器组件，基于 ResourcePicker
 type ShellSelector struct {
 	dockerClient docker.Client
+	picker       *ResourcePicker
 	
 	containerID   string
 	containerName string
 	
-	shells       []ShellInfo // 可用的 Shell 列表
-	selectedIdx  int         // 当前选中的索引
-	loading      bool        // 是否正在加载
-	errorMsg     string      // 错误信息
-	
-	width  int
-	height int
-	
 	// 回调
 	onSelect func(shell string) // 选择 Shell 后的回调
 	onCancel func()             // 取消选择的回调
@@ -54,27 +46,31 @@ type ShellSelector struct {
 func NewShellSelector(dockerClient docker.Client) *ShellSelector {
 	return &ShellSelector{
 		dockerClient: dockerClient,
-		shells:       []ShellInfo{},
-		selectedIdx:  0,
-		width:        60,
-		height:       20,
+		picker:       NewResourcePicker("🐚 Select Shell", false),
+	}
+}
+
+// SetRecording 设置是否显示会话录制提示
+func (s *ShellSelector) SetRecording(recording bool) {
+	notice := ""
+	if recording {
+		recStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		notice = recStyle.Render("● REC") + lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(" Session will be recorded")
 	}
+	s.picker.SetNotice(notice)
 }
 
 // SetContainer 设置容器
 func (s *ShellSelector) SetContainer(containerID, containerName string) {
 	s.containerID = containerID
 	s.containerName = containerName
-	s.shells = []ShellInfo{}
-	s.selectedIdx = 0
-	s.loading = true
-	s.errorMsg = ""
+	s.picker.SetTitle("🐚 Select Shell", "Container: "+containerName)
+	s.picker.SetLoading("Detecting available shells...")
 }
 
 // SetSize 设置尺寸
 func (s *ShellSelector) SetSize(width, height int) {
-	s.width = width
-	s.height = height
+	s.picker.SetSize(width, height)
 }
 
 // SetCallbacks 设置回调函数
@@ -107,194 +103,88 @@ func (s *ShellSelector) detectShells() tea.Msg {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	
-	// 获取可用的 Shell 列表
-	availableShells := s.dockerClient.GetAvailableShells(ctx, s.containerID)
-	
-	// 构建 Shell 信息列表
-	shells := make([]ShellInfo, 0)
-	availableSet := make(map[string]bool)
-	for _, shell := range availableShells {
-		availableSet[shell] = true
+	// 探测容器中的工具（按容器缓存），列出所有已知的 Shell，不存在的显示为灰色
+	tools, err := s.dockerClient.ProbeTools(ctx, s.containerID)
+	if err != nil {
+		return ShellsDetectErrorMsg{Err: fmt.Errorf("failed to detect shells: %w", err)}
 	}
 	
-	// 按预定义顺序添加可用的 Shell
+	shells := make([]ShellInfo, 0, len(knownShells))
 	for _, known := range knownShells {
-		if availableSet[known.Path] {
-			shell := known
+		shell := known
+		if p := tools.Path(known.Name); p != "" {
+			shell.Path = p
 			shell.Available = true
-			shells = append(shells, shell)
 		}
-	}
-	
-	if len(shells) == 0 {
-		return ShellsDetectErrorMsg{Err: fmt.Errorf("no available shell in container")}
+		shells = append(shells, shell)
 	}
 	
 	return ShellsDetectedMsg{Shells: shells}
 }
 
 // Update 处理消息
-func (s *ShellSelector) Update(msg tea.Msg) tea.Cmd {
+// 返回值: (shell string, cancelled bool, cmd tea.Cmd)，选择 Shell 后 shell 为其路径
+func (s *ShellSelector) Update(msg tea.Msg) (string, bool, tea.Cmd) {
 	switch msg := msg.(type) {
 	case ShellsDetectedMsg:
-		s.loading = false
-		s.shells = msg.Shells
-		s.selectedIdx = 0
-		return nil
+		// 可用的 Shell 排在前面，数字快捷键对应可选的项
+		items := make([]PickerItem, 0, len(msg.Shells))
+		var missing []PickerItem
+		for _, shell := range msg.Shells {
+			if shell.Available {
+				items = append(items, PickerItem{ID: shell.Path, Name: shell.Name, Detail: shell.Description})
+			} else {
+				missing = append(missing, PickerItem{ID: shell.Path, Name: shell.Name, Detail: "not installed", Disabled: true})
+			}
+		}
+		if len(items) == 0 {
+			s.picker.SetTitle("🐚 Select Shell", "Container: "+s.containerName+" — "+docker.NoShellHint)
+		}
+		s.picker.SetItems(append(items, missing...))
 		
 	case ShellsDetectErrorMsg:
-		s.loading = false
-		s.errorMsg = msg.Err.Error()
-		return nil
+		s.picker.SetError(msg.Err.Error())
 		
 	case tea.KeyMsg:
-		if s.loading {
-			return nil
-		}
-		
-		switch msg.String() {
-		case "up", "k":
-			if s.selectedIdx > 0 {
-				s.selectedIdx--
-			}
-		case "down", "j":
-			if s.selectedIdx < len(s.shells)-1 {
-				s.selectedIdx++
-			}
-		case "enter":
-			if len(s.shells) > 0 && s.onSelect != nil {
-				s.onSelect(s.shells[s.selectedIdx].Path)
-			}
-		case "esc", "q":
+		confirmed, cancelled, cmd := s.picker.Update(msg)
+		if cancelled {
 			if s.onCancel != nil {
 				s.onCancel()
 			}
-		case "1", "2", "3", "4", "5", "6":
-			// 数字快捷键选择
-			idx := int(msg.String()[0] - '1')
-			if idx >= 0 && idx < len(s.shells) {
-				s.selectedIdx = idx
-				if s.onSelect != nil {
-					s.onSelect(s.shells[s.selectedIdx].Path)
-				}
+			return "", true, nil
+		}
+		if confirmed {
+			shell := s.GetSelectedShell()
+			if s.onSelect != nil {
+				s.onSelect(shell)
 			}
+			return shell, false, nil
 		}
+		return "", false, cmd
 	}
 	
-	return nil
+	return "", false, nil
 }
 
 // View 渲染视图
 func (s *ShellSelector) View() string {
-	// 对话框样式
-	dialogStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("63")).
-		Padding(1, 2).
-		Width(50)
-	
-	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("220")).
-		Bold(true)
-	
-	subtitleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("245"))
-	
-	var content strings.Builder
-	
-	// 标题
-	content.WriteString(titleStyle.Render("🐚 Select Shell"))
-	content.WriteString("\n")
-	content.WriteString(subtitleStyle.Render("Container: " + s.containerName))
-	content.WriteString("\n\n")
-	
-	if s.loading {
-		content.WriteString(subtitleStyle.Render("⏳ Detecting available shells..."))
-	} else if s.errorMsg != "" {
-		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-		content.WriteString(errorStyle.Render("❌ " + s.errorMsg))
-		content.WriteString("\n\n")
-		content.WriteString(subtitleStyle.Render("Press Esc to go back"))
-	} else {
-		// Shell 列表
-		for i, shell := range s.shells {
-			var line string
-			
-			// 数字快捷键
-			numStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
-			num := numStyle.Render(fmt.Sprintf("[%d]", i+1))
-			
-			// Shell 名称和描述
-			if i == s.selectedIdx {
-				// 选中状态
-				selectedStyle := lipgloss.NewStyle().
-					Foreground(lipgloss.Color("220")).
-					Bold(true)
-				descStyle := lipgloss.NewStyle().
-					Foreground(lipgloss.Color("252"))
-				
-				line = fmt.Sprintf("%s %s %s %s",
-					num,
-					selectedStyle.Render("▶"),
-					selectedStyle.Render(shell.Name),
-					descStyle.Render("("+shell.Description+")"),
-				)
-			} else {
-				// 未选中状态
-				nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
-				descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
-				
-				line = fmt.Sprintf("%s   %s %s",
-					num,
-					nameStyle.Render(shell.Name),
-					descStyle.Render("("+shell.Description+")"),
-				)
-			}
-			
-			content.WriteString(line)
-			content.WriteString("\n")
-		}
-		
-		// 底部提示
-		content.WriteString("\n")
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
-		keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
-		
-		hints := []string{
-			keyStyle.Render("↑/↓") + hintStyle.Render(" Select"),
-			keyStyle.Render("Enter") + hintStyle.Render(" Confirm"),
-			keyStyle.Render("1-6") + hintStyle.Render(" Quick select"),
-			keyStyle.Render("Esc") + hintStyle.Render(" Cancel"),
-		}
-		content.WriteString(hintStyle.Render(strings.Join(hints, "  ")))
-	}
-	
-	dialog := dialogStyle.Render(content.String())
-	
-	// 居中显示
-	return lipgloss.Place(
-		s.width,
-		s.height,
-		lipgloss.Center,
-		lipgloss.Center,
-		dialog,
-	)
+	return s.picker.View()
 }
 
 // IsLoading 是否正在加载
 func (s *ShellSelector) IsLoading() bool {
-	return s.loading
+	return s.picker.IsLoading()
 }
 
 // HasError 是否有错误
 func (s *ShellSelector) HasError() bool {
-	return s.errorMsg != ""
+	return s.picker.HasError()
 }
 
 // GetSelectedShell 获取选中的 Shell
 func (s *ShellSelector) GetSelectedShell() string {
-	if len(s.shells) > 0 && s.selectedIdx < len(s.shells) {
-		return s.shells[s.selectedIdx].Path
+	if item := s.picker.Current(); item != nil {
+		return item.ID
 	}
 	return ""
 }
@@ -1,7 +1,6 @@
 package components
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -10,6 +9,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/metrics"
 )
 
 // TimeGranularity 时间粒度
@@ -22,56 +23,53 @@ const (
 	Granularity30s                         // 30秒（最近30分钟，60个点）
 )
 
-// DataPoint 数据点
-type DataPoint struct {
-	Timestamp time.Time
-	Value     float64
-}
-
-// StatsView 资源监控视图组件
+// StatsView 资源监控视图组件（采样和速率计算由 metrics.Poller 完成）
 type StatsView struct {
-	dockerClient docker.Client
+	poller       *metrics.Poller
+	sub          <-chan metrics.Sample
 	containerID  string
 	width, height int
-	currentStats *docker.ContainerStats
-	cpuRawData, memoryRawData []DataPoint
+	currentStats *metrics.Sample
+	cpuSeries, memorySeries *metrics.Series
 	cpuHistory, memoryHistory []float64
 	granularity TimeGranularity
 	cpuChart, memoryChart *Sparkline
 	loading  bool
 	errorMsg string
 	active   bool
-	lastNetworkRx, lastNetworkTx uint64
-	lastBlockRead, lastBlockWrite uint64
-	lastStatsTime time.Time
-	networkRxRate, networkTxRate float64
-	blockReadRate, blockWriteRate float64
+	refresh  *RefreshTimer
 }
 
-// NewStatsView 创建资源监控视图
+// NewStatsView 创建资源监控视图，客户端不支持统计数据流时显示错误
 func NewStatsView(dockerClient docker.Client) *StatsView {
-	return &StatsView{
-		dockerClient:  dockerClient,
-		cpuRawData:    make([]DataPoint, 0, 1800),
-		memoryRawData: make([]DataPoint, 0, 1800),
+	v := &StatsView{
+		cpuSeries:     metrics.NewSeries(30 * time.Minute),
+		memorySeries:  metrics.NewSeries(30 * time.Minute),
 		cpuHistory:    make([]float64, 0, 60),
 		memoryHistory: make([]float64, 0, 60),
 		granularity:   Granularity1s,
 		cpuChart:      NewSparkline("CPU Usage", 60, 8),
 		memoryChart:   NewSparkline("Memory Usage", 60, 8),
+		refresh:       NewRefreshTimer(statsRefreshInterval),
 	}
+	if src, ok := dockerClient.(metrics.Source); ok {
+		v.poller = metrics.NewPoller(src)
+	}
+	return v
 }
 
-// SetContainer 设置容器
+// SetContainer 设置容器（取消对上一个容器的订阅，需要重新 Start）
 func (v *StatsView) SetContainer(containerID string) {
+	v.unsubscribe()
 	v.containerID = containerID
-	v.cpuRawData = make([]DataPoint, 0, 1800)
-	v.memoryRawData = make([]DataPoint, 0, 1800)
+	v.cpuSeries.Reset()
+	v.memorySeries.Reset()
 	v.cpuHistory = make([]float64, 0, 60)
 	v.memoryHistory = make([]float64, 0, 60)
 	v.currentStats = nil
-	v.lastStatsTime = time.Time{}
+	v.errorMsg = ""
 	v.granularity = Granularity1s
+	v.refresh.Reset()
 }
 
 // SetSize 设置尺寸
@@ -92,37 +90,51 @@ func (v *StatsView) SetSize(width, height int) {
 func (v *StatsView) Start() tea.Cmd {
 	v.active = true
 	v.loading = true
-	return v.fetchStats
+	v.refresh.SetInterval(statsRefreshInterval)
+	return v.subscribe()
 }
 
-// Stop 停止监控
-func (v *StatsView) Stop() { v.active = false }
+// Stop 停止监控并关闭统计数据流
+func (v *StatsView) Stop() {
+	v.active = false
+	v.unsubscribe()
+}
 
-// StatsLoadedMsg 统计数据加载完成消息
-type StatsLoadedMsg struct { Stats *docker.ContainerStats }
+// StatsLoadedMsg 收到一次采样
+type StatsLoadedMsg struct {
+	Sample metrics.Sample
+	sub    <-chan metrics.Sample
+}
 
 // StatsErrorMsg 统计数据加载错误消息
 type StatsErrorMsg struct { Err error }
 
-// StatsRefreshMsg 统计数据刷新消息
+// StatsRefreshMsg 数据流中断后重新订阅
 type StatsRefreshMsg struct{}
 
 // Update 处理消息
 func (v *StatsView) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case StatsLoadedMsg:
+		if msg.sub != v.sub { return nil } // 已取消的订阅
 		v.loading = false
+		if msg.Sample.Err != nil {
+			// 出错后通道会被关闭，稍后重新订阅（容器重启后继续显示）
+			v.errorMsg = msg.Sample.Err.Error()
+			v.sub = nil
+			if v.active { return v.scheduleRefresh() }
+			return nil
+		}
 		v.errorMsg = ""
-		v.updateStats(msg.Stats)
-		if v.active { return v.scheduleRefresh() }
-		return nil
+		v.updateStats(msg.Sample)
+		return waitSample(v.sub)
 	case StatsErrorMsg:
 		v.loading = false
 		v.errorMsg = msg.Err.Error()
 		if v.active { return v.scheduleRefresh() }
 		return nil
 	case StatsRefreshMsg:
-		if v.active { return v.fetchStats }
+		if v.active && v.sub == nil { return v.subscribe() }
 		return nil
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -135,42 +147,16 @@ func (v *StatsView) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
-// updateStats 更新统计数据
-func (v *StatsView) updateStats(stats *docker.ContainerStats) {
-	if stats == nil { return }
-	if !v.lastStatsTime.IsZero() {
-		elapsed := time.Since(v.lastStatsTime).Seconds()
-		if elapsed > 0 {
-			v.networkRxRate = float64(stats.NetworkRx-v.lastNetworkRx) / elapsed
-			v.networkTxRate = float64(stats.NetworkTx-v.lastNetworkTx) / elapsed
-			v.blockReadRate = float64(stats.BlockRead-v.lastBlockRead) / elapsed
-			v.blockWriteRate = float64(stats.BlockWrite-v.lastBlockWrite) / elapsed
-		}
-	}
-	v.lastNetworkRx = stats.NetworkRx
-	v.lastNetworkTx = stats.NetworkTx
-	v.lastBlockRead = stats.BlockRead
-	v.lastBlockWrite = stats.BlockWrite
-	v.lastStatsTime = time.Now()
-	v.currentStats = stats
-	now := time.Now()
-	v.cpuRawData = append(v.cpuRawData, DataPoint{Timestamp: now, Value: stats.CPUPercent})
-	memoryMB := float64(stats.MemoryUsage) / 1024 / 1024
-	v.memoryRawData = append(v.memoryRawData, DataPoint{Timestamp: now, Value: memoryMB})
-	cutoff := now.Add(-30 * time.Minute)
-	v.cpuRawData = v.cleanOldData(v.cpuRawData, cutoff)
-	v.memoryRawData = v.cleanOldData(v.memoryRawData, cutoff)
+// updateStats 记录一次采样；每个采样都计入历史，摘要和图表按刷新间隔更新
+func (v *StatsView) updateStats(sample metrics.Sample) {
+	v.cpuSeries.Add(sample.Time, sample.CPUPercent)
+	v.memorySeries.Add(sample.Time, float64(sample.MemoryUsage)/1024/1024)
+	if v.currentStats != nil && !v.refresh.Due() { return }
+	v.currentStats = &sample
+	v.refresh.Mark()
 	v.aggregateData()
 }
 
-// cleanOldData 清理过期数据
-func (v *StatsView) cleanOldData(data []DataPoint, cutoff time.Time) []DataPoint {
-	for i, point := range data {
-		if point.Timestamp.After(cutoff) { return data[i:] }
-	}
-	return []DataPoint{}
-}
-
 // setGranularity 设置时间粒度
 func (v *StatsView) setGranularity(g TimeGranularity) {
 	v.granularity = g
@@ -180,20 +166,22 @@ func (v *StatsView) setGranularity(g TimeGranularity) {
 // aggregateData 根据时间粒度聚合数据
 func (v *StatsView) aggregateData() {
 	var interval time.Duration
-	var maxPoints int
 	var timeRange string
 	switch v.granularity {
 	case Granularity1s:
-		interval, maxPoints, timeRange = 1*time.Second, 60, "1min"
+		interval, timeRange = 1*time.Second, "1min"
 	case Granularity5s:
-		interval, maxPoints, timeRange = 5*time.Second, 60, "5min"
+		interval, timeRange = 5*time.Second, "5min"
 	case Granularity10s:
-		interval, maxPoints, timeRange = 10*time.Second, 60, "10min"
+		interval, timeRange = 10*time.Second, "10min"
 	case Granularity30s:
-		interval, maxPoints, timeRange = 30*time.Second, 60, "30min"
+		interval, timeRange = 30*time.Second, "30min"
 	}
-	v.cpuHistory = v.aggregateDataPoints(v.cpuRawData, interval, maxPoints)
-	v.memoryHistory = v.aggregateDataPoints(v.memoryRawData, interval, maxPoints)
+	// 以最新采样的时间（守护进程的时钟）为终点，避免与本机时钟不一致
+	now := time.Now()
+	if v.currentStats != nil { now = v.currentStats.Time }
+	v.cpuHistory = v.cpuSeries.Buckets(now, interval, 60)
+	v.memoryHistory = v.memorySeries.Buckets(now, interval, 60)
 	v.cpuChart.SetData(v.cpuHistory)
 	v.cpuChart.Max = 100
 	v.cpuChart.Unit = "%"
@@ -208,34 +196,6 @@ func (v *StatsView) aggregateData() {
 	}
 }
 
-// aggregateDataPoints 聚合数据点
-func (v *StatsView) aggregateDataPoints(data []DataPoint, interval time.Duration, maxPoints int) []float64 {
-	if len(data) == 0 { return []float64{} }
-	result := make([]float64, 0, maxPoints)
-	now := time.Now()
-	startTime := now.Add(-time.Duration(maxPoints) * interval)
-	for i := 0; i < maxPoints; i++ {
-		bucketStart := startTime.Add(time.Duration(i) * interval)
-		bucketEnd := bucketStart.Add(interval)
-		var sum float64
-		var count int
-		for _, point := range data {
-			if point.Timestamp.After(bucketStart) && point.Timestamp.Before(bucketEnd) {
-				sum += point.Value
-				count++
-			}
-		}
-		if count > 0 {
-			result = append(result, sum/float64(count))
-		} else if len(result) > 0 {
-			result = append(result, result[len(result)-1])
-		} else {
-			result = append(result, 0)
-		}
-	}
-	return result
-}
-
 // Render 渲染视图
 func (v *StatsView) Render() string {
 	if v.loading && v.currentStats == nil { return v.renderLoading() }
@@ -263,8 +223,8 @@ func (v *StatsView) renderSummary() string {
 	cpuStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(cpuColor)).Bold(true)
 	memStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(memColor)).Bold(true)
 	cpuText := cpuStyle.Render(fmt.Sprintf("%.1f%%", stats.CPUPercent))
-	memUsed := FormatBytes(stats.MemoryUsage)
-	memLimit := FormatBytes(stats.MemoryLimit)
+	memUsed := format.CompactSize(stats.MemoryUsage)
+	memLimit := format.CompactSize(stats.MemoryLimit)
 	memText := memStyle.Render(fmt.Sprintf("%s / %s (%.1f%%)", memUsed, memLimit, stats.MemoryPercent))
 	line1 := labelStyle.Render("CPU: ") + cpuText + "    " + labelStyle.Render("Memory: ") + memText + "    " + labelStyle.Render("PIDs: ") + valueStyle.Render(fmt.Sprintf("%d", stats.PIDs))
 	granularityNames := []string{"1s", "5s", "10s", "30s"}
@@ -276,7 +236,7 @@ func (v *StatsView) renderSummary() string {
 			granularityHints = append(granularityHints, hintStyle.Render(fmt.Sprintf("[%d] %s", i+1, name)))
 		}
 	}
-	line2 := hintStyle.Render("Granularity: ") + strings.Join(granularityHints, "  ")
+	line2 := hintStyle.Render("Granularity: ") + strings.Join(granularityHints, "  ") + "    " + v.refresh.View()
 	
 	content := line1 + "\n" + line2
 	return "\n" + v.wrapInBox("Resource Overview", content, v.width-6)
@@ -329,10 +289,10 @@ func (v *StatsView) renderIOInfo() string {
 	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
 	rxStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
 	txStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
-	netRx := rxStyle.Render("↓ " + FormatBytesRate(v.networkRxRate))
-	netTx := txStyle.Render("↑ " + FormatBytesRate(v.networkTxRate))
-	blockR := rxStyle.Render("R " + FormatBytes(v.currentStats.BlockRead))
-	blockW := txStyle.Render("W " + FormatBytes(v.currentStats.BlockWrite))
+	netRx := rxStyle.Render("↓ " + format.Rate(v.currentStats.NetworkRxRate))
+	netTx := txStyle.Render("↑ " + format.Rate(v.currentStats.NetworkTxRate))
+	blockR := rxStyle.Render("R " + format.CompactSize(v.currentStats.BlockRead))
+	blockW := txStyle.Render("W " + format.CompactSize(v.currentStats.BlockWrite))
 	content := labelStyle.Render("Network I/O: ") + netRx + "  " + netTx + "    " + labelStyle.Render("Disk I/O: ") + blockR + "  " + blockW
 	
 	return v.wrapInBox("I/O Stats", content, v.width-6)
@@ -353,38 +313,35 @@ func (v *StatsView) renderEmpty() string {
 	return "\n" + style.Render("📊 Waiting for data...")
 }
 
-// fetchStats 获取统计数据
-func (v *StatsView) fetchStats() tea.Msg {
-	if v.containerID == "" { return StatsErrorMsg{Err: fmt.Errorf("container ID is empty")} }
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	stats, err := v.dockerClient.ContainerStats(ctx, v.containerID)
-	if err != nil { return StatsErrorMsg{Err: err} }
-	return StatsLoadedMsg{Stats: stats}
+// subscribe 订阅当前容器的采样
+func (v *StatsView) subscribe() tea.Cmd {
+	if v.sub != nil { return nil }
+	if v.containerID == "" {
+		return func() tea.Msg { return StatsErrorMsg{Err: fmt.Errorf("container ID is empty")} }
+	}
+	if v.poller == nil {
+		return func() tea.Msg { return StatsErrorMsg{Err: fmt.Errorf("resource stats are not available for this connection")} }
+	}
+	v.sub = v.poller.Subscribe(v.containerID)
+	return waitSample(v.sub)
 }
 
-// scheduleRefresh 安排下次刷新
-func (v *StatsView) scheduleRefresh() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return StatsRefreshMsg{} })
+// unsubscribe 取消订阅
+func (v *StatsView) unsubscribe() {
+	if v.sub != nil && v.poller != nil { v.poller.Unsubscribe(v.sub) }
+	v.sub = nil
 }
 
-// FormatBytes 格式化字节数
-func FormatBytes(bytes uint64) string {
-	const (KB, MB, GB = 1024, 1024 * 1024, 1024 * 1024 * 1024)
-	switch {
-	case bytes >= GB: return fmt.Sprintf("%.1fGB", float64(bytes)/float64(GB))
-	case bytes >= MB: return fmt.Sprintf("%.1fMB", float64(bytes)/float64(MB))
-	case bytes >= KB: return fmt.Sprintf("%.1fKB", float64(bytes)/float64(KB))
-	default: return fmt.Sprintf("%dB", bytes)
+// waitSample 等待下一次采样，通道被取消订阅关闭时不产生消息
+func waitSample(sub <-chan metrics.Sample) tea.Cmd {
+	return func() tea.Msg {
+		sample, ok := <-sub
+		if !ok { return nil }
+		return StatsLoadedMsg{Sample: sample, sub: sub}
 	}
 }
 
-// FormatBytesRate 格式化字节速率
-func FormatBytesRate(bytesPerSec float64) string {
-	const (KB, MB = 1024.0, 1024.0 * 1024.0)
-	switch {
-	case bytesPerSec >= MB: return fmt.Sprintf("%.1fMB/s", bytesPerSec/MB)
-	case bytesPerSec >= KB: return fmt.Sprintf("%.1fKB/s", bytesPerSec/KB)
-	default: return fmt.Sprintf("%.0fB/s", bytesPerSec)
-	}
+// scheduleRefresh 数据流中断后稍后重新订阅
+func (v *StatsView) scheduleRefresh() tea.Cmd {
+	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return StatsRefreshMsg{} })
 }
@@ -1,11 +1,14 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/task"
 )
 
 // TagInputView 镜像打标签输入框
@@ -17,6 +20,11 @@ type TagInputView struct {
 	visible       bool
 	width         int
 	focusIndex    int
+
+	// 批量模式：为多选的镜像按模板生成目标，只有一个目标输入框（tagInput 不使用）
+	batch   int
+	example [3]string // 用于预览的镜像：仓库、标签、ID
+	errMsg  string
 }
 
 var (
@@ -72,6 +80,33 @@ func (v *TagInputView) Show(sourceImage, sourceImageID, sourceRepo, sourceTag st
 	v.focusIndex = 0
 	v.repoInput.Focus()
 	v.tagInput.Blur()
+	v.batch = 0
+	v.errMsg = ""
+	v.repoInput.Placeholder = "myrepo/image"
+}
+
+// ShowBatch 显示批量打标签输入框，目标是一个模板（如 myreg.local/{{repo}}:{{tag}}），
+// 预览使用第一个选中的镜像
+func (v *TagInputView) ShowBatch(count int, exampleRepo, exampleTag, exampleID string) {
+	v.Show(exampleRepo+":"+exampleTag, exampleID, "", "")
+	v.batch = count
+	v.example = [3]string{exampleRepo, exampleTag, exampleID}
+	v.repoInput.Placeholder = "myreg.local/{{repo}}:{{tag}}"
+}
+
+// IsBatch 是否为批量模式
+func (v *TagInputView) IsBatch() bool {
+	return v.batch > 0
+}
+
+// Template 返回批量模式下输入的目标模板
+func (v *TagInputView) Template() string {
+	return strings.TrimSpace(v.repoInput.Value())
+}
+
+// SetError 显示错误（如模板对某个镜像生成的目标无效），输入框保持打开
+func (v *TagInputView) SetError(msg string) {
+	v.errMsg = msg
 }
 
 // Hide 隐藏输入框
@@ -121,7 +156,8 @@ func (v *TagInputView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
 		keyStr := msg.String()
 
 		if msg.Type == tea.KeyEnter || keyStr == "enter" {
-			if v.focusIndex == 3 {
+			// 批量模式只有一个输入框，在输入框中回车直接确认
+			if v.focusIndex == 3 || (v.IsBatch() && v.focusIndex == 0) {
 				repo, _ := v.GetValues()
 				if repo != "" {
 					return true, true, nil
@@ -178,6 +214,7 @@ func (v *TagInputView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
 
 	var cmd tea.Cmd
 	if v.focusIndex == 0 {
+		v.errMsg = ""
 		v.repoInput, cmd = v.repoInput.Update(msg)
 	} else if v.focusIndex == 1 {
 		v.tagInput, cmd = v.tagInput.Update(msg)
@@ -188,11 +225,17 @@ func (v *TagInputView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
 
 func (v *TagInputView) nextFocus() {
 	v.focusIndex = (v.focusIndex + 1) % 4
+	if v.focusIndex == 1 && v.IsBatch() {
+		v.focusIndex = 2
+	}
 	v.updateInputFocus()
 }
 
 func (v *TagInputView) prevFocus() {
 	v.focusIndex = (v.focusIndex + 3) % 4
+	if v.focusIndex == 1 && v.IsBatch() {
+		v.focusIndex = 0
+	}
 	v.updateInputFocus()
 }
 
@@ -215,6 +258,10 @@ func (v *TagInputView) View() string {
 		return ""
 	}
 
+	if v.IsBatch() {
+		return v.batchView()
+	}
+
 	title := tagInputTitleStyle.Render("🏷️  Tag Image")
 
 	sourceInfo := tagInputLabelStyle.Render("Source:") + " " +
@@ -282,8 +329,54 @@ func (v *TagInputView) View() string {
 		boxWidth = 70
 	}
 
-	box := tagInputBoxStyle.Width(boxWidth).Render(content)
+	return v.center(tagInputBoxStyle.Width(boxWidth).Render(content), boxWidth)
+}
+
+// batchView 渲染批量打标签输入框：目标模板、可用占位符和第一个镜像的预览
+func (v *TagInputView) batchView() string {
+	title := tagInputTitleStyle.Render(fmt.Sprintf("🏷️  Tag %d Images", v.batch))
+
+	targetStyle := lipgloss.NewStyle()
+	if v.focusIndex == 0 {
+		targetStyle = targetStyle.Foreground(lipgloss.Color("81"))
+	}
+	targetLine := tagInputLabelStyle.Render("Target:") + " " + targetStyle.Render(v.repoInput.View())
+	placeholders := tagInputLabelStyle.Render("") + " " + tagInputHintStyle.Render(strings.Join(task.ImageTargetPlaceholders, " "))
+
+	contentParts := []string{title, "", targetLine, placeholders}
+	if v.errMsg != "" {
+		contentParts = append(contentParts, "", lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+v.errMsg))
+	} else if tmpl := v.Template(); tmpl != "" {
+		if target, err := task.RenderImageTarget(tmpl, v.example[0], v.example[1], v.example[2]); err == nil {
+			contentParts = append(contentParts, "", tagInputHintStyle.Render("e.g. ")+
+				tagInputSourceStyle.Render(v.sourceImage+" → "+target))
+		}
+	}
+
+	cancelBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	okBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	if v.focusIndex == 2 {
+		cancelBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	if v.focusIndex == 3 {
+		okBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	buttons := cancelBtnStyle.Render("< Cancel >") + "    " + okBtnStyle.Render("< Confirm >")
+	hints := tagInputHintStyle.Render("[Tab/↑↓=Switch] [Enter=Confirm] [Esc=Cancel]")
+	contentParts = append(contentParts, "", buttons, "", hints)
+
+	boxWidth := v.width - 10
+	if boxWidth < 55 {
+		boxWidth = 55
+	}
+	if boxWidth > 70 {
+		boxWidth = 70
+	}
+	return v.center(tagInputBoxStyle.Width(boxWidth).Render(lipgloss.JoinVertical(lipgloss.Left, contentParts...)), boxWidth)
+}
 
+// center 终端足够宽时水平居中
+func (v *TagInputView) center(box string, boxWidth int) string {
 	if v.width > boxWidth+10 {
 		leftPadding := (v.width - boxWidth - 4) / 2
 		lines := strings.Split(box, "\n")
@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"docktui/internal/format"
 	"docktui/internal/task"
 )
 
@@ -16,6 +17,7 @@ type TaskBar struct {
 	expanded bool
 	width    int
 	events   <-chan task.Event
+	picker   taskPicker
 }
 
 // 任务栏样式
@@ -78,16 +80,6 @@ func (t *TaskBar) HasActiveTasks() bool {
 	return len(t.manager.ListActiveTasks()) > 0
 }
 
-// CancelFirstTask 取消第一个活跃任务
-func (t *TaskBar) CancelFirstTask() bool {
-	tasks := t.manager.ListActiveTasks()
-	if len(tasks) == 0 {
-		return false
-	}
-	t.manager.Cancel(tasks[0].ID())
-	return true
-}
-
 // CancelAllTasks 取消所有活跃任务
 func (t *TaskBar) CancelAllTasks() int {
 	tasks := t.manager.ListActiveTasks()
@@ -163,7 +155,7 @@ func (t *TaskBar) renderCollapsed(tasks []task.Task, width int) string {
 		line += "  " + taskBarHintStyle.Render(message)
 	}
 
-	line += "  " + taskBarHintStyle.Render("[T=Expand]") + " " + taskBarCancelStyle.Render("[x=Cancel]")
+	line += "  " + taskBarHintStyle.Render("[T=Expand]") + " " + taskBarCancelStyle.Render("[x=Cancel/Pause]")
 
 	separator := taskBarLineStyle.Render(strings.Repeat("─", width))
 
@@ -174,7 +166,7 @@ func (t *TaskBar) renderExpanded(tasks []task.Task, width int) string {
 	var lines []string
 
 	title := taskBarIconStyle.Render(fmt.Sprintf("Background Tasks (%d)", len(tasks))) +
-		"  " + taskBarHintStyle.Render("[T=Collapse]") + " " + taskBarCancelStyle.Render("[x=Cancel]")
+		"  " + taskBarHintStyle.Render("[T=Collapse]") + " " + taskBarCancelStyle.Render("[x=Cancel/Pause]")
 	lines = append(lines, title)
 
 	innerWidth := width - 6
@@ -209,6 +201,9 @@ func (t *TaskBar) renderTaskDetail(tsk task.Task, width int) string {
 	case task.StatusPending:
 		icon = "⏳"
 	}
+	if p, ok := tsk.(task.Pausable); ok && p.Paused() {
+		icon = "⏸️"
+	}
 
 	barWidth := 25
 	filled := int(progress / 100 * float64(barWidth))
@@ -234,23 +229,12 @@ func (t *TaskBar) renderTaskDetail(tsk task.Task, width int) string {
 		if status == task.StatusFailed {
 			msgStyle = taskBarErrorStyle
 		}
-		line += "\n   └─ " + msgStyle.Render(TruncateString(message, width-10))
+		line += "\n   └─ " + msgStyle.Render(format.Truncate(message, width-10))
 	}
 
 	return line
 }
 
-// TruncateString 截断字符串
-func TruncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	if maxLen <= 3 {
-		return s[:maxLen]
-	}
-	return s[:maxLen-3] + "..."
-}
-
 // TaskEventMsg 任务事件消息（用于 Bubble Tea）
 type TaskEventMsg struct {
 	Event task.Event
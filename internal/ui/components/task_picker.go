@@ -0,0 +1,207 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/format"
+	"docktui/internal/task"
+)
+
+// taskPicker 任务栏的任务选择对话框：选择要取消或暂停的后台任务
+type taskPicker struct {
+	visible bool
+	tasks   []task.Task // 打开时的活跃任务，按提交顺序
+	cursor  int
+	confirm string // 非空时正在确认取消选中的任务，内容为取消的后果说明
+}
+
+// ShowPicker 打开任务选择对话框，没有活跃任务时返回 false
+func (t *TaskBar) ShowPicker() bool {
+	t.reloadPicker()
+	if len(t.picker.tasks) == 0 {
+		return false
+	}
+	t.picker.visible = true
+	t.picker.cursor = 0
+	t.picker.confirm = ""
+	return true
+}
+
+// IsPickerVisible 任务选择对话框是否可见
+func (t *TaskBar) IsPickerVisible() bool {
+	return t.picker.visible
+}
+
+// reloadPicker 按提交顺序重新读取活跃任务，已结束的任务从列表中移除
+func (t *TaskBar) reloadPicker() {
+	history := t.manager.ListHistory()
+	tasks := make([]task.Task, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		if status := history[i].Status(); status == task.StatusPending || status == task.StatusRunning {
+			tasks = append(tasks, history[i])
+		}
+	}
+	t.picker.tasks = tasks
+	if t.picker.cursor >= len(tasks) {
+		t.picker.cursor = len(tasks) - 1
+	}
+	if t.picker.cursor < 0 {
+		t.picker.cursor = 0
+	}
+}
+
+func (t *TaskBar) pickerCurrent() task.Task {
+	if t.picker.cursor < 0 || t.picker.cursor >= len(t.picker.tasks) {
+		return nil
+	}
+	return t.picker.tasks[t.picker.cursor]
+}
+
+// UpdatePicker 处理对话框按键，返回给宿主视图显示的结果消息（为空表示没有执行操作）
+func (t *TaskBar) UpdatePicker(msg tea.KeyMsg) string {
+	if t.picker.confirm != "" {
+		switch msg.String() {
+		case "y", "Y", "enter":
+			t.picker.confirm = ""
+			return t.cancelPicked()
+		case "n", "N", "esc":
+			t.picker.confirm = ""
+		}
+		return ""
+	}
+
+	t.reloadPicker()
+	switch msg.String() {
+	case "esc", "q":
+		t.picker.visible = false
+	case "j", "down":
+		if t.picker.cursor < len(t.picker.tasks)-1 {
+			t.picker.cursor++
+		}
+	case "k", "up":
+		if t.picker.cursor > 0 {
+			t.picker.cursor--
+		}
+	case "x", "enter":
+		tsk := t.pickerCurrent()
+		if tsk == nil {
+			return ""
+		}
+		if w, ok := tsk.(task.CancelWarner); ok {
+			if warning := w.CancelWarning(); warning != "" {
+				t.picker.confirm = warning
+				return ""
+			}
+		}
+		return t.cancelPicked()
+	case "p", " ":
+		tsk := t.pickerCurrent()
+		if tsk == nil {
+			return ""
+		}
+		p, ok := tsk.(task.Pausable)
+		if !ok {
+			return "💡 " + tsk.Name() + " cannot be paused"
+		}
+		paused := !p.Paused()
+		t.manager.SetPaused(tsk.ID(), paused)
+		if paused {
+			return "⏸️ Paused " + tsk.Name()
+		}
+		return "▶️ Resumed " + tsk.Name()
+	}
+	if len(t.picker.tasks) == 0 {
+		t.picker.visible = false
+	}
+	return ""
+}
+
+// cancelPicked 取消选中的任务，取消后列表为空时关闭对话框
+func (t *TaskBar) cancelPicked() string {
+	tsk := t.pickerCurrent()
+	if tsk == nil {
+		return ""
+	}
+	t.manager.Cancel(tsk.ID())
+	t.reloadPicker()
+	if len(t.picker.tasks) == 0 {
+		t.picker.visible = false
+	}
+	return "⏹️ Cancelling " + tsk.Name() + "..."
+}
+
+// PickerView 渲染任务选择对话框（由宿主视图居中叠加）
+func (t *TaskBar) PickerView() string {
+	if !t.picker.visible {
+		return ""
+	}
+	dialogWidth := 70
+	if t.width-4 < dialogWidth {
+		dialogWidth = t.width - 4
+	}
+	if dialogWidth < 50 {
+		dialogWidth = 50
+	}
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63")).
+		Padding(1, 2).
+		Width(dialogWidth)
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("Background Tasks (%d)", len(t.picker.tasks))))
+	content.WriteString("\n\n")
+
+	innerWidth := dialogWidth - 6
+	for i, tsk := range t.picker.tasks {
+		state := fmt.Sprintf("%5.1f%%", tsk.Progress())
+		if p, ok := tsk.(task.Pausable); ok && p.Paused() {
+			state = "paused"
+		} else if tsk.Status() == task.StatusPending {
+			state = "queued"
+		}
+		line := fmt.Sprintf("%-6s  %s", state, format.Truncate(tsk.Name(), innerWidth-10))
+		if i == t.picker.cursor {
+			line = selectedStyle.Render("▶ " + line)
+		} else {
+			line = "  " + taskBarNameStyle.Render(line)
+		}
+		content.WriteString(line + "\n")
+		if message := tsk.Message(); message != "" {
+			content.WriteString("    " + taskBarHintStyle.Render(format.Truncate(message, innerWidth-4)) + "\n")
+		}
+	}
+	content.WriteString("\n")
+
+	if t.picker.confirm != "" {
+		name := ""
+		if tsk := t.pickerCurrent(); tsk != nil {
+			name = tsk.Name()
+		}
+		content.WriteString(taskBarCancelStyle.Render("Cancel "+format.Truncate(name, innerWidth-8)+"?") + "\n")
+		content.WriteString(taskBarErrorStyle.Render("⚠ "+t.picker.confirm) + "\n\n")
+		content.WriteString(joinHints([]string{
+			keyStyle.Render("y") + taskBarHintStyle.Render(" Cancel task"),
+			keyStyle.Render("n") + taskBarHintStyle.Render(" Keep running"),
+		}, innerWidth))
+	} else {
+		hints := []string{
+			keyStyle.Render("j/k") + taskBarHintStyle.Render(" Select"),
+			keyStyle.Render("x") + taskBarHintStyle.Render(" Cancel"),
+		}
+		if _, ok := t.pickerCurrent().(task.Pausable); ok {
+			hints = append(hints, keyStyle.Render("p")+taskBarHintStyle.Render(" Pause/Resume"))
+		}
+		hints = append(hints, keyStyle.Render("Esc")+taskBarHintStyle.Render(" Close"))
+		content.WriteString(joinHints(hints, innerWidth))
+	}
+
+	return dialogStyle.Render(content.String())
+}
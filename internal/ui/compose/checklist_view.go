@@ -0,0 +1,143 @@
+package compose
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ChecklistView 多选对话框，用于选择 compose profiles 和 -f 文件
+type ChecklistView struct {
+	visible  bool
+	width    int
+	cursor   int
+	title    string
+	note     string
+	items    []string
+	hints    map[string]string // 选项 -> 说明
+	selected map[string]bool
+}
+
+// NewChecklistView 创建多选对话框
+func NewChecklistView() *ChecklistView {
+	return &ChecklistView{}
+}
+
+// Show 显示对话框，active 为当前选中的选项
+func (v *ChecklistView) Show(title, note string, items []string, hints map[string]string, active []string) {
+	v.visible = true
+	v.cursor = 0
+	v.title = title
+	v.note = note
+	v.items = items
+	v.hints = hints
+	v.selected = make(map[string]bool)
+	for _, item := range active {
+		v.selected[item] = true
+	}
+}
+
+// Hide 隐藏对话框
+func (v *ChecklistView) Hide() {
+	v.visible = false
+}
+
+// IsVisible 是否可见
+func (v *ChecklistView) IsVisible() bool {
+	return v.visible
+}
+
+// SetWidth 设置宽度
+func (v *ChecklistView) SetWidth(width int) {
+	v.width = width
+}
+
+// Selected 返回选中的选项（保持选项原有顺序）
+func (v *ChecklistView) Selected() []string {
+	var selected []string
+	for _, item := range v.items {
+		if v.selected[item] {
+			selected = append(selected, item)
+		}
+	}
+	return selected
+}
+
+// Update 处理按键
+// 返回值: (applied bool, handled bool)
+func (v *ChecklistView) Update(msg tea.KeyMsg) (bool, bool) {
+	if !v.visible {
+		return false, false
+	}
+
+	switch msg.String() {
+	case "esc":
+		v.Hide()
+	case "enter":
+		v.Hide()
+		return true, true
+	case "j", "down":
+		if v.cursor < len(v.items)-1 {
+			v.cursor++
+		}
+	case "k", "up":
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case " ", "x":
+		if len(v.items) > 0 {
+			item := v.items[v.cursor]
+			v.selected[item] = !v.selected[item]
+		}
+	case "a":
+		// 全部选中时清空，否则全选
+		all := len(v.Selected()) == len(v.items)
+		for _, item := range v.items {
+			v.selected[item] = !all
+		}
+	}
+	return false, true
+}
+
+// View 渲染对话框
+func (v *ChecklistView) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	boxWidth := v.width - 20
+	if boxWidth < 50 {
+		boxWidth = 50
+	}
+	if boxWidth > 80 {
+		boxWidth = 80
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	lines := []string{ConfigTitleStyle.Render(v.title), ""}
+	for i, item := range v.items {
+		check := "[ ]"
+		if v.selected[item] {
+			check = StatusRunningStyle.Render("[✓]")
+		}
+		name := ValueStyle.Render(item)
+		prefix := "  "
+		if i == v.cursor {
+			prefix = FooterKeyStyle.Render("▶ ")
+			name = TabActiveStyle.UnderlineSpaces(false).Render(item)
+		}
+		hint := v.hints[item]
+		maxLen := boxWidth - lipgloss.Width(item) - 14
+		if maxLen > 3 && len(hint) > maxLen {
+			hint = hint[:maxLen-3] + "..."
+		}
+		lines = append(lines, prefix+check+" "+name+"  "+hintStyle.Render(hint))
+	}
+	if v.note != "" {
+		lines = append(lines, "", hintStyle.Render(v.note))
+	}
+	lines = append(lines, "", hintStyle.Render("[Space=Toggle] [a=All/None] [Enter=Apply] [Esc=Cancel]"))
+
+	return BoxFocusedStyle.Width(boxWidth).Padding(1, 2).Render(strings.Join(lines, "\n"))
+}
@@ -2,9 +2,11 @@ package compose
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,6 +15,10 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	composelib "docktui/internal/compose"
+	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/ui/components"
+	"docktui/internal/ui/styles"
 )
 
 // Tab 索引常量
@@ -23,6 +29,12 @@ const (
 	tabInfo
 )
 
+// 多选对话框用途
+const (
+	checklistProfiles = iota
+	checklistFiles
+)
+
 // 布局常量
 const (
 	detailHeaderHeight   = 2
@@ -36,6 +48,7 @@ const (
 // DetailView Compose 项目详情视图
 type DetailView struct {
 	composeClient composelib.Client
+	dockerClient  docker.Client // 用于拉取服务镜像
 
 	width  int
 	height int
@@ -59,16 +72,43 @@ type DetailView struct {
 
 	serviceTable table.Model
 
+	// 与 compose config 的差异检查结果，nil 表示未检查
+	drift         map[string]composelib.ServiceDrift
+	driftChecking bool
+
+	// 合并后的配置（docker compose config）
+	mergedConfig string
+	showMerged   bool
+
+	// compose 文件中定义的 profiles
+	serviceProfiles map[string][]string // 服务 -> 所属 profiles
+
+	// profiles / -f 文件多选对话框
+	checklist     *ChecklistView
+	checklistMode int
+
 	operatingService string
 	operationType    string
 	
 	// 操作日志视图
 	operationLogView *OperationLogView
 	operationStream  *composelib.OperationStream
+
+	// 后台任务栏
+	taskBar *components.TaskBar
+
+	// 进行中的服务镜像拉取
+	pullBatch *pullBatch
+
+	// 滚动重启时每个服务等待恢复健康的时长，0 表示默认
+	healthTimeout time.Duration
+
+	// 服务表格的 CPU / 内存列，nil 表示客户端不支持统计数据流
+	stats *serviceStats
 }
 
 // NewDetailView 创建 Compose 详情视图
-func NewDetailView(composeClient composelib.Client) *DetailView {
+func NewDetailView(composeClient composelib.Client, dockerClient docker.Client) *DetailView {
 	t := table.New(
 		table.WithColumns([]table.Column{}),
 		table.WithFocused(true),
@@ -89,17 +129,33 @@ func NewDetailView(composeClient composelib.Client) *DetailView {
 
 	return &DetailView{
 		composeClient:    composeClient,
+		dockerClient:     dockerClient,
 		serviceTable:     t,
 		currentTab:       tabServices,
 		configFocusLeft:  true,
 		operationLogView: NewOperationLogView(),
+		checklist:        NewChecklistView(),
+		taskBar:          components.NewTaskBar(),
+		stats:            newServiceStats(dockerClient),
 	}
 }
 
+// SetHealthTimeout 设置滚动重启时每个服务等待恢复健康的时长
+func (v *DetailView) SetHealthTimeout(timeout time.Duration) {
+	v.healthTimeout = timeout
+}
+
 // SetProject 设置要查看的项目
 func (v *DetailView) SetProject(project *composelib.Project) {
+	v.StopStats()
 	v.project = project
 	v.services = project.Services
+	v.serviceProfiles = nil
+	v.checklist.Hide()
+	v.mergedConfig = ""
+	v.showMerged = false
+	v.drift = nil
+	v.driftChecking = false
 	v.envContent = ""
 	v.ymlContent = ""
 	v.envFileName = ""
@@ -118,7 +174,12 @@ func (v *DetailView) Init() tea.Cmd {
 		return nil
 	}
 	v.loading = true
-	return tea.Batch(v.refreshServices, v.detectConfigFiles)
+	cmds := []tea.Cmd{v.refreshServices, v.detectConfigFiles}
+	// 离开视图期间拉取仍在进行时恢复进度刷新
+	if v.pullBatch != nil {
+		cmds = append(cmds, v.tickPullBatch())
+	}
+	return tea.Batch(cmds...)
 }
 
 // detectConfigFiles 检测配置文件
@@ -162,11 +223,16 @@ func (v *DetailView) Update(msg tea.Msg) tea.Cmd {
 			v.errorMsg = fmt.Sprintf("Failed to refresh services: %v", msg.err)
 		} else {
 			v.services = msg.services
+			v.serviceProfiles = msg.profiles
 			v.updateServiceTable()
 			v.errorMsg = ""
+			return v.startStats()
 		}
 		return nil
 
+	case detailStatsTickMsg:
+		return v.updateStats(msg)
+
 	case detailConfigFilesMsg:
 		v.loading = false
 		if msg.err != nil {
@@ -182,6 +248,30 @@ func (v *DetailView) Update(msg tea.Msg) tea.Cmd {
 		}
 		return nil
 
+	case detailDriftMsg:
+		v.driftChecking = false
+		if msg.err != nil {
+			v.drift = nil
+			v.errorMsg = fmt.Sprintf("Drift check failed: %v", msg.err)
+			return nil
+		}
+		v.drift = make(map[string]composelib.ServiceDrift, len(msg.drift))
+		for _, d := range msg.drift {
+			v.drift[d.Service] = d
+		}
+		v.updateServiceTable()
+		return nil
+
+	case detailMergedConfigMsg:
+		if msg.err != nil {
+			v.mergedConfig = ""
+			v.showMerged = false
+			v.errorMsg = fmt.Sprintf("Failed to load merged config: %v", msg.err)
+			return nil
+		}
+		v.mergedConfig = msg.content
+		return nil
+
 	case detailOperationMsg:
 		v.operatingService = ""
 		v.operationType = ""
@@ -191,11 +281,16 @@ func (v *DetailView) Update(msg tea.Msg) tea.Cmd {
 		} else {
 			v.successMsg = msg.message
 			v.errorMsg = ""
+			v.drift = nil
 			return v.refreshServices
 		}
 		return nil
 
 	case detailOperationLogMsg:
+		// 其他视图发起的操作
+		if msg.stream != v.operationStream {
+			return nil
+		}
 		// 追加日志行
 		if v.operationLogView != nil {
 			v.operationLogView.AppendLog(msg.line)
@@ -204,6 +299,9 @@ func (v *DetailView) Update(msg tea.Msg) tea.Cmd {
 		return v.continueListenOperationStream()
 
 	case detailOperationDoneMsg:
+		if msg.stream != v.operationStream {
+			return nil
+		}
 		// 操作完成
 		if v.operationLogView != nil && msg.result != nil {
 			v.operationLogView.SetComplete(msg.result.Success, msg.result.Message)
@@ -211,6 +309,7 @@ func (v *DetailView) Update(msg tea.Msg) tea.Cmd {
 		v.operatingService = ""
 		v.operationType = ""
 		v.operationStream = nil
+		v.drift = nil
 		// 刷新服务列表
 		return v.refreshServices
 
@@ -219,7 +318,36 @@ func (v *DetailView) Update(msg tea.Msg) tea.Cmd {
 		v.errorMsg = ""
 		return nil
 
+	case detailPullImagesMsg:
+		return v.submitPulls(msg)
+
+	case detailPullTickMsg:
+		return v.updatePullBatch()
+
 	case tea.KeyMsg:
+		// 取消进行中的流式操作
+		if msg.String() == "x" && v.operationStream != nil && !v.operationLogView.IsCapturingInput() {
+			v.operationStream.Cancel()
+			v.successMsg = "⏹️ Cancelling operation..."
+			return nil
+		}
+
+		// 后台任务选择对话框可见时，按键只交给对话框
+		if v.taskBar.IsPickerVisible() {
+			if notice := v.taskBar.UpdatePicker(msg); notice != "" {
+				v.successMsg = notice
+				return v.clearMessageAfter(3)
+			}
+			return nil
+		}
+
+		if applied, handled := v.checklist.Update(msg); handled {
+			if applied {
+				return v.applyChecklist()
+			}
+			return nil
+		}
+
 		// 如果操作日志视图可见，优先处理
 		if v.operationLogView != nil && v.operationLogView.IsVisible() {
 			if v.operationLogView.Update(msg) {
@@ -257,6 +385,9 @@ func (v *DetailView) Update(msg tea.Msg) tea.Cmd {
 			return nil
 		case "2":
 			v.currentTab = tabConfig
+			if v.showMerged && v.mergedConfig == "" {
+				return tea.Batch(v.loadConfigFiles, v.loadMergedConfig)
+			}
 			return v.loadConfigFiles
 		case "3":
 			v.currentTab = tabLogs
@@ -268,27 +399,108 @@ func (v *DetailView) Update(msg tea.Msg) tea.Cmd {
 		case "R", "f5":
 			v.loading = true
 			if v.currentTab == tabConfig {
+				if v.showMerged {
+					v.mergedConfig = ""
+					return tea.Batch(v.loadConfigFiles, v.loadMergedConfig)
+				}
 				return v.loadConfigFiles
 			}
 			return v.refreshServices
 
 		case "u":
 			if v.currentTab == tabServices {
-				return v.startServiceOperation("start")
+				return components.RunAction("compose.start", func() tea.Cmd { return v.startServiceOperation("start") })
 			}
 		case "s":
 			if v.currentTab == tabServices {
-				return v.startServiceOperation("stop")
+				return components.RunAction("compose.stop", func() tea.Cmd { return v.startServiceOperation("stop") })
 			}
 		case "r":
 			if v.currentTab == tabServices {
-				return v.startServiceOperation("restart")
+				return components.RunAction("compose.restart", func() tea.Cmd { return v.startServiceOperation("restart") })
+			}
+
+		case "P":
+			profiles := composelib.ProfileNames(v.serviceProfiles)
+			if len(profiles) == 0 {
+				v.errorMsg = "No profiles defined in compose file"
+				return v.clearMessageAfter(3)
+			}
+			members := make(map[string]string)
+			for _, p := range profiles {
+				var services []string
+				for svc, list := range v.serviceProfiles {
+					if profileActive(list, []string{p}) {
+						services = append(services, svc)
+					}
+				}
+				sort.Strings(services)
+				members[p] = strings.Join(services, ", ")
+			}
+			v.checklistMode = checklistProfiles
+			v.checklist.SetWidth(v.width)
+			v.checklist.Show("🎛️  Compose Profiles", "Services without profiles always start; selected profiles are passed as --profile",
+				profiles, members, v.project.Profiles)
+			return nil
+
+		case "F":
+			files := composelib.ComposeFileCandidates(v.project)
+			if len(files) == 0 {
+				v.errorMsg = "No compose files found in project directory"
+				return v.clearMessageAfter(3)
+			}
+			hints := make(map[string]string)
+			for _, f := range files {
+				hints[f] = "override"
+				if isBaseComposeFile(f) {
+					hints[f] = "base"
+				}
+			}
+			active := make([]string, len(v.project.ComposeFiles))
+			for i, f := range v.project.ComposeFiles {
+				active[i] = composelib.ComposeFileName(v.project, f)
+			}
+			v.checklistMode = checklistFiles
+			v.checklist.SetWidth(v.width)
+			v.checklist.Show("📄 Compose Files", "Selected files are passed as -f in the order shown (later files override earlier ones)",
+				files, hints, active)
+			return nil
+
+		case "p":
+			if v.currentTab == tabServices {
+				return components.RunAction("image.pull", v.startPullImages)
+			}
+
+		case "c":
+			if v.currentTab == tabServices && !v.driftChecking {
+				v.driftChecking = true
+				v.errorMsg = ""
+				return v.checkDrift
+			}
+
+		case "m":
+			if v.currentTab == tabConfig {
+				v.showMerged = !v.showMerged
+				v.ymlScrollOffset = 0
+				if v.showMerged && v.mergedConfig == "" {
+					return v.loadMergedConfig
+				}
+				return nil
 			}
 
 		case "U":
-			return v.startProjectOperation("up")
+			return components.RunAction("compose.up", func() tea.Cmd { return v.startProjectOperation("up") })
 		case "D":
-			return v.startProjectOperation("down")
+			return components.RunAction("compose.down", func() tea.Cmd { return v.startProjectOperation("down") })
+		case "O":
+			return components.RunAction("compose.restart", v.startRollingRestart)
+
+		case "x":
+			v.taskBar.ShowPicker()
+			return nil
+		case "T":
+			v.taskBar.Toggle()
+			return nil
 
 		case "enter":
 			if v.currentTab == tabServices {
@@ -301,10 +513,16 @@ func (v *DetailView) Update(msg tea.Msg) tea.Cmd {
 				return v.viewContainerLogs()
 			}
 
+		case "A":
+			// 按时间戳合并查看所有服务的日志
+			if v.currentTab == tabServices {
+				return v.viewMergedLogs()
+			}
+
 		case "S":
 			// 进入容器 Shell
 			if v.currentTab == tabServices {
-				return v.execContainerShell()
+				return components.RunAction("container.exec", v.execContainerShell)
 			}
 
 		case "h", "left":
@@ -377,11 +595,21 @@ func (v *DetailView) View() string {
 	footer := v.renderFooter()
 
 	baseView := lipgloss.JoinVertical(lipgloss.Left, header, tabBar, content, footer)
+	if v.taskBar.HasActiveTasks() {
+		v.taskBar.SetWidth(v.width)
+		baseView += v.taskBar.View()
+	}
 
 	// 如果操作日志视图可见，叠加显示
 	if v.operationLogView != nil && v.operationLogView.IsVisible() {
 		return v.operationLogView.Overlay(baseView)
 	}
+	if v.checklist.IsVisible() {
+		return components.OverlayCentered(baseView, v.checklist.View(), v.width, v.height)
+	}
+	if v.taskBar.IsPickerVisible() {
+		return components.OverlayCentered(baseView, v.taskBar.PickerView(), v.width, v.height)
+	}
 
 	return baseView
 }
@@ -394,6 +622,27 @@ func (v *DetailView) SetSize(width, height int) {
 	if v.operationLogView != nil {
 		v.operationLogView.SetSize(width, height)
 	}
+	v.taskBar.SetWidth(width)
+}
+
+// IsOperationLogVisible 返回操作日志窗口是否可见
+func (v *DetailView) IsOperationLogVisible() bool {
+	return v.operationLogView != nil && v.operationLogView.IsVisible()
+}
+
+// IsChecklistVisible profiles / 文件选择对话框是否可见
+func (v *DetailView) IsChecklistVisible() bool {
+	return v.checklist.IsVisible()
+}
+
+// IsTaskPickerVisible 返回后台任务选择对话框是否可见
+func (v *DetailView) IsTaskPickerVisible() bool {
+	return v.taskBar.IsPickerVisible()
+}
+
+// GetProject 获取当前项目
+func (v *DetailView) GetProject() *composelib.Project {
+	return v.project
 }
 
 // GetSelectedService 获取选中的服务
@@ -414,7 +663,8 @@ func (v *DetailView) scrollCurrentPanel(delta int) {
 	if v.configFocusLeft {
 		v.scrollPanel(&v.envScrollOffset, v.envContent, delta)
 	} else {
-		v.scrollPanel(&v.ymlScrollOffset, v.ymlContent, delta)
+		_, content := v.composePanel()
+		v.scrollPanel(&v.ymlScrollOffset, content, delta)
 	}
 }
 
@@ -431,7 +681,8 @@ func (v *DetailView) scrollCurrentPanelToEnd() {
 	if v.configFocusLeft {
 		v.scrollPanelToEnd(&v.envScrollOffset, v.envContent, visibleLines)
 	} else {
-		v.scrollPanelToEnd(&v.ymlScrollOffset, v.ymlContent, visibleLines)
+		_, content := v.composePanel()
+		v.scrollPanelToEnd(&v.ymlScrollOffset, content, visibleLines)
 	}
 }
 
@@ -482,8 +733,12 @@ func (v *DetailView) getContentHeight() int {
 		msgHeight = 1
 	}
 	footerHeight := v.getFooterHeight()
+	taskBarHeight := 0
+	if v.taskBar.HasActiveTasks() {
+		taskBarHeight = lipgloss.Height(v.taskBar.View())
+	}
 
-	contentHeight := v.height - detailHeaderHeight - detailTabBarHeight - footerHeight - msgHeight
+	contentHeight := v.height - detailHeaderHeight - detailTabBarHeight - footerHeight - msgHeight - taskBarHeight
 	if contentHeight < detailMinContentHeight {
 		contentHeight = detailMinContentHeight
 	}
@@ -503,22 +758,11 @@ func (v *DetailView) renderHeader() string {
 		return HeaderStyle.Width(v.width).Render("🧩 Compose Project Details")
 	}
 
-	var statusStyle lipgloss.Style
-	var statusText string
-	switch v.project.Status {
-	case composelib.StatusRunning:
-		statusStyle = StatusRunningStyle
-		statusText = "● Running"
-	case composelib.StatusPartial:
-		statusStyle = StatusPartialStyle
-		statusText = "◐ Partial"
-	case composelib.StatusStopped:
-		statusStyle = StatusStoppedStyle
-		statusText = "○ Stopped"
-	default:
-		statusStyle = StatusErrorStyle
-		statusText = "✗ Error"
+	statusStyle := StatusErrorStyle
+	if st, ok := ProjectStatus(v.project.Status); ok {
+		statusStyle = styles.StatusStyle(st).Bold(st != styles.StatusStopped)
 	}
+	statusText := projectStatusLabel(v.project.Status)
 
 	title := fmt.Sprintf("🧩 %s", v.project.Name)
 	status := statusStyle.Render(statusText)
@@ -612,13 +856,55 @@ func (v *DetailView) renderServicesTab(contentHeight int) string {
 		return v.renderCentered("📭 No service info", contentHeight)
 	}
 
+	driftPanel := v.renderDriftPanel()
 	tableHeight := contentHeight - 1
+	if driftPanel != "" {
+		tableHeight -= lipgloss.Height(driftPanel) + 1
+	}
 	if tableHeight < 3 {
 		tableHeight = 3
 	}
 	v.serviceTable.SetHeight(tableHeight)
 
-	return "\n" + v.serviceTable.View()
+	if driftPanel == "" {
+		return "\n" + v.serviceTable.View()
+	}
+	return "\n" + v.serviceTable.View() + "\n\n" + driftPanel
+}
+
+// maxDriftLines 差异面板最多列出的服务数
+const maxDriftLines = 5
+
+// renderDriftPanel 渲染与 compose config 的差异，未检查时返回空
+func (v *DetailView) renderDriftPanel() string {
+	if v.driftChecking {
+		return " " + LoadingStyle.Render("🔄 Comparing compose config with running containers...")
+	}
+	if v.drift == nil {
+		return ""
+	}
+
+	var changed []composelib.ServiceDrift
+	for _, svc := range v.services {
+		if d, ok := v.drift[svc.Name]; ok && !d.InSync() {
+			changed = append(changed, d)
+		}
+	}
+	if len(changed) == 0 {
+		return " " + SuccessStyle.Render("✓ All services match compose config — next up is a no-op")
+	}
+
+	warnStyle := StatusPartialStyle
+	lines := []string{" " + warnStyle.Render(fmt.Sprintf("⚠ %d service(s) would change on next up:", len(changed)))}
+	for i, d := range changed {
+		if i == maxDriftLines {
+			lines = append(lines, "   "+ConfigHintStyle.Render(fmt.Sprintf("...and %d more", len(changed)-maxDriftLines)))
+			break
+		}
+		line := d.Service + ": " + strings.Join(d.Changes, "; ")
+		lines = append(lines, "   "+ValueStyle.Render(format.TruncateLines(line, v.width-5)))
+	}
+	return strings.Join(lines, "\n")
 }
 
 
@@ -643,13 +929,14 @@ func (v *DetailView) renderConfigTabWide(contentHeight int) string {
 		panelWidth, visibleLines, v.configFocusLeft, "No env file",
 	)
 
+	ymlName, ymlContent := v.composePanel()
 	rightPanel := v.renderConfigPanel(
-		v.ymlFileName, v.ymlContent, v.ymlScrollOffset,
+		ymlName, ymlContent, v.ymlScrollOffset,
 		panelWidth, visibleLines, !v.configFocusLeft, "No Compose file",
 	)
 
 	combined := lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, "  ", rightPanel)
-	hint := ConfigHintStyle.Render(" h/l=Switch panel  j/k=Scroll  g/G=Top/Bottom")
+	hint := ConfigHintStyle.Render(" h/l=Switch panel  j/k=Scroll  g/G=Top/Bottom  m=Raw/Merged  F=Files")
 
 	return "\n" + combined + "\n" + hint
 }
@@ -662,13 +949,14 @@ func (v *DetailView) renderConfigTabNarrow(contentHeight int) string {
 
 	visibleLines := v.getConfigPanelVisibleLines()
 
+	ymlName, ymlContent := v.composePanel()
 	var envTab, ymlTab string
 	if v.configFocusLeft {
 		envTab = TabActiveStyle.Render("[" + v.getDisplayFileName(v.envFileName, ".env") + "]")
-		ymlTab = TabInactiveStyle.Render("[" + v.getDisplayFileName(v.ymlFileName, "compose.yml") + "]")
+		ymlTab = TabInactiveStyle.Render("[" + v.getDisplayFileName(ymlName, "compose.yml") + "]")
 	} else {
 		envTab = TabInactiveStyle.Render("[" + v.getDisplayFileName(v.envFileName, ".env") + "]")
-		ymlTab = TabActiveStyle.Render("[" + v.getDisplayFileName(v.ymlFileName, "compose.yml") + "]")
+		ymlTab = TabActiveStyle.Render("[" + v.getDisplayFileName(ymlName, "compose.yml") + "]")
 	}
 	fileTabs := " " + envTab + "  " + ymlTab
 
@@ -680,14 +968,14 @@ func (v *DetailView) renderConfigTabNarrow(contentHeight int) string {
 		scrollOffset = v.envScrollOffset
 		emptyMsg = "No env file"
 	} else {
-		content = v.ymlContent
-		fileName = v.ymlFileName
+		content = ymlContent
+		fileName = ymlName
 		scrollOffset = v.ymlScrollOffset
 		emptyMsg = "No Compose file"
 	}
 
 	panel := v.renderConfigPanel(fileName, content, scrollOffset, panelWidth, visibleLines, true, emptyMsg)
-	hint := ConfigHintStyle.Render(" h/l=Switch file  j/k=Scroll")
+	hint := ConfigHintStyle.Render(" h/l=Switch file  j/k=Scroll  m=Merged")
 
 	return "\n" + fileTabs + "\n" + panel + "\n" + hint
 }
@@ -733,7 +1021,7 @@ func (v *DetailView) renderConfigPanel(fileName, content string, scrollOffset, w
 		visibleContent = strings.Join(lines[startLine:endLine], "\n")
 	}
 
-	visibleContent = v.truncateLines(visibleContent, contentWidth)
+	visibleContent = format.TruncateLines(visibleContent, contentWidth)
 
 	var scrollInfo string
 	if totalLines > visibleLines {
@@ -746,17 +1034,16 @@ func (v *DetailView) renderConfigPanel(fileName, content string, scrollOffset, w
 	return title + scrollInfo + "\n" + box
 }
 
-func (v *DetailView) truncateLines(content string, maxWidth int) string {
-	if maxWidth < 10 {
-		maxWidth = 10
+// composePanel 返回 Config Tab 右侧面板的标题和内容：原始 compose 文件或合并后的配置
+func (v *DetailView) composePanel() (string, string) {
+	if !v.showMerged {
+		return v.ymlFileName, v.ymlContent
 	}
-	lines := strings.Split(content, "\n")
-	for i, line := range lines {
-		if len(line) > maxWidth {
-			lines[i] = line[:maxWidth-3] + "..."
-		}
+	name := fmt.Sprintf("Merged config (%d files)", len(v.project.ComposeFiles))
+	if v.mergedConfig == "" {
+		return name, "🔄 Loading docker compose config..."
 	}
-	return strings.Join(lines, "\n")
+	return name, v.mergedConfig
 }
 
 func (v *DetailView) getDisplayFileName(fileName, defaultName string) string {
@@ -802,7 +1089,9 @@ func (v *DetailView) renderInfoTab(contentHeight int) string {
 	}
 
 	composeFiles := "-"
-	if v.ymlFileName != "" {
+	if len(v.project.ComposeFiles) > 1 {
+		composeFiles = strings.Join(v.project.ComposeFiles, ", ")
+	} else if v.ymlFileName != "" {
 		composeFiles = v.ymlFileName
 	} else if len(v.project.ComposeFiles) > 0 {
 		composeFiles = strings.Join(v.project.ComposeFiles, ", ")
@@ -825,6 +1114,7 @@ func (v *DetailView) renderInfoTab(contentHeight int) string {
 		row("Project Path", path),
 		row("Compose File", composeFiles),
 		row("Env File", envFiles),
+		row("Profiles", profilesLabel(v.project.Profiles)+profilesAvailable(v.serviceProfiles)),
 		row("Service Count", fmt.Sprintf("%d", len(v.services))),
 		row("Status", v.project.Status.String()),
 	)
@@ -859,6 +1149,8 @@ func (v *DetailView) renderFooterWide() string {
 			FooterKeyStyle.Render("r") + "=Restart",
 			FooterKeyStyle.Render("l") + "=Logs",
 			FooterKeyStyle.Render("S") + "=Shell",
+			FooterKeyStyle.Render("c") + "=Drift",
+			FooterKeyStyle.Render("p") + "=Pull images",
 			FooterKeyStyle.Render("Enter") + "=Details",
 		}
 		line1 = " Service: " + strings.Join(line1Keys, "  ")
@@ -867,6 +1159,10 @@ func (v *DetailView) renderFooterWide() string {
 	line2Keys := []string{
 		FooterKeyStyle.Render("U") + "=Start project",
 		FooterKeyStyle.Render("D") + "=Stop project",
+		FooterKeyStyle.Render("O") + "=Rolling restart",
+		FooterKeyStyle.Render("A") + "=All logs",
+		FooterKeyStyle.Render("P") + "=Profiles",
+		FooterKeyStyle.Render("F") + "=Files",
 		FooterKeyStyle.Render("1-4") + "=Tabs",
 		FooterKeyStyle.Render("R") + "=Refresh",
 		FooterKeyStyle.Render("Esc") + "=Back",
@@ -894,6 +1190,7 @@ func (v *DetailView) renderFooterMedium() string {
 	} else {
 		keys = []string{
 			FooterKeyStyle.Render("U/D") + "=Project ops",
+			FooterKeyStyle.Render("P/F") + "=Profiles/Files",
 			FooterKeyStyle.Render("1-4") + "=Tabs",
 			FooterKeyStyle.Render("R") + "=Refresh",
 			FooterKeyStyle.Render("Esc") + "=Back",
@@ -929,13 +1226,19 @@ func (v *DetailView) updateServiceTable() {
 		var status string
 		switch svc.State {
 		case "running":
-			status = "● Running"
+			status = styles.StatusLabel(styles.StatusRunning, "Running")
 		case "exited":
-			status = "○ Stopped"
+			status = styles.StatusLabel(styles.StatusStopped, "Stopped")
 		case "partial":
-			status = "◐ Partial"
+			status = styles.StatusLabel(styles.StatusWarning, "Partial")
 		case "paused":
-			status = "❚❚ Paused"
+			status = styles.StatusLabel(styles.StatusPaused, "Paused")
+		case "":
+			// 只在 compose 文件中定义、还没有容器的 profile 服务
+			status = "- Inactive"
+			if profileActive(svc.Profiles, v.project.Profiles) {
+				status = "○ Not created"
+			}
 		default:
 			status = "? " + svc.State
 		}
@@ -948,20 +1251,59 @@ func (v *DetailView) updateServiceTable() {
 			image = image[:imageWidth-6] + "..."
 		}
 
-		rows[i] = table.Row{svc.Name, status, replicas, image}
+		row := table.Row{svc.Name, status, replicas, image}
+		if v.stats != nil {
+			cpu, mem := v.stats.cells(svc)
+			row = append(row, cpu, mem)
+		}
+		if len(v.serviceProfiles) > 0 {
+			row = append(row, strings.Join(svc.Profiles, ","))
+		}
+		rows[i] = row
+	}
+	// 检查过差异时在服务名前标记
+	if v.drift != nil {
+		for i, svc := range v.services {
+			mark := "  "
+			if d, ok := v.drift[svc.Name]; ok && !d.InSync() {
+				mark = "⚠ "
+			} else if ok {
+				mark = "✓ "
+			}
+			rows[i][0] = mark + rows[i][0]
+		}
 	}
 	v.serviceTable.SetRows(rows)
 }
 
 func (v *DetailView) getImageColumnWidth() int {
 	nameWidth, statusWidth, replicasWidth := v.getColumnWidths()
-	imageWidth := v.width - nameWidth - statusWidth - replicasWidth - 8
+	imageWidth := v.width - nameWidth - statusWidth - replicasWidth - v.getStatsColumnsWidth() - v.getProfilesColumnWidth() - 8
 	if imageWidth < 15 {
 		imageWidth = 15
 	}
 	return imageWidth
 }
 
+// getStatsColumnsWidth CPU 和内存两列的总宽度，客户端不支持统计数据流时不显示
+func (v *DetailView) getStatsColumnsWidth() int {
+	if v.stats == nil {
+		return 0
+	}
+	return statsColumnWidth * 2
+}
+
+// getProfilesColumnWidth Profiles 列宽度，项目未使用 profiles 时不显示该列
+func (v *DetailView) getProfilesColumnWidth() int {
+	if len(v.serviceProfiles) == 0 {
+		return 0
+	}
+	if v.width >= 100 {
+		return 16
+	}
+	return 10
+}
+
 func (v *DetailView) getColumnWidths() (nameWidth, statusWidth, replicasWidth int) {
 	if v.width >= 120 {
 		return 25, 12, 8
@@ -985,14 +1327,34 @@ func (v *DetailView) updateTableColumns() {
 		{Title: "Replicas", Width: replicasWidth},
 		{Title: "Image", Width: imageWidth},
 	}
+	if v.stats != nil {
+		columns = append(columns,
+			table.Column{Title: "CPU", Width: statsColumnWidth},
+			table.Column{Title: "MEM", Width: statsColumnWidth},
+		)
+	}
+	if width := v.getProfilesColumnWidth(); width > 0 {
+		columns = append(columns, table.Column{Title: "Profiles", Width: width})
+	}
+	// 列数变化时先清空行，避免表格按旧行渲染越界
+	if len(columns) != len(v.serviceTable.Columns()) {
+		v.serviceTable.SetRows(nil)
+	}
 	v.serviceTable.SetColumns(columns)
 }
 
 func (v *DetailView) handleTabChange() tea.Cmd {
-	if v.currentTab == tabConfig && v.ymlContent == "" {
-		return v.loadConfigFiles
+	if v.currentTab != tabConfig {
+		return nil
 	}
-	return nil
+	var cmds []tea.Cmd
+	if v.ymlContent == "" {
+		cmds = append(cmds, v.loadConfigFiles)
+	}
+	if v.showMerged && v.mergedConfig == "" {
+		cmds = append(cmds, v.loadMergedConfig)
+	}
+	return tea.Batch(cmds...)
 }
 
 // 数据加载方法
@@ -1010,7 +1372,109 @@ func (v *DetailView) refreshServices() tea.Msg {
 		return detailServicesMsg{err: err}
 	}
 
-	return detailServicesMsg{services: services}
+	// 读取不到 compose 文件（例如只通过标签发现的项目）时不显示 profiles
+	profiles, _ := composelib.ServiceProfiles(v.project)
+	return detailServicesMsg{services: withProfileServices(services, profiles), profiles: profiles}
+}
+
+// checkDrift 比较 compose config 与实际运行的容器
+func (v *DetailView) checkDrift() tea.Msg {
+	if v.composeClient == nil || v.project == nil {
+		return detailDriftMsg{err: fmt.Errorf("client or project not initialized")}
+	}
+	drift, err := v.composeClient.Drift(v.project)
+	return detailDriftMsg{drift: drift, err: err}
+}
+
+// loadMergedConfig 读取 docker compose config 输出的合并配置
+func (v *DetailView) loadMergedConfig() tea.Msg {
+	if v.composeClient == nil || v.project == nil {
+		return detailMergedConfigMsg{err: fmt.Errorf("client or project not initialized")}
+	}
+	content, err := v.composeClient.Config(v.project)
+	return detailMergedConfigMsg{content: content, err: err}
+}
+
+// applyChecklist 应用多选对话框的结果
+func (v *DetailView) applyChecklist() tea.Cmd {
+	selected := v.checklist.Selected()
+	switch v.checklistMode {
+	case checklistFiles:
+		if len(selected) == 0 {
+			v.errorMsg = "Select at least one compose file"
+			return v.clearMessageAfter(3)
+		}
+		v.project.ComposeFiles = selected
+		v.ymlContent = ""
+		v.ymlFileName = selected[0]
+		v.ymlScrollOffset = 0
+		v.successMsg = "Compose files: " + strings.Join(selected, ", ")
+	default:
+		v.project.Profiles = selected
+		v.successMsg = "Active profiles: " + profilesLabel(v.project.Profiles)
+	}
+	// 文件和 profiles 都会影响合并后的配置和差异检查结果
+	v.mergedConfig = ""
+	v.drift = nil
+	return tea.Batch(v.refreshServices, v.handleTabChange(), v.clearMessageAfter(3))
+}
+
+// isBaseComposeFile 是否是主 compose 文件（其余为 override 文件）
+func isBaseComposeFile(name string) bool {
+	switch strings.ToLower(name) {
+	case "docker-compose.yml", "docker-compose.yaml", "compose.yml", "compose.yaml":
+		return true
+	}
+	return false
+}
+
+// withProfileServices 为服务填充所属 profiles，并补上还没有容器的 profile 服务
+func withProfileServices(services []composelib.Service, profiles map[string][]string) []composelib.Service {
+	seen := make(map[string]bool, len(services))
+	for i := range services {
+		services[i].Profiles = profiles[services[i].Name]
+		seen[services[i].Name] = true
+	}
+	var missing []string
+	for name := range profiles {
+		if !seen[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		services = append(services, composelib.Service{Name: name, Profiles: profiles[name]})
+	}
+	return services
+}
+
+// profileActive 服务所属的 profiles 中是否有启用的
+func profileActive(serviceProfiles, active []string) bool {
+	for _, p := range serviceProfiles {
+		for _, a := range active {
+			if p == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// profilesLabel 启用的 profiles 显示文本
+func profilesLabel(active []string) string {
+	if len(active) == 0 {
+		return "none"
+	}
+	return strings.Join(active, ", ")
+}
+
+// profilesAvailable 可用 profiles 的数量提示
+func profilesAvailable(serviceProfiles map[string][]string) string {
+	names := composelib.ProfileNames(serviceProfiles)
+	if len(names) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d defined)", len(names))
 }
 
 func (v *DetailView) loadConfigFiles() tea.Msg {
@@ -1121,6 +1585,45 @@ func (v *DetailView) startProjectOperation(opType string) tea.Cmd {
 	return v.executeProjectOperationStream(opType)
 }
 
+// startRollingRestart 按依赖关系逐个重启有容器的服务，每个服务恢复健康后再重启下一个
+func (v *DetailView) startRollingRestart() tea.Cmd {
+	if v.project == nil {
+		v.errorMsg = "Project not initialized"
+		return v.clearMessageAfter(3)
+	}
+	wrapper, ok := v.composeClient.(*composelib.ComposeClientWrapper)
+	if !ok {
+		v.errorMsg = "Rolling restart requires the docker compose CLI"
+		return v.clearMessageAfter(3)
+	}
+	var services []string
+	for _, svc := range v.services {
+		if len(svc.Containers) > 0 {
+			services = append(services, svc.Name)
+		}
+	}
+	if len(services) == 0 {
+		v.errorMsg = "No services with containers to restart"
+		return v.clearMessageAfter(3)
+	}
+
+	v.operatingService = v.project.Name
+	v.operationType = "rolling-restart"
+	v.errorMsg = ""
+	v.successMsg = ""
+	if v.operationLogView != nil {
+		v.operationLogView.SetSize(v.width, v.height)
+		v.operationLogView.Show("Rolling Restart: " + v.project.Name)
+	}
+
+	project := v.project
+	opts := composelib.RollingRestartOptions{Services: services, StopTimeout: 10, HealthTimeout: v.healthTimeout}
+	v.operationStream = submitOperationTask(fmt.Sprintf("Compose rolling restart %s", project.Name), func() *composelib.OperationStream {
+		return wrapper.RollingRestartStream(project, opts)
+	})
+	return v.listenOperationStream()
+}
+
 func (v *DetailView) executeServiceOperation(serviceName, opType string) tea.Cmd {
 	return func() tea.Msg {
 		if v.composeClient == nil {
@@ -1146,7 +1649,7 @@ func (v *DetailView) executeServiceOperation(serviceName, opType string) tea.Cmd
 			return detailOperationMsg{err: err}
 		}
 		if result != nil && !result.Success {
-			return detailOperationMsg{err: fmt.Errorf(result.Message)}
+			return detailOperationMsg{err: errors.New(result.Message)}
 		}
 
 		opNames := map[string]string{"start": "Start", "stop": "Stop", "restart": "Restart"}
@@ -1162,22 +1665,23 @@ func (v *DetailView) executeServiceOperationStream(serviceName, opType string) t
 		return v.executeServiceOperation(serviceName, opType)
 	}
 
+	project := v.project
 	services := []string{serviceName}
-	var stream *composelib.OperationStream
+	var start func() *composelib.OperationStream
 
 	switch opType {
 	case "start":
-		stream = wrapper.StartStream(v.project, services)
+		start = func() *composelib.OperationStream { return wrapper.StartStream(project, services) }
 	case "stop":
-		stream = wrapper.StopStream(v.project, services, 10)
+		start = func() *composelib.OperationStream { return wrapper.StopStream(project, services, 10) }
 	case "restart":
-		stream = wrapper.RestartStream(v.project, services, 10)
+		start = func() *composelib.OperationStream { return wrapper.RestartStream(project, services, 10) }
 	default:
 		v.errorMsg = "Unknown operation: " + opType
 		return nil
 	}
 
-	v.operationStream = stream
+	v.operationStream = submitOperationTask(fmt.Sprintf("Compose %s %s/%s", opType, project.Name, serviceName), start)
 	return v.listenOperationStream()
 }
 
@@ -1203,7 +1707,7 @@ func (v *DetailView) executeProjectOperation(opType string) tea.Cmd {
 			return detailOperationMsg{err: err}
 		}
 		if result != nil && !result.Success {
-			return detailOperationMsg{err: fmt.Errorf(result.Message)}
+			return detailOperationMsg{err: errors.New(result.Message)}
 		}
 
 		opNames := map[string]string{"up": "Start", "down": "Stop"}
@@ -1220,18 +1724,23 @@ func (v *DetailView) executeProjectOperationStream(opType string) tea.Cmd {
 		return v.executeProjectOperation(opType)
 	}
 
-	var stream *composelib.OperationStream
+	project := v.project
+	var start func() *composelib.OperationStream
 	switch opType {
 	case "up":
-		stream = wrapper.UpStream(v.project, composelib.UpOptions{Detach: true})
+		start = func() *composelib.OperationStream {
+			return wrapper.UpStream(project, composelib.UpOptions{Detach: true})
+		}
 	case "down":
-		stream = wrapper.DownStream(v.project, composelib.DownOptions{})
+		start = func() *composelib.OperationStream {
+			return wrapper.DownStream(project, composelib.DownOptions{})
+		}
 	default:
 		v.errorMsg = "Unknown operation: " + opType
 		return nil
 	}
 
-	v.operationStream = stream
+	v.operationStream = submitOperationTask(fmt.Sprintf("Compose %s %s", opType, project.Name), start)
 
 	// 返回一个命令来监听日志
 	return v.listenOperationStream()
@@ -1243,21 +1752,7 @@ func (v *DetailView) listenOperationStream() tea.Cmd {
 		return nil
 	}
 
-	return func() tea.Msg {
-		select {
-		case line, ok := <-v.operationStream.LogChan:
-			if ok {
-				return detailOperationLogMsg{line: line}
-			}
-			// LogChan 关闭，等待完成
-			return nil
-		case result, ok := <-v.operationStream.DoneChan:
-			if ok {
-				return detailOperationDoneMsg{result: result}
-			}
-			return nil
-		}
-	}
+	return waitOperationStream(v.operationStream)
 }
 
 // continueListenOperationStream 继续监听操作流
@@ -1300,6 +1795,31 @@ func (v *DetailView) viewContainerLogs() tea.Cmd {
 	}
 }
 
+// viewMergedLogs 跳转到项目所有容器的合并日志
+func (v *DetailView) viewMergedLogs() tea.Cmd {
+	if v.project == nil {
+		return nil
+	}
+	var containers []LogContainer
+	for _, svc := range v.services {
+		for i, id := range svc.Containers {
+			name := svc.Name
+			if len(svc.Containers) > 1 {
+				name = fmt.Sprintf("%s-%d", svc.Name, i+1)
+			}
+			containers = append(containers, LogContainer{ID: id, Name: name})
+		}
+	}
+	if len(containers) == 0 {
+		v.errorMsg = "This project has no containers"
+		return v.clearMessageAfter(3)
+	}
+	projectName := v.project.Name
+	return func() tea.Msg {
+		return GoToMergedLogsMsg{ProjectName: projectName, Containers: containers}
+	}
+}
+
 func (v *DetailView) execContainerShell() tea.Cmd {
 	svc := v.GetSelectedService()
 	if svc == nil || len(svc.Containers) == 0 {
@@ -2,6 +2,7 @@ package compose
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,9 +10,11 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	sdk "github.com/docker/docker/client"
 
 	composelib "docktui/internal/compose"
+	"docktui/internal/favorites"
+	"docktui/internal/task"
+	"docktui/internal/ui/components"
 )
 
 // ListView Compose 项目列表视图
@@ -37,15 +40,13 @@ type ListView struct {
 	// 操作日志视图
 	operationLogView *OperationLogView
 	operationStream  *composelib.OperationStream
-}
 
-// NewListView 创建 Compose 列表视图
-func NewListView(composeClient composelib.Client, dockerCli *sdk.Client) *ListView {
-	var discovery *composelib.Discovery
-	if dockerCli != nil {
-		discovery = composelib.NewDiscovery(dockerCli)
-	}
+	// 后台任务栏
+	taskBar *components.TaskBar
+}
 
+// NewListView 创建 Compose 列表视图，discovery 为 nil 时无法发现项目
+func NewListView(composeClient composelib.Client, discovery *composelib.Discovery) *ListView {
 	columns := []table.Column{
 		{Title: "Project Name", Width: 20},
 		{Title: "Status", Width: 10},
@@ -78,6 +79,7 @@ func NewListView(composeClient composelib.Client, dockerCli *sdk.Client) *ListVi
 		loading:          false,
 		autoRefresh:      false,
 		operationLogView: NewOperationLogView(),
+		taskBar:          components.NewTaskBar(),
 	}
 }
 
@@ -126,6 +128,10 @@ func (v *ListView) Update(msg tea.Msg) tea.Cmd {
 		return nil
 
 	case detailOperationLogMsg:
+		// 其他视图发起的操作
+		if msg.stream != v.operationStream {
+			return nil
+		}
 		// 追加日志行
 		if v.operationLogView != nil {
 			v.operationLogView.AppendLog(msg.line)
@@ -134,6 +140,9 @@ func (v *ListView) Update(msg tea.Msg) tea.Cmd {
 		return v.continueListenOperationStream()
 
 	case detailOperationDoneMsg:
+		if msg.stream != v.operationStream {
+			return nil
+		}
 		// 操作完成
 		if v.operationLogView != nil && msg.result != nil {
 			v.operationLogView.SetComplete(msg.result.Success, msg.result.Message)
@@ -145,6 +154,22 @@ func (v *ListView) Update(msg tea.Msg) tea.Cmd {
 		return v.refreshProjectStatus
 
 	case tea.KeyMsg:
+		// 取消进行中的流式操作
+		if msg.String() == "x" && v.operationStream != nil && !v.operationLogView.IsCapturingInput() {
+			v.operationStream.Cancel()
+			v.successMsg = "⏹️ Cancelling operation..."
+			return nil
+		}
+
+		// 后台任务选择对话框可见时，按键只交给对话框
+		if v.taskBar.IsPickerVisible() {
+			if notice := v.taskBar.UpdatePicker(msg); notice != "" {
+				v.successMsg = notice
+				return v.clearMessageAfter(3)
+			}
+			return nil
+		}
+
 		// 如果操作日志视图可见，优先处理
 		if v.operationLogView != nil && v.operationLogView.IsVisible() {
 			if v.operationLogView.Update(msg) {
@@ -177,21 +202,32 @@ func (v *ListView) Update(msg tea.Msg) tea.Cmd {
 			v.tableModel.GotoBottom()
 			return nil
 		case "u":
-			return v.startOperation("up")
+			return components.RunAction("compose.up", func() tea.Cmd { return v.startOperation("up") })
 		case "d":
-			return v.startOperation("down")
+			return components.RunAction("compose.down", func() tea.Cmd { return v.startOperation("down") })
 		case "r":
-			return v.startOperation("restart")
+			return components.RunAction("compose.restart", func() tea.Cmd { return v.startOperation("restart") })
 		case "s":
-			return v.startOperation("stop")
+			return components.RunAction("compose.stop", func() tea.Cmd { return v.startOperation("stop") })
 		case "t":
-			return v.startOperation("start")
+			return components.RunAction("compose.start", func() tea.Cmd { return v.startOperation("start") })
 		case "R", "f5":
 			v.loading = true
+			if v.discovery != nil {
+				v.discovery.InvalidateCache()
+			}
 			return v.discoverProjects
+		case "x":
+			v.taskBar.ShowPicker()
+			return nil
+		case "T":
+			v.taskBar.Toggle()
+			return nil
 		case "l":
 			v.successMsg = "📜 Log feature in development..."
 			return v.clearMessageAfter(3)
+		case "*":
+			return v.toggleFavorite()
 		case "enter":
 			project := v.GetSelectedProject()
 			if project != nil {
@@ -217,7 +253,15 @@ func (v *ListView) View() string {
 		messageHeight = 1
 	}
 
-	tableHeight := v.height - headerHeight - footerHeight - messageHeight - 2
+	taskBarView := ""
+	taskBarHeight := 0
+	if v.taskBar.HasActiveTasks() {
+		v.taskBar.SetWidth(v.width)
+		taskBarView = v.taskBar.View()
+		taskBarHeight = lipgloss.Height(taskBarView)
+	}
+
+	tableHeight := v.height - headerHeight - footerHeight - messageHeight - taskBarHeight - 2
 	if tableHeight < 5 {
 		tableHeight = 5
 	}
@@ -228,11 +272,17 @@ func (v *ListView) View() string {
 	footer := v.renderFooter()
 
 	baseView := lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+	if taskBarView != "" {
+		baseView += taskBarView
+	}
 
 	// 如果操作日志视图可见，叠加显示
 	if v.operationLogView != nil && v.operationLogView.IsVisible() {
 		return v.operationLogView.Overlay(baseView)
 	}
+	if v.taskBar.IsPickerVisible() {
+		return components.OverlayCentered(baseView, v.taskBar.PickerView(), v.width, v.height)
+	}
 
 	return baseView
 }
@@ -245,6 +295,17 @@ func (v *ListView) SetSize(width, height int) {
 	if v.operationLogView != nil {
 		v.operationLogView.SetSize(width, height)
 	}
+	v.taskBar.SetWidth(width)
+}
+
+// IsOperationLogVisible 返回操作日志窗口是否可见
+func (v *ListView) IsOperationLogVisible() bool {
+	return v.operationLogView != nil && v.operationLogView.IsVisible()
+}
+
+// IsTaskPickerVisible 返回后台任务选择对话框是否可见
+func (v *ListView) IsTaskPickerVisible() bool {
+	return v.taskBar.IsPickerVisible()
 }
 
 // GetSelectedProject 获取当前选中的项目
@@ -333,6 +394,7 @@ func (v *ListView) renderFooter() string {
 		FooterKeyStyle.Render("l") + "=Logs",
 		FooterKeyStyle.Render("R") + "=Refresh",
 		FooterKeyStyle.Render("Enter") + "=Details",
+		FooterKeyStyle.Render("*") + "=Star",
 	}
 	line2 := " View: " + strings.Join(line2Keys, "  ")
 
@@ -354,19 +416,7 @@ func (v *ListView) renderFooter() string {
 func (v *ListView) updateTable() {
 	rows := make([]table.Row, len(v.projects))
 	for i, p := range v.projects {
-		var status string
-		switch p.Status {
-		case composelib.StatusRunning:
-			status = "● Running"
-		case composelib.StatusPartial:
-			status = "◐ Partial"
-		case composelib.StatusStopped:
-			status = "○ Stopped"
-		case composelib.StatusError:
-			status = "✗ Error"
-		default:
-			status = "? Unknown"
-		}
+		status := projectStatusLabel(p.Status)
 
 		runningServices := 0
 		for _, svc := range p.Services {
@@ -388,7 +438,7 @@ func (v *ListView) updateTable() {
 			path = "..." + path[len(path)-maxPathLen+3:]
 		}
 
-		rows[i] = table.Row{p.Name, status, services, path}
+		rows[i] = table.Row{p.Name + favorites.Badge(favorites.KindCompose, p.Path), status, services, path}
 	}
 	v.tableModel.SetRows(rows)
 }
@@ -432,10 +482,12 @@ func (v *ListView) discoverProjects() tea.Msg {
 	return listScanResultMsg{projects: projects}
 }
 
+// refreshProjectStatus 操作完成后重新发现项目（不使用缓存）
 func (v *ListView) refreshProjectStatus() tea.Msg {
 	if v.discovery == nil {
 		return listRefreshStatusMsg{projects: v.projects}
 	}
+	v.discovery.InvalidateCache()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -506,7 +558,7 @@ func (v *ListView) executeOperation(project *composelib.Project, opType string)
 		}
 
 		if result != nil && !result.Success {
-			return listOperationResultMsg{err: fmt.Errorf(result.Message)}
+			return listOperationResultMsg{err: errors.New(result.Message)}
 		}
 
 		opNames := map[string]string{
@@ -532,41 +584,52 @@ func (v *ListView) executeOperationStream(project *composelib.Project, opType st
 		return v.executeOperation(project, opType)
 	}
 
-	var stream *composelib.OperationStream
+	var start func() *composelib.OperationStream
 	switch opType {
 	case "up":
-		stream = wrapper.UpStream(project, composelib.UpOptions{Detach: true})
+		start = func() *composelib.OperationStream {
+			return wrapper.UpStream(project, composelib.UpOptions{Detach: true})
+		}
 	case "down":
-		stream = wrapper.DownStream(project, composelib.DownOptions{})
+		start = func() *composelib.OperationStream {
+			return wrapper.DownStream(project, composelib.DownOptions{})
+		}
 	default:
 		return v.executeOperation(project, opType)
 	}
 
-	v.operationStream = stream
+	v.operationStream = submitOperationTask(fmt.Sprintf("Compose %s %s", opType, project.Name), start)
 
 	return v.listenOperationStream()
 }
 
+// submitOperationTask 将 Compose 操作注册为后台任务，进度同步显示在 TaskBar 中
+func submitOperationTask(name string, start func() *composelib.OperationStream) *composelib.OperationStream {
+	composeTask := task.NewComposeTask(name, start)
+	task.GetManager().Submit(composeTask)
+	return composeTask.Stream()
+}
+
+// waitOperationStream 读取操作流的下一条日志；日志读完（通道关闭）后再等待结果，保证日志不会因为先收到结果而丢失
+func waitOperationStream(stream *composelib.OperationStream) tea.Cmd {
+	return func() tea.Msg {
+		if line, ok := <-stream.LogChan; ok {
+			return detailOperationLogMsg{stream: stream, line: line}
+		}
+		if result, ok := <-stream.DoneChan; ok {
+			return detailOperationDoneMsg{stream: stream, result: result}
+		}
+		return nil
+	}
+}
+
 // listenOperationStream 监听操作流
 func (v *ListView) listenOperationStream() tea.Cmd {
 	if v.operationStream == nil {
 		return nil
 	}
 
-	return func() tea.Msg {
-		select {
-		case line, ok := <-v.operationStream.LogChan:
-			if ok {
-				return detailOperationLogMsg{line: line}
-			}
-			return nil
-		case result, ok := <-v.operationStream.DoneChan:
-			if ok {
-				return detailOperationDoneMsg{result: result}
-			}
-			return nil
-		}
-	}
+	return waitOperationStream(v.operationStream)
 }
 
 // continueListenOperationStream 继续监听操作流
@@ -577,6 +640,33 @@ func (v *ListView) continueListenOperationStream() tea.Cmd {
 	return v.listenOperationStream()
 }
 
+// toggleFavorite 收藏/取消收藏选中的项目（按项目目录）
+func (v *ListView) toggleFavorite() tea.Cmd {
+	project := v.GetSelectedProject()
+	if project == nil {
+		return nil
+	}
+	starred, err := favorites.Toggle(favorites.Ref{
+		Kind:         favorites.KindCompose,
+		Key:          project.Path,
+		Name:         project.Name,
+		ComposeFiles: project.ComposeFiles,
+		EnvFiles:     project.EnvFiles,
+	})
+	if err != nil {
+		v.errorMsg = fmt.Sprintf("Failed to update favorites: %v", err)
+		v.successMsg = ""
+		return v.clearMessageAfter(3)
+	}
+	v.updateTable()
+	v.successMsg = "★ Added to favorites: " + project.Name
+	if !starred {
+		v.successMsg = "☆ Removed from favorites: " + project.Name
+	}
+	v.errorMsg = ""
+	return v.clearMessageAfter(3)
+}
+
 func (v *ListView) clearMessageAfter(seconds int) tea.Cmd {
 	return tea.Tick(time.Duration(seconds)*time.Second, func(t time.Time) tea.Msg {
 		return listClearMessageMsg{}
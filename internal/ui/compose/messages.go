@@ -19,6 +19,18 @@ type GoToContainerLogsMsg struct {
 	ContainerName string
 }
 
+// GoToMergedLogsMsg 跳转到项目所有容器的合并日志的消息
+type GoToMergedLogsMsg struct {
+	ProjectName string
+	Containers  []LogContainer
+}
+
+// LogContainer 合并日志中的一个容器
+type LogContainer struct {
+	ID   string
+	Name string // 服务名称，多副本时加序号
+}
+
 // ExecContainerShellMsg 执行容器 Shell 的消息
 type ExecContainerShellMsg struct {
 	ContainerID   string
@@ -48,6 +60,7 @@ type listClearMessageMsg struct{}
 // 详情视图内部消息
 type detailServicesMsg struct {
 	services []composelib.Service
+	profiles map[string][]string // 服务 -> 所属 profiles
 	err      error
 }
 
@@ -59,19 +72,51 @@ type detailConfigFilesMsg struct {
 	err         error
 }
 
+// detailDriftMsg 与 compose config 的差异检查结果
+type detailDriftMsg struct {
+	drift []composelib.ServiceDrift
+	err   error
+}
+
+// detailMergedConfigMsg docker compose config 合并配置
+type detailMergedConfigMsg struct {
+	content string
+	err     error
+}
+
 type detailOperationMsg struct {
 	message string
 	err     error
 }
 
+// OperationStreamMsg 操作流的日志和完成消息；离开 Compose 视图后仍要交给发起操作的视图继续读取，
+// 否则任务会阻塞在日志发送上
+type OperationStreamMsg interface {
+	operationStreamMsg()
+}
+
 // detailOperationLogMsg 操作日志消息
 type detailOperationLogMsg struct {
-	line string
+	stream *composelib.OperationStream
+	line   string
 }
 
 // detailOperationDoneMsg 操作完成消息
 type detailOperationDoneMsg struct {
+	stream *composelib.OperationStream
 	result *composelib.OperationResult
 }
 
+func (detailOperationLogMsg) operationStreamMsg()  {}
+func (detailOperationDoneMsg) operationStreamMsg() {}
+
 type detailClearMessageMsg struct{}
+
+// detailPullImagesMsg 读取到的服务镜像（服务名 -> 镜像）
+type detailPullImagesMsg struct {
+	images map[string]string
+	err    error
+}
+
+// detailPullTickMsg 刷新服务镜像拉取进度
+type detailPullTickMsg struct{}
@@ -1,14 +1,25 @@
 package compose
 
 import (
+	"fmt"
+	"os"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/format"
 )
 
+// logLine 操作日志的一行及其接收时间
+type logLine struct {
+	Time time.Time
+	Text string
+}
+
 // OperationLogView 操作日志视图组件
 type OperationLogView struct {
 	width  int
@@ -16,9 +27,22 @@ type OperationLogView struct {
 
 	visible   bool
 	title     string
-	logs      []string
+	logs      []logLine
 	maxLines  int
 	scrollPos int
+
+	showTime bool // 行首显示接收时间
+
+	// 搜索
+	searching bool
+	query     string
+	matches   []int // 匹配的行号
+	matchIdx  int
+
+	// 保存到文件（操作完成后）
+	saving   bool
+	savePath string
+	notice   string
 	
 	// 操作状态
 	running   bool
@@ -33,8 +57,8 @@ type OperationLogView struct {
 // NewOperationLogView 创建操作日志视图
 func NewOperationLogView() *OperationLogView {
 	return &OperationLogView{
-		maxLines: 500,
-		logs:     make([]string, 0),
+		maxLines: 10000,
+		logs:     make([]logLine, 0),
 	}
 }
 
@@ -66,6 +90,10 @@ var (
 
 	logHintStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("245"))
+
+	logMatchStyle = lipgloss.NewStyle().
+		Background(lipgloss.Color("220")).
+		Foreground(lipgloss.Color("16"))
 )
 
 // Show 显示日志视图
@@ -75,8 +103,10 @@ func (v *OperationLogView) Show(title string) {
 
 	v.visible = true
 	v.title = title
-	v.logs = make([]string, 0)
+	v.logs = make([]logLine, 0)
 	v.scrollPos = 0
+	v.searching, v.query, v.matches, v.matchIdx = false, "", nil, 0
+	v.saving, v.notice = false, ""
 	v.running = true
 	v.success = false
 	v.errorMsg = ""
@@ -97,6 +127,13 @@ func (v *OperationLogView) IsVisible() bool {
 	return v.visible
 }
 
+// IsCapturingInput 是否正在输入搜索词或保存路径（此时按键不是快捷键）
+func (v *OperationLogView) IsCapturingInput() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.visible && (v.searching || v.saving)
+}
+
 // IsRunning 是否正在运行
 func (v *OperationLogView) IsRunning() bool {
 	v.mu.Lock()
@@ -109,14 +146,18 @@ func (v *OperationLogView) AppendLog(line string) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 
-	v.logs = append(v.logs, line)
+	v.logs = append(v.logs, logLine{Time: time.Now(), Text: line})
 	
 	// 限制最大行数
 	if len(v.logs) > v.maxLines {
 		v.logs = v.logs[len(v.logs)-v.maxLines:]
 	}
 	
-	// 自动滚动到底部
+	// 搜索时保持在当前匹配处，否则自动滚动到底部
+	if v.query != "" {
+		v.updateMatches(false)
+		return
+	}
 	v.scrollToBottom()
 }
 
@@ -145,9 +186,22 @@ func (v *OperationLogView) Update(msg tea.KeyMsg) bool {
 	if !v.visible {
 		return false
 	}
+	if v.searching {
+		v.updateSearch(msg)
+		return true
+	}
+	if v.saving {
+		v.updateSave(msg)
+		return true
+	}
 
 	switch msg.String() {
 	case "esc", "q":
+		// 先清除搜索
+		if v.query != "" {
+			v.query, v.matches, v.matchIdx = "", nil, 0
+			return true
+		}
 		// 只有操作完成后才能关闭
 		if !v.running {
 			v.visible = false
@@ -172,11 +226,149 @@ func (v *OperationLogView) Update(msg tea.KeyMsg) bool {
 	case "ctrl+u", "pgup":
 		v.scrollUp(10)
 		return true
+	case "/":
+		v.searching = true
+		v.query = ""
+		v.notice = ""
+		return true
+	case "n":
+		v.jumpMatch(1)
+		return true
+	case "N":
+		v.jumpMatch(-1)
+		return true
+	case "t":
+		v.showTime = !v.showTime
+		return true
+	case "S", "e":
+		if v.running {
+			v.notice = "Wait for the operation to finish before saving"
+			return true
+		}
+		v.saving = true
+		v.notice = ""
+		v.savePath = v.defaultSavePath()
+		return true
 	}
 
 	return true
 }
 
+// updateSearch 输入搜索词，输入时即时跳转到第一个匹配
+func (v *OperationLogView) updateSearch(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		v.searching = false
+		if v.query != "" && len(v.matches) == 0 {
+			v.notice = "No matches for " + v.query
+		}
+	case tea.KeyEsc:
+		v.searching = false
+		v.query, v.matches, v.matchIdx = "", nil, 0
+	case tea.KeyBackspace:
+		if r := []rune(v.query); len(r) > 0 {
+			v.query = string(r[:len(r)-1])
+			v.updateMatches(true)
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		v.query += string(msg.Runes)
+		v.updateMatches(true)
+	}
+}
+
+// updateSave 输入保存路径，回车后写入完整日志
+func (v *OperationLogView) updateSave(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		v.saving = false
+		if strings.TrimSpace(v.savePath) == "" {
+			return
+		}
+		if err := v.saveLog(v.savePath); err != nil {
+			v.notice = "❌ Save failed: " + err.Error()
+		} else {
+			v.notice = "💾 Saved to " + v.savePath
+		}
+	case tea.KeyEsc:
+		v.saving = false
+	case tea.KeyBackspace:
+		if r := []rune(v.savePath); len(r) > 0 {
+			v.savePath = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		v.savePath += string(msg.Runes)
+	}
+}
+
+// updateMatches 重新计算匹配的行，jump 时跳转到第一个匹配
+func (v *OperationLogView) updateMatches(jump bool) {
+	v.matches = v.matches[:0]
+	if v.query == "" {
+		v.matchIdx = 0
+		return
+	}
+	query := strings.ToLower(v.query)
+	for i, l := range v.logs {
+		if strings.Contains(strings.ToLower(l.Text), query) {
+			v.matches = append(v.matches, i)
+		}
+	}
+	if v.matchIdx >= len(v.matches) {
+		v.matchIdx = max(0, len(v.matches)-1)
+	}
+	if jump {
+		v.matchIdx = 0
+		v.scrollToMatch()
+	}
+}
+
+// jumpMatch 跳转到下一个（delta=1）或上一个（delta=-1）匹配
+func (v *OperationLogView) jumpMatch(delta int) {
+	if len(v.matches) == 0 {
+		return
+	}
+	v.matchIdx = (v.matchIdx + delta + len(v.matches)) % len(v.matches)
+	v.scrollToMatch()
+}
+
+// scrollToMatch 滚动使当前匹配行显示在可见区域的上部
+func (v *OperationLogView) scrollToMatch() {
+	if v.matchIdx >= len(v.matches) {
+		return
+	}
+	v.scrollPos = min(max(v.matches[v.matchIdx]-v.getVisibleLines()/3, 0), v.getMaxScroll())
+}
+
+// unsafeFileChars 默认文件名中需要替换的字符
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// defaultSavePath 默认的保存文件名，如 compose-up-myapp_20260101_150405.log
+func (v *OperationLogView) defaultSavePath() string {
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(strings.ToLower(v.title), "-"), "-")
+	if name == "" {
+		name = "operation"
+	}
+	return fmt.Sprintf("compose-%s_%s.log", name, v.startTime.Format("20060102_150405"))
+}
+
+// saveLog 把完整日志（带时间戳）和操作结果写入文件
+func (v *OperationLogView) saveLog(path string) error {
+	var b strings.Builder
+	status := "completed"
+	if !v.success {
+		status = "failed"
+	}
+	fmt.Fprintf(&b, "# %s (%s, %s - %s)\n", v.title, status,
+		v.startTime.Format("2006-01-02 15:04:05"), v.endTime.Format("15:04:05"))
+	if v.errorMsg != "" {
+		fmt.Fprintf(&b, "# error: %s\n", v.errorMsg)
+	}
+	for _, l := range v.logs {
+		b.WriteString(l.Time.Format("15:04:05.000") + " " + l.Text + "\n")
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
 func (v *OperationLogView) scrollUp(n int) {
 	v.scrollPos -= n
 	if v.scrollPos < 0 {
@@ -274,15 +466,28 @@ func (v *OperationLogView) View() string {
 	if endIdx > len(v.logs) {
 		endIdx = len(v.logs)
 	}
-	if startIdx < len(v.logs) {
-		logLines = v.logs[startIdx:endIdx]
+	current := -1
+	if v.query != "" && v.matchIdx < len(v.matches) {
+		current = v.matches[v.matchIdx]
 	}
-
-	// 截断过长的行
-	for i, line := range logLines {
-		if len(line) > contentWidth {
-			logLines[i] = line[:contentWidth-3] + "..."
+	for i := startIdx; i < endIdx; i++ {
+		line := v.logs[i].Text
+		if v.showTime {
+			line = v.logs[i].Time.Format("15:04:05") + " " + line
+		}
+		// 截断过长的行（当前匹配行留出标记的位置），再高亮搜索匹配
+		width := contentWidth
+		if i == current {
+			width--
 		}
+		line = format.Truncate(line, width)
+		if v.query != "" {
+			line = format.HighlightRanges(line, format.MatchRanges(line, v.query), func(s string) string { return logMatchStyle.Render(s) })
+		}
+		if i == current {
+			line = logRunningStyle.Render("▶") + line
+		}
+		logLines = append(logLines, line)
 	}
 
 	// 填充空行
@@ -295,23 +500,29 @@ func (v *OperationLogView) View() string {
 	// 滚动指示器
 	var scrollInfo string
 	if len(v.logs) > visibleLines {
-		scrollInfo = logHintStyle.Render(
-			" [" + string(rune('0'+startIdx+1)) + "-" + 
-			string(rune('0'+endIdx)) + "/" + 
-			string(rune('0'+len(v.logs))) + "]",
-		)
-		// 使用 fmt.Sprintf 更准确
 		scrollInfo = logHintStyle.Render(
 			" [Lines: " + itoa(startIdx+1) + "-" + itoa(endIdx) + "/" + itoa(len(v.logs)) + "]",
 		)
 	}
+	if v.query != "" && len(v.matches) > 0 {
+		scrollInfo += logHintStyle.Render(" [Match " + itoa(v.matchIdx+1) + "/" + itoa(len(v.matches)) + "]")
+	}
 
-	// 提示
+	// 提示（输入搜索词或保存路径时显示输入行）
+	cursor := lipgloss.NewStyle().Reverse(true).Render(" ")
 	var hint string
-	if v.running {
-		hint = logHintStyle.Render("j/k=Scroll  g/G=Top/Bottom")
-	} else {
-		hint = logHintStyle.Render("j/k=Scroll  g/G=Top/Bottom  Esc/q=Close")
+	switch {
+	case v.searching:
+		hint = logTitleStyle.Render("Search:") + " " + v.query + cursor + "  " + logHintStyle.Render("[Enter=Confirm | Esc=Cancel]")
+	case v.saving:
+		hint = logTitleStyle.Render("💾 Save to:") + " " + v.savePath + cursor + "  " + logHintStyle.Render("[Enter=Save | Esc=Cancel]")
+	case v.running:
+		hint = logHintStyle.Render("j/k=Scroll  g/G=Top/Bottom  /=Search  n/N=Next/Prev  t=Timestamps  x=Cancel")
+	default:
+		hint = logHintStyle.Render("j/k=Scroll  g/G=Top/Bottom  /=Search  n/N=Next/Prev  t=Timestamps  S=Save  Esc/q=Close")
+	}
+	if v.notice != "" && !v.searching && !v.saving {
+		hint = logHintStyle.Render(v.notice) + "\n " + hint
 	}
 
 	// 组装
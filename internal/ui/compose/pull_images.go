@@ -0,0 +1,134 @@
+package compose
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/task"
+)
+
+// pullTickInterval 拉取进度汇总的刷新间隔
+const pullTickInterval = 500 * time.Millisecond
+
+// pullBatch 一次拉取项目全部服务镜像时提交的任务
+type pullBatch struct {
+	project string
+	taskIDs []string
+	images  []string // 与 taskIDs 一一对应
+}
+
+// progress 统计批次中已完成、失败和未结束的任务
+func (b *pullBatch) progress() (done, running int, failed []string) {
+	manager := task.GetManager()
+	for i, id := range b.taskIDs {
+		t := manager.GetTask(id)
+		if t == nil {
+			// 已被清理的任务视为完成
+			done++
+			continue
+		}
+		switch t.Status() {
+		case task.StatusCompleted:
+			done++
+		case task.StatusFailed, task.StatusCancelled:
+			failed = append(failed, b.images[i])
+		default:
+			running++
+		}
+	}
+	return done, running, failed
+}
+
+// startPullImages 读取 compose config 中各服务的镜像，随后为每个镜像提交拉取任务
+func (v *DetailView) startPullImages() tea.Cmd {
+	if v.project == nil || v.composeClient == nil || v.dockerClient == nil {
+		v.errorMsg = "Cannot pull images: client or project not initialized"
+		return v.clearMessageAfter(3)
+	}
+	if v.pullBatch != nil {
+		v.errorMsg = "Service images are already being pulled"
+		return v.clearMessageAfter(3)
+	}
+
+	v.errorMsg = ""
+	v.successMsg = "Reading service images..."
+	client := v.composeClient
+	project := v.project
+	return func() tea.Msg {
+		images, err := client.ServiceImages(project)
+		return detailPullImagesMsg{images: images, err: err}
+	}
+}
+
+// submitPulls 为每个不同的镜像提交一个拉取任务，并行数量由任务管理器的工作池限制
+func (v *DetailView) submitPulls(msg detailPullImagesMsg) tea.Cmd {
+	if msg.err != nil {
+		v.successMsg = ""
+		v.errorMsg = fmt.Sprintf("Failed to read service images: %v", msg.err)
+		return v.clearMessageAfter(5)
+	}
+
+	seen := make(map[string]bool)
+	var images []string
+	for _, img := range msg.images {
+		if !seen[img] {
+			seen[img] = true
+			images = append(images, img)
+		}
+	}
+	if len(images) == 0 {
+		v.successMsg = ""
+		v.errorMsg = "No images to pull (services are built locally)"
+		return v.clearMessageAfter(3)
+	}
+	sort.Strings(images)
+
+	batch := &pullBatch{project: v.project.Name, images: images}
+	manager := task.GetManager()
+	for _, img := range images {
+		batch.taskIDs = append(batch.taskIDs, manager.Submit(task.NewPullTask(v.dockerClient, img)))
+	}
+	v.pullBatch = batch
+	v.successMsg = fmt.Sprintf("Pulling %d images for %s", len(images), batch.project)
+	return v.tickPullBatch()
+}
+
+// tickPullBatch 定时刷新拉取进度
+func (v *DetailView) tickPullBatch() tea.Cmd {
+	return tea.Tick(pullTickInterval, func(time.Time) tea.Msg {
+		return detailPullTickMsg{}
+	})
+}
+
+// updatePullBatch 刷新拉取进度汇总，全部结束后显示结果
+func (v *DetailView) updatePullBatch() tea.Cmd {
+	batch := v.pullBatch
+	if batch == nil {
+		return nil
+	}
+
+	done, running, failed := batch.progress()
+	total := len(batch.taskIDs)
+	if running > 0 {
+		v.errorMsg = ""
+		v.successMsg = fmt.Sprintf("Pulling images for %s: %d/%d done", batch.project, done+len(failed), total)
+		if len(failed) > 0 {
+			v.successMsg += fmt.Sprintf(", %d failed", len(failed))
+		}
+		return v.tickPullBatch()
+	}
+
+	v.pullBatch = nil
+	if len(failed) > 0 {
+		v.successMsg = ""
+		v.errorMsg = fmt.Sprintf("Pulled %d/%d images, failed: %s", done, total, strings.Join(failed, ", "))
+		return v.clearMessageAfter(10)
+	}
+	v.errorMsg = ""
+	v.successMsg = fmt.Sprintf("Pulled %d images for %s", total, batch.project)
+	return v.clearMessageAfter(5)
+}
@@ -0,0 +1,154 @@
+package compose
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	composelib "docktui/internal/compose"
+	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/metrics"
+	"docktui/internal/ui/components"
+)
+
+// statsColumnWidth CPU、内存列的宽度
+const statsColumnWidth = 8
+
+// detailStatsTickMsg 刷新服务资源列，gen 与当前不一致的是已停止的刷新
+type detailStatsTickMsg struct {
+	gen int
+}
+
+// serviceStats 项目中运行的容器的资源采样（每个容器一个统计数据流），按服务汇总后显示在服务表格中
+type serviceStats struct {
+	poller  *metrics.Poller
+	subs    map[string]<-chan metrics.Sample // 容器 ID -> 订阅
+	samples map[string]metrics.Sample        // 容器 ID -> 最新采样
+	gen     int
+}
+
+// newServiceStats 创建资源采样，客户端不支持统计数据流时返回 nil（不显示资源列）
+func newServiceStats(client docker.Client) *serviceStats {
+	src, ok := client.(metrics.Source)
+	if !ok {
+		return nil
+	}
+	return &serviceStats{
+		poller:  metrics.NewPoller(src),
+		subs:    make(map[string]<-chan metrics.Sample),
+		samples: make(map[string]metrics.Sample),
+	}
+}
+
+// sync 订阅运行中服务的容器，取消已不属于项目的容器的订阅
+func (s *serviceStats) sync(services []composelib.Service) {
+	wanted := make(map[string]bool)
+	for _, svc := range services {
+		if svc.Running == 0 {
+			continue
+		}
+		for _, id := range svc.Containers {
+			wanted[id] = true
+		}
+	}
+	for id, sub := range s.subs {
+		if !wanted[id] {
+			s.poller.Unsubscribe(sub)
+			delete(s.subs, id)
+			delete(s.samples, id)
+		}
+	}
+	for id := range wanted {
+		if _, ok := s.subs[id]; !ok {
+			s.subs[id] = s.poller.Subscribe(id)
+		}
+	}
+}
+
+// drain 读取每个订阅的最新采样（不阻塞）；数据流结束的容器（通常已停止）不再显示，下次刷新服务时重新订阅
+func (s *serviceStats) drain() {
+	for id, sub := range s.subs {
+		select {
+		case sample, ok := <-sub:
+			if !ok || sample.Err != nil {
+				delete(s.subs, id)
+				delete(s.samples, id)
+				continue
+			}
+			s.samples[id] = sample
+		default:
+		}
+	}
+}
+
+// stop 取消所有订阅
+func (s *serviceStats) stop() {
+	for id, sub := range s.subs {
+		s.poller.Unsubscribe(sub)
+		delete(s.subs, id)
+	}
+	s.samples = make(map[string]metrics.Sample)
+	s.gen++
+}
+
+// tick 按资源统计的刷新间隔（配置文件 refresh.stats）刷新
+func (s *serviceStats) tick() tea.Cmd {
+	gen := s.gen
+	return tea.Tick(components.StatsRefreshInterval(), func(time.Time) tea.Msg { return detailStatsTickMsg{gen: gen} })
+}
+
+// cells 服务的 CPU（各副本之和）和内存列，还没有采样时显示 -
+func (s *serviceStats) cells(svc composelib.Service) (cpu, mem string) {
+	var cpuTotal float64
+	var memTotal uint64
+	found := false
+	for _, id := range svc.Containers {
+		if sample, ok := s.samples[id]; ok {
+			cpuTotal += sample.CPUPercent
+			memTotal += sample.MemoryUsage
+			found = true
+		}
+	}
+	if !found {
+		return "-", "-"
+	}
+	return fmt.Sprintf("%.1f%%", cpuTotal), format.CompactSize(memTotal)
+}
+
+// startStats 订阅当前服务的容器并开始刷新资源列
+func (v *DetailView) startStats() tea.Cmd {
+	if v.stats == nil {
+		return nil
+	}
+	v.stats.sync(v.services)
+	v.stats.gen++
+	return v.stats.tick()
+}
+
+// ResumeStats 从日志、容器详情返回时恢复资源列的刷新（离开期间的刷新消息已被其他视图丢弃）
+func (v *DetailView) ResumeStats() tea.Cmd {
+	if v.stats == nil || len(v.stats.subs) == 0 {
+		return nil
+	}
+	v.stats.gen++
+	return v.stats.tick()
+}
+
+// StopStats 离开项目详情时关闭所有统计数据流
+func (v *DetailView) StopStats() {
+	if v.stats != nil {
+		v.stats.stop()
+	}
+}
+
+// updateStats 处理刷新消息
+func (v *DetailView) updateStats(msg detailStatsTickMsg) tea.Cmd {
+	if v.stats == nil || msg.gen != v.stats.gen {
+		return nil
+	}
+	v.stats.drain()
+	v.updateServiceTable()
+	return v.stats.tick()
+}
@@ -1,6 +1,11 @@
 package compose
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	composelib "docktui/internal/compose"
+	"docktui/internal/ui/styles"
+)
 
 // 共享样式定义
 var (
@@ -91,3 +96,26 @@ var (
 			Foreground(lipgloss.Color("245")).
 			Italic(true)
 )
+
+// ProjectStatus compose 项目状态对应的通用状态类别，部分运行视为警告
+func ProjectStatus(s composelib.ProjectStatus) (styles.Status, bool) {
+	switch s {
+	case composelib.StatusRunning:
+		return styles.StatusRunning, true
+	case composelib.StatusPartial:
+		return styles.StatusWarning, true
+	case composelib.StatusStopped:
+		return styles.StatusStopped, true
+	case composelib.StatusError:
+		return styles.StatusError, true
+	}
+	return 0, false
+}
+
+// projectStatusLabel 带状态图标的项目状态文本
+func projectStatusLabel(s composelib.ProjectStatus) string {
+	if st, ok := ProjectStatus(s); ok {
+		return styles.StatusLabel(st, s.String())
+	}
+	return "? Unknown"
+}
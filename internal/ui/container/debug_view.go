@@ -0,0 +1,134 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
+)
+
+// DebugView 调试运行对话框：以替换后的入口命令从同一镜像启动临时容器并进入 shell，退出后删除
+type DebugView struct {
+	container *docker.Container
+
+	entrypointInput textinput.Model
+
+	visible  bool
+	width    int
+	errorMsg string
+}
+
+// NewDebugView 创建调试运行对话框
+func NewDebugView() *DebugView {
+	input := textinput.New()
+	input.CharLimit = 256
+	input.Width = 44
+	input.Prompt = ""
+
+	return &DebugView{entrypointInput: input}
+}
+
+// Show 显示对话框，入口命令默认为 sleep infinity
+func (v *DebugView) Show(container *docker.Container) {
+	v.visible = true
+	v.container = container
+	v.errorMsg = ""
+	v.entrypointInput.SetValue(strings.Join(docker.DefaultDebugEntrypoint, " "))
+	v.entrypointInput.CursorEnd()
+	v.entrypointInput.Focus()
+}
+
+// Hide 隐藏对话框
+func (v *DebugView) Hide() {
+	v.visible = false
+	v.container = nil
+	v.entrypointInput.Blur()
+}
+
+// IsVisible 是否可见
+func (v *DebugView) IsVisible() bool {
+	return v.visible
+}
+
+// SetWidth 设置宽度
+func (v *DebugView) SetWidth(width int) {
+	v.width = width
+}
+
+// GetContainer 获取源容器
+func (v *DebugView) GetContainer() *docker.Container {
+	return v.container
+}
+
+// Entrypoint 获取输入的入口命令（按空白拆分）
+func (v *DebugView) Entrypoint() []string {
+	return strings.Fields(v.entrypointInput.Value())
+}
+
+// Update 处理输入
+// 返回值: (confirmed bool, handled bool, cmd tea.Cmd)
+func (v *DebugView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
+	if !v.visible {
+		return false, false, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, false, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEsc:
+		v.Hide()
+		return false, true, nil
+	case tea.KeyEnter:
+		if len(v.Entrypoint()) == 0 {
+			v.errorMsg = "entrypoint is required"
+			return false, true, nil
+		}
+		return true, true, nil
+	}
+
+	var cmd tea.Cmd
+	v.entrypointInput, cmd = v.entrypointInput.Update(msg)
+	return false, true, cmd
+}
+
+// View 渲染对话框
+func (v *DebugView) View() string {
+	if !v.visible || v.container == nil {
+		return ""
+	}
+
+	title := editTitleStyle.Render("🐞 Debug Run: " + v.container.Name)
+	source := editHintStyle.Render("Image: ") + editValueStyle.Render(v.container.Image)
+	if v.container.Command != "" {
+		source += "\n" + editHintStyle.Render("Command: ") + editValueStyle.Render(v.container.Command)
+	}
+	inputLine := editLabelStyle.Render("Entrypoint:") + " " +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Render(v.entrypointInput.View())
+
+	contentParts := []string{title, "", source, "", inputLine, "",
+		editHintStyle.Render("Starts a temporary container from the same image with the same env,"),
+		editHintStyle.Render("mounts and networks (no host ports, no restart, no healthcheck),"),
+		editHintStyle.Render("opens a shell in it and removes it when the shell exits"),
+	}
+	if v.errorMsg != "" {
+		contentParts = append(contentParts, "", editErrorStyle.Render(fmt.Sprintf("❌ %s", v.errorMsg)))
+	}
+	contentParts = append(contentParts, "", editHintStyle.Render("[Enter=Start] [Esc=Cancel]"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, contentParts...)
+
+	boxWidth := v.width - 10
+	if boxWidth < 65 {
+		boxWidth = 65
+	}
+	if boxWidth > 75 {
+		boxWidth = 75
+	}
+	return editBoxStyle.Width(boxWidth).Render(content)
+}
@@ -3,15 +3,20 @@ package container
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
 
 	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/notes"
 	"docktui/internal/ui/components"
+	"docktui/internal/ui/styles"
 )
 
 // DetailView 容器详情视图
@@ -24,6 +29,7 @@ type DetailView struct {
 	containerID   string
 	containerName string
 	details       *docker.ContainerDetails
+	pinned        bool // 已固定：同名容器重建后自动重新附加
 	
 	loading    bool
 	errorMsg   string
@@ -39,18 +45,39 @@ type DetailView struct {
 	// 进程列表视图
 	processesView *components.ProcessesView
 	
+	// 存储标签页选中的挂载及操作反馈
+	mountCursor int
+	mountMsg    string
+	
+	// 环境变量标签页：搜索和敏感值显示
+	envSearch    textinput.Model
+	envSearching bool
+	envReveal    bool // 显示被遮盖的敏感值
+	
+	// 网络标签页：容器内 /etc/resolv.conf 和 /etc/hosts 查看器
+	dnsViewer *components.JSONViewer
+	dnsMsg    string
+	
 	keys components.KeyMap
 }
 
 // NewDetailView 创建容器详情视图
 func NewDetailView(dockerClient docker.Client) *DetailView {
+	envSearch := textinput.New()
+	envSearch.Placeholder = "key or value"
+	envSearch.CharLimit = 100
+	envSearch.Width = 30
+	envSearch.Prompt = "/ "
+	
 	return &DetailView{
+		envSearch:     envSearch,
 		dockerClient:  dockerClient,
 		keys:          components.DefaultKeyMap(),
 		width:         100,
 		height:        30,
 		statsView:     components.NewStatsView(dockerClient),
 		processesView: components.NewProcessesView(dockerClient),
+		dnsViewer:     components.NewJSONViewer(),
 	}
 }
 
@@ -58,8 +85,53 @@ func NewDetailView(dockerClient docker.Client) *DetailView {
 func (v *DetailView) SetContainer(containerID, containerName string) {
 	v.containerID = containerID
 	v.containerName = containerName
+	v.mountCursor = 0
+	v.mountMsg = ""
+	v.envReveal = false
+	v.envSearching = false
+	v.envSearch.Blur()
+	v.envSearch.SetValue("")
+	v.dnsViewer.Hide()
+	v.dnsMsg = ""
+	v.statsView.SetContainer(containerID)
+	v.processesView.SetContainer(containerID)
+}
+
+// IsSearching 是否正在输入环境变量搜索
+func (v *DetailView) IsSearching() bool {
+	return v.envSearching
+}
+
+// IsCapturingInput 是否正在接收输入（环境变量搜索或 DNS 文件查看器），此时不处理全局快捷键
+func (v *DetailView) IsCapturingInput() bool {
+	return v.envSearching || v.dnsViewer.IsVisible()
+}
+
+// ContainerID 返回当前查看的容器 ID
+func (v *DetailView) ContainerID() string {
+	return v.containerID
+}
+
+// ContainerName 返回当前查看的容器名称
+func (v *DetailView) ContainerName() string {
+	if v.details != nil {
+		return v.details.Name
+	}
+	return v.containerName
+}
+
+// SetPinned 设置是否显示固定标记
+func (v *DetailView) SetPinned(pinned bool) {
+	v.pinned = pinned
+}
+
+// Reattach 附加到同名的新容器实例，保留当前标签页，资源监控和进程列表继续刷新
+func (v *DetailView) Reattach(containerID string) tea.Cmd {
+	v.containerID = containerID
 	v.statsView.SetContainer(containerID)
 	v.processesView.SetContainer(containerID)
+	v.loading = true
+	return v.loadWithStats()
 }
 
 // Init 初始化
@@ -68,9 +140,23 @@ func (v *DetailView) Init() tea.Cmd {
 		return nil
 	}
 	v.loading = true
+	return v.loadWithStats()
+}
+
+// loadWithStats 加载详情，停留在资源监控标签时同时订阅新容器的统计数据
+func (v *DetailView) loadWithStats() tea.Cmd {
+	if v.currentTab == 1 {
+		return tea.Batch(v.loadDetails, v.statsView.Start())
+	}
 	return v.loadDetails
 }
 
+// Stop 离开详情视图时停止资源监控和进程列表刷新
+func (v *DetailView) Stop() {
+	v.statsView.Stop()
+	v.processesView.Stop()
+}
+
 // Update 处理消息
 func (v *DetailView) Update(msg tea.Msg) (*DetailView, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -78,6 +164,30 @@ func (v *DetailView) Update(msg tea.Msg) (*DetailView, tea.Cmd) {
 		v.details = msg.Details
 		v.loading = false
 		v.errorMsg = ""
+		if v.mountCursor >= len(v.details.Mounts) {
+			v.mountCursor = 0
+		}
+		return v, nil
+	
+	case hostPathMsg:
+		if msg.err != nil {
+			v.mountMsg = "❌ " + msg.err.Error()
+		} else {
+			v.mountMsg = msg.message
+		}
+		return v, nil
+	
+	case dnsFilesMsg:
+		if msg.containerID != v.containerID {
+			return v, nil
+		}
+		if msg.err != nil {
+			v.dnsMsg = "❌ " + msg.err.Error()
+			return v, nil
+		}
+		v.dnsMsg = ""
+		v.dnsViewer.SetSize(v.width, v.height)
+		v.dnsViewer.Show("DNS: "+v.ContainerName(), formatDNSFiles(msg.files))
 		return v, nil
 		
 	case DetailsLoadErrorMsg:
@@ -102,6 +212,18 @@ func (v *DetailView) Update(msg tea.Msg) (*DetailView, tea.Cmd) {
 		return v, nil
 		
 	case tea.KeyMsg:
+		// DNS 文件查看器打开时由它处理按键
+		if v.dnsViewer.IsVisible() {
+			v.dnsViewer.Update(msg)
+			return v, nil
+		}
+		
+		// 网络标签页：读取容器内实际生效的 /etc/resolv.conf 和 /etc/hosts
+		if v.currentTab == 2 && v.details != nil && msg.String() == "d" {
+			v.dnsMsg = "⏳ Reading /etc/resolv.conf and /etc/hosts..."
+			return v, v.loadDNSFiles()
+		}
+		
 		// 如果在资源监控标签页，先让 statsView 处理按键
 		if v.currentTab == 1 {
 			cmd := v.statsView.Update(msg)
@@ -110,6 +232,20 @@ func (v *DetailView) Update(msg tea.Msg) (*DetailView, tea.Cmd) {
 			}
 		}
 		
+		// 存储标签页：选择挂载并打开/复制宿主机路径
+		if v.currentTab == 3 && v.details != nil && len(v.details.Mounts) > 0 {
+			if cmd, handled := v.handleMountKey(msg.String()); handled {
+				return v, cmd
+			}
+		}
+		
+		// 环境变量标签页：搜索和显示敏感值
+		if v.currentTab == 4 && v.details != nil {
+			if cmd, handled := v.handleEnvKey(msg); handled {
+				return v, cmd
+			}
+		}
+		
 		switch {
 		case msg.String() == "esc":
 			// ESC 返回上一级
@@ -123,18 +259,18 @@ func (v *DetailView) Update(msg tea.Msg) (*DetailView, tea.Cmd) {
 			if v.currentTab > 0 {
 				v.currentTab--
 			} else {
-				v.currentTab = 6
+				v.currentTab = 7
 			}
 			v.scrollOffset = 0 // 切换标签时重置滚动
 			return v, v.handleTabChange(oldTab, v.currentTab)
 		case msg.String() == "right", msg.String() == "l":
 			oldTab := v.currentTab
-			v.currentTab = (v.currentTab + 1) % 7
+			v.currentTab = (v.currentTab + 1) % 8
 			v.scrollOffset = 0 // 切换标签时重置滚动
 			return v, v.handleTabChange(oldTab, v.currentTab)
 		case msg.String() == "tab":
 			oldTab := v.currentTab
-			v.currentTab = (v.currentTab + 1) % 7
+			v.currentTab = (v.currentTab + 1) % 8
 			v.scrollOffset = 0 // 切换标签时重置滚动
 			return v, v.handleTabChange(oldTab, v.currentTab)
 		case msg.String() == "j", msg.String() == "down":
@@ -158,6 +294,85 @@ func (v *DetailView) Update(msg tea.Msg) (*DetailView, tea.Cmd) {
 	return v, nil
 }
 
+// handleMountKey 处理存储标签页中挂载相关的按键
+func (v *DetailView) handleMountKey(k string) (tea.Cmd, bool) {
+	switch k {
+	case "j", "down":
+		if v.mountCursor < len(v.details.Mounts)-1 {
+			v.mountCursor++
+		}
+		v.mountMsg = ""
+		return nil, true
+	case "k", "up":
+		if v.mountCursor > 0 {
+			v.mountCursor--
+		}
+		v.mountMsg = ""
+		return nil, true
+	case "y":
+		return copyHostPath(v.mountSource(v.details.Mounts[v.mountCursor])), true
+	case "o":
+		m := v.details.Mounts[v.mountCursor]
+		if m.Type != "bind" {
+			v.mountMsg = "⚠️ Only bind mounts have a host path to open, press y to copy the source"
+			return nil, true
+		}
+		if host := docker.RemoteHost(); host != "" {
+			v.mountMsg = fmt.Sprintf("⚠️ %s is on remote host %s, press y to copy the path", m.Source, host)
+			return nil, true
+		}
+		return openHostPath(v.mountSource(m)), true
+	}
+	return nil, false
+}
+
+// handleEnvKey 处理环境变量标签页的按键：/ 搜索，v 显示/遮盖敏感值，Esc 先清除搜索
+func (v *DetailView) handleEnvKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if v.envSearching {
+		switch msg.Type {
+		case tea.KeyEsc:
+			v.envSearching = false
+			v.envSearch.Blur()
+			v.envSearch.SetValue("")
+		case tea.KeyEnter:
+			v.envSearching = false
+			v.envSearch.Blur()
+		default:
+			var cmd tea.Cmd
+			v.envSearch, cmd = v.envSearch.Update(msg)
+			v.scrollOffset = 0
+			return cmd, true
+		}
+		v.scrollOffset = 0
+		return nil, true
+	}
+	
+	switch msg.String() {
+	case "/":
+		v.envSearching = true
+		v.scrollOffset = 0
+		return v.envSearch.Focus(), true
+	case "v":
+		v.envReveal = !v.envReveal
+		return nil, true
+	case "esc":
+		if v.envSearch.Value() != "" {
+			v.envSearch.SetValue("")
+			v.scrollOffset = 0
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// mountSource 返回挂载源在本机可访问的路径（Windows/WSL 下转换 Docker Desktop 的路径格式）
+func (v *DetailView) mountSource(m docker.MountInfo) string {
+	if m.Type != "bind" || docker.RemoteHost() != "" {
+		return m.Source
+	}
+	return docker.LocalHostPath(m.Source)
+}
+
 // handleTabChange 处理标签页切换
 func (v *DetailView) handleTabChange(oldTab, newTab int) tea.Cmd {
 	// 离开资源监控标签时停止监控
@@ -185,6 +400,10 @@ func (v *DetailView) handleTabChange(oldTab, newTab int) tea.Cmd {
 
 // View 渲染视图
 func (v *DetailView) View() string {
+	if v.dnsViewer.IsVisible() {
+		return v.dnsViewer.View()
+	}
+	
 	// 渲染各部分
 	header := v.renderHeader()
 	footer := v.renderKeyHints()
@@ -270,38 +489,25 @@ func (v *DetailView) renderHeader() string {
 	infoStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("245"))
 	
+	pinBadge := ""
+	if v.pinned {
+		pinBadge = "  " + titleStyle.Render("📌 Pinned")
+	}
+	
 	if v.details == nil {
 		title := titleStyle.Render("📋 " + v.containerName)
-		return headerStyle.Render(title)
+		return headerStyle.Render(title + pinBadge)
 	}
 	
 	// 状态徽章
-	var statusStyle lipgloss.Style
-	var statusText string
-	switch v.details.State {
-	case "running":
-		statusStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("82")).
-			Bold(true)
-		statusText = "● RUNNING"
-	case "exited":
-		statusStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245"))
-		statusText = "■ STOPPED"
-	case "paused":
-		statusStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("220"))
-		statusText = "❚❚ PAUSED"
-	default:
-		statusStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196"))
-		statusText = "✗ " + strings.ToUpper(v.details.State)
-	}
+	state := styles.ContainerStatus(v.details.State, fmt.Sprintf("Exited (%d)", v.details.ExitCode))
+	statusStyle := styles.StatusStyle(state).Bold(state == styles.StatusRunning)
+	statusText := styles.StatusLabel(state, strings.ToUpper(v.details.State))
 	
 	// 第一行：名称 + 状态
 	title := titleStyle.Render("📋 " + v.details.Name)
 	status := statusStyle.Render(statusText)
-	line1 := title + "  " + status
+	line1 := title + "  " + status + pinBadge
 	
 	// 第二行：ID + 镜像 + 创建时间
 	shortID := v.details.ID
@@ -314,24 +520,53 @@ func (v *DetailView) renderHeader() string {
 	if v.width > 80 {
 		line2 = infoStyle.Render(fmt.Sprintf("ID: %s  │  Image: %s  │  Created: %s",
 			shortID,
-			v.truncate(v.details.Image, 30),
+			format.Truncate(v.details.Image, 30),
 			v.details.Created.Format("2006-01-02 15:04"),
 		))
 	} else {
-		line2 = infoStyle.Render(fmt.Sprintf("ID: %s  │  %s", shortID, v.truncate(v.details.Image, 20)))
+		line2 = infoStyle.Render(fmt.Sprintf("ID: %s  │  %s", shortID, format.Truncate(v.details.Image, 20)))
 	}
 	
-	content := line1 + "\n" + line2
+	content := line1 + "\n" + line2 + "\n" + v.renderLifecycleLine(infoStyle)
 	return headerStyle.Render(content)
 }
 
+// renderLifecycleLine 头部第三行：运行/退出了多久、重启次数和重启策略，排查时最先要看的信息
+func (v *DetailView) renderLifecycleLine(infoStyle lipgloss.Style) string {
+	var uptime string
+	switch {
+	case v.details.State == "running" && !v.details.StartedAt.IsZero():
+		uptime = "Up " + format.Duration(time.Since(v.details.StartedAt))
+		if v.width > 80 {
+			uptime += " (since " + v.details.StartedAt.Local().Format("2006-01-02 15:04") + ")"
+		}
+	case !v.details.FinishedAt.IsZero():
+		uptime = fmt.Sprintf("Exited (%d) %s", v.details.ExitCode, format.Ago(v.details.FinishedAt))
+	default:
+		uptime = "Never started"
+	}
+
+	restarts := infoStyle.Render(fmt.Sprintf("Restarts: %d", v.details.RestartCount))
+	if v.details.RestartCount > 0 {
+		restarts = lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true).
+			Render(fmt.Sprintf("⟳ Restarts: %d", v.details.RestartCount))
+	}
+
+	restartPolicy := v.details.RestartPolicy
+	if restartPolicy == "" {
+		restartPolicy = "no"
+	}
+	sep := infoStyle.Render("  │  ")
+	return infoStyle.Render(uptime) + sep + restarts + sep + infoStyle.Render("Policy: "+restartPolicy)
+}
+
 // renderTabBar 渲染标签页导航
 func (v *DetailView) renderTabBar() string {
-	tabs := []string{"Basic Info", "Resources", "Network", "Storage", "Env Vars", "Labels", "Processes"}
+	tabs := []string{"Basic Info", "Resources", "Network", "Storage", "Env Vars", "Labels", "Processes", "Security"}
 	
 	// 根据宽度决定是否使用简短标签
 	if v.width < 80 {
-		tabs = []string{"Basic", "Stats", "Net", "Storage", "Env", "Labels", "Proc"}
+		tabs = []string{"Basic", "Stats", "Net", "Storage", "Env", "Labels", "Proc", "Sec"}
 	}
 	
 	activeStyle := lipgloss.NewStyle().
@@ -398,10 +633,141 @@ func (v *DetailView) renderBasicInfo() string {
 	lines = append(lines, row("Image", v.details.Image))
 	lines = append(lines, row("Created", v.details.Created.Format("2006-01-02 15:04:05")))
 	lines = append(lines, row("Status", v.details.Status))
+	if !v.details.StartedAt.IsZero() {
+		lines = append(lines, row("Started", v.details.StartedAt.Local().Format("2006-01-02 15:04:05")))
+	}
+	if !v.details.FinishedAt.IsZero() {
+		lines = append(lines, row("Finished", fmt.Sprintf("%s (exit %d)", v.details.FinishedAt.Local().Format("2006-01-02 15:04:05"), v.details.ExitCode)))
+	}
 	lines = append(lines, row("Restart", restartPolicy))
+	lines = append(lines, row("Restarts", fmt.Sprintf("%d", v.details.RestartCount)))
 	lines = append(lines, row("Network", v.details.NetworkMode))
 	
-	return "\n" + v.wrapInBox("Basic Information", strings.Join(lines, "\n"), boxWidth)
+	content := "\n" + v.wrapInBox("Basic Information", strings.Join(lines, "\n"), boxWidth)
+	
+	// 本地备注（在容器列表按 N 编辑）
+	if note := notes.Lookup(notes.KindContainer, v.details.Name); !note.Empty() {
+		var noteLines []string
+		if note.Color != "" {
+			noteLines = append(noteLines, row("Tag", notes.ColorBadge(note.Color)+" "+note.Color))
+		}
+		if note.Text != "" {
+			noteLines = append(noteLines, row("Note", note.Text))
+		}
+		if note.StopTimeout > 0 {
+			noteLines = append(noteLines, row("Stop wait", fmt.Sprintf("%ds", note.StopTimeout)))
+		}
+		noteLines = append(noteLines, row("Updated", note.UpdatedAt.Format("2006-01-02 15:04")))
+		content += "\n\n" + v.wrapInBox("📝 Notes", strings.Join(noteLines, "\n"), boxWidth)
+	}
+	return content
+}
+
+// renderSecurityTab 渲染安全标签页：安全状况汇总和详细配置
+func (v *DetailView) renderSecurityTab() string {
+	boxWidth := v.width - 6
+	if boxWidth < 60 {
+		boxWidth = 60
+	}
+	return "\n" + v.wrapInBox("Security Posture", v.renderSecurityPosture(), boxWidth) +
+		"\n\n" + v.wrapInBox("Cgroup, Namespaces & Capabilities", v.renderSecurityInfo(), boxWidth)
+}
+
+// renderSecurityPosture 渲染安全检查结果，红/黄/绿表示风险/注意/正常
+func (v *DetailView) renderSecurityPosture() string {
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("81")).
+		Width(14)
+	levelStyles := map[docker.SecurityLevel]lipgloss.Style{
+		docker.SecurityOK:   lipgloss.NewStyle().Foreground(lipgloss.Color("82")),
+		docker.SecurityWarn: lipgloss.NewStyle().Foreground(lipgloss.Color("220")),
+		docker.SecurityRisk: lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true),
+	}
+
+	var lines []string
+	for _, f := range docker.AnalyzeSecurity(v.details) {
+		style := levelStyles[f.Level]
+		lines = append(lines, style.Render("●")+" "+labelStyle.Render(f.Check)+style.Render(f.Detail))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderSecurityInfo 渲染 cgroup、命名空间和权限配置，特权、共享宿主机命名空间和额外 capabilities 高亮显示
+func (v *DetailView) renderSecurityInfo() string {
+	sec := v.details.Security
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("81")).
+		Width(12)
+	valueStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+	warnStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("220")).
+		Bold(true)
+	dangerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196")).
+		Bold(true)
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("245"))
+
+	row := func(label, value string) string {
+		return labelStyle.Render(label) + value
+	}
+	// 命名空间：host 为红色，共享其他容器为黄色，私有为灰色
+	namespace := func(mode, fallback string) string {
+		switch {
+		case mode == "host":
+			return dangerStyle.Render("host")
+		case strings.HasPrefix(mode, "container:"):
+			return warnStyle.Render(mode)
+		case mode == "":
+			return hintStyle.Render(fallback)
+		}
+		return valueStyle.Render(mode)
+	}
+	list := func(items []string, style lipgloss.Style) string {
+		if len(items) == 0 {
+			return hintStyle.Render("-")
+		}
+		return style.Render(strings.Join(items, ", "))
+	}
+
+	var lines []string
+	if sec.Privileged {
+		lines = append(lines, row("Privileged", dangerStyle.Render("yes (all capabilities, all devices)")))
+	} else {
+		lines = append(lines, row("Privileged", valueStyle.Render("no")))
+	}
+	lines = append(lines, row("Cap Add", list(sec.CapAdd, warnStyle)))
+	lines = append(lines, row("Cap Drop", list(sec.CapDrop, valueStyle)))
+	if sec.User == "" {
+		lines = append(lines, row("User", hintStyle.Render("image default")))
+	} else {
+		lines = append(lines, row("User", valueStyle.Render(sec.User)))
+	}
+	if sec.ReadonlyRoot {
+		lines = append(lines, row("Rootfs", valueStyle.Render("read-only")))
+	}
+	lines = append(lines, "")
+	lines = append(lines, row("PID", namespace(sec.PidMode, "private")))
+	lines = append(lines, row("Network", namespace(v.details.NetworkMode, "default")))
+	lines = append(lines, row("IPC", namespace(sec.IpcMode, "private")))
+	lines = append(lines, row("UTS", namespace(sec.UTSMode, "private")))
+	lines = append(lines, row("User NS", namespace(sec.UsernsMode, "private")))
+	lines = append(lines, row("Cgroup NS", namespace(sec.CgroupnsMode, "daemon default")))
+	if sec.CgroupParent == "" {
+		lines = append(lines, row("Cgroup", hintStyle.Render("daemon default")))
+	} else {
+		lines = append(lines, row("Cgroup", valueStyle.Render(sec.CgroupParent)))
+	}
+	for _, opt := range sec.SecurityOpt {
+		style := valueStyle
+		if strings.HasSuffix(opt, "=unconfined") {
+			style = warnStyle
+		}
+		lines = append(lines, row("Security", style.Render(opt)))
+	}
+	return strings.Join(lines, "\n")
 }
 
 // renderTabContent 渲染标签页内容
@@ -423,6 +789,8 @@ func (v *DetailView) renderTabContent(availableHeight int) string {
 		content = v.renderLabelsInfo()
 	case 6:
 		content = v.renderProcessesInfo(availableHeight)
+	case 7:
+		content = v.renderSecurityTab()
 	default:
 		content = v.renderBasicInfo()
 	}
@@ -526,9 +894,58 @@ func (v *DetailView) renderNetworkInfo() string {
 	// 网络模式
 	s.WriteString("\n\n" + v.wrapInBox("Network Config", valueStyle.Render("Mode: "+v.details.NetworkMode), boxWidth))
 	
+	// 主机名和 DNS 配置
+	s.WriteString("\n\n" + v.wrapInBox("DNS", v.renderDNSConfig(valueStyle, hintStyle), boxWidth))
+	
+	hint := "d=View live /etc/resolv.conf and /etc/hosts"
+	if v.dnsMsg != "" {
+		hint = v.dnsMsg
+	}
+	s.WriteString("\n\n  " + hintStyle.Render(hint))
+	
 	return s.String()
 }
 
+// renderDNSConfig 渲染主机名、DNS 服务器、搜索域、选项和额外的 hosts 条目
+func (v *DetailView) renderDNSConfig(valueStyle, hintStyle lipgloss.Style) string {
+	dns := v.details.DNS
+	row := func(label, value string) string {
+		return hintStyle.Render(fmt.Sprintf("%-14s", label)) + valueStyle.Render(value)
+	}
+	
+	hostname := dns.Hostname
+	if dns.Domainname != "" {
+		hostname += "." + dns.Domainname
+	}
+	lines := []string{row("Hostname:", hostname)}
+	
+	if len(dns.Servers) == 0 {
+		lines = append(lines, row("Servers:", "")+hintStyle.Render("daemon default"))
+	} else {
+		lines = append(lines, row("Servers:", strings.Join(dns.Servers, ", ")))
+	}
+	if len(dns.Search) > 0 {
+		lines = append(lines, row("Search:", strings.Join(dns.Search, " ")))
+	}
+	if len(dns.Options) > 0 {
+		lines = append(lines, row("Options:", strings.Join(dns.Options, " ")))
+	}
+	
+	if len(dns.ExtraHosts) == 0 {
+		lines = append(lines, row("Extra hosts:", "")+hintStyle.Render("none"))
+	} else {
+		for i, entry := range dns.ExtraHosts {
+			label := ""
+			if i == 0 {
+				label = "Extra hosts:"
+			}
+			host, ip := docker.SplitExtraHost(entry)
+			lines = append(lines, row(label, fmt.Sprintf("%s → %s", host, ip)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // renderStorageInfo 渲染存储信息
 func (v *DetailView) renderStorageInfo() string {
 	boxWidth := v.width - 6
@@ -549,19 +966,48 @@ func (v *DetailView) renderStorageInfo() string {
 		return "\n" + v.wrapInBox("Mounts", hintStyle.Render("No mounts"), boxWidth)
 	}
 	
+	remoteStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("220"))
+	
+	cursorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("220")).
+		Bold(true)
+	
+	// 远程守护进程的 bind 挂载路径在远程主机上，明确标注
+	remoteHost := docker.RemoteHost()
+	
 	var lines []string
-	for _, m := range v.details.Mounts {
+	for i, m := range v.details.Mounts {
+		prefix := "  "
+		if i == v.mountCursor {
+			prefix = cursorStyle.Render("▶ ")
+		}
+		source := valueStyle.Render(v.mountSource(m))
+		if m.Type == "bind" && remoteHost != "" {
+			source = remoteStyle.Render(remoteHost+":") + source
+		} else if local := v.mountSource(m); local != m.Source {
+			source += hintStyle.Render(" (daemon: " + m.Source + ")")
+		}
 		// 不截断路径，完整显示
-		line := typeStyle.Render(fmt.Sprintf("[%-6s]", m.Type)) + " " +
-			valueStyle.Render(m.Source+" → "+m.Destination) + " " +
+		line := prefix + typeStyle.Render(fmt.Sprintf("[%-6s]", m.Type)) + " " +
+			source + valueStyle.Render(" → "+m.Destination) + " " +
 			hintStyle.Render("("+m.Mode+")")
 		lines = append(lines, line)
 	}
 	
-	return "\n" + v.wrapInBox("Mounts", strings.Join(lines, "\n"), boxWidth)
+	title := "Mounts"
+	if remoteHost != "" {
+		title = "Mounts (bind sources on remote host " + remoteHost + ")"
+	}
+	
+	hint := "j/k=Select  o=Open host path  y=Copy path"
+	if v.mountMsg != "" {
+		hint = v.mountMsg
+	}
+	return "\n" + v.wrapInBox(title, strings.Join(lines, "\n"), boxWidth) + "\n\n  " + hintStyle.Render(hint)
 }
 
-// renderEnvInfo 渲染环境变量
+// renderEnvInfo 渲染环境变量：按名称排序，支持搜索，敏感值默认遮盖
 func (v *DetailView) renderEnvInfo() string {
 	boxWidth := v.width - 6
 	if boxWidth < 60 {
@@ -577,44 +1023,89 @@ func (v *DetailView) renderEnvInfo() string {
 	hintStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("245"))
 	
+	maskedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("220"))
+	
 	if len(v.details.Env) == 0 {
 		return "\n" + v.wrapInBox("Environment Variables", hintStyle.Render("No environment variables"), boxWidth)
 	}
 	
-	// 分类
-	var appVars, sysVars []string
+	type envVar struct {
+		key, value string
+		secret     bool
+	}
+	
+	// 分类，按名称排序
+	var appVars, sysVars []envVar
 	sysKeys := map[string]bool{"PATH": true, "HOME": true, "USER": true, "SHELL": true, "TERM": true, "HOSTNAME": true}
+	query := strings.ToLower(strings.TrimSpace(v.envSearch.Value()))
+	masked := 0
 	
 	for _, env := range v.details.Env {
-		parts := strings.SplitN(env, "=", 2)
-		if len(parts) == 2 {
-			if sysKeys[parts[0]] {
-				sysVars = append(sysVars, env)
-			} else {
-				appVars = append(appVars, env)
-			}
+		key, value, ok := strings.Cut(env, "=")
+		if !ok {
+			continue
+		}
+		e := envVar{key: key, value: value, secret: docker.IsSecretEnv(key, value)}
+		if e.secret && !v.envReveal {
+			masked++
+		}
+		// 遮盖时只按名称搜索，避免通过搜索结果推测敏感值
+		if query != "" && !strings.Contains(strings.ToLower(key), query) &&
+			(e.secret && !v.envReveal || !strings.Contains(strings.ToLower(value), query)) {
+			continue
+		}
+		if sysKeys[key] {
+			sysVars = append(sysVars, e)
+		} else {
+			appVars = append(appVars, e)
 		}
 	}
-	
-	formatEnv := func(env string, isApp bool) string {
-		parts := strings.SplitN(env, "=", 2)
-		if len(parts) != 2 {
-			return env
+	sort.Slice(appVars, func(i, j int) bool { return appVars[i].key < appVars[j].key })
+	sort.Slice(sysVars, func(i, j int) bool { return sysVars[i].key < sysVars[j].key })
+	
+	formatEnv := func(e envVar, isApp bool) string {
+		value := e.value
+		if e.secret && !v.envReveal {
+			// 固定长度，不暴露值的长度
+			return keyStyle.Render(e.key) + " = " + maskedStyle.Render("•••••••• 🔒")
 		}
 		// 不截断，完整显示
 		if isApp {
-			return keyStyle.Render(parts[0]) + " = " + valueStyle.Render(parts[1])
+			return keyStyle.Render(e.key) + " = " + valueStyle.Render(value)
 		}
-		return hintStyle.Render(parts[0] + " = " + parts[1])
+		return hintStyle.Render(e.key + " = " + value)
 	}
 	
 	var s strings.Builder
 	
+	// 搜索栏和遮盖状态
+	var status []string
+	switch {
+	case v.envSearching:
+		status = append(status, v.envSearch.View())
+	case query != "":
+		status = append(status, keyStyle.Render("/ "+v.envSearch.Value()))
+	}
+	switch {
+	case v.envReveal:
+		status = append(status, maskedStyle.Render("⚠️ Secrets revealed (v=Mask)"))
+	case masked > 0:
+		status = append(status, hintStyle.Render(fmt.Sprintf("🔒 %d secret(s) masked (v=Reveal)", masked)))
+	}
+	status = append(status, hintStyle.Render("/=Search"))
+	s.WriteString("\n  " + strings.Join(status, "  ") + "\n")
+	
+	if len(appVars) == 0 && len(sysVars) == 0 {
+		s.WriteString("\n" + v.wrapInBox("Environment Variables", hintStyle.Render("No variables match \""+v.envSearch.Value()+"\""), boxWidth))
+		return s.String()
+	}
+	
 	// 应用变量
 	if len(appVars) > 0 {
 		var lines []string
-		for _, env := range appVars {
-			lines = append(lines, formatEnv(env, true))
+		for _, e := range appVars {
+			lines = append(lines, formatEnv(e, true))
 		}
 		s.WriteString("\n" + v.wrapInBox(fmt.Sprintf("App Env Vars (%d)", len(appVars)), strings.Join(lines, "\n"), boxWidth))
 	}
@@ -622,8 +1113,8 @@ func (v *DetailView) renderEnvInfo() string {
 	// 系统变量
 	if len(sysVars) > 0 {
 		var lines []string
-		for _, env := range sysVars {
-			lines = append(lines, formatEnv(env, false))
+		for _, e := range sysVars {
+			lines = append(lines, formatEnv(e, false))
 		}
 		s.WriteString("\n\n" + v.wrapInBox(fmt.Sprintf("System Env Vars (%d)", len(sysVars)), strings.Join(lines, "\n"), boxWidth))
 	}
@@ -739,6 +1230,7 @@ func (v *DetailView) renderKeyHints() string {
 			{"l", "Logs"},
 			{"s", "Shell"},
 			{"r", "Refresh"},
+			{"W", "Pin"},
 			{"Esc", "Back"},
 			{"q", "Quit"},
 		}
@@ -767,17 +1259,6 @@ func (v *DetailView) renderKeyHints() string {
 	return footerStyle.Render(line)
 }
 
-// truncate 截断字符串
-func (v *DetailView) truncate(s string, maxLen int) string {
-	if maxLen < 4 {
-		maxLen = 4
-	}
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
-}
-
 // wrapInBox 用边框包裹内容（和镜像/网络模块保持一致）
 func (v *DetailView) wrapInBox(title, content string, width int) string {
 	return components.WrapInBox(title, content, width)
@@ -788,6 +1269,7 @@ func (v *DetailView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
 	v.statsView.SetSize(width, height-10)
+	v.dnsViewer.SetSize(width, height)
 }
 
 // loadDetails 加载容器详情
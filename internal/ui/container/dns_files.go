@@ -0,0 +1,45 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+)
+
+// dnsFilesMsg 读取容器内 /etc/resolv.conf 和 /etc/hosts 的结果
+type dnsFilesMsg struct {
+	containerID string
+	files       *docker.DNSFiles
+	err         error
+}
+
+// loadDNSFiles 读取容器内实际生效的 /etc/resolv.conf 和 /etc/hosts（运行中的容器用 exec cat，否则通过 API 复制）
+func (v *DetailView) loadDNSFiles() tea.Cmd {
+	client, containerID := v.dockerClient, v.containerID
+	running := v.details != nil && v.details.State == "running"
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		files, err := docker.ReadDNSFiles(ctx, client, containerID, running)
+		return dnsFilesMsg{containerID: containerID, files: files, err: err}
+	}
+}
+
+// formatDNSFiles 把两个文件拼接为查看器内容，使用 Docker 内置 DNS 时附加说明
+func formatDNSFiles(files *docker.DNSFiles) string {
+	var b strings.Builder
+	b.WriteString("# /etc/resolv.conf\n")
+	b.WriteString(strings.TrimRight(files.ResolvConf, "\n"))
+	if slices.Contains(docker.ParseResolvConf(files.ResolvConf).Nameservers, docker.EmbeddedDNSServer) {
+		fmt.Fprintf(&b, "\n\n# %s is Docker's embedded DNS: it resolves container and service names on user-defined networks\n# and forwards other queries to the servers configured with --dns or the daemon's defaults", docker.EmbeddedDNSServer)
+	}
+	b.WriteString("\n\n# /etc/hosts\n")
+	b.WriteString(strings.TrimRight(files.Hosts, "\n"))
+	return b.String()
+}
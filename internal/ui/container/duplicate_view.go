@@ -0,0 +1,310 @@
+package container
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
+)
+
+// defaultDuplicatePortOffset 复制容器时默认的宿主机端口偏移量
+const defaultDuplicatePortOffset = 1000
+
+// DuplicateView 复制容器对话框：输入新名称，选择宿主机端口偏移或不绑定
+type DuplicateView struct {
+	container *docker.Container
+
+	nameInput   textinput.Model
+	offsetInput textinput.Model
+	unbound     bool // true=不绑定宿主机端口，false=按偏移量映射
+
+	presets   []docker.ResourcePreset
+	presetIdx int // 0=沿用源容器的资源限制，i 表示 presets[i-1]
+
+	// UI 状态
+	visible    bool
+	width      int
+	focusIndex int // 0=名称, 1=端口方式, 2=偏移量, 3=资源预设, 4=取消, 5=确认
+	errorMsg   string
+}
+
+// NewDuplicateView 创建复制容器对话框
+func NewDuplicateView() *DuplicateView {
+	nameInput := textinput.New()
+	nameInput.CharLimit = 128
+	nameInput.Width = 40
+	nameInput.Prompt = ""
+
+	offsetInput := textinput.New()
+	offsetInput.CharLimit = 5
+	offsetInput.Width = 8
+	offsetInput.Prompt = ""
+
+	return &DuplicateView{
+		nameInput:   nameInput,
+		offsetInput: offsetInput,
+	}
+}
+
+// Show 显示对话框
+func (v *DuplicateView) Show(container *docker.Container) {
+	v.visible = true
+	v.container = container
+	v.errorMsg = ""
+	v.focusIndex = 0
+	v.unbound = false
+	v.nameInput.SetValue(container.Name + "-copy")
+	v.nameInput.CursorEnd()
+	v.offsetInput.SetValue(strconv.Itoa(defaultDuplicatePortOffset))
+	v.presets = docker.ResourcePresets()
+	v.presetIdx = 0
+	v.updateInputFocus()
+}
+
+// Hide 隐藏对话框
+func (v *DuplicateView) Hide() {
+	v.visible = false
+	v.container = nil
+	v.nameInput.Blur()
+	v.offsetInput.Blur()
+}
+
+// IsVisible 是否可见
+func (v *DuplicateView) IsVisible() bool {
+	return v.visible
+}
+
+// SetWidth 设置宽度
+func (v *DuplicateView) SetWidth(width int) {
+	v.width = width
+}
+
+// GetContainer 获取源容器
+func (v *DuplicateView) GetContainer() *docker.Container {
+	return v.container
+}
+
+// GetOptions 获取复制参数
+func (v *DuplicateView) GetOptions() (docker.ContainerDuplicateOptions, error) {
+	opts := docker.ContainerDuplicateOptions{
+		Name: strings.TrimSpace(v.nameInput.Value()),
+	}
+	if opts.Name == "" {
+		return opts, fmt.Errorf("container name is required")
+	}
+	if v.presetIdx > 0 {
+		p := v.presets[v.presetIdx-1]
+		opts.NanoCPUs, opts.Memory = p.NanoCPUs(), p.Memory
+	}
+	if v.unbound {
+		return opts, nil
+	}
+	offset, err := strconv.Atoi(strings.TrimSpace(v.offsetInput.Value()))
+	if err != nil || offset <= 0 {
+		return opts, fmt.Errorf("invalid port offset %q", v.offsetInput.Value())
+	}
+	opts.PortOffset = offset
+	return opts, nil
+}
+
+// Update 处理输入
+// 返回值: (confirmed bool, handled bool, cmd tea.Cmd)
+func (v *DuplicateView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
+	if !v.visible {
+		return false, false, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, false, nil
+	}
+
+	keyStr := keyMsg.String()
+	switch {
+	case keyMsg.Type == tea.KeyEsc:
+		v.Hide()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyEnter:
+		if v.focusIndex == 4 {
+			v.Hide()
+			return false, true, nil
+		}
+		if v.focusIndex == 5 || v.focusIndex == 0 || v.focusIndex == 2 || v.focusIndex == 3 {
+			if _, err := v.GetOptions(); err != nil {
+				v.errorMsg = err.Error()
+				return false, true, nil
+			}
+			return true, true, nil
+		}
+		v.nextFocus()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyTab || keyMsg.Type == tea.KeyDown:
+		v.nextFocus()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyShiftTab || keyMsg.Type == tea.KeyUp:
+		v.prevFocus()
+		return false, true, nil
+	}
+
+	switch v.focusIndex {
+	case 1:
+		if keyMsg.Type == tea.KeyLeft || keyMsg.Type == tea.KeyRight || keyStr == "h" || keyStr == "l" || keyStr == " " {
+			v.unbound = !v.unbound
+		}
+		return false, true, nil
+	case 3:
+		if (keyMsg.Type == tea.KeyLeft || keyStr == "h") && v.presetIdx > 0 {
+			v.presetIdx--
+		} else if (keyMsg.Type == tea.KeyRight || keyStr == "l") && v.presetIdx < len(v.presets) {
+			v.presetIdx++
+		}
+		return false, true, nil
+	case 4, 5:
+		if keyMsg.Type == tea.KeyLeft || keyStr == "h" {
+			v.focusIndex = 4
+		} else if keyMsg.Type == tea.KeyRight || keyStr == "l" {
+			v.focusIndex = 5
+		}
+		return false, true, nil
+	}
+
+	var cmd tea.Cmd
+	if v.focusIndex == 0 {
+		v.nameInput, cmd = v.nameInput.Update(msg)
+	} else {
+		v.offsetInput, cmd = v.offsetInput.Update(msg)
+	}
+	return false, true, cmd
+}
+
+// nextFocus 切换到下一个焦点（不绑定端口时跳过偏移量）
+func (v *DuplicateView) nextFocus() {
+	v.focusIndex = (v.focusIndex + 1) % 6
+	if v.focusIndex == 2 && v.unbound {
+		v.focusIndex = 3
+	}
+	v.updateInputFocus()
+}
+
+// prevFocus 切换到上一个焦点
+func (v *DuplicateView) prevFocus() {
+	v.focusIndex = (v.focusIndex + 5) % 6
+	if v.focusIndex == 2 && v.unbound {
+		v.focusIndex = 1
+	}
+	v.updateInputFocus()
+}
+
+// updateInputFocus 更新输入框焦点状态
+func (v *DuplicateView) updateInputFocus() {
+	if v.focusIndex == 0 {
+		v.nameInput.Focus()
+	} else {
+		v.nameInput.Blur()
+	}
+	if v.focusIndex == 2 {
+		v.offsetInput.Focus()
+	} else {
+		v.offsetInput.Blur()
+	}
+}
+
+// View 渲染对话框
+func (v *DuplicateView) View() string {
+	if !v.visible || v.container == nil {
+		return ""
+	}
+
+	title := editTitleStyle.Render("🧬 Duplicate Container: " + v.container.Name)
+	source := editHintStyle.Render("Image: ") + editValueStyle.Render(v.container.Image)
+	if v.container.Ports != "" {
+		source += "\n" + editHintStyle.Render("Ports: ") + editValueStyle.Render(v.container.Ports)
+	}
+
+	inputStyle := func(focused bool) lipgloss.Style {
+		if focused {
+			return lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+		}
+		return lipgloss.NewStyle()
+	}
+	nameLine := editLabelStyle.Render("New Name:") + " " + inputStyle(v.focusIndex == 0).Render(v.nameInput.View())
+
+	modes := []struct {
+		label   string
+		enabled bool
+	}{{"Offset host ports", !v.unbound}, {"Unbound", v.unbound}}
+	var modeOptions []string
+	for _, mode := range modes {
+		switch {
+		case mode.enabled && v.focusIndex == 1:
+			modeOptions = append(modeOptions, editSelectedStyle.Render("["+mode.label+"]"))
+		case mode.enabled:
+			modeOptions = append(modeOptions, editValueStyle.Render("["+mode.label+"]"))
+		default:
+			modeOptions = append(modeOptions, editHintStyle.Render(" "+mode.label+" "))
+		}
+	}
+	modeLine := editLabelStyle.Render("Ports:") + " " + strings.Join(modeOptions, " ")
+
+	contentParts := []string{title, "", source, "", nameLine, modeLine}
+	if !v.unbound {
+		contentParts = append(contentParts, editLabelStyle.Render("Offset:")+" "+
+			inputStyle(v.focusIndex == 2).Render(v.offsetInput.View())+
+			editHintStyle.Render(" (e.g. 8080 → 9080)"))
+	}
+	presetOptions := []string{"same as source"}
+	for _, p := range v.presets {
+		presetOptions = append(presetOptions, p.Name)
+	}
+	for i, name := range presetOptions {
+		switch {
+		case i == v.presetIdx && v.focusIndex == 3:
+			presetOptions[i] = editSelectedStyle.Render("[" + name + "]")
+		case i == v.presetIdx:
+			presetOptions[i] = editValueStyle.Render("[" + name + "]")
+		default:
+			presetOptions[i] = editHintStyle.Render(" " + name + " ")
+		}
+	}
+	contentParts = append(contentParts, editLabelStyle.Render("Resources:")+" "+strings.Join(presetOptions, " "))
+	if v.presetIdx > 0 {
+		contentParts = append(contentParts, editLabelStyle.Render("")+" "+editHintStyle.Render(v.presets[v.presetIdx-1].Summary()))
+	}
+	contentParts = append(contentParts, "", editHintStyle.Render("Same image, env, mounts and networks; compose labels are dropped"))
+
+	if v.errorMsg != "" {
+		contentParts = append(contentParts, "", editErrorStyle.Render("❌ "+v.errorMsg))
+	}
+
+	cancelBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	okBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	if v.focusIndex == 4 {
+		cancelBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	if v.focusIndex == 5 {
+		okBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	buttons := cancelBtnStyle.Render("< Cancel >") + "    " + okBtnStyle.Render("< Duplicate >")
+
+	hints := editHintStyle.Render("[Tab/↑↓=Switch] [←→=Select] [Enter=Confirm] [Esc=Cancel]")
+	contentParts = append(contentParts, "", buttons, "", hints)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, contentParts...)
+
+	boxWidth := v.width - 10
+	if boxWidth < 65 {
+		boxWidth = 65
+	}
+	if boxWidth > 75 {
+		boxWidth = 75
+	}
+	return editBoxStyle.Width(boxWidth).Render(content)
+}
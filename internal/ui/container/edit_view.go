@@ -47,8 +47,13 @@ type EditView struct {
 	containerID string
 	details     *docker.ContainerDetails
 
+	// 资源预设：0 表示自定义，i 表示 presets[i-1]
+	presets   []docker.ResourcePreset
+	presetIdx int
+
 	// 输入框
 	cpuSharesInput textinput.Model // CPU 份额输入
+	cpusInput      textinput.Model // CPU 上限输入（CPU 数量）
 	memoryInput    textinput.Model // 内存限制输入（MB）
 
 	// 重启策略选择
@@ -59,7 +64,7 @@ type EditView struct {
 	// UI 状态
 	visible    bool
 	width      int
-	focusIndex int // 0=重启策略, 1=最大重试, 2=CPU份额, 3=内存, 4=取消, 5=确认
+	focusIndex int // 0=资源预设, 1=重启策略, 2=最大重试, 3=CPU份额, 4=CPU上限, 5=内存, 6=取消, 7=确认
 	errorMsg   string
 }
 
@@ -72,6 +77,13 @@ func NewEditView() *EditView {
 	cpuInput.Width = 12
 	cpuInput.Prompt = ""
 
+	// CPU 上限输入框
+	cpusInput := textinput.New()
+	cpusInput.Placeholder = "1.5"
+	cpusInput.CharLimit = 8
+	cpusInput.Width = 12
+	cpusInput.Prompt = ""
+
 	// 内存输入框
 	memInput := textinput.New()
 	memInput.Placeholder = "512"
@@ -88,6 +100,7 @@ func NewEditView() *EditView {
 
 	return &EditView{
 		cpuSharesInput:   cpuInput,
+		cpusInput:        cpusInput,
 		memoryInput:      memInput,
 		maxRetriesInput:  retriesInput,
 		restartPolicies:  []string{"no", "always", "on-failure", "unless-stopped"},
@@ -133,10 +146,13 @@ func (v *EditView) Show(container *docker.Container, details *docker.ContainerDe
 
 	// 清空资源限制输入（留空表示不修改）
 	v.cpuSharesInput.SetValue("")
+	v.cpusInput.SetValue("")
 	v.memoryInput.SetValue("")
+	v.presets = docker.ResourcePresets()
+	v.presetIdx = 0
 
 	// 聚焦到重启策略
-	v.focusIndex = 0
+	v.focusIndex = 1
 	v.updateInputFocus()
 }
 
@@ -146,6 +162,7 @@ func (v *EditView) Hide() {
 	v.container = nil
 	v.details = nil
 	v.cpuSharesInput.Blur()
+	v.cpusInput.Blur()
 	v.memoryInput.Blur()
 	v.maxRetriesInput.Blur()
 }
@@ -175,6 +192,13 @@ func (v *EditView) GetConfig() docker.ContainerUpdateConfig {
 		}
 	}
 
+	// 解析 CPU 上限（CPU 数量 -> 纳秒 CPU）
+	if cpusStr := strings.TrimSpace(v.cpusInput.Value()); cpusStr != "" {
+		if cpus, err := strconv.ParseFloat(cpusStr, 64); err == nil && cpus > 0 {
+			config.NanoCPUs = docker.ResourcePreset{CPUs: cpus}.NanoCPUs()
+		}
+	}
+
 	// 解析内存限制（MB -> 字节）
 	if memStr := strings.TrimSpace(v.memoryInput.Value()); memStr != "" {
 		if mem, err := strconv.ParseInt(memStr, 10, 64); err == nil && mem > 0 {
@@ -216,10 +240,10 @@ func (v *EditView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
 
 		// Enter 键
 		if msg.Type == tea.KeyEnter || keyStr == "enter" {
-			if v.focusIndex == 5 { // 确认按钮
+			if v.focusIndex == 7 { // 确认按钮
 				return true, true, nil
 			}
-			if v.focusIndex == 4 { // 取消按钮
+			if v.focusIndex == 6 { // 取消按钮
 				v.Hide()
 				return false, true, nil
 			}
@@ -256,8 +280,18 @@ func (v *EditView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
 			return false, true, nil
 		}
 
-		// 左右键在重启策略和按钮区域切换
+		// 左右键选择资源预设、重启策略，或在按钮区域切换
 		if v.focusIndex == 0 {
+			if msg.Type == tea.KeyLeft || keyStr == "left" || keyStr == "h" {
+				v.selectPreset(v.presetIdx - 1)
+				return false, true, nil
+			}
+			if msg.Type == tea.KeyRight || keyStr == "right" || keyStr == "l" {
+				v.selectPreset(v.presetIdx + 1)
+				return false, true, nil
+			}
+		}
+		if v.focusIndex == 1 {
 			// 重启策略选择
 			if msg.Type == tea.KeyLeft || keyStr == "left" || keyStr == "h" {
 				if v.restartPolicyIdx > 0 {
@@ -273,17 +307,17 @@ func (v *EditView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
 			}
 		}
 
-		if v.focusIndex >= 4 {
+		if v.focusIndex >= 6 {
 			// 按钮区域
 			if msg.Type == tea.KeyLeft || keyStr == "left" {
-				if v.focusIndex == 5 {
-					v.focusIndex = 4
+				if v.focusIndex == 7 {
+					v.focusIndex = 6
 				}
 				return false, true, nil
 			}
 			if msg.Type == tea.KeyRight || keyStr == "right" {
-				if v.focusIndex == 4 {
-					v.focusIndex = 5
+				if v.focusIndex == 6 {
+					v.focusIndex = 7
 				}
 				return false, true, nil
 			}
@@ -293,24 +327,60 @@ func (v *EditView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
 	// 传递给当前聚焦的输入框
 	var cmd tea.Cmd
 	switch v.focusIndex {
-	case 1:
-		v.maxRetriesInput, cmd = v.maxRetriesInput.Update(msg)
 	case 2:
-		v.cpuSharesInput, cmd = v.cpuSharesInput.Update(msg)
+		v.maxRetriesInput, cmd = v.maxRetriesInput.Update(msg)
 	case 3:
+		v.cpuSharesInput, cmd = v.cpuSharesInput.Update(msg)
+	case 4:
+		v.cpusInput, cmd = v.cpusInput.Update(msg)
+		v.matchPreset()
+	case 5:
 		v.memoryInput, cmd = v.memoryInput.Update(msg)
+		v.matchPreset()
 	}
 
 	return false, true, cmd
 }
 
+// selectPreset 选择资源预设并填入 CPU 上限和内存，0 表示自定义（保留当前输入）
+func (v *EditView) selectPreset(idx int) {
+	if idx < 0 || idx > len(v.presets) {
+		return
+	}
+	v.presetIdx = idx
+	if idx == 0 {
+		return
+	}
+	p := v.presets[idx-1]
+	v.cpusInput.SetValue("")
+	if p.CPUs > 0 {
+		v.cpusInput.SetValue(strconv.FormatFloat(p.CPUs, 'f', -1, 64))
+	}
+	v.memoryInput.SetValue("")
+	if p.Memory > 0 {
+		v.memoryInput.SetValue(strconv.FormatInt(p.Memory>>20, 10))
+	}
+}
+
+// matchPreset 手动修改 CPU 上限或内存后，显示与输入一致的预设，否则显示为自定义
+func (v *EditView) matchPreset() {
+	config := v.GetConfig()
+	v.presetIdx = 0
+	for i, p := range v.presets {
+		if p.NanoCPUs() == config.NanoCPUs && p.Memory>>20 == config.Memory>>20 {
+			v.presetIdx = i + 1
+			return
+		}
+	}
+}
+
 // nextFocus 切换到下一个焦点
 func (v *EditView) nextFocus() {
 	// 如果不是 on-failure，跳过最大重试次数
-	if v.focusIndex == 0 && v.restartPolicies[v.restartPolicyIdx] != "on-failure" {
-		v.focusIndex = 2 // 跳到 CPU 份额
+	if v.focusIndex == 1 && v.restartPolicies[v.restartPolicyIdx] != "on-failure" {
+		v.focusIndex = 3 // 跳到 CPU 份额
 	} else {
-		v.focusIndex = (v.focusIndex + 1) % 6
+		v.focusIndex = (v.focusIndex + 1) % 8
 	}
 	v.updateInputFocus()
 }
@@ -318,10 +388,10 @@ func (v *EditView) nextFocus() {
 // prevFocus 切换到上一个焦点
 func (v *EditView) prevFocus() {
 	// 如果不是 on-failure，跳过最大重试次数
-	if v.focusIndex == 2 && v.restartPolicies[v.restartPolicyIdx] != "on-failure" {
-		v.focusIndex = 0 // 跳回重启策略
+	if v.focusIndex == 3 && v.restartPolicies[v.restartPolicyIdx] != "on-failure" {
+		v.focusIndex = 1 // 跳回重启策略
 	} else {
-		v.focusIndex = (v.focusIndex + 5) % 6
+		v.focusIndex = (v.focusIndex + 7) % 8
 	}
 	v.updateInputFocus()
 }
@@ -329,15 +399,18 @@ func (v *EditView) prevFocus() {
 // updateInputFocus 更新输入框焦点状态
 func (v *EditView) updateInputFocus() {
 	v.cpuSharesInput.Blur()
+	v.cpusInput.Blur()
 	v.memoryInput.Blur()
 	v.maxRetriesInput.Blur()
 
 	switch v.focusIndex {
-	case 1:
-		v.maxRetriesInput.Focus()
 	case 2:
-		v.cpuSharesInput.Focus()
+		v.maxRetriesInput.Focus()
 	case 3:
+		v.cpuSharesInput.Focus()
+	case 4:
+		v.cpusInput.Focus()
+	case 5:
 		v.memoryInput.Focus()
 	}
 }
@@ -365,12 +438,32 @@ func (v *EditView) View() string {
 	}
 	currentInfo := editHintStyle.Render("Current restart policy: ") + editValueStyle.Render(currentPolicy)
 
+	// 资源预设选择
+	presetOptions := []string{"custom"}
+	for _, p := range v.presets {
+		presetOptions = append(presetOptions, p.Name)
+	}
+	for i, name := range presetOptions {
+		switch {
+		case i == v.presetIdx && v.focusIndex == 0:
+			presetOptions[i] = editSelectedStyle.Render("[" + name + "]")
+		case i == v.presetIdx:
+			presetOptions[i] = editValueStyle.Render("[" + name + "]")
+		default:
+			presetOptions[i] = editHintStyle.Render(" " + name + " ")
+		}
+	}
+	presetLine := editLabelStyle.Render("Preset:") + " " + strings.Join(presetOptions, " ")
+	if v.presetIdx > 0 {
+		presetLine += "\n" + editLabelStyle.Render("") + " " + editHintStyle.Render(v.presets[v.presetIdx-1].Summary())
+	}
+
 	// 重启策略选择
 	restartLabel := editLabelStyle.Render("Restart Policy:")
 	var policyOptions []string
 	for i, p := range v.restartPolicies {
 		if i == v.restartPolicyIdx {
-			if v.focusIndex == 0 {
+			if v.focusIndex == 1 {
 				policyOptions = append(policyOptions, editSelectedStyle.Render("["+p+"]"))
 			} else {
 				policyOptions = append(policyOptions, editValueStyle.Render("["+p+"]"))
@@ -386,7 +479,7 @@ func (v *EditView) View() string {
 	if v.restartPolicies[v.restartPolicyIdx] == "on-failure" {
 		retriesLabel := editLabelStyle.Render("Max Retries:")
 		retriesInputStyle := lipgloss.NewStyle()
-		if v.focusIndex == 1 {
+		if v.focusIndex == 2 {
 			retriesInputStyle = retriesInputStyle.Foreground(lipgloss.Color("81"))
 		}
 		retriesLine = retriesLabel + " " + retriesInputStyle.Render(v.maxRetriesInput.View()) +
@@ -396,16 +489,24 @@ func (v *EditView) View() string {
 	// CPU 份额
 	cpuLabel := editLabelStyle.Render("CPU Shares:")
 	cpuInputStyle := lipgloss.NewStyle()
-	if v.focusIndex == 2 {
+	if v.focusIndex == 3 {
 		cpuInputStyle = cpuInputStyle.Foreground(lipgloss.Color("81"))
 	}
 	cpuLine := cpuLabel + " " + cpuInputStyle.Render(v.cpuSharesInput.View()) +
 		editHintStyle.Render(" (default 1024, leave empty to keep unchanged)")
 
+	// CPU 上限
+	cpusInputStyle := lipgloss.NewStyle()
+	if v.focusIndex == 4 {
+		cpusInputStyle = cpusInputStyle.Foreground(lipgloss.Color("81"))
+	}
+	cpusLine := editLabelStyle.Render("CPUs:") + " " + cpusInputStyle.Render(v.cpusInput.View()) +
+		editHintStyle.Render(" (e.g. 0.5, leave empty to keep unchanged)")
+
 	// 内存限制
 	memLabel := editLabelStyle.Render("Memory Limit:")
 	memInputStyle := lipgloss.NewStyle()
-	if v.focusIndex == 3 {
+	if v.focusIndex == 5 {
 		memInputStyle = memInputStyle.Foreground(lipgloss.Color("81"))
 	}
 	memLine := memLabel + " " + memInputStyle.Render(v.memoryInput.View()) +
@@ -415,13 +516,13 @@ func (v *EditView) View() string {
 	cancelBtnStyle := lipgloss.NewStyle().Padding(0, 2)
 	okBtnStyle := lipgloss.NewStyle().Padding(0, 2)
 
-	if v.focusIndex == 4 {
+	if v.focusIndex == 6 {
 		cancelBtnStyle = cancelBtnStyle.Reverse(true).Bold(true)
 	} else {
 		cancelBtnStyle = cancelBtnStyle.Foreground(lipgloss.Color("245"))
 	}
 
-	if v.focusIndex == 5 {
+	if v.focusIndex == 7 {
 		okBtnStyle = okBtnStyle.Reverse(true).Bold(true)
 	} else {
 		okBtnStyle = okBtnStyle.Foreground(lipgloss.Color("245"))
@@ -438,18 +539,18 @@ func (v *EditView) View() string {
 	}
 
 	// 提示
-	hints := editHintStyle.Render("[Tab/↑↓=Switch] [←→=Select policy] [Enter=Confirm] [Esc=Cancel]")
+	hints := editHintStyle.Render("[Tab/↑↓=Switch] [←→=Select preset/policy] [Enter=Confirm] [Esc=Cancel]")
 	note := editHintStyle.Render("Note: CPU/Memory limits only supported on Linux native Docker")
 
 	// 组合内容
 	var contentParts []string
-	contentParts = append(contentParts, title, "", currentInfo, "", restartLine)
+	contentParts = append(contentParts, title, "", currentInfo, "", presetLine, "", restartLine)
 
 	if retriesLine != "" {
 		contentParts = append(contentParts, retriesLine)
 	}
 
-	contentParts = append(contentParts, "", cpuLine, memLine)
+	contentParts = append(contentParts, "", cpuLine, cpusLine, memLine)
 
 	if errorLine != "" {
 		contentParts = append(contentParts, "", errorLine)
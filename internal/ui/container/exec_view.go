@@ -0,0 +1,372 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
+	"docktui/internal/format"
+)
+
+// execOutputLines 每个输出框最多显示的行数（保留末尾）
+const execOutputLines = 12
+
+// execDoneMsg 一次性命令执行结果
+type execDoneMsg struct {
+	containerID string
+	command     string
+	result      *docker.ExecResult
+	err         error
+	duration    time.Duration
+}
+
+// toolsProbedMsg 容器中工具的探测结果（执行命令和网络测试对话框共用）
+type toolsProbedMsg struct {
+	containerID string
+	tools       *docker.ContainerTools
+	err         error
+}
+
+// execProbedTools 执行命令对话框中展示是否存在的工具
+var execProbedTools = []string{"bash", "sh", "curl", "wget", "nc", "ip", "ps"}
+
+// probeToolsCmd 探测容器中的工具（docker 客户端按容器缓存结果）
+func probeToolsCmd(dockerClient docker.Client, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		tools, err := dockerClient.ProbeTools(ctx, containerID)
+		return toolsProbedMsg{containerID: containerID, tools: tools, err: err}
+	}
+}
+
+// ExecView 在容器中执行一次性命令的对话框：分开显示 stdout/stderr，可提供 stdin，可重新执行上一条命令
+type ExecView struct {
+	dockerClient docker.Client
+	container    *docker.Container
+
+	cmdInput   textinput.Model
+	stdinInput textinput.Model
+	focusIndex int // 0=命令, 1=标准输入
+
+	history    []string // 本次会话执行过的命令（最新在后）
+	historyIdx int
+	lastCmd    string
+	lastStdin  string
+
+	running bool
+	last    *execDoneMsg
+	tools   *docker.ContainerTools // 探测到的工具，为 nil 表示还在探测或探测失败
+	toolErr bool                   // 探测失败（按有 sh 处理）
+
+	visible bool
+	width   int
+	height  int
+}
+
+// NewExecView 创建执行命令对话框
+func NewExecView(dockerClient docker.Client) *ExecView {
+	cmdInput := textinput.New()
+	cmdInput.Placeholder = "ls -la /app"
+	cmdInput.CharLimit = 1024
+	cmdInput.Prompt = "$ "
+
+	stdinInput := textinput.New()
+	stdinInput.Placeholder = "optional, \\n for newline or @/path/to/file"
+	stdinInput.CharLimit = 4096
+	stdinInput.Prompt = ""
+
+	return &ExecView{
+		dockerClient: dockerClient,
+		cmdInput:     cmdInput,
+		stdinInput:   stdinInput,
+	}
+}
+
+// Show 显示对话框，切换容器时清空上次结果并探测容器中的工具
+func (v *ExecView) Show(container *docker.Container) tea.Cmd {
+	var cmd tea.Cmd
+	if v.container == nil || v.container.ID != container.ID {
+		v.last = nil
+		v.tools = nil
+		v.toolErr = false
+		cmd = probeToolsCmd(v.dockerClient, container.ID)
+	}
+	v.visible = true
+	v.container = container
+	v.focusIndex = 0
+	v.historyIdx = len(v.history)
+	v.updateInputFocus()
+	return cmd
+}
+
+// Hide 隐藏对话框（保留命令历史）
+func (v *ExecView) Hide() {
+	v.visible = false
+	v.cmdInput.Blur()
+	v.stdinInput.Blur()
+}
+
+// IsVisible 是否可见
+func (v *ExecView) IsVisible() bool {
+	return v.visible
+}
+
+// SetSize 设置尺寸
+func (v *ExecView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	inputWidth := width - 30
+	if inputWidth < 30 {
+		inputWidth = 30
+	}
+	if inputWidth > 90 {
+		inputWidth = 90
+	}
+	v.cmdInput.Width = inputWidth
+	v.stdinInput.Width = inputWidth
+}
+
+// Update 处理输入和执行结果
+// 返回值: (handled bool, cmd tea.Cmd)
+func (v *ExecView) Update(msg tea.Msg) (bool, tea.Cmd) {
+	if done, ok := msg.(execDoneMsg); ok {
+		v.running = false
+		v.last = &done
+		return true, nil
+	}
+	if probed, ok := msg.(toolsProbedMsg); ok {
+		if v.container != nil && v.container.ID == probed.containerID {
+			v.tools = probed.tools
+			v.toolErr = probed.err != nil
+		}
+		return true, nil
+	}
+	if !v.visible {
+		return false, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		v.Hide()
+		return true, nil
+	case "tab", "shift+tab":
+		v.focusIndex = 1 - v.focusIndex
+		v.updateInputFocus()
+		return true, nil
+	case "enter":
+		return true, v.run(strings.TrimSpace(v.cmdInput.Value()), v.stdinInput.Value())
+	case "ctrl+r":
+		// 重新执行上一条命令（使用当时的标准输入）
+		return true, v.run(v.lastCmd, v.lastStdin)
+	case "ctrl+l":
+		v.last = nil
+		return true, nil
+	case "up", "down":
+		if v.focusIndex == 0 && len(v.history) > 0 {
+			if keyMsg.String() == "up" && v.historyIdx > 0 {
+				v.historyIdx--
+			} else if keyMsg.String() == "down" && v.historyIdx < len(v.history) {
+				v.historyIdx++
+			}
+			if v.historyIdx < len(v.history) {
+				v.cmdInput.SetValue(v.history[v.historyIdx])
+			} else {
+				v.cmdInput.SetValue("")
+			}
+			v.cmdInput.CursorEnd()
+		}
+		return true, nil
+	}
+
+	var cmd tea.Cmd
+	if v.focusIndex == 0 {
+		v.cmdInput, cmd = v.cmdInput.Update(msg)
+	} else {
+		v.stdinInput, cmd = v.stdinInput.Update(msg)
+	}
+	return true, cmd
+}
+
+// run 通过 sh -c 执行命令（容器中没有 sh 时按空格拆分后直接执行），stdin 以 @ 开头时读取文件内容，否则把 \n 转为换行
+func (v *ExecView) run(command, stdin string) tea.Cmd {
+	if v.running || command == "" || v.container == nil {
+		return nil
+	}
+	if len(v.history) == 0 || v.history[len(v.history)-1] != command {
+		v.history = append(v.history, command)
+	}
+	v.historyIdx = len(v.history)
+	v.lastCmd, v.lastStdin = command, stdin
+	v.running = true
+
+	containerID := v.container.ID
+	cmd := []string{"sh", "-c", command}
+	if v.noShell() {
+		cmd = strings.Fields(command)
+	}
+	return func() tea.Msg {
+		config := docker.ExecConfig{
+			Cmd:          cmd,
+			AttachStdout: true,
+			AttachStderr: true,
+		}
+		if path, ok := strings.CutPrefix(stdin, "@"); ok {
+			f, err := os.Open(strings.TrimSpace(path))
+			if err != nil {
+				return execDoneMsg{containerID: containerID, command: command, err: fmt.Errorf("stdin file: %w", err)}
+			}
+			defer f.Close()
+			config.Stdin = f
+		} else if stdin != "" {
+			config.Stdin = strings.NewReader(strings.ReplaceAll(stdin, `\n`, "\n"))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		start := time.Now()
+		result, err := v.dockerClient.ExecCommand(ctx, containerID, config)
+		return execDoneMsg{containerID: containerID, command: command, result: result, err: err, duration: time.Since(start)}
+	}
+}
+
+// noShell 已探测到容器中没有 sh
+func (v *ExecView) noShell() bool {
+	return v.tools != nil && !v.tools.Has("sh")
+}
+
+// renderTools 列出常用工具，不存在的显示为灰色
+func (v *ExecView) renderTools() string {
+	if v.toolErr {
+		return editHintStyle.Render("Tools: unknown")
+	}
+	if v.tools == nil {
+		return editHintStyle.Render("Tools: detecting...")
+	}
+	missingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Strikethrough(true)
+	names := make([]string, len(execProbedTools))
+	for i, name := range execProbedTools {
+		if v.tools.Has(name) {
+			names[i] = editValueStyle.Render(name)
+		} else {
+			names[i] = missingStyle.Render(name)
+		}
+	}
+	return editHintStyle.Render("Tools: ") + strings.Join(names, " ")
+}
+
+// updateInputFocus 更新输入框焦点状态
+func (v *ExecView) updateInputFocus() {
+	if v.focusIndex == 0 {
+		v.cmdInput.Focus()
+		v.stdinInput.Blur()
+	} else {
+		v.cmdInput.Blur()
+		v.stdinInput.Focus()
+	}
+}
+
+// View 渲染对话框
+func (v *ExecView) View() string {
+	if !v.visible || v.container == nil {
+		return ""
+	}
+
+	boxWidth := v.width - 8
+	if boxWidth < 60 {
+		boxWidth = 60
+	}
+	if boxWidth > 120 {
+		boxWidth = 120
+	}
+
+	inputStyle := func(focused bool) lipgloss.Style {
+		if focused {
+			return lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+		}
+		return lipgloss.NewStyle()
+	}
+	contentParts := []string{
+		editTitleStyle.Render("⚡ Exec in " + v.container.Name),
+		"",
+		editLabelStyle.Render("Command:") + " " + inputStyle(v.focusIndex == 0).Render(v.cmdInput.View()),
+		editLabelStyle.Render("Stdin:") + " " + inputStyle(v.focusIndex == 1).Render(v.stdinInput.View()),
+		v.renderTools(),
+		"",
+	}
+	if v.noShell() {
+		contentParts = append(contentParts[:len(contentParts)-1],
+			editErrorStyle.Render("⚠ "+docker.NoShellHint+": the command runs directly, without pipes, quotes or variables"), "")
+	}
+
+	switch {
+	case v.running:
+		contentParts = append(contentParts, editHintStyle.Render("⏳ Running "+v.lastCmd+"..."))
+	case v.last == nil:
+		if v.noShell() {
+			contentParts = append(contentParts, editHintStyle.Render("stdout and stderr are shown separately"))
+		} else {
+			contentParts = append(contentParts, editHintStyle.Render("Runs via sh -c; stdout and stderr are shown separately"))
+		}
+	case v.last.err != nil:
+		contentParts = append(contentParts, editErrorStyle.Render("❌ "+v.last.err.Error()))
+	default:
+		contentParts = append(contentParts, v.renderResult(boxWidth-6)...)
+	}
+
+	contentParts = append(contentParts, "",
+		editHintStyle.Render("[Enter=Run] [Ctrl+R=Re-run last] [↑↓=History] [Tab=Command/Stdin] [Ctrl+L=Clear] [Esc=Close]"))
+
+	return editBoxStyle.Width(boxWidth).Render(lipgloss.JoinVertical(lipgloss.Left, contentParts...))
+}
+
+// renderResult 渲染退出码和分开的 stdout/stderr
+func (v *ExecView) renderResult(width int) []string {
+	r := v.last.result
+	status := editValueStyle.Render(fmt.Sprintf("✅ exit 0 in %s", v.last.duration.Round(time.Millisecond)))
+	if r.ExitCode != 0 {
+		status = editErrorStyle.Render(fmt.Sprintf("❌ exit %d in %s", r.ExitCode, v.last.duration.Round(time.Millisecond)))
+	}
+	lines := []string{editHintStyle.Render("$ "+v.last.command) + "  " + status}
+	if r.Truncated {
+		lines = append(lines, editHintStyle.Render("(output truncated to 1 MiB per stream)"))
+	}
+
+	stream := func(title, output string, style lipgloss.Style) {
+		output = strings.TrimRight(output, "\n")
+		if output == "" {
+			return
+		}
+		outLines := strings.Split(output, "\n")
+		header := fmt.Sprintf("── %s (%d lines) ", title, len(outLines))
+		if len(outLines) > execOutputLines {
+			header = fmt.Sprintf("── %s (last %d of %d lines) ", title, execOutputLines, len(outLines))
+			outLines = outLines[len(outLines)-execOutputLines:]
+		}
+		lines = append(lines, "", editHintStyle.Render(header))
+		for _, l := range outLines {
+			l = strings.ReplaceAll(l, "\t", "    ")
+			if lipgloss.Width(l) > width {
+				l = format.Truncate(l, width)
+			}
+			lines = append(lines, style.Render(l))
+		}
+	}
+	stream("stdout", r.Stdout, lipgloss.NewStyle().Foreground(lipgloss.Color("252")))
+	stream("stderr", r.Stderr, lipgloss.NewStyle().Foreground(lipgloss.Color("203")))
+	if strings.TrimSpace(r.Stdout) == "" && strings.TrimSpace(r.Stderr) == "" {
+		lines = append(lines, "", editHintStyle.Render("(no output)"))
+	}
+	return lines
+}
@@ -0,0 +1,52 @@
+package container
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// hostPathMsg 打开/复制宿主机路径的结果
+type hostPathMsg struct {
+	message string
+	err     error
+}
+
+// openHostPath 用系统文件管理器打开宿主机路径，文件则打开其所在目录
+func openHostPath(path string) tea.Cmd {
+	return func() tea.Msg {
+		info, err := os.Stat(path)
+		if err != nil {
+			return hostPathMsg{err: fmt.Errorf("host path not accessible: %w", err)}
+		}
+		dir := path
+		if !info.IsDir() {
+			dir = filepath.Dir(path)
+		}
+
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", dir)
+		case "windows":
+			cmd = exec.Command("explorer", dir)
+		default:
+			cmd = exec.Command("xdg-open", dir)
+		}
+		if err := cmd.Start(); err != nil {
+			return hostPathMsg{err: fmt.Errorf("failed to open file manager: %w", err)}
+		}
+		// 不等待文件管理器退出，避免产生僵尸进程
+		go cmd.Wait()
+		return hostPathMsg{message: "📂 Opened " + dir}
+	}
+}
+
+// copyHostPath 复制路径到剪贴板，剪贴板不可用时直接显示路径
+func copyHostPath(path string) tea.Cmd {
+	return copyToClipboard("path", path)
+}
@@ -0,0 +1,146 @@
+package container
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
+	"docktui/internal/ui/components"
+)
+
+// kubeBadge kind/k3d/minikube 节点容器在名称后显示的标记
+func kubeBadge(c docker.Container) string {
+	node := c.KubeNode()
+	if node == nil {
+		return ""
+	}
+	return " ☸ " + node.Tool
+}
+
+// KubeView 本地 Kubernetes 节点容器的信息对话框：kubeconfig 路径、获取命令，进入节点 Shell
+type KubeView struct {
+	container *docker.Container
+	node      *docker.KubeNode
+
+	visible bool
+	width   int
+}
+
+// NewKubeView 创建 Kubernetes 节点对话框
+func NewKubeView() *KubeView {
+	return &KubeView{}
+}
+
+// Show 显示对话框
+func (v *KubeView) Show(container *docker.Container, node *docker.KubeNode) {
+	v.visible = true
+	v.container = container
+	v.node = node
+}
+
+// Hide 隐藏对话框
+func (v *KubeView) Hide() {
+	v.visible = false
+	v.container = nil
+	v.node = nil
+}
+
+// IsVisible 是否可见
+func (v *KubeView) IsVisible() bool {
+	return v.visible
+}
+
+// SetWidth 设置宽度
+func (v *KubeView) SetWidth(width int) {
+	v.width = width
+}
+
+// Update 处理输入
+// 返回值: (handled bool, cmd tea.Cmd)
+func (v *KubeView) Update(msg tea.Msg) (bool, tea.Cmd) {
+	if !v.visible {
+		return false, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "q", "K":
+		v.Hide()
+	case "c":
+		return true, copyToClipboard("kubeconfig-path", docker.KubeconfigPath())
+	case "y":
+		return true, copyToClipboard("kubeconfig-cmd", v.node.KubeconfigCommand())
+	case "enter", "s":
+		container := v.container
+		v.Hide()
+		if container.State != "running" {
+			return true, func() tea.Msg {
+				return ContainerOperationWarningMsg{Message: "Can only execute shell in running containers"}
+			}
+		}
+		return true, func() tea.Msg {
+			return ExecShellMsg{ContainerID: container.ID, ContainerName: container.Name}
+		}
+	}
+	return true, nil
+}
+
+// copyToClipboard 复制文本到剪贴板，剪贴板不可用时直接显示文本（开启文件回退时同时写入临时文件）
+func copyToClipboard(name, text string) tea.Cmd {
+	return func() tea.Msg {
+		result := components.CopyText(name, text)
+		switch {
+		case result.Copied:
+			return hostPathMsg{message: "📋 Copied " + text}
+		case result.File != "":
+			return hostPathMsg{message: "📍 " + text + "  (clipboard unavailable, saved to " + result.File + ")"}
+		default:
+			return hostPathMsg{message: "📍 " + text + "  (clipboard unavailable)"}
+		}
+	}
+}
+
+// View 渲染对话框
+func (v *KubeView) View() string {
+	if !v.visible || v.container == nil || v.node == nil {
+		return ""
+	}
+
+	row := func(label, value string) string {
+		return editLabelStyle.Render(label) + " " + editValueStyle.Render(value)
+	}
+	contentParts := []string{
+		editTitleStyle.Render("☸ Kubernetes Node: " + v.container.Name),
+		"",
+		row("Tool:", v.node.Tool),
+		row("Cluster:", v.node.Cluster),
+	}
+	if v.node.Role != "" {
+		contentParts = append(contentParts, row("Role:", v.node.Role))
+	}
+	contentParts = append(contentParts,
+		row("Context:", v.node.Context()),
+		row("Kubeconfig:", docker.KubeconfigPath()),
+		row("Get config:", v.node.KubeconfigCommand()),
+		"",
+		editHintStyle.Render(fmt.Sprintf("kubectl --context %s get nodes", v.node.Context())),
+		"",
+		editHintStyle.Render("[c=Copy path] [y=Copy command] [Enter/s=Node shell] [Esc=Close]"),
+	)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, contentParts...)
+
+	boxWidth := v.width - 10
+	if boxWidth < 65 {
+		boxWidth = 65
+	}
+	if boxWidth > 80 {
+		boxWidth = 80
+	}
+	return editBoxStyle.Width(boxWidth).Render(content)
+}
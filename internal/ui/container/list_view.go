@@ -12,7 +12,11 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"docktui/internal/docker"
+	"docktui/internal/favorites"
+	"docktui/internal/format"
+	"docktui/internal/notes"
 	"docktui/internal/ui/components"
+	"docktui/internal/ui/styles"
 )
 
 // ListView 容器列表视图
@@ -38,13 +42,15 @@ type ListView struct {
 	isSearching bool
 	
 	// 筛选状态
-	filterType string // "all", "running", "exited", "paused"
+	filterType string // "all", "running", "exited", "paused", "healthy", "unhealthy", "starting"
 	
-	// 刷新状态
-	lastRefreshTime time.Time
+	// 刷新状态：事件流可用时由容器事件触发刷新，不可用时按 fallbackRefreshInterval 轮询
+	refresh *components.RefreshTimer
 	
 	// 事件监听状态
 	eventListening bool
+	polling        bool // 事件流出错后的轮询循环是否在运行
+	pollGen        int  // 轮询循环的代次，重新进入视图时旧的循环自然结束
 	
 	// 确认对话框状态
 	showConfirmDialog bool
@@ -55,8 +61,31 @@ type ListView struct {
 	// 多选功能
 	selectedContainers map[string]bool
 	
+	// 停止/重启中的容器（容器 ID -> 过渡状态）
+	transitions       map[string]*transition
+	transitionTicking bool
+	
 	// 编辑视图
 	editView *EditView
+
+	// 清理对话框
+	pruneView *PruneView
+
+	// 批量重启策略对话框
+	restartPolicyView *RestartPolicyView
+
+	// 复制容器对话框
+	duplicateView *DuplicateView
+	debugView     *DebugView
+	kubeView      *KubeView
+	execView      *ExecView
+	netTestView   *NetTestView
+
+	// 备注对话框
+	noteInput *components.NoteInputView
+
+	// 列表导出对话框
+	listExport *components.ListExportView
 	
 	// 错误弹窗
 	errorDialog *components.ErrorDialog
@@ -66,6 +95,9 @@ type ListView struct {
 	
 	// 快捷键管理
 	keys components.KeyMap
+
+	// . 重复和宏录制
+	recorder actionRecorder
 }
 
 // NewListView 创建容器列表视图
@@ -119,7 +151,18 @@ func NewListView(dockerClient docker.Client) *ListView {
 		isSearching:        false,
 		filterType:         "all",
 		selectedContainers: make(map[string]bool),
+		transitions:        make(map[string]*transition),
 		editView:           NewEditView(),
+		pruneView:          NewPruneView(),
+		restartPolicyView:  NewRestartPolicyView(),
+		duplicateView:      NewDuplicateView(),
+		debugView:          NewDebugView(),
+		kubeView:           NewKubeView(),
+		execView:           NewExecView(dockerClient),
+		netTestView:        NewNetTestView(dockerClient),
+		noteInput:          components.NewNoteInputView(),
+		listExport:         components.NewListExportView(),
+		refresh:            components.NewRefreshTimer(fallbackRefreshInterval),
 		errorDialog:        components.NewErrorDialog(),
 		jsonViewer:         components.NewJSONViewer(),
 	}
@@ -128,6 +171,9 @@ func NewListView(dockerClient docker.Client) *ListView {
 // Init 初始化容器列表视图
 func (v *ListView) Init() tea.Cmd {
 	v.loading = true
+	v.polling = false
+	v.pollGen++
+	v.refresh.SetInterval(fallbackRefreshInterval)
 	return tea.Batch(
 		v.loadContainers,
 		v.watchDockerEvents(),
@@ -147,20 +193,28 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case ContainersLoadedMsg:
+		// 重新加载后按 ID 找回之前选中的容器，避免光标停在原来的行号上指向别的容器
+		var selectedID string
+		if c := v.GetSelectedContainer(); c != nil {
+			selectedID = c.ID
+		}
 		v.containers = msg.Containers
 		v.loading = false
 		v.errorMsg = ""
-		v.lastRefreshTime = time.Now()
+		v.refresh.Mark()
 		v.applyFilters()
 		v.updateColumnWidths()
+		v.restoreCursor(selectedID)
 		return v, nil
 		
 	case ContainersLoadErrorMsg:
 		v.loading = false
 		v.errorMsg = msg.Err.Error()
+		v.refresh.Mark()
 		return v, nil
 		
 	case ContainerEventMsg:
+		v.eventListening = true
 		event := msg.Event
 		switch event.Action {
 		case "start", "die", "stop", "rename", "create", "destroy":
@@ -169,7 +223,42 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 		return v, v.watchDockerEvents()
 		
 	case ContainerEventErrorMsg:
-		return v, v.watchDockerEvents()
+		// 事件流不可用（如守护进程重启、代理不支持长连接）时改为轮询，每次轮询时重新尝试监听事件
+		v.eventListening = false
+		if v.polling {
+			return v, nil
+		}
+		v.polling = true
+		return v, v.pollTick()
+		
+	case containerPollTickMsg:
+		if msg.gen != v.pollGen {
+			return v, nil
+		}
+		if v.eventListening {
+			v.polling = false
+			return v, nil
+		}
+		if !v.refresh.Due() {
+			return v, v.pollTick()
+		}
+		v.refresh.Start()
+		return v, tea.Batch(v.loadContainers, v.watchDockerEvents(), v.pollTick())
+		
+	case containerTransitionDoneMsg:
+		for _, id := range msg.ids {
+			delete(v.transitions, id)
+		}
+		v.updateColumnWidths()
+		return v.Update(msg.result)
+		
+	case containerTransitionTickMsg:
+		if len(v.transitions) == 0 {
+			v.transitionTicking = false
+			return v, nil
+		}
+		v.updateTableData()
+		return v, v.tickTransitions()
 		
 	case ContainerOperationSuccessMsg:
 		v.successMsg = fmt.Sprintf("✅ %s container succeeded: %s", msg.Operation, msg.Container)
@@ -223,6 +312,18 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 		}
 		return v, nil
 
+	case ContainerRunCommandMsg:
+		if v.jsonViewer != nil {
+			v.jsonViewer.SetSize(v.width, v.height)
+			v.jsonViewer.Show("docker run: "+msg.ContainerName, msg.Command)
+		}
+		if msg.Copy.Err != nil {
+			return v, nil
+		}
+		v.successMsg = msg.Copy.Message("docker run command")
+		v.successMsgTime = time.Now()
+		return v, v.clearSuccessMessageAfter(3*time.Second)
+
 	case ContainerInspectErrorMsg:
 		if v.errorDialog != nil {
 			v.errorDialog.ShowError(fmt.Sprintf("Failed to get container info: %v", msg.Err))
@@ -234,6 +335,57 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 			v.editView.Show(msg.Container, msg.Details)
 		}
 		return v, nil
+
+	case components.ListExportResultMsg:
+		if msg.Err != nil {
+			if v.errorDialog != nil {
+				v.errorDialog.ShowError(fmt.Sprintf("Export failed: %v", msg.Err))
+			}
+			return v, nil
+		}
+		v.successMsg = fmt.Sprintf("✅ Exported %d containers to %s", msg.Rows, msg.Path)
+		v.successMsgTime = time.Now()
+		return v, v.clearSuccessMessageAfter(3*time.Second)
+
+	case execDoneMsg:
+		v.execView.Update(msg)
+		return v, nil
+
+	case netTestDoneMsg:
+		v.netTestView.Update(msg)
+		return v, nil
+
+	case toolsProbedMsg:
+		v.execView.Update(msg)
+		v.netTestView.Update(msg)
+		return v, nil
+
+	case hostPathMsg:
+		v.successMsg = msg.message
+		v.successMsgTime = time.Now()
+		return v, v.clearSuccessMessageAfter(3*time.Second)
+
+	case ContainerPrunePreviewMsg:
+		if v.pruneView.IsVisible() {
+			v.pruneView.SetCandidates(msg.Candidates)
+		}
+		return v, nil
+
+	case ContainerPrunePreviewErrorMsg:
+		if v.pruneView.IsVisible() {
+			v.pruneView.SetError(msg.Err.Error())
+		}
+		return v, nil
+
+	case ContainerPruneSuccessMsg:
+		v.successMsg = fmt.Sprintf("✅ Pruned %d containers, freed %s", msg.Count, format.CompactSize(uint64(msg.SpaceReclaimed)))
+		v.successMsgTime = time.Now()
+		v.errorMsg = ""
+		v.selectedContainers = make(map[string]bool)
+		return v, tea.Batch(
+			v.loadContainers,
+			v.clearSuccessMessageAfter(3*time.Second),
+		)
 		
 	case tea.KeyMsg:
 		// 优先处理错误弹窗
@@ -254,6 +406,96 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 			}
 		}
 		
+		// 优先处理列表导出对话框
+		if v.listExport.IsVisible() {
+			confirmed, handled, cmd := v.listExport.Update(msg)
+			if confirmed {
+				path, format := v.listExport.GetValues()
+				v.listExport.Hide()
+				columns, rows := containerExportRows(v.filteredContainers)
+				return v, components.ListExportCmd(path, format, columns, rows)
+			}
+			if handled {
+				return v, cmd
+			}
+		}
+		
+		// 优先处理执行命令对话框
+		if v.execView.IsVisible() {
+			if handled, cmd := v.execView.Update(msg); handled {
+				return v, cmd
+			}
+		}
+		
+		// 优先处理网络测试对话框
+		if v.netTestView.IsVisible() {
+			if handled, cmd := v.netTestView.Update(msg); handled {
+				return v, cmd
+			}
+		}
+		
+		// 优先处理 Kubernetes 节点对话框
+		if v.kubeView.IsVisible() {
+			if handled, cmd := v.kubeView.Update(msg); handled {
+				return v, cmd
+			}
+		}
+		
+		// 优先处理备注对话框
+		if v.noteInput.IsVisible() {
+			confirmed, handled, cmd := v.noteInput.Update(msg)
+			if confirmed {
+				return v, v.saveNote()
+			}
+			if handled {
+				return v, cmd
+			}
+		}
+		
+		// 优先处理复制容器对话框
+		if v.duplicateView.IsVisible() {
+			confirmed, handled, cmd := v.duplicateView.Update(msg)
+			if confirmed {
+				return v, v.duplicateContainer()
+			}
+			if handled {
+				return v, cmd
+			}
+		}
+		
+		// 优先处理调试运行对话框
+		if v.debugView.IsVisible() {
+			confirmed, handled, cmd := v.debugView.Update(msg)
+			if confirmed {
+				return v, v.debugContainer()
+			}
+			if handled {
+				return v, cmd
+			}
+		}
+		
+		// 优先处理批量重启策略对话框
+		if v.restartPolicyView.IsVisible() {
+			confirmed, handled, cmd := v.restartPolicyView.Update(msg)
+			if confirmed {
+				return v, v.applyRestartPolicy()
+			}
+			if handled {
+				return v, cmd
+			}
+		}
+		
+		// 优先处理清理对话框
+		if v.pruneView.IsVisible() {
+			confirmed, handled, cmd := v.pruneView.Update(msg)
+			if confirmed {
+				return v, v.handlePruneConfirm()
+			}
+			if handled {
+				return v, cmd
+			}
+		}
+		
 		// 优先处理确认对话框
 		if v.showConfirmDialog {
 			switch msg.Type {
@@ -348,7 +590,16 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 		}
 		
 		// 快捷键处理
+		v.recorder.observe(msg)
 		switch {
+		case msg.String() == ".":
+			return v, v.repeatLastAction()
+		case msg.String() == "m":
+			v.successMsg = v.recorder.toggleRecording()
+			v.successMsgTime = time.Now()
+			return v, v.clearSuccessMessageAfter(3 * time.Second)
+		case msg.String() == "@":
+			return v, v.replayMacro()
 		case key.Matches(msg, v.keys.Refresh):
 			v.loading = true
 			v.errorMsg = ""
@@ -362,6 +613,12 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 			case "exited":
 				v.filterType = "paused"
 			case "paused":
+				v.filterType = "healthy"
+			case "healthy":
+				v.filterType = "unhealthy"
+			case "unhealthy":
+				v.filterType = "starting"
+			case "starting":
 				v.filterType = "all"
 			default:
 				v.filterType = "all"
@@ -369,10 +626,25 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 			v.applyFilters()
 			v.updateColumnWidths()
 			return v, nil
+		case msg.String() == "H":
+			// 一键定位不健康的容器，再按一次恢复
+			if v.filterType == "unhealthy" {
+				v.filterType = "all"
+			} else {
+				v.filterType = "unhealthy"
+			}
+			v.applyFilters()
+			v.updateColumnWidths()
+			return v, nil
 		case msg.String() == "/":
 			v.isSearching = true
 			v.searchQuery = ""
 			return v, nil
+		case msg.String() == ":":
+			// 按宿主机端口查找
+			v.isSearching = true
+			v.searchQuery = ":"
+			return v, nil
 		case msg.String() == "left", msg.String() == "h":
 			if v.scrollTable != nil {
 				v.scrollTable.ScrollLeft()
@@ -412,19 +684,107 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 			}
 			return v, nil
 		case msg.String() == "t":
-			return v, v.startSelectedContainer()
+			return v, components.RunAction("container.start", v.startSelectedContainer)
 		case msg.String() == "o":
-			return v, v.stopSelectedContainer()
+			return v, components.RunAction("container.stop", v.stopSelectedContainer)
 		case msg.String() == "u":
-			return v, v.togglePauseContainer()
+			return v, components.RunAction(v.pauseAction(), v.togglePauseContainer)
 		case msg.String() == "R":
-			return v, v.restartSelectedContainer()
+			return v, components.RunAction("container.restart", v.restartSelectedContainer)
 		case msg.String() == "ctrl+d":
-			return v, v.showRemoveConfirmDialog()
+			return v, components.RunAction("container.remove", v.showRemoveConfirmDialog)
+		case msg.String() == "p":
+			return v, components.RunAction("container.prune", func() tea.Cmd {
+				v.pruneView.SetWidth(v.width)
+				v.pruneView.Show()
+				return nil
+			})
+		case msg.String() == "w":
+			v.listExport.SetWidth(v.width)
+			v.listExport.Show("containers", len(v.filteredContainers))
+			return v, nil
+		case msg.String() == "P":
+			return v, components.RunAction("container.update", func() tea.Cmd {
+				containers := v.getSelectedOrCurrentContainers()
+				if len(containers) == 0 {
+					return nil
+				}
+				v.restartPolicyView.SetWidth(v.width)
+				v.restartPolicyView.Show(containers)
+				return nil
+			})
 		case msg.String() == "e":
-			return v, v.showEditView()
+			return v, components.RunAction("container.update", v.showEditView)
+		case msg.String() == "D":
+			return v, components.RunAction("container.duplicate", func() tea.Cmd {
+				if container := v.GetSelectedContainer(); container != nil {
+					v.duplicateView.SetWidth(v.width)
+					v.duplicateView.Show(container)
+				}
+				return nil
+			})
+		case msg.String() == "b":
+			return v, components.RunAction("container.debug", func() tea.Cmd {
+				if container := v.GetSelectedContainer(); container != nil {
+					v.debugView.SetWidth(v.width)
+					v.debugView.Show(container)
+				}
+				return nil
+			})
+		case msg.String() == "x":
+			return v, components.RunAction("container.exec", func() tea.Cmd {
+				container := v.GetSelectedContainer()
+				if container == nil {
+					return nil
+				}
+				if container.State != "running" {
+					return func() tea.Msg {
+						return ContainerOperationWarningMsg{Message: "Can only exec in running containers"}
+					}
+				}
+				v.execView.SetSize(v.width, v.height)
+				return v.execView.Show(container)
+			})
+		case msg.String() == "X":
+			return v, components.RunAction("container.exec", func() tea.Cmd {
+				container := v.GetSelectedContainer()
+				if container == nil {
+					return nil
+				}
+				if container.State != "running" {
+					return func() tea.Msg {
+						return ContainerOperationWarningMsg{Message: "Can only run network tests in running containers"}
+					}
+				}
+				v.netTestView.SetSize(v.width, v.height)
+				return v.netTestView.Show(container)
+			})
+		case msg.String() == "K":
+			container := v.GetSelectedContainer()
+			if container == nil {
+				return v, nil
+			}
+			node := container.KubeNode()
+			if node == nil {
+				return v, func() tea.Msg {
+					return ContainerOperationWarningMsg{Message: "Not a kind/k3d/minikube node container"}
+				}
+			}
+			v.kubeView.SetWidth(v.width)
+			v.kubeView.Show(container, node)
+			return v, nil
+		case msg.String() == "N":
+			if container := v.GetSelectedContainer(); container != nil {
+				v.noteInput.SetWidth(v.width)
+				v.noteInput.Show(notes.KindContainer, container.Name, container.Name)
+			}
+			return v, nil
+		case msg.String() == "*":
+			return v, v.toggleFavorite()
 		case msg.String() == "i":
 			return v, v.inspectContainer()
+		case msg.String() == "C":
+			return v, v.copyRunCommand()
 		case msg.String() == " ":
 			container := v.GetSelectedContainer()
 			if container != nil {
@@ -475,6 +835,17 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 					ContainerName: container.Name,
 				}
 			}
+		case msg.String() == "v":
+			container := v.GetSelectedContainer()
+			if container == nil {
+				return v, nil
+			}
+			return v, func() tea.Msg {
+				return ViewEventsMsg{
+					ContainerID:   container.ID,
+					ContainerName: container.Name,
+				}
+			}
 		default:
 			v.tableModel, _ = v.tableModel.Update(msg)
 			return v, nil
@@ -506,25 +877,39 @@ func (v *ListView) View() string {
 	
 	runningCount := 0
 	stoppedCount := 0
+	healthCounts := make(map[string]int)
 	for _, c := range v.containers {
 		if c.State == "running" {
 			runningCount++
 		} else {
 			stoppedCount++
 		}
+		if h := c.Health(); h != "" {
+			healthCounts[h]++
+		}
 	}
 	
 	totalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
-	runningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("82")).Bold(true)
-	stoppedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	runningStyle := styles.StatusStyle(styles.StatusRunning).Bold(true)
+	stoppedStyle := styles.StatusStyle(styles.StatusStopped)
 	separatorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 	
 	statsContent := totalStyle.Render(fmt.Sprintf("📦 Total: %d", totalCount)) +
 		separatorStyle.Render("  │  ") +
-		runningStyle.Render(fmt.Sprintf("✓ Running: %d", runningCount)) +
+		runningStyle.Render(styles.StatusLabel(styles.StatusRunning, fmt.Sprintf("Running: %d", runningCount))) +
 		separatorStyle.Render("  │  ") +
-		stoppedStyle.Render(fmt.Sprintf("■ Stopped: %d", stoppedCount))
+		stoppedStyle.Render(styles.StatusLabel(styles.StatusStopped, fmt.Sprintf("Stopped: %d", stoppedCount)))
+	
+	// 仅在存在配置了健康检查的容器时显示健康统计
+	if len(healthCounts) > 0 {
+		unhealthyStyle := styles.StatusStyle(styles.StatusError).Bold(true)
+		startingStyle := styles.StatusStyle(styles.StatusWarning)
+		statsContent += separatorStyle.Render("  │  ") +
+			runningStyle.Render(fmt.Sprintf("♥ Healthy: %d", healthCounts["healthy"])) + " " +
+			unhealthyStyle.Render(styles.StatusLabel(styles.StatusError, fmt.Sprintf("Unhealthy: %d", healthCounts["unhealthy"]))) + " " +
+			startingStyle.Render(styles.StatusLabel(styles.StatusWarning, fmt.Sprintf("Starting: %d", healthCounts["starting"])))
+	}
 	
 	if showingCount != totalCount || (!v.isSearching && v.searchQuery != "") {
 		filterParts := []string{}
@@ -595,7 +980,7 @@ func (v *ListView) View() string {
 			StatusBarKeyStyle.Render("2.") + SearchHintStyle.Render(" Refresh container list:"),
 			SearchHintStyle.Render("   Press r to refresh"),
 			"",
-			SearchHintStyle.Render("Tip: Container list auto-refreshes (event-driven mode)"),
+			SearchHintStyle.Render("Tip: Container list auto-refreshes (event-driven, polling when events are unavailable)"),
 			"",
 		)
 		s += "\n  " + StateBoxStyle.Render(emptyContent) + "\n"
@@ -606,7 +991,9 @@ func (v *ListView) View() string {
 		var filterHints []string
 		filterHints = append(filterHints, "", SearchHintStyle.Render("🔍 No matching containers"), "")
 		filterHints = append(filterHints, StatusBarLabelStyle.Render("Current search:"))
-		if v.searchQuery != "" {
+		if port, ok := parsePortQuery(v.searchQuery); ok {
+			filterHints = append(filterHints, SearchHintStyle.Render("   • No container publishes host port ")+StatusBarKeyStyle.Render(fmt.Sprintf("%d", port)))
+		} else if v.searchQuery != "" {
 			filterHints = append(filterHints, SearchHintStyle.Render("   • Keyword: ")+StatusBarKeyStyle.Render("\""+v.searchQuery+"\""))
 		}
 		filterHints = append(filterHints, "", StatusBarLabelStyle.Render("💡 Tips:"))
@@ -657,6 +1044,37 @@ func (v *ListView) View() string {
 		s = v.overlayEditView(s)
 	}
 	
+	if v.pruneView.IsVisible() {
+		s = components.OverlayCentered(s, v.pruneView.View(), v.width, v.height)
+	}
+	
+	if v.restartPolicyView.IsVisible() {
+		s = components.OverlayCentered(s, v.restartPolicyView.View(), v.width, v.height)
+	}
+	
+	if v.duplicateView.IsVisible() {
+		s = components.OverlayCentered(s, v.duplicateView.View(), v.width, v.height)
+	}
+	if v.debugView.IsVisible() {
+		s = components.OverlayCentered(s, v.debugView.View(), v.width, v.height)
+	}
+	if v.kubeView.IsVisible() {
+		s = components.OverlayCentered(s, v.kubeView.View(), v.width, v.height)
+	}
+	if v.execView.IsVisible() {
+		s = components.OverlayCentered(s, v.execView.View(), v.width, v.height)
+	}
+	if v.netTestView.IsVisible() {
+		s = components.OverlayCentered(s, v.netTestView.View(), v.width, v.height)
+	}
+	if v.noteInput.IsVisible() {
+		s = components.OverlayCentered(s, v.noteInput.View(), v.width, v.height)
+	}
+	
+	if v.listExport.IsVisible() {
+		s = components.OverlayCentered(s, v.listExport.View(), v.width, v.height)
+	}
+	
 	if v.errorDialog != nil && v.errorDialog.IsVisible() {
 		s = v.errorDialog.Overlay(s)
 	}
@@ -792,7 +1210,7 @@ func (v *ListView) renderStatusBar() string {
 	var lines []string
 	
 	row1Label := labelStyle.Render("📦 Containers")
-	row1Keys := makeItem("<f>", "Filter") + makeItem("</>", "Search") + makeItem("<r>", "Refresh")
+	row1Keys := makeItem("<f>", "Filter") + makeItem("<H>", "Unhealthy") + makeItem("</>", "Search") + makeItem("<r>", "Refresh")
 	lines = append(lines, "  "+row1Label+row1Keys)
 	
 	row2Label := labelStyle.Render("Ops:")
@@ -800,26 +1218,36 @@ func (v *ListView) renderStatusBar() string {
 	lines = append(lines, "  "+row2Label+row2Keys)
 	
 	row3Label := labelStyle.Render("Advanced:")
-	row3Keys := makeItem("<Ctrl+D>", "Delete") + makeItem("<e>", "Edit") + makeItem("<i>", "Inspect") + makeItem("<L>", "Logs")
+	row3Keys := makeItem("<Ctrl+D>", "Delete") + makeItem("<e>", "Edit") + makeItem("<i>", "Inspect") + makeItem("<C>", "Run Cmd") + makeItem("<L>", "Logs") + makeItem("<v>", "Events") + makeItem("<D>", "Duplicate") + makeItem("<b>", "Debug") + makeItem("<x>", "Exec") + makeItem("<X>", "Net Test") + makeItem("<K>", "Kube") + makeItem("<N>", "Note") + makeItem("<*>", "Star")
 	lines = append(lines, "  "+row3Label+row3Keys)
 
 	row4Label := labelStyle.Render("Select:")
-	row4Keys := makeItem("<Space>", "Toggle") + makeItem("<a>", "All")
+	row4Keys := makeItem("<Space>", "Toggle") + makeItem("<a>", "All") + makeItem("<P>", "Restart policy") + makeItem("<p>", "Prune")
 	lines = append(lines, "  "+row4Label+row4Keys)
+
+	row4bLabel := labelStyle.Render("Data:")
+	row4bKeys := makeItem("<w>", "Export list") + makeItem("<.>", "Repeat") + makeItem("<m>", "Record") + makeItem("<@>", "Replay")
+	lines = append(lines, "  "+row4bLabel+row4bKeys)
 	
-	refreshInfo := "-"
-	if !v.lastRefreshTime.IsZero() {
-		refreshInfo = formatDuration(time.Since(v.lastRefreshTime)) + " ago"
+	refreshInfo := hintStyle.Render("-")
+	if v.polling {
+		refreshInfo = v.refresh.View() + hintStyle.Render(" (events unavailable, polling)")
+	} else if updated := v.refresh.Updated(); !updated.IsZero() {
+		refreshInfo = hintStyle.Render(format.Duration(time.Since(updated)) + " ago (live events)")
 	}
 	
 	row5Label := labelStyle.Render("Last Refresh:")
-	row5Info := hintStyle.Render(refreshInfo) + "    " + 
+	row5Info := refreshInfo + "    " + 
 		hintStyle.Render("j/k=Up/Down  Enter=Details  Esc=Back  q=Quit")
 	
 	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("82")).Bold(true)
 	if len(v.selectedContainers) > 0 {
 		row5Info += "    " + selectedStyle.Render(fmt.Sprintf("[Selected: %d]", len(v.selectedContainers)))
 	}
+	if v.recorder.recording {
+		recStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		row5Info += "    " + recStyle.Render(fmt.Sprintf("[● REC %d]", len(v.recorder.pending)))
+	}
 	
 	lines = append(lines, "  "+row5Label+row5Info)
 	
@@ -830,53 +1258,35 @@ func (v *ListView) renderStatusBar() string {
 func (v *ListView) containersToRows(containers []docker.Container) []table.Row {
 	rows := make([]table.Row, len(containers))
 	
-	exitedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
-	pausedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
-	unhealthyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
 	
 	for i, c := range containers {
-		created := formatCreatedTime(c.Created)
+		created := format.AgoLong(c.Created)
 		ports := c.Ports
 		if ports == "" {
 			ports = ""
 		}
 		
-		var rowStyle lipgloss.Style
-		var needsStyle bool
-		
-		switch {
-		case strings.Contains(strings.ToLower(c.Status), "unhealthy"):
-			rowStyle = unhealthyStyle
-			needsStyle = true
-		case c.State == "paused":
-			rowStyle = pausedStyle
-			needsStyle = true
-		case c.State == "exited":
-			rowStyle = exitedStyle
-			needsStyle = true
-		default:
-			needsStyle = false
-		}
+		rowStyle, needsStyle := containerRowStyle(c)
 		
 		if needsStyle {
 			rows[i] = table.Row{
 				rowStyle.Render(c.ShortID),
-				rowStyle.Render(c.Name),
+				rowStyle.Render(nameWithBadges(c)),
 				rowStyle.Render(c.Image),
 				rowStyle.Render(c.Command),
 				rowStyle.Render(created),
-				rowStyle.Render(c.Status),
-				rowStyle.Render(ports),
+				rowStyle.Render(v.statusText(c)),
+				v.portsCell(ports, rowStyle.Render),
 			}
 		} else {
 			rows[i] = table.Row{
 				c.ShortID,
-				c.Name,
+				nameWithBadges(c),
 				c.Image,
 				c.Command,
 				created,
-				c.Status,
-				ports,
+				styles.StatusStyle(styles.StatusRunning).Render(v.statusText(c)),
+				v.portsCell(ports, plainText),
 			}
 		}
 	}
@@ -884,51 +1294,27 @@ func (v *ListView) containersToRows(containers []docker.Container) []table.Row {
 	return rows
 }
 
-// formatCreatedTime 格式化创建时间
-func formatCreatedTime(t time.Time) string {
-	d := time.Since(t)
-	
-	if d < time.Minute {
-		return fmt.Sprintf("%d seconds ago", int(d.Seconds()))
-	}
-	if d < time.Hour {
-		m := int(d.Minutes())
-		if m == 1 {
-			return "1 minute ago"
-		}
-		return fmt.Sprintf("%d minutes ago", m)
-	}
-	if d < 24*time.Hour {
-		h := int(d.Hours())
-		if h == 1 {
-			return "1 hour ago"
-		}
-		return fmt.Sprintf("%d hours ago", h)
+// containerRowStyle 非运行状态（停止、暂停、失败、unhealthy 等）的容器整行按状态着色；
+// 运行中的容器返回 false，只给 STATUS 列着色
+func containerRowStyle(c docker.Container) (lipgloss.Style, bool) {
+	st := styles.ContainerStatus(c.State, c.Status)
+	if st == styles.StatusRunning {
+		return lipgloss.Style{}, false
 	}
-	if d < 48*time.Hour {
-		return "1 day ago"
-	}
-	if d < 30*24*time.Hour {
-		return fmt.Sprintf("%d days ago", int(d.Hours())/24)
-	}
-	if d < 60*24*time.Hour {
-		return "1 month ago"
-	}
-	return fmt.Sprintf("%d months ago", int(d.Hours())/(24*30))
+	return styles.StatusStyle(st), true
 }
 
-// formatDuration 格式化时间差
-func formatDuration(d time.Duration) string {
-	if d < time.Second {
-		return "just now"
-	}
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", int(d.Seconds()))
-	}
-	if d < time.Hour {
-		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+// nameWithBadges 容器名称加上 Kubernetes 节点、仿真运行和备注标记
+func nameWithBadges(c docker.Container) string {
+	return c.Name + favorites.Badge(favorites.KindContainer, c.Name) + kubeBadge(c) + emulatedBadge(c) + notes.Lookup(notes.KindContainer, c.Name).Badge()
+}
+
+// emulatedBadge 镜像架构与守护进程不同（通过 QEMU/Rosetta 仿真运行）的容器显示红色标记
+func emulatedBadge(c docker.Container) string {
+	if !c.Emulated {
+		return ""
 	}
-	return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	return " " + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("⚠ "+c.Platform)
 }
 
 // SetSize 设置视图尺寸
@@ -936,7 +1322,7 @@ func (v *ListView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
 	
-	tableHeight := height - 15
+	tableHeight := height - 16
 	if tableHeight < 5 {
 		tableHeight = 5
 	}
@@ -950,6 +1336,34 @@ func (v *ListView) SetSize(width, height int) {
 		v.editView.SetWidth(width)
 	}
 	
+	if v.pruneView != nil {
+		v.pruneView.SetWidth(width)
+	}
+	
+	if v.restartPolicyView != nil {
+		v.restartPolicyView.SetWidth(width)
+	}
+	
+	if v.netTestView != nil {
+		v.netTestView.SetSize(width, height)
+	}
+	if v.execView != nil {
+		v.execView.SetSize(width, height)
+	}
+	if v.duplicateView != nil {
+		v.duplicateView.SetWidth(width)
+	}
+	if v.debugView != nil {
+		v.debugView.SetWidth(width)
+	}
+	if v.noteInput != nil {
+		v.noteInput.SetWidth(width)
+	}
+	
+	if v.listExport != nil {
+		v.listExport.SetWidth(width)
+	}
+	
 	if v.errorDialog != nil {
 		v.errorDialog.SetWidth(width)
 	}
@@ -975,18 +1389,18 @@ func (v *ListView) updateColumnWidths() {
 		if len(c.Command) > maxCommand {
 			maxCommand = len(c.Command)
 		}
-		created := formatCreatedTime(c.Created)
+		created := format.AgoLong(c.Created)
 		if len(created) > maxCreated {
 			maxCreated = len(created)
 		}
-		if len(c.Status) > maxStatus {
-			maxStatus = len(c.Status)
+		if n := lipgloss.Width(v.statusText(c)); n > maxStatus {
+			maxStatus = n
 		}
 		if len(c.Ports) > maxPorts {
 			maxPorts = len(c.Ports)
 		}
-		if len(c.Name) > maxNames {
-			maxNames = len(c.Name)
+		if n := lipgloss.Width(nameWithBadges(c)); n > maxNames {
+			maxNames = n
 		}
 	}
 	
@@ -1052,13 +1466,10 @@ func (v *ListView) updateColumnWidths() {
 		if len(v.filteredContainers) > 0 {
 			rows := make([]components.TableRow, len(v.filteredContainers))
 			
-			exitedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
-			pausedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
-			unhealthyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
 			selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
 			
 			for i, c := range v.filteredContainers {
-				created := formatCreatedTime(c.Created)
+				created := format.AgoLong(c.Created)
 				ports := c.Ports
 				if ports == "" {
 					ports = "-"
@@ -1069,44 +1480,29 @@ func (v *ListView) updateColumnWidths() {
 					selMark = selectedStyle.Render("✓")
 				}
 				
-				var rowStyle lipgloss.Style
-				var needsStyle bool
-				
-				switch {
-				case strings.Contains(strings.ToLower(c.Status), "unhealthy"):
-					rowStyle = unhealthyStyle
-					needsStyle = true
-				case c.State == "paused":
-					rowStyle = pausedStyle
-					needsStyle = true
-				case c.State == "exited":
-					rowStyle = exitedStyle
-					needsStyle = true
-				default:
-					needsStyle = false
-				}
+				rowStyle, needsStyle := containerRowStyle(c)
 				
 				if needsStyle {
 					rows[i] = components.TableRow{
 						selMark,
 						rowStyle.Render(c.ShortID),
-						rowStyle.Render(c.Name),
+						rowStyle.Render(nameWithBadges(c)),
 						rowStyle.Render(c.Image),
 						rowStyle.Render(c.Command),
 						rowStyle.Render(created),
-						rowStyle.Render(c.Status),
-						rowStyle.Render(ports),
+						rowStyle.Render(v.statusText(c)),
+						v.portsCell(ports, rowStyle.Render),
 					}
 				} else {
 					rows[i] = components.TableRow{
 						selMark,
 						c.ShortID,
-						c.Name,
+						nameWithBadges(c),
 						c.Image,
 						c.Command,
 						created,
-						c.Status,
-						ports,
+						styles.StatusStyle(styles.StatusRunning).Render(v.statusText(c)),
+						v.portsCell(ports, plainText),
 					}
 				}
 			}
@@ -1141,6 +1537,35 @@ func (v *ListView) GetSelectedContainer() *docker.Container {
 	return &v.filteredContainers[selectedIndex]
 }
 
+// restoreCursor 把光标移到指定 ID 的容器上，容器已不在列表中时保持原来的行号
+func (v *ListView) restoreCursor(id string) {
+	if id == "" {
+		return
+	}
+	for i, c := range v.filteredContainers {
+		if c.ID == id {
+			v.scrollTable.SetCursor(i)
+			v.tableModel.SetCursor(i)
+			return
+		}
+	}
+}
+
+// SetFilter 预设状态过滤和搜索关键字（用于命令行 --filter/--search），数据加载后生效
+func (v *ListView) SetFilter(filterType, query string) error {
+	switch filterType {
+	case "":
+		filterType = "all"
+	case "all", "running", "exited", "paused", "healthy", "unhealthy", "starting":
+	default:
+		return fmt.Errorf("unknown container filter %q (available: all, running, exited, paused, healthy, unhealthy, starting)", filterType)
+	}
+	v.filterType = filterType
+	v.searchQuery = query
+	v.applyFilters()
+	return nil
+}
+
 // IsSearching 返回是否处于搜索模式
 func (v *ListView) IsSearching() bool {
 	return v.isSearching
@@ -1164,9 +1589,18 @@ func (v *ListView) applyFilters() {
 			if container.State != "paused" {
 				continue
 			}
+		case "healthy", "unhealthy", "starting":
+			if container.Health() != v.filterType {
+				continue
+			}
 		}
 		
-		if v.searchQuery != "" {
+		// :8080 查找发布了该宿主机端口的容器
+		if port, ok := parsePortQuery(v.searchQuery); ok {
+			if !publishesPort(container.Ports, port) {
+				continue
+			}
+		} else if v.searchQuery != "" {
 			query := strings.ToLower(v.searchQuery)
 			if !strings.Contains(strings.ToLower(container.Name), query) &&
 			   !strings.Contains(strings.ToLower(container.Image), query) &&
@@ -1177,6 +1611,15 @@ func (v *ListView) applyFilters() {
 		
 		v.filteredContainers = append(v.filteredContainers, container)
 	}
+
+	// 在 ID、名称、镜像列中高亮搜索关键字（:8080 端口查询不高亮）
+	if v.scrollTable != nil {
+		highlight := v.searchQuery
+		if _, ok := parsePortQuery(highlight); ok {
+			highlight = ""
+		}
+		v.scrollTable.SetHighlight(highlight, 1, 2, 3)
+	}
 }
 
 // loadContainers 加载容器列表
@@ -1192,6 +1635,27 @@ func (v *ListView) loadContainers() tea.Msg {
 	return ContainersLoadedMsg{Containers: containers}
 }
 
+// fallbackRefreshInterval 事件流不可用时容器列表的轮询间隔（配置文件 refresh.containers）
+var fallbackRefreshInterval = 5 * time.Second
+
+// SetFallbackRefresh 设置事件流不可用时的轮询间隔，为 0 时保持默认值
+func SetFallbackRefresh(interval time.Duration) {
+	if interval > 0 {
+		fallbackRefreshInterval = interval
+	}
+}
+
+// containerPollTickMsg 轮询的倒计时
+type containerPollTickMsg struct {
+	gen int
+}
+
+// pollTick 触发下一次轮询的倒计时
+func (v *ListView) pollTick() tea.Cmd {
+	gen := v.pollGen
+	return tea.Tick(v.refresh.Step(), func(time.Time) tea.Msg { return containerPollTickMsg{gen: gen} })
+}
+
 // watchDockerEvents 监听 Docker 容器事件
 func (v *ListView) watchDockerEvents() tea.Cmd {
 	return func() tea.Msg {
@@ -1262,9 +1726,7 @@ func (v *ListView) stopSelectedContainer() tea.Cmd {
 		}
 	}
 
-	return v.batchContainerOperation("Stop", toStop, func(ctx context.Context, id string) error {
-		return v.dockerClient.StopContainer(ctx, id, 10)
-	})
+	return v.transitionOperation("Stop", "Stopping", toStop, v.stopAndWait)
 }
 
 // restartSelectedContainer 重启选中的容器
@@ -1276,9 +1738,7 @@ func (v *ListView) restartSelectedContainer() tea.Cmd {
 		}
 	}
 
-	return v.batchContainerOperation("Restart", containers, func(ctx context.Context, id string) error {
-		return v.dockerClient.RestartContainer(ctx, id, 10)
-	})
+	return v.transitionOperation("Restart", "Restarting", containers, v.restartAndWait)
 }
 
 // showRemoveConfirmDialog 显示删除确认对话框
@@ -1379,6 +1839,14 @@ func (v *ListView) clearSuccessMessageAfter(duration time.Duration) tea.Cmd {
 	}
 }
 
+// pauseAction u 键对当前容器执行的操作：已暂停时为恢复
+func (v *ListView) pauseAction() string {
+	if container := v.GetSelectedContainer(); container != nil && container.State == "paused" {
+		return "container.unpause"
+	}
+	return "container.pause"
+}
+
 // togglePauseContainer 暂停/恢复选中的容器
 func (v *ListView) togglePauseContainer() tea.Cmd {
 	container := v.GetSelectedContainer()
@@ -1466,6 +1934,30 @@ func (v *ListView) inspectContainer() tea.Cmd {
 	}
 }
 
+// copyRunCommand 还原选中容器的 docker run 命令，复制到剪贴板并在查看器中显示
+func (v *ListView) copyRunCommand() tea.Cmd {
+	container := v.GetSelectedContainer()
+	if container == nil {
+		return nil
+	}
+
+	containerID := container.ID
+	containerName := container.Name
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		command, err := v.dockerClient.ContainerRunCommand(ctx, containerID)
+		if err != nil {
+			return ContainerInspectErrorMsg{Err: err}
+		}
+
+		copied := components.CopyText("run-command", command)
+		return ContainerRunCommandMsg{ContainerName: containerName, Command: command, Copy: copied}
+	}
+}
+
 // updateContainerConfig 更新容器配置
 func (v *ListView) updateContainerConfig() tea.Cmd {
 	if v.editView == nil {
@@ -1490,6 +1982,118 @@ func (v *ListView) updateContainerConfig() tea.Cmd {
 	}
 }
 
+// applyRestartPolicy 将选择的重启策略批量应用到所选容器
+func (v *ListView) applyRestartPolicy() tea.Cmd {
+	config, err := v.restartPolicyView.GetConfig()
+	if err != nil {
+		return nil
+	}
+	containers := v.restartPolicyView.GetContainers()
+	v.restartPolicyView.Hide()
+
+	v.successMsg = fmt.Sprintf("⏳ Setting restart policy %s on %d containers...", config.RestartPolicy, len(containers))
+	v.successMsgTime = time.Now()
+	return v.batchContainerOperation("Set restart policy", containers, func(ctx context.Context, id string) error {
+		return v.dockerClient.UpdateContainer(ctx, id, config)
+	})
+}
+
+// duplicateContainer 按对话框中的参数复制容器
+func (v *ListView) duplicateContainer() tea.Cmd {
+	opts, err := v.duplicateView.GetOptions()
+	if err != nil {
+		return nil
+	}
+	source := v.duplicateView.GetContainer()
+	v.duplicateView.Hide()
+	if source == nil {
+		return nil
+	}
+
+	v.successMsg = fmt.Sprintf("⏳ Duplicating %s as %s...", source.Name, opts.Name)
+	v.successMsgTime = time.Now()
+	sourceID := source.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if _, err := v.dockerClient.DuplicateContainer(ctx, sourceID, opts); err != nil {
+			return ContainerOperationErrorMsg{Operation: "Duplicate container", Container: opts.Name, Err: err}
+		}
+		return ContainerOperationSuccessMsg{Operation: "Duplicate", Container: opts.Name}
+	}
+}
+
+// debugContainer 按对话框中的入口命令启动调试运行的临时容器，启动后进入其 shell
+func (v *ListView) debugContainer() tea.Cmd {
+	entrypoint := v.debugView.Entrypoint()
+	source := v.debugView.GetContainer()
+	v.debugView.Hide()
+	if source == nil {
+		return nil
+	}
+
+	v.successMsg = fmt.Sprintf("⏳ Starting debug container for %s...", source.Name)
+	v.successMsgTime = time.Now()
+	sourceID, sourceName := source.ID, source.Name
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		id, name, err := v.dockerClient.DebugContainer(ctx, sourceID, entrypoint)
+		if err != nil {
+			return ContainerOperationErrorMsg{Operation: "Debug run", Container: sourceName, Err: err}
+		}
+		return DebugShellMsg{ContainerID: id, ContainerName: name}
+	}
+}
+
+// saveNote 保存备注对话框中的备注并刷新列表标记
+func (v *ListView) saveNote() tea.Cmd {
+	kind, key, name := v.noteInput.Target()
+	note := v.noteInput.Note()
+	v.noteInput.Hide()
+	if err := notes.Save(kind, key, note); err != nil {
+		return func() tea.Msg {
+			return ContainerOperationErrorMsg{Operation: "Save note", Container: name, Err: err}
+		}
+	}
+	v.updateColumnWidths()
+	v.successMsg = "📝 Note saved for " + name
+	if note.Empty() {
+		v.successMsg = "📝 Note removed from " + name
+	}
+	v.successMsgTime = time.Now()
+	return v.clearSuccessMessageAfter(3 * time.Second)
+}
+
+// ShowPruneView 打开清理对话框（首页快捷操作使用）
+func (v *ListView) ShowPruneView() {
+	v.pruneView.SetWidth(v.width)
+	v.pruneView.Show()
+}
+
+// toggleFavorite 收藏/取消收藏选中的容器（按名称，重建后仍然保留）
+func (v *ListView) toggleFavorite() tea.Cmd {
+	container := v.GetSelectedContainer()
+	if container == nil {
+		return nil
+	}
+	starred, err := favorites.Toggle(favorites.Ref{Kind: favorites.KindContainer, Key: container.Name, Name: container.Name})
+	if err != nil {
+		return func() tea.Msg {
+			return ContainerOperationErrorMsg{Operation: "Favorite", Container: container.Name, Err: err}
+		}
+	}
+	v.updateColumnWidths()
+	v.successMsg = "★ Added to favorites: " + container.Name
+	if !starred {
+		v.successMsg = "☆ Removed from favorites: " + container.Name
+	}
+	v.successMsgTime = time.Now()
+	return v.clearSuccessMessageAfter(3 * time.Second)
+}
+
 // overlayEditView 将编辑视图叠加到现有内容上
 func (v *ListView) overlayEditView(baseContent string) string {
 	if v.editView == nil {
@@ -1498,11 +2102,61 @@ func (v *ListView) overlayEditView(baseContent string) string {
 	return components.OverlayCentered(baseContent, v.editView.View(), v.width, v.height)
 }
 
+// IsPruneViewVisible 返回清理对话框是否可见
+func (v *ListView) IsPruneViewVisible() bool {
+	return v.pruneView != nil && v.pruneView.IsVisible()
+}
+
+// IsListExportVisible 返回列表导出对话框是否可见
+func (v *ListView) IsListExportVisible() bool {
+	return v.listExport != nil && v.listExport.IsVisible()
+}
+
+// IsRestartPolicyViewVisible 返回批量重启策略对话框是否可见
+func (v *ListView) IsRestartPolicyViewVisible() bool {
+	return v.restartPolicyView != nil && v.restartPolicyView.IsVisible()
+}
+
+// IsExecViewVisible 返回执行命令对话框是否可见
+func (v *ListView) IsExecViewVisible() bool {
+	return v.execView != nil && v.execView.IsVisible()
+}
+
+// IsNetTestViewVisible 返回网络测试对话框是否可见
+func (v *ListView) IsNetTestViewVisible() bool {
+	return v.netTestView != nil && v.netTestView.IsVisible()
+}
+
+// IsKubeViewVisible 返回 Kubernetes 节点对话框是否可见
+func (v *ListView) IsKubeViewVisible() bool {
+	return v.kubeView != nil && v.kubeView.IsVisible()
+}
+
+// IsNoteInputVisible 返回备注对话框是否可见
+func (v *ListView) IsNoteInputVisible() bool {
+	return v.noteInput != nil && v.noteInput.IsVisible()
+}
+
+// IsDuplicateViewVisible 返回复制容器对话框是否可见
+func (v *ListView) IsDuplicateViewVisible() bool {
+	return v.duplicateView != nil && v.duplicateView.IsVisible()
+}
+
+// IsDebugViewVisible 返回调试运行对话框是否可见
+func (v *ListView) IsDebugViewVisible() bool {
+	return v.debugView != nil && v.debugView.IsVisible()
+}
+
 // IsEditViewVisible 返回编辑视图是否可见
 func (v *ListView) IsEditViewVisible() bool {
 	return v.editView != nil && v.editView.IsVisible()
 }
 
+// IsConfirmDialogVisible 返回是否正在显示确认对话框
+func (v *ListView) IsConfirmDialogVisible() bool {
+	return v.showConfirmDialog
+}
+
 // HasError 返回是否有错误信息显示
 func (v *ListView) HasError() bool {
 	return v.errorDialog != nil && v.errorDialog.IsVisible()
@@ -1534,8 +2188,13 @@ func (v *ListView) getSelectedOrCurrentContainers() []docker.Container {
 
 // batchContainerOperation 批量执行容器操作
 func (v *ListView) batchContainerOperation(opName string, containers []docker.Container, op func(ctx context.Context, id string) error) tea.Cmd {
+	return v.batchContainerOperationTimeout(opName, 60*time.Second, containers, op)
+}
+
+// batchContainerOperationTimeout 与 batchContainerOperation 相同，但使用指定的总超时
+func (v *ListView) batchContainerOperationTimeout(opName string, timeout time.Duration, containers []docker.Container, op func(ctx context.Context, id string) error) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
 		successCount := 0
@@ -1586,13 +2245,10 @@ func (v *ListView) updateTableData() {
 
 	rows := make([]components.TableRow, len(v.filteredContainers))
 	
-	exitedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
-	pausedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
-	unhealthyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
 	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
 	
 	for i, c := range v.filteredContainers {
-		created := formatCreatedTime(c.Created)
+		created := format.AgoLong(c.Created)
 		ports := c.Ports
 		if ports == "" {
 			ports = "-"
@@ -1603,44 +2259,29 @@ func (v *ListView) updateTableData() {
 			selMark = selectedStyle.Render("✓")
 		}
 		
-		var rowStyle lipgloss.Style
-		var needsStyle bool
-		
-		switch {
-		case strings.Contains(strings.ToLower(c.Status), "unhealthy"):
-			rowStyle = unhealthyStyle
-			needsStyle = true
-		case c.State == "paused":
-			rowStyle = pausedStyle
-			needsStyle = true
-		case c.State == "exited":
-			rowStyle = exitedStyle
-			needsStyle = true
-		default:
-			needsStyle = false
-		}
+		rowStyle, needsStyle := containerRowStyle(c)
 		
 		if needsStyle {
 			rows[i] = components.TableRow{
 				selMark,
 				rowStyle.Render(c.ShortID),
-				rowStyle.Render(c.Name),
+				rowStyle.Render(nameWithBadges(c)),
 				rowStyle.Render(c.Image),
 				rowStyle.Render(c.Command),
 				rowStyle.Render(created),
-				rowStyle.Render(c.Status),
-				rowStyle.Render(ports),
+				rowStyle.Render(v.statusText(c)),
+				v.portsCell(ports, rowStyle.Render),
 			}
 		} else {
 			rows[i] = components.TableRow{
 				selMark,
 				c.ShortID,
-				c.Name,
+				nameWithBadges(c),
 				c.Image,
 				c.Command,
 				created,
-				c.Status,
-				ports,
+				styles.StatusStyle(styles.StatusRunning).Render(v.statusText(c)),
+				v.portsCell(ports, plainText),
 			}
 		}
 	}
@@ -1651,3 +2292,65 @@ func (v *ListView) updateTableData() {
 func (v *ListView) GetSelectedCount() int {
 	return len(v.selectedContainers)
 }
+
+// handlePruneConfirm 处理清理对话框的确认：选项阶段加载预览，预览阶段执行清理
+func (v *ListView) handlePruneConfirm() tea.Cmd {
+	opts, err := v.pruneView.GetOptions()
+	if err != nil {
+		v.pruneView.SetError(err.Error())
+		return nil
+	}
+	if !v.pruneView.IsPreviewing() {
+		v.pruneView.SetLoading()
+		return v.previewPrune(opts)
+	}
+	v.pruneView.Hide()
+	v.successMsg = "⏳ Pruning stopped containers..."
+	v.successMsgTime = time.Now()
+	return v.pruneContainers(opts)
+}
+
+// previewPrune 获取将被清理的容器列表
+func (v *ListView) previewPrune(opts docker.ContainerPruneOptions) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		candidates, err := v.dockerClient.PruneContainersPreview(ctx, opts)
+		if err != nil {
+			return ContainerPrunePreviewErrorMsg{Err: err}
+		}
+		return ContainerPrunePreviewMsg{Candidates: candidates}
+	}
+}
+
+// pruneContainers 执行已停止容器清理
+func (v *ListView) pruneContainers(opts docker.ContainerPruneOptions) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+		count, spaceReclaimed, err := v.dockerClient.PruneContainers(ctx, opts)
+		if err != nil {
+			return ContainerOperationErrorMsg{Operation: "Prune containers", Container: "stopped", Err: err}
+		}
+		return ContainerPruneSuccessMsg{Count: count, SpaceReclaimed: spaceReclaimed}
+	}
+}
+
+// containerExportRows 将容器列表映射为导出的列和行
+func containerExportRows(containers []docker.Container) ([]string, [][]any) {
+	columns := []string{"ID", "Name", "Image", "Command", "Created", "State", "Status", "Ports"}
+	rows := make([][]any, 0, len(containers))
+	for _, c := range containers {
+		rows = append(rows, []any{
+			c.ID,
+			c.Name,
+			c.Image,
+			c.Command,
+			c.Created.Format(time.RFC3339),
+			c.State,
+			c.Status,
+			c.Ports,
+		})
+	}
+	return columns, rows
+}
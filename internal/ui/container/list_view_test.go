@@ -0,0 +1,179 @@
+package container
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+	"docktui/internal/policy"
+	"docktui/internal/ui/components"
+)
+
+// benchContainers 基准测试使用的容器数量（模拟大型主机）
+const benchContainers = 10000
+
+// syntheticContainers 生成 n 个状态、健康检查、端口和名称各不相同的容器
+func syntheticContainers(n int) []docker.Container {
+	states := []struct{ state, status string }{
+		{"running", "Up 2 hours"},
+		{"running", "Up 5 minutes (healthy)"},
+		{"running", "Up 30 seconds (unhealthy)"},
+		{"running", "Up 3 seconds (health: starting)"},
+		{"exited", "Exited (0) 3 days ago"},
+		{"exited", "Exited (137) 10 minutes ago"},
+		{"paused", "Up 1 hour (Paused)"},
+	}
+	now := time.Now()
+	containers := make([]docker.Container, n)
+	for i := range containers {
+		s := states[i%len(states)]
+		id := fmt.Sprintf("%064x", i*7919+1)
+		containers[i] = docker.Container{
+			ID:      id,
+			ShortID: id[:12],
+			Name:    fmt.Sprintf("project-%d-service-%d-%d", i/50, i%50, i%3+1),
+			Image:   fmt.Sprintf("registry.example.com/team-%d/app-%d:1.%d.%d", i%20, i%200, i%10, i%7),
+			Command: fmt.Sprintf("\"/docker-entrypoint.sh --worker %d\"", i),
+			Created: now.Add(-time.Duration(i) * time.Minute),
+			Status:  s.status,
+			State:   s.state,
+			Ports:   fmt.Sprintf("0.0.0.0:%d->80/tcp, [::]:%d->80/tcp", 10000+i%50000, 10000+i%50000),
+			Labels:  map[string]string{"com.docker.compose.project": fmt.Sprintf("project-%d", i/50)},
+		}
+	}
+	return containers
+}
+
+// newBenchListView 创建已加载合成容器的列表视图
+func newBenchListView(b *testing.B) *ListView {
+	b.Helper()
+	v := NewListView(nil)
+	v.containers = syntheticContainers(benchContainers)
+	v.width, v.height = 200, 60
+	return v
+}
+
+// BenchmarkApplyFilters 过滤 10k 个容器：无过滤、状态、健康检查、关键字搜索和端口搜索
+func BenchmarkApplyFilters(b *testing.B) {
+	cases := []struct {
+		name, filter, query string
+	}{
+		{"all", "all", ""},
+		{"running", "running", ""},
+		{"unhealthy", "unhealthy", ""},
+		{"search", "all", "service-7"},
+		{"search-miss", "all", "no-such-container"},
+		{"port", "all", ":10080"},
+	}
+	for _, tc := range cases {
+		b.Run(tc.name, func(b *testing.B) {
+			v := newBenchListView(b)
+			v.filterType, v.searchQuery = tc.filter, tc.query
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				v.applyFilters()
+			}
+		})
+	}
+}
+
+// BenchmarkUpdateColumnWidths 按 10k 个容器计算列宽并生成表格行
+func BenchmarkUpdateColumnWidths(b *testing.B) {
+	v := newBenchListView(b)
+	v.filterType = "all"
+	v.applyFilters()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.updateColumnWidths()
+	}
+}
+
+// TestApplyFilters 测试合成数据上的过滤结果，保证基准测试测量的是有效的过滤
+func TestApplyFilters(t *testing.T) {
+	v := NewListView(nil)
+	v.containers = syntheticContainers(700)
+	tests := []struct {
+		filter, query string
+		want          int
+	}{
+		{"all", "", 700},
+		{"running", "", 400},
+		{"exited", "", 200},
+		{"paused", "", 100},
+		{"healthy", "", 100},
+		{"unhealthy", "", 100},
+		{"starting", "", 100},
+		{"all", "project-13-", 50},
+		{"running", "PROJECT-13-", 28},
+		{"all", ":10080", 1},
+		{"all", ":9", 0},
+	}
+	for _, tt := range tests {
+		v.filterType, v.searchQuery = tt.filter, tt.query
+		v.applyFilters()
+		if got := len(v.filteredContainers); got != tt.want {
+			t.Errorf("filter %q, search %q: got %d containers, want %d", tt.filter, tt.query, got, tt.want)
+		}
+	}
+}
+
+// collectMsgs 执行命令并展开批量命令，返回产生的消息
+func collectMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, collectMsgs(c)...)
+	}
+	return msgs
+}
+
+// TestRepeatAndReplayRespectPolicy 测试只读模式下用 . 重复和 @ 回放的操作同样被拒绝
+func TestRepeatAndReplayRespectPolicy(t *testing.T) {
+	policy.SetReadOnly(true)
+	defer policy.SetReadOnly(false)
+
+	v := NewListView(nil)
+	v.containers = syntheticContainers(3)
+	v.width, v.height = 200, 60
+	v.applyFilters()
+
+	stop := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}}
+	down := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}
+	v.recorder.last, v.recorder.hasLast = stop, true
+	v.recorder.macro = []tea.KeyMsg{stop, down, stop}
+
+	tests := []struct {
+		name string
+		cmd  func() tea.Cmd
+		want int
+	}{
+		{"repeat", v.repeatLastAction, 1},
+		{"replay", v.replayMacro, 2},
+	}
+	for _, tt := range tests {
+		var denied int
+		for _, msg := range collectMsgs(tt.cmd()) {
+			if d, ok := msg.(components.ActionDeniedMsg); ok {
+				if d.Action != "container.stop" {
+					t.Errorf("%s: denied action = %s, want container.stop", tt.name, d.Action)
+				}
+				denied++
+			}
+		}
+		if denied != tt.want {
+			t.Errorf("%s: denied %d actions, want %d", tt.name, denied, tt.want)
+		}
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,13 +18,22 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/docker/docker/pkg/stdcopy"
 
 	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/redact"
 	"docktui/internal/ui/components"
 	"docktui/internal/ui/search"
 )
 
+// LogSource 合并日志中的一个容器
+type LogSource struct {
+	ID   string
+	Name string
+}
+
 // LogsView 日志视图
 type LogsView struct {
 	dockerClient docker.Client
@@ -31,21 +43,28 @@ type LogsView struct {
 	
 	containerID   string
 	containerName string
+	pinned        bool // 已固定：同名容器重建后自动重新附加
+	sources       []LogSource // 合并查看多个容器的日志时的容器，为空时查看单个容器
 	
 	logs       []string
+	entries    []docker.TimedLogLine // 与 logs 一一对应的日志行（时间戳已解析），logs 是按显示设置渲染的文本
+	lineRows   []int // 每条日志在 viewport 中的起始行（换行模式下一条日志占多行）
 	viewport   viewport.Model
 	followMode bool
 	wrapMode   bool
 	showTimestamp bool
+	sinceStart bool      // 只显示最近一次启动以来的日志
+	startedAt  time.Time // sinceStart 时容器的启动时间
 	loading    bool
 	errorMsg   string
 	successMsg string
 	
 	followCancel    context.CancelFunc
 	followActive    bool
+	waitingStart    bool // 容器未运行：等待启动事件，启动后自动开始跟随
 	lastRefreshTime time.Time
 	lastLogTime     string
-	logChan         chan string
+	logChan         chan docker.TimedLogLine
 	chanClosed      bool
 	
 	// 搜索相关
@@ -57,9 +76,24 @@ type LogsView struct {
 	exportMode   bool
 	exportInput  textinput.Model
 	
+	// 跳转到时间：jumpAt 非零时只显示该时间前后 logJumpSpan 内的日志，不跟随
+	gotoMode  bool
+	gotoInput textinput.Model
+	gotoErr   string
+	jumpAt    time.Time
+	
 	keys components.KeyMap
 }
 
+// logJumpSpan 跳转到时间时查询的时间窗口（前后各一段）
+const logJumpSpan = 5 * time.Minute
+
+// logJumpSpanText 时间窗口的显示文本
+var logJumpSpanText = fmt.Sprintf("%gm", logJumpSpan.Minutes())
+
+// logJumpMaxLines 时间窗口内最多保留的日志行数（保留离目标时间最近的部分）
+const logJumpMaxLines = 2000
+
 // NewLogsView 创建日志视图
 func NewLogsView(dockerClient docker.Client) *LogsView {
 	vp := viewport.New(80, 20)
@@ -81,6 +115,12 @@ func NewLogsView(dockerClient docker.Client) *LogsView {
 	ei.CharLimit = 200
 	ei.Width = 50
 	
+	// 跳转时间输入框
+	gi := textinput.New()
+	gi.Placeholder = "15:04, 01-02 15:04:05, RFC3339 or 10m ago"
+	gi.CharLimit = 40
+	gi.Width = 40
+	
 	return &LogsView{
 		dockerClient:  dockerClient,
 		viewport:      vp,
@@ -88,12 +128,13 @@ func NewLogsView(dockerClient docker.Client) *LogsView {
 		wrapMode:      true,
 		showTimestamp: false,
 		keys:          components.DefaultKeyMap(),
-		logChan:       make(chan string, 100),
+		logChan:       make(chan docker.TimedLogLine, 100),
 		width:         100,
 		height:        30,
 		searchInput:   ti,
 		searcher:      search.NewTextSearcher(),
 		exportInput:   ei,
+		gotoInput:     gi,
 	}
 }
 
@@ -101,11 +142,102 @@ func NewLogsView(dockerClient docker.Client) *LogsView {
 func (v *LogsView) SetContainer(containerID, containerName string) {
 	v.containerID = containerID
 	v.containerName = containerName
+	v.sources = nil
+}
+
+// SetMergedContainers 合并查看多个容器的日志，按时间戳交错显示，每行前标注容器名称
+func (v *LogsView) SetMergedContainers(title string, sources []LogSource) {
+	v.containerID = ""
+	v.containerName = title
+	v.sources = sources
+}
+
+// IsMerged 是否在合并查看多个容器的日志
+func (v *LogsView) IsMerged() bool {
+	return len(v.sources) > 0
+}
+
+// hasTarget 是否设置了要查看的容器
+func (v *LogsView) hasTarget() bool {
+	return v.containerID != "" || len(v.sources) > 0
+}
+
+// targets 要读取日志的容器：合并模式下为所有容器，否则为当前容器（不标注名称）
+func (v *LogsView) targets() []LogSource {
+	if len(v.sources) > 0 {
+		return v.sources
+	}
+	return []LogSource{{ID: v.containerID}}
+}
+
+// ContainerID 返回当前查看的容器 ID
+func (v *LogsView) ContainerID() string {
+	return v.containerID
+}
+
+// ContainerName 返回当前查看的容器名称
+func (v *LogsView) ContainerName() string {
+	return v.containerName
+}
+
+// SetPinned 设置是否显示固定标记
+func (v *LogsView) SetPinned(pinned bool) {
+	v.pinned = pinned
+}
+
+// IsFollowing 是否正在跟随日志流
+func (v *LogsView) IsFollowing() bool {
+	return v.followActive
+}
+
+// IsWaitingStart 是否在等待容器启动
+func (v *LogsView) IsWaitingStart() bool {
+	return v.waitingStart
+}
+
+// waitForStart 进入等待启动状态，由主模型订阅启动事件后调用 Reattach
+func (v *LogsView) waitForStart() tea.Cmd {
+	v.waitingStart = true
+	v.followActive = false
+	id := v.containerID
+	return func() tea.Msg { return WaitForStartMsg{ContainerID: id} }
+}
+
+// IsCapturingInput 是否处于搜索、导出或跳转时间的输入状态
+func (v *LogsView) IsCapturingInput() bool {
+	return v.searchMode || v.exportMode || v.gotoMode
+}
+
+// Reattach 附加到同名的新容器实例（或重新启动的同一实例），保留已显示的日志（只看本次启动时清空），从 since 起继续跟随
+func (v *LogsView) Reattach(containerID string, since time.Time) tea.Cmd {
+	v.Cleanup()
+	if containerID != v.containerID {
+		shortID := containerID
+		if len(shortID) > 12 {
+			shortID = shortID[:12]
+		}
+		v.appendEntry(docker.TimedLogLine{Text: fmt.Sprintf("──── 📌 reattached to %s (%s) ────", v.containerName, shortID)})
+	}
+	if v.sinceStart {
+		// 只看本次启动的日志：丢弃之前实例的日志
+		v.logs = nil
+		v.entries = nil
+		v.startedAt = since
+	}
+	v.viewport.SetContent(v.formatLogs())
+	v.viewport.GotoBottom()
+	v.containerID = containerID
+	v.lastLogTime = since.UTC().Format(time.RFC3339Nano)
+	v.errorMsg = ""
+	v.waitingStart = false
+	v.followMode = true
+	v.followActive = true
+	return v.startStreamingLogs()
 }
 
 // Init 初始化
 func (v *LogsView) Init() tea.Cmd {
-	if v.containerID == "" {
+	if !v.hasTarget() {
 		return nil
 	}
 	v.loading = true
@@ -115,7 +247,9 @@ func (v *LogsView) Init() tea.Cmd {
 
 // 消息类型定义
 type logsLoadedMsg struct {
-	logs []string
+	entries   []docker.TimedLogLine
+	stopped   bool      // 单个容器未在运行（已创建或已退出）
+	startedAt time.Time // sinceStart 时容器的启动时间
 }
 
 type logsLoadErrorMsg struct {
@@ -123,7 +257,7 @@ type logsLoadErrorMsg struct {
 }
 
 type followLogLineMsg struct {
-	line string
+	line docker.TimedLogLine
 }
 
 type followStoppedMsg struct {
@@ -144,18 +278,33 @@ func (v *LogsView) Update(msg tea.Msg) (*LogsView, tea.Cmd) {
 	
 	switch msg := msg.(type) {
 	case logsLoadedMsg:
-		v.logs = msg.logs
+		v.entries = msg.entries
+		v.renderLines()
+		v.lastLogTime = ""
+		if n := len(v.entries); n > 0 && !v.entries[n-1].Time.IsZero() {
+			v.lastLogTime = v.entries[n-1].Time.UTC().Format(time.RFC3339Nano)
+		}
+		v.startedAt = msg.startedAt
 		v.loading = false
 		v.errorMsg = ""
 		v.viewport.SetContent(v.formatLogs())
+		if !v.jumpAt.IsZero() {
+			v.gotoTime(v.jumpAt)
+			return v, nil
+		}
 		v.viewport.GotoBottom()
 		
+		// 容器未运行时日志流会立即结束：等容器启动后再跟随
+		if v.followMode && msg.stopped {
+			return v, v.waitForStart()
+		}
+		
 		// 自动启动跟随模式
 		if v.followMode && !v.followActive {
 			if v.followCancel != nil {
 				v.followCancel()
 			}
-			v.logChan = make(chan string, 100)
+			v.logChan = make(chan docker.TimedLogLine, 100)
 			v.chanClosed = false
 			v.followActive = true
 			return v, v.startStreamingLogs()
@@ -168,11 +317,12 @@ func (v *LogsView) Update(msg tea.Msg) (*LogsView, tea.Cmd) {
 		return v, nil
 		
 	case followLogLineMsg:
-		if msg.line != "" {
-			v.logs = append(v.logs, msg.line)
+		if msg.line.Text != "" || !msg.line.Time.IsZero() {
+			v.insertEntry(msg.line)
 			v.lastRefreshTime = time.Now()
 			if len(v.logs) > 1000 {
 				v.logs = v.logs[len(v.logs)-1000:]
+				v.entries = v.entries[len(v.entries)-1000:]
 			}
 			v.viewport.SetContent(v.formatLogs())
 			v.viewport.GotoBottom()
@@ -186,6 +336,11 @@ func (v *LogsView) Update(msg tea.Msg) (*LogsView, tea.Cmd) {
 		v.followActive = false
 		if msg.err != nil {
 			v.errorMsg = fmt.Sprintf("Follow stopped: %s", msg.err.Error())
+			return v, nil
+		}
+		// 日志流正常结束说明容器已退出（如启动即崩溃），继续等待下一次启动
+		if v.followMode && !v.waitingStart && !v.IsMerged() && v.containerID != "" {
+			return v, v.waitForStart()
 		}
 		return v, nil
 		
@@ -224,6 +379,29 @@ func (v *LogsView) Update(msg tea.Msg) (*LogsView, tea.Cmd) {
 			}
 		}
 		
+		// 跳转时间输入的按键处理
+		if v.gotoMode {
+			switch msg.String() {
+			case "esc":
+				v.gotoMode = false
+				v.gotoInput.Blur()
+				return v, nil
+			case "enter":
+				at, err := docker.ParseLogTime(v.gotoInput.Value(), time.Now())
+				if err != nil {
+					v.gotoErr = err.Error()
+					return v, nil
+				}
+				v.gotoMode = false
+				v.gotoInput.Blur()
+				return v, v.jumpTo(at)
+			default:
+				v.gotoErr = ""
+				v.gotoInput, cmd = v.gotoInput.Update(msg)
+				return v, cmd
+			}
+		}
+		
 		// 搜索模式下的按键处理
 		if v.searchMode {
 			switch msg.String() {
@@ -265,7 +443,18 @@ func (v *LogsView) Update(msg tea.Msg) (*LogsView, tea.Cmd) {
 				v.viewport.SetContent(v.formatLogs())
 				return v, nil
 			}
+			// 跳转到时间后，先回到最新的日志并恢复跟随
+			if !v.jumpAt.IsZero() {
+				return v, v.leaveJump(true)
+			}
 			return v, func() tea.Msg { return GoBackMsg{} }
+		case msg.String() == "T":
+			// 输入要跳转的时间
+			v.gotoMode = true
+			v.gotoErr = ""
+			v.gotoInput.SetValue("")
+			v.gotoInput.Focus()
+			return v, textinput.Blink
 		case msg.String() == "/":
 			// 进入搜索模式
 			v.searchMode = true
@@ -296,12 +485,43 @@ func (v *LogsView) Update(msg tea.Msg) (*LogsView, tea.Cmd) {
 			}
 			return v, nil
 		case key.Matches(msg, v.keys.ToggleFollow):
+			if !v.jumpAt.IsZero() {
+				return v, v.leaveJump(true)
+			}
 			return v.toggleFollowMode()
 		case key.Matches(msg, v.keys.ToggleWrap):
 			v.wrapMode = !v.wrapMode
 			v.viewport.SetContent(v.formatLogs())
 			return v, nil
+		case msg.String() == "t":
+			// 切换时间戳显示，已有的日志按新设置重新渲染
+			v.showTimestamp = !v.showTimestamp
+			atBottom, top := v.viewport.AtBottom(), v.topLine()
+			v.renderLines()
+			if query := v.searchInput.Value(); query != "" && v.searcher.HasMatches() {
+				v.searcher.Search(v.logs, query)
+			}
+			v.viewport.SetContent(v.formatLogs())
+			if atBottom {
+				v.viewport.GotoBottom()
+			} else {
+				v.gotoLine(top)
+			}
+			return v, nil
+		case msg.String() == "s":
+			// 切换只显示最近一次启动以来的日志，跟随模式保持不变
+			v.sinceStart = !v.sinceStart
+			v.jumpAt = time.Time{}
+			v.loading = true
+			v.errorMsg = ""
+			if v.followActive && v.followCancel != nil {
+				v.followCancel()
+				v.followCancel = nil
+				v.followActive = false
+			}
+			return v, v.loadLogs
 		case key.Matches(msg, v.keys.Refresh):
+			v.jumpAt = time.Time{}
 			v.loading = true
 			v.errorMsg = ""
 			if v.followActive && v.followCancel != nil {
@@ -320,15 +540,78 @@ func (v *LogsView) Update(msg tea.Msg) (*LogsView, tea.Cmd) {
 	return v, cmd
 }
 
-// gotoLine 跳转到指定行
+// jumpTo 停止跟随，重新查询 at 前后的日志并定位到 at
+func (v *LogsView) jumpTo(at time.Time) tea.Cmd {
+	if v.followCancel != nil {
+		v.followCancel()
+		v.followCancel = nil
+	}
+	v.followActive = false
+	v.followMode = false
+	v.waitingStart = false
+	v.jumpAt = at
+	v.loading = true
+	v.errorMsg = ""
+	return v.loadLogs
+}
+
+// leaveJump 离开跳转到时间的窗口，重新加载最新的日志，follow 为 true 时恢复跟随
+func (v *LogsView) leaveJump(follow bool) tea.Cmd {
+	v.jumpAt = time.Time{}
+	v.followMode = follow
+	v.loading = true
+	v.errorMsg = ""
+	return v.loadLogs
+}
+
+// gotoTime 定位到 at 或之后的第一行日志，放在 viewport 上方三分之一处
+func (v *LogsView) gotoTime(at time.Time) {
+	idx := sort.Search(len(v.entries), func(i int) bool { return !v.entries[i].Time.Before(at) })
+	if idx >= len(v.entries) {
+		v.viewport.GotoBottom()
+		return
+	}
+	row := idx
+	if idx < len(v.lineRows) {
+		row = v.lineRows[idx]
+	}
+	v.viewport.SetYOffset(max(row-v.viewport.Height/3, 0))
+}
+
+// trimAround 只保留离 at 最近的 n 行日志（entries 已按时间排序）
+func trimAround(entries []docker.TimedLogLine, at time.Time, n int) []docker.TimedLogLine {
+	if len(entries) <= n {
+		return entries
+	}
+	idx := sort.Search(len(entries), func(i int) bool { return !entries[i].Time.Before(at) })
+	start := min(max(idx-n/2, 0), len(entries)-n)
+	return entries[start : start+n]
+}
+
+// gotoLine 跳转到指定日志行（换行模式下按该行的起始显示行）
 func (v *LogsView) gotoLine(lineIdx int) {
 	targetY := lineIdx
+	if lineIdx >= 0 && lineIdx < len(v.lineRows) {
+		targetY = v.lineRows[lineIdx]
+	}
 	if targetY < 0 {
 		targetY = 0
 	}
 	v.viewport.SetYOffset(targetY)
 }
 
+// topLine 返回 viewport 顶部显示的日志行
+func (v *LogsView) topLine() int {
+	top := 0
+	for i, row := range v.lineRows {
+		if row > v.viewport.YOffset {
+			break
+		}
+		top = i
+	}
+	return top
+}
+
 // exportLogs 导出日志到文件
 func (v *LogsView) exportLogs(filename string) error {
 	content := strings.Join(v.logs, "\n")
@@ -349,14 +632,18 @@ func (v *LogsView) View() string {
 	}
 	
 	if v.errorMsg != "" {
-		s.WriteString(v.renderStateBox("❌ Load failed", v.truncate(v.errorMsg, 50)))
+		s.WriteString(v.renderStateBox("❌ Load failed", format.Truncate(v.errorMsg, 50)))
 		s.WriteString(v.renderKeyHints())
 		return s.String()
 	}
 	
 	if len(v.logs) == 0 {
 		s.WriteString(v.renderEmptyState())
-		s.WriteString(v.renderKeyHints())
+		if v.gotoMode {
+			s.WriteString(v.renderGotoBar())
+		} else {
+			s.WriteString(v.renderKeyHints())
+		}
 		return s.String()
 	}
 	
@@ -370,6 +657,8 @@ func (v *LogsView) View() string {
 	// 导出模式显示输入框
 	if v.exportMode {
 		s.WriteString(v.renderExportBar())
+	} else if v.gotoMode {
+		s.WriteString(v.renderGotoBar())
 	} else if v.searchMode {
 		// 搜索模式下只显示搜索栏，隐藏快捷键提示
 		s.WriteString(v.renderSearchBar())
@@ -433,6 +722,29 @@ func (v *LogsView) renderExportBar() string {
 	return "\n  " + divider + "\n  " + content + "\n"
 }
 
+// renderGotoBar 渲染跳转时间的输入栏
+func (v *LogsView) renderGotoBar() string {
+	promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+	infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	sepStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	
+	availableWidth := v.width - 4
+	if availableWidth < 80 {
+		availableWidth = 80
+	}
+	
+	divider := sepStyle.Render(strings.Repeat("─", availableWidth))
+	
+	content := promptStyle.Render("⏱ Go to time: ") + v.gotoInput.View() +
+		"  " + infoStyle.Render(fmt.Sprintf("[Enter=Jump (±%s) ESC=Cancel]", logJumpSpanText))
+	if v.gotoErr != "" {
+		content += "\n  " + errStyle.Render("❌ "+v.gotoErr)
+	}
+	
+	return "\n  " + divider + "\n  " + content + "\n"
+}
+
 // renderSuccessMsg 渲染成功消息
 func (v *LogsView) renderSuccessMsg() string {
 	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("82")).Bold(true)
@@ -448,6 +760,12 @@ func (v *LogsView) renderHeader() string {
 		Padding(0, 1)
 	
 	title := titleStyle.Render("📜 Logs: " + v.containerName)
+	if v.IsMerged() {
+		title = titleStyle.Render(fmt.Sprintf("📜 Merged logs: %s (%d containers)", v.containerName, len(v.sources)))
+	}
+	if v.pinned {
+		title += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true).Render("📌 Pinned")
+	}
 	
 	lineWidth := v.width - 4
 	if lineWidth < 60 {
@@ -471,7 +789,9 @@ func (v *LogsView) renderStatusBar() string {
 	
 	var followStatus string
 	if v.followMode {
-		if v.followActive {
+		if v.waitingStart {
+			followStatus = onStyle.Render("⏳ WAITING FOR START")
+		} else if v.followActive {
 			followStatus = liveStyle.Render("● LIVE")
 		} else {
 			followStatus = onStyle.Render("READY")
@@ -489,10 +809,26 @@ func (v *LogsView) renderStatusBar() string {
 	
 	sep := sepStyle.Render("  │  ")
 	
+	timeStatus := offStyle.Render("OFF")
+	if v.showTimestamp {
+		timeStatus = onStyle.Render("ON")
+	}
+	
 	status := labelStyle.Render("Follow:") + " " + followStatus + sep +
 		labelStyle.Render("Wrap:") + " " + wrapStatus + sep +
+		labelStyle.Render("Time:") + " " + timeStatus + sep +
 		labelStyle.Render("Lines:") + " " + valueStyle.Render(fmt.Sprintf("%d", len(v.logs)))
 	
+	if !v.jumpAt.IsZero() {
+		status += sep + labelStyle.Render("At:") + " " + onStyle.Render(v.jumpAt.Local().Format("01-02 15:04:05")+" ±"+logJumpSpanText)
+	} else if v.sinceStart && !v.startedAt.IsZero() {
+		status += sep + labelStyle.Render("Since:") + " " + onStyle.Render("last start "+v.startedAt.Local().Format("01-02 15:04:05"))
+	}
+	
+	if n := redact.Count(); n > 0 {
+		status += sep + labelStyle.Render("Redact:") + " " + onStyle.Render(fmt.Sprintf("%d rules", n))
+	}
+	
 	if v.followMode && v.followActive && !v.lastRefreshTime.IsZero() {
 		status += sep + offStyle.Render("Latest: "+v.lastRefreshTime.Format("15:04:05"))
 	}
@@ -545,6 +881,30 @@ func (v *LogsView) renderEmptyState() string {
 	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
 	
+	if v.waitingStart {
+		content := lipgloss.JoinVertical(lipgloss.Left,
+			titleStyle.Render("⏳ Waiting for the container to start"),
+			"",
+			hintStyle.Render("  • Logs stream automatically as soon as it starts"),
+			hintStyle.Render("  • If it exits again, the view keeps waiting for the next start"),
+			"",
+			hintStyle.Render("  • Press ")+keyStyle.Render("f")+hintStyle.Render(" to stop waiting"),
+		)
+		return "\n  " + boxStyle.Render(content) + "\n"
+	}
+	
+	if !v.jumpAt.IsZero() {
+		content := lipgloss.JoinVertical(lipgloss.Left,
+			titleStyle.Render("📭 No logs around "+v.jumpAt.Local().Format("01-02 15:04:05")),
+			"",
+			hintStyle.Render(fmt.Sprintf("  • Nothing was logged within ±%s of this time", logJumpSpanText)),
+			hintStyle.Render("  • Older logs may have been rotated away by the logging driver"),
+			"",
+			hintStyle.Render("  • Press ")+keyStyle.Render("T")+hintStyle.Render(" to pick another time, ")+keyStyle.Render("Esc")+hintStyle.Render(" to return to the latest logs"),
+		)
+		return "\n  " + boxStyle.Render(content) + "\n"
+	}
+	
 	content := lipgloss.JoinVertical(lipgloss.Left,
 		hintStyle.Render("📭 No logs"),
 		"",
@@ -577,9 +937,13 @@ func (v *LogsView) renderKeyHints() string {
 		{"g/G", "Top/Bottom"},
 		{"/", "Search"},
 		{"e", "Export"},
-		{"f", "Follow"},
-		{"w", "Wrap"},
+		{v.keys.ToggleFollow.Help().Key, "Follow"},
+		{v.keys.ToggleWrap.Help().Key, "Wrap"},
+		{"t", "Timestamps"},
+		{"T", "Go to time"},
 		{"r", "Refresh"},
+		{"s", "Since start"},
+		{"W", "Pin"},
 		{"Esc", "Back"},
 	}
 	
@@ -613,12 +977,13 @@ func (v *LogsView) formatLogs() string {
 	currentHighlightStyle := lipgloss.NewStyle().Background(lipgloss.Color("208")).Foreground(lipgloss.Color("0")).Bold(true)
 	
 	var formatted strings.Builder
-	contentWidth := v.width - 12
-	if contentWidth < 40 {
-		contentWidth = 40
-	}
+	contentWidth := v.contentWidth()
+	v.lineRows = v.lineRows[:0]
+	row := 0
 	
 	for i, line := range v.logs {
+		v.lineRows = append(v.lineRows, row)
+		row++
 		formatted.WriteString(lineNumStyle.Render(fmt.Sprintf("%4d │ ", i+1)))
 		
 		// 基础样式
@@ -643,17 +1008,14 @@ func (v *LogsView) formatLogs() string {
 			displayLine = style.Render(line)
 		}
 		
-		if v.wrapMode && len(line) > contentWidth {
-			// 换行模式下简化处理（不高亮，避免复杂度）
-			for j := 0; j < len(line); j += contentWidth {
-				end := j + contentWidth
-				if end > len(line) {
-					end = len(line)
-				}
+		if v.wrapMode && ansi.StringWidth(line) > contentWidth {
+			// 换行模式下简化处理（不高亮，避免复杂度）；按显示宽度折行，宽字符不会被截断
+			for j, part := range strings.Split(ansi.Hardwrap(line, contentWidth, true), "\n") {
 				if j == 0 {
-					formatted.WriteString(style.Render(line[j:end]))
+					formatted.WriteString(style.Render(part))
 				} else {
-					formatted.WriteString("\n" + lineNumStyle.Render("     │ ") + style.Render(line[j:end]))
+					formatted.WriteString("\n" + lineNumStyle.Render("     │ ") + style.Render(part))
+					row++
 				}
 			}
 		} else {
@@ -709,18 +1071,15 @@ func (v *LogsView) highlightLine(line string, lineIdx int, hlStyle, currentHlSty
 	return result.String()
 }
 
-// truncate 截断字符串
-func (v *LogsView) truncate(s string, maxLen int) string {
-	if maxLen < 4 {
-		maxLen = 4
-	}
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
+// logLinePrefixWidth 每行前的行号（"%4d │ "）宽度
+const logLinePrefixWidth = 7
+
+// contentWidth 换行模式下每行日志内容的宽度：viewport 去掉边框、内边距和行号
+func (v *LogsView) contentWidth() int {
+	return max(10, v.viewport.Width-v.viewport.Style.GetHorizontalFrameSize()-logLinePrefixWidth)
 }
 
-// SetSize 设置视图尺寸
+// SetSize 设置视图尺寸；已有日志时按新宽度重新折行，保持顶部显示的日志行（在底部时保持在底部）
 func (v *LogsView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
@@ -729,78 +1088,170 @@ func (v *LogsView) SetSize(width, height int) {
 	if v.viewport.Height < 5 {
 		v.viewport.Height = 5
 	}
+	if len(v.logs) == 0 {
+		return
+	}
+	atBottom, top := v.viewport.AtBottom(), v.topLine()
+	v.viewport.SetContent(v.formatLogs())
+	if atBottom {
+		v.viewport.GotoBottom()
+	} else {
+		v.gotoLine(top)
+	}
 }
 
-// processLogLine 处理日志行：提取时间戳并根据设置决定是否显示
-func (v *LogsView) processLogLine(line string) string {
-	if len(line) > 30 && line[0] >= '0' && line[0] <= '9' {
-		if idx := strings.Index(line, " "); idx > 0 && idx < 35 {
-			v.lastLogTime = line[:idx]
-			if !v.showTimestamp && idx+1 < len(line) {
-				return line[idx+1:]
-			}
+// parseLogLine 处理日志行：解析行首的时间戳，再按遮盖规则处理敏感内容
+// 遮盖在读取时完成，搜索、显示和导出看到的都是遮盖后的内容
+func parseLogLine(source, line string) docker.TimedLogLine {
+	t, text, _ := docker.SplitLogTimestamp(line)
+	return docker.TimedLogLine{Time: t, Source: source, Text: redact.Apply(text)}
+}
+
+// renderLine 按显示设置渲染一行：时间戳统一为本地时区的 LogTimeLayout，合并模式下标注容器名称
+func (v *LogsView) renderLine(e docker.TimedLogLine) string {
+	var b strings.Builder
+	if v.showTimestamp && !e.Time.IsZero() {
+		b.WriteString(docker.FormatLogTime(e.Time) + " ")
+	}
+	if e.Source != "" {
+		b.WriteString("[" + e.Source + "] ")
+	}
+	b.WriteString(e.Text)
+	return b.String()
+}
+
+// renderLines 按 entries 重新渲染所有行
+func (v *LogsView) renderLines() {
+	v.logs = make([]string, len(v.entries))
+	for i, e := range v.entries {
+		v.logs[i] = v.renderLine(e)
+	}
+}
+
+// appendEntry 在末尾追加一行
+func (v *LogsView) appendEntry(e docker.TimedLogLine) {
+	v.entries = append(v.entries, e)
+	v.logs = append(v.logs, v.renderLine(e))
+}
+
+// insertEntry 按时间戳插入跟随模式收到的行：stdout/stderr 和多个容器的日志到达顺序不一定是产生顺序
+func (v *LogsView) insertEntry(e docker.TimedLogLine) {
+	i := len(v.entries)
+	if !e.Time.IsZero() {
+		for i > 0 && v.entries[i-1].Time.After(e.Time) {
+			i--
 		}
 	}
-	return line
+	if i == len(v.entries) {
+		v.appendEntry(e)
+		if !e.Time.IsZero() {
+			// 重新跟随时从最新的一行继续
+			v.lastLogTime = e.Time.UTC().Format(time.RFC3339Nano)
+		}
+		return
+	}
+	v.entries = slices.Insert(v.entries, i, e)
+	v.logs = slices.Insert(v.logs, i, v.renderLine(e))
+	// 插入到中间时搜索结果的行号已变化
+	if query := v.searchInput.Value(); query != "" && v.searcher.HasMatches() {
+		v.searcher.Search(v.logs, query)
+	}
 }
 
-// loadLogs 加载容器日志
+// loadLogs 加载容器日志，合并模式下读取所有容器；stdout/stderr 和各容器的日志按时间戳排序
 func (v *LogsView) loadLogs() tea.Msg {
-	if v.containerID == "" {
+	if !v.hasTarget() {
 		return logsLoadErrorMsg{err: fmt.Errorf("container ID is empty")}
 	}
 	
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	
+	var entries []docker.TimedLogLine
+	var startedAt time.Time
+	for _, target := range v.targets() {
+		lines, started, err := v.fetchLogs(ctx, target)
+		if err != nil {
+			if v.IsMerged() {
+				err = fmt.Errorf("%s: %w", target.Name, err)
+			}
+			return logsLoadErrorMsg{err: err}
+		}
+		entries = append(entries, lines...)
+		if startedAt.IsZero() || (!started.IsZero() && started.Before(startedAt)) {
+			startedAt = started
+		}
+	}
+	docker.SortLogLines(entries)
+	if !v.jumpAt.IsZero() {
+		entries = trimAround(entries, v.jumpAt, logJumpMaxLines)
+	}
+	
+	// 单个容器时检查是否在运行，查询失败时按运行中处理
+	stopped := false
+	if !v.IsMerged() {
+		if details, err := v.dockerClient.ContainerDetails(ctx, v.containerID); err == nil {
+			stopped = details.State != "running" && details.State != "paused"
+		}
+	}
+	
+	return logsLoadedMsg{entries: entries, stopped: stopped, startedAt: startedAt}
+}
+
+// fetchLogs 读取一个容器最近的日志
+func (v *LogsView) fetchLogs(ctx context.Context, target LogSource) ([]docker.TimedLogLine, time.Time, error) {
 	opts := docker.LogOptions{
 		Follow:     false,
 		Tail:       100,       // 只获取最近 100 行作为初始显示
 		Timestamps: true,
 	}
 	
-	logReader, err := v.dockerClient.ContainerLogs(ctx, v.containerID, opts)
+	var startedAt time.Time
+	if !v.jumpAt.IsZero() {
+		// 跳转到时间：查询该时间前后的窗口
+		opts.Since, opts.Until = docker.LogWindow(v.jumpAt, logJumpSpan)
+		opts.Tail = -1
+	} else if v.sinceStart {
+		// 只显示最近一次启动以来的日志：按 inspect 的 State.StartedAt 过滤
+		details, err := v.dockerClient.ContainerDetails(ctx, target.ID)
+		if err != nil {
+			return nil, startedAt, err
+		}
+		if details.StartedAt.IsZero() {
+			return nil, startedAt, fmt.Errorf("container has never been started")
+		}
+		startedAt = details.StartedAt
+		opts.Since = startedAt.UTC().Format(time.RFC3339Nano)
+		opts.Tail = 1000
+	}
+	
+	logReader, err := v.dockerClient.ContainerLogs(ctx, target.ID, opts)
 	if err != nil {
-		return logsLoadErrorMsg{err: err}
+		return nil, startedAt, err
 	}
 	defer logReader.Close()
 	
 	var stdout, stderr bytes.Buffer
 	_, err = stdcopy.StdCopy(&stdout, &stderr, logReader)
 	if err != nil && err != io.EOF {
-		return logsLoadErrorMsg{err: fmt.Errorf("failed to parse log stream: %w", err)}
-	}
-	
-	var logs []string
-	
-	stdoutScanner := bufio.NewScanner(&stdout)
-	buf := make([]byte, 0, 64*1024)
-	stdoutScanner.Buffer(buf, 1024*1024)
-	
-	for stdoutScanner.Scan() {
-		line := stdoutScanner.Text()
-		line = v.processLogLine(line)
-		logs = append(logs, line)
-	}
-	
-	stderrScanner := bufio.NewScanner(&stderr)
-	stderrScanner.Buffer(buf, 1024*1024)
-	
-	for stderrScanner.Scan() {
-		line := stderrScanner.Text()
-		line = v.processLogLine(line)
-		logs = append(logs, line)
-	}
-	
-	if err := stdoutScanner.Err(); err != nil {
-		return logsLoadErrorMsg{err: fmt.Errorf("failed to read stdout: %w", err)}
+		return nil, startedAt, fmt.Errorf("failed to parse log stream: %w", err)
 	}
 	
-	if err := stderrScanner.Err(); err != nil {
-		return logsLoadErrorMsg{err: fmt.Errorf("failed to read stderr: %w", err)}
+	var lines []docker.TimedLogLine
+	for _, stream := range []struct {
+		name string
+		buf  *bytes.Buffer
+	}{{"stdout", &stdout}, {"stderr", &stderr}} {
+		scanner := bufio.NewScanner(stream.buf)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines = append(lines, parseLogLine(target.Name, scanner.Text()))
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, startedAt, fmt.Errorf("failed to read %s: %w", stream.name, err)
+		}
 	}
-	
-	return logsLoadedMsg{logs: logs}
+	return lines, startedAt, nil
 }
 
 // toggleFollowMode 切换 follow 模式
@@ -808,11 +1259,11 @@ func (v *LogsView) toggleFollowMode() (*LogsView, tea.Cmd) {
 	v.followMode = !v.followMode
 	
 	if v.followMode {
-		if v.containerID != "" {
+		if v.hasTarget() {
 			if v.followCancel != nil {
 				v.followCancel()
 			}
-			v.logChan = make(chan string, 100)
+			v.logChan = make(chan docker.TimedLogLine, 100)
 			v.chanClosed = false
 			v.followActive = true
 			v.viewport.GotoBottom()
@@ -824,6 +1275,7 @@ func (v *LogsView) toggleFollowMode() (*LogsView, tea.Cmd) {
 			v.followCancel = nil
 		}
 		v.followActive = false
+		v.waitingStart = false
 	}
 	
 	return v, nil
@@ -841,8 +1293,8 @@ func (v *LogsView) startStreamingLogs() tea.Cmd {
 func (v *LogsView) listenForLogs() tea.Cmd {
 	return func() tea.Msg {
 		select {
-		case line := <-v.logChan:
-			if line == "" {
+		case line, ok := <-v.logChan:
+			if !ok || line == (docker.TimedLogLine{}) {
 				return followStoppedMsg{err: nil}
 			}
 			return followLogLineMsg{line: line}
@@ -855,16 +1307,18 @@ func (v *LogsView) listenForLogs() tea.Cmd {
 	}
 }
 
-// readLogStream 读取日志流
+// readLogStream 读取日志流，合并模式下每个容器一个流，全部结束后通知停止
 func (v *LogsView) readLogStream() tea.Cmd {
 	return func() tea.Msg {
-		if v.containerID == "" {
+		if !v.hasTarget() {
 			return followStoppedMsg{err: fmt.Errorf("container ID is empty")}
 		}
 		
 		ctx, cancel := context.WithCancel(context.Background())
 		v.followCancel = cancel
 		v.chanClosed = false
+		since := v.lastLogTime
+		targets := v.targets()
 		
 		go func() {
 			defer func() {
@@ -874,75 +1328,18 @@ func (v *LogsView) readLogStream() tea.Cmd {
 				}
 			}()
 			
-			opts := docker.LogOptions{
-				Follow:     true,
-				Tail:       0,
-				Timestamps: true,
-			}
-			
-			if v.lastLogTime != "" {
-				opts.Since = v.lastLogTime
-			}
-			
-			logReader, err := v.dockerClient.ContainerLogs(ctx, v.containerID, opts)
-			if err != nil {
-				return
-			}
-			defer logReader.Close()
-			
-			stdoutReader, stdoutWriter := io.Pipe()
-			stderrReader, stderrWriter := io.Pipe()
-			
-			go func() {
-				defer stdoutWriter.Close()
-				defer stderrWriter.Close()
-				stdcopy.StdCopy(stdoutWriter, stderrWriter, logReader)
-			}()
-			
-			go func() {
-				scanner := bufio.NewScanner(stdoutReader)
-				buf := make([]byte, 0, 64*1024)
-				scanner.Buffer(buf, 1024*1024)
-				
-				for scanner.Scan() {
-					select {
-					case <-ctx.Done():
-						return
-					default:
-						line := scanner.Text()
-						line = v.processLogLine(line)
-						
-						select {
-						case v.logChan <- line:
-						case <-ctx.Done():
-							return
-						}
-					}
-				}
-			}()
-			
-			scanner := bufio.NewScanner(stderrReader)
-			buf := make([]byte, 0, 64*1024)
-			scanner.Buffer(buf, 1024*1024)
-			
-			for scanner.Scan() {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					line := scanner.Text()
-					line = v.processLogLine(line)
-					
-					select {
-					case v.logChan <- line:
-					case <-ctx.Done():
-						return
-					}
-				}
+			var wg sync.WaitGroup
+			for _, target := range targets {
+				wg.Add(1)
+				go func(target LogSource) {
+					defer wg.Done()
+					v.streamContainer(ctx, target, since)
+				}(target)
 			}
+			wg.Wait()
 			
 			select {
-			case v.logChan <- "":
+			case v.logChan <- docker.TimedLogLine{}:
 			case <-ctx.Done():
 			}
 		}()
@@ -951,6 +1348,49 @@ func (v *LogsView) readLogStream() tea.Cmd {
 	}
 }
 
+// streamContainer 跟随一个容器的日志，把 stdout 和 stderr 的每一行发送到日志通道
+func (v *LogsView) streamContainer(ctx context.Context, target LogSource, since string) {
+	opts := docker.LogOptions{
+		Follow:     true,
+		Tail:       0,
+		Timestamps: true,
+		Since:      since,
+	}
+	
+	logReader, err := v.dockerClient.ContainerLogs(ctx, target.ID, opts)
+	if err != nil {
+		return
+	}
+	defer logReader.Close()
+	
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+	
+	go func() {
+		defer stdoutWriter.Close()
+		defer stderrWriter.Close()
+		stdcopy.StdCopy(stdoutWriter, stderrWriter, logReader)
+	}()
+	
+	scan := func(r io.Reader) {
+		scanner := bufio.NewScanner(r)
+		buf := make([]byte, 0, 64*1024)
+		scanner.Buffer(buf, 1024*1024)
+		
+		for scanner.Scan() {
+			line := parseLogLine(target.Name, scanner.Text())
+			select {
+			case v.logChan <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+	
+	go scan(stdoutReader)
+	scan(stderrReader)
+}
+
 // Cleanup 清理资源
 func (v *LogsView) Cleanup() {
 	if v.followCancel != nil {
@@ -959,6 +1399,7 @@ func (v *LogsView) Cleanup() {
 	}
 	v.followActive = false
 	v.followMode = false
-	v.logChan = make(chan string, 100)
+	v.waitingStart = false
+	v.logChan = make(chan docker.TimedLogLine, 100)
 	v.chanClosed = false
 }
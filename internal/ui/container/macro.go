@@ -0,0 +1,122 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// repeatableKeys 可以用 . 重复的操作键（作用于当前行或已勾选的容器）
+var repeatableKeys = map[string]bool{
+	"t":      true, // 启动
+	"o":      true, // 停止
+	"u":      true, // 暂停/恢复
+	"R":      true, // 重启
+	"ctrl+d": true, // 删除（每次都会确认）
+	"*":      true, // 收藏
+}
+
+// macroKeys 宏中除操作键外还会录制的按键：移动光标和勾选，回放时可以逐行处理
+var macroKeys = map[string]bool{
+	"j": true, "down": true,
+	"k": true, "up": true,
+	"g": true, "G": true,
+	" ": true,
+}
+
+// maxMacroKeys 宏最多录制的按键数
+const maxMacroKeys = 64
+
+// actionRecorder 记录容器列表中的操作按键：最近一次操作用于 . 重复，m 开始/结束录制宏，@ 回放
+type actionRecorder struct {
+	last      tea.KeyMsg
+	hasLast   bool
+	recording bool
+	macro     []tea.KeyMsg // 最近录制完成的宏
+	pending   []tea.KeyMsg // 录制中的按键
+	replaying bool         // 回放中的按键不再录制
+}
+
+// observe 记录一次快捷键
+func (r *actionRecorder) observe(msg tea.KeyMsg) {
+	if r.replaying {
+		return
+	}
+	k := msg.String()
+	if repeatableKeys[k] {
+		r.last = msg
+		r.hasLast = true
+	}
+	// 删除会弹出确认对话框，回放时后面的按键会被对话框吞掉，不录入宏
+	if r.recording && (repeatableKeys[k] && k != "ctrl+d" || macroKeys[k]) && len(r.pending) < maxMacroKeys {
+		r.pending = append(r.pending, msg)
+	}
+}
+
+// toggleRecording 开始或结束录制，返回状态提示
+func (r *actionRecorder) toggleRecording() string {
+	if !r.recording {
+		r.recording = true
+		r.pending = nil
+		return "⏺️ Recording macro... press m to stop"
+	}
+	r.recording = false
+	if len(r.pending) == 0 {
+		return "⏹️ Macro is empty, kept the previous one"
+	}
+	r.macro = r.pending
+	r.pending = nil
+	return fmt.Sprintf("⏹️ Recorded macro: %s (press @ to replay)", describeKeys(r.macro))
+}
+
+// describeKeys 按键序列的简短说明，如 "o j t"
+func describeKeys(keys []tea.KeyMsg) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.String()
+		if names[i] == " " {
+			names[i] = "space"
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// replay 依次把按键交给列表处理，合并产生的命令
+func (v *ListView) replay(keys []tea.KeyMsg) tea.Cmd {
+	v.recorder.replaying = true
+	defer func() { v.recorder.replaying = false }()
+
+	var cmds []tea.Cmd
+	for _, k := range keys {
+		var cmd tea.Cmd
+		_, cmd = v.Update(k)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// repeatLastAction 对当前行（或已勾选的容器）重复最近一次操作
+func (v *ListView) repeatLastAction() tea.Cmd {
+	if !v.recorder.hasLast {
+		return func() tea.Msg {
+			return ContainerOperationWarningMsg{Message: "No action to repeat yet"}
+		}
+	}
+	return v.replay([]tea.KeyMsg{v.recorder.last})
+}
+
+// replayMacro 回放最近录制的宏
+func (v *ListView) replayMacro() tea.Cmd {
+	if v.recorder.recording {
+		return func() tea.Msg {
+			return ContainerOperationWarningMsg{Message: "Stop recording (m) before replaying the macro"}
+		}
+	}
+	if len(v.recorder.macro) == 0 {
+		return func() tea.Msg {
+			return ContainerOperationWarningMsg{Message: "No macro recorded; press m to start recording"}
+		}
+	}
+	return v.replay(v.recorder.macro)
+}
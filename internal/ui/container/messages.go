@@ -1,6 +1,9 @@
 package container
 
-import "docktui/internal/docker"
+import (
+	"docktui/internal/docker"
+	"docktui/internal/ui/components"
+)
 
 // ========== 列表视图消息 ==========
 
@@ -60,6 +63,13 @@ type ContainerInspectMsg struct {
 	JSONContent   string
 }
 
+// ContainerRunCommandMsg 还原的 docker run 命令
+type ContainerRunCommandMsg struct {
+	ContainerName string
+	Command       string
+	Copy          components.CopyResult // 复制到剪贴板（或回退文件）的结果
+}
+
 // ContainerInspectErrorMsg 容器检查错误消息
 type ContainerInspectErrorMsg struct {
 	Err error
@@ -76,6 +86,22 @@ type ContainerUpdateSuccessMsg struct {
 	Container string
 }
 
+// ContainerPrunePreviewMsg 容器清理预览结果消息
+type ContainerPrunePreviewMsg struct {
+	Candidates []docker.ContainerPruneCandidate
+}
+
+// ContainerPrunePreviewErrorMsg 容器清理预览错误消息
+type ContainerPrunePreviewErrorMsg struct {
+	Err error
+}
+
+// ContainerPruneSuccessMsg 容器清理完成消息
+type ContainerPruneSuccessMsg struct {
+	Count          int
+	SpaceReclaimed int64
+}
+
 // ========== 详情视图消息 ==========
 
 // DetailsLoadedMsg 详情加载完成消息
@@ -104,3 +130,26 @@ type ViewLogsMsg struct {
 	ContainerID   string
 	ContainerName string
 }
+
+// ViewEventsMsg 请求切换到事件视图并聚焦该容器
+type ViewEventsMsg struct {
+	ContainerID   string
+	ContainerName string
+}
+
+// WaitForStartMsg 日志视图查看的容器未运行，请求在容器启动时通知（调用 LogsView.Reattach）
+type WaitForStartMsg struct {
+	ContainerID string
+}
+
+// ExecShellMsg 请求进入容器 Shell（显示 Shell 选择器）
+type ExecShellMsg struct {
+	ContainerID   string
+	ContainerName string
+}
+
+// DebugShellMsg 调试运行的临时容器已启动，请求进入其 Shell，退出后删除该容器
+type DebugShellMsg struct {
+	ContainerID   string
+	ContainerName string
+}
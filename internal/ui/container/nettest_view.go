@@ -0,0 +1,271 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
+	"docktui/internal/format"
+)
+
+// netTestTimeout 一次网络测试的超时（工具自身的超时为 5-10 秒）
+const netTestTimeout = 30 * time.Second
+
+// netTestMaxResults 对话框中保留的测试结果数
+const netTestMaxResults = 4
+
+// netTestKinds 可选的测试类型及输入提示，按 Tab 切换
+var netTestKinds = []struct {
+	kind        docker.NetTestKind
+	placeholder string
+}{
+	{docker.NetTestDNS, "db"},
+	{docker.NetTestTCP, "db:5432"},
+	{docker.NetTestHTTP, "http://api:8080/health"},
+}
+
+// netTestDoneMsg 网络测试结束
+type netTestDoneMsg struct {
+	containerID string
+	kind        docker.NetTestKind
+	target      string
+	result      *docker.NetTestResult
+	err         error
+}
+
+// NetTestView 在容器内做网络连通性测试的对话框：DNS 解析、TCP 连接、HTTP GET，使用镜像里已有的工具并整理输出
+type NetTestView struct {
+	dockerClient docker.Client
+	container    *docker.Container
+
+	input   textinput.Model
+	kindIdx int
+	targets [3]string // 每种测试上次输入的目标
+
+	running bool
+	results []netTestDoneMsg       // 最新在前
+	tools   *docker.ContainerTools // 探测到的工具，为 nil 表示还在探测
+
+	visible bool
+	width   int
+	height  int
+}
+
+// NewNetTestView 创建网络测试对话框
+func NewNetTestView(dockerClient docker.Client) *NetTestView {
+	input := textinput.New()
+	input.CharLimit = 512
+	input.Prompt = "› "
+	input.Placeholder = netTestKinds[0].placeholder
+	return &NetTestView{dockerClient: dockerClient, input: input}
+}
+
+// Show 显示对话框，切换容器时清空结果并探测容器中的工具
+func (v *NetTestView) Show(container *docker.Container) tea.Cmd {
+	var cmd tea.Cmd
+	if v.container == nil || v.container.ID != container.ID {
+		v.results = nil
+		v.tools = nil
+		cmd = probeToolsCmd(v.dockerClient, container.ID)
+	}
+	v.visible = true
+	v.container = container
+	v.input.Focus()
+	return cmd
+}
+
+// Hide 隐藏对话框（保留输入和结果）
+func (v *NetTestView) Hide() {
+	v.visible = false
+	v.input.Blur()
+}
+
+// IsVisible 是否可见
+func (v *NetTestView) IsVisible() bool {
+	return v.visible
+}
+
+// SetSize 设置尺寸
+func (v *NetTestView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.input.Width = max(30, min(80, width-30))
+}
+
+// Update 处理输入和测试结果
+// 返回值: (handled bool, cmd tea.Cmd)
+func (v *NetTestView) Update(msg tea.Msg) (bool, tea.Cmd) {
+	if done, ok := msg.(netTestDoneMsg); ok {
+		v.running = false
+		if v.container != nil && v.container.ID == done.containerID {
+			v.results = append([]netTestDoneMsg{done}, v.results...)
+			if len(v.results) > netTestMaxResults {
+				v.results = v.results[:netTestMaxResults]
+			}
+		}
+		return true, nil
+	}
+	if probed, ok := msg.(toolsProbedMsg); ok {
+		if v.container != nil && v.container.ID == probed.containerID && probed.err == nil {
+			v.tools = probed.tools
+		}
+		return true, nil
+	}
+	if !v.visible {
+		return false, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		v.Hide()
+		return true, nil
+	case "tab", "shift+tab":
+		step := 1
+		if keyMsg.String() == "shift+tab" {
+			step = len(netTestKinds) - 1
+		}
+		v.targets[v.kindIdx] = v.input.Value()
+		v.kindIdx = (v.kindIdx + step) % len(netTestKinds)
+		v.input.SetValue(v.targets[v.kindIdx])
+		v.input.Placeholder = netTestKinds[v.kindIdx].placeholder
+		v.input.CursorEnd()
+		return true, nil
+	case "enter":
+		return true, v.run()
+	case "ctrl+l":
+		v.results = nil
+		return true, nil
+	}
+
+	var cmd tea.Cmd
+	v.input, cmd = v.input.Update(msg)
+	return true, cmd
+}
+
+// run 在容器中执行当前类型的测试
+func (v *NetTestView) run() tea.Cmd {
+	if v.running || v.container == nil {
+		return nil
+	}
+	kind := netTestKinds[v.kindIdx].kind
+	target := strings.TrimSpace(v.input.Value())
+	containerID, containerName := v.container.ID, v.container.Name
+	if err := v.unavailable(kind); err != nil {
+		return func() tea.Msg {
+			return netTestDoneMsg{containerID: containerID, kind: kind, target: target, err: err}
+		}
+	}
+	if _, err := docker.ParseNetTestTarget(kind, target); err != nil {
+		return func() tea.Msg {
+			return netTestDoneMsg{containerID: containerID, kind: kind, target: target, err: err}
+		}
+	}
+	v.running = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), netTestTimeout)
+		defer cancel()
+		result, err := docker.RunNetTest(ctx, v.dockerClient, containerID, containerName, kind, target)
+		return netTestDoneMsg{containerID: containerID, kind: kind, target: target, result: result, err: err}
+	}
+}
+
+// unavailable 已探测到容器中缺少该测试需要的工具时返回原因
+func (v *NetTestView) unavailable(kind docker.NetTestKind) error {
+	switch {
+	case v.tools == nil || v.tools.CanRunNetTest(kind):
+		return nil
+	case !v.tools.Has("sh"):
+		return fmt.Errorf("%s", docker.NoShellHint)
+	}
+	return fmt.Errorf("no %s tool in the image (looked for %s)", kind, strings.Join(docker.NetTestTools(kind), ", "))
+}
+
+// View 渲染对话框
+func (v *NetTestView) View() string {
+	if !v.visible || v.container == nil {
+		return ""
+	}
+	boxWidth := max(60, min(110, v.width-8))
+	innerWidth := boxWidth - 6
+
+	var tabs []string
+	missingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Strikethrough(true)
+	for i, k := range netTestKinds {
+		label := " " + k.kind.String() + " "
+		if i == v.kindIdx {
+			label = "[" + k.kind.String() + "]"
+		}
+		switch {
+		case v.unavailable(k.kind) != nil:
+			tabs = append(tabs, missingStyle.Render(label))
+		case i == v.kindIdx:
+			tabs = append(tabs, editSelectedStyle.Render(label))
+		default:
+			tabs = append(tabs, editHintStyle.Render(label))
+		}
+	}
+
+	parts := []string{
+		editTitleStyle.Render("🩺 Network test from " + v.container.Name),
+		"",
+		strings.Join(tabs, " "),
+		v.input.View(),
+		"",
+	}
+
+	switch {
+	case v.running:
+		parts = append(parts, editHintStyle.Render("⏳ Running "+netTestKinds[v.kindIdx].kind.String()+" test..."))
+	case v.unavailable(netTestKinds[v.kindIdx].kind) != nil:
+		parts = append(parts, editErrorStyle.Render("⚠ "+v.unavailable(netTestKinds[v.kindIdx].kind).Error()))
+	case len(v.results) == 0:
+		parts = append(parts, editHintStyle.Render("Uses whatever the image has: getent/nslookup/dig, nc/bash, curl/wget"))
+	}
+	for i, r := range v.results {
+		if i > 0 {
+			parts = append(parts, "")
+		}
+		parts = append(parts, renderNetTestResult(r, innerWidth)...)
+	}
+
+	parts = append(parts, "",
+		editHintStyle.Render("[Tab=DNS/TCP/HTTP] [Enter=Run] [Ctrl+L=Clear] [Esc=Close]"))
+	return editBoxStyle.Width(boxWidth).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+// renderNetTestResult 渲染一次测试：结论一行，随后是解析出的要点和处理建议
+func renderNetTestResult(r netTestDoneMsg, width int) []string {
+	header := editHintStyle.Render(r.kind.String() + " " + format.Truncate(r.target, width/2))
+	if r.err != nil {
+		return []string{header, editErrorStyle.Render("  ❌ " + format.Truncate(r.err.Error(), width-4))}
+	}
+
+	res := r.result
+	summary := "✅ " + res.Summary
+	style := editValueStyle
+	if !res.OK {
+		summary = "❌ " + res.Summary
+		style = editErrorStyle
+	}
+	if res.Tool != "" {
+		summary += fmt.Sprintf(" in %s via %s", res.Elapsed.Round(time.Millisecond), res.Tool)
+	}
+	lines := []string{header, "  " + style.Render(format.Truncate(summary, width-2))}
+	for _, d := range res.Details {
+		lines = append(lines, "    "+format.Truncate(d, width-4))
+	}
+	if res.Hint != "" {
+		lines = append(lines, "  "+editHintStyle.Width(width-2).Render("💡 "+res.Hint))
+	}
+	return lines
+}
@@ -0,0 +1,69 @@
+package container
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// portMatchStyle PORTS 列中与端口搜索匹配的映射
+var portMatchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("220")).Bold(true)
+
+// parsePortQuery 解析端口搜索（如 :8080），返回宿主机端口
+func parsePortQuery(query string) (int, bool) {
+	if !strings.HasPrefix(query, ":") {
+		return 0, false
+	}
+	port, err := strconv.Atoi(query[1:])
+	if err != nil || port < 1 || port > 65535 {
+		return 0, false
+	}
+	return port, true
+}
+
+// publishedPort 返回端口映射（如 0.0.0.0:8080->80/tcp、[::]:8080->80/tcp）的宿主机端口，未发布时返回 0
+func publishedPort(mapping string) int {
+	host, _, ok := strings.Cut(mapping, "->")
+	if !ok {
+		return 0
+	}
+	i := strings.LastIndex(host, ":")
+	if i < 0 {
+		return 0
+	}
+	port, _ := strconv.Atoi(host[i+1:])
+	return port
+}
+
+// publishesPort 容器的端口映射中是否发布了指定的宿主机端口
+func publishesPort(ports string, port int) bool {
+	for _, mapping := range strings.Split(ports, ", ") {
+		if publishedPort(mapping) == port {
+			return true
+		}
+	}
+	return false
+}
+
+// portsCell 渲染 PORTS 列，端口搜索时高亮匹配的映射，其余部分用 render 渲染
+func (v *ListView) portsCell(ports string, render func(...string) string) string {
+	port, ok := parsePortQuery(v.searchQuery)
+	if !ok || !publishesPort(ports, port) {
+		return render(ports)
+	}
+	mappings := strings.Split(ports, ", ")
+	for i, mapping := range mappings {
+		if publishedPort(mapping) == port {
+			mappings[i] = portMatchStyle.Render(mapping)
+		} else {
+			mappings[i] = render(mapping)
+		}
+	}
+	return strings.Join(mappings, render(", "))
+}
+
+// plainText 不加样式
+func plainText(strs ...string) string {
+	return strings.Join(strs, " ")
+}
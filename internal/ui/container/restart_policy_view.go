@@ -0,0 +1,267 @@
+package container
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
+)
+
+// restartPolicyMaxNames 对话框中最多列出的容器名称数量
+const restartPolicyMaxNames = 5
+
+// RestartPolicyView 批量设置重启策略对话框
+// 将所选容器统一设置为同一重启策略，复用 UpdateContainer 接口
+type RestartPolicyView struct {
+	containers []docker.Container
+
+	restartPolicies  []string
+	restartPolicyIdx int
+	maxRetriesInput  textinput.Model
+
+	// UI 状态
+	visible    bool
+	width      int
+	focusIndex int // 0=重启策略, 1=最大重试, 2=取消, 3=确认
+	errorMsg   string
+}
+
+// NewRestartPolicyView 创建批量重启策略对话框
+func NewRestartPolicyView() *RestartPolicyView {
+	retriesInput := textinput.New()
+	retriesInput.Placeholder = "3"
+	retriesInput.CharLimit = 5
+	retriesInput.Width = 8
+	retriesInput.Prompt = ""
+
+	return &RestartPolicyView{
+		restartPolicies:  []string{"no", "on-failure", "unless-stopped", "always"},
+		restartPolicyIdx: 2,
+		maxRetriesInput:  retriesInput,
+	}
+}
+
+// Show 显示对话框（保留上次选择的策略）
+func (v *RestartPolicyView) Show(containers []docker.Container) {
+	v.visible = true
+	v.containers = containers
+	v.errorMsg = ""
+	v.focusIndex = 0
+	v.updateInputFocus()
+}
+
+// Hide 隐藏对话框
+func (v *RestartPolicyView) Hide() {
+	v.visible = false
+	v.containers = nil
+	v.maxRetriesInput.Blur()
+}
+
+// IsVisible 是否可见
+func (v *RestartPolicyView) IsVisible() bool {
+	return v.visible
+}
+
+// SetWidth 设置宽度
+func (v *RestartPolicyView) SetWidth(width int) {
+	v.width = width
+}
+
+// GetContainers 获取待更新的容器
+func (v *RestartPolicyView) GetContainers() []docker.Container {
+	return v.containers
+}
+
+// GetConfig 获取重启策略配置
+func (v *RestartPolicyView) GetConfig() (docker.ContainerUpdateConfig, error) {
+	config := docker.ContainerUpdateConfig{
+		RestartPolicy: v.restartPolicies[v.restartPolicyIdx],
+	}
+	if config.RestartPolicy != "on-failure" {
+		return config, nil
+	}
+	if retriesStr := strings.TrimSpace(v.maxRetriesInput.Value()); retriesStr != "" {
+		retries, err := strconv.Atoi(retriesStr)
+		if err != nil || retries < 0 {
+			return config, fmt.Errorf("invalid max retries %q", retriesStr)
+		}
+		config.RestartMaxRetries = retries
+	}
+	return config, nil
+}
+
+// Update 处理输入
+// 返回值: (confirmed bool, handled bool, cmd tea.Cmd)
+func (v *RestartPolicyView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
+	if !v.visible {
+		return false, false, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, false, nil
+	}
+
+	keyStr := keyMsg.String()
+	switch {
+	case keyMsg.Type == tea.KeyEsc:
+		v.Hide()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyEnter:
+		if v.focusIndex == 3 {
+			if _, err := v.GetConfig(); err != nil {
+				v.errorMsg = err.Error()
+				return false, true, nil
+			}
+			return true, true, nil
+		}
+		if v.focusIndex == 2 {
+			v.Hide()
+			return false, true, nil
+		}
+		v.nextFocus()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyTab || keyMsg.Type == tea.KeyDown:
+		v.nextFocus()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyShiftTab || keyMsg.Type == tea.KeyUp:
+		v.prevFocus()
+		return false, true, nil
+	}
+
+	if v.focusIndex == 0 {
+		if keyMsg.Type == tea.KeyLeft || keyStr == "h" {
+			if v.restartPolicyIdx > 0 {
+				v.restartPolicyIdx--
+			}
+		} else if keyMsg.Type == tea.KeyRight || keyStr == "l" {
+			if v.restartPolicyIdx < len(v.restartPolicies)-1 {
+				v.restartPolicyIdx++
+			}
+		}
+		return false, true, nil
+	}
+
+	if v.focusIndex >= 2 {
+		if keyMsg.Type == tea.KeyLeft || keyStr == "h" {
+			v.focusIndex = 2
+		} else if keyMsg.Type == tea.KeyRight || keyStr == "l" {
+			v.focusIndex = 3
+		}
+		return false, true, nil
+	}
+
+	var cmd tea.Cmd
+	v.maxRetriesInput, cmd = v.maxRetriesInput.Update(msg)
+	return false, true, cmd
+}
+
+// nextFocus 切换到下一个焦点（非 on-failure 时跳过最大重试次数）
+func (v *RestartPolicyView) nextFocus() {
+	if v.focusIndex == 0 && v.restartPolicies[v.restartPolicyIdx] != "on-failure" {
+		v.focusIndex = 2
+	} else {
+		v.focusIndex = (v.focusIndex + 1) % 4
+	}
+	v.updateInputFocus()
+}
+
+// prevFocus 切换到上一个焦点
+func (v *RestartPolicyView) prevFocus() {
+	if v.focusIndex == 2 && v.restartPolicies[v.restartPolicyIdx] != "on-failure" {
+		v.focusIndex = 0
+	} else {
+		v.focusIndex = (v.focusIndex + 3) % 4
+	}
+	v.updateInputFocus()
+}
+
+// updateInputFocus 更新输入框焦点状态
+func (v *RestartPolicyView) updateInputFocus() {
+	if v.focusIndex == 1 {
+		v.maxRetriesInput.Focus()
+	} else {
+		v.maxRetriesInput.Blur()
+	}
+}
+
+// View 渲染对话框
+func (v *RestartPolicyView) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	title := editTitleStyle.Render(fmt.Sprintf("🔁 Set Restart Policy: %d containers", len(v.containers)))
+
+	names := make([]string, 0, restartPolicyMaxNames)
+	for i, c := range v.containers {
+		if i >= restartPolicyMaxNames {
+			names = append(names, fmt.Sprintf("+%d more", len(v.containers)-restartPolicyMaxNames))
+			break
+		}
+		names = append(names, c.Name)
+	}
+	targets := editHintStyle.Render("Targets: ") + editValueStyle.Render(strings.Join(names, ", "))
+
+	var policyOptions []string
+	for i, p := range v.restartPolicies {
+		if i == v.restartPolicyIdx {
+			if v.focusIndex == 0 {
+				policyOptions = append(policyOptions, editSelectedStyle.Render("["+p+"]"))
+			} else {
+				policyOptions = append(policyOptions, editValueStyle.Render("["+p+"]"))
+			}
+		} else {
+			policyOptions = append(policyOptions, editHintStyle.Render(" "+p+" "))
+		}
+	}
+	restartLine := editLabelStyle.Render("Restart Policy:") + " " + strings.Join(policyOptions, " ")
+
+	contentParts := []string{title, "", targets, "", restartLine}
+
+	if v.restartPolicies[v.restartPolicyIdx] == "on-failure" {
+		retriesInputStyle := lipgloss.NewStyle()
+		if v.focusIndex == 1 {
+			retriesInputStyle = retriesInputStyle.Foreground(lipgloss.Color("81"))
+		}
+		contentParts = append(contentParts, editLabelStyle.Render("Max Retries:")+" "+
+			retriesInputStyle.Render(v.maxRetriesInput.View())+
+			editHintStyle.Render(" (times, 0=unlimited)"))
+	}
+
+	if v.errorMsg != "" {
+		contentParts = append(contentParts, "", editErrorStyle.Render("❌ "+v.errorMsg))
+	}
+
+	cancelBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	okBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	if v.focusIndex == 2 {
+		cancelBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	if v.focusIndex == 3 {
+		okBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	buttons := cancelBtnStyle.Render("< Cancel >") + "    " + okBtnStyle.Render("< Apply >")
+
+	hints := editHintStyle.Render("[Tab/↑↓=Switch] [←→=Select policy] [Enter=Confirm] [Esc=Cancel]")
+	contentParts = append(contentParts, "", buttons, "", hints)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, contentParts...)
+
+	boxWidth := v.width - 10
+	if boxWidth < 65 {
+		boxWidth = 65
+	}
+	if boxWidth > 75 {
+		boxWidth = 75
+	}
+	return editBoxStyle.Width(boxWidth).Render(content)
+}
@@ -0,0 +1,124 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+	"docktui/internal/notes"
+	"docktui/internal/ui/styles"
+)
+
+// defaultStopTimeout 未在备注中设置停止超时的容器，停止/重启时等待退出的秒数
+const defaultStopTimeout = 10
+
+// stopWaitSlack 停止超时之外再等待的时间（强制结束和状态同步）
+const stopWaitSlack = 30 * time.Second
+
+// transition 停止/重启中的容器，在 STATUS 列显示过渡状态和已用时间
+type transition struct {
+	mu      sync.Mutex
+	label   string // 可由后台等待更新（如重启时由 Restarting 变为 Starting）
+	since   time.Time
+	timeout int // 停止超时（秒）
+}
+
+// setLabel 更新过渡状态
+func (t *transition) setLabel(label string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.label = label
+}
+
+// String 返回 STATUS 列显示的文本，如 "⏳ Stopping… 8s"
+func (t *transition) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return fmt.Sprintf("⏳ %s… %ds", t.label, int(time.Since(t.since).Seconds()))
+}
+
+// containerTransitionTickMsg 刷新过渡状态的已用时间
+type containerTransitionTickMsg struct{}
+
+// containerTransitionDoneMsg 停止/重启操作结束，result 为操作结果消息
+type containerTransitionDoneMsg struct {
+	ids    []string
+	result tea.Msg
+}
+
+// statusText 返回 STATUS 列的内容（状态图标加 docker 的状态文本），停止/重启中的容器显示过渡状态
+func (v *ListView) statusText(c docker.Container) string {
+	if t, ok := v.transitions[c.ID]; ok {
+		return t.String()
+	}
+	return styles.StatusLabel(styles.ContainerStatus(c.State, c.Status), c.Status)
+}
+
+// transitionOperation 执行停止/重启并在完成前显示过渡状态；op 在容器真正达到目标状态后才返回，
+// 成功提示因此在状态实际变化时出现，而不是在 API 调用返回时
+func (v *ListView) transitionOperation(opName, label string, containers []docker.Container, op func(ctx context.Context, id string, t *transition) error) tea.Cmd {
+	if v.transitions == nil {
+		v.transitions = make(map[string]*transition)
+	}
+	ids := make([]string, len(containers))
+	states := make(map[string]*transition, len(containers))
+	var budget time.Duration
+	for i, c := range containers {
+		ids[i] = c.ID
+		t := &transition{label: label, since: time.Now(), timeout: stopTimeout(c)}
+		v.transitions[c.ID] = t
+		states[c.ID] = t
+		budget += time.Duration(t.timeout)*time.Second + stopWaitSlack
+	}
+	v.updateColumnWidths()
+
+	// 按顺序执行，总超时按各容器的停止超时累加
+	run := v.batchContainerOperationTimeout(opName, budget, containers, func(ctx context.Context, id string) error {
+		return op(ctx, id, states[id])
+	})
+	cmds := []tea.Cmd{func() tea.Msg {
+		return containerTransitionDoneMsg{ids: ids, result: run()}
+	}}
+	if !v.transitionTicking {
+		v.transitionTicking = true
+		cmds = append(cmds, v.tickTransitions())
+	}
+	return tea.Batch(cmds...)
+}
+
+// tickTransitions 每秒刷新一次过渡状态
+func (v *ListView) tickTransitions() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return containerTransitionTickMsg{}
+	})
+}
+
+// stopTimeout 返回容器的停止超时：备注中设置的值，否则为默认值
+func stopTimeout(c docker.Container) int {
+	return notes.Lookup(notes.KindContainer, c.Name).StopTimeoutOr(defaultStopTimeout)
+}
+
+// stopAndWait 停止容器并等待其真正退出
+func (v *ListView) stopAndWait(ctx context.Context, id string, t *transition) error {
+	if err := v.dockerClient.StopContainer(ctx, id, t.timeout); err != nil {
+		return err
+	}
+	_, err := v.dockerClient.WaitContainer(ctx, id, docker.WaitNotRunning)
+	return err
+}
+
+// restartAndWait 重启容器；容器退出后过渡状态从 Restarting 变为 Starting
+func (v *ListView) restartAndWait(ctx context.Context, id string, t *transition) error {
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		if _, err := v.dockerClient.WaitContainer(waitCtx, id, docker.WaitNextExit); err == nil {
+			t.setLabel("Starting")
+		}
+	}()
+	return v.dockerClient.RestartContainer(ctx, id, t.timeout)
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/plugin"
+	"docktui/internal/policy"
+)
+
+// customActionDoneMsg 自定义操作命令结束
+type customActionDoneMsg struct {
+	name string
+	err  error
+}
+
+// SetCustomActions 设置配置文件中定义的自定义操作
+func SetCustomActions(m Model, actions []plugin.Action) Model {
+	m.customActions = actions
+	return m
+}
+
+// customActionContext 返回当前视图的资源类型和选中资源的模板上下文
+func (m Model) customActionContext() (string, plugin.Context, bool) {
+	var ctx plugin.Context
+	switch m.currentView {
+	case ViewContainerList:
+		c := m.containerListView.GetSelectedContainer()
+		if c == nil {
+			return plugin.ResourceContainer, ctx, false
+		}
+		ctx.ContainerID, ctx.ContainerName, ctx.Image = c.ID, c.Name, c.Image
+	case ViewContainerDetail:
+		ctx.ContainerID, ctx.ContainerName = m.containerDetailView.ContainerID(), m.containerDetailView.ContainerName()
+		if details := m.containerDetailView.GetDetails(); details != nil {
+			ctx.Image = details.Image
+		}
+	case ViewLogs:
+		ctx.ContainerID, ctx.ContainerName = m.logsView.ContainerID(), m.logsView.ContainerName()
+	case ViewImageList:
+		img := m.imageListView.GetSelectedImage()
+		if img == nil {
+			return plugin.ResourceImage, ctx, false
+		}
+		ctx.ImageID, ctx.Image = img.ID, img.Repository+":"+img.Tag
+		if img.Dangling {
+			ctx.Image = img.ID
+		}
+		return plugin.ResourceImage, ctx, true
+	case ViewNetworkList:
+		n := m.networkListView.GetSelectedNetwork()
+		if n == nil {
+			return plugin.ResourceNetwork, ctx, false
+		}
+		ctx.NetworkID, ctx.NetworkName = n.ID, n.Name
+		return plugin.ResourceNetwork, ctx, true
+	case ViewComposeList:
+		p := m.composeListView.GetSelectedProject()
+		if p == nil {
+			return plugin.ResourceCompose, ctx, false
+		}
+		ctx.ProjectName, ctx.ProjectDir = p.Name, p.Path
+		return plugin.ResourceCompose, ctx, true
+	case ViewComposeDetail:
+		p := m.composeDetailView.GetProject()
+		if p == nil {
+			return plugin.ResourceCompose, ctx, false
+		}
+		ctx.ProjectName, ctx.ProjectDir = p.Name, p.Path
+		if svc := m.composeDetailView.GetSelectedService(); svc != nil {
+			ctx.ServiceName = svc.Name
+		}
+		return plugin.ResourceCompose, ctx, true
+	default:
+		return "", ctx, false
+	}
+	return plugin.ResourceContainer, ctx, ctx.ContainerID != ""
+}
+
+// customActionFor 返回按键对应的自定义操作，没有、当前有输入框或按键是退出/帮助键时返回 nil
+func (m Model) customActionFor(msg tea.KeyMsg) *plugin.Action {
+	if len(m.customActions) == 0 || m.viewCapturesInput() {
+		return nil
+	}
+	// 配置为退出/帮助的按键始终执行内置功能
+	if key.Matches(msg, m.keys.Quit, m.keys.Help) {
+		return nil
+	}
+	switch m.currentView {
+	case ViewContainerList, ViewContainerDetail, ViewLogs, ViewImageList, ViewNetworkList, ViewComposeList, ViewComposeDetail:
+	default:
+		return nil
+	}
+	resource, _, _ := m.customActionContext()
+	return plugin.Find(m.customActions, resource, msg.String())
+}
+
+// runCustomAction 释放终端执行自定义操作的命令，结束后返回界面
+func (m Model) runCustomAction(action *plugin.Action) (tea.Model, tea.Cmd) {
+	// 只读模式下只允许声明为只读的命令
+	if policy.ReadOnly() && !action.ReadOnly {
+		return m, m.SetTemporaryMessage(MsgWarning, fmt.Sprintf("🔒 %s is disabled in read-only mode (set read_only: true if it only inspects)", action.Name), 4)
+	}
+	_, ctx, ok := m.customActionContext()
+	if !ok {
+		return m, m.SetTemporaryMessage(MsgWarning, "⚠️ Please select a "+action.Resource+" first", 3)
+	}
+	cmd, err := action.Cmd(ctx)
+	if err != nil {
+		return m, m.SetTemporaryMessage(MsgWarning, "⚠️ "+err.Error(), 5)
+	}
+	name := action.Name
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return customActionDoneMsg{name: name, err: err}
+	})
+}
+
+// handleCustomActionDone 显示命令结果并刷新界面
+func (m Model) handleCustomActionDone(msg customActionDoneMsg) (tea.Model, tea.Cmd) {
+	var notice tea.Cmd
+	if msg.err != nil {
+		notice = m.SetTemporaryMessage(MsgWarning, fmt.Sprintf("⚠️ %s: %v", msg.name, msg.err), 5)
+	} else {
+		notice = m.SetTemporaryMessage(MsgSuccess, "✅ "+msg.name+" finished", 3)
+	}
+	return m, tea.Batch(notice, func() tea.Msg {
+		return tea.WindowSizeMsg{Width: m.width, Height: m.height}
+	})
+}
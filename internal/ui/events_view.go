@@ -0,0 +1,403 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+	"docktui/internal/format"
+)
+
+// eventsMaxCaptured 事件视图最多保留的事件数，超出后丢弃最早的
+const eventsMaxCaptured = 5000
+
+// eventsStreamMsg 事件视图收到一条 Docker 事件
+type eventsStreamMsg struct {
+	gen   int
+	event docker.Event
+}
+
+// eventsStreamErrMsg 事件流断开
+type eventsStreamErrMsg struct {
+	gen int
+	err error
+}
+
+// EventsView 事件视图：实时显示容器、镜像、网络和卷的 Docker 事件，
+// 可聚焦到单个容器/镜像、按关键字过滤、暂停，并把捕获的事件导出为 JSON
+type EventsView struct {
+	dockerClient docker.Client
+	width        int
+	height       int
+
+	gen     int // 每次进入视图递增，丢弃上一次订阅的事件
+	cancel  context.CancelFunc
+	eventCh <-chan docker.Event
+	errCh   <-chan error
+	err     error
+
+	focus    docker.EventFocus
+	events   []docker.Event // 本次进入视图后捕获的全部事件
+	dropped  int            // 超出上限被丢弃的事件数
+	paused   bool
+	pausedAt int // 暂停时已捕获的事件数，暂停期间只显示这些
+
+	filter    string
+	filtering bool
+
+	follow bool // 跟随最新事件
+	scroll int  // 不跟随时，第一行显示的事件序号
+
+	saving   bool
+	savePath string
+	notice   string
+}
+
+// NewEventsView 创建事件视图
+func NewEventsView(dockerClient docker.Client) *EventsView {
+	return &EventsView{dockerClient: dockerClient}
+}
+
+// SetFocus 设置聚焦的资源，零值表示显示全部事件
+func (v *EventsView) SetFocus(focus docker.EventFocus) {
+	v.focus = focus
+}
+
+// Init 清空已捕获的事件并开始订阅
+func (v *EventsView) Init() tea.Cmd {
+	v.Stop()
+	v.gen++
+	v.events, v.dropped = nil, 0
+	v.paused, v.pausedAt = false, 0
+	v.filter, v.filtering = "", false
+	v.saving, v.notice, v.err = false, "", nil
+	v.follow, v.scroll = true, 0
+	if v.dockerClient == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	v.eventCh, v.errCh = v.dockerClient.StreamEvents(ctx)
+	return waitEvent(v.gen, v.eventCh, v.errCh)
+}
+
+// Stop 离开视图时取消订阅
+func (v *EventsView) Stop() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+}
+
+// waitEvent 等待下一条事件，通道关闭时返回事件流的错误
+func waitEvent(gen int, eventCh <-chan docker.Event, errCh <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-eventCh
+		if !ok {
+			return eventsStreamErrMsg{gen: gen, err: <-errCh}
+		}
+		return eventsStreamMsg{gen: gen, event: event}
+	}
+}
+
+// IsCapturingInput 是否正在输入过滤关键字或导出路径
+func (v *EventsView) IsCapturingInput() bool {
+	return v.filtering || v.saving
+}
+
+// Update 处理消息
+func (v *EventsView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case eventsStreamMsg:
+		if msg.gen != v.gen {
+			return v, nil
+		}
+		v.events = append(v.events, msg.event)
+		if over := len(v.events) - eventsMaxCaptured; over > 0 {
+			v.events = v.events[over:]
+			v.dropped += over
+			v.pausedAt = max(0, v.pausedAt-over)
+			v.scroll = max(0, v.scroll-over)
+		}
+		return v, waitEvent(v.gen, v.eventCh, v.errCh)
+
+	case eventsStreamErrMsg:
+		if msg.gen == v.gen && msg.err != nil {
+			v.err = msg.err
+		}
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.saving {
+			v.updateSave(msg)
+			return v, nil
+		}
+		if v.filtering {
+			v.updateFilter(msg)
+			return v, nil
+		}
+		return v, v.handleKey(msg)
+	}
+	return v, nil
+}
+
+func (v *EventsView) handleKey(msg tea.KeyMsg) tea.Cmd {
+	visible := v.visibleEvents()
+	switch msg.String() {
+	case "esc":
+		if v.filter != "" {
+			v.filter = ""
+			return nil
+		}
+		v.Stop()
+		return func() tea.Msg { return GoBackMsg{} }
+	case "/":
+		v.filtering = true
+		v.notice = ""
+	case "p", " ":
+		v.paused = !v.paused
+		if v.paused {
+			v.pausedAt = len(v.events)
+		}
+	case "F":
+		if !v.focus.IsZero() {
+			v.focus = docker.EventFocus{}
+			v.notice = "Showing events of all resources"
+		}
+	case "c":
+		v.events, v.dropped, v.pausedAt = nil, 0, 0
+		v.follow, v.scroll = true, 0
+	case "j", "down":
+		v.scrollBy(1, len(visible))
+	case "k", "up":
+		v.scrollBy(-1, len(visible))
+	case "pgdown", "ctrl+f":
+		v.scrollBy(v.pageSize(), len(visible))
+	case "pgup", "ctrl+b":
+		v.scrollBy(-v.pageSize(), len(visible))
+	case "g":
+		v.follow, v.scroll = false, 0
+	case "G":
+		v.follow = true
+	case "S", "e":
+		if len(visible) == 0 {
+			v.notice = "No events to export"
+			return nil
+		}
+		v.saving = true
+		v.notice = ""
+		v.savePath = v.defaultSavePath()
+	}
+	return nil
+}
+
+// scrollBy 滚动 delta 行，滚到底部时恢复跟随
+func (v *EventsView) scrollBy(delta, total int) {
+	page := v.pageSize()
+	maxScroll := max(0, total-page)
+	if v.follow {
+		v.scroll = maxScroll
+	}
+	v.scroll = max(0, min(maxScroll, v.scroll+delta))
+	v.follow = v.scroll >= maxScroll
+}
+
+// updateFilter 输入过滤关键字，输入时即时过滤
+func (v *EventsView) updateFilter(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		v.filtering = false
+	case tea.KeyEsc:
+		v.filtering = false
+		v.filter = ""
+	case tea.KeyBackspace:
+		if r := []rune(v.filter); len(r) > 0 {
+			v.filter = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		v.filter += string(msg.Runes)
+	}
+	v.follow = true
+}
+
+// updateSave 输入导出路径，回车后写入当前显示的事件
+func (v *EventsView) updateSave(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		v.saving = false
+		if strings.TrimSpace(v.savePath) == "" {
+			return
+		}
+		events := v.visibleEvents()
+		if err := saveEvents(v.savePath, events); err != nil {
+			v.notice = auditErrorStyle.Render("❌ Export failed: " + err.Error())
+		} else {
+			v.notice = fmt.Sprintf("💾 Exported %d events to %s", len(events), v.savePath)
+		}
+	case tea.KeyEsc:
+		v.saving = false
+	case tea.KeyBackspace:
+		if r := []rune(v.savePath); len(r) > 0 {
+			v.savePath = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		v.savePath += string(msg.Runes)
+	}
+}
+
+// saveEvents 把事件写成 JSON 数组
+func saveEvents(path string, events []docker.Event) error {
+	data, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// eventsFileChars 默认文件名中需要替换的字符
+var eventsFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// defaultSavePath 默认的导出文件名，如 docktui-events-web_20260101_150405.json
+func (v *EventsView) defaultSavePath() string {
+	name := "docktui-events"
+	if !v.focus.IsZero() {
+		label := v.focus.Name
+		if label == "" {
+			label = v.focus.ID
+		}
+		if label = strings.Trim(eventsFileChars.ReplaceAllString(label, "-"), "-"); label != "" {
+			name += "-" + label
+		}
+	}
+	return fmt.Sprintf("%s_%s.json", name, time.Now().Format("20060102_150405"))
+}
+
+// visibleEvents 聚焦和过滤后要显示的事件（暂停时不包括暂停后收到的）
+func (v *EventsView) visibleEvents() []docker.Event {
+	captured := v.events
+	if v.paused {
+		captured = captured[:min(v.pausedAt, len(captured))]
+	}
+	query := strings.ToLower(v.filter)
+	var out []docker.Event
+	for _, e := range captured {
+		if !v.focus.Matches(e) {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(e.Type+" "+e.Action+" "+e.ActorID+" "+e.ActorName), query) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// pageSize 列表可显示的行数
+func (v *EventsView) pageSize() int {
+	return max(5, v.height-12)
+}
+
+// SetSize 设置视图尺寸
+func (v *EventsView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// eventDetail 事件的附加信息：容器的镜像和退出码、网络连接的容器等
+func eventDetail(e docker.Event) string {
+	var parts []string
+	if code := e.Attributes["exitCode"]; code != "" {
+		parts = append(parts, "exit="+code)
+	}
+	switch e.Type {
+	case "container":
+		if img := e.Attributes["image"]; img != "" {
+			parts = append(parts, "image="+img)
+		}
+	case "network", "volume":
+		if c := e.Attributes["container"]; c != "" {
+			parts = append(parts, "container="+format.Truncate(strings.TrimPrefix(c, "sha256:"), 12))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// View 渲染视图
+func (v *EventsView) View() string {
+	var b strings.Builder
+	title := "📡 Events"
+	if !v.focus.IsZero() {
+		title += ": " + v.focus.Label()
+	}
+	b.WriteString("\n  " + auditTitleStyle.Render(title))
+	state := "● live"
+	if v.paused {
+		state = fmt.Sprintf("⏸ paused · %d new", len(v.events)-v.pausedAt)
+	}
+	b.WriteString("  " + auditMutedStyle.Render(fmt.Sprintf("%s · %d captured", state, len(v.events)+v.dropped)))
+	if v.filter != "" || v.filtering {
+		b.WriteString("  " + auditKeyStyle.Render("/"+v.filter))
+	}
+	b.WriteString("\n\n")
+
+	visible := v.visibleEvents()
+	switch {
+	case v.err != nil:
+		b.WriteString("  " + auditErrorStyle.Render("❌ "+v.err.Error()) + "\n")
+	case len(visible) == 0:
+		hint := "Waiting for events..."
+		if len(v.events) > 0 {
+			hint = "No captured events match"
+		}
+		b.WriteString("  " + auditMutedStyle.Render(hint) + "\n")
+	}
+	if len(visible) > 0 {
+		header := fmt.Sprintf("  %-12s  %-9s  %-16s  %-28s  %s", "TIME", "TYPE", "ACTION", "NAME", "DETAILS")
+		b.WriteString(auditHeaderStyle.Render(header) + "\n")
+
+		page := v.pageSize()
+		start := v.scroll
+		if v.follow {
+			start = max(0, len(visible)-page)
+		}
+		end := min(len(visible), start+page)
+		for _, e := range visible[start:end] {
+			name := e.ActorName
+			if name == "" {
+				name = strings.TrimPrefix(e.ActorID, "sha256:")
+			}
+			line := fmt.Sprintf("  %-12s  %-9s  %-16s  %-28s  %s", e.Time.Format("15:04:05.000"), e.Type,
+				format.Truncate(e.Action, 16), format.Truncate(name, 28), auditMutedStyle.Render(eventDetail(e)))
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n  " + auditMutedStyle.Render(fmt.Sprintf("%d-%d of %d", start+1, end, len(visible))) + "\n")
+	}
+
+	switch {
+	case v.saving:
+		b.WriteString("\n  " + auditTitleStyle.Render("💾 Export to:") + " " + v.savePath + "█  " + auditMutedStyle.Render("[Enter=Save | Esc=Cancel]") + "\n")
+	case v.notice != "":
+		b.WriteString("\n  " + v.notice + "\n")
+	}
+
+	keys := []string{
+		auditKeyStyle.Render("/") + " Filter",
+		auditKeyStyle.Render("p") + " Pause",
+		auditKeyStyle.Render("e") + " Export JSON",
+		auditKeyStyle.Render("c") + " Clear",
+	}
+	if !v.focus.IsZero() {
+		keys = append(keys, auditKeyStyle.Render("F")+" All resources")
+	}
+	keys = append(keys, auditKeyStyle.Render("j/k")+" Scroll", auditKeyStyle.Render("G")+" Follow", auditKeyStyle.Render("Esc")+" Back")
+	b.WriteString("\n  " + auditMutedStyle.Render(strings.Join(keys, "  ")))
+	return b.String()
+}
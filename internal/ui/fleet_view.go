@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+	"docktui/internal/fleet"
+	"docktui/internal/format"
+	"docktui/internal/policy"
+)
+
+// fleetHostMsg 一台主机的查询结果
+type fleetHostMsg struct {
+	gen    int
+	index  int
+	status fleet.Status
+}
+
+// fleetSessionDoneMsg 在其他主机上打开的 docktui 已退出
+type fleetSessionDoneMsg struct {
+	name string
+	err  error
+}
+
+// fleetOpenLocalMsg 选中的是当前连接的主机，直接进入容器列表
+type fleetOpenLocalMsg struct{}
+
+// FleetView 多主机概览：并发查询 hosts.yaml 中的主机和 docker CLI 上下文的容器数量和异常容器，每台主机一行
+type FleetView struct {
+	width  int
+	height int
+
+	path     string // 配置文件路径
+	hosts    []fleet.Host
+	statuses []*fleet.Status // 与 hosts 对应，查询中为 nil
+	selected int
+	gen      int // 每次刷新递增，丢弃过期的查询结果
+	notice   string
+}
+
+// NewFleetView 创建多主机概览视图
+func NewFleetView() *FleetView {
+	return &FleetView{}
+}
+
+// SetFleetHosts 设置多主机概览中的主机（同时作为镜像传输的目标主机），path 为读取的配置文件
+func SetFleetHosts(m Model, path string, hosts []fleet.Host) Model {
+	m.fleetView.path = path
+	m.fleetView.hosts = hosts
+	if m.imageListView != nil {
+		m.imageListView.SetTransferHosts(hosts)
+	}
+	return m
+}
+
+// Init 并发查询所有主机，每台主机的结果返回后单独更新
+func (v *FleetView) Init() tea.Cmd {
+	v.gen++
+	v.notice = ""
+	v.statuses = make([]*fleet.Status, len(v.hosts))
+	cmds := make([]tea.Cmd, len(v.hosts))
+	for i, h := range v.hosts {
+		gen, index, host := v.gen, i, h
+		cmds[i] = func() tea.Msg {
+			return fleetHostMsg{gen: gen, index: index, status: fleet.Check(context.Background(), host)}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// Update 处理消息
+func (v *FleetView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case fleetHostMsg:
+		if msg.gen == v.gen && msg.index < len(v.statuses) {
+			status := msg.status
+			v.statuses[msg.index] = &status
+		}
+		return v, nil
+
+	case fleetSessionDoneMsg:
+		v.notice = "↩ Back from " + msg.name
+		if msg.err != nil {
+			v.notice = fmt.Sprintf("❌ docktui on %s exited: %v", msg.name, msg.err)
+		}
+		width, height := v.width, v.height
+		return v, tea.Batch(v.Init(), func() tea.Msg { return tea.WindowSizeMsg{Width: width, Height: height} })
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "b":
+			return v, func() tea.Msg { return GoBackMsg{} }
+		case "j", "down":
+			if v.selected < len(v.hosts)-1 {
+				v.selected++
+			}
+		case "k", "up":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "r", "f5":
+			return v, v.Init()
+		case "enter":
+			return v, v.openHost()
+		}
+	}
+	return v, nil
+}
+
+// SetSize 设置视图尺寸
+func (v *FleetView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// openHost 打开选中主机的容器列表：当前连接的主机直接进入，其他主机释放终端启动连接该主机的 docktui，退出后返回概览
+func (v *FleetView) openHost() tea.Cmd {
+	if v.selected >= len(v.hosts) {
+		return nil
+	}
+	h := v.hosts[v.selected]
+	if h.Host == docker.DockerHost() {
+		return func() tea.Msg { return fleetOpenLocalMsg{} }
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		v.notice = "❌ Cannot find the docktui executable: " + err.Error()
+		return nil
+	}
+	args := []string{"--view", "containers"}
+	if policy.ReadOnly() {
+		args = append(args, "--read-only")
+	}
+	cmd := exec.Command(exe, args...)
+	cmd.Env = h.Endpoint().Environ(os.Environ())
+	name := h.Name
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return fleetSessionDoneMsg{name: name, err: err}
+	})
+}
+
+// View 渲染视图
+func (v *FleetView) View() string {
+	var b strings.Builder
+
+	b.WriteString("\n  " + auditTitleStyle.Render("🖥️  Fleet"))
+	if v.path != "" {
+		b.WriteString("  " + auditMutedStyle.Render(v.path))
+	}
+	b.WriteString("\n\n")
+
+	if len(v.hosts) == 0 {
+		path := "hosts.yaml"
+		if p, err := fleet.DefaultPath(); err == nil {
+			path = p
+		}
+		b.WriteString("  " + auditMutedStyle.Render("No hosts configured; list them in "+path+" (or DOCKTUI_HOSTS), or add them with docker context create:") + "\n\n")
+		b.WriteString(auditMutedStyle.Render("    hosts:\n      - name: db1\n        host: tcp://10.0.0.5:2376\n      - name: web\n        host: unix:///var/run/docker.sock") + "\n")
+	} else {
+		header := fmt.Sprintf("  %-20s  %-8s  %-8s  %-8s  %-9s  %-7s  %s", "HOST", "TOTAL", "RUNNING", "EXITED", "UNHEALTHY", "PING", "ADDRESS")
+		b.WriteString(auditHeaderStyle.Render(header) + "\n")
+		current := docker.DockerHost()
+		for i, h := range v.hosts {
+			name := format.Truncate(h.Name, 20)
+			address := h.Host
+			if h.Context != "" {
+				address += " [context]"
+			}
+			if h.Host == current {
+				address += " (current)"
+			}
+			var s *fleet.Status
+			if i < len(v.statuses) {
+				s = v.statuses[i]
+			}
+			var line string
+			switch {
+			case s == nil:
+				line = fmt.Sprintf("%-20s  %s", name, auditMutedStyle.Render("⏳ querying..."))
+			case s.Err != nil:
+				line = fmt.Sprintf("%-20s  %s", name, auditErrorStyle.Render("✖ "+format.Truncate(s.Err.Error(), max(20, v.width-30))))
+			default:
+				exited := fmt.Sprintf("%-8d", s.Exited)
+				if s.Exited > 0 {
+					exited = auditErrorStyle.Render(exited)
+				}
+				unhealthy := fmt.Sprintf("%-9d", s.Unhealthy)
+				if s.Unhealthy > 0 {
+					unhealthy = auditErrorStyle.Render(unhealthy)
+				}
+				line = fmt.Sprintf("%-20s  %-8d  %s  %s  %s  %-7s  %s", name, s.Total,
+					auditOKStyle.Render(fmt.Sprintf("%-8d", s.Running)), exited, unhealthy,
+					s.Latency.Round(time.Millisecond), auditMutedStyle.Render(address))
+			}
+			if i == v.selected {
+				line = auditKeyStyle.Render("▶ ") + line
+			} else {
+				line = "  " + line
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	if v.notice != "" {
+		b.WriteString("\n  " + v.notice + "\n")
+	}
+
+	keys := []string{
+		auditKeyStyle.Render("j/k") + " Select",
+		auditKeyStyle.Render("Enter") + " Open containers",
+		auditKeyStyle.Render("r") + " Refresh",
+		auditKeyStyle.Render("Esc") + " Back",
+	}
+	b.WriteString("\n  " + auditMutedStyle.Render(strings.Join(keys, "  ")))
+	return b.String()
+}
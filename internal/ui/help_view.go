@@ -5,10 +5,12 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
 
 	"docktui/internal/docker"
 	"docktui/internal/ui/components"
+	"docktui/internal/version"
 )
 
 var (
@@ -84,11 +86,16 @@ func (v *HelpView) Init() tea.Cmd {
 	return nil
 }
 
+// bindingKeys 快捷键的所有按键（包括 keybindings 中配置的），用于帮助面板
+func bindingKeys(b key.Binding) string {
+	return strings.Join(b.Keys(), " / ")
+}
+
 // Update 处理消息并更新视图状态
 func (v *HelpView) Update(msg tea.Msg) (View, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if msg.String() == "esc" || msg.String() == "?" {
+		if msg.String() == "esc" || key.Matches(msg, v.keys.Help) {
 			// ESC 或 ? 返回上一级
 			return v, func() tea.Msg { return GoBackMsg{} }
 		}
@@ -99,13 +106,16 @@ func (v *HelpView) Update(msg tea.Msg) (View, tea.Cmd) {
 // View 渲染帮助面板（借鉴 k9s 风格）
 func (v *HelpView) View() string {
 	// 定义帮助章节
+	k := v.keys
 	sections := []helpSection{
 		{
 			title: "Global Shortcuts",
 			items: []helpItem{
-				{"q / Ctrl+C", "Quit"},
-				{"?", "Show/Hide Help"},
+				{bindingKeys(k.Quit), "Quit (running tasks: w wait, c cancel, a abandon)"},
+				{bindingKeys(k.Help), "Show/Hide Help"},
 				{"Esc", "Go Back"},
+				{"Ctrl+Z", "Action History / Undo"},
+				{bindingKeys(k.DebugDump), "Dump Screen and State"},
 				{"c", "Go to Containers"},
 				{"i", "Go to Images"},
 				{"n", "Go to Networks (WIP)"},
@@ -120,16 +130,18 @@ func (v *HelpView) View() string {
 				{"←/→", "Select Runtime/Resource"},
 				{"1-5", "Quick Select Resource"},
 				{"Enter", "Enter Selected"},
-				{"r", "Refresh"},
+				{bindingKeys(k.Refresh), "Refresh"},
 			},
 		},
 		{
 			title: "List Navigation",
 			items: []helpItem{
-				{"j / ↓", "Move Down"},
-				{"k / ↑", "Move Up"},
-				{"g / Home", "Go to Top"},
-				{"G / End", "Go to Bottom"},
+				{bindingKeys(k.Down), "Move Down"},
+				{bindingKeys(k.Up), "Move Up"},
+				{bindingKeys(k.PageDown), "Page Down"},
+				{bindingKeys(k.PageUp), "Page Up"},
+				{bindingKeys(k.Home), "Go to Top"},
+				{bindingKeys(k.End), "Go to Bottom"},
 				{"/", "Search"},
 			},
 		},
@@ -142,15 +154,17 @@ func (v *HelpView) View() string {
 				{"t", "Start Container"},
 				{"p", "Stop Container"},
 				{"R", "Restart Container"},
+				{":", "Find by Host Port"},
 			},
 		},
 		{
 			title: "Log Operations",
 			items: []helpItem{
-				{"f", "Toggle Follow Mode"},
-				{"w", "Toggle Word Wrap"},
+				{bindingKeys(k.ToggleFollow), "Toggle Follow Mode"},
+				{bindingKeys(k.ToggleWrap), "Toggle Word Wrap"},
 				{"j/k", "Scroll Up/Down"},
 				{"g/G", "Go to Top/Bottom"},
+				{"T", "Jump to Time"},
 			},
 		},
 	}
@@ -196,7 +210,7 @@ func (v *HelpView) View() string {
 	footer := helpFooterStyle.Render(
 		"💡 Tip: Shortcuts follow vim conventions\n" +
 		"📦 Repository: github.com/yourusername/docktui\n" +
-		"📖 Version: v0.1.0\n\n" +
+		"📖 Version: " + version.Current() + "\n\n" +
 		lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Bold(true).Render("Press ESC or b to go back"),
 	)
 	
@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/audit"
+	"docktui/internal/format"
+	"docktui/internal/ui/components"
+)
+
+// historyUndoTimeout 撤销一次操作的超时
+const historyUndoTimeout = 30 * time.Second
+
+// historyUndoneMsg 撤销操作结束
+type historyUndoneMsg struct {
+	label  string
+	target string
+	err    error
+}
+
+// HistoryOverlay 本次运行的操作历史弹窗（Ctrl+Z）：按操作名/对象搜索，撤销可逆的操作
+type HistoryOverlay struct {
+	width  int
+	height int
+
+	visible   bool
+	entries   []audit.SessionEntry // 匹配搜索的记录，按时间倒序
+	query     string
+	searching bool
+	selected  int
+	notice    string
+}
+
+// NewHistoryOverlay 创建操作历史弹窗
+func NewHistoryOverlay() *HistoryOverlay {
+	return &HistoryOverlay{}
+}
+
+// Show 打开弹窗并加载最新的历史
+func (h *HistoryOverlay) Show() {
+	h.visible = true
+	h.selected = 0
+	h.notice = ""
+	h.reload()
+}
+
+// IsVisible 弹窗是否可见
+func (h *HistoryOverlay) IsVisible() bool {
+	return h.visible
+}
+
+// SetSize 设置屏幕尺寸
+func (h *HistoryOverlay) SetSize(width, height int) {
+	h.width = width
+	h.height = height
+}
+
+// reload 按搜索条件重新读取历史
+func (h *HistoryOverlay) reload() {
+	query := strings.ToLower(h.query)
+	h.entries = h.entries[:0]
+	for _, e := range audit.Session() {
+		if query == "" || strings.Contains(strings.ToLower(e.Action+" "+e.Target), query) {
+			h.entries = append(h.entries, e)
+		}
+	}
+	if h.selected >= len(h.entries) {
+		h.selected = len(h.entries) - 1
+	}
+	if h.selected < 0 {
+		h.selected = 0
+	}
+}
+
+// Update 处理按键，撤销时返回执行撤销的命令
+func (h *HistoryOverlay) Update(msg tea.KeyMsg) tea.Cmd {
+	if h.searching {
+		switch msg.Type {
+		case tea.KeyEnter:
+			h.searching = false
+		case tea.KeyEsc:
+			h.searching = false
+			h.query = ""
+		case tea.KeyBackspace:
+			if len(h.query) > 0 {
+				h.query = h.query[:len(h.query)-1]
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			h.query += msg.String()
+		}
+		h.reload()
+		return nil
+	}
+
+	switch msg.String() {
+	case "esc", "ctrl+z":
+		if h.query != "" && msg.String() == "esc" {
+			h.query = ""
+			h.reload()
+			return nil
+		}
+		h.visible = false
+	case "/":
+		h.searching = true
+	case "j", "down":
+		if h.selected < len(h.entries)-1 {
+			h.selected++
+		}
+	case "k", "up":
+		if h.selected > 0 {
+			h.selected--
+		}
+	case "g":
+		h.selected = 0
+	case "G":
+		h.selected = max(0, len(h.entries)-1)
+	case "u", "enter":
+		return h.undoSelected()
+	}
+	return nil
+}
+
+// undoSelected 撤销选中的操作
+func (h *HistoryOverlay) undoSelected() tea.Cmd {
+	if h.selected >= len(h.entries) {
+		return nil
+	}
+	e := h.entries[h.selected]
+	if !e.CanUndo() {
+		switch {
+		case e.Undone:
+			h.notice = "💡 Already undone"
+		case e.Result != audit.ResultOK:
+			h.notice = "💡 Failed actions have nothing to undo"
+		default:
+			h.notice = "💡 " + e.Action + " cannot be undone"
+		}
+		return nil
+	}
+	h.notice = "⏳ " + e.UndoLabel() + " " + e.Target + "..."
+	seq, label, target := e.Seq, e.UndoLabel(), e.Target
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), historyUndoTimeout)
+		defer cancel()
+		return historyUndoneMsg{label: label, target: target, err: audit.UndoSession(ctx, seq)}
+	}
+}
+
+// handleUndone 撤销结束后更新提示和列表，返回结果说明
+func (h *HistoryOverlay) handleUndone(msg historyUndoneMsg) string {
+	if msg.err != nil {
+		h.notice = fmt.Sprintf("❌ Undo failed (%s %s): %v", msg.label, msg.target, msg.err)
+	} else {
+		h.notice = fmt.Sprintf("↩️ Undone: %s %s", msg.label, msg.target)
+	}
+	h.reload()
+	return h.notice
+}
+
+// View 渲染弹窗，叠加在当前视图上
+func (h *HistoryOverlay) View(content string) string {
+	// 与 OverlayCenteredBox 的边框宽度一致（去掉边框和内边距）
+	width := min(80, max(50, h.width/2)) - 6
+	pageSize := max(5, min(15, h.height-14))
+
+	var lines []string
+	if h.searching || h.query != "" {
+		cursor := ""
+		if h.searching {
+			cursor = "█"
+		}
+		lines = append(lines, auditKeyStyle.Render("/ ")+h.query+cursor, "")
+	}
+
+	if len(h.entries) == 0 {
+		if h.query != "" {
+			lines = append(lines, auditMutedStyle.Render("No actions match the search"))
+		} else {
+			lines = append(lines, auditMutedStyle.Render("No actions in this session yet"))
+		}
+	} else {
+		start := 0
+		if h.selected >= pageSize {
+			start = h.selected - pageSize + 1
+		}
+		end := min(len(h.entries), start+pageSize)
+		targetWidth := max(10, width-48)
+		for i := start; i < end; i++ {
+			e := h.entries[i]
+			result := auditOKStyle.Render("✓")
+			if e.Result != audit.ResultOK {
+				result = auditErrorStyle.Render("✗")
+			}
+			undo := ""
+			switch {
+			case e.Undone:
+				undo = auditMutedStyle.Render("undone")
+			case e.CanUndo():
+				undo = auditKeyStyle.Render("↩ " + e.UndoLabel())
+			}
+			line := fmt.Sprintf("%s %s %-18s %-*s %s", auditMutedStyle.Render(e.Time.Format("15:04:05")), result,
+				format.Truncate(e.Action, 18), targetWidth, format.Truncate(e.Target, targetWidth), undo)
+			if i == h.selected {
+				line = auditKeyStyle.Render("▶ ") + line
+			} else {
+				line = "  " + line
+			}
+			lines = append(lines, line)
+		}
+		lines = append(lines, "", auditMutedStyle.Render(fmt.Sprintf("%d of %d", h.selected+1, len(h.entries))))
+		if e := h.entries[h.selected]; e.Error != "" {
+			lines = append(lines, auditErrorStyle.Render(format.Truncate(e.Error, width-4)))
+		}
+	}
+
+	if h.notice != "" {
+		lines = append(lines, "", h.notice)
+	}
+	keys := []string{
+		auditKeyStyle.Render("j/k") + " Select",
+		auditKeyStyle.Render("u") + " Undo",
+		auditKeyStyle.Render("/") + " Search",
+		auditKeyStyle.Render("Esc") + " Close",
+	}
+	lines = append(lines, "", auditMutedStyle.Render(strings.Join(keys, "  ")))
+	return components.OverlayCenteredBox(content, "↩️ Action History", strings.Join(lines, "\n"), h.width, h.height, "81")
+}
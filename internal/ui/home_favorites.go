@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/compose"
+	"docktui/internal/docker"
+	"docktui/internal/favorites"
+	"docktui/internal/format"
+	composeui "docktui/internal/ui/compose"
+	containerui "docktui/internal/ui/container"
+	imageui "docktui/internal/ui/image"
+)
+
+// jumpKeys 首页收藏/最近访问条目的跳转键（大写字母，避开首页已用的 L/R/T）
+const jumpKeys = "ABCDEFGHIJ"
+
+// maxHomeFavorites 首页最多显示的收藏数，其余位置留给最近访问
+const maxHomeFavorites = 5
+
+// favoriteJumpFailedMsg 跳转到收藏/最近访问的资源失败（资源已被删除等）
+type favoriteJumpFailedMsg struct {
+	name string
+	err  error
+}
+
+// kindIcons 各类资源在首页的图标，与资源卡片一致
+var kindIcons = map[string]string{
+	favorites.KindContainer: "◈",
+	favorites.KindImage:     "▣",
+	favorites.KindCompose:   "⚙",
+}
+
+// jumpEntries 返回首页显示的收藏和最近访问条目（最近访问中去掉已收藏的）
+func (v *HomeView) jumpEntries() (starred, recent []favorites.Ref) {
+	store := favorites.Default()
+	if store == nil {
+		return nil, nil
+	}
+	starred = store.Favorites()
+	if len(starred) > maxHomeFavorites {
+		starred = starred[:maxHomeFavorites]
+	}
+	for _, ref := range store.Recent() {
+		if len(starred)+len(recent) >= len(jumpKeys) {
+			break
+		}
+		if !store.IsFavorite(ref.Kind, ref.Key) {
+			recent = append(recent, ref)
+		}
+	}
+	return starred, recent
+}
+
+// JumpTarget 返回跳转键对应的资源
+func (v *HomeView) JumpTarget(key string) (favorites.Ref, bool) {
+	idx := strings.Index(jumpKeys, key)
+	if len(key) != 1 || idx < 0 {
+		return favorites.Ref{}, false
+	}
+	starred, recent := v.jumpEntries()
+	entries := append(starred, recent...)
+	if idx >= len(entries) {
+		return favorites.Ref{}, false
+	}
+	return entries[idx], true
+}
+
+// renderJumpSection 渲染收藏和最近访问区域，未启用时返回空字符串
+func (v *HomeView) renderJumpSection() string {
+	if favorites.Default() == nil {
+		return ""
+	}
+	width := v.width
+	if width < 80 {
+		width = 80
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Bold(true).Width(14)
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	starred, recent := v.jumpEntries()
+	if len(starred) == 0 && len(recent) == 0 {
+		hint := hintStyle.Render("★ Press * in a container, image or compose list to add favorites")
+		return strings.Repeat(" ", max(2, (width-lipgloss.Width(hint))/2)) + hint
+	}
+
+	var lines []string
+	renderGroup := func(label string, refs []favorites.Ref, offset int) {
+		if len(refs) == 0 {
+			return
+		}
+		line := labelStyle.Render(label)
+		indent := strings.Repeat(" ", lipgloss.Width(line))
+		for i, ref := range refs {
+			item := keyStyle.Render("["+string(jumpKeys[offset+i])+"]") + " " + nameStyle.Render(kindIcons[ref.Kind]+" "+format.Truncate(ref.Name, 24))
+			if lipgloss.Width(line+"   "+item) > width-4 && i > 0 {
+				lines = append(lines, line)
+				line = indent
+			} else if i > 0 {
+				line += "   "
+			}
+			line += item
+		}
+		lines = append(lines, line)
+	}
+	renderGroup("★ Favorites", starred, 0)
+	renderGroup("🕘 Recent", recent, len(starred))
+
+	// 整体居中
+	blockWidth := 0
+	for _, line := range lines {
+		blockWidth = max(blockWidth, lipgloss.Width(line))
+	}
+	padding := strings.Repeat(" ", max(2, (width-blockWidth)/2))
+	for i := range lines {
+		lines[i] = padding + lines[i]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recordRecent 记录一次访问（首页最近访问列表）
+func recordRecent(kind, key, name string) {
+	favorites.Touch(favorites.Ref{Kind: kind, Key: key, Name: name})
+}
+
+// recordRecentProject 记录一次 Compose 项目访问，保存重新打开项目所需的配置文件
+func recordRecentProject(project *compose.Project) {
+	if project == nil {
+		return
+	}
+	favorites.Touch(favorites.Ref{
+		Kind:         favorites.KindCompose,
+		Key:          project.Path,
+		Name:         project.Name,
+		ComposeFiles: project.ComposeFiles,
+		EnvFiles:     project.EnvFiles,
+	})
+}
+
+// jumpToRef 从首页跳转到收藏/最近访问的资源：先进入对应列表，再打开详情，
+// 这样从详情返回时回到列表
+func (m Model) jumpToRef(ref favorites.Ref) (tea.Model, tea.Cmd) {
+	switch ref.Kind {
+	case favorites.KindContainer:
+		next, initCmd := m.enterContainerList()
+		return next, tea.Batch(initCmd, m.resolveContainerRef(ref))
+	case favorites.KindImage:
+		next, initCmd := m.enterImageList()
+		return next, tea.Batch(initCmd, m.resolveImageRef(ref))
+	case favorites.KindCompose:
+		next, initCmd := m.enterComposeList()
+		project := &compose.Project{
+			Name:         ref.Name,
+			Path:         ref.Key,
+			WorkingDir:   ref.Key,
+			ComposeFiles: ref.ComposeFiles,
+			EnvFiles:     ref.EnvFiles,
+		}
+		return next, tea.Batch(initCmd, func() tea.Msg { return composeui.GoToDetailMsg{Project: project} })
+	}
+	return m, nil
+}
+
+// resolveContainerRef 按名称查找容器，找到后打开容器详情
+func (m Model) resolveContainerRef(ref favorites.Ref) tea.Cmd {
+	client := m.dockerClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		details, err := client.ContainerDetails(ctx, ref.Key)
+		if err != nil {
+			return favoriteJumpFailedMsg{name: ref.Name, err: err}
+		}
+		return containerui.ViewDetailsMsg{ContainerID: details.ID, ContainerName: details.Name}
+	}
+}
+
+// resolveImageRef 按 ID 或 repo:tag 查找镜像，找到后打开镜像详情
+func (m Model) resolveImageRef(ref favorites.Ref) tea.Cmd {
+	client := m.dockerClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		images, err := client.ListImages(ctx, true)
+		if err != nil {
+			return favoriteJumpFailedMsg{name: ref.Name, err: err}
+		}
+		for i := range images {
+			if matchImage(images[i], ref.Key) {
+				return imageui.ViewImageDetailsMsg{Image: &images[i]}
+			}
+		}
+		return favoriteJumpFailedMsg{name: ref.Name, err: fmt.Errorf("image not found")}
+	}
+}
+
+// matchImage 镜像是否匹配完整 ID、短 ID 或 repo:tag（省略标签时匹配 latest）
+func matchImage(img docker.Image, key string) bool {
+	if img.ID == key || img.ShortID == key {
+		return true
+	}
+	if len(key) >= 12 && strings.HasPrefix(strings.TrimPrefix(img.ID, "sha256:"), strings.TrimPrefix(key, "sha256:")) {
+		return true
+	}
+	if img.Repository+":"+img.Tag == key {
+		return true
+	}
+	return img.Repository == key && img.Tag == "latest"
+}
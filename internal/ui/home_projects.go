@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/compose"
+	"docktui/internal/format"
+	composeui "docktui/internal/ui/compose"
+	"docktui/internal/ui/styles"
+)
+
+// projectKeys 首页 Compose 项目卡片的跳转键（接在收藏的 A–J 之后，跳过切换语言的 L）
+const projectKeys = "KMNOP"
+
+// topProjects 按最近活跃（最新容器的创建时间）排序，取前 n 个项目
+func topProjects(projects []*compose.Project, n int) []*compose.Project {
+	if n <= 0 || len(projects) == 0 {
+		return nil
+	}
+	sorted := append([]*compose.Project(nil), projects...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].LastActivity.Equal(sorted[j].LastActivity) {
+			return sorted[i].LastActivity.After(sorted[j].LastActivity)
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// serviceCounts 项目中有容器在运行的服务数和服务总数
+func serviceCounts(p *compose.Project) (running, total int) {
+	for _, svc := range p.Services {
+		if svc.Running > 0 {
+			running++
+		}
+	}
+	return running, len(p.Services)
+}
+
+// ProjectTarget 返回跳转键对应的 Compose 项目
+func (v *HomeView) ProjectTarget(key string) (*compose.Project, bool) {
+	idx := strings.Index(projectKeys, key)
+	if len(key) != 1 || idx < 0 || idx >= len(v.projects) {
+		return nil, false
+	}
+	return v.projects[idx], true
+}
+
+// renderProjectCards 渲染最近活跃的 Compose 项目卡片，没有项目时返回空字符串
+func (v *HomeView) renderProjectCards() string {
+	if len(v.projects) == 0 {
+		return ""
+	}
+	width := v.width
+	if width < 80 {
+		width = 80
+	}
+
+	// 与资源卡片同宽，放不下时缩小
+	cardWidth := min(24, max(16, (width-4)/len(v.projects)-2))
+	contentWidth := cardWidth - 6
+	cardStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 2).
+		Width(cardWidth)
+	lineStyle := lipgloss.NewStyle().Width(contentWidth).Align(lipgloss.Center)
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+
+	var cards []string
+	for i, p := range v.projects {
+		name := nameStyle.Render(format.Truncate("⚙ "+p.Name, contentWidth))
+
+		running, total := serviceCounts(p)
+		counts := fmt.Sprintf("%d/%d svc", running, total)
+		if st, ok := composeui.ProjectStatus(p.Status); ok {
+			counts = styles.StatusStyle(st).Render(styles.StatusGlyph(st) + " " + counts)
+		}
+
+		hint := keyStyle.Render(string(projectKeys[i]))
+		if !p.LastActivity.IsZero() {
+			hint += mutedStyle.Render(" · " + format.Ago(p.LastActivity))
+		}
+
+		content := lipgloss.JoinVertical(lipgloss.Center, lineStyle.Render(name), lineStyle.Render(counts), lineStyle.Render(hint))
+		cards = append(cards, cardStyle.Render(content))
+	}
+
+	row := v.joinCardsHorizontal(cards, "  ")
+	padding := strings.Repeat(" ", max(2, (width-v.getFirstLineWidth(row))/2))
+	lines := strings.Split(row, "\n")
+	for i := range lines {
+		lines[i] = padding + lines[i]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// jumpToProject 从首页打开 Compose 项目详情：先进入 Compose 列表，从详情返回时回到列表
+func (m Model) jumpToProject(project *compose.Project) (tea.Model, tea.Cmd) {
+	next, initCmd := m.enterComposeList()
+	return next, tea.Batch(initCmd, func() tea.Msg { return composeui.GoToDetailMsg{Project: project} })
+}
@@ -12,6 +12,11 @@ import (
 
 	"docktui/internal/compose"
 	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/launchpad"
+	"docktui/internal/ui/components"
+	"docktui/internal/ui/styles"
+	"docktui/internal/version"
 )
 
 // ResourceType 资源类型
@@ -22,6 +27,7 @@ const (
 	ResourceImages
 	ResourceNetworks
 	ResourceCompose
+	ResourceAction // 配置文件中定义的快捷操作卡片
 )
 
 // ResourceInfo 资源信息
@@ -33,6 +39,9 @@ type ResourceInfo struct {
 	Count       int
 	ActiveCount int
 	Available   bool
+	Loading     bool              // 数量加载中
+	Err         string            // 数量加载失败的原因
+	Action      *launchpad.Action // 快捷操作卡片对应的操作
 }
 
 // HomeView 首页导航视图
@@ -45,14 +54,29 @@ type HomeView struct {
 	resources        []ResourceInfo
 	selectedResource int
 
-	loading         bool
-	lastRefreshTime time.Time
+	// 各卡片并行加载，generation 在每次刷新时递增，用于丢弃上一次刷新的过期结果
+	generation      int
+	spinnerFrame    int
 	dockerConnected bool
+
+	// 自动刷新：refreshGen 在 Init 时递增，手动刷新后旧的倒计时循环自然结束
+	refresh    *components.RefreshTimer
+	refreshGen int
 	dockerHost      string
+
+	discovery    *compose.Discovery // Compose 项目发现器，与 Compose 列表共用缓存
+	projects     []*compose.Project // 首页显示的最近活跃的 Compose 项目
+	projectLimit int                // 首页显示的 Compose 项目卡片数，0 表示不显示
+	disk        *docker.DiskUsage
+	diskLoading bool
+	diskErr     string
+
+	selfCheck []docker.Diagnostic // 启动自检结果，nil 表示尚未完成
+	update    *version.Update     // 新版本检查结果，未开启检查或查询失败时为 nil
 }
 
-// NewHomeView 创建首页视图
-func NewHomeView(dockerClient docker.Client) *HomeView {
+// NewHomeView 创建首页视图，discovery 为 nil 时 Compose 卡片不显示项目数量
+func NewHomeView(dockerClient docker.Client, discovery *compose.Discovery) *HomeView {
 	// 获取 Docker Host
 	dockerHost := os.Getenv("DOCKER_HOST")
 	if dockerHost == "" {
@@ -63,49 +87,161 @@ func NewHomeView(dockerClient docker.Client) *HomeView {
 		dockerClient:     dockerClient,
 		selectedResource: 0,
 		dockerHost:       dockerHost,
+		discovery:        discovery,
+		refresh:          components.NewRefreshTimer(homeRefreshInterval),
 	}
 
-	v.resources = []ResourceInfo{
-		{Type: ResourceContainers, Name: "Containers", Icon: "◈", Key: "c", Available: true},
-		{Type: ResourceImages, Name: "Images", Icon: "▣", Key: "i", Available: true},
-		{Type: ResourceNetworks, Name: "Networks", Icon: "⊕", Key: "n", Available: true},
-		{Type: ResourceCompose, Name: "Compose", Icon: "⚙", Key: "o", Available: true},
-	}
+	v.SetLaunchpad(launchpad.Default())
 
 	return v
 }
 
-// Init 初始化
+// resourceCards 各资源卡片的默认信息
+var resourceCards = map[string]ResourceInfo{
+	launchpad.CardContainers: {Type: ResourceContainers, Name: "Containers", Icon: "◈", Key: "c", Available: true},
+	launchpad.CardImages:     {Type: ResourceImages, Name: "Images", Icon: "▣", Key: "i", Available: true},
+	launchpad.CardNetworks:   {Type: ResourceNetworks, Name: "Networks", Icon: "⊕", Key: "n", Available: true},
+	launchpad.CardCompose:    {Type: ResourceCompose, Name: "Compose", Icon: "⚙", Key: "o", Available: true},
+}
+
+// actionIcons 快捷操作卡片未指定图标时按类型使用的图标
+var actionIcons = map[string]string{
+	launchpad.KindContainer: "◈",
+	launchpad.KindImage:     "▣",
+	launchpad.KindCompose:   "⚙",
+	launchpad.KindPrune:     "🧹",
+}
+
+// SetLaunchpad 按首页配置设置卡片：资源卡片按配置的顺序，快捷操作卡片排在后面
+func (v *HomeView) SetLaunchpad(cfg *launchpad.Config) {
+	v.resources = nil
+	for _, card := range cfg.Cards {
+		if res, ok := resourceCards[card]; ok {
+			v.resources = append(v.resources, res)
+		}
+	}
+	for i := range cfg.Actions {
+		action := &cfg.Actions[i]
+		icon := action.Icon
+		if icon == "" {
+			icon = actionIcons[action.Kind()]
+		}
+		v.resources = append(v.resources, ResourceInfo{Type: ResourceAction, Name: action.Name, Icon: icon, Available: true, Action: action})
+	}
+	v.selectedResource = 0
+	v.projectLimit = cfg.Projects
+}
+
+// Init 初始化：加载各卡片并开始自动刷新的倒计时
 func (v *HomeView) Init() tea.Cmd {
-	v.loading = true
-	return v.loadStats
+	v.refreshGen++
+	v.refresh.SetInterval(homeRefreshInterval)
+	return tea.Batch(v.load(false), v.tickRefresh(v.refreshGen))
+}
+
+// load 各卡片的数量和磁盘占用并行加载，某个接口较慢（常见于 Compose 项目发现）时不影响其他卡片；
+// quiet 为 true 时（自动刷新）卡片保留旧的数量，不显示加载动画
+func (v *HomeView) load(quiet bool) tea.Cmd {
+	v.generation++
+	gen := v.generation
+	v.refresh.Start()
+
+	// 容器数量同时用于判断连接状态，没有容器卡片时也加载
+	cmds := []tea.Cmd{v.loadContainers(gen), v.loadDisk(gen)}
+	if !quiet {
+		v.diskLoading = true
+		cmds = append(cmds, v.tickSpinner(gen))
+	}
+	composeLoading := false
+	for i := range v.resources {
+		res := &v.resources[i]
+		var cmd tea.Cmd
+		switch res.Type {
+		case ResourceContainers:
+		case ResourceImages:
+			cmd = v.loadImages(gen)
+		case ResourceNetworks:
+			cmd = v.loadNetworks(gen)
+		case ResourceCompose:
+			cmd = v.loadCompose(gen)
+			composeLoading = true
+		default:
+			continue
+		}
+		if !quiet {
+			res.Loading = true
+			res.Err = ""
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	// 没有 Compose 卡片时项目卡片仍需要加载
+	if !composeLoading && v.projectLimit > 0 {
+		cmds = append(cmds, v.loadCompose(gen))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Refresh 手动刷新：Compose 项目重新发现，不使用缓存
+func (v *HomeView) Refresh() tea.Cmd {
+	if v.discovery != nil {
+		v.discovery.InvalidateCache()
+	}
+	return v.Init()
 }
 
 // Update 处理消息
 func (v *HomeView) Update(msg tea.Msg) (View, tea.Cmd) {
 	switch msg := msg.(type) {
-	case homeStatsLoadedMsg:
-		v.loading = false
-		v.lastRefreshTime = time.Now()
-		v.dockerConnected = msg.dockerConnected
-
+	case homeCardLoadedMsg:
+		if msg.gen != v.generation {
+			return v, nil
+		}
+		if msg.resource == ResourceContainers {
+			v.dockerConnected = msg.err == nil
+		}
+		if msg.resource == ResourceCompose && msg.err == nil {
+			v.projects = topProjects(msg.projects, v.projectLimit)
+		}
 		for i := range v.resources {
-			switch v.resources[i].Type {
-			case ResourceContainers:
-				v.resources[i].Count = msg.containerCount
-				v.resources[i].ActiveCount = msg.runningCount
-			case ResourceImages:
-				v.resources[i].Count = msg.imageCount
-			case ResourceNetworks:
-				v.resources[i].Count = msg.networkCount
-			case ResourceCompose:
-				v.resources[i].Count = msg.composeCount
-				v.resources[i].ActiveCount = msg.composeRunning
-				v.resources[i].Available = msg.composeAvailable
+			res := &v.resources[i]
+			if res.Type != msg.resource {
+				continue
 			}
+			res.Loading = false
+			res.Count = msg.count
+			res.ActiveCount = msg.active
+			res.Available = msg.available
+			res.Err = ""
+			if msg.err != nil {
+				res.Err = msg.err.Error()
+			}
+		}
+		if !v.isLoading() {
+			v.refresh.Mark()
 		}
 		return v, nil
 
+	case homeDiskLoadedMsg:
+		if msg.gen != v.generation {
+			return v, nil
+		}
+		v.diskLoading = false
+		v.disk = msg.usage
+		v.diskErr = ""
+		if msg.err != nil {
+			v.diskErr = msg.err.Error()
+		}
+		return v, nil
+
+	case homeSpinnerTickMsg:
+		if msg.gen != v.generation || !v.isLoading() {
+			return v, nil
+		}
+		v.spinnerFrame++
+		return v, v.tickSpinner(msg.gen)
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "left", "h":
@@ -116,14 +252,10 @@ func (v *HomeView) Update(msg tea.Msg) (View, tea.Cmd) {
 			if v.selectedResource < len(v.resources)-1 {
 				v.selectedResource++
 			}
-		case "1", "2", "3", "4":
-			idx := int(msg.String()[0] - '1')
-			if idx >= 0 && idx < len(v.resources) {
-				v.selectedResource = idx
-			}
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			v.SelectCard(int(msg.String()[0] - '1'))
 		case "r", "f5":
-			v.loading = true
-			return v, v.loadStats
+			return v, v.Refresh()
 		}
 	}
 
@@ -144,17 +276,35 @@ func (v *HomeView) View() string {
 	// 渲染各部分
 	logo := v.renderLogo()
 	status := v.renderConnectionStatus()
+	disk := v.renderDiskUsage()
+	checks := v.renderSelfCheck()
+	if notice := v.renderUpdateNotice(); notice != "" {
+		if checks != "" {
+			checks += "\n"
+		}
+		checks += notice
+	}
 	cards := v.renderResourceCards()
+	if projects := v.renderProjectCards(); projects != "" {
+		cards += "\n\n" + projects
+	}
+	jumps := v.renderJumpSection()
 	footer := v.renderFooter()
 
 	// 计算各部分高度
 	logoHeight := strings.Count(logo, "\n") + 1
-	statusHeight := 1
+	statusHeight := 2
 	cardsHeight := strings.Count(cards, "\n") + 1
 	footerHeight := strings.Count(footer, "\n") + 1
 
 	// 内容总高度
 	contentHeight := logoHeight + statusHeight + cardsHeight + 4 // +4 for spacing
+	if jumps != "" {
+		contentHeight += strings.Count(jumps, "\n") + 3 // +2 for spacing
+	}
+	if checks != "" {
+		contentHeight += strings.Count(checks, "\n") + 1
+	}
 
 	// 计算垂直居中的顶部填充
 	topPadding := (height - contentHeight - footerHeight) / 3
@@ -179,11 +329,25 @@ func (v *HomeView) View() string {
 
 	// 连接状态
 	b.WriteString(status)
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	b.WriteString(disk)
+	b.WriteString("\n")
+	// 启动自检
+	if checks != "" {
+		b.WriteString(checks)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// 资源卡片
 	b.WriteString(cards)
 
+	// 收藏和最近访问
+	if jumps != "" {
+		b.WriteString("\n\n\n")
+		b.WriteString(jumps)
+	}
+
 	// 底部填充
 	b.WriteString(strings.Repeat("\n", bottomPadding))
 
@@ -232,7 +396,7 @@ func (v *HomeView) renderLogo() string {
 	}
 
 	// 版本信息居中
-	subtitle := versionStyle.Render("Docker TUI  v0.1.0")
+	subtitle := versionStyle.Render("Docker TUI  " + version.Current())
 	subtitleWidth := lipgloss.Width(subtitle)
 	subtitlePadding := (width - subtitleWidth) / 2
 	if subtitlePadding < 0 {
@@ -252,17 +416,17 @@ func (v *HomeView) renderHeader() string {
 		Foreground(lipgloss.Color("245"))
 
 	title := titleStyle.Render("🐳 DockTUI")
-	version := versionStyle.Render("v0.1.0")
+	versionText := versionStyle.Render(version.Current())
 
 	// 右侧刷新时间
 	var rightPart string
-	if v.loading {
+	if v.isLoading() {
 		rightPart = versionStyle.Render("Loading...")
-	} else if !v.lastRefreshTime.IsZero() {
-		rightPart = versionStyle.Render("Refresh: " + v.lastRefreshTime.Format("15:04:05"))
+	} else if updated := v.refresh.Updated(); !updated.IsZero() {
+		rightPart = versionStyle.Render("Refresh: " + updated.Format("15:04:05"))
 	}
 
-	leftPart := title + " " + version
+	leftPart := title + " " + versionText
 	leftWidth := lipgloss.Width(leftPart)
 	rightWidth := lipgloss.Width(rightPart)
 
@@ -286,23 +450,18 @@ func (v *HomeView) renderConnectionStatus() string {
 		width = 80
 	}
 
-	var statusIcon, statusText string
-	var statusColor lipgloss.Color
-
+	status, statusText := styles.StatusError, "Docker Disconnected"
 	if v.dockerConnected {
-		statusIcon = "●"
-		statusText = "Docker Connected"
-		statusColor = lipgloss.Color("82")
-	} else {
-		statusIcon = "○"
-		statusText = "Docker Disconnected"
-		statusColor = lipgloss.Color("196")
+		status, statusText = styles.StatusRunning, "Docker Connected"
 	}
-
-	statusStyle := lipgloss.NewStyle().Foreground(statusColor)
+	statusIcon := styles.StatusGlyph(status)
+	statusStyle := styles.StatusStyle(status)
 	hostStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 
 	content := statusStyle.Render(statusIcon+" "+statusText) + "    " + hostStyle.Render(v.dockerHost)
+	if refresh := v.refresh.View(); refresh != "" {
+		content += "    " + refresh
+	}
 
 	// 居中
 	contentWidth := lipgloss.Width(content)
@@ -314,6 +473,35 @@ func (v *HomeView) renderConnectionStatus() string {
 	return strings.Repeat(" ", leftPadding) + content
 }
 
+// renderDiskUsage 渲染磁盘占用（加载中、失败和结果分别显示）
+func (v *HomeView) renderDiskUsage() string {
+	width := v.width
+	if width < 80 {
+		width = 80
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
+	content := labelStyle.Render("💾 Disk") + "  "
+	switch {
+	case v.diskLoading:
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Render(v.spinner())
+	case v.diskErr != "":
+		content += lipgloss.NewStyle().Foreground(ThemeError).Render(format.Truncate("✖ "+v.diskErr, width-20))
+	case v.disk != nil:
+		content += mutedStyle.Render(fmt.Sprintf("%s  (images %s · containers %s · volumes %s · build cache %s)",
+			format.Size(v.disk.Total()), format.Size(v.disk.Images), format.Size(v.disk.Containers),
+			format.Size(v.disk.Volumes), format.Size(v.disk.BuildCache)))
+	}
+
+	leftPadding := (width - lipgloss.Width(content)) / 2
+	if leftPadding < 2 {
+		leftPadding = 2
+	}
+	return strings.Repeat(" ", leftPadding) + content
+}
+
 // renderResourceCards 渲染资源卡片
 func (v *HomeView) renderResourceCards() string {
 	width := v.width
@@ -333,28 +521,39 @@ func (v *HomeView) renderResourceCards() string {
 		cardWidth = 24
 	}
 
-	var cards []string
-	for i, res := range v.resources {
-		isSelected := i == v.selectedResource
-		cards = append(cards, v.renderCardWithWidth(res, isSelected, i+1, cardWidth))
+	// 卡片较多时（含快捷操作卡片）分多行显示
+	perRow := (width - 4) / (cardWidth + 2)
+	if perRow < 1 {
+		perRow = 1
 	}
 
-	// 水平拼接卡片
-	cardsRow := v.joinCardsHorizontal(cards, "  ")
+	var rows []string
+	for start := 0; start < len(v.resources); start += perRow {
+		end := min(start+perRow, len(v.resources))
+		var cards []string
+		for i := start; i < end; i++ {
+			isSelected := i == v.selectedResource
+			cards = append(cards, v.renderCardWithWidth(v.resources[i], isSelected, i+1, cardWidth))
+		}
 
-	// 居中
-	cardsWidth := v.getFirstLineWidth(cardsRow)
-	leftPadding := (width - cardsWidth) / 2
-	if leftPadding < 2 {
-		leftPadding = 2
-	}
+		// 水平拼接卡片
+		cardsRow := v.joinCardsHorizontal(cards, "  ")
 
-	lines := strings.Split(cardsRow, "\n")
-	for i, line := range lines {
-		lines[i] = strings.Repeat(" ", leftPadding) + line
+		// 居中
+		cardsWidth := v.getFirstLineWidth(cardsRow)
+		leftPadding := (width - cardsWidth) / 2
+		if leftPadding < 2 {
+			leftPadding = 2
+		}
+
+		lines := strings.Split(cardsRow, "\n")
+		for i, line := range lines {
+			lines[i] = strings.Repeat(" ", leftPadding) + line
+		}
+		rows = append(rows, strings.Join(lines, "\n"))
 	}
 
-	return strings.Join(lines, "\n")
+	return strings.Join(rows, "\n")
 }
 
 // renderCardWithWidth 渲染指定宽度的卡片
@@ -397,8 +596,17 @@ func (v *HomeView) renderCardWithWidth(res ResourceInfo, selected bool, num int,
 	statsStyle := lipgloss.NewStyle().Width(contentWidth).Align(lipgloss.Center)
 
 	var stats string
-	if v.loading {
-		stats = lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("...")
+	if res.Action != nil {
+		// 快捷操作卡片显示操作目标
+		target := res.Action.Target()
+		if lipgloss.Width(target) > contentWidth {
+			target = format.Truncate(target, contentWidth)
+		}
+		stats = lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(target)
+	} else if res.Loading {
+		stats = lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Render(v.spinner())
+	} else if res.Err != "" {
+		stats = lipgloss.NewStyle().Foreground(ThemeError).Render(format.Truncate("✖ "+res.Err, contentWidth))
 	} else if !res.Available {
 		stats = lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Render("Unavailable")
 	} else {
@@ -417,6 +625,12 @@ func (v *HomeView) renderCardWithWidth(res ResourceInfo, selected bool, num int,
 	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 	keyHintStyle := lipgloss.NewStyle().Width(contentWidth).Align(lipgloss.Center)
 	keyHint := keyHintStyle.Render(keyStyle.Render(res.Key) + hintStyle.Render(" or "+fmt.Sprintf("%d", num)))
+	if res.Key == "" {
+		keyHint = keyHintStyle.Render(keyStyle.Render(fmt.Sprintf("%d", num)))
+		if num > 9 {
+			keyHint = keyHintStyle.Render(hintStyle.Render("Enter"))
+		}
+	}
 
 	content := lipgloss.JoinVertical(lipgloss.Center, title, stats, keyHint)
 	return cardStyle.Render(content)
@@ -438,12 +652,24 @@ func (v *HomeView) renderFooter() string {
 	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 	sepStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 
+	km := components.DefaultKeyMap()
 	keys := []struct{ key, desc string }{
 		{"←→", "Select"},
 		{"Enter", "Enter"},
 		{"r", "Refresh"},
-		{"?", "Help"},
-		{"q", "Exit"},
+		{"a", "Audit"},
+		{"t", "Tasks"},
+		{"f", "Fleet"},
+		{"R", "Recipes"},
+		{"S", "Stacks"},
+		{"s", "Services"},
+		{"T", "Troubleshoot"},
+		{"d", "Trash"},
+		{"e", "Events"},
+		{"A-J", "Jump"},
+		{"K-P", "Projects"},
+		{km.Help.Help().Key, "Help"},
+		{km.Quit.Help().Key, "Exit"},
 	}
 
 	var parts []string
@@ -519,12 +745,34 @@ func (v *HomeView) getFirstLineWidth(s string) int {
 	return 0
 }
 
+// SetSelfCheck 设置启动自检结果
+func (v *HomeView) SetSelfCheck(results []docker.Diagnostic) {
+	v.selfCheck = results
+}
+
 // SetSize 设置尺寸
 func (v *HomeView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
 }
 
+// SelectCard 选中指定位置的卡片，超出范围时忽略，返回是否选中
+func (v *HomeView) SelectCard(idx int) bool {
+	if idx < 0 || idx >= len(v.resources) {
+		return false
+	}
+	v.selectedResource = idx
+	return true
+}
+
+// GetSelectedAction 获取选中的快捷操作，选中的不是快捷操作卡片时返回 nil
+func (v *HomeView) GetSelectedAction() *launchpad.Action {
+	if v.selectedResource >= 0 && v.selectedResource < len(v.resources) {
+		return v.resources[v.selectedResource].Action
+	}
+	return nil
+}
+
 // GetSelectedResource 获取选中的资源类型
 func (v *HomeView) GetSelectedResource() ResourceType {
 	if v.selectedResource >= 0 && v.selectedResource < len(v.resources) {
@@ -541,61 +789,175 @@ func (v *HomeView) IsResourceAvailable() bool {
 	return false
 }
 
-// homeStatsLoadedMsg 统计数据加载完成消息
-type homeStatsLoadedMsg struct {
-	dockerConnected  bool
-	containerCount   int
-	runningCount     int
-	imageCount       int
-	networkCount     int
-	composeCount     int
-	composeRunning   int
-	composeAvailable bool
+// homeCardLoadedMsg 一张资源卡片的数量加载完成
+type homeCardLoadedMsg struct {
+	gen       int
+	resource  ResourceType
+	count     int
+	active    int
+	available bool
+	err       error
+	projects  []*compose.Project // Compose 卡片：发现的项目
 }
 
-// loadStats 加载统计数据
-func (v *HomeView) loadStats() tea.Msg {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// homeDiskLoadedMsg 磁盘占用加载完成
+type homeDiskLoadedMsg struct {
+	gen   int
+	usage *docker.DiskUsage
+	err   error
+}
+
+// homeSpinnerTickMsg 加载动画的下一帧
+type homeSpinnerTickMsg struct {
+	gen int
+}
+
+// homeRefreshTickMsg 自动刷新的倒计时
+type homeRefreshTickMsg struct {
+	gen int
+}
+
+// homeRefreshInterval 首页卡片的自动刷新间隔（配置文件 refresh.home）
+var homeRefreshInterval = 30 * time.Second
+
+// spinnerFrames 加载动画帧，ASCII 回退模式下使用 spinnerFramesASCII
+var (
+	spinnerFrames      = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	spinnerFramesASCII = []string{"|", "/", "-", "\\"}
+)
 
-	result := homeStatsLoadedMsg{
-		dockerConnected:  true,
-		composeAvailable: true,
+// homeLoadTimeout 单个卡片的加载超时
+const homeLoadTimeout = 10 * time.Second
+
+// isLoading 是否还有卡片或磁盘占用在加载
+func (v *HomeView) isLoading() bool {
+	if v.diskLoading {
+		return true
+	}
+	for _, res := range v.resources {
+		if res.Loading {
+			return true
+		}
 	}
+	return false
+}
 
-	// 容器统计
-	containers, err := v.dockerClient.ListContainers(ctx, true)
-	if err != nil {
-		result.dockerConnected = false
-	} else {
-		result.containerCount = len(containers)
+// spinner 返回当前的加载动画帧
+func (v *HomeView) spinner() string {
+	frames := spinnerFrames
+	if styles.ASCII() {
+		frames = spinnerFramesASCII
+	}
+	return frames[v.spinnerFrame%len(frames)]
+}
+
+// tickSpinner 触发下一帧加载动画
+func (v *HomeView) tickSpinner(gen int) tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return homeSpinnerTickMsg{gen: gen}
+	})
+}
+
+// tickRefresh 触发下一次自动刷新的倒计时
+func (v *HomeView) tickRefresh(gen int) tea.Cmd {
+	return tea.Tick(v.refresh.Step(), func(time.Time) tea.Msg {
+		return homeRefreshTickMsg{gen: gen}
+	})
+}
+
+// RefreshTick 处理自动刷新的倒计时；首页不可见时不重新加载，回到首页后到期的刷新立即执行
+func (v *HomeView) RefreshTick(msg homeRefreshTickMsg, visible bool) tea.Cmd {
+	if msg.gen != v.refreshGen {
+		return nil
+	}
+	if visible && v.refresh.Due() {
+		return tea.Batch(v.load(true), v.tickRefresh(msg.gen))
+	}
+	return v.tickRefresh(msg.gen)
+}
+
+// loadContainers 加载容器数量和运行中的数量
+func (v *HomeView) loadContainers(gen int) tea.Cmd {
+	client := v.dockerClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), homeLoadTimeout)
+		defer cancel()
+		msg := homeCardLoadedMsg{gen: gen, resource: ResourceContainers, available: true}
+		containers, err := client.ListContainers(ctx, true)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.count = len(containers)
 		for _, c := range containers {
 			if c.State == "running" {
-				result.runningCount++
+				msg.active++
 			}
 		}
+		return msg
 	}
+}
 
-	// 镜像统计
-	images, err := v.dockerClient.ListImages(ctx, true)
-	if err == nil {
-		result.imageCount = len(images)
+// loadImages 加载镜像数量
+func (v *HomeView) loadImages(gen int) tea.Cmd {
+	client := v.dockerClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), homeLoadTimeout)
+		defer cancel()
+		images, err := client.ListImages(ctx, true)
+		return homeCardLoadedMsg{gen: gen, resource: ResourceImages, count: len(images), available: true, err: err}
 	}
+}
 
-	// 网络统计
-	networks, err := v.dockerClient.ListNetworks(ctx)
-	if err == nil {
-		result.networkCount = len(networks)
+// loadNetworks 加载网络数量
+func (v *HomeView) loadNetworks(gen int) tea.Cmd {
+	client := v.dockerClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), homeLoadTimeout)
+		defer cancel()
+		networks, err := client.ListNetworks(ctx)
+		return homeCardLoadedMsg{gen: gen, resource: ResourceNetworks, count: len(networks), available: true, err: err}
 	}
+}
 
-	// Compose 统计
-	composeClient, err := compose.NewClient()
-	if err != nil {
-		result.composeAvailable = false
-	} else {
-		result.composeAvailable = true
-		_ = composeClient
+// loadCompose 加载 Compose 项目数量和运行中的项目数量，compose 命令不可用时卡片显示为不可用
+func (v *HomeView) loadCompose(gen int) tea.Cmd {
+	discovery := v.discovery
+	return func() tea.Msg {
+		msg := homeCardLoadedMsg{gen: gen, resource: ResourceCompose}
+		if _, err := compose.NewClient(); err != nil {
+			return msg
+		}
+		msg.available = true
+		if discovery == nil {
+			return msg
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), homeLoadTimeout)
+		defer cancel()
+		projects, err := discovery.DiscoverProjects(ctx)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.count = len(projects)
+		msg.projects = projects
+		for _, p := range projects {
+			if p.Status == compose.StatusRunning || p.Status == compose.StatusPartial {
+				msg.active++
+			}
+		}
+		return msg
 	}
+}
 
-	return result
+// loadDisk 加载磁盘占用
+func (v *HomeView) loadDisk(gen int) tea.Cmd {
+	client := v.dockerClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), homeLoadTimeout)
+		defer cancel()
+		usage, err := client.DiskUsage(ctx)
+		return homeDiskLoadedMsg{gen: gen, usage: usage, err: err}
+	}
 }
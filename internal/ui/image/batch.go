@@ -0,0 +1,123 @@
+package image
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+	"docktui/internal/task"
+)
+
+// imageBatch 多选镜像的批量打标签/推送：每个镜像一个后台任务，全部结束后汇总结果
+type imageBatch struct {
+	operation string // Tag 或 Push
+	total     int
+	pending   map[string]string // 未结束的任务 ID -> 目标
+	succeeded int
+	failures  []string // "目标: 错误"
+}
+
+// selectedImageList 按列表顺序返回选中的镜像
+func (v *ListView) selectedImageList() []docker.Image {
+	var images []docker.Image
+	for _, img := range v.filteredImages {
+		if v.selectedImages[img.ID] {
+			images = append(images, img)
+		}
+	}
+	return images
+}
+
+// batchTargets 按模板为选中的镜像生成目标，任何一个无效或两个镜像的目标相同时返回错误
+func (v *ListView) batchTargets(template string) ([]docker.Image, []string, error) {
+	images := v.selectedImageList()
+	if len(images) == 0 {
+		return nil, nil, fmt.Errorf("no images selected")
+	}
+	targets := make([]string, len(images))
+	sources := make(map[string]string, len(images))
+	for i, img := range images {
+		target, err := task.RenderImageTarget(template, img.Repository, img.Tag, img.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		source := img.Repository + ":" + img.Tag
+		if prev, dup := sources[target]; dup {
+			return nil, nil, fmt.Errorf("%s and %s would both become %s", prev, source, target)
+		}
+		sources[target] = source
+		targets[i] = target
+	}
+	return images, targets, nil
+}
+
+// startImageBatch 为每个选中的镜像提交一个打标签（Tag）或打标签并推送（Push）任务
+func (v *ListView) startImageBatch(operation, template string, deleteTag bool) error {
+	images, targets, err := v.batchTargets(template)
+	if err != nil {
+		return err
+	}
+	b := &imageBatch{operation: operation, total: len(images), pending: make(map[string]string, len(images))}
+	manager := task.GetManager()
+	for i, img := range images {
+		var t task.Task = task.NewTagTask(v.dockerClient, img.ID, targets[i])
+		if operation == "Push" {
+			t = task.NewRetagPushTask(v.dockerClient, img.ID, targets[i], deleteTag)
+		}
+		id := manager.Submit(t)
+		b.pending[id] = targets[i]
+		v.batches[id] = b
+	}
+
+	v.successMsg = fmt.Sprintf("🏷️ Tagging %d images...", len(images))
+	if operation == "Push" {
+		v.successMsg = fmt.Sprintf("📤 Pushing %d images...", len(images))
+	}
+	v.successMsgTime = time.Now()
+	v.selectedImages = make(map[string]bool)
+	v.updateTableData()
+	return nil
+}
+
+// finishBatchItem 记录批量任务中一个任务的结果，最后一个任务结束后显示汇总
+func (v *ListView) finishBatchItem(b *imageBatch, event task.Event) tea.Cmd {
+	target := b.pending[event.TaskID]
+	delete(b.pending, event.TaskID)
+	delete(v.batches, event.TaskID)
+	switch event.Type {
+	case task.EventCompleted:
+		b.succeeded++
+	case task.EventCancelled:
+		b.failures = append(b.failures, target+": cancelled")
+	default:
+		b.failures = append(b.failures, fmt.Sprintf("%s: %v", target, event.Error))
+	}
+	if len(b.pending) > 0 {
+		verb := "Tagging"
+		if b.operation == "Push" {
+			verb = "Pushing"
+		}
+		v.successMsg = fmt.Sprintf("⏳ %s images: %d/%d done", verb, b.total-len(b.pending), b.total)
+		v.successMsgTime = time.Now()
+		return nil
+	}
+
+	done := "Tagged"
+	if b.operation == "Push" {
+		done = "Pushed"
+	}
+	if len(b.failures) == 0 {
+		v.successMsg = fmt.Sprintf("✅ %s %d images", done, b.total)
+		v.successMsgTime = time.Now()
+		return tea.Batch(v.loadImages, v.clearSuccessMessageAfter(5*time.Second))
+	}
+	v.successMsg = ""
+	if v.errorDialog != nil {
+		v.errorDialog.ShowError(fmt.Sprintf("%s %d of %d images, %d failed:\n\n%s",
+			done, b.succeeded, b.total, len(b.failures), strings.Join(b.failures, "\n")))
+	}
+	return v.loadImages
+}
@@ -0,0 +1,357 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
+	"docktui/internal/format"
+)
+
+// BuildCacheLoadedMsg 构建缓存列表加载完成消息
+type BuildCacheLoadedMsg struct {
+	Entries []docker.BuildCacheEntry
+	Err     error
+}
+
+var buildCacheCursorStyle = lipgloss.NewStyle().Reverse(true)
+
+// BuildCacheView 构建缓存对话框（docker buildx du）：列出记录的大小和最近使用时间，选择后作为后台任务清理
+type BuildCacheView struct {
+	dockerClient docker.Client
+
+	entries  []docker.BuildCacheEntry
+	selected map[string]bool
+	cursor   int
+	scroll   int
+
+	loading    bool
+	confirming bool
+	visible    bool
+	width      int
+	height     int
+	errorMsg   string
+}
+
+// NewBuildCacheView 创建构建缓存对话框
+func NewBuildCacheView(dockerClient docker.Client) *BuildCacheView {
+	return &BuildCacheView{
+		dockerClient: dockerClient,
+		selected:     make(map[string]bool),
+	}
+}
+
+// Show 显示对话框并加载构建缓存
+func (v *BuildCacheView) Show() tea.Cmd {
+	v.visible = true
+	v.confirming = false
+	v.selected = make(map[string]bool)
+	v.cursor, v.scroll = 0, 0
+	return v.load()
+}
+
+// Hide 隐藏对话框
+func (v *BuildCacheView) Hide() {
+	v.visible = false
+	v.confirming = false
+	v.entries = nil
+}
+
+// IsVisible 是否可见
+func (v *BuildCacheView) IsVisible() bool {
+	return v.visible
+}
+
+// SetSize 设置尺寸
+func (v *BuildCacheView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// load 加载构建缓存列表
+func (v *BuildCacheView) load() tea.Cmd {
+	v.loading = true
+	v.errorMsg = ""
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		entries, err := v.dockerClient.ListBuildCache(ctx)
+		return BuildCacheLoadedMsg{Entries: entries, Err: err}
+	}
+}
+
+// SetEntries 设置加载结果，保留仍存在的记录的选择
+func (v *BuildCacheView) SetEntries(msg BuildCacheLoadedMsg) {
+	v.loading = false
+	if msg.Err != nil {
+		v.errorMsg = msg.Err.Error()
+		return
+	}
+	v.entries = msg.Entries
+	selected := make(map[string]bool)
+	for _, e := range v.entries {
+		if v.selected[e.ID] && e.Reclaimable() {
+			selected[e.ID] = true
+		}
+	}
+	v.selected = selected
+	if v.cursor >= len(v.entries) {
+		v.cursor = len(v.entries) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+	v.clampScroll()
+}
+
+// SelectedIDs 要清理的记录：已选择的记录，未选择时为光标所在的记录
+func (v *BuildCacheView) SelectedIDs() []string {
+	var ids []string
+	for _, e := range v.entries {
+		if v.selected[e.ID] {
+			ids = append(ids, e.ID)
+		}
+	}
+	if len(ids) == 0 && v.cursor < len(v.entries) && v.entries[v.cursor].Reclaimable() {
+		ids = append(ids, v.entries[v.cursor].ID)
+	}
+	return ids
+}
+
+// Update 处理输入
+// 返回值: (confirmed bool, handled bool, cmd tea.Cmd)，confirmed 表示确认清理 SelectedIDs
+func (v *BuildCacheView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
+	if !v.visible {
+		return false, false, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, false, nil
+	}
+
+	if v.confirming {
+		switch keyMsg.String() {
+		case "y", "Y":
+			v.confirming = false
+			return true, true, nil
+		case "n", "N", "esc", "q":
+			v.confirming = false
+		}
+		return false, true, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "q":
+		v.Hide()
+	case "r", "f5":
+		if !v.loading {
+			return false, true, v.load()
+		}
+	case "j", "down":
+		v.moveCursor(1)
+	case "k", "up":
+		v.moveCursor(-1)
+	case "pgdown", "ctrl+d":
+		v.moveCursor(v.maxRows())
+	case "pgup", "ctrl+u":
+		v.moveCursor(-v.maxRows())
+	case "g", "home":
+		v.moveCursor(-len(v.entries))
+	case "G", "end":
+		v.moveCursor(len(v.entries))
+	case " ":
+		if v.cursor < len(v.entries) {
+			e := v.entries[v.cursor]
+			if !e.Reclaimable() {
+				v.errorMsg = "Record is in use by a running build"
+			} else if v.selected[e.ID] {
+				delete(v.selected, e.ID)
+			} else {
+				v.selected[e.ID] = true
+			}
+			v.moveCursor(1)
+		}
+	case "a":
+		// 全选未使用的记录，已全选时清空
+		all := true
+		for _, e := range v.entries {
+			if e.Reclaimable() && !v.selected[e.ID] {
+				all = false
+				break
+			}
+		}
+		v.selected = make(map[string]bool)
+		if !all {
+			for _, e := range v.entries {
+				if e.Reclaimable() {
+					v.selected[e.ID] = true
+				}
+			}
+		}
+	case "d", "enter":
+		if v.loading {
+			break
+		}
+		if len(v.SelectedIDs()) == 0 {
+			v.errorMsg = "Nothing to prune: select records that are not in use"
+			break
+		}
+		v.errorMsg = ""
+		v.confirming = true
+	}
+	return false, true, nil
+}
+
+// moveCursor 移动光标并保持在可见范围内
+func (v *BuildCacheView) moveCursor(delta int) {
+	v.cursor += delta
+	if v.cursor >= len(v.entries) {
+		v.cursor = len(v.entries) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+	v.clampScroll()
+}
+
+// clampScroll 调整滚动位置使光标可见
+func (v *BuildCacheView) clampScroll() {
+	rows := v.maxRows()
+	if v.cursor < v.scroll {
+		v.scroll = v.cursor
+	}
+	if v.cursor >= v.scroll+rows {
+		v.scroll = v.cursor - rows + 1
+	}
+	if v.scroll < 0 {
+		v.scroll = 0
+	}
+}
+
+// maxRows 列表最多显示的行数
+func (v *BuildCacheView) maxRows() int {
+	rows := v.height - 18
+	if rows < 5 {
+		rows = 5
+	}
+	return rows
+}
+
+// selectedSize 选中记录的大小之和（共享的记录清理后不一定释放空间）
+func (v *BuildCacheView) selectedSize() int64 {
+	var size int64
+	for _, e := range v.entries {
+		if v.selected[e.ID] {
+			size += e.Size
+		}
+	}
+	return size
+}
+
+// View 渲染对话框
+func (v *BuildCacheView) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	var total, reclaimable int64
+	for _, e := range v.entries {
+		total += e.Size
+		if e.Reclaimable() {
+			reclaimable += e.Size
+		}
+	}
+	parts := []string{
+		pruneTitleStyle.Render(fmt.Sprintf("🧱 Build Cache: %d records, %s total, %s reclaimable", len(v.entries), format.Size(total), format.Size(reclaimable))),
+	}
+	if len(v.selected) > 0 {
+		parts = append(parts, pruneValueStyle.Render(fmt.Sprintf("Selected: %d records, %s", len(v.selected), format.Size(v.selectedSize()))))
+	} else {
+		parts = append(parts, pruneHintStyle.Render("Nothing selected (d prunes the record under the cursor)"))
+	}
+	parts = append(parts, "")
+
+	switch {
+	case v.loading:
+		parts = append(parts, pruneHintStyle.Render("⏳ Loading build cache..."))
+	case len(v.entries) == 0 && v.errorMsg == "":
+		parts = append(parts, pruneHintStyle.Render("No build cache records"))
+	case len(v.entries) > 0:
+		parts = append(parts, v.renderRows()...)
+	}
+
+	if v.errorMsg != "" {
+		parts = append(parts, "", pruneErrorStyle.Render("❌ "+v.errorMsg))
+	}
+
+	if v.confirming {
+		ids := v.SelectedIDs()
+		parts = append(parts, "",
+			pruneErrorStyle.Render(fmt.Sprintf("Prune %d build cache records? This cannot be undone.", len(ids))),
+			pruneHintStyle.Render("[y=Prune] [n/Esc=Cancel]"))
+	} else {
+		parts = append(parts, "", pruneHintStyle.Render("[↑↓=Move] [Space=Select] [a=All unused] [d=Prune] [r=Refresh] [Esc=Close]"))
+	}
+
+	boxWidth := v.width - 10
+	if boxWidth < 80 {
+		boxWidth = 80
+	}
+	if boxWidth > 120 {
+		boxWidth = 120
+	}
+	return pruneBoxStyle.Width(boxWidth).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+// renderRows 渲染记录列表
+func (v *BuildCacheView) renderRows() []string {
+	descWidth := v.width - 10 - 4 - 70
+	if descWidth < 10 {
+		descWidth = 10
+	}
+	if descWidth > 46 {
+		descWidth = 46
+	}
+
+	rows := []string{pruneHintStyle.Render(fmt.Sprintf("     %-12s  %-16s %9s  %-10s %5s  %-7s %s", "ID", "TYPE", "SIZE", "LAST USED", "USES", "FLAGS", "DESCRIPTION"))}
+	end := v.scroll + v.maxRows()
+	if end > len(v.entries) {
+		end = len(v.entries)
+	}
+	for i := v.scroll; i < end; i++ {
+		e := v.entries[i]
+		mark := "[ ]"
+		if v.selected[e.ID] {
+			mark = "[x]"
+		}
+		lastUsed := "never"
+		if !e.LastUsedAt.IsZero() {
+			lastUsed = format.Ago(e.LastUsedAt)
+		}
+		flags := ""
+		switch {
+		case e.InUse:
+			flags = "in use"
+		case e.Shared:
+			flags = "shared"
+		}
+		line := fmt.Sprintf(" %s %-12s  %-16s %9s  %-10s %5d  %-7s %s",
+			mark, e.ShortID(), format.Truncate(e.Type, 16), format.Size(e.Size), lastUsed, e.UsageCount, flags,
+			format.Truncate(e.Description, descWidth))
+		switch {
+		case i == v.cursor:
+			line = buildCacheCursorStyle.Render(line)
+		case e.InUse:
+			line = pruneHintStyle.Render(line)
+		}
+		rows = append(rows, line)
+	}
+	if len(v.entries) > v.maxRows() {
+		rows = append(rows, pruneHintStyle.Render(fmt.Sprintf("  %d-%d of %d records", v.scroll+1, end, len(v.entries))))
+	}
+	return rows
+}
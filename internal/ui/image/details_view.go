@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/notes"
 	"docktui/internal/ui/components"
+	"docktui/internal/ui/styles"
 )
 
 // DetailsTab 镜像详情标签页类型
@@ -22,9 +26,10 @@ const (
 	TabEnvVars
 	TabHistory
 	TabLabels
+	TabProvenance
 )
 
-var tabNames = []string{"Basic Info", "Usage", "Config", "Env Vars", "History", "Labels"}
+var tabNames = []string{"Basic Info", "Usage", "Config", "Env Vars", "History", "Labels", "Provenance"}
 
 // DetailsView 镜像详情视图
 type DetailsView struct {
@@ -36,6 +41,12 @@ type DetailsView struct {
 	scrollOffset, maxScroll int
 	loading bool
 	errorMsg string
+
+	// 仓库摘要比对（切换到 Provenance 标签时才查询）
+	registryChecked bool
+	registryLoading bool
+	registryDigest string
+	registryErr error
 }
 
 // NewDetailsView 创建镜像详情视图
@@ -61,6 +72,11 @@ func (v *DetailsView) Update(msg tea.Msg) (*DetailsView, tea.Cmd) {
 		v.loading = false
 		v.errorMsg = msg.Err.Error()
 		return v, nil
+	case ImageRegistryDigestMsg:
+		v.registryLoading = false
+		v.registryDigest = msg.Digest
+		v.registryErr = msg.Err
+		return v, nil
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc": return v, func() tea.Msg { return GoBackMsg{} }
@@ -80,11 +96,35 @@ func (v *DetailsView) Update(msg tea.Msg) (*DetailsView, tea.Cmd) {
 		case "4": v.activeTab = TabEnvVars; v.scrollOffset = 0
 		case "5": v.activeTab = TabHistory; v.scrollOffset = 0
 		case "6": v.activeTab = TabLabels; v.scrollOffset = 0
+		case "7": v.activeTab = TabProvenance; v.scrollOffset = 0
+		case "r": if v.activeTab == TabProvenance { v.registryChecked = false }
 		}
+		if v.activeTab == TabProvenance && !v.registryChecked { return v, v.checkRegistryDigest() }
 	}
 	return v, nil
 }
 
+// registryRef 用于查询仓库的镜像引用，没有标签时返回空字符串
+func (v *DetailsView) registryRef() string {
+	if v.details == nil || v.details.Repository == "<none>" || v.details.Tag == "<none>" { return "" }
+	return v.details.Repository + ":" + v.details.Tag
+}
+
+// checkRegistryDigest 查询仓库中标签当前指向的摘要
+func (v *DetailsView) checkRegistryDigest() tea.Cmd {
+	ref := v.registryRef()
+	if ref == "" { return nil }
+	v.registryChecked = true
+	v.registryLoading = true
+	v.registryDigest, v.registryErr = "", nil
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		digest, err := v.dockerClient.ImageRegistryDigest(ctx, ref)
+		return ImageRegistryDigestMsg{Ref: ref, Digest: digest, Err: err}
+	}
+}
+
 // View 渲染视图
 func (v *DetailsView) View() string {
 	var s strings.Builder
@@ -127,6 +167,7 @@ func (v *DetailsView) renderCurrentTab() string {
 	case TabEnvVars: return v.renderEnvVars()
 	case TabHistory: return v.renderHistory()
 	case TabLabels: return v.renderLabels()
+	case TabProvenance: return v.renderProvenance()
 	default: return ""
 	}
 }
@@ -142,8 +183,8 @@ func (v *DetailsView) renderBasicInfo() string {
 			digest := v.details.Digest; if len(digest) > 50 { digest = digest[:47] + "..." }
 			lines = append(lines, v.formatLine("DIGEST", digest))
 		}
-		lines = append(lines, v.formatLine("SIZE", FormatSize(v.details.Size)))
-		lines = append(lines, v.formatLine("CREATED", v.details.Created.Format("2006-01-02 15:04:05")+" ("+FormatCreatedTime(v.details.Created)+")"))
+		lines = append(lines, v.formatLine("SIZE", format.Size(v.details.Size)))
+		lines = append(lines, v.formatLine("CREATED", v.details.Created.Format("2006-01-02 15:04:05")+" ("+format.Ago(v.details.Created)+")"))
 		lines = append(lines, v.formatLine("ARCHITECTURE", v.details.Architecture))
 		lines = append(lines, v.formatLine("OS", v.details.OS))
 		if v.details.Author != "" { lines = append(lines, v.formatLine("AUTHOR", v.details.Author)) }
@@ -151,31 +192,34 @@ func (v *DetailsView) renderBasicInfo() string {
 		lines = append(lines, v.formatLine("IMAGE ID", v.image.ID))
 		lines = append(lines, v.formatLine("REPOSITORY", v.image.Repository))
 		lines = append(lines, v.formatLine("TAG", v.image.Tag))
-		lines = append(lines, v.formatLine("SIZE", FormatSize(v.image.Size)))
+		lines = append(lines, v.formatLine("SIZE", format.Size(v.image.Size)))
 		lines = append(lines, v.formatLine("CREATED", v.image.Created.Format("2006-01-02 15:04:05")))
 	}
 	boxWidth := v.width - 6; if boxWidth < 60 { boxWidth = 60 }
-	return "\n" + v.wrapInBox("Basic Information", strings.Join(lines, "\n"), boxWidth)
+	content := "\n" + v.wrapInBox("Basic Information", strings.Join(lines, "\n"), boxWidth)
+	// 本地备注（在镜像列表按 N 编辑）
+	if note := notes.Lookup(notes.KindImage, v.image.ID); !note.Empty() {
+		var noteLines []string
+		if note.Color != "" { noteLines = append(noteLines, v.formatLine("TAG", notes.ColorBadge(note.Color)+" "+note.Color)) }
+		if note.Text != "" { noteLines = append(noteLines, v.formatLine("NOTE", note.Text)) }
+		noteLines = append(noteLines, v.formatLine("UPDATED", note.UpdatedAt.Format("2006-01-02 15:04")))
+		content += "\n\n" + v.wrapInBox("📝 Notes", strings.Join(noteLines, "\n"), boxWidth)
+	}
+	return content
 }
 
 func (v *DetailsView) renderUsage() string {
 	var lines []string
 	if v.image != nil {
-		if v.image.InUse { lines = append(lines, v.formatLine("STATUS", "🟢 In Use")) } else if v.image.Dangling { lines = append(lines, v.formatLine("STATUS", "🟡 Dangling (no tag)")) } else { lines = append(lines, v.formatLine("STATUS", "🔴 Unused")) }
+		if v.image.InUse { lines = append(lines, v.formatLine("STATUS", styles.RenderStatus(styles.StatusRunning, "In Use"))) } else if v.image.Dangling { lines = append(lines, v.formatLine("STATUS", styles.RenderStatus(styles.StatusWarning, "Dangling (no tag)"))) } else { lines = append(lines, v.formatLine("STATUS", styles.RenderStatus(styles.StatusStopped, "Unused"))) }
 	}
 	if v.details != nil && len(v.details.Containers) > 0 {
 		lines = append(lines, "", DetailsLabelStyle.Render("CONTAINERS:")+" ("+fmt.Sprintf("%d", len(v.details.Containers))+")")
 		for i, containerRef := range v.details.Containers {
 			if i >= 10 { lines = append(lines, "  "+DetailsHintStyle.Render(fmt.Sprintf("... and %d more", len(v.details.Containers)-10))); break }
 			shortID := containerRef.ID; if len(shortID) > 12 { shortID = shortID[:12] }
-			var stateStyle lipgloss.Style; var stateIcon string
-			switch containerRef.State {
-			case "running": stateStyle = ContainerRunningStyle; stateIcon = "🟢"
-			case "exited": stateStyle = ContainerStoppedStyle; stateIcon = "🔴"
-			case "paused": stateStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220")); stateIcon = "🟡"
-			default: stateStyle = DetailsHintStyle; stateIcon = "⚪"
-			}
-			containerInfo := fmt.Sprintf("%s (%s) %s %s", DetailsKeyStyle.Render(shortID), DetailsValueStyle.Render(containerRef.Name), stateIcon, stateStyle.Render(containerRef.State))
+			state := styles.ContainerStatus(containerRef.State, "")
+			containerInfo := fmt.Sprintf("%s (%s) %s", DetailsKeyStyle.Render(shortID), DetailsValueStyle.Render(containerRef.Name), styles.RenderStatus(state, containerRef.State))
 			lines = append(lines, "  • "+containerInfo)
 		}
 	} else if v.image != nil && len(v.image.Containers) > 0 {
@@ -240,13 +284,13 @@ func (v *DetailsView) renderHistory() string {
 		if len(idStr) > 12 && idStr != "<missing>" {
 			if strings.HasPrefix(idStr, "sha256:") { idStr = idStr[7:19] } else { idStr = idStr[:12] }
 		}
-		createdStr := FormatCreatedTime(h.Created)
+		createdStr := format.Ago(h.Created)
 		cmdStr := h.CreatedBy
 		cmdStr = strings.TrimPrefix(cmdStr, "/bin/sh -c ")
 		cmdStr = strings.TrimPrefix(cmdStr, "#(nop) ")
 		maxCmdLen := v.width - 20; if maxCmdLen < 40 { maxCmdLen = 40 }
 		if len(cmdStr) > maxCmdLen { cmdStr = cmdStr[:maxCmdLen-3] + "..." }
-		sizeStr := "0B"; if h.Size > 0 { sizeStr = FormatSize(h.Size) }
+		sizeStr := "0B"; if h.Size > 0 { sizeStr = format.Size(h.Size) }
 		line1 := fmt.Sprintf("  %s  %s  %s", DetailsKeyStyle.Render(idStr), DetailsHintStyle.Render(createdStr), DetailsValueStyle.Render(sizeStr))
 		line2 := "    " + DetailsValueStyle.Render(cmdStr)
 		lines = append(lines, line1, line2)
@@ -287,13 +331,58 @@ func (v *DetailsView) renderLabels() string {
 	return "\n" + v.wrapInBox(fmt.Sprintf("Labels (%d)", labelCount), strings.Join(lines, "\n"), boxWidth)
 }
 
+func (v *DetailsView) renderProvenance() string {
+	if v.details == nil { return "\n  " + DetailsHintStyle.Render("No image info") }
+	boxWidth := v.width - 6; if boxWidth < 60 { boxWidth = 60 }
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+	// 仓库摘要及与仓库的比对
+	var digestLines []string
+	if len(v.details.RepoDigests) == 0 {
+		digestLines = append(digestLines, DetailsHintStyle.Render("No repo digests (built or loaded locally, never pulled/pushed)"))
+	}
+	for _, rd := range v.details.RepoDigests { digestLines = append(digestLines, "  "+DetailsValueStyle.Render(rd)) }
+	digestLines = append(digestLines, "")
+	ref := v.registryRef()
+	switch {
+	case ref == "":
+		digestLines = append(digestLines, DetailsHintStyle.Render("Untagged image, nothing to compare with the registry"))
+	case v.registryLoading:
+		digestLines = append(digestLines, DetailsHintStyle.Render("⏳ Checking "+ref+" in registry..."))
+	case v.registryErr != nil:
+		digestLines = append(digestLines, errStyle.Render("❌ Registry check failed: "+v.registryErr.Error()))
+	case v.registryDigest == "":
+	case v.details.MatchesDigest(v.registryDigest):
+		digestLines = append(digestLines, okStyle.Render("✅ "+ref+" matches the registry"))
+		digestLines = append(digestLines, v.formatLine("REGISTRY", v.registryDigest))
+	default:
+		digestLines = append(digestLines, warnStyle.Render("⚠️  "+ref+" differs from the registry (outdated or rebuilt locally)"))
+		digestLines = append(digestLines, v.formatLine("REGISTRY", v.registryDigest))
+	}
+
+	// 来源信息
+	var sourceLines []string
+	sourceLines = append(sourceLines, v.formatLine("BUILDER", v.details.Builder()))
+	provenance := v.details.Provenance()
+	for _, f := range provenance { sourceLines = append(sourceLines, v.formatLine(strings.ToUpper(f.Name), f.Value)) }
+	if len(provenance) == 0 {
+		sourceLines = append(sourceLines, "", DetailsHintStyle.Render("No org.opencontainers.image.* or label-schema labels"))
+	}
+
+	return "\n" + v.wrapInBox(fmt.Sprintf("Repo Digests (%d)", len(v.details.RepoDigests)), strings.Join(digestLines, "\n"), boxWidth) +
+		"\n\n" + v.wrapInBox("Source", strings.Join(sourceLines, "\n"), boxWidth)
+}
+
 func (v *DetailsView) renderHints() string {
 	hints := []string{
 		DetailsKeyStyle.Render("<Tab/←/→>") + " Switch tabs",
-		DetailsKeyStyle.Render("<1-6>") + " Quick jump",
+		DetailsKeyStyle.Render("<1-7>") + " Quick jump",
 		DetailsKeyStyle.Render("<j/k>") + " Scroll",
 		DetailsKeyStyle.Render("<Esc>") + " Back",
 	}
+	if v.activeTab == TabProvenance { hints = append(hints[:3], DetailsKeyStyle.Render("<r>")+" Re-check registry", hints[3]) }
 	return "  " + DetailsHintStyle.Render(strings.Join(hints, "  │  "))
 }
 
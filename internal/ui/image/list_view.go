@@ -11,8 +11,13 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"docktui/internal/docker"
+	"docktui/internal/favorites"
+	"docktui/internal/fleet"
+	"docktui/internal/format"
+	"docktui/internal/notes"
 	"docktui/internal/task"
 	"docktui/internal/ui/components"
+	"docktui/internal/ui/styles"
 )
 
 // ListView 镜像列表视图
@@ -35,14 +40,27 @@ type ListView struct {
 	confirmImage *docker.Image
 	confirmSelection int
 	confirmPullRef string
+	confirmBlockers []docker.ContainerRef // 阻止删除的容器（强制删除确认框中列出）
 	keys components.KeyMap
 	pullInput *components.PullInputView
+	importInput *components.PullInputView
 	taskBar *components.TaskBar
 	tagInput *components.TagInputView
+	pushInput *components.PushInputView
 	errorDialog *components.ErrorDialog
 	jsonViewer *components.JSONViewer
 	selectedImages map[string]bool
 	exportInput *components.ExportInputView
+	pruneView *PruneView
+	buildCacheView *BuildCacheView
+	usedByView     *UsedByView
+	listExport *components.ListExportView
+	noteInput *components.NoteInputView
+	batches map[string]*imageBatch // 批量打标签/推送的任务 ID -> 所属批次
+	transferHosts []fleet.Host // 镜像传输的目标主机
+	transferPicker *components.ResourcePicker
+	transferShown bool
+	transferImages []task.TransferImageInfo // 等待选择目标主机的镜像
 }
 
 // NewListView 创建镜像列表视图
@@ -51,6 +69,7 @@ func NewListView(dockerClient docker.Client) *ListView {
 		{Title: "IMAGE ID", Width: 14},
 		{Title: "REPOSITORY", Width: 30},
 		{Title: "TAG", Width: 20},
+		{Title: "PLATFORM", Width: 14},
 		{Title: "SIZE", Width: 12},
 		{Title: "CREATED", Width: 14},
 	}
@@ -64,6 +83,7 @@ func NewListView(dockerClient docker.Client) *ListView {
 		{Title: "IMAGE ID", Width: 14},
 		{Title: "REPOSITORY", Width: 35},
 		{Title: "TAG", Width: 25},
+		{Title: "PLATFORM", Width: 14},
 		{Title: "SIZE", Width: 12},
 		{Title: "CREATED", Width: 16},
 	}
@@ -75,12 +95,21 @@ func NewListView(dockerClient docker.Client) *ListView {
 		filterType: "all",
 		sortBy: "created",
 		pullInput: components.NewPullInputView(),
+		importInput: newImportInput(),
 		taskBar: components.NewTaskBar(),
 		tagInput: components.NewTagInputView(),
+		pushInput: components.NewPushInputView(),
 		errorDialog: components.NewErrorDialog(),
 		jsonViewer: components.NewJSONViewer(),
 		selectedImages: make(map[string]bool),
+		batches: make(map[string]*imageBatch),
 		exportInput: components.NewExportInputView(),
+		pruneView: NewPruneView(),
+		buildCacheView: NewBuildCacheView(dockerClient),
+		usedByView:     NewUsedByView(dockerClient),
+		listExport: components.NewListExportView(),
+		noteInput: components.NewNoteInputView(),
+		transferPicker: components.NewResourcePicker("🚚 Transfer Images", false),
 	}
 }
 
@@ -107,14 +136,30 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 			if v.jsonViewer.Update(keyMsg) { return v, nil }
 		}
 	}
+	// 后台任务选择对话框可见时，按键只交给对话框
+	if v.taskBar.IsPickerVisible() {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			if notice := v.taskBar.UpdatePicker(keyMsg); notice != "" {
+				v.successMsg = notice
+				v.successMsgTime = time.Now()
+				return v, v.clearSuccessMessageAfter(2*time.Second)
+			}
+			return v, nil
+		}
+	}
 	switch msg := msg.(type) {
 	case ImagesLoadedMsg:
+		// 重新加载后找回之前选中的镜像（同一 ID 的多个标签按 repo:tag 区分）
+		selected := v.GetSelectedImage()
+		var prev docker.Image
+		if selected != nil { prev = *selected }
 		v.images = msg.Images
 		v.loading = false
 		v.errorMsg = ""
 		v.lastRefreshTime = time.Now()
 		v.applyFilters()
 		v.updateColumnWidths()
+		if selected != nil { v.restoreCursor(prev) }
 		return v, nil
 	case ImagesLoadErrorMsg:
 		v.loading = false
@@ -131,7 +176,7 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 		v.successMsg = ""
 		return v, nil
 	case ImageInUseErrorMsg:
-		v.showForceRemoveConfirmDialog(msg.Image)
+		v.showForceRemoveConfirmDialog(msg.Image, msg.Containers)
 		return v, nil
 	case ClearSuccessMessageMsg:
 		if time.Since(v.successMsgTime) >= 3*time.Second { v.successMsg = "" }
@@ -145,6 +190,23 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 	case ImageInspectErrorMsg:
 		if v.errorDialog != nil { v.errorDialog.ShowError(fmt.Sprintf("Failed to get image info: %v", msg.Err)) }
 		return v, nil
+	case components.ListExportResultMsg:
+		if msg.Err != nil { v.errorDialog.ShowError(fmt.Sprintf("Export failed: %v", msg.Err)); return v, nil }
+		v.successMsg = fmt.Sprintf("✅ Exported %d images to %s", msg.Rows, msg.Path)
+		v.successMsgTime = time.Now()
+		return v, v.clearSuccessMessageAfter(3*time.Second)
+	case PrunePreviewMsg:
+		if v.pruneView.IsVisible() { v.pruneView.SetCandidates(msg.Candidates) }
+		return v, nil
+	case PrunePreviewErrorMsg:
+		if v.pruneView.IsVisible() { v.pruneView.SetError(msg.Err.Error()) }
+		return v, nil
+	case BuildCacheLoadedMsg:
+		if v.buildCacheView.IsVisible() { v.buildCacheView.SetEntries(msg) }
+		return v, nil
+	case UsedByLoadedMsg:
+		if v.usedByView.IsVisible() { v.usedByView.SetContainers(msg) }
+		return v, nil
 	case ImageExportSuccessMsg:
 		v.successMsg = fmt.Sprintf("✅ Exported %d images to %s", msg.Count, msg.Dir)
 		v.successMsgTime = time.Now()
@@ -171,6 +233,9 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 
 func (v *ListView) handleTaskEvent(msg components.TaskEventMsg) (*ListView, tea.Cmd) {
 	event := msg.Event
+	if b := v.batches[event.TaskID]; b != nil && (event.Type == task.EventCompleted || event.Type == task.EventFailed || event.Type == task.EventCancelled) {
+		return v, tea.Batch(v.finishBatchItem(b, event), v.taskBar.ListenForEvents())
+	}
 	switch event.Type {
 	case task.EventCompleted:
 		v.successMsg = fmt.Sprintf("✅ %s", event.Message)
@@ -204,8 +269,28 @@ func (v *ListView) handleKeyMsg(msg tea.KeyMsg) (*ListView, tea.Cmd) {
 		}
 		if handled { return v, cmd }
 	}
+	if v.importInput.IsVisible() {
+		confirmed, handled, cmd := v.importInput.Update(msg)
+		if confirmed {
+			path := v.importInput.Value()
+			v.importInput.Hide()
+			v.startImportTask(path)
+			return v, tea.Batch(v.taskBar.ListenForEvents(), v.scheduleTaskTick())
+		}
+		if handled { return v, cmd }
+	}
+	if v.noteInput.IsVisible() {
+		confirmed, handled, cmd := v.noteInput.Update(msg)
+		if confirmed { return v, v.saveNote() }
+		if handled { return v, cmd }
+	}
 	if v.tagInput.IsVisible() {
 		confirmed, handled, cmd := v.tagInput.Update(msg)
+		if confirmed && v.tagInput.IsBatch() {
+			if err := v.startImageBatch("Tag", v.tagInput.Template(), false); err != nil { v.tagInput.SetError(err.Error()); return v, nil }
+			v.tagInput.Hide()
+			return v, tea.Batch(v.taskBar.ListenForEvents(), v.scheduleTaskTick())
+		}
 		if confirmed {
 			repo, tag := v.tagInput.GetValues()
 			sourceImageID := v.tagInput.SourceImageID
@@ -214,6 +299,51 @@ func (v *ListView) handleKeyMsg(msg tea.KeyMsg) (*ListView, tea.Cmd) {
 		}
 		if handled { return v, cmd }
 	}
+	if v.pushInput.IsVisible() {
+		confirmed, handled, cmd := v.pushInput.Update(msg)
+		if confirmed && v.pushInput.IsBatch() {
+			_, deleteTag := v.pushInput.GetValues()
+			if err := v.startImageBatch("Push", v.pushInput.Template(), deleteTag); err != nil { v.pushInput.SetError(err.Error()); return v, nil }
+			v.pushInput.Hide()
+			return v, tea.Batch(v.taskBar.ListenForEvents(), v.scheduleTaskTick())
+		}
+		if confirmed {
+			targetRef, deleteTag := v.pushInput.GetValues()
+			sourceImageID := v.pushInput.SourceImageID
+			v.pushInput.Hide()
+			v.startPushTask(sourceImageID, targetRef, deleteTag)
+			return v, tea.Batch(v.taskBar.ListenForEvents(), v.scheduleTaskTick())
+		}
+		if handled { return v, cmd }
+	}
+	if v.listExport.IsVisible() {
+		confirmed, handled, cmd := v.listExport.Update(msg)
+		if confirmed {
+			path, format := v.listExport.GetValues()
+			v.listExport.Hide()
+			columns, rows := imageExportRows(v.filteredImages)
+			return v, components.ListExportCmd(path, format, columns, rows)
+		}
+		if handled { return v, cmd }
+	}
+	if v.pruneView.IsVisible() {
+		confirmed, handled, cmd := v.pruneView.Update(msg)
+		if confirmed { return v, v.handlePruneConfirm() }
+		if handled { return v, cmd }
+	}
+	if v.buildCacheView.IsVisible() {
+		confirmed, handled, cmd := v.buildCacheView.Update(msg)
+		if confirmed {
+			v.startBuildCachePrune(v.buildCacheView.SelectedIDs())
+			v.buildCacheView.Hide()
+			return v, tea.Batch(v.taskBar.ListenForEvents(), v.scheduleTaskTick())
+		}
+		if handled { return v, cmd }
+	}
+	if v.usedByView.IsVisible() {
+		if handled, cmd := v.usedByView.Update(msg); handled { return v, cmd }
+	}
+	if v.transferShown { return v.handleTransferPickerKey(msg) }
 	if v.showConfirmDialog { return v.handleConfirmDialogKey(msg) }
 	if v.isSearching { return v.handleSearchKey(msg) }
 	return v.handleNormalKey(msg)
@@ -234,6 +364,13 @@ func (v *ListView) handleConfirmDialogKey(msg tea.KeyMsg) (*ListView, tea.Cmd) {
 			v.confirmSelection = 1 - v.confirmSelection
 			return v, nil
 		}
+		// 强制删除确认框中查看阻止删除的容器
+		if msg.String() == "u" && v.confirmAction == "force_remove" && v.confirmImage != nil && len(v.confirmBlockers) > 0 {
+			image := v.confirmImage
+			v.resetConfirmDialog()
+			v.usedByView.SetSize(v.width, v.height)
+			return v, v.usedByView.Show(image)
+		}
 	}
 	if msg.String() == "enter" { return v.executeConfirmAction() }
 	return v, nil
@@ -257,7 +394,6 @@ func (v *ListView) executeConfirmAction() (*ListView, tea.Cmd) {
 			v.successMsgTime = time.Now()
 			return v, v.removeBatchImages(true)
 		}
-		if action == "prune" { return v, v.pruneImages() }
 		if action == "pull" && pullRef != "" {
 			v.startPullTaskSync(pullRef)
 			return v, v.taskBar.ListenForEvents()
@@ -271,6 +407,7 @@ func (v *ListView) resetConfirmDialog() {
 	v.confirmAction = ""
 	v.confirmImage = nil
 	v.confirmPullRef = ""
+	v.confirmBlockers = nil
 	v.confirmSelection = 0
 }
 
@@ -312,12 +449,29 @@ func (v *ListView) handleNormalKey(msg tea.KeyMsg) (*ListView, tea.Cmd) {
 		image := v.GetSelectedImage()
 		if image == nil { return v, nil }
 		return v, func() tea.Msg { return ViewImageDetailsMsg{Image: image} }
-	case "d": return v, v.showRemoveConfirmDialog()
-	case "p": return v, v.showPruneConfirmDialog()
-	case "P": v.pullInput.SetWidth(v.width); v.pullInput.Show()
+	case "d": return v, components.RunAction("image.remove", v.showRemoveConfirmDialog)
+	case "p": return v, components.RunAction("image.prune", func() tea.Cmd { v.pruneView.SetWidth(v.width); v.pruneView.Show(); return nil })
+	case "B": v.buildCacheView.SetSize(v.width, v.height); return v, v.buildCacheView.Show()
+	case "P": return v, components.RunAction("image.pull", func() tea.Cmd { v.pullInput.SetWidth(v.width); v.pullInput.Show(); return nil })
+	case "I": return v, components.RunAction("image.load", func() tea.Cmd { v.importInput.SetWidth(v.width); v.importInput.Show(); return nil })
 	case "T": v.taskBar.Toggle()
-	case "t": return v, v.showTagInput()
+	case "t": return v, components.RunAction("image.tag", v.showTagInput)
+	case "U": return v, components.RunAction("image.push", v.showPushInput)
 	case "i": return v, v.inspectImage()
+	case "u":
+		if image := v.GetSelectedImage(); image != nil { v.usedByView.SetSize(v.width, v.height); return v, v.usedByView.Show(image) }
+	case "v":
+		if image := v.GetSelectedImage(); image != nil {
+			name := ""
+			if !image.Dangling { name = image.Repository + ":" + image.Tag }
+			return v, func() tea.Msg { return ViewEventsMsg{ImageID: image.ID, ImageName: name} }
+		}
+	case "N":
+		if image := v.GetSelectedImage(); image != nil {
+			v.noteInput.SetWidth(v.width)
+			v.noteInput.Show(notes.KindImage, image.ID, image.Repository+":"+image.Tag)
+		}
+	case "*": return v, v.toggleFavorite()
 	case " ":
 		image := v.GetSelectedImage()
 		if image != nil {
@@ -330,13 +484,9 @@ func (v *ListView) handleNormalKey(msg tea.KeyMsg) (*ListView, tea.Cmd) {
 		if allSelected && len(v.filteredImages) > 0 { v.selectedImages = make(map[string]bool) } else { for _, img := range v.filteredImages { v.selectedImages[img.ID] = true } }
 		v.updateTableData()
 	case "E": return v, v.showExportDialog()
-	case "x":
-		if v.taskBar.HasActiveTasks() {
-			v.taskBar.CancelFirstTask()
-			v.successMsg = "⏹️ Cancelling task..."
-			v.successMsgTime = time.Now()
-			return v, v.clearSuccessMessageAfter(2*time.Second)
-		}
+	case "H": return v, components.RunAction("image.transfer", v.showTransferPicker)
+	case "w": v.listExport.SetWidth(v.width); v.listExport.Show("images", len(v.filteredImages))
+	case "x": v.taskBar.SetWidth(v.width); v.taskBar.ShowPicker()
 	}
 	return v, nil
 }
@@ -379,8 +529,17 @@ func (v *ListView) View() string {
 	}
 	if v.taskBar.HasActiveTasks() { v.taskBar.SetWidth(v.width); s += v.taskBar.View() }
 	if v.pullInput.IsVisible() { s = v.overlayPullInput(s) }
+	if v.importInput.IsVisible() { s = components.OverlayCentered(s, v.importInput.View(), v.width, v.height) }
 	if v.tagInput.IsVisible() { s = v.overlayTagInput(s) }
+	if v.noteInput.IsVisible() { s = components.OverlayCentered(s, v.noteInput.View(), v.width, v.height) }
+	if v.pushInput.IsVisible() { s = components.OverlayCentered(s, v.pushInput.View(), v.width, v.height) }
 	if v.showConfirmDialog { s = v.overlayDialog(s) }
+	if v.taskBar.IsPickerVisible() { s = components.OverlayCentered(s, v.taskBar.PickerView(), v.width, v.height) }
+	if v.pruneView.IsVisible() { s = components.OverlayCentered(s, v.pruneView.View(), v.width, v.height) }
+	if v.buildCacheView.IsVisible() { s = components.OverlayCentered(s, v.buildCacheView.View(), v.width, v.height) }
+	if v.usedByView.IsVisible() { s = components.OverlayCentered(s, v.usedByView.View(), v.width, v.height) }
+	if v.listExport.IsVisible() { s = components.OverlayCentered(s, v.listExport.View(), v.width, v.height) }
+	if v.transferShown { s = components.OverlayCentered(s, v.transferPicker.View(), v.width, v.height) }
 	if v.errorDialog != nil && v.errorDialog.IsVisible() { s = v.errorDialog.Overlay(s) }
 	if v.exportInput != nil && v.exportInput.IsVisible() { s = v.overlayExportInput(s) }
 	return s
@@ -393,7 +552,14 @@ func (v *ListView) SetSize(width, height int) {
 	v.tableModel.SetHeight(tableHeight)
 	if v.scrollTable != nil { v.scrollTable.SetSize(width-4, tableHeight) }
 	v.pullInput.SetWidth(width)
+	v.importInput.SetWidth(width)
 	v.taskBar.SetWidth(width)
+	v.pruneView.SetWidth(width)
+	v.buildCacheView.SetSize(width, height)
+	v.usedByView.SetSize(width, height)
+	v.listExport.SetWidth(width)
+	v.noteInput.SetWidth(width)
+	v.transferPicker.SetSize(width, height)
 	if v.errorDialog != nil { v.errorDialog.SetWidth(width) }
 }
 
@@ -412,10 +578,10 @@ func (v *ListView) renderStatusBar() string {
 	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("82")).Bold(true)
 	makeItem := func(key, desc string) string { return itemStyle.Render(keyStyle.Render(key) + descStyle.Render(" "+desc)) }
 	var lines []string
-	lines = append(lines, "  "+labelStyle.Render("🖼️ Images")+makeItem("<f>", "Filter")+makeItem("</>", "Search")+makeItem("<r>", "Refresh"))
-	lines = append(lines, "  "+labelStyle.Render("Ops:")+makeItem("<d>", "Delete")+makeItem("<p>", "Prune")+makeItem("<P>", "Pull"))
-	lines = append(lines, "  "+labelStyle.Render("Advanced:")+makeItem("<t>", "Tag")+makeItem("<Space>", "Select")+makeItem("<a>", "All")+makeItem("<E>", "Export"))
-	refreshInfo := "-"; if !v.lastRefreshTime.IsZero() { refreshInfo = FormatDuration(time.Since(v.lastRefreshTime)) + " ago" }
+	lines = append(lines, "  "+labelStyle.Render("🖼️ Images")+makeItem("<f>", "Filter")+makeItem("</>", "Search")+makeItem("<r>", "Refresh")+makeItem("<w>", "Export list"))
+	lines = append(lines, "  "+labelStyle.Render("Ops:")+makeItem("<d>", "Delete")+makeItem("<p>", "Prune")+makeItem("<B>", "Build cache")+makeItem("<P>", "Pull")+makeItem("<u>", "Used by")+makeItem("<v>", "Events"))
+	lines = append(lines, "  "+labelStyle.Render("Advanced:")+makeItem("<t>", "Tag")+makeItem("<U>", "Push")+makeItem("<Space>", "Select")+makeItem("<a>", "All")+makeItem("<E>", "Export")+makeItem("<I>", "Import")+makeItem("<H>", "Transfer")+makeItem("<N>", "Note")+makeItem("<*>", "Star"))
+	refreshInfo := "-"; if !v.lastRefreshTime.IsZero() { refreshInfo = format.Duration(time.Since(v.lastRefreshTime)) + " ago" }
 	row4Info := hintStyle.Render(refreshInfo) + "    " + hintStyle.Render("j/k=Up/Down  Enter=Details  Esc=Back  q=Quit")
 	if len(v.selectedImages) > 0 { row4Info += "    " + selectedStyle.Render(fmt.Sprintf("[Selected: %d]", len(v.selectedImages))) }
 	lines = append(lines, "  "+labelStyle.Render("Last Refresh:")+row4Info)
@@ -432,12 +598,12 @@ func (v *ListView) renderStatsBar() string {
 		if !img.InUse && !img.Dangling { unusedCount++ }
 	}
 	totalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
-	activeStyleColor := lipgloss.NewStyle().Foreground(lipgloss.Color("82")).Bold(true)
-	danglingStyleColor := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
-	unusedStyleColor := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	activeStyleColor := styles.StatusStyle(styles.StatusRunning).Bold(true)
+	danglingStyleColor := styles.StatusStyle(styles.StatusWarning).Bold(true)
+	unusedStyleColor := styles.StatusStyle(styles.StatusStopped)
 	separatorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	statsContent := totalStyle.Render(fmt.Sprintf("📦 Total: %d", totalCount)) + separatorStyle.Render("  │  ") + activeStyleColor.Render(fmt.Sprintf("🟢 Active: %d", activeCount)) + separatorStyle.Render("  │  ") + danglingStyleColor.Render(fmt.Sprintf("🟡 Dangling: %d", danglingCount)) + separatorStyle.Render("  │  ") + unusedStyleColor.Render(fmt.Sprintf("🔴 Unused: %d", unusedCount))
+	statsContent := totalStyle.Render(fmt.Sprintf("📦 Total: %d", totalCount)) + separatorStyle.Render("  │  ") + activeStyleColor.Render(styles.StatusLabel(styles.StatusRunning, fmt.Sprintf("Active: %d", activeCount))) + separatorStyle.Render("  │  ") + danglingStyleColor.Render(styles.StatusLabel(styles.StatusWarning, fmt.Sprintf("Dangling: %d", danglingCount))) + separatorStyle.Render("  │  ") + unusedStyleColor.Render(styles.StatusLabel(styles.StatusStopped, fmt.Sprintf("Unused: %d", unusedCount)))
 	if showingCount != totalCount || (!v.isSearching && v.searchQuery != "") {
 		filterParts := []string{}
 		if showingCount != totalCount { filterParts = append(filterParts, fmt.Sprintf("Showing: %d", showingCount)) }
@@ -472,6 +638,8 @@ func (v *ListView) applyFilters() {
 		}
 		v.filteredImages = append(v.filteredImages, img)
 	}
+	// 在 ID、仓库、标签列中高亮搜索关键字
+	if v.scrollTable != nil { v.scrollTable.SetHighlight(v.searchQuery, 1, 2, 3) }
 }
 
 func (v *ListView) updateTableData() {
@@ -481,27 +649,28 @@ func (v *ListView) updateTableData() {
 	unusedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
 	for i, img := range v.filteredImages {
-		created := FormatCreatedTime(img.Created)
-		size := FormatSize(img.Size)
+		created := format.Ago(img.Created)
+		size := format.Size(img.Size)
 		selMark := " "; if v.selectedImages[img.ID] { selMark = selectedStyle.Render("✓") }
 		var rowStyle lipgloss.Style; var needsStyle bool
 		if img.Dangling { rowStyle = danglingStyle; needsStyle = true } else if !img.InUse { rowStyle = unusedStyle; needsStyle = true }
 		if needsStyle {
-			rows[i] = components.TableRow{selMark, rowStyle.Render(img.ShortID), rowStyle.Render(img.Repository), rowStyle.Render(img.Tag), rowStyle.Render(size), rowStyle.Render(created)}
+			rows[i] = components.TableRow{selMark, rowStyle.Render(img.ShortID), rowStyle.Render(repositoryWithBadge(img)), rowStyle.Render(img.Tag), platformCell(img, rowStyle), rowStyle.Render(size), rowStyle.Render(created)}
 		} else {
-			rows[i] = components.TableRow{selMark, img.ShortID, img.Repository, img.Tag, size, created}
+			rows[i] = components.TableRow{selMark, img.ShortID, repositoryWithBadge(img), img.Tag, platformCell(img, lipgloss.NewStyle()), size, created}
 		}
 	}
 	v.scrollTable.SetRows(rows)
 }
 
 func (v *ListView) updateColumnWidths() {
-	maxID, maxRepository, maxTag, maxSize, maxCreated := 12, 10, 3, 4, 7
+	maxID, maxRepository, maxTag, maxPlatform, maxSize, maxCreated := 12, 10, 3, 8, 4, 7
 	for _, img := range v.filteredImages {
-		if len(img.Repository) > maxRepository { maxRepository = len(img.Repository) }
+		if n := lipgloss.Width(repositoryWithBadge(img)); n > maxRepository { maxRepository = n }
 		if len(img.Tag) > maxTag { maxTag = len(img.Tag) }
-		sizeStr := FormatSize(img.Size); if len(sizeStr) > maxSize { maxSize = len(sizeStr) }
-		created := FormatCreatedTime(img.Created); if len(created) > maxCreated { maxCreated = len(created) }
+		if n := lipgloss.Width(platformText(img)); n > maxPlatform { maxPlatform = n }
+		sizeStr := format.Size(img.Size); if len(sizeStr) > maxSize { maxSize = len(sizeStr) }
+		created := format.Ago(img.Created); if len(created) > maxCreated { maxCreated = len(created) }
 	}
 	if v.scrollTable != nil {
 		v.scrollTable.SetColumns([]components.TableColumn{
@@ -509,6 +678,7 @@ func (v *ListView) updateColumnWidths() {
 			{Title: "IMAGE ID", Width: maxID + 2},
 			{Title: "REPOSITORY", Width: maxRepository + 2},
 			{Title: "TAG", Width: maxTag + 2},
+			{Title: "PLATFORM", Width: maxPlatform + 2},
 			{Title: "SIZE", Width: maxSize + 2},
 			{Title: "CREATED", Width: maxCreated + 2},
 		})
@@ -525,14 +695,14 @@ func (v *ListView) imagesToRows(images []docker.Image) []table.Row {
 	danglingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
 	unusedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 	for i, img := range images {
-		created := FormatCreatedTime(img.Created)
-		size := FormatSize(img.Size)
+		created := format.Ago(img.Created)
+		size := format.Size(img.Size)
 		var rowStyle lipgloss.Style; var needsStyle bool
 		if img.Dangling { rowStyle = danglingStyle; needsStyle = true } else if !img.InUse { rowStyle = unusedStyle; needsStyle = true }
 		if needsStyle {
-			rows[i] = table.Row{rowStyle.Render(img.ShortID), rowStyle.Render(img.Repository), rowStyle.Render(img.Tag), rowStyle.Render(size), rowStyle.Render(created)}
+			rows[i] = table.Row{rowStyle.Render(img.ShortID), rowStyle.Render(repositoryWithBadge(img)), rowStyle.Render(img.Tag), platformCell(img, rowStyle), rowStyle.Render(size), rowStyle.Render(created)}
 		} else {
-			rows[i] = table.Row{img.ShortID, img.Repository, img.Tag, size, created}
+			rows[i] = table.Row{img.ShortID, repositoryWithBadge(img), img.Tag, platformCell(img, lipgloss.NewStyle()), size, created}
 		}
 	}
 	return rows
@@ -547,6 +717,19 @@ func (v *ListView) GetSelectedImage() *docker.Image {
 	return &v.filteredImages[selectedIndex]
 }
 
+// restoreCursor 把光标移到与 prev 相同的镜像上：优先匹配 ID 和 repo:tag，其次只匹配 ID；都找不到时保持原来的行号
+func (v *ListView) restoreCursor(prev docker.Image) {
+	idx := -1
+	for i, img := range v.filteredImages {
+		if img.ID != prev.ID { continue }
+		if img.Repository == prev.Repository && img.Tag == prev.Tag { idx = i; break }
+		if idx < 0 { idx = i }
+	}
+	if idx < 0 { return }
+	if v.scrollTable != nil { v.scrollTable.SetCursor(idx) }
+	v.tableModel.SetCursor(idx)
+}
+
 func (v *ListView) inspectImage() tea.Cmd {
 	image := v.GetSelectedImage()
 	if image == nil { return nil }
@@ -594,13 +777,18 @@ func (v *ListView) renderConfirmDialogContent() string {
 	} else if v.confirmAction == "force_remove" && v.confirmImage != nil {
 		imageName := v.confirmImage.Repository + ":" + v.confirmImage.Tag; if len(imageName) > 35 { imageName = imageName[:32] + "..." }
 		title = titleStyle.Render("⚠️  Force Delete Image: " + imageName)
-		warning = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render("⚠️  Cannot delete normally!\n") + warningStyle.Render("Possible reasons:\n• Image has multiple tags (same ID, different names)\n• Image is referenced by stopped containers\n\n💡 Force delete will remove all related tags.\nAre you sure?")
+		if len(v.confirmBlockers) > 0 {
+			warning = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render("⚠️  Image is used by:\n") + renderBlockers(v.confirmBlockers) + "\n\n" + warningStyle.Render("💡 Force delete removes the tags; containers keep the\nuntagged image. Running containers still block deletion.\nPress u to open the containers. Are you sure?")
+		} else {
+			warning = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render("⚠️  Cannot delete normally!\n") + warningStyle.Render("Possible reasons:\n• Image has multiple tags (same ID, different names)\n• Image is referenced by stopped containers\n\n💡 Force delete will remove all related tags.\nAre you sure?")
+		}
 	} else if v.confirmAction == "force_remove_batch" {
 		title = titleStyle.Render(fmt.Sprintf("⚠️  Force Delete %d Images", len(v.selectedImages)))
-		warning = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render("⚠️  Some images cannot be deleted normally!\n") + warningStyle.Render("Possible reasons:\n• Images have multiple tags (same ID, different names)\n• Images are referenced by stopped containers\n\n💡 Force delete will remove all related tags.\nAre you sure?")
-	} else if v.confirmAction == "prune" {
-		title = titleStyle.Render("⚠️  Prune Dangling Images")
-		warning = warningStyle.Render("Will delete all untagged dangling images to free disk space")
+		if len(v.confirmBlockers) > 0 {
+			warning = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render("⚠️  Some images are used by:\n") + renderBlockers(v.confirmBlockers) + "\n\n" + warningStyle.Render("💡 Force delete removes the tags; containers keep the\nuntagged images. Running containers still block deletion.\nAre you sure?")
+		} else {
+			warning = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render("⚠️  Some images cannot be deleted normally!\n") + warningStyle.Render("Possible reasons:\n• Images have multiple tags (same ID, different names)\n• Images are referenced by stopped containers\n\n💡 Force delete will remove all related tags.\nAre you sure?")
+		}
 	} else if v.confirmAction == "pull" && v.confirmPullRef != "" {
 		imageName := v.confirmPullRef; if len(imageName) > 35 { imageName = imageName[:32] + "..." }
 		title = titleStyle.Render("📥  Pull Image: " + imageName)
@@ -635,7 +823,20 @@ func (v *ListView) showRemoveConfirmDialog() tea.Cmd {
 	return nil
 }
 
-func (v *ListView) showForceRemoveConfirmDialog(image *docker.Image) {
+// renderBlockers 列出阻止删除镜像的容器（最多 5 个）
+func renderBlockers(containers []docker.ContainerRef) string {
+	const limit = 5
+	var lines []string
+	for i, c := range containers {
+		if i == limit { lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(fmt.Sprintf("  ... and %d more", len(containers)-limit))); break }
+		state := styles.ContainerStatus(c.State, "")
+		lines = append(lines, "  • "+format.Truncate(c.Name, 30)+" "+styles.RenderStatus(state, c.State))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (v *ListView) showForceRemoveConfirmDialog(image *docker.Image, blockers []docker.ContainerRef) {
+	v.confirmBlockers = blockers
 	// 如果是批量删除时遇到的错误，显示批量强制删除确认框
 	if len(v.selectedImages) > 0 {
 		v.showConfirmDialog = true
@@ -650,9 +851,26 @@ func (v *ListView) showForceRemoveConfirmDialog(image *docker.Image) {
 	}
 }
 
-func (v *ListView) showPruneConfirmDialog() tea.Cmd {
-	v.showConfirmDialog = true; v.confirmAction = "prune"; v.confirmImage = nil; v.confirmSelection = 0
-	return nil
+// handlePruneConfirm 处理清理对话框的确认：选项阶段加载预览，预览阶段执行清理
+func (v *ListView) handlePruneConfirm() tea.Cmd {
+	opts, err := v.pruneView.GetOptions()
+	if err != nil { v.pruneView.SetError(err.Error()); return nil }
+	if !v.pruneView.IsPreviewing() {
+		v.pruneView.SetLoading()
+		return v.previewPrune(opts)
+	}
+	v.pruneView.Hide()
+	return v.pruneImages(opts)
+}
+
+func (v *ListView) previewPrune(opts docker.ImagePruneOptions) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		candidates, err := v.dockerClient.PruneImagesPreview(ctx, opts)
+		if err != nil { return PrunePreviewErrorMsg{Err: err} }
+		return PrunePreviewMsg{Candidates: candidates}
+	}
 }
 
 func (v *ListView) removeImage(image *docker.Image, force bool) tea.Cmd {
@@ -666,7 +884,9 @@ func (v *ListView) removeImage(image *docker.Image, force bool) tea.Cmd {
 			if strings.Contains(errStr, "image is being used by") || 
 			   strings.Contains(errStr, "is using") ||
 			   strings.Contains(errStr, "referenced in multiple repositories") {
-				return ImageInUseErrorMsg{Image: image, Err: err}
+				// 查询阻止删除的容器，在确认框中列出（查询失败时仍显示通用原因）
+				containers, _ := v.dockerClient.ImageUsedBy(ctx, image.ID)
+				return ImageInUseErrorMsg{Image: image, Containers: containers, Err: err}
 			}
 			return ImageOperationErrorMsg{Operation: "Delete image", Image: image.Repository + ":" + image.Tag, Err: err}
 		}
@@ -721,7 +941,12 @@ func (v *ListView) removeBatchImages(force bool) tea.Cmd {
 		// 如果有镜像正在使用且不是强制删除，提示用户
 		if len(inUseImages) > 0 && !force {
 			// 只提示第一个正在使用的镜像
-			return ImageInUseErrorMsg{Image: inUseImages[0], Err: fmt.Errorf("image is being used by containers")}
+			var containers []docker.ContainerRef
+			for _, img := range inUseImages {
+				refs, _ := v.dockerClient.ImageUsedBy(ctx, img.ID)
+				containers = append(containers, refs...)
+			}
+			return ImageInUseErrorMsg{Image: inUseImages[0], Containers: containers, Err: fmt.Errorf("image is being used by containers")}
 		}
 		
 		// 删除完成后清空选择（通过消息通知）
@@ -741,13 +966,13 @@ func (v *ListView) removeBatchImages(force bool) tea.Cmd {
 	}
 }
 
-func (v *ListView) pruneImages() tea.Cmd {
+func (v *ListView) pruneImages(opts docker.ImagePruneOptions) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 		defer cancel()
-		count, spaceReclaimed, err := v.dockerClient.PruneImages(ctx)
-		if err != nil { return ImageOperationErrorMsg{Operation: "Prune dangling images", Image: "", Err: err} }
-		return ImageOperationSuccessMsg{Operation: "Prune dangling images", Image: fmt.Sprintf("Deleted %d images, freed %s space", count, FormatSize(spaceReclaimed))}
+		count, spaceReclaimed, err := v.dockerClient.PruneImages(ctx, opts)
+		if err != nil { return ImageOperationErrorMsg{Operation: "Prune images", Image: "", Err: err} }
+		return ImageOperationSuccessMsg{Operation: "Prune images", Image: fmt.Sprintf("Deleted %d images, freed %s space", count, format.Size(spaceReclaimed))}
 	}
 }
 
@@ -767,7 +992,63 @@ func (v *ListView) startPullTaskSync(imageRef string) {
 	v.successMsgTime = time.Now()
 }
 
+// repositoryWithBadge 仓库名加上备注标记
+func repositoryWithBadge(img docker.Image) string {
+	return img.Repository + favorites.Badge(favorites.KindImage, img.ID) + notes.Lookup(notes.KindImage, img.ID).Badge()
+}
+
+// platformText 镜像平台列的文本，仿真运行的镜像带警告标记
+func platformText(img docker.Image) string {
+	platform := img.Platform.String()
+	if platform == "" { return "-" }
+	if img.Emulated { return "⚠ " + platform + " (emulated)" }
+	return platform
+}
+
+// platformCell 渲染平台列：与守护进程架构不同的镜像标红，其余沿用行样式
+func platformCell(img docker.Image, rowStyle lipgloss.Style) string {
+	if img.Emulated { return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(platformText(img)) }
+	return rowStyle.Render(platformText(img))
+}
+
+// saveNote 保存备注对话框中的备注并刷新列表标记
+func (v *ListView) saveNote() tea.Cmd {
+	kind, key, name := v.noteInput.Target()
+	note := v.noteInput.Note()
+	v.noteInput.Hide()
+	if err := notes.Save(kind, key, note); err != nil {
+		return func() tea.Msg { return ImageOperationErrorMsg{Operation: "Save note", Image: name, Err: err} }
+	}
+	v.updateColumnWidths()
+	v.successMsg = "📝 Note saved for " + name
+	if note.Empty() { v.successMsg = "📝 Note removed from " + name }
+	v.successMsgTime = time.Now()
+	return v.clearSuccessMessageAfter(3 * time.Second)
+}
+
+// ShowPruneView 打开清理对话框（首页快捷操作使用）
+func (v *ListView) ShowPruneView() { v.pruneView.SetWidth(v.width); v.pruneView.Show() }
+
+// toggleFavorite 收藏/取消收藏选中的镜像（按 ID，重新打标签后仍然保留）
+func (v *ListView) toggleFavorite() tea.Cmd {
+	image := v.GetSelectedImage()
+	if image == nil { return nil }
+	name := image.Repository + ":" + image.Tag
+	starred, err := favorites.Toggle(favorites.Ref{Kind: favorites.KindImage, Key: image.ID, Name: name})
+	if err != nil { return func() tea.Msg { return ImageOperationErrorMsg{Operation: "Favorite", Image: name, Err: err} } }
+	v.updateColumnWidths()
+	v.successMsg = "★ Added to favorites: " + name
+	if !starred { v.successMsg = "☆ Removed from favorites: " + name }
+	v.successMsgTime = time.Now()
+	return v.clearSuccessMessageAfter(3 * time.Second)
+}
+
 func (v *ListView) showTagInput() tea.Cmd {
+	if selected := v.selectedImageList(); len(selected) > 0 {
+		v.tagInput.SetWidth(v.width)
+		v.tagInput.ShowBatch(len(selected), selected[0].Repository, selected[0].Tag, selected[0].ID)
+		return nil
+	}
 	image := v.GetSelectedImage()
 	if image == nil { return func() tea.Msg { return ImageOperationErrorMsg{Operation: "Tag", Image: "", Err: fmt.Errorf("please select an image first")} } }
 	v.tagInput.SetWidth(v.width)
@@ -775,6 +1056,26 @@ func (v *ListView) showTagInput() tea.Cmd {
 	return nil
 }
 
+func (v *ListView) showPushInput() tea.Cmd {
+	if selected := v.selectedImageList(); len(selected) > 0 {
+		v.pushInput.SetWidth(v.width)
+		v.pushInput.ShowBatch(len(selected), selected[0].Repository, selected[0].Tag, selected[0].ID)
+		return nil
+	}
+	image := v.GetSelectedImage()
+	if image == nil { return func() tea.Msg { return ImageOperationErrorMsg{Operation: "Push", Image: "", Err: fmt.Errorf("please select an image first")} } }
+	v.pushInput.SetWidth(v.width)
+	v.pushInput.Show(image.Repository+":"+image.Tag, image.ID, image.Repository, image.Tag)
+	return nil
+}
+
+func (v *ListView) startPushTask(sourceImageID, targetRef string, deleteTag bool) {
+	pushTask := task.NewRetagPushTask(v.dockerClient, sourceImageID, targetRef, deleteTag)
+	task.GetManager().Submit(pushTask)
+	v.successMsg = fmt.Sprintf("📤 Start pushing: %s", targetRef)
+	v.successMsgTime = time.Now()
+}
+
 func (v *ListView) tagImage(sourceImageID, repository, tag string) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -790,11 +1091,11 @@ func (v *ListView) showExportDialog() tea.Cmd {
 	var images []components.ExportImageInfo
 	if len(v.selectedImages) > 0 {
 		for _, img := range v.filteredImages {
-			if v.selectedImages[img.ID] { images = append(images, components.ExportImageInfo{ID: img.ID, Repository: img.Repository, Tag: img.Tag}) }
+			if v.selectedImages[img.ID] { images = append(images, components.ExportImageInfo{ID: img.ID, Repository: img.Repository, Tag: img.Tag, Digest: img.Digest}) }
 		}
 	} else {
 		img := v.GetSelectedImage()
-		if img != nil { images = append(images, components.ExportImageInfo{ID: img.ID, Repository: img.Repository, Tag: img.Tag}) }
+		if img != nil { images = append(images, components.ExportImageInfo{ID: img.ID, Repository: img.Repository, Tag: img.Tag, Digest: img.Digest}) }
 	}
 	if len(images) == 0 {
 		v.successMsg = "⚠️ Please select images to export first"
@@ -812,7 +1113,7 @@ func (v *ListView) showExportDialog() tea.Cmd {
 
 func (v *ListView) startExportTask(images []components.ExportImageInfo, dir string, mode components.ExportMode, compress bool) {
 	taskImages := make([]task.ExportImageInfo, len(images))
-	for i, img := range images { taskImages[i] = task.ExportImageInfo{ID: img.ID, Repository: img.Repository, Tag: img.Tag} }
+	for i, img := range images { taskImages[i] = task.ExportImageInfo{ID: img.ID, Repository: img.Repository, Tag: img.Tag, Digest: img.Digest} }
 	taskMode := task.ExportModeSingle; if mode == components.ExportModeMultiple { taskMode = task.ExportModeMultiple }
 	exportTask := task.NewExportTask(v.dockerClient, taskImages, dir, taskMode, compress)
 	manager := task.GetManager()
@@ -823,12 +1124,57 @@ func (v *ListView) startExportTask(images []components.ExportImageInfo, dir stri
 	v.updateTableData()
 }
 
+// newImportInput 创建导入清单路径输入框
+func newImportInput() *components.PullInputView {
+	input := components.NewPullInputView()
+	input.SetPrompt("📦 Import From Manifest", "Manifest or dir: ", "./exports/"+task.ManifestFileName)
+	return input
+}
+
+// startImportTask 提交按清单导入镜像的任务
+func (v *ListView) startImportTask(path string) {
+	importTask := task.NewImportTask(v.dockerClient, path)
+	task.GetManager().Submit(importTask)
+	v.successMsg = fmt.Sprintf("📦 Start importing images from %s", path)
+	v.successMsgTime = time.Now()
+}
+
+// startBuildCachePrune 提交构建缓存清理任务
+func (v *ListView) startBuildCachePrune(ids []string) {
+	task.GetManager().Submit(task.NewBuildCachePruneTask(v.dockerClient, ids))
+	v.successMsg = fmt.Sprintf("🧱 Start pruning %d build cache records", len(ids))
+	v.successMsgTime = time.Now()
+}
+
+// IsImportInputVisible 返回导入清单输入框是否可见
+func (v *ListView) IsImportInputVisible() bool {
+	return v.importInput != nil && v.importInput.IsVisible()
+}
+
 // HasError 返回是否有错误弹窗显示
 func (v *ListView) HasError() bool { return v.errorDialog != nil && v.errorDialog.IsVisible() }
 
 // IsShowingJSONViewer 返回是否正在显示 JSON 查看器
 func (v *ListView) IsShowingJSONViewer() bool { return v.jsonViewer != nil && v.jsonViewer.IsVisible() }
 
+// SetFilter 预设过滤和搜索关键字（用于命令行 --filter/--search），数据加载后生效
+func (v *ListView) SetFilter(filterType, query string) error {
+	switch filterType {
+	case "":
+		filterType = "all"
+	case "all", "active", "dangling", "unused":
+	default:
+		return fmt.Errorf("unknown image filter %q (available: all, active, dangling, unused)", filterType)
+	}
+	v.filterType = filterType
+	v.searchQuery = query
+	v.applyFilters()
+	return nil
+}
+
+// IsSearching 返回是否处于搜索模式
+func (v *ListView) IsSearching() bool { return v.isSearching }
+
 // GetSelectedCount 获取选中的镜像数量
 func (v *ListView) GetSelectedCount() int { return len(v.selectedImages) }
 
@@ -849,7 +1195,52 @@ func (v *ListView) IsPullInputVisible() bool {
 	return v.pullInput != nil && v.pullInput.IsVisible()
 }
 
+// IsNoteInputVisible 返回备注对话框是否可见
+func (v *ListView) IsNoteInputVisible() bool {
+	return v.noteInput != nil && v.noteInput.IsVisible()
+}
+
 // IsTagInputVisible 返回打标签输入框是否可见
 func (v *ListView) IsTagInputVisible() bool {
 	return v.tagInput != nil && v.tagInput.IsVisible()
 }
+
+// IsPushInputVisible 返回推送对话框是否可见
+func (v *ListView) IsPushInputVisible() bool {
+	return v.pushInput != nil && v.pushInput.IsVisible()
+}
+
+// IsPruneViewVisible 返回清理对话框是否可见
+func (v *ListView) IsPruneViewVisible() bool {
+	return v.pruneView != nil && v.pruneView.IsVisible()
+}
+
+// IsUsedByViewVisible 返回"被谁使用"对话框是否可见
+func (v *ListView) IsUsedByViewVisible() bool {
+	return v.usedByView != nil && v.usedByView.IsVisible()
+}
+
+// IsBuildCacheViewVisible 返回构建缓存对话框是否可见
+func (v *ListView) IsBuildCacheViewVisible() bool {
+	return v.buildCacheView != nil && v.buildCacheView.IsVisible()
+}
+
+// IsListExportVisible 返回列表导出对话框是否可见
+func (v *ListView) IsListExportVisible() bool {
+	return v.listExport != nil && v.listExport.IsVisible()
+}
+
+// IsTaskPickerVisible 返回后台任务选择对话框是否可见
+func (v *ListView) IsTaskPickerVisible() bool {
+	return v.taskBar.IsPickerVisible()
+}
+
+// imageExportRows 将镜像列表映射为导出的列和行
+func imageExportRows(images []docker.Image) ([]string, [][]any) {
+	columns := []string{"ID", "Repository", "Tag", "Digest", "Platform", "Emulated", "Size", "Created", "InUse", "Dangling"}
+	rows := make([][]any, 0, len(images))
+	for _, img := range images {
+		rows = append(rows, []any{img.ID, img.Repository, img.Tag, img.Digest, img.Platform.String(), img.Emulated, img.Size, img.Created.Format(time.RFC3339), img.InUse, img.Dangling})
+	}
+	return columns, rows
+}
@@ -27,8 +27,9 @@ type ImageOperationErrorMsg struct {
 
 // ImageInUseErrorMsg 镜像被容器引用错误消息
 type ImageInUseErrorMsg struct {
-	Image *docker.Image
-	Err   error
+	Image      *docker.Image
+	Containers []docker.ContainerRef // 阻止删除的容器（镜像只是有多个标签时为空）
+	Err        error
 }
 
 // ImageInspectMsg 镜像检查消息
@@ -61,11 +62,28 @@ type ImageExportProgressMsg struct {
 	Name    string
 }
 
+// PrunePreviewMsg 清理预览结果消息
+type PrunePreviewMsg struct {
+	Candidates []docker.Image
+}
+
+// PrunePreviewErrorMsg 清理预览错误消息
+type PrunePreviewErrorMsg struct {
+	Err error
+}
+
 // ImageDetailsLoadedMsg 镜像详情加载完成消息
 type ImageDetailsLoadedMsg struct {
 	Details *docker.ImageDetails
 }
 
+// ImageRegistryDigestMsg 仓库摘要查询结果
+type ImageRegistryDigestMsg struct {
+	Ref    string
+	Digest string
+	Err    error
+}
+
 // ImageDetailsLoadErrorMsg 镜像详情加载错误消息
 type ImageDetailsLoadErrorMsg struct {
 	Err error
@@ -82,5 +100,23 @@ type ViewImageDetailsMsg struct {
 	Image *docker.Image
 }
 
+// GoToContainerDetailMsg 跳转到容器详情的消息
+type GoToContainerDetailMsg struct {
+	ContainerID   string
+	ContainerName string
+}
+
+// ViewEventsMsg 请求切换到事件视图并聚焦该镜像（包括由它创建的容器）
+type ViewEventsMsg struct {
+	ImageID   string
+	ImageName string
+}
+
+// GoToContainerLogsMsg 跳转到容器日志的消息
+type GoToContainerLogsMsg struct {
+	ContainerID   string
+	ContainerName string
+}
+
 // GoBackMsg 返回上一级消息
 type GoBackMsg struct{}
@@ -0,0 +1,496 @@
+package image
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
+	"docktui/internal/format"
+)
+
+// 清理对话框样式
+var (
+	pruneBoxStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("220")).
+			Padding(1, 2)
+
+	pruneTitleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("220")).
+			Bold(true)
+
+	pruneLabelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("252")).
+			Width(12)
+
+	pruneHintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245"))
+
+	pruneValueStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("81"))
+
+	pruneSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("81")).
+				Bold(true)
+
+	pruneErrorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+)
+
+// pruneMaxPreviewRows 预览列表最多显示的镜像行数
+const pruneMaxPreviewRows = 10
+
+// PruneView 镜像清理对话框
+// 第一步选择过滤条件，第二步预览将被删除的镜像及大小，确认后执行
+type PruneView struct {
+	modes      []string
+	modeIdx    int
+	untilInput textinput.Model
+	labelInput textinput.Model
+
+	// 预览状态
+	previewing bool
+	loading    bool
+	candidates []docker.Image
+	scroll     int // 预览列表的滚动位置
+
+	// UI 状态
+	visible    bool
+	width      int
+	focusIndex int // 0=模式, 1=时间, 2=标签, 3=取消/返回, 4=预览/确认
+	errorMsg   string
+}
+
+// NewPruneView 创建镜像清理对话框
+func NewPruneView() *PruneView {
+	untilInput := textinput.New()
+	untilInput.Placeholder = "e.g. 24h, 168h"
+	untilInput.CharLimit = 16
+	untilInput.Width = 16
+	untilInput.Prompt = ""
+
+	labelInput := textinput.New()
+	labelInput.Placeholder = "e.g. env=ci,!keep"
+	labelInput.CharLimit = 128
+	labelInput.Width = 32
+	labelInput.Prompt = ""
+
+	return &PruneView{
+		modes:      []string{"dangling", "all unused"},
+		untilInput: untilInput,
+		labelInput: labelInput,
+	}
+}
+
+// Show 显示对话框（保留上次的过滤条件）
+func (v *PruneView) Show() {
+	v.visible = true
+	v.previewing = false
+	v.loading = false
+	v.candidates = nil
+	v.errorMsg = ""
+	v.focusIndex = 0
+	v.updateInputFocus()
+}
+
+// Hide 隐藏对话框
+func (v *PruneView) Hide() {
+	v.visible = false
+	v.previewing = false
+	v.candidates = nil
+	v.untilInput.Blur()
+	v.labelInput.Blur()
+}
+
+// IsVisible 是否可见
+func (v *PruneView) IsVisible() bool {
+	return v.visible
+}
+
+// IsPreviewing 是否处于预览确认阶段
+func (v *PruneView) IsPreviewing() bool {
+	return v.previewing
+}
+
+// SetWidth 设置宽度
+func (v *PruneView) SetWidth(width int) {
+	v.width = width
+}
+
+// GetOptions 获取当前的清理选项
+func (v *PruneView) GetOptions() (docker.ImagePruneOptions, error) {
+	opts := docker.ImagePruneOptions{
+		All:   v.modeIdx == 1,
+		Until: strings.TrimSpace(v.untilInput.Value()),
+	}
+	if opts.Until != "" {
+		if _, err := time.ParseDuration(opts.Until); err != nil {
+			return opts, fmt.Errorf("invalid age %q (use Go duration like 24h)", opts.Until)
+		}
+	}
+	for _, sel := range strings.Split(v.labelInput.Value(), ",") {
+		if sel = strings.TrimSpace(sel); sel != "" {
+			opts.Labels = append(opts.Labels, sel)
+		}
+	}
+	return opts, nil
+}
+
+// SetLoading 标记正在加载预览
+func (v *PruneView) SetLoading() {
+	v.loading = true
+	v.errorMsg = ""
+}
+
+// SetCandidates 设置预览结果并进入确认阶段
+func (v *PruneView) SetCandidates(candidates []docker.Image) {
+	v.loading = false
+	v.previewing = true
+	v.candidates = candidates
+	v.scroll = 0
+	v.errorMsg = ""
+	v.focusIndex = 3
+	v.updateInputFocus()
+}
+
+// SetError 显示错误信息
+func (v *PruneView) SetError(msg string) {
+	v.loading = false
+	v.errorMsg = msg
+}
+
+// CandidateCount 返回预览的镜像数量
+func (v *PruneView) CandidateCount() int {
+	return len(v.candidates)
+}
+
+// Update 处理输入
+// 返回值: (confirmed bool, handled bool, cmd tea.Cmd)
+// confirmed 在选项阶段表示请求预览，在预览阶段表示确认执行清理
+func (v *PruneView) Update(msg tea.Msg) (bool, bool, tea.Cmd) {
+	if !v.visible {
+		return false, false, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, false, nil
+	}
+	if v.loading {
+		return false, true, nil
+	}
+
+	keyStr := keyMsg.String()
+	if v.previewing && v.scrollPreview(keyStr) {
+		return false, true, nil
+	}
+	switch {
+	case keyMsg.Type == tea.KeyEsc:
+		if v.previewing {
+			v.backToOptions()
+			return false, true, nil
+		}
+		v.Hide()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyEnter:
+		if v.focusIndex == 4 {
+			if v.previewing && len(v.candidates) == 0 {
+				return false, true, nil
+			}
+			return true, true, nil
+		}
+		if v.focusIndex == 3 {
+			if v.previewing {
+				v.backToOptions()
+			} else {
+				v.Hide()
+			}
+			return false, true, nil
+		}
+		v.nextFocus()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyTab || keyMsg.Type == tea.KeyDown:
+		v.nextFocus()
+		return false, true, nil
+
+	case keyMsg.Type == tea.KeyShiftTab || keyMsg.Type == tea.KeyUp:
+		v.prevFocus()
+		return false, true, nil
+	}
+
+	if v.focusIndex == 0 {
+		if keyMsg.Type == tea.KeyLeft || keyStr == "h" || keyMsg.Type == tea.KeyRight || keyStr == "l" || keyStr == " " {
+			v.modeIdx = 1 - v.modeIdx
+		}
+		return false, true, nil
+	}
+
+	if v.focusIndex >= 3 {
+		if keyMsg.Type == tea.KeyLeft || keyStr == "h" {
+			v.focusIndex = 3
+		} else if keyMsg.Type == tea.KeyRight || keyStr == "l" {
+			v.focusIndex = 4
+		}
+		return false, true, nil
+	}
+
+	var cmd tea.Cmd
+	switch v.focusIndex {
+	case 1:
+		v.untilInput, cmd = v.untilInput.Update(msg)
+	case 2:
+		v.labelInput, cmd = v.labelInput.Update(msg)
+	}
+	return false, true, cmd
+}
+
+// scrollPreview 在预览阶段滚动完整的待删除列表，返回是否处理了按键
+func (v *PruneView) scrollPreview(keyStr string) bool {
+	maxScroll := len(v.candidates) - pruneMaxPreviewRows
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	switch keyStr {
+	case "j", "down":
+		v.scroll++
+	case "k", "up":
+		v.scroll--
+	case "pgdown", "ctrl+d":
+		v.scroll += pruneMaxPreviewRows
+	case "pgup", "ctrl+u":
+		v.scroll -= pruneMaxPreviewRows
+	case "g", "home":
+		v.scroll = 0
+	case "G", "end":
+		v.scroll = maxScroll
+	default:
+		return false
+	}
+	if v.scroll > maxScroll {
+		v.scroll = maxScroll
+	}
+	if v.scroll < 0 {
+		v.scroll = 0
+	}
+	return true
+}
+
+// backToOptions 从预览阶段返回选项阶段
+func (v *PruneView) backToOptions() {
+	v.previewing = false
+	v.candidates = nil
+	v.focusIndex = 0
+	v.updateInputFocus()
+}
+
+// nextFocus 切换到下一个焦点（预览阶段只在按钮间切换）
+func (v *PruneView) nextFocus() {
+	if v.previewing {
+		v.focusIndex = 3 + (v.focusIndex-2)%2
+	} else {
+		v.focusIndex = (v.focusIndex + 1) % 5
+	}
+	v.updateInputFocus()
+}
+
+// prevFocus 切换到上一个焦点
+func (v *PruneView) prevFocus() {
+	if v.previewing {
+		v.focusIndex = 3 + (v.focusIndex-2)%2
+	} else {
+		v.focusIndex = (v.focusIndex + 4) % 5
+	}
+	v.updateInputFocus()
+}
+
+// updateInputFocus 更新输入框焦点状态
+func (v *PruneView) updateInputFocus() {
+	v.untilInput.Blur()
+	v.labelInput.Blur()
+	switch v.focusIndex {
+	case 1:
+		v.untilInput.Focus()
+	case 2:
+		v.labelInput.Focus()
+	}
+}
+
+// View 渲染对话框
+func (v *PruneView) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	var contentParts []string
+	if v.previewing {
+		contentParts = v.renderPreview()
+	} else {
+		contentParts = v.renderOptions()
+	}
+
+	if v.errorMsg != "" {
+		contentParts = append(contentParts, "", pruneErrorStyle.Render("❌ "+v.errorMsg))
+	}
+
+	cancelLabel, okLabel := "< Cancel >", "< Preview >"
+	if v.previewing {
+		cancelLabel, okLabel = "< Back >", "< Prune >"
+	}
+	if v.loading {
+		okLabel = "< Loading... >"
+	}
+	cancelBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	okBtnStyle := lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("245"))
+	if v.focusIndex == 3 {
+		cancelBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	if v.focusIndex == 4 {
+		okBtnStyle = lipgloss.NewStyle().Padding(0, 2).Reverse(true).Bold(true)
+	}
+	buttons := cancelBtnStyle.Render(cancelLabel) + "    " + okBtnStyle.Render(okLabel)
+
+	hints := pruneHintStyle.Render("[Tab/↑↓=Switch] [←→=Select mode] [Enter=Confirm] [Esc=Cancel]")
+	if v.previewing {
+		hints = pruneHintStyle.Render("[↑↓/PgUp/PgDn=Scroll] [Tab/←→=Switch] [Enter=Confirm] [Esc=Back]")
+	}
+	contentParts = append(contentParts, "", buttons, "", hints)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, contentParts...)
+
+	boxWidth := v.width - 10
+	if boxWidth < 65 {
+		boxWidth = 65
+	}
+	if boxWidth > 90 {
+		boxWidth = 90
+	}
+	return pruneBoxStyle.Width(boxWidth).Render(content)
+}
+
+// renderOptions 渲染过滤条件
+func (v *PruneView) renderOptions() []string {
+	title := pruneTitleStyle.Render("🧹 Prune Images")
+
+	var modeOptions []string
+	for i, m := range v.modes {
+		if i == v.modeIdx {
+			if v.focusIndex == 0 {
+				modeOptions = append(modeOptions, pruneSelectedStyle.Render("["+m+"]"))
+			} else {
+				modeOptions = append(modeOptions, pruneValueStyle.Render("["+m+"]"))
+			}
+		} else {
+			modeOptions = append(modeOptions, pruneHintStyle.Render(" "+m+" "))
+		}
+	}
+	modeLine := pruneLabelStyle.Render("Mode:") + " " + strings.Join(modeOptions, " ")
+
+	untilStyle := lipgloss.NewStyle()
+	if v.focusIndex == 1 {
+		untilStyle = untilStyle.Foreground(lipgloss.Color("81"))
+	}
+	untilLine := pruneLabelStyle.Render("Older than:") + " " + untilStyle.Render(v.untilInput.View()) +
+		pruneHintStyle.Render(" (empty = any age)")
+
+	labelStyle := lipgloss.NewStyle()
+	if v.focusIndex == 2 {
+		labelStyle = labelStyle.Foreground(lipgloss.Color("81"))
+	}
+	labelLine := pruneLabelStyle.Render("Labels:") + " " + labelStyle.Render(v.labelInput.View())
+
+	modeHint := "Only untagged images not used by any container"
+	if v.modeIdx == 1 {
+		modeHint = "All images not used by any container, including tagged ones"
+	}
+
+	return []string{
+		title, "",
+		modeLine,
+		pruneHintStyle.Render("  " + modeHint), "",
+		untilLine,
+		labelLine,
+		pruneHintStyle.Render("  Comma separated: key, key=value, !key to exclude"),
+	}
+}
+
+// renderPreview 渲染完整的待删除列表及可释放空间
+// 与其他镜像共享的层不会被删除，因此可释放空间在“独占层之和”与“镜像大小之和”之间
+// （只被本次清理的镜像共享的层也会被删除）
+func (v *PruneView) renderPreview() []string {
+	opts, _ := v.GetOptions()
+
+	var total, unique int64
+	sharedKnown := true
+	for _, img := range v.candidates {
+		total += img.Size
+		if img.SharedSize < 0 {
+			sharedKnown = false
+			continue
+		}
+		unique += img.Size - img.SharedSize
+	}
+
+	filterDesc := v.modes[v.modeIdx]
+	if opts.Until != "" {
+		filterDesc += ", older than " + opts.Until
+	}
+	if len(opts.Labels) > 0 {
+		filterDesc += ", labels " + strings.Join(opts.Labels, ",")
+	}
+
+	reclaim := format.Size(total)
+	if sharedKnown && unique < total {
+		reclaim = format.Size(unique) + " – " + format.Size(total)
+	}
+	parts := []string{
+		pruneTitleStyle.Render(fmt.Sprintf("🧹 Prune Preview: %d images, frees %s", len(v.candidates), reclaim)),
+		pruneHintStyle.Render("Filter: " + filterDesc),
+	}
+	if sharedKnown && unique < total {
+		parts = append(parts, pruneHintStyle.Render("Layers shared with kept images are not freed"))
+	}
+	parts = append(parts, "")
+
+	if len(v.candidates) == 0 {
+		parts = append(parts, pruneHintStyle.Render("No images match these filters"))
+		return parts
+	}
+
+	parts = append(parts, pruneHintStyle.Render(fmt.Sprintf("  %-12s  %-32s %9s %9s  %s", "ID", "IMAGE", "SIZE", "UNIQUE", "CREATED")))
+	end := v.scroll + pruneMaxPreviewRows
+	if end > len(v.candidates) {
+		end = len(v.candidates)
+	}
+	for _, img := range v.candidates[v.scroll:end] {
+		name := img.Repository + ":" + img.Tag
+		if img.Dangling {
+			name = "<none>"
+		}
+		if len(name) > 32 {
+			name = name[:29] + "..."
+		}
+		uniqueSize := "-"
+		if img.SharedSize >= 0 {
+			uniqueSize = format.Size(img.Size - img.SharedSize)
+		}
+		parts = append(parts, fmt.Sprintf("  %s  %-32s %9s %9s  %s",
+			pruneValueStyle.Render(img.ShortID),
+			name,
+			format.Size(img.Size),
+			uniqueSize,
+			pruneHintStyle.Render(format.Ago(img.Created))))
+	}
+	if len(v.candidates) > pruneMaxPreviewRows {
+		parts = append(parts, pruneHintStyle.Render(fmt.Sprintf("  %d-%d of %d images (↑↓ to scroll)", v.scroll+1, end, len(v.candidates))))
+	}
+
+	parts = append(parts, "", pruneErrorStyle.Render("This action cannot be undone!"))
+	return parts
+}
@@ -0,0 +1,116 @@
+package image
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+	"docktui/internal/fleet"
+	"docktui/internal/format"
+	"docktui/internal/task"
+	"docktui/internal/ui/components"
+)
+
+// SetTransferHosts 设置可作为镜像传输目标的主机（hosts.yaml 中的主机和 docker CLI 上下文）
+func (v *ListView) SetTransferHosts(hosts []fleet.Host) {
+	v.transferHosts = hosts
+}
+
+// showTransferPicker 为选中的镜像（没有选中时为光标所在镜像）打开目标主机选择框
+func (v *ListView) showTransferPicker() tea.Cmd {
+	var images []task.TransferImageInfo
+	add := func(img docker.Image) {
+		info := task.TransferImageInfo{ID: img.ID, Size: img.Size}
+		if img.Repository != "<none>" && img.Tag != "<none>" {
+			info.Ref = img.Repository + ":" + img.Tag
+		}
+		images = append(images, info)
+	}
+	if len(v.selectedImages) > 0 {
+		for _, img := range v.filteredImages {
+			if v.selectedImages[img.ID] {
+				add(img)
+			}
+		}
+	} else if img := v.GetSelectedImage(); img != nil {
+		add(*img)
+	}
+	if len(images) == 0 {
+		v.successMsg = "⚠️ Please select images to transfer first"
+		v.successMsgTime = time.Now()
+		return v.clearSuccessMessageAfter(2 * time.Second)
+	}
+
+	current := docker.DockerHost()
+	var items []components.PickerItem
+	for _, h := range v.transferHosts {
+		if h.Host == current {
+			continue
+		}
+		items = append(items, components.PickerItem{ID: h.Name, Name: h.Name, Detail: hostDetail(h)})
+	}
+	if len(items) == 0 {
+		v.successMsg = "⚠️ No other hosts configured; list them in hosts.yaml (see the Fleet view)"
+		v.successMsgTime = time.Now()
+		return v.clearSuccessMessageAfter(3 * time.Second)
+	}
+
+	var size int64
+	for _, img := range images {
+		size += img.Size
+	}
+	v.transferImages = images
+	v.transferPicker.SetTitle("🚚 Transfer Images",
+		fmt.Sprintf("Stream %d images (%s) with docker save | docker load, no temp files", len(images), format.Size(size)))
+	v.transferPicker.SetSize(v.width, v.height)
+	v.transferPicker.SetItems(items)
+	v.transferShown = true
+	return nil
+}
+
+// handleTransferPickerKey 处理目标主机选择框的按键，确认后提交传输任务
+func (v *ListView) handleTransferPickerKey(msg tea.KeyMsg) (*ListView, tea.Cmd) {
+	confirmed, cancelled, cmd := v.transferPicker.Update(msg)
+	if cancelled {
+		v.transferShown = false
+		v.transferImages = nil
+		return v, nil
+	}
+	if !confirmed {
+		return v, cmd
+	}
+	v.transferShown = false
+	target := v.transferPicker.Current()
+	if target == nil {
+		return v, nil
+	}
+	var endpoint docker.Endpoint
+	for _, h := range v.transferHosts {
+		if h.Name == target.ID {
+			endpoint = h.Endpoint()
+		}
+	}
+	transferTask := task.NewTransferTask(v.dockerClient, v.transferImages, target.Name, endpoint)
+	task.GetManager().Submit(transferTask)
+	v.successMsg = fmt.Sprintf("🚚 Start transferring %d images to %s", len(v.transferImages), target.Name)
+	v.successMsgTime = time.Now()
+	v.transferImages = nil
+	v.selectedImages = make(map[string]bool)
+	v.updateTableData()
+	return v, tea.Batch(v.taskBar.ListenForEvents(), v.scheduleTaskTick())
+}
+
+// hostDetail 目标主机的地址，来自 docker CLI 上下文时注明
+func hostDetail(h fleet.Host) string {
+	if h.Context != "" {
+		return h.Host + " (docker context)"
+	}
+	return h.Host
+}
+
+// IsTransferPickerVisible 返回传输目标选择框是否可见
+func (v *ListView) IsTransferPickerVisible() bool {
+	return v.transferShown
+}
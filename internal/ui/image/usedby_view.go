@@ -0,0 +1,207 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/ui/styles"
+)
+
+// UsedByLoadedMsg 镜像的使用者（由镜像创建的容器）加载完成消息
+type UsedByLoadedMsg struct {
+	ImageID    string
+	Containers []docker.ContainerRef
+	Err        error
+}
+
+// UsedByView "被谁使用"对话框：列出由镜像创建的容器（包括已停止的），可跳转到容器详情或日志
+type UsedByView struct {
+	dockerClient docker.Client
+
+	image      *docker.Image
+	containers []docker.ContainerRef
+	cursor     int
+	scroll     int
+
+	loading  bool
+	visible  bool
+	width    int
+	height   int
+	errorMsg string
+}
+
+// NewUsedByView 创建"被谁使用"对话框
+func NewUsedByView(dockerClient docker.Client) *UsedByView {
+	return &UsedByView{dockerClient: dockerClient}
+}
+
+// Show 显示对话框并加载使用镜像的容器
+func (v *UsedByView) Show(image *docker.Image) tea.Cmd {
+	v.visible = true
+	v.image = image
+	v.containers = nil
+	v.cursor, v.scroll = 0, 0
+	v.loading = true
+	v.errorMsg = ""
+	imageID := image.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		containers, err := v.dockerClient.ImageUsedBy(ctx, imageID)
+		return UsedByLoadedMsg{ImageID: imageID, Containers: containers, Err: err}
+	}
+}
+
+// Hide 隐藏对话框
+func (v *UsedByView) Hide() {
+	v.visible = false
+	v.image = nil
+	v.containers = nil
+}
+
+// IsVisible 是否可见
+func (v *UsedByView) IsVisible() bool {
+	return v.visible
+}
+
+// SetSize 设置尺寸
+func (v *UsedByView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// SetContainers 设置加载结果（忽略已切换到其他镜像后返回的结果）
+func (v *UsedByView) SetContainers(msg UsedByLoadedMsg) {
+	if v.image == nil || msg.ImageID != v.image.ID {
+		return
+	}
+	v.loading = false
+	if msg.Err != nil {
+		v.errorMsg = msg.Err.Error()
+		return
+	}
+	v.containers = msg.Containers
+}
+
+// Update 处理输入，返回值: (handled bool, cmd tea.Cmd)
+func (v *UsedByView) Update(msg tea.Msg) (bool, tea.Cmd) {
+	if !v.visible {
+		return false, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "q", "u":
+		v.Hide()
+	case "j", "down":
+		v.moveCursor(1)
+	case "k", "up":
+		v.moveCursor(-1)
+	case "g":
+		v.moveCursor(-len(v.containers))
+	case "G":
+		v.moveCursor(len(v.containers))
+	case "enter", "L":
+		if v.cursor >= len(v.containers) {
+			break
+		}
+		c := v.containers[v.cursor]
+		v.Hide()
+		if keyMsg.String() == "L" {
+			return true, func() tea.Msg { return GoToContainerLogsMsg{ContainerID: c.ID, ContainerName: c.Name} }
+		}
+		return true, func() tea.Msg { return GoToContainerDetailMsg{ContainerID: c.ID, ContainerName: c.Name} }
+	}
+	return true, nil
+}
+
+// moveCursor 移动光标并保持在可见范围内
+func (v *UsedByView) moveCursor(delta int) {
+	v.cursor = max(0, min(len(v.containers)-1, v.cursor+delta))
+	rows := v.maxRows()
+	if v.cursor < v.scroll {
+		v.scroll = v.cursor
+	}
+	if v.cursor >= v.scroll+rows {
+		v.scroll = v.cursor - rows + 1
+	}
+}
+
+// maxRows 列表最多显示的行数
+func (v *UsedByView) maxRows() int {
+	return max(5, v.height-16)
+}
+
+// View 渲染对话框
+func (v *UsedByView) View() string {
+	if !v.visible || v.image == nil {
+		return ""
+	}
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+
+	boxWidth := max(60, min(90, v.width-10))
+	imageName := format.Truncate(v.image.Repository+":"+v.image.Tag, boxWidth-20)
+	lines := []string{titleStyle.Render("🔗 Used by: " + imageName), ""}
+
+	switch {
+	case v.loading:
+		lines = append(lines, hintStyle.Render("⏳ Loading containers..."))
+	case v.errorMsg != "":
+		lines = append(lines, ErrorMsgStyle.Render("❌ "+v.errorMsg))
+	case len(v.containers) == 0:
+		lines = append(lines, hintStyle.Render("No containers were created from this image"))
+	default:
+		running := 0
+		for _, c := range v.containers {
+			if c.State == "running" {
+				running++
+			}
+		}
+		lines = append(lines, hintStyle.Render(fmt.Sprintf("%d container(s), %d running", len(v.containers), running)), "")
+		end := min(len(v.containers), v.scroll+v.maxRows())
+		for i := v.scroll; i < end; i++ {
+			c := v.containers[i]
+			state := styles.ContainerStatus(c.State, "")
+			shortID := c.ID
+			if len(shortID) > 12 {
+				shortID = shortID[:12]
+			}
+			name := format.Fit(c.Name, boxWidth-36)
+			row := shortID + "  " + name + "  "
+			if i == v.cursor {
+				row = buildCacheCursorStyle.Render(row)
+			}
+			lines = append(lines, row+styles.RenderStatus(state, c.State))
+		}
+		if len(v.containers) > end {
+			lines = append(lines, hintStyle.Render(fmt.Sprintf("... %d more", len(v.containers)-end)))
+		}
+	}
+
+	footer := strings.Join([]string{
+		keyStyle.Render("Enter") + hintStyle.Render("=Details"),
+		keyStyle.Render("L") + hintStyle.Render("=Logs"),
+		keyStyle.Render("j/k") + hintStyle.Render("=Move"),
+		keyStyle.Render("Esc") + hintStyle.Render("=Close"),
+	}, "  ")
+	lines = append(lines, "", footer)
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(strings.Join(lines, "\n"))
+}
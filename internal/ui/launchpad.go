@@ -0,0 +1,60 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/favorites"
+	"docktui/internal/launchpad"
+	"docktui/internal/policy"
+)
+
+// SetLaunchpad 设置首页配置（资源卡片顺序、快捷操作卡片）
+func SetLaunchpad(m Model, cfg *launchpad.Config) Model {
+	if m.homeView != nil && cfg != nil {
+		m.homeView.SetLaunchpad(cfg)
+	}
+	return m
+}
+
+// runQuickAction 执行首页快捷操作卡片：打开资源详情，或进入列表并打开清理对话框
+func (m Model) runQuickAction(action *launchpad.Action) (tea.Model, tea.Cmd) {
+	if action == nil {
+		return m, nil
+	}
+	switch action.Kind() {
+	case launchpad.KindContainer:
+		return m.jumpToRef(favorites.Ref{Kind: favorites.KindContainer, Key: action.Container, Name: action.Container})
+	case launchpad.KindImage:
+		return m.jumpToRef(favorites.Ref{Kind: favorites.KindImage, Key: action.Image, Name: action.Image})
+	case launchpad.KindCompose:
+		return m.jumpToRef(favorites.Ref{Kind: favorites.KindCompose, Key: action.Compose, Name: action.Project, ComposeFiles: action.Files})
+	case launchpad.KindPrune:
+		return m.openPrune(action.Prune)
+	}
+	return m, nil
+}
+
+// openPrune 进入列表并打开清理对话框（清理前仍需在对话框中预览确认）
+func (m Model) openPrune(target string) (tea.Model, tea.Cmd) {
+	action := "container.prune"
+	if target == launchpad.CardImages {
+		action = "image.prune"
+	}
+	if !policy.Allowed(action) {
+		m.policyNotice = action
+		return m, nil
+	}
+
+	if target == launchpad.CardImages {
+		next, initCmd := m.enterImageList()
+		if m.imageListView != nil {
+			m.imageListView.ShowPruneView()
+		}
+		return next, initCmd
+	}
+	next, initCmd := m.enterContainerList()
+	if m.containerListView != nil {
+		m.containerListView.ShowPruneView()
+	}
+	return next, initCmd
+}
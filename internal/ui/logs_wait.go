@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+	containerui "docktui/internal/ui/container"
+)
+
+// logsStartMsg 日志视图等待的容器已启动
+type logsStartMsg struct {
+	event docker.ContainerEvent
+	ch    <-chan docker.ContainerEvent // 来源订阅，重新订阅后收到的旧事件直接丢弃
+}
+
+// waitLogsStart 等待下一个事件
+func waitLogsStart(ch <-chan docker.ContainerEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return logsStartMsg{event: event, ch: ch}
+	}
+}
+
+// startLogsWait 订阅容器事件，等待日志视图查看的容器启动；
+// 订阅前容器可能已经启动，订阅后再查询一次状态，避免错过启动事件
+func (m Model) startLogsWait(msg containerui.WaitForStartMsg) (Model, tea.Cmd) {
+	m = m.stopLogsWait()
+	if m.eventHub == nil || msg.ContainerID == "" {
+		return m, nil
+	}
+	m.logsWaitID = msg.ContainerID
+	m.logsWaitEvents, m.logsWaitUnsubscribe = m.eventHub.Subscribe()
+
+	ch, id, client := m.logsWaitEvents, msg.ContainerID, m.dockerClient
+	check := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		details, err := client.ContainerDetails(ctx, id)
+		if err != nil || details.State != "running" {
+			return nil
+		}
+		return logsStartMsg{event: docker.ContainerEvent{Action: "start", ContainerID: id, Timestamp: details.StartedAt}, ch: ch}
+	}
+	return m, tea.Batch(waitLogsStart(ch), check)
+}
+
+// stopLogsWait 取消等待容器启动的订阅
+func (m Model) stopLogsWait() Model {
+	if m.logsWaitUnsubscribe != nil {
+		m.logsWaitUnsubscribe()
+	}
+	m.logsWaitUnsubscribe = nil
+	m.logsWaitEvents = nil
+	m.logsWaitID = ""
+	return m
+}
+
+// handleLogsStart 等待的容器启动后，日志视图从启动时间开始跟随
+func (m Model) handleLogsStart(msg logsStartMsg) (Model, tea.Cmd) {
+	if msg.ch != m.logsWaitEvents || m.logsWaitID == "" {
+		return m, nil
+	}
+	event := msg.event
+	if event.Action != "start" || !strings.HasPrefix(event.ContainerID, m.logsWaitID) {
+		return m, waitLogsStart(m.logsWaitEvents)
+	}
+
+	id := m.logsWaitID
+	m = m.stopLogsWait()
+	// 已离开日志视图、换了容器，或已由固定容器的重新附加处理
+	if m.logsView == nil || m.logsView.ContainerID() != id || !m.logsView.IsWaitingStart() {
+		return m, nil
+	}
+	return m, tea.Batch(
+		m.logsView.Reattach(id, event.Timestamp),
+		m.SetTemporaryMessage(MsgInfo, "▶️ "+m.logsView.ContainerName()+" started, following logs", 3),
+	)
+}
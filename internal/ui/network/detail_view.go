@@ -3,12 +3,14 @@ package network
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"docktui/internal/docker"
+	"docktui/internal/format"
 	"docktui/internal/ui/components"
 )
 
@@ -76,7 +78,7 @@ func (v *DetailView) Update(msg tea.Msg) (*DetailView, tea.Cmd) {
 		case "2": v.activeTab = TabIPAM; v.scrollOffset = 0
 		case "3": v.activeTab = TabContainers; v.scrollOffset = 0
 		case "4": v.activeTab = TabLabels; v.scrollOffset = 0
-		case "r": v.loading = true; return v, v.loadNetworkDetails
+		case "r", "f5": v.loading = true; return v, v.loadNetworkDetails
 		}
 	}
 	return v, nil
@@ -132,11 +134,19 @@ func (v *DetailView) renderBasicInfo() string {
 	lines = append(lines, v.formatLine("NETWORK ID", v.details.ID))
 	lines = append(lines, v.formatLine("NAME", v.details.Name))
 	lines = append(lines, v.formatLine("DRIVER", v.details.Driver))
-	lines = append(lines, v.formatLine("SCOPE", v.details.Scope))
-	lines = append(lines, v.formatLine("CREATED", v.details.Created.Format("2006-01-02 15:04:05")+" ("+formatCreatedTime(v.details.Created)+")"))
+	scopeStr := v.details.Scope
+	if v.details.IsSwarmScoped() { scopeStr += "  " + SwarmStyle.Render("🔒 managed by Swarm, read-only here") }
+	lines = append(lines, v.formatLine("SCOPE", scopeStr))
+	lines = append(lines, v.formatLine("CREATED", v.details.Created.Format("2006-01-02 15:04:05")+" ("+format.Ago(v.details.Created)+")"))
 	internalStr := "No"; if v.details.Internal { internalStr = "Yes (cannot access external network)" }
 	lines = append(lines, v.formatLine("INTERNAL", internalStr))
-	ipv6Str := "No"; if v.details.IPv6 { ipv6Str = "Yes" }
+	ipv6Str := "No"
+	if v.details.IPv6 {
+		ipv6Str = "Yes"
+		var subnets []string
+		for _, cfg := range v.details.IPAM.Configs { if cfg.IsIPv6() { subnets = append(subnets, cfg.Subnet) } }
+		if len(subnets) > 0 { ipv6Str += " (" + strings.Join(subnets, ", ") + ")" }
+	}
 	lines = append(lines, v.formatLine("IPv6", ipv6Str))
 	attachableStr := "No"; if v.details.Attachable { attachableStr = "Yes (can manually attach containers)" }
 	lines = append(lines, v.formatLine("ATTACHABLE", attachableStr))
@@ -144,8 +154,11 @@ func (v *DetailView) renderBasicInfo() string {
 	lines = append(lines, v.formatLine("INGRESS", ingressStr))
 	if len(v.details.Options) > 0 {
 		lines = append(lines, "", DetailLabelStyle.Render("DRIVER OPTIONS:"))
-		for k, val := range v.details.Options {
-			lines = append(lines, "  "+DetailKeyStyle.Render(k)+" = "+DetailValueStyle.Render(val))
+		keys := make([]string, 0, len(v.details.Options))
+		for k := range v.details.Options { keys = append(keys, k) }
+		sort.Strings(keys)
+		for _, k := range keys {
+			lines = append(lines, "  "+DetailKeyStyle.Render(k)+" = "+DetailValueStyle.Render(v.details.Options[k]))
 		}
 	}
 	boxWidth := v.width - 6; if boxWidth < 60 { boxWidth = 60 }
@@ -166,7 +179,8 @@ func (v *DetailView) renderIPAMConfig() string {
 	if len(v.details.IPAM.Configs) > 0 {
 		lines = append(lines, "", DetailLabelStyle.Render("IP POOLS:")+" ("+fmt.Sprintf("%d", len(v.details.IPAM.Configs))+")")
 		for i, cfg := range v.details.IPAM.Configs {
-			lines = append(lines, "", DetailKeyStyle.Render(fmt.Sprintf("  Pool #%d:", i+1)))
+			family := "IPv4"; if cfg.IsIPv6() { family = "IPv6" }
+			lines = append(lines, "", DetailKeyStyle.Render(fmt.Sprintf("  Pool #%d (%s):", i+1, family)))
 			if cfg.Subnet != "" { lines = append(lines, "    "+DetailLabelStyle.Render("Subnet:")+" "+DetailValueStyle.Render(cfg.Subnet)) }
 			if cfg.Gateway != "" { lines = append(lines, "    "+DetailLabelStyle.Render("Gateway:")+" "+DetailValueStyle.Render(cfg.Gateway)) }
 			if cfg.IPRange != "" { lines = append(lines, "    "+DetailLabelStyle.Render("IP Range:")+" "+DetailValueStyle.Render(cfg.IPRange)) }
@@ -260,16 +274,3 @@ func (v *DetailView) loadNetworkDetails() tea.Msg {
 	if err != nil { return NetworkDetailLoadErrorMsg{Err: err} }
 	return NetworkDetailLoadedMsg{Details: details}
 }
-
-// formatCreatedTime format created time
-func formatCreatedTime(t time.Time) string {
-	d := time.Since(t)
-	switch {
-	case d < time.Minute: return "just now"
-	case d < time.Hour: return fmt.Sprintf("%dm ago", int(d.Minutes()))
-	case d < 24*time.Hour: return fmt.Sprintf("%dh ago", int(d.Hours()))
-	case d < 30*24*time.Hour: return fmt.Sprintf("%dd ago", int(d.Hours()/24))
-	case d < 365*24*time.Hour: return fmt.Sprintf("%dmo ago", int(d.Hours()/24/30))
-	default: return fmt.Sprintf("%dy ago", int(d.Hours()/24/365))
-	}
-}
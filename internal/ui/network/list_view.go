@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"docktui/internal/docker"
+	"docktui/internal/format"
 	"docktui/internal/ui/components"
 )
 
@@ -48,6 +49,7 @@ type ListView struct {
 	showCreateView bool
 	jsonViewer *components.JSONViewer
 	errorDialog *components.ErrorDialog
+	listExport *components.ListExportView
 }
 
 // NewListView 创建网络列表视图
@@ -57,6 +59,7 @@ func NewListView(dockerClient docker.Client) *ListView {
 		{Title: "NAME", Width: 25},
 		{Title: "DRIVER", Width: 12},
 		{Title: "SCOPE", Width: 10},
+		{Title: "SUBNETS", Width: 34},
 		{Title: "CONTAINERS", Width: 12},
 		{Title: "CREATED", Width: 16},
 	}
@@ -69,6 +72,7 @@ func NewListView(dockerClient docker.Client) *ListView {
 		errorDialog: components.NewErrorDialog(),
 		createView: NewCreateView(dockerClient),
 		jsonViewer: components.NewJSONViewer(),
+		listExport: components.NewListExportView(),
 	}
 }
 
@@ -103,12 +107,16 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 	}
 	switch msg := msg.(type) {
 	case NetworksLoadedMsg:
+		// 重新加载后按 ID 找回之前选中的网络
+		var selectedID string
+		if n := v.GetSelectedNetwork(); n != nil { selectedID = n.ID }
 		v.networks = msg.Networks
 		v.loading = false
 		v.errorMsg = ""
 		v.lastRefreshTime = time.Now()
 		v.applyFilters()
 		v.updateTableData()
+		v.restoreCursor(selectedID)
 		return v, nil
 	case NetworksLoadErrorMsg:
 		v.loading = false
@@ -131,11 +139,26 @@ func (v *ListView) Update(msg tea.Msg) (*ListView, tea.Cmd) {
 			v.jsonViewer.Show("Network Inspect: "+msg.NetworkName, msg.JSONContent)
 		}
 		return v, nil
+	case components.ListExportResultMsg:
+		if msg.Err != nil { v.errorDialog.ShowError(fmt.Sprintf("Export failed: %v", msg.Err)); return v, nil }
+		v.successMsg = fmt.Sprintf("✅ Exported %d networks to %s", msg.Rows, msg.Path)
+		v.successMsgTime = time.Now()
+		return v, v.clearSuccessMessageAfter(3*time.Second)
 	case NetworkInspectErrorMsg:
 		if v.errorDialog != nil { v.errorDialog.ShowError(fmt.Sprintf("Failed to get network info: %v", msg.Err)) }
 		return v, nil
 	case tea.KeyMsg:
 		if v.errorDialog != nil && v.errorDialog.IsVisible() { if v.errorDialog.Update(msg) { return v, nil } }
+		if v.listExport.IsVisible() {
+			confirmed, handled, cmd := v.listExport.Update(msg)
+			if confirmed {
+				path, format := v.listExport.GetValues()
+				v.listExport.Hide()
+				columns, rows := networkExportRows(v.filteredNetworks)
+				return v, components.ListExportCmd(path, format, columns, rows)
+			}
+			if handled { return v, cmd }
+		}
 		if v.showConfirmDialog { return v.handleConfirmDialogKey(msg) }
 		if v.showFilterMenu { return v.handleFilterMenuKey(msg) }
 		if v.isSearching { return v.handleSearchKey(msg) }
@@ -211,16 +234,19 @@ func (v *ListView) handleNormalKey(msg tea.KeyMsg) (*ListView, tea.Cmd) {
 	case "G": if v.scrollTable != nil { v.scrollTable.GotoBottom() }
 	case "h", "left": if v.scrollTable != nil { v.scrollTable.ScrollLeft() }
 	case "l", "right": if v.scrollTable != nil { v.scrollTable.ScrollRight() }
-	case "d": return v, v.showRemoveConfirmDialog()
-	case "p": return v, v.showPruneConfirmDialog()
+	case "d": return v, components.RunAction("network.remove", v.showRemoveConfirmDialog)
+	case "p": return v, components.RunAction("network.prune", v.showPruneConfirmDialog)
 	case "c":
-		v.showCreateView = true
-		v.createView.Reset()
-		v.createView.SetSize(v.width, v.height)
-		v.createView.SetCallbacks(func(networkID string) {}, func() { v.showCreateView = false })
-		return v, nil
+		return v, components.RunAction("network.create", func() tea.Cmd {
+			v.showCreateView = true
+			v.createView.Reset()
+			v.createView.SetSize(v.width, v.height)
+			v.createView.SetCallbacks(func(networkID string) {}, func() { v.showCreateView = false })
+			return nil
+		})
 	case "f": v.showFilterMenu = true; return v, nil
 	case "i": return v, v.inspectNetwork()
+	case "w": v.listExport.SetWidth(v.width); v.listExport.Show("networks", len(v.filteredNetworks))
 	case "enter":
 		network := v.GetSelectedNetwork()
 		if network == nil { return v, nil }
@@ -272,6 +298,7 @@ func (v *ListView) View() string {
 	}
 	if v.showFilterMenu { s = v.overlayFilterMenu(s) }
 	if v.showConfirmDialog { s = v.overlayDialog(s) }
+	if v.listExport.IsVisible() { s = components.OverlayCentered(s, v.listExport.View(), v.width, v.height) }
 	if v.errorDialog != nil && v.errorDialog.IsVisible() { s = v.errorDialog.Overlay(s) }
 	return s
 }
@@ -283,6 +310,7 @@ func (v *ListView) SetSize(width, height int) {
 	if v.scrollTable != nil { v.scrollTable.SetSize(width-4, tableHeight) }
 	if v.errorDialog != nil { v.errorDialog.SetWidth(width) }
 	if v.createView != nil { v.createView.SetSize(width, height) }
+	if v.listExport != nil { v.listExport.SetWidth(width) }
 }
 
 func (v *ListView) renderStatusBar() string {
@@ -295,10 +323,10 @@ func (v *ListView) renderStatusBar() string {
 	itemStyle := lipgloss.NewStyle().Width(itemWidth)
 	makeItem := func(key, desc string) string { return itemStyle.Render(keyStyle.Render(key) + descStyle.Render(" "+desc)) }
 	var lines []string
-	lines = append(lines, "  "+labelStyle.Render("🌐 Networks")+makeItem("</>", "Search")+makeItem("<r>", "Refresh")+makeItem("<d>", "Delete"))
+	lines = append(lines, "  "+labelStyle.Render("🌐 Networks")+makeItem("</>", "Search")+makeItem("<r>", "Refresh")+makeItem("<d>", "Delete")+makeItem("<w>", "Export list"))
 	lines = append(lines, "  "+labelStyle.Render("Ops:")+makeItem("<c>", "Create")+makeItem("<p>", "Prune")+makeItem("<f>", "Filter")+makeItem("<i>", "Inspect"))
 	refreshInfo := "-"
-	if !v.lastRefreshTime.IsZero() { refreshInfo = formatDuration(time.Since(v.lastRefreshTime)) + " ago" }
+	if !v.lastRefreshTime.IsZero() { refreshInfo = format.Duration(time.Since(v.lastRefreshTime)) + " ago" }
 	filterInfo := ""; if v.filterDriver != "all" { filterInfo = " [Filter: " + v.filterDriver + "]" }
 	sortNames := []string{"Name", "Driver", "Created", "Containers"}
 	sortInfo := " [Sort: " + sortNames[v.sortField] + "]"
@@ -309,8 +337,9 @@ func (v *ListView) renderStatusBar() string {
 func (v *ListView) renderStatsBar() string {
 	totalCount := len(v.networks)
 	showingCount := len(v.filteredNetworks)
-	bridgeCount, hostCount, overlayCount := 0, 0, 0
+	bridgeCount, hostCount, overlayCount, unusedCount := 0, 0, 0, 0
 	for _, net := range v.networks {
+		if net.Unused { unusedCount++ }
 		switch net.Driver {
 		case "bridge": bridgeCount++
 		case "host": hostCount++
@@ -324,6 +353,7 @@ func (v *ListView) renderStatsBar() string {
 	separatorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 	statsContent := totalStyle.Render(fmt.Sprintf("🌐 Total: %d", totalCount)) + separatorStyle.Render("  │  ") + bridgeStyle.Render(fmt.Sprintf("🔗 Bridge: %d", bridgeCount)) + separatorStyle.Render("  │  ") + hostStyle.Render(fmt.Sprintf("🖥️ Host: %d", hostCount)) + separatorStyle.Render("  │  ") + overlayStyle.Render(fmt.Sprintf("☁️ Overlay: %d", overlayCount))
+	if unusedCount > 0 { statsContent += separatorStyle.Render("  │  ") + UnusedStyle.Render(fmt.Sprintf("🕸️ Unused: %d", unusedCount)) }
 	if showingCount != totalCount { statsContent += BuiltInStyle.Render(fmt.Sprintf("  [Showing: %d]", showingCount)) }
 	lineWidth := v.width - 6; if lineWidth < 60 { lineWidth = 60 }
 	line := lineStyle.Render(strings.Repeat("─", lineWidth))
@@ -350,6 +380,8 @@ func (v *ListView) applyFilters() {
 		v.filteredNetworks = append(v.filteredNetworks, net)
 	}
 	v.sortNetworks()
+	// 在 ID、名称、驱动列中高亮搜索关键字
+	if v.scrollTable != nil { v.scrollTable.SetHighlight(v.searchQuery, 0, 1, 2) }
 }
 
 func (v *ListView) sortNetworks() {
@@ -377,11 +409,16 @@ func (v *ListView) updateTableData() {
 	if v.scrollTable == nil || len(v.filteredNetworks) == 0 { return }
 	rows := make([]components.TableRow, len(v.filteredNetworks))
 	for i, net := range v.filteredNetworks {
-		created := formatCreatedTime(net.Created)
+		created := format.Ago(net.Created)
 		containers := fmt.Sprintf("%d", net.ContainerCount)
+		if net.Unused { containers = UnusedStyle.Render(containers + " (unused)") }
 		var nameStyled, driverStyled string
 		if net.IsBuiltIn { nameStyled = BuiltInStyle.Render(net.Name); driverStyled = BuiltInStyle.Render(net.Driver) } else { nameStyled = CustomStyle.Render(net.Name); driverStyled = DriverStyle.Render(net.Driver) }
-		rows[i] = components.TableRow{net.ShortID, nameStyled, driverStyled, net.Scope, containers, created}
+		scope := net.Scope
+		if net.IsSwarmScoped() { scope = SwarmStyle.Render("🔒 " + net.Scope) }
+		subnets := strings.Join(net.Subnets, ", ")
+		if subnets == "" { subnets = BuiltInStyle.Render("-") }
+		rows[i] = components.TableRow{net.ShortID, nameStyled, driverStyled, scope, subnets, containers, created}
 	}
 	v.scrollTable.SetRows(rows)
 }
@@ -394,16 +431,30 @@ func (v *ListView) GetSelectedNetwork() *docker.Network {
 	return &v.filteredNetworks[idx]
 }
 
+// restoreCursor 把光标移到指定 ID 的网络上，网络已不在列表中时保持原来的行号
+func (v *ListView) restoreCursor(id string) {
+	if id == "" || v.scrollTable == nil { return }
+	for i, n := range v.filteredNetworks {
+		if n.ID == id { v.scrollTable.SetCursor(i); return }
+	}
+}
+
 func (v *ListView) showRemoveConfirmDialog() tea.Cmd {
 	network := v.GetSelectedNetwork()
 	if network == nil { return nil }
 	if network.IsBuiltIn { if v.errorDialog != nil { v.errorDialog.ShowError("Cannot delete built-in network: " + network.Name) }; return nil }
+	if network.IsSwarmScoped() { if v.errorDialog != nil { v.errorDialog.ShowError(fmt.Sprintf("Network %s is managed by Swarm (scope swarm), remove it on a manager with docker network rm or docker stack rm", network.Name)) }; return nil }
 	if network.ContainerCount > 0 { if v.errorDialog != nil { v.errorDialog.ShowError(fmt.Sprintf("Network %s still has %d containers connected, please disconnect first", network.Name, network.ContainerCount)) }; return nil }
 	v.showConfirmDialog = true; v.confirmAction = "remove"; v.confirmNetwork = network; v.confirmSelection = 0
 	return nil
 }
 
 func (v *ListView) showPruneConfirmDialog() tea.Cmd {
+	if len(v.unusedNetworks()) == 0 {
+		v.successMsg = "✨ No unused networks to prune"
+		v.successMsgTime = time.Now()
+		return v.clearSuccessMessageAfter(3*time.Second)
+	}
 	v.showConfirmDialog = true; v.confirmAction = "prune"; v.confirmNetwork = nil; v.confirmSelection = 0
 	return nil
 }
@@ -429,8 +480,17 @@ func (v *ListView) pruneNetworks() tea.Cmd {
 		deleted, err := v.dockerClient.PruneNetworks(ctx)
 		if err != nil { return NetworkOperationErrorMsg{Operation: "Prune networks", Err: err} }
 		if len(deleted) == 0 { return NetworkOperationSuccessMsg{Operation: "Prune networks", Network: "No unused networks"} }
-		return NetworkOperationSuccessMsg{Operation: "Prune networks", Network: fmt.Sprintf("Deleted %d networks", len(deleted))}
+		return NetworkOperationSuccessMsg{Operation: "Prune networks", Network: fmt.Sprintf("removed %d (%s)", len(deleted), strings.Join(deleted, ", "))}
+	}
+}
+
+// unusedNetworks 返回没有容器引用、会被清理的自定义网络
+func (v *ListView) unusedNetworks() []docker.Network {
+	var unused []docker.Network
+	for _, net := range v.networks {
+		if net.Unused { unused = append(unused, net) }
 	}
+	return unused
 }
 
 func (v *ListView) clearSuccessMessageAfter(d time.Duration) tea.Cmd {
@@ -462,13 +522,24 @@ func (v *ListView) renderConfirmDialogContent() string {
 	okBtnStyle := lipgloss.NewStyle().Padding(0, 2)
 	if v.confirmSelection == 0 { cancelBtnStyle = cancelBtnStyle.Reverse(true).Bold(true); okBtnStyle = okBtnStyle.Foreground(lipgloss.Color("245")) } else { cancelBtnStyle = cancelBtnStyle.Foreground(lipgloss.Color("245")); okBtnStyle = okBtnStyle.Reverse(true).Bold(true) }
 	var title, warning string
-	if v.confirmAction == "remove" && v.confirmNetwork != nil { title = "🗑️  Confirm Delete Network"; warning = fmt.Sprintf("Are you sure you want to delete network \"%s\"?", v.confirmNetwork.Name) } else if v.confirmAction == "prune" { title = "🧹  Confirm Prune Networks"; warning = "Are you sure you want to prune all unused networks?\nThis action cannot be undone." }
+	if v.confirmAction == "remove" && v.confirmNetwork != nil { title = "🗑️  Confirm Delete Network"; warning = fmt.Sprintf("Are you sure you want to delete network \"%s\"?", v.confirmNetwork.Name) } else if v.confirmAction == "prune" { title = "🧹  Confirm Prune Networks"; warning = v.pruneWarning() }
 	buttons := lipgloss.JoinHorizontal(lipgloss.Center, cancelBtnStyle.Render("[ Cancel ]"), "    ", okBtnStyle.Render("[   OK   ]"))
 	content := lipgloss.JoinVertical(lipgloss.Center, "", titleStyle.Render(title), "", warningStyle.Render(warning), "", buttons, "")
 	leftPadding := (v.width - 60) / 2; if leftPadding < 0 { leftPadding = 0 }
 	return strings.Repeat(" ", leftPadding) + dialogStyle.Render(content)
 }
 
+// pruneWarning 列出将被清理的网络（最多 8 个）
+func (v *ListView) pruneWarning() string {
+	unused := v.unusedNetworks()
+	lines := []string{fmt.Sprintf("These %d unused networks will be removed:", len(unused)), ""}
+	for i, net := range unused {
+		if i == 8 { lines = append(lines, fmt.Sprintf("  … and %d more", len(unused)-i)); break }
+		lines = append(lines, fmt.Sprintf("  • %s (%s)", net.Name, net.Driver))
+	}
+	return strings.Join(append(lines, "", "This action cannot be undone."), "\n")
+}
+
 // HasError 检查是否有错误弹窗显示
 func (v *ListView) HasError() bool { return v.errorDialog != nil && v.errorDialog.IsVisible() }
 
@@ -500,14 +571,34 @@ func (v *ListView) renderFilterMenuContent() string {
 // IsShowingCreateView 检查是否正在显示创建视图
 func (v *ListView) IsShowingCreateView() bool { return v.showCreateView }
 
-func formatDuration(d time.Duration) string {
-	if d < time.Minute { return fmt.Sprintf("%ds", int(d.Seconds())) }
-	if d < time.Hour { return fmt.Sprintf("%dm", int(d.Minutes())) }
-	return fmt.Sprintf("%dh", int(d.Hours()))
-}
-
 // ShowConfirmDialog 返回是否显示确认对话框
 func (v *ListView) ShowConfirmDialog() bool { return v.showConfirmDialog }
 
+// SetSearch 预设搜索关键字（用于命令行 --search），数据加载后生效
+func (v *ListView) SetSearch(query string) {
+	v.searchQuery = query
+	v.applyFilters()
+}
+
+// IsSearching 返回是否处于搜索模式
+func (v *ListView) IsSearching() bool { return v.isSearching }
+
 // ShowFilterMenu 返回是否显示筛选菜单
 func (v *ListView) ShowFilterMenu() bool { return v.showFilterMenu }
+
+// IsListExportVisible 返回列表导出对话框是否可见
+func (v *ListView) IsListExportVisible() bool { return v.listExport != nil && v.listExport.IsVisible() }
+
+// networkExportRows 将网络列表映射为导出的列和行
+func networkExportRows(networks []docker.Network) ([]string, [][]any) {
+	columns := []string{"ID", "Name", "Driver", "Scope", "Subnets", "Internal", "IPv6", "Containers", "Created"}
+	rows := make([][]any, 0, len(networks))
+	for _, n := range networks {
+		subnets := n.Subnets
+		if subnets == nil {
+			subnets = []string{}
+		}
+		rows = append(rows, []any{n.ID, n.Name, n.Driver, n.Scope, subnets, n.Internal, n.IPv6, n.ContainerCount, n.Created.Format(time.RFC3339)})
+	}
+	return columns, rows
+}
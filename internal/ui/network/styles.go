@@ -13,6 +13,8 @@ var (
 	DriverStyle  = styles.KeyStyle
 	BuiltInStyle = styles.MutedStyle
 	CustomStyle  = styles.ActiveStyle
+	UnusedStyle  = styles.WarningStyle
+	SwarmStyle   = styles.MutedStyle
 
 	// 状态框
 	StateBoxStyle = styles.StateBoxStyle
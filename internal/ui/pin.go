@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+)
+
+// pinEventMsg 固定容器相关的 Docker 事件
+type pinEventMsg struct {
+	event docker.ContainerEvent
+	ch    <-chan docker.ContainerEvent // 来源订阅，取消固定后收到的旧事件直接丢弃
+}
+
+// waitPinEvent 等待下一个事件
+func waitPinEvent(ch <-chan docker.ContainerEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return pinEventMsg{event: event, ch: ch}
+	}
+}
+
+// currentContainerName 返回日志/详情视图当前查看的容器名称
+func (m Model) currentContainerName() string {
+	switch m.currentView {
+	case ViewLogs:
+		// 合并查看多个容器的日志时没有单个容器可固定
+		if m.logsView != nil && !m.logsView.IsMerged() {
+			return m.logsView.ContainerName()
+		}
+	case ViewContainerDetail:
+		if m.containerDetailView != nil {
+			return m.containerDetailView.ContainerName()
+		}
+	}
+	return ""
+}
+
+// togglePin 固定/取消固定当前容器：固定后同名容器被重建（如 compose 重新部署）时，日志、资源监控和详情自动附加到新实例
+func (m Model) togglePin() (Model, tea.Cmd) {
+	name := m.currentContainerName()
+	if name == "" {
+		return m, m.SetTemporaryMessage(MsgWarning, "⚠️ No container to pin", 3)
+	}
+
+	if m.pinUnsubscribe != nil {
+		m.pinUnsubscribe()
+		m.pinUnsubscribe = nil
+		m.pinEvents = nil
+	}
+	if m.pinnedName == name {
+		m.pinnedName = ""
+		m.syncPinBadges()
+		return m, m.SetTemporaryMessage(MsgInfo, "📌 Unpinned "+name, 3)
+	}
+
+	m.pinnedName = name
+	m.pinEvents, m.pinUnsubscribe = m.eventHub.Subscribe()
+	m.syncPinBadges()
+	return m, tea.Batch(
+		waitPinEvent(m.pinEvents),
+		m.SetTemporaryMessage(MsgSuccess, fmt.Sprintf("📌 Pinned %s: views follow it when recreated", name), 3),
+	)
+}
+
+// syncPinBadges 更新日志/详情视图的固定标记
+func (m Model) syncPinBadges() {
+	if m.logsView != nil {
+		m.logsView.SetPinned(m.pinnedName != "" && m.logsView.ContainerName() == m.pinnedName)
+	}
+	if m.containerDetailView != nil {
+		m.containerDetailView.SetPinned(m.pinnedName != "" && m.containerDetailView.ContainerName() == m.pinnedName)
+	}
+}
+
+// handlePinEvent 同名容器创建或启动时，把正在查看它的日志/详情视图附加到新实例
+func (m Model) handlePinEvent(msg pinEventMsg) (Model, tea.Cmd) {
+	if msg.ch != m.pinEvents || m.pinnedName == "" {
+		return m, nil
+	}
+	next := waitPinEvent(m.pinEvents)
+
+	event := msg.event
+	if strings.TrimPrefix(event.ContainerName, "/") != m.pinnedName || (event.Action != "create" && event.Action != "start") {
+		return m, next
+	}
+
+	cmds := []tea.Cmd{next}
+	reattached := false
+	if m.logsView != nil && m.logsView.ContainerName() == m.pinnedName {
+		// 同一实例且仍在跟随时无需处理
+		if event.ContainerID != m.logsView.ContainerID() || !m.logsView.IsFollowing() {
+			cmds = append(cmds, m.logsView.Reattach(event.ContainerID, event.Timestamp))
+			reattached = true
+		}
+	}
+	if m.containerDetailView != nil && m.containerDetailView.ContainerName() == m.pinnedName {
+		// 详情视图中的 l/s 快捷键使用 selectedContainerID
+		if m.selectedContainerID == m.containerDetailView.ContainerID() {
+			m.selectedContainerID = event.ContainerID
+		}
+		cmds = append(cmds, m.containerDetailView.Reattach(event.ContainerID))
+		reattached = true
+	}
+	if reattached && event.Action == "create" {
+		cmds = append(cmds, m.SetTemporaryMessage(MsgInfo, fmt.Sprintf("📌 %s was recreated, reattached", m.pinnedName), 3))
+	}
+	return m, tea.Batch(cmds...)
+}
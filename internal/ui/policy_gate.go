@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/policy"
+	"docktui/internal/ui/components"
+)
+
+// viewCapturesInput 当前视图是否有输入框/弹窗在接收按键（此时按键不是操作快捷键）
+func (m Model) viewCapturesInput() bool {
+	switch m.currentView {
+	case ViewContainerList:
+		v := m.containerListView
+		return v == nil || v.IsSearching() || v.IsConfirmDialogVisible() || v.IsEditViewVisible() || v.IsPruneViewVisible() ||
+			v.IsRestartPolicyViewVisible() || v.IsDuplicateViewVisible() || v.IsDebugViewVisible() || v.IsNoteInputVisible() || v.IsKubeViewVisible() || v.IsExecViewVisible() || v.IsNetTestViewVisible() || v.IsListExportVisible() || v.HasError() || v.IsShowingJSONViewer()
+	case ViewImageList:
+		v := m.imageListView
+		return v == nil || v.IsSearching() || v.IsPullInputVisible() || v.IsImportInputVisible() || v.IsTagInputVisible() || v.IsNoteInputVisible() || v.IsPushInputVisible() || v.IsPruneViewVisible() ||
+			v.IsBuildCacheViewVisible() || v.IsUsedByViewVisible() || v.IsListExportVisible() || v.IsShowingExportInput() || v.IsTransferPickerVisible() || v.ShowConfirmDialog() || v.HasError() || v.IsShowingJSONViewer()
+	case ViewNetworkList:
+		v := m.networkListView
+		return v == nil || v.IsSearching() || v.ShowConfirmDialog() || v.ShowFilterMenu() || v.IsShowingCreateView() ||
+			v.IsListExportVisible() || v.HasError() || v.IsShowingJSONViewer()
+	case ViewContainerDetail:
+		return m.containerDetailView == nil || m.containerDetailView.IsCapturingInput()
+	case ViewComposeList:
+		return m.composeListView == nil || m.composeListView.IsOperationLogVisible()
+	case ViewComposeDetail:
+		return m.composeDetailView == nil || m.composeDetailView.IsOperationLogVisible() || m.composeDetailView.IsChecklistVisible()
+	case ViewLogs:
+		return m.logsView == nil || m.logsView.IsCapturingInput()
+	case ViewServices:
+		return m.servicesView.IsCapturingInput()
+	case ViewStacks:
+		return m.stacksView.IsCapturingInput()
+	case ViewTrash:
+		return m.trashView.IsCapturingInput()
+	case ViewEvents:
+		return m.eventsView.IsCapturingInput()
+	}
+	return false
+}
+
+// overlayPolicyNotice 在内容上叠加操作被拒绝的说明
+func (m Model) overlayPolicyNotice(content string) string {
+	hint := lipgloss.NewStyle().Foreground(ThemeTextMuted)
+	body := strings.Join([]string{
+		fmt.Sprintf("'%s' is not allowed: %v", m.policyNotice, policy.Check(m.policyNotice)),
+		"",
+		"Inspection, logs and stats are still available.",
+		"",
+		hint.Render("Press any key to close"),
+	}, "\n")
+	return components.OverlayCenteredBox(content, "🔒 Action disabled", body, m.width, m.height, "220")
+}
+
+// renderPolicyBadge 在第一行右侧显示只读/受限标记
+func (m Model) renderPolicyBadge(content string) string {
+	label := " 🔒 RESTRICTED "
+	if policy.ReadOnly() {
+		label = " 🔒 READ-ONLY "
+	}
+	badge := lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(ThemeWarning).Bold(true).Render(label)
+	badgeWidth := lipgloss.Width(badge)
+	if m.width <= badgeWidth {
+		return content
+	}
+
+	lines := strings.SplitN(content, "\n", 2)
+	first := lipgloss.NewStyle().MaxWidth(m.width - badgeWidth).Render(lines[0])
+	if w := lipgloss.Width(first); w < m.width-badgeWidth {
+		first += strings.Repeat(" ", m.width-badgeWidth-w)
+	}
+	lines[0] = first + badge
+	return strings.Join(lines, "\n")
+}
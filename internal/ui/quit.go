@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/task"
+	"docktui/internal/ui/components"
+)
+
+// quitState 退出流程：还有后台任务在运行时先询问如何处理
+type quitState int
+
+const (
+	quitNone       quitState = iota
+	quitPrompt               // 询问等待、取消还是直接退出
+	quitWaiting              // 等待任务结束后退出
+	quitCancelling           // 已取消任务，等待它们结束后退出
+)
+
+// quitCancelTimeout 取消任务后最多等待多久（任务不响应取消时仍然退出）
+const quitCancelTimeout = 10 * time.Second
+
+// quitCheckMsg 等待任务结束时的定时检查
+type quitCheckMsg struct{}
+
+func quitCheck() tea.Cmd {
+	return tea.Tick(500*time.Millisecond, func(time.Time) tea.Msg { return quitCheckMsg{} })
+}
+
+// requestQuit 处理 q / ctrl+c：没有运行中的任务时直接退出，否则显示确认弹窗
+func (m Model) requestQuit() (tea.Model, tea.Cmd) {
+	if len(task.GetManager().ListActiveTasks()) == 0 {
+		return m.shutdown()
+	}
+	m.showShellSelector = false
+	m.quit = quitPrompt
+	return m, nil
+}
+
+// handleQuitKeys 退出确认弹窗和等待期间的按键
+func (m Model) handleQuitKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.quit != quitPrompt {
+		// 等待期间再按一次 ctrl+c 立即退出，Esc 放弃退出继续使用
+		switch msg.String() {
+		case "ctrl+c":
+			return m.shutdown()
+		case "esc":
+			if m.quit == quitWaiting {
+				m.quit = quitNone
+			}
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "w":
+		m.quit = quitWaiting
+		return m, quitCheck()
+	case "c":
+		task.GetManager().CancelAll()
+		m.quit = quitCancelling
+		m.quitDeadline = time.Now().Add(quitCancelTimeout)
+		return m, quitCheck()
+	case "a", "ctrl+c":
+		// 直接退出：运行中的任务随进程结束被放弃，不会转到后台继续执行
+		return m.shutdown()
+	case "esc", "q":
+		m.quit = quitNone
+	}
+	return m, nil
+}
+
+// handleQuitCheck 任务全部结束（或取消超时）后退出
+func (m Model) handleQuitCheck() (tea.Model, tea.Cmd) {
+	if m.quit != quitWaiting && m.quit != quitCancelling {
+		return m, nil
+	}
+	if len(task.GetManager().ListActiveTasks()) == 0 {
+		return m.shutdown()
+	}
+	if m.quit == quitCancelling && time.Now().After(m.quitDeadline) {
+		return m.shutdown()
+	}
+	return m, quitCheck()
+}
+
+// shutdown 停止日志跟随、资源监控和 Docker 事件订阅后退出；
+// 审计日志和任务状态文件在每次变更时同步写入，等待或取消任务保证它们的结果也已写入
+func (m Model) shutdown() (tea.Model, tea.Cmd) {
+	if m.logsView != nil {
+		m.logsView.Cleanup()
+	}
+	if m.containerDetailView != nil {
+		m.containerDetailView.Stop()
+	}
+	if m.pinUnsubscribe != nil {
+		m.pinUnsubscribe()
+		m.pinUnsubscribe = nil
+	}
+	if m.eventHub != nil {
+		m.eventHub.Close()
+	}
+	return m, tea.Quit
+}
+
+// overlayQuitPrompt 在内容上叠加退出确认或等待进度
+func (m Model) overlayQuitPrompt(content string) string {
+	manager := task.GetManager()
+	// 按提交顺序显示，避免每次渲染顺序不同
+	var active []task.Task
+	for _, t := range manager.ListHistory() {
+		if s := t.Status(); s == task.StatusPending || s == task.StatusRunning {
+			active = append([]task.Task{t}, active...)
+		}
+	}
+	hint := lipgloss.NewStyle().Foreground(ThemeTextMuted)
+	keyStyle := lipgloss.NewStyle().Foreground(ThemeHighlight).Bold(true)
+
+	var lines []string
+	for i, t := range active {
+		if i == 5 {
+			lines = append(lines, hint.Render(fmt.Sprintf("  … and %d more", len(active)-i)))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  • %s %s", t.Name(), hint.Render(fmt.Sprintf("%.0f%%", t.Progress()))))
+	}
+
+	switch m.quit {
+	case quitWaiting:
+		lines = append([]string{fmt.Sprintf("⏳ Waiting for %d task(s) to finish...", len(active)), ""}, lines...)
+		lines = append(lines, "", hint.Render("Esc keep using docktui   ctrl+c quit now"))
+		return components.OverlayCenteredBox(content, "👋 Quitting", strings.Join(lines, "\n"), m.width, m.height, "220")
+	case quitCancelling:
+		lines = append([]string{fmt.Sprintf("🛑 Cancelling %d task(s)...", len(active)), ""}, lines...)
+		lines = append(lines, "", hint.Render("ctrl+c quit now"))
+		return components.OverlayCenteredBox(content, "👋 Quitting", strings.Join(lines, "\n"), m.width, m.height, "220")
+	}
+
+	resumable := 0
+	for _, t := range active {
+		if manager.WillResume(t) {
+			resumable++
+		}
+	}
+	leave := "abandon them and quit now"
+	switch {
+	case resumable == len(active):
+		leave += hint.Render(" (next launch offers to rerun them)")
+	case resumable > 0:
+		leave += hint.Render(fmt.Sprintf(" (next launch offers to rerun %d of them)", resumable))
+	}
+
+	lines = append([]string{fmt.Sprintf("%d background task(s) still running:", len(active)), ""}, lines...)
+	lines = append(lines, "",
+		keyStyle.Render("w")+" wait for them to finish, then quit",
+		keyStyle.Render("c")+" cancel them and quit",
+		keyStyle.Render("a")+" "+leave,
+		"",
+		hint.Render("Esc keep using docktui"),
+	)
+	return components.OverlayCenteredBox(content, "👋 Quit docktui?", strings.Join(lines, "\n"), m.width, m.height, "220")
+}
@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+	"docktui/internal/recipe"
+	"docktui/internal/ui/components"
+)
+
+// recipesLoadedMsg 模板加载结果
+type recipesLoadedMsg struct {
+	dir     string
+	recipes []recipe.Recipe
+	errs    []error
+}
+
+// recipeLaunchedMsg 模板启动结果
+type recipeLaunchedMsg struct {
+	name string
+	id   string
+	err  error
+}
+
+// RecipesView 容器模板列表，选中后一键启动
+type RecipesView struct {
+	dockerClient docker.Client
+
+	width  int
+	height int
+
+	dir       string
+	recipes   []recipe.Recipe
+	loadErrs  []error
+	loading   bool
+	cursor    int
+	launching string // 正在启动的模板名称
+	message   string
+	isError   bool
+}
+
+// NewRecipesView 创建模板视图
+func NewRecipesView(dockerClient docker.Client) *RecipesView {
+	return &RecipesView{dockerClient: dockerClient}
+}
+
+// Init 加载模板目录
+func (v *RecipesView) Init() tea.Cmd {
+	v.loading = true
+	return v.loadRecipes
+}
+
+func (v *RecipesView) loadRecipes() tea.Msg {
+	dir, err := recipe.DefaultDir()
+	if err != nil {
+		return recipesLoadedMsg{errs: []error{err}}
+	}
+	recipes, errs := recipe.LoadDir(dir)
+	return recipesLoadedMsg{dir: dir, recipes: recipes, errs: errs}
+}
+
+// Update 处理消息
+func (v *RecipesView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case recipesLoadedMsg:
+		v.loading = false
+		v.dir = msg.dir
+		v.recipes = msg.recipes
+		v.loadErrs = msg.errs
+		if v.cursor >= len(v.recipes) {
+			v.cursor = 0
+		}
+		return v, nil
+
+	case recipeLaunchedMsg:
+		v.launching = ""
+		if msg.err != nil {
+			v.message = fmt.Sprintf("❌ %s: %v", msg.name, msg.err)
+			v.isError = true
+		} else {
+			id := msg.id
+			if len(id) > 12 {
+				id = id[:12]
+			}
+			v.message = fmt.Sprintf("✅ Started %s (%s)", msg.name, id)
+			v.isError = false
+		}
+		return v, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "b":
+			return v, func() tea.Msg { return GoBackMsg{} }
+		case "j", "down":
+			if v.cursor < len(v.recipes)-1 {
+				v.cursor++
+			}
+		case "k", "up":
+			if v.cursor > 0 {
+				v.cursor--
+			}
+		case "r", "f5":
+			v.message = ""
+			return v, v.Init()
+		case "enter":
+			return v, components.RunAction("container.run", v.launchSelected)
+		}
+	}
+	return v, nil
+}
+
+// launchSelected 启动选中的模板
+func (v *RecipesView) launchSelected() tea.Cmd {
+	if v.launching != "" || len(v.recipes) == 0 {
+		return nil
+	}
+	r := v.recipes[v.cursor]
+	v.launching = r.Name
+	v.message = ""
+	return func() tea.Msg {
+		// 可能需要先拉取镜像，超时时间放宽
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+		id, err := v.dockerClient.RunContainer(ctx, r.RunOptions())
+		return recipeLaunchedMsg{name: r.Name, id: id, err: err}
+	}
+}
+
+// SetSize 设置视图尺寸
+func (v *RecipesView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// View 渲染视图
+func (v *RecipesView) View() string {
+	var b strings.Builder
+
+	b.WriteString("\n  " + auditTitleStyle.Render("🧪 Recipes"))
+	if v.dir != "" {
+		b.WriteString("  " + auditMutedStyle.Render(v.dir))
+	}
+	b.WriteString("\n\n")
+
+	switch {
+	case v.loading:
+		b.WriteString("  " + auditMutedStyle.Render("⏳ Loading...") + "\n")
+	case len(v.recipes) == 0:
+		b.WriteString("  " + auditMutedStyle.Render("No recipes yet. Create a YAML file in the directory above, e.g. postgres.yaml:") + "\n\n")
+		for _, line := range []string{
+			"image: postgres:16",
+			"container_name: pg-dev",
+			"ports: [\"5432:5432\"]",
+			"env:",
+			"  POSTGRES_PASSWORD: dev",
+			"volumes: [\"pgdata:/var/lib/postgresql/data\"]",
+		} {
+			b.WriteString("    " + auditMutedStyle.Render(line) + "\n")
+		}
+	default:
+		for i, r := range v.recipes {
+			prefix := "  "
+			name := auditHeaderStyle.Render(r.Name)
+			if i == v.cursor {
+				prefix = auditKeyStyle.Render("▶ ")
+				name = auditTitleStyle.Render(r.Name)
+			}
+			line := prefix + name + "  " + auditMutedStyle.Render(r.Image)
+			if r.Description != "" {
+				line += "  " + auditMutedStyle.Render("— "+r.Description)
+			}
+			b.WriteString("  " + line + "\n")
+		}
+		b.WriteString("\n" + v.renderSelected())
+	}
+
+	for _, err := range v.loadErrs {
+		b.WriteString("\n  " + auditErrorStyle.Render("⚠️ "+err.Error()))
+	}
+
+	switch {
+	case v.launching != "":
+		b.WriteString("\n\n  " + auditMutedStyle.Render("⏳ Launching "+v.launching+"..."))
+	case v.message != "" && v.isError:
+		b.WriteString("\n\n  " + auditErrorStyle.Render(v.message))
+	case v.message != "":
+		b.WriteString("\n\n  " + auditOKStyle.Render(v.message))
+	}
+
+	keys := []string{
+		auditKeyStyle.Render("j/k") + " Select",
+		auditKeyStyle.Render("Enter") + " Launch",
+		auditKeyStyle.Render("r") + " Reload",
+		auditKeyStyle.Render("Esc") + " Back",
+	}
+	b.WriteString("\n\n  " + auditMutedStyle.Render(strings.Join(keys, "  ")))
+	return b.String()
+}
+
+// renderSelected 渲染选中模板的配置
+func (v *RecipesView) renderSelected() string {
+	r := v.recipes[v.cursor]
+	var lines []string
+	row := func(label, value string) {
+		if value != "" {
+			lines = append(lines, "    "+auditKeyStyle.Render(fmt.Sprintf("%-10s", label))+" "+value)
+		}
+	}
+	row("Image", r.Image)
+	row("Name", r.ContainerName)
+	row("Command", strings.Join(r.Command, " "))
+	row("Ports", strings.Join(r.Ports, ", "))
+	for _, env := range r.EnvList() {
+		row("Env", env)
+	}
+	for _, vol := range r.Volumes {
+		row("Volume", vol)
+	}
+	row("Restart", r.Restart)
+	if p, ok := docker.FindResourcePreset(r.Resources); ok {
+		row("Resources", p.Name+" "+auditMutedStyle.Render("("+p.Summary()+")"))
+	}
+	if r.Remove {
+		row("Remove", "on exit")
+	}
+	row("File", auditMutedStyle.Render(r.Path))
+	return strings.Join(lines, "\n") + "\n"
+}
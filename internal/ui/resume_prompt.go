@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/task"
+	"docktui/internal/ui/components"
+)
+
+// SetPendingTasks 设置上次退出时未完成的任务，启动后先询问是否恢复，确认前不会重新提交
+func SetPendingTasks(m Model, specs []task.Spec) Model {
+	m.pendingTasks = specs
+	return m
+}
+
+// tasksResumedMsg 恢复或丢弃上次未完成任务的结果
+type tasksResumedMsg struct {
+	resumed   int
+	discarded int
+}
+
+// handleResumeKeys 恢复任务弹窗的按键：y 恢复，n 丢弃，Esc 下次启动再问
+func (m Model) handleResumeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.pendingTasks = nil
+		client := m.dockerClient
+		return m, func() tea.Msg {
+			return tasksResumedMsg{resumed: task.GetManager().ResumePending(client)}
+		}
+	case "n", "N":
+		m.pendingTasks = nil
+		return m, func() tea.Msg {
+			return tasksResumedMsg{discarded: task.GetManager().DiscardPending()}
+		}
+	case "esc":
+		m.pendingTasks = nil
+	}
+	return m, nil
+}
+
+// handleTasksResumed 显示恢复或丢弃的任务数量
+func (m Model) handleTasksResumed(msg tasksResumedMsg) (tea.Model, tea.Cmd) {
+	if msg.resumed > 0 {
+		return m, m.SetTemporaryMessage(MsgInfo, fmt.Sprintf("🔄 Resumed %d unfinished task(s) from last session, press t on the home view to follow them", msg.resumed), 5)
+	}
+	if msg.discarded > 0 {
+		return m, m.SetTemporaryMessage(MsgInfo, fmt.Sprintf("🗑️ Discarded %d unfinished task(s) from last session", msg.discarded), 3)
+	}
+	return m, nil
+}
+
+// overlayResumePrompt 在内容上叠加是否恢复上次未完成任务的确认弹窗
+func (m Model) overlayResumePrompt(content string) string {
+	hint := lipgloss.NewStyle().Foreground(ThemeTextMuted)
+	keyStyle := lipgloss.NewStyle().Foreground(ThemeHighlight).Bold(true)
+
+	lines := []string{fmt.Sprintf("%d task(s) did not finish when docktui last exited:", len(m.pendingTasks)), ""}
+	for i, spec := range m.pendingTasks {
+		if i == 5 {
+			lines = append(lines, hint.Render(fmt.Sprintf("  … and %d more", len(m.pendingTasks)-i)))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  • %s %s", spec.Name, hint.Render(spec.SubmittedAt.Format("01-02 15:04"))))
+	}
+	lines = append(lines, "",
+		hint.Render("Resuming starts them over: exports overwrite their target files"),
+		hint.Render("and imports load the archive again."),
+		"",
+		keyStyle.Render("y")+" resume them",
+		keyStyle.Render("n")+" discard them",
+		"",
+		hint.Render("Esc decide on next launch"),
+	)
+	return components.OverlayCenteredBox(content, "🔄 Resume unfinished tasks?", strings.Join(lines, "\n"), m.width, m.height, "220")
+}
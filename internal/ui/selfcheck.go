@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"docktui/internal/compose"
+	"docktui/internal/docker"
+	"docktui/internal/ui/styles"
+)
+
+// slowPing 守护进程响应超过该时间时提示（常见于远程连接）
+const slowPing = time.Second
+
+// selfCheckMsg 启动自检结果
+type selfCheckMsg struct {
+	results []docker.Diagnostic
+}
+
+// SetConfigProblems 设置启动时加载失败而被停用的配置（如损坏的备注文件），在首页自检中显示
+func SetConfigProblems(m Model, problems []string) Model {
+	m.configProblems = problems
+	return m
+}
+
+// runSelfCheck 启动自检：守护进程、compose 命令、配置文件和终端能力
+func (m Model) runSelfCheck() tea.Cmd {
+	client := m.dockerClient
+	problems := m.configProblems
+	return func() tea.Msg {
+		results := []docker.Diagnostic{checkDaemon(client), checkCompose(), checkConfig(problems)}
+		results = append(results, checkTerminal()...)
+		return selfCheckMsg{results: results}
+	}
+}
+
+// checkDaemon 检查守护进程是否响应及响应时间
+func checkDaemon(client docker.Client) docker.Diagnostic {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := client.Ping(ctx); err != nil {
+		return docker.Diagnostic{Check: "Daemon", Status: docker.DiagFail, Detail: err.Error(),
+			Suggestions: []string{"Press T to run connection troubleshooting"}}
+	}
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if elapsed > slowPing {
+		return docker.Diagnostic{Check: "Daemon", Status: docker.DiagWarn, Detail: fmt.Sprintf("responding slowly (%s)", elapsed),
+			Suggestions: []string{"Lists refresh slower on remote daemons; an ssh:// DOCKER_HOST with ControlMaster enabled helps"}}
+	}
+	return docker.Diagnostic{Check: "Daemon", Status: docker.DiagOK, Detail: fmt.Sprintf("responding (%s)", elapsed)}
+}
+
+// checkCompose 检查 compose 命令是否可用
+func checkCompose() docker.Diagnostic {
+	client, err := compose.NewClient()
+	if err != nil {
+		d := docker.Diagnostic{Check: "Compose", Status: docker.DiagWarn, Detail: "docker compose not found, the Compose view is unavailable"}
+		var composeErr *compose.ComposeError
+		if errors.As(err, &composeErr) && composeErr.Suggestion != "" {
+			d.Suggestions = []string{composeErr.Suggestion}
+		}
+		return d
+	}
+	version, _ := client.Version()
+	if client.CommandType() == "docker-compose" {
+		return docker.Diagnostic{Check: "Compose", Status: docker.DiagWarn, Detail: "docker-compose " + version + " (v1)",
+			Suggestions: []string{"docker-compose v1 is no longer maintained; install the docker compose plugin"}}
+	}
+	return docker.Diagnostic{Check: "Compose", Status: docker.DiagOK, Detail: client.CommandType() + " " + version}
+}
+
+// checkConfig 汇总启动时被停用的配置
+func checkConfig(problems []string) docker.Diagnostic {
+	if len(problems) == 0 {
+		return docker.Diagnostic{Check: "Config", Status: docker.DiagOK, Detail: "config files loaded"}
+	}
+	return docker.Diagnostic{Check: "Config", Status: docker.DiagWarn, Detail: strings.Join(problems, "; "),
+		Suggestions: []string{"Fix or remove the file and restart docktui"}}
+}
+
+// checkTerminal 检查终端颜色和 Unicode 支持
+func checkTerminal() []docker.Diagnostic {
+	var results []docker.Diagnostic
+
+	switch lipgloss.ColorProfile() {
+	case termenv.TrueColor:
+		results = append(results, docker.Diagnostic{Check: "Colors", Status: docker.DiagOK, Detail: "truecolor"})
+	case termenv.ANSI256:
+		results = append(results, docker.Diagnostic{Check: "Colors", Status: docker.DiagOK, Detail: "256 colors"})
+	case termenv.ANSI:
+		results = append(results, docker.Diagnostic{Check: "Colors", Status: docker.DiagWarn, Detail: "16 colors only (TERM=" + os.Getenv("TERM") + ")",
+			Suggestions: []string{"Set TERM=xterm-256color or COLORTERM=truecolor if your terminal supports it"}})
+	default:
+		results = append(results, docker.Diagnostic{Check: "Colors", Status: docker.DiagWarn, Detail: "no color support detected",
+			Suggestions: []string{"Output is not a color terminal; unset NO_COLOR or set TERM=xterm-256color"}})
+	}
+
+	// Windows 终端不依赖 locale 环境变量
+	if runtime.GOOS == "windows" {
+		return results
+	}
+	locale := firstEnv("LC_ALL", "LC_CTYPE", "LANG")
+	switch {
+	case os.Getenv("TERM") == "linux":
+		results = append(results, docker.Diagnostic{Check: "Unicode", Status: docker.DiagWarn, Detail: "Linux console: emoji and some symbols cannot be displayed",
+			Suggestions: []string{"Use a terminal emulator or connect over SSH for full rendering", asciiHint()}})
+	case isUTF8Locale(locale):
+		results = append(results, docker.Diagnostic{Check: "Unicode", Status: docker.DiagOK, Detail: "UTF-8 (" + locale + ")"})
+	default:
+		detail := "locale is not UTF-8"
+		if locale != "" {
+			detail += " (" + locale + ")"
+		}
+		results = append(results, docker.Diagnostic{Check: "Unicode", Status: docker.DiagWarn, Detail: detail + ", icons and borders may render as garbage",
+			Suggestions: []string{"export LANG=C.UTF-8 (or en_US.UTF-8) before starting docktui", asciiHint()}})
+	}
+	return results
+}
+
+// asciiHint 返回 ASCII 回退模式的提示
+func asciiHint() string {
+	if styles.ASCII() {
+		return "ASCII fallback is on (--ascii=off to disable)"
+	}
+	return "Start with --ascii=on to replace icons and borders with plain text"
+}
+
+// firstEnv 返回第一个非空的环境变量值
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// isUTF8Locale 是否是 UTF-8 locale（如 en_US.UTF-8、C.utf8）
+func isUTF8Locale(locale string) bool {
+	l := strings.ToLower(locale)
+	return strings.Contains(l, "utf-8") || strings.Contains(l, "utf8")
+}
+
+// renderSelfCheck 渲染首页的自检结果：全部通过时显示一行摘要，否则列出有问题的项和处理建议
+func (v *HomeView) renderSelfCheck() string {
+	if v.selfCheck == nil {
+		return ""
+	}
+	width := v.width
+	if width < 80 {
+		width = 80
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	var lines []string
+	var passed []string
+	for _, d := range v.selfCheck {
+		if d.Status == docker.DiagOK {
+			passed = append(passed, d.Check)
+			continue
+		}
+		mark := lipgloss.NewStyle().Foreground(ThemeWarning).Render("▲")
+		if d.Status == docker.DiagFail {
+			mark = lipgloss.NewStyle().Foreground(ThemeError).Render("✖")
+		}
+		line := mark + " " + lipgloss.NewStyle().Bold(true).Render(d.Check+":") + " " + d.Detail
+		if len(d.Suggestions) > 0 {
+			line += mutedStyle.Render("  → " + d.Suggestions[0])
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, mutedStyle.Render("✓ Self-check passed: "+strings.Join(passed, ", ")))
+	}
+
+	for i, line := range lines {
+		if lipgloss.Width(line) > width-4 {
+			line = lipgloss.NewStyle().MaxWidth(width - 4).Render(line)
+		}
+		lines[i] = strings.Repeat(" ", max(2, (width-lipgloss.Width(line))/2)) + line
+	}
+	return strings.Join(lines, "\n")
+}
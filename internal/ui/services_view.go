@@ -0,0 +1,533 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/ui/components"
+)
+
+// servicesRefreshInterval 服务列表自动刷新间隔（观察滚动更新和扩缩容进度）
+var servicesRefreshInterval = 3 * time.Second
+
+// servicesTabs 视图的标签页：服务、secret、config（后两者只读）
+var servicesTabs = []string{"Services", "Secrets", "Configs"}
+
+// servicesLoadedMsg 服务列表加载结果
+type servicesLoadedMsg struct {
+	manager  bool
+	services []docker.Service
+	secrets  []docker.SwarmObject
+	configs  []docker.SwarmObject
+	err      error
+}
+
+// servicesTickMsg 定时刷新服务列表
+type servicesTickMsg struct {
+	gen int
+}
+
+// serviceActionMsg 扩缩容/强制更新的结果
+type serviceActionMsg struct {
+	text string
+	err  error
+}
+
+// serviceInspectMsg 服务的原始 JSON
+type serviceInspectMsg struct {
+	name string
+	json string
+	err  error
+}
+
+// ServicesView Swarm 服务列表：副本数、镜像、更新状态，支持扩缩容和强制更新；另有只读的 secret/config 列表
+type ServicesView struct {
+	dockerClient docker.Client
+
+	width  int
+	height int
+
+	loading  bool
+	loaded   bool
+	manager  bool // 守护进程是否为 Swarm 管理节点
+	services []docker.Service
+	secrets  []docker.SwarmObject
+	configs  []docker.SwarmObject
+	err      error
+	tab      int // 当前标签页，见 servicesTabs
+	cursor   int
+	gen      int // 刷新循环的代次，重新进入视图时旧的循环自然结束
+	refresh  *components.RefreshTimer
+
+	scaling     bool // 正在输入副本数
+	scaleInput  textinput.Model
+	confirmName string // 等待确认强制更新的服务名称
+	message     string
+	isError     bool
+
+	jsonViewer *components.JSONViewer
+}
+
+// NewServicesView 创建服务视图
+func NewServicesView(dockerClient docker.Client) *ServicesView {
+	input := textinput.New()
+	input.CharLimit = 5
+	input.Width = 8
+	input.Prompt = ""
+	return &ServicesView{
+		dockerClient: dockerClient,
+		scaleInput:   input,
+		jsonViewer:   components.NewJSONViewer(),
+		refresh:      components.NewRefreshTimer(servicesRefreshInterval),
+	}
+}
+
+// Init 加载服务列表并开始定时刷新
+func (v *ServicesView) Init() tea.Cmd {
+	v.loading = true
+	v.gen++
+	v.refresh.SetInterval(servicesRefreshInterval)
+	v.refresh.Start()
+	return tea.Batch(v.loadServices, v.tick())
+}
+
+// tick 每秒一次：到期时刷新，否则只更新倒计时
+func (v *ServicesView) tick() tea.Cmd {
+	gen := v.gen
+	return tea.Tick(v.refresh.Step(), func(time.Time) tea.Msg { return servicesTickMsg{gen: gen} })
+}
+
+func (v *ServicesView) loadServices() tea.Msg {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	manager, err := v.dockerClient.SwarmManager(ctx)
+	if err != nil || !manager {
+		return servicesLoadedMsg{err: err}
+	}
+	msg := servicesLoadedMsg{manager: true}
+	if msg.services, err = v.dockerClient.ListServices(ctx); err != nil {
+		msg.err = err
+		return msg
+	}
+	if msg.secrets, err = v.dockerClient.ListSecrets(ctx); err != nil {
+		msg.err = err
+		return msg
+	}
+	msg.configs, msg.err = v.dockerClient.ListConfigs(ctx)
+	return msg
+}
+
+// Update 处理消息
+func (v *ServicesView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case servicesLoadedMsg:
+		v.loading = false
+		v.loaded = true
+		v.manager = msg.manager
+		v.err = msg.err
+		v.refresh.Mark()
+		if msg.err == nil {
+			v.services, v.secrets, v.configs = msg.services, msg.secrets, msg.configs
+		}
+		v.clampCursor()
+		return v, nil
+
+	case servicesTickMsg:
+		// 不是 Swarm 管理节点时不再轮询
+		if msg.gen != v.gen || (v.loaded && !v.manager) {
+			return v, nil
+		}
+		if !v.refresh.Due() {
+			return v, v.tick()
+		}
+		v.refresh.Start()
+		return v, tea.Batch(v.loadServices, v.tick())
+
+	case serviceActionMsg:
+		if msg.err != nil {
+			v.message, v.isError = "❌ "+msg.err.Error(), true
+		} else {
+			v.message, v.isError = "✅ "+msg.text, false
+		}
+		return v, v.loadServices
+
+	case serviceInspectMsg:
+		if msg.err != nil {
+			v.message, v.isError = "❌ "+msg.err.Error(), true
+			return v, nil
+		}
+		v.jsonViewer.SetSize(v.width, v.height)
+		v.jsonViewer.Show("Service Inspect: "+msg.name, msg.json)
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.jsonViewer.IsVisible() {
+			v.jsonViewer.Update(msg)
+			return v, nil
+		}
+		if v.scaling {
+			return v, v.handleScaleKey(msg)
+		}
+		if v.confirmName != "" {
+			return v, v.handleConfirmKey(msg)
+		}
+		return v, v.handleKey(msg)
+	}
+	return v, nil
+}
+
+func (v *ServicesView) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "b":
+		return func() tea.Msg { return GoBackMsg{} }
+	case "tab", "l", "right":
+		v.switchTab((v.tab + 1) % len(servicesTabs))
+	case "shift+tab", "h", "left":
+		v.switchTab((v.tab + len(servicesTabs) - 1) % len(servicesTabs))
+	case "1", "2", "3":
+		v.switchTab(int(msg.String()[0] - '1'))
+	case "j", "down":
+		if v.cursor < v.rowCount()-1 {
+			v.cursor++
+		}
+	case "k", "up":
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case "g":
+		v.cursor = 0
+	case "G":
+		v.cursor = v.rowCount() - 1
+		v.clampCursor()
+	case "r", "f5":
+		v.message = ""
+		return v.loadServices
+	}
+
+	// 以下操作只针对服务，secret 和 config 只读
+	if v.tab != 0 {
+		return nil
+	}
+	switch msg.String() {
+	case "S":
+		return components.RunAction("service.scale", v.startScale)
+	case "F":
+		return components.RunAction("service.update", func() tea.Cmd {
+			if svc := v.selected(); svc != nil {
+				v.confirmName = svc.Name
+				v.message = ""
+			}
+			return nil
+		})
+	case "enter", "i":
+		return v.inspectSelected()
+	}
+	return nil
+}
+
+// startScale 打开所选服务的副本数输入框
+func (v *ServicesView) startScale() tea.Cmd {
+	svc := v.selected()
+	if svc == nil {
+		return nil
+	}
+	if !svc.IsReplicated() {
+		v.message, v.isError = fmt.Sprintf("⚠️ %s runs in %s mode, only replicated services can be scaled", svc.Name, svc.Mode), true
+		return nil
+	}
+	v.scaling = true
+	v.message = ""
+	v.scaleInput.SetValue(strconv.FormatUint(svc.DesiredTasks, 10))
+	v.scaleInput.CursorEnd()
+	return v.scaleInput.Focus()
+}
+
+func (v *ServicesView) handleScaleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		v.scaling = false
+		v.scaleInput.Blur()
+		return nil
+	case tea.KeyEnter:
+		replicas, err := strconv.ParseUint(strings.TrimSpace(v.scaleInput.Value()), 10, 64)
+		if err != nil {
+			v.message, v.isError = fmt.Sprintf("❌ Invalid replica count %q", v.scaleInput.Value()), true
+			return nil
+		}
+		v.scaling = false
+		v.scaleInput.Blur()
+		svc := v.selected()
+		if svc == nil {
+			return nil
+		}
+		id, name := svc.ID, svc.Name
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err := v.dockerClient.ScaleService(ctx, id, replicas)
+			return serviceActionMsg{text: fmt.Sprintf("Scaled %s to %d", name, replicas), err: err}
+		}
+	}
+	var cmd tea.Cmd
+	v.scaleInput, cmd = v.scaleInput.Update(msg)
+	return cmd
+}
+
+func (v *ServicesView) handleConfirmKey(msg tea.KeyMsg) tea.Cmd {
+	name := v.confirmName
+	v.confirmName = ""
+	if msg.String() != "y" && msg.String() != "Y" {
+		return nil
+	}
+	svc := v.selected()
+	if svc == nil || svc.Name != name {
+		return nil
+	}
+	id := svc.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		err := v.dockerClient.ForceUpdateService(ctx, id)
+		return serviceActionMsg{text: "Force update started for " + name, err: err}
+	}
+}
+
+func (v *ServicesView) inspectSelected() tea.Cmd {
+	svc := v.selected()
+	if svc == nil {
+		return nil
+	}
+	id, name := svc.ID, svc.Name
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		content, err := v.dockerClient.InspectServiceRaw(ctx, id)
+		return serviceInspectMsg{name: name, json: content, err: err}
+	}
+}
+
+func (v *ServicesView) selected() *docker.Service {
+	if v.tab != 0 || v.cursor < 0 || v.cursor >= len(v.services) {
+		return nil
+	}
+	return &v.services[v.cursor]
+}
+
+// objects 返回当前标签页的 secret 或 config 列表
+func (v *ServicesView) objects() []docker.SwarmObject {
+	if v.tab == 1 {
+		return v.secrets
+	}
+	return v.configs
+}
+
+func (v *ServicesView) rowCount() int {
+	if v.tab == 0 {
+		return len(v.services)
+	}
+	return len(v.objects())
+}
+
+func (v *ServicesView) switchTab(tab int) {
+	if tab != v.tab {
+		v.tab = tab
+		v.cursor = 0
+		v.message = ""
+	}
+}
+
+func (v *ServicesView) clampCursor() {
+	if v.cursor >= v.rowCount() {
+		v.cursor = v.rowCount() - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+// IsCapturingInput 是否正在输入副本数、确认操作或查看 JSON
+func (v *ServicesView) IsCapturingInput() bool {
+	return v.scaling || v.confirmName != "" || v.jsonViewer.IsVisible()
+}
+
+// SetSize 设置视图尺寸
+func (v *ServicesView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.jsonViewer.SetSize(width, height)
+}
+
+// View 渲染视图
+func (v *ServicesView) View() string {
+	if v.jsonViewer.IsVisible() {
+		return v.jsonViewer.View()
+	}
+
+	var b strings.Builder
+	b.WriteString("\n  " + auditTitleStyle.Render("🐝 Swarm"))
+	if v.manager {
+		b.WriteString("  " + v.refresh.View())
+	}
+	b.WriteString("\n\n")
+	var tabs []string
+	for i, name := range servicesTabs {
+		label := fmt.Sprintf("[%d] %s", i+1, name)
+		if i == v.tab {
+			tabs = append(tabs, auditTitleStyle.Render(label))
+		} else {
+			tabs = append(tabs, auditMutedStyle.Render(label))
+		}
+	}
+	b.WriteString("  " + strings.Join(tabs, auditMutedStyle.Render("  │  ")) + "\n\n")
+
+	switch {
+	case v.loading && !v.loaded:
+		b.WriteString("  " + auditMutedStyle.Render("⏳ Loading...") + "\n")
+	case v.err != nil:
+		b.WriteString("  " + auditErrorStyle.Render("❌ "+v.err.Error()) + "\n")
+	case !v.manager:
+		b.WriteString("  " + auditMutedStyle.Render("This daemon is not a Swarm manager. Services are only visible on manager nodes;") + "\n")
+		b.WriteString("  " + auditMutedStyle.Render("on worker nodes the containers list shows the tasks running here.") + "\n")
+	case v.tab != 0:
+		v.renderObjects(&b)
+	case len(v.services) == 0:
+		b.WriteString("  " + auditMutedStyle.Render("No services. Deploy one with docker service create or docker stack deploy.") + "\n")
+	default:
+		header := fmt.Sprintf("  %-28s  %-14s  %-8s  %-36s  %-18s  %s", "NAME", "MODE", "REPLICAS", "IMAGE", "PORTS", "UPDATE")
+		b.WriteString(auditHeaderStyle.Render(header) + "\n")
+		for i, svc := range v.services {
+			prefix := "  "
+			name := fmt.Sprintf("%-28s", format.Truncate(svc.Name, 28))
+			if i == v.cursor {
+				prefix = auditKeyStyle.Render("▶ ")
+				name = auditTitleStyle.Render(name)
+			}
+			replicas := fmt.Sprintf("%-8s", svc.Replicas())
+			if svc.RunningTasks < svc.DesiredTasks {
+				replicas = auditErrorStyle.Render(replicas)
+			} else {
+				replicas = auditOKStyle.Render(replicas)
+			}
+			line := fmt.Sprintf("%s%s  %-14s  %s  %-36s  %-18s  %s",
+				prefix,
+				name,
+				svc.Mode,
+				replicas,
+				format.Truncate(svc.Image, 36),
+				format.Truncate(svc.Ports, 18),
+				renderUpdateState(svc),
+			)
+			b.WriteString(line + "\n")
+		}
+		if svc := v.selected(); svc != nil && svc.UpdateMessage != "" {
+			b.WriteString("\n  " + auditMutedStyle.Render(svc.Name+": "+svc.UpdateMessage) + "\n")
+		}
+	}
+
+	switch {
+	case v.scaling:
+		b.WriteString("\n  " + auditKeyStyle.Render("Replicas:") + " " + v.scaleInput.View() +
+			"  " + auditMutedStyle.Render("[Enter=Scale] [Esc=Cancel]"))
+	case v.confirmName != "":
+		b.WriteString("\n  " + auditErrorStyle.Render("Force update "+v.confirmName+"? All tasks will be recreated.") +
+			"  " + auditMutedStyle.Render("[y=Confirm] [any key=Cancel]"))
+	case v.message != "" && v.isError:
+		b.WriteString("\n  " + auditErrorStyle.Render(v.message))
+	case v.message != "":
+		b.WriteString("\n  " + auditOKStyle.Render(v.message))
+	}
+
+	keys := []string{
+		auditKeyStyle.Render("Tab") + " Switch",
+		auditKeyStyle.Render("j/k") + " Select",
+	}
+	if v.tab == 0 {
+		keys = append(keys,
+			auditKeyStyle.Render("S")+" Scale",
+			auditKeyStyle.Render("F")+" Force update",
+			auditKeyStyle.Render("Enter")+" Inspect",
+		)
+	}
+	keys = append(keys,
+		auditKeyStyle.Render("r")+" Refresh",
+		auditKeyStyle.Render("Esc")+" Back",
+	)
+	b.WriteString("\n\n  " + auditMutedStyle.Render(strings.Join(keys, "  ")))
+	return b.String()
+}
+
+// renderObjects 渲染 secret/config 列表及选中项的标签和引用它的服务
+func (v *ServicesView) renderObjects(b *strings.Builder) {
+	objs := v.objects()
+	kind := strings.ToLower(servicesTabs[v.tab])
+	if len(objs) == 0 {
+		b.WriteString("  " + auditMutedStyle.Render("No "+kind+". Create one with docker "+strings.TrimSuffix(kind, "s")+" create.") + "\n")
+		return
+	}
+
+	header := fmt.Sprintf("  %-36s  %-16s  %-16s  %s", "NAME", "CREATED", "UPDATED", "SERVICES")
+	b.WriteString(auditHeaderStyle.Render(header) + "\n")
+	for i, obj := range objs {
+		prefix := "  "
+		name := fmt.Sprintf("%-36s", format.Truncate(obj.Name, 36))
+		if i == v.cursor {
+			prefix = auditKeyStyle.Render("▶ ")
+			name = auditTitleStyle.Render(name)
+		}
+		used := auditMutedStyle.Render("unused")
+		if n := len(obj.Services); n > 0 {
+			used = auditOKStyle.Render(fmt.Sprintf("%d", n))
+		}
+		b.WriteString(fmt.Sprintf("%s%s  %-16s  %-16s  %s\n", prefix, name,
+			obj.Created.Local().Format("2006-01-02 15:04"), obj.Updated.Local().Format("2006-01-02 15:04"), used))
+	}
+
+	if v.cursor >= len(objs) {
+		return
+	}
+	obj := objs[v.cursor]
+	row := func(label, value string) {
+		b.WriteString("    " + auditKeyStyle.Render(fmt.Sprintf("%-10s", label)) + " " + value + "\n")
+	}
+	b.WriteString("\n")
+	row("ID", obj.ID)
+	if obj.Driver != "" {
+		row("Driver", obj.Driver)
+	}
+	if len(obj.Services) > 0 {
+		row("Used by", strings.Join(obj.Services, ", "))
+	} else {
+		row("Used by", auditMutedStyle.Render("no services"))
+	}
+	labels := make([]string, 0, len(obj.Labels))
+	for k, val := range obj.Labels {
+		labels = append(labels, k+"="+val)
+	}
+	sort.Strings(labels)
+	for _, l := range labels {
+		row("Label", l)
+	}
+}
+
+// renderUpdateState 渲染服务的滚动更新状态
+func renderUpdateState(svc docker.Service) string {
+	switch svc.UpdateState {
+	case "":
+		return auditMutedStyle.Render("-")
+	case "completed", "rollback_completed":
+		return auditOKStyle.Render(svc.UpdateState)
+	case "paused", "rollback_paused":
+		return auditErrorStyle.Render(svc.UpdateState)
+	default:
+		return auditKeyStyle.Render("⏳ " + svc.UpdateState)
+	}
+}
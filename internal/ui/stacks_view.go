@@ -0,0 +1,603 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/stack"
+	"docktui/internal/task"
+	"docktui/internal/ui/components"
+	"docktui/internal/ui/styles"
+)
+
+// stackTaskPollInterval 栈任务运行期间刷新进度的间隔
+const stackTaskPollInterval = 500 * time.Millisecond
+
+// stackContainersMsg 容器列表加载结果（显示成员状态、选择成员）
+type stackContainersMsg struct {
+	containers []docker.Container
+	err        error
+}
+
+// stackTaskTickMsg 定时查看栈任务的进度
+type stackTaskTickMsg struct {
+	taskID string
+}
+
+// StacksView 容器栈：把没有用 compose 管理的容器组织成命名的栈，按顺序和间隔一键启动、按相反顺序停止
+type StacksView struct {
+	dockerClient docker.Client
+
+	width  int
+	height int
+
+	stacks     []stack.Stack
+	containers map[string]docker.Container // 容器名称 -> 容器
+	loading    bool
+	cursor     int
+
+	editing      bool // 正在调整选中栈的成员
+	memberCursor int
+
+	picker      *components.ResourcePicker
+	pickerShown bool
+	adding      bool // 选择器用于向已有的栈添加成员（否则是新建）
+
+	naming     bool // 正在输入新栈的名称
+	nameInput  textinput.Model
+	newMembers []stack.Member
+
+	delaying   bool // 正在输入成员启动后的等待秒数
+	delayInput textinput.Model
+
+	confirmDelete string // 等待确认删除的栈名称
+
+	taskID  string // 正在运行的栈任务
+	message string
+	isError bool
+}
+
+// NewStacksView 创建栈视图
+func NewStacksView(dockerClient docker.Client) *StacksView {
+	nameInput := textinput.New()
+	nameInput.CharLimit = 48
+	nameInput.Width = 30
+	nameInput.Prompt = ""
+	nameInput.Placeholder = "legacy-app"
+	delayInput := textinput.New()
+	delayInput.CharLimit = 4
+	delayInput.Width = 6
+	delayInput.Prompt = ""
+	return &StacksView{
+		dockerClient: dockerClient,
+		containers:   make(map[string]docker.Container),
+		picker:       components.NewResourcePicker("📚 New Stack", true),
+		nameInput:    nameInput,
+		delayInput:   delayInput,
+	}
+}
+
+// Init 读取栈并加载容器状态
+func (v *StacksView) Init() tea.Cmd {
+	v.reloadStacks()
+	v.loading = true
+	return v.loadContainers
+}
+
+func (v *StacksView) loadContainers() tea.Msg {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	containers, err := v.dockerClient.ListContainers(ctx, true)
+	return stackContainersMsg{containers: containers, err: err}
+}
+
+// reloadStacks 重新读取栈列表，保持光标在原来的栈上
+func (v *StacksView) reloadStacks() {
+	store := stack.Default()
+	if store == nil {
+		v.stacks = nil
+		return
+	}
+	current := ""
+	if st := v.selected(); st != nil {
+		current = st.Name
+	}
+	v.stacks = store.List()
+	for i, st := range v.stacks {
+		if st.Name == current {
+			v.cursor = i
+		}
+	}
+	v.clampCursor()
+}
+
+// Update 处理消息
+func (v *StacksView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case stackContainersMsg:
+		v.loading = false
+		if msg.err != nil {
+			v.message, v.isError = "❌ Failed to list containers: "+msg.err.Error(), true
+			if v.pickerShown {
+				v.picker.SetError(msg.err.Error())
+			}
+			return v, nil
+		}
+		v.containers = make(map[string]docker.Container, len(msg.containers))
+		for _, c := range msg.containers {
+			v.containers[c.Name] = c
+		}
+		if v.pickerShown {
+			v.picker.SetItems(v.pickerItems(msg.containers))
+		}
+		return v, nil
+
+	case stackTaskTickMsg:
+		if msg.taskID != v.taskID {
+			return v, nil
+		}
+		t := task.GetManager().GetTask(v.taskID)
+		if t == nil {
+			v.taskID = ""
+			return v, nil
+		}
+		switch t.Status() {
+		case task.StatusPending, task.StatusRunning:
+			v.message, v.isError = fmt.Sprintf("⏳ %s: %s", t.Name(), t.Message()), false
+			return v, v.pollTask()
+		case task.StatusCompleted:
+			v.message, v.isError = "✅ "+t.Message(), false
+		default:
+			v.message, v.isError = fmt.Sprintf("❌ %s: %s", t.Name(), t.Message()), true
+		}
+		v.taskID = ""
+		return v, v.loadContainers
+
+	case tea.KeyMsg:
+		switch {
+		case v.pickerShown:
+			return v, v.handlePickerKey(msg)
+		case v.naming:
+			return v, v.handleNameKey(msg)
+		case v.delaying:
+			return v, v.handleDelayKey(msg)
+		case v.confirmDelete != "":
+			return v, v.handleDeleteKey(msg)
+		case v.editing:
+			return v, v.handleEditKey(msg)
+		}
+		return v, v.handleKey(msg)
+	}
+	return v, nil
+}
+
+func (v *StacksView) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "b":
+		return func() tea.Msg { return GoBackMsg{} }
+	case "j", "down":
+		if v.cursor < len(v.stacks)-1 {
+			v.cursor++
+		}
+	case "k", "up":
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case "r", "f5":
+		v.message = ""
+		return v.Init()
+	case "n":
+		if stack.Default() == nil {
+			v.message, v.isError = "❌ Stacks are not available (the stacks file could not be opened)", true
+			return nil
+		}
+		return v.showPicker(false)
+	case "enter", "e":
+		if v.selected() != nil {
+			v.editing = true
+			v.memberCursor = 0
+			v.message = ""
+		}
+	case "t":
+		return components.RunAction("container.start", func() tea.Cmd { return v.runSelected(task.StackStart) })
+	case "s":
+		return components.RunAction("container.stop", func() tea.Cmd { return v.runSelected(task.StackStop) })
+	case "ctrl+d":
+		if st := v.selected(); st != nil {
+			v.confirmDelete = st.Name
+			v.message = ""
+		}
+	}
+	return nil
+}
+
+// handleEditKey 调整成员：J/K 移动顺序，w 设置等待时长，a 添加，x 移除
+func (v *StacksView) handleEditKey(msg tea.KeyMsg) tea.Cmd {
+	st := v.selected()
+	if st == nil {
+		v.editing = false
+		return nil
+	}
+	switch msg.String() {
+	case "esc", "enter":
+		v.editing = false
+	case "j", "down":
+		if v.memberCursor < len(st.Members)-1 {
+			v.memberCursor++
+		}
+	case "k", "up":
+		if v.memberCursor > 0 {
+			v.memberCursor--
+		}
+	case "J", "shift+down":
+		if v.memberCursor < len(st.Members)-1 {
+			i := v.memberCursor
+			st.Members[i], st.Members[i+1] = st.Members[i+1], st.Members[i]
+			v.memberCursor++
+			v.save(*st, "")
+		}
+	case "K", "shift+up":
+		if v.memberCursor > 0 {
+			i := v.memberCursor
+			st.Members[i], st.Members[i-1] = st.Members[i-1], st.Members[i]
+			v.memberCursor--
+			v.save(*st, "")
+		}
+	case "w":
+		if v.memberCursor < len(st.Members) {
+			v.delaying = true
+			v.delayInput.SetValue(strconv.Itoa(st.Members[v.memberCursor].DelaySeconds))
+			v.delayInput.CursorEnd()
+			return v.delayInput.Focus()
+		}
+	case "a":
+		return v.showPicker(true)
+	case "x":
+		if len(st.Members) <= 1 {
+			v.message, v.isError = "⚠️ A stack needs at least one container; delete the stack with Ctrl+D instead", true
+			return nil
+		}
+		name := st.Members[v.memberCursor].Container
+		st.Members = append(st.Members[:v.memberCursor], st.Members[v.memberCursor+1:]...)
+		if v.memberCursor >= len(st.Members) {
+			v.memberCursor = len(st.Members) - 1
+		}
+		v.save(*st, "Removed "+name+" from "+st.Name)
+	}
+	return nil
+}
+
+// showPicker 打开容器选择器；adding 为 true 时向选中的栈添加成员
+func (v *StacksView) showPicker(adding bool) tea.Cmd {
+	v.adding = adding
+	v.pickerShown = true
+	v.message = ""
+	if adding {
+		v.picker.SetTitle("➕ Add to "+v.selected().Name, "Selected containers are appended in list order")
+	} else {
+		v.picker.SetTitle("📚 New Stack", "Start order follows the list; reorder later with J/K")
+	}
+	v.picker.SetSize(v.width, v.height)
+	v.picker.SetLoading("Loading containers...")
+	return v.loadContainers
+}
+
+// pickerItems 选择器中的容器，添加成员时排除已在栈中的容器
+func (v *StacksView) pickerItems(containers []docker.Container) []components.PickerItem {
+	st := v.selected()
+	var list []docker.Container
+	for _, c := range containers {
+		if v.adding && st != nil && st.Has(c.Name) {
+			continue
+		}
+		list = append(list, c)
+	}
+	return components.ContainerPickerItems(list)
+}
+
+func (v *StacksView) handlePickerKey(msg tea.KeyMsg) tea.Cmd {
+	confirmed, cancelled, cmd := v.picker.Update(msg)
+	if cancelled {
+		v.pickerShown = false
+		return nil
+	}
+	if !confirmed {
+		return cmd
+	}
+	selected := v.picker.Selected()
+	if len(selected) == 0 {
+		return nil
+	}
+	v.pickerShown = false
+	members := make([]stack.Member, 0, len(selected))
+	for _, item := range selected {
+		members = append(members, stack.Member{Container: item.Name})
+	}
+	if v.adding {
+		if st := v.selected(); st != nil {
+			st.Members = append(st.Members, members...)
+			v.save(*st, fmt.Sprintf("Added %d container(s) to %s", len(members), st.Name))
+		}
+		return nil
+	}
+	v.newMembers = members
+	v.naming = true
+	v.nameInput.SetValue("")
+	return v.nameInput.Focus()
+}
+
+func (v *StacksView) handleNameKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		v.naming = false
+		v.nameInput.Blur()
+		v.newMembers = nil
+		return nil
+	case tea.KeyEnter:
+		name := strings.TrimSpace(v.nameInput.Value())
+		if name == "" {
+			v.message, v.isError = "❌ Enter a name for the stack", true
+			return nil
+		}
+		if _, exists := stack.Default().Get(name); exists {
+			v.message, v.isError = fmt.Sprintf("❌ Stack %s already exists", name), true
+			return nil
+		}
+		v.naming = false
+		v.nameInput.Blur()
+		st := stack.Stack{Name: name, Members: v.newMembers}
+		v.newMembers = nil
+		if v.save(st, fmt.Sprintf("Created stack %s with %d container(s)", name, len(st.Members))) {
+			for i, s := range v.stacks {
+				if s.Name == name {
+					v.cursor = i
+				}
+			}
+		}
+		return nil
+	}
+	var cmd tea.Cmd
+	v.nameInput, cmd = v.nameInput.Update(msg)
+	return cmd
+}
+
+func (v *StacksView) handleDelayKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		v.delaying = false
+		v.delayInput.Blur()
+		return nil
+	case tea.KeyEnter:
+		seconds, err := strconv.Atoi(strings.TrimSpace(v.delayInput.Value()))
+		if err != nil || seconds < 0 || time.Duration(seconds)*time.Second > stack.MaxDelay {
+			v.message, v.isError = fmt.Sprintf("❌ Enter a number of seconds from 0 to %d", int(stack.MaxDelay.Seconds())), true
+			return nil
+		}
+		v.delaying = false
+		v.delayInput.Blur()
+		if st := v.selected(); st != nil && v.memberCursor < len(st.Members) {
+			st.Members[v.memberCursor].DelaySeconds = seconds
+			v.save(*st, "")
+		}
+		return nil
+	}
+	var cmd tea.Cmd
+	v.delayInput, cmd = v.delayInput.Update(msg)
+	return cmd
+}
+
+func (v *StacksView) handleDeleteKey(msg tea.KeyMsg) tea.Cmd {
+	name := v.confirmDelete
+	v.confirmDelete = ""
+	if msg.String() != "y" && msg.String() != "Y" {
+		return nil
+	}
+	if err := stack.Default().Delete(name); err != nil {
+		v.message, v.isError = "❌ "+err.Error(), true
+		return nil
+	}
+	v.reloadStacks()
+	v.message, v.isError = "🗑️ Deleted stack "+name+" (the containers are unchanged)", false
+	return nil
+}
+
+// save 保存栈并刷新列表；text 不为空时显示为结果消息
+func (v *StacksView) save(st stack.Stack, text string) bool {
+	store := stack.Default()
+	if store == nil {
+		v.message, v.isError = "❌ Stacks are not available", true
+		return false
+	}
+	if err := store.Save(st); err != nil {
+		v.message, v.isError = "❌ "+err.Error(), true
+		v.reloadStacks()
+		return false
+	}
+	v.reloadStacks()
+	if text != "" {
+		v.message, v.isError = "✅ "+text, false
+	}
+	return true
+}
+
+// runSelected 提交启动或停止选中栈的后台任务
+func (v *StacksView) runSelected(action task.StackAction) tea.Cmd {
+	st := v.selected()
+	if st == nil {
+		return nil
+	}
+	if v.taskID != "" {
+		v.message, v.isError = "⚠️ A stack operation is already running", true
+		return nil
+	}
+	v.taskID = task.GetManager().Submit(task.NewStackTask(v.dockerClient, *st, action))
+	v.message, v.isError = "⏳ Submitted: "+task.GetManager().GetTask(v.taskID).Name(), false
+	return v.pollTask()
+}
+
+func (v *StacksView) pollTask() tea.Cmd {
+	id := v.taskID
+	return tea.Tick(stackTaskPollInterval, func(time.Time) tea.Msg { return stackTaskTickMsg{taskID: id} })
+}
+
+func (v *StacksView) selected() *stack.Stack {
+	if v.cursor < 0 || v.cursor >= len(v.stacks) {
+		return nil
+	}
+	return &v.stacks[v.cursor]
+}
+
+func (v *StacksView) clampCursor() {
+	if v.cursor >= len(v.stacks) {
+		v.cursor = len(v.stacks) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+// IsCapturingInput 是否正在选择容器、输入名称或等待时长、确认删除或调整成员
+func (v *StacksView) IsCapturingInput() bool {
+	return v.pickerShown || v.naming || v.delaying || v.confirmDelete != "" || v.editing
+}
+
+// SetSize 设置视图尺寸
+func (v *StacksView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.picker.SetSize(width, height)
+}
+
+// View 渲染视图
+func (v *StacksView) View() string {
+	if v.pickerShown {
+		return v.picker.View()
+	}
+
+	var b strings.Builder
+	b.WriteString("\n  " + auditTitleStyle.Render("📚 Stacks"))
+	if store := stack.Default(); store != nil {
+		b.WriteString("  " + auditMutedStyle.Render(store.Path()))
+	}
+	b.WriteString("\n\n")
+
+	switch {
+	case stack.Default() == nil:
+		b.WriteString("  " + auditErrorStyle.Render("❌ Stacks are not available (the stacks file could not be opened)") + "\n")
+	case len(v.stacks) == 0:
+		b.WriteString("  " + auditMutedStyle.Render("No stacks yet. Press n to group containers that are not managed by compose") + "\n")
+		b.WriteString("  " + auditMutedStyle.Render("into a stack started in order with one key.") + "\n")
+	default:
+		header := fmt.Sprintf("  %-24s  %-8s  %s", "STACK", "RUNNING", "START ORDER")
+		b.WriteString(auditHeaderStyle.Render(header) + "\n")
+		for i, st := range v.stacks {
+			prefix := "  "
+			name := fmt.Sprintf("%-24s", format.Truncate(st.Name, 24))
+			if i == v.cursor {
+				prefix = auditKeyStyle.Render("▶ ")
+				name = auditTitleStyle.Render(name)
+			}
+			running := 0
+			names := make([]string, len(st.Members))
+			for j, m := range st.Members {
+				names[j] = m.Container
+				if v.containers[m.Container].State == "running" {
+					running++
+				}
+			}
+			count := fmt.Sprintf("%-8s", fmt.Sprintf("%d/%d", running, len(st.Members)))
+			if running == len(st.Members) {
+				count = auditOKStyle.Render(count)
+			} else if running > 0 {
+				count = auditErrorStyle.Render(count)
+			} else {
+				count = auditMutedStyle.Render(count)
+			}
+			order := format.Truncate(strings.Join(names, " → "), max(20, v.width-42))
+			b.WriteString(fmt.Sprintf("%s%s  %s  %s\n", prefix, name, count, order))
+		}
+		if st := v.selected(); st != nil {
+			v.renderMembers(&b, *st)
+		}
+	}
+
+	switch {
+	case v.naming:
+		b.WriteString("\n  " + auditKeyStyle.Render("Stack name:") + " " + v.nameInput.View() +
+			"  " + auditMutedStyle.Render(fmt.Sprintf("%d container(s)  [Enter=Create] [Esc=Cancel]", len(v.newMembers))))
+	case v.delaying:
+		b.WriteString("\n  " + auditKeyStyle.Render("Wait after start (seconds):") + " " + v.delayInput.View() +
+			"  " + auditMutedStyle.Render("[Enter=Save] [Esc=Cancel]"))
+	case v.confirmDelete != "":
+		b.WriteString("\n  " + auditErrorStyle.Render("Delete stack "+v.confirmDelete+"? The containers are not removed.") +
+			"  " + auditMutedStyle.Render("[y=Confirm] [any key=Cancel]"))
+	}
+	if v.message != "" {
+		if v.isError {
+			b.WriteString("\n  " + auditErrorStyle.Render(v.message))
+		} else {
+			b.WriteString("\n  " + auditOKStyle.Render(v.message))
+		}
+	}
+
+	var keys []string
+	if v.editing {
+		keys = []string{
+			auditKeyStyle.Render("j/k") + " Select",
+			auditKeyStyle.Render("J/K") + " Move",
+			auditKeyStyle.Render("w") + " Wait",
+			auditKeyStyle.Render("a") + " Add",
+			auditKeyStyle.Render("x") + " Remove",
+			auditKeyStyle.Render("Esc") + " Done",
+		}
+	} else {
+		keys = []string{
+			auditKeyStyle.Render("j/k") + " Select",
+			auditKeyStyle.Render("t") + " Start",
+			auditKeyStyle.Render("s") + " Stop",
+			auditKeyStyle.Render("n") + " New",
+			auditKeyStyle.Render("Enter") + " Edit",
+			auditKeyStyle.Render("Ctrl+D") + " Delete",
+			auditKeyStyle.Render("r") + " Refresh",
+			auditKeyStyle.Render("Esc") + " Back",
+		}
+	}
+	b.WriteString("\n\n  " + auditMutedStyle.Render(strings.Join(keys, "  ")))
+	return b.String()
+}
+
+// renderMembers 渲染选中栈的成员：启动顺序、启动后的等待时长和当前状态
+func (v *StacksView) renderMembers(b *strings.Builder, st stack.Stack) {
+	title := "Members of " + st.Name + " (stopped in reverse order)"
+	if v.editing {
+		title = "Editing " + st.Name
+	}
+	b.WriteString("\n  " + auditTitleStyle.Render(title) + "\n")
+	for i, m := range st.Members {
+		prefix := "    "
+		name := fmt.Sprintf("%-32s", format.Truncate(m.Container, 32))
+		if v.editing && i == v.memberCursor {
+			prefix = "  " + auditKeyStyle.Render("▶ ")
+			name = auditTitleStyle.Render(name)
+		}
+		wait := "-"
+		if m.DelaySeconds > 0 {
+			wait = "wait " + format.Duration(m.Delay())
+		}
+		state := auditErrorStyle.Render("not found")
+		if c, ok := v.containers[m.Container]; ok {
+			state = styles.RenderStatus(styles.ContainerStatus(c.State, c.Status), c.State)
+		} else if v.loading {
+			state = auditMutedStyle.Render("...")
+		}
+		b.WriteString(fmt.Sprintf("%s%2d. %s  %-10s  %s\n", prefix, i+1, name, wait, state))
+	}
+}
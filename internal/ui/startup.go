@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// StartupViews 命令行 --view 可用的视图名称
+var StartupViews = []string{"home", "containers", "images", "networks", "compose", "services", "recipes", "audit"}
+
+// SetStartupView 启动时直接打开指定视图，并预设过滤和搜索关键字（命令行 --view/--filter/--search）
+func SetStartupView(m Model, view, filter, search string) (Model, error) {
+	view = strings.ToLower(strings.TrimSpace(view))
+	if view == "" || view == "home" {
+		if filter != "" || search != "" {
+			return m, fmt.Errorf("--filter and --search need --view")
+		}
+		return m, nil
+	}
+
+	var next tea.Model
+	var cmd tea.Cmd
+	switch view {
+	case "containers":
+		if err := m.containerListView.SetFilter(filter, search); err != nil {
+			return m, err
+		}
+		next, cmd = m.enterContainerList()
+	case "images":
+		if m.imageListView == nil {
+			return m, fmt.Errorf("images view is unavailable")
+		}
+		if err := m.imageListView.SetFilter(filter, search); err != nil {
+			return m, err
+		}
+		next, cmd = m.enterImageList()
+	case "networks":
+		if m.networkListView == nil {
+			return m, fmt.Errorf("networks view is unavailable")
+		}
+		if filter != "" {
+			return m, fmt.Errorf("the networks view has no --filter, use --search")
+		}
+		m.networkListView.SetSearch(search)
+		next, cmd = m.enterNetworkList()
+	case "compose", "services", "recipes", "audit":
+		if filter != "" || search != "" {
+			return m, fmt.Errorf("the %s view does not support --filter or --search", view)
+		}
+		switch view {
+		case "compose":
+			if m.composeListView == nil {
+				return m, fmt.Errorf("Docker Compose is not installed or unavailable")
+			}
+			next, cmd = m.enterComposeList()
+		case "services":
+			next, cmd = m.enterServices()
+		case "recipes":
+			next, cmd = m.enterRecipes()
+		default:
+			next, cmd = m.enterAuditLog()
+		}
+	default:
+		return m, fmt.Errorf("unknown view %q (available: %s)", view, strings.Join(StartupViews, ", "))
+	}
+
+	m = next.(Model)
+	m.startupCmd = cmd
+	return m, nil
+}
+
+// SetStartupLogs 启动时直接打开容器日志（docktui logs <name>），返回时回到容器列表
+func SetStartupLogs(m Model, containerID, containerName string) Model {
+	m.selectedContainerID = containerID
+	m.logsView.SetContainer(containerID, containerName)
+	m.previousView = ViewContainerList
+	m.currentView = ViewLogs
+	m.startupCmd = tea.Batch(m.containerListView.Init(), m.logsView.Init())
+	return m
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/crash"
+)
+
+// stateDumpMsg 界面转储结果
+type stateDumpMsg struct {
+	path string // 不带扩展名的文件路径
+	err  error
+}
+
+// viewTypeNames 转储中显示的视图名称
+var viewTypeNames = map[ViewType]string{
+	ViewWelcome:         "home",
+	ViewContainerList:   "containers",
+	ViewContainerDetail: "container-detail",
+	ViewLogs:            "logs",
+	ViewHelp:            "help",
+	ViewComposeList:     "compose",
+	ViewImageList:       "images",
+	ViewImageDetails:    "image-detail",
+	ViewNetworkList:     "networks",
+	ViewNetworkDetail:   "network-detail",
+	ViewComposeDetail:   "compose-detail",
+	ViewAuditLog:        "audit",
+	ViewRecipes:         "recipes",
+	ViewServices:        "services",
+	ViewTroubleshoot:    "troubleshoot",
+	ViewTasks:           "tasks",
+	ViewFleet:           "fleet",
+	ViewStacks:          "stacks",
+	ViewTrash:           "trash",
+	ViewEvents:          "events",
+}
+
+// currentViewModel 返回当前视图的实例
+func (m Model) currentViewModel() any {
+	switch m.currentView {
+	case ViewWelcome:
+		return m.homeView
+	case ViewContainerList:
+		return m.containerListView
+	case ViewContainerDetail:
+		return m.containerDetailView
+	case ViewLogs:
+		return m.logsView
+	case ViewHelp:
+		return m.helpView
+	case ViewComposeList:
+		return m.composeListView
+	case ViewImageList:
+		return m.imageListView
+	case ViewImageDetails:
+		return m.imageDetailsView
+	case ViewNetworkList:
+		return m.networkListView
+	case ViewNetworkDetail:
+		return m.networkDetailView
+	case ViewComposeDetail:
+		return m.composeDetailView
+	case ViewAuditLog:
+		return m.auditView
+	case ViewRecipes:
+		return m.recipesView
+	case ViewServices:
+		return m.servicesView
+	case ViewTroubleshoot:
+		return m.troubleshootView
+	case ViewTasks:
+		return m.tasksView
+	case ViewFleet:
+		return m.fleetView
+	case ViewStacks:
+		return m.stacksView
+	case ViewTrash:
+		return m.trashView
+	case ViewEvents:
+		return m.eventsView
+	}
+	return nil
+}
+
+// dumpState 把当前画面和视图状态写入文件（调试快捷键，默认 F12），用于提交界面问题和编写回归用例
+func (m Model) dumpState() tea.Cmd {
+	// 画面和状态在 Update 中取得，避免与后续消息并发读取视图
+	frame := m.View()
+	state := map[string]any{
+		"view":          viewTypeNames[m.currentView],
+		"previous_view": viewTypeNames[m.previousView],
+		"width":         m.width,
+		"height":        m.height,
+		"overlays": map[string]bool{
+			"shell_selector": m.showShellSelector,
+			"history":        m.history.IsVisible(),
+			"policy_notice":  m.policyNotice != "",
+			"quit_prompt":    m.quit != quitNone,
+		},
+		"messages": crash.State(map[string]string{
+			"error":   m.errorMsg,
+			"warning": m.warningMsg,
+			"info":    m.infoMsg,
+			"success": m.successMsg,
+		}),
+		"view_state": crash.State(m.currentViewModel()),
+	}
+	return func() tea.Msg {
+		path, err := crash.WriteDump(frame, state)
+		return stateDumpMsg{path: path, err: err}
+	}
+}
+
+// handleStateDump 显示转储文件的位置
+func (m Model) handleStateDump(msg stateDumpMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, m.SetTemporaryMessage(MsgWarning, "⚠️ "+msg.err.Error(), 5)
+	}
+	return m, m.SetTemporaryMessage(MsgSuccess, "📸 Saved screen and state to "+msg.path+".{ansi,txt,json}", 5)
+}
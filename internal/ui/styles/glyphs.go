@@ -0,0 +1,156 @@
+package styles
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// asciiMode 是否把图标和边框字符替换为 ASCII（PuTTY、Linux 控制台、非 UTF-8 locale 等终端）
+var asciiMode atomic.Bool
+
+// 终端 ASCII 回退模式的配置值
+const (
+	ASCIIAuto = "auto" // 按 TERM 和 locale 检测
+	ASCIIOn   = "on"
+	ASCIIOff  = "off"
+)
+
+// variationSelector emoji 变体选择符（U+FE0F），跟在 ⚠ 等符号后表示用 emoji 样式显示
+const variationSelector = '\uFE0F'
+
+// asciiTerms 无法正确显示 emoji 或框线字符的终端类型（TERM 前缀）
+var asciiTerms = []string{"linux", "dumb", "vt100", "vt102", "vt220", "ansi", "putty", "cons25"}
+
+// SetASCII 开启或关闭 ASCII 回退
+func SetASCII(enabled bool) {
+	asciiMode.Store(enabled)
+}
+
+// ASCII 是否处于 ASCII 回退模式
+func ASCII() bool {
+	return asciiMode.Load()
+}
+
+// ResolveASCII 按配置值（auto/on/off）决定是否使用 ASCII 回退
+func ResolveASCII(mode string) bool {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case ASCIIOn, "true", "1", "yes":
+		return true
+	case ASCIIOff, "false", "0", "no":
+		return false
+	}
+	return DetectASCII()
+}
+
+// DetectASCII 按 TERM 和 locale 检测终端是否需要 ASCII 回退；未设置 locale 时不回退
+func DetectASCII() bool {
+	term := strings.ToLower(os.Getenv("TERM"))
+	for _, t := range asciiTerms {
+		if strings.HasPrefix(term, t) {
+			return true
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return false
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			l := strings.ToLower(v)
+			return !strings.Contains(l, "utf-8") && !strings.Contains(l, "utf8")
+		}
+	}
+	return false
+}
+
+// asciiGlyphs 图标和框线字符对应的 ASCII 标记
+var asciiGlyphs = map[rune]string{
+	// 框线和块
+	'─': "-", '━': "-", '═': "-", '│': "|", '┃': "|", '║': "|",
+	'╭': "+", '╮': "+", '╰': "+", '╯': "+", '┌': "+", '┐': "+", '└': "+", '┘': "+",
+	'├': "+", '┤': "+", '┬': "+", '┴': "+", '┼': "+", '╔': "+", '╗': "+", '╚': "+", '╝': "+",
+	'█': "#", '▓': "#", '▒': ":", '░': ".", '▏': "|", '▕': "|",
+	// 箭头
+	'↑': "^", '↓': "v", '→': ">", '←': "<", '▶': ">", '◀': "<", '▲': "^", '▼': "v", '⇅': "^", '⬆': "^",
+	// 状态和列表标记
+	'•': "*", '●': "*", '○': "o", '◐': "o", '■': "#", '◈': "#", '▣': "#", '⊕': "+", '★': "*", '☆': "o",
+	'✓': "v", '✔': "v", '✗': "x", '✖': "x", '❚': "|", '…': "~", '—': "-", '–': "-", '♥': "+",
+	'⚠': "!", '❌': "x", '✅': "v", '⏳': "~", '⏹': "#", '⚡': "!", '✨': "*", '☸': "k", '⚙': "@", '⚪': "o",
+	// 备注颜色标记
+	'🔴': "r", '🟠': "o", '🟡': "y", '🟢': "g", '🔵': "b", '🟣': "p",
+	// 常见的提示图标
+	'💡': "i", '🔒': "L", '📌': "^", '📝': "n", '📋': "=", '📦': "#", '🔍': "?", '🔄': "~", '🔁': "~", '↻': "~",
+}
+
+// Plain ASCII 回退模式下把渲染结果中的图标和框线替换为 ASCII，未开启时原样返回；
+// 替换后按原字符的显示宽度补齐空格，保证表格和边框不错位
+func Plain(s string) string {
+	if !ASCII() || isASCII(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		cluster := s[i : i+size]
+		i += size
+		if r < utf8.RuneSelf {
+			b.WriteRune(r)
+			continue
+		}
+		// emoji 的变体选择符属于同一个字符
+		if i < len(s) {
+			if next, n := utf8.DecodeRuneInString(s[i:]); next == variationSelector {
+				cluster += s[i : i+n]
+				i += n
+			}
+		}
+		if r == variationSelector {
+			continue
+		}
+		replacement, ok := asciiGlyphs[r]
+		if !ok {
+			if !isPictograph(r) {
+				b.WriteString(cluster)
+				continue
+			}
+			replacement = "*"
+		}
+		b.WriteString(replacement)
+		if pad := lipgloss.Width(cluster) - len(replacement); pad > 0 {
+			b.WriteString(strings.Repeat(" ", pad))
+		}
+	}
+	return b.String()
+}
+
+// isASCII 字符串是否只包含 ASCII 字符
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// isPictograph 是否是 emoji 或符号类字符（CJK 等文字不替换）
+func isPictograph(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // emoji
+		return true
+	case r >= 0x2190 && r <= 0x21FF: // 箭头
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // 技术符号
+		return true
+	case r >= 0x2500 && r <= 0x27BF: // 框线、几何图形、杂项符号、装饰符号
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // 箭头和符号补充
+		return true
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package styles
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Status 资源状态的类别：每一类有独立的图标，不只靠颜色区分
+type Status int
+
+const (
+	StatusRunning Status = iota // 运行中
+	StatusStopped               // 已停止、已创建
+	StatusPaused                // 已暂停
+	StatusError                 // 非零退出、unhealthy、dead
+	StatusWarning               // 重启中、健康检查启动中、删除中
+)
+
+// 调色板的配置值
+const (
+	PaletteDefault    = "default"
+	PaletteColorblind = "colorblind" // 不依赖红绿区分（Okabe-Ito 配色）
+)
+
+// statusGlyphs 各状态的图标
+var statusGlyphs = map[Status]string{
+	StatusRunning: "▶",
+	StatusStopped: "■",
+	StatusPaused:  "❚❚",
+	StatusError:   "✖",
+	StatusWarning: "⚠",
+}
+
+// palettes 各调色板中状态的颜色
+var palettes = map[string]map[Status]lipgloss.Color{
+	PaletteDefault: {
+		StatusRunning: lipgloss.Color(ColorSuccess),
+		StatusStopped: lipgloss.Color(ColorMuted),
+		StatusPaused:  lipgloss.Color("220"),
+		StatusError:   lipgloss.Color(ColorError),
+		StatusWarning: lipgloss.Color(ColorWarning),
+	},
+	PaletteColorblind: {
+		StatusRunning: lipgloss.Color("#56B4E9"), // 天蓝
+		StatusStopped: lipgloss.Color(ColorMuted),
+		StatusPaused:  lipgloss.Color("#F0E442"), // 黄
+		StatusError:   lipgloss.Color("#D55E00"), // 朱红
+		StatusWarning: lipgloss.Color("#E69F00"), // 橙
+	},
+}
+
+var palette atomic.Value // string
+
+// SetPalette 按配置值设置状态颜色，未知的值使用默认调色板
+func SetPalette(name string) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if _, ok := palettes[name]; !ok {
+		name = PaletteDefault
+	}
+	palette.Store(name)
+}
+
+// Palette 当前调色板
+func Palette() string {
+	if name, ok := palette.Load().(string); ok {
+		return name
+	}
+	return PaletteDefault
+}
+
+// StatusColor 状态在当前调色板中的颜色
+func StatusColor(s Status) lipgloss.Color {
+	return palettes[Palette()][s]
+}
+
+// StatusStyle 状态的文字样式
+func StatusStyle(s Status) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(StatusColor(s))
+}
+
+// StatusGlyph 状态的图标
+func StatusGlyph(s Status) string {
+	return statusGlyphs[s]
+}
+
+// StatusLabel 图标加文字（未着色），用于整行已着色的表格
+func StatusLabel(s Status, text string) string {
+	return StatusGlyph(s) + " " + text
+}
+
+// RenderStatus 按状态着色的图标加文字
+func RenderStatus(s Status, text string) string {
+	return StatusStyle(s).Render(StatusLabel(s, text))
+}
+
+// ContainerStatus 按容器的 State（running、exited 等）和 Status 文本（"Up 2 hours (unhealthy)"、"Exited (1) ..."）归类
+func ContainerStatus(state, status string) Status {
+	lower := strings.ToLower(status)
+	switch {
+	case strings.Contains(lower, "unhealthy"):
+		return StatusError
+	case strings.Contains(lower, "health: starting"):
+		return StatusWarning
+	}
+	switch strings.ToLower(state) {
+	case "running":
+		return StatusRunning
+	case "paused":
+		return StatusPaused
+	case "restarting", "removing":
+		return StatusWarning
+	case "dead":
+		return StatusError
+	case "exited":
+		if code, ok := exitCode(lower); ok && !stopSignalExit[code] {
+			return StatusError
+		}
+	}
+	return StatusStopped
+}
+
+// stopSignalExit 正常退出或被 docker stop / Ctrl+C 结束的退出码，不算失败
+var stopSignalExit = map[int]bool{0: true, 130: true, 137: true, 143: true}
+
+// exitCode 从 "exited (1) 5 minutes ago" 中取出退出码
+func exitCode(status string) (int, bool) {
+	rest, ok := strings.CutPrefix(status, "exited (")
+	if !ok {
+		return 0, false
+	}
+	code, _, ok := strings.Cut(rest, ")")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(code)
+	return n, err == nil
+}
@@ -1,7 +1,24 @@
 // Package styles 定义全局统一的 UI 样式
 package styles
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// SetColorMode 按配置值（auto、truecolor、256、16、off）设置颜色输出，auto 保持终端检测结果
+func SetColorMode(mode string) {
+	switch mode {
+	case "truecolor":
+		lipgloss.SetColorProfile(termenv.TrueColor)
+	case "256":
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	case "16":
+		lipgloss.SetColorProfile(termenv.ANSI)
+	case "off":
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
 
 // 颜色常量
 const (
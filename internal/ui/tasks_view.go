@@ -0,0 +1,282 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/format"
+	"docktui/internal/task"
+	"docktui/internal/ui/components"
+)
+
+// tasksTickMsg 定时刷新任务历史
+type tasksTickMsg struct {
+	gen int
+}
+
+// taskLogClosedMsg 日志查看程序退出
+type taskLogClosedMsg struct {
+	err error
+}
+
+// TasksView 后台任务历史视图：本次运行提交的全部任务及其输出日志文件
+type TasksView struct {
+	width  int
+	height int
+
+	tasks    []task.Task
+	selected int
+	notice   string
+	gen      int // 每次进入视图递增，丢弃上一次的刷新计时
+
+	confirmCancel task.Task // 等待确认取消的任务（取消会留下不完整结果）
+}
+
+// NewTasksView 创建任务历史视图
+func NewTasksView() *TasksView {
+	return &TasksView{}
+}
+
+// Init 加载任务列表并开始定时刷新
+func (v *TasksView) Init() tea.Cmd {
+	v.gen++
+	v.notice = ""
+	v.reload()
+	return v.tick()
+}
+
+func (v *TasksView) tick() tea.Cmd {
+	gen := v.gen
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return tasksTickMsg{gen: gen}
+	})
+}
+
+func (v *TasksView) reload() {
+	v.tasks = task.GetManager().ListHistory()
+	if v.selected >= len(v.tasks) {
+		v.selected = len(v.tasks) - 1
+	}
+	if v.selected < 0 {
+		v.selected = 0
+	}
+}
+
+// Update 处理消息
+func (v *TasksView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tasksTickMsg:
+		if msg.gen != v.gen {
+			return v, nil
+		}
+		v.reload()
+		return v, v.tick()
+
+	case taskLogClosedMsg:
+		if msg.err != nil {
+			v.notice = "❌ Log viewer failed: " + msg.err.Error()
+		}
+		width, height := v.width, v.height
+		return v, func() tea.Msg { return tea.WindowSizeMsg{Width: width, Height: height} }
+
+	case tea.KeyMsg:
+		if t := v.confirmCancel; t != nil {
+			v.confirmCancel = nil
+			if s := msg.String(); s == "y" || s == "Y" {
+				task.GetManager().Cancel(t.ID())
+				v.notice = "⏹️ Cancelled " + t.Name()
+			} else {
+				v.notice = ""
+			}
+			return v, nil
+		}
+		switch msg.String() {
+		case "esc", "b":
+			v.gen++
+			return v, func() tea.Msg { return GoBackMsg{} }
+		case "j", "down":
+			if v.selected < len(v.tasks)-1 {
+				v.selected++
+			}
+		case "k", "up":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "g":
+			v.selected = 0
+		case "G":
+			v.selected = max(0, len(v.tasks)-1)
+		case "enter", "o":
+			return v, v.openLog()
+		case "y":
+			if path := v.selectedLogPath(); path != "" {
+				v.notice = components.CopyText("task-log-path", path).Message("log path")
+			} else {
+				v.notice = v.noLogHint()
+			}
+		case "x":
+			if t := v.selectedTask(); t != nil && (t.Status() == task.StatusPending || t.Status() == task.StatusRunning) {
+				if w, ok := t.(task.CancelWarner); ok && w.CancelWarning() != "" {
+					v.confirmCancel = t
+					v.notice = "⚠️ " + w.CancelWarning() + " — cancel " + t.Name() + "? (y/n)"
+					return v, nil
+				}
+				task.GetManager().Cancel(t.ID())
+				v.notice = "⏹️ Cancelled " + t.Name()
+			}
+		case "p":
+			if t := v.selectedTask(); t != nil && t.Status() == task.StatusRunning {
+				p, ok := t.(task.Pausable)
+				if !ok {
+					v.notice = "💡 " + t.Name() + " cannot be paused"
+				} else if task.GetManager().SetPaused(t.ID(), !p.Paused()); p.Paused() {
+					v.notice = "⏸️ Paused " + t.Name()
+				} else {
+					v.notice = "▶️ Resumed " + t.Name()
+				}
+			}
+		}
+	}
+	return v, nil
+}
+
+// SetSize 设置视图尺寸
+func (v *TasksView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+func (v *TasksView) selectedTask() task.Task {
+	if v.selected < 0 || v.selected >= len(v.tasks) {
+		return nil
+	}
+	return v.tasks[v.selected]
+}
+
+// taskLogPath 返回任务的输出日志文件，未记录时返回空字符串
+func taskLogPath(t task.Task) string {
+	if l, ok := t.(interface{ LogPath() string }); ok {
+		return l.LogPath()
+	}
+	return ""
+}
+
+func (v *TasksView) selectedLogPath() string {
+	if t := v.selectedTask(); t != nil {
+		return taskLogPath(t)
+	}
+	return ""
+}
+
+// noLogHint 选中任务没有日志文件时的提示
+func (v *TasksView) noLogHint() string {
+	if task.GetManager().LogDir() == "" {
+		return "💡 Task logs are off; set DOCKTUI_TASK_LOG_DIR to keep task output"
+	}
+	return "💡 This task has no log file"
+}
+
+// openLog 释放终端，用 $PAGER（默认 less）打开选中任务的日志文件
+func (v *TasksView) openLog() tea.Cmd {
+	path := v.selectedLogPath()
+	if path == "" {
+		v.notice = v.noLogHint()
+		return nil
+	}
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less", "+G"}
+	}
+	cmd := exec.Command(pager[0], append(pager[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return taskLogClosedMsg{err: err}
+	})
+}
+
+// View 渲染视图
+func (v *TasksView) View() string {
+	var b strings.Builder
+
+	b.WriteString("\n  " + auditTitleStyle.Render("📋 Task History"))
+	if dir := task.GetManager().LogDir(); dir != "" {
+		b.WriteString("  " + auditMutedStyle.Render("logs: "+dir))
+	}
+	b.WriteString("\n\n")
+
+	if len(v.tasks) == 0 {
+		b.WriteString("  " + auditMutedStyle.Render("No background tasks in this session") + "\n")
+	} else {
+		header := fmt.Sprintf("  %-10s  %-8s  %-9s  %-2s %-34s  %s", "STATUS", "STARTED", "DURATION", "", "TASK", "MESSAGE")
+		b.WriteString(auditHeaderStyle.Render(header) + "\n")
+
+		pageSize := max(5, v.height-10)
+		start := 0
+		if v.selected >= pageSize {
+			start = v.selected - pageSize + 1
+		}
+		end := min(len(v.tasks), start+pageSize)
+		msgWidth := max(20, v.width-75)
+		for i := start; i < end; i++ {
+			t := v.tasks[i]
+			status := auditOKStyle.Render(fmt.Sprintf("%-10s", t.Status()))
+			switch t.Status() {
+			case task.StatusFailed:
+				status = auditErrorStyle.Render(fmt.Sprintf("%-10s", t.Status()))
+			case task.StatusPending, task.StatusRunning, task.StatusCancelled:
+				status = auditMutedStyle.Render(fmt.Sprintf("%-10s", t.Status()))
+			}
+			if p, ok := t.(task.Pausable); ok && p.Paused() && t.Status() == task.StatusRunning {
+				status = auditMutedStyle.Render(fmt.Sprintf("%-10s", "Paused"))
+			}
+			started, duration := "-", "-"
+			if bt, ok := t.(interface {
+				StartTime() time.Time
+				Duration() time.Duration
+			}); ok && !bt.StartTime().IsZero() {
+				started = bt.StartTime().Format("15:04:05")
+				duration = format.Duration(bt.Duration())
+			}
+			// 有日志文件的任务标记 📝（占两列）
+			logMark := "  "
+			if taskLogPath(t) != "" {
+				logMark = "📝"
+			}
+			message := t.Message()
+			if err := t.Error(); err != nil && t.Status() == task.StatusFailed {
+				message = err.Error()
+			}
+			line := fmt.Sprintf("%s  %-8s  %-9s  %s %-34s  %s", status, started, duration, logMark, format.Truncate(t.Name(), 34), format.Truncate(message, msgWidth))
+			if i == v.selected {
+				line = auditKeyStyle.Render("▶ ") + line
+			} else {
+				line = "  " + line
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n  " + auditMutedStyle.Render(fmt.Sprintf("%d of %d", v.selected+1, len(v.tasks))))
+		if path := v.selectedLogPath(); path != "" {
+			b.WriteString("  " + auditMutedStyle.Render(path))
+		}
+		b.WriteString("\n")
+	}
+
+	if v.notice != "" {
+		b.WriteString("\n  " + v.notice + "\n")
+	}
+
+	keys := []string{
+		auditKeyStyle.Render("j/k") + " Select",
+		auditKeyStyle.Render("Enter") + " Open log",
+		auditKeyStyle.Render("y") + " Copy log path",
+		auditKeyStyle.Render("x") + " Cancel",
+		auditKeyStyle.Render("p") + " Pause/Resume",
+		auditKeyStyle.Render("Esc") + " Back",
+	}
+	b.WriteString("\n  " + auditMutedStyle.Render(strings.Join(keys, "  ")))
+	return b.String()
+}
@@ -0,0 +1,252 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"docktui/internal/docker"
+	"docktui/internal/format"
+	"docktui/internal/trash"
+	"docktui/internal/ui/components"
+)
+
+// trashRunCommandMsg 已删除容器的 docker run 命令还原结果
+type trashRunCommandMsg struct {
+	name    string
+	command string
+	copied  components.CopyResult
+	err     error
+}
+
+// TrashView 回收站视图：通过 docktui 删除的容器保留的 inspect 数据，可查看原配置或还原 docker run 命令
+type TrashView struct {
+	dockerClient docker.Client
+	width        int
+	height       int
+
+	entries  []trash.Entry
+	selected int
+	notice   string
+	err      error
+
+	confirmDelete bool // 等待确认永久删除选中的记录
+
+	jsonViewer *components.JSONViewer
+}
+
+// NewTrashView 创建回收站视图
+func NewTrashView(dockerClient docker.Client) *TrashView {
+	return &TrashView{dockerClient: dockerClient, jsonViewer: components.NewJSONViewer()}
+}
+
+// Init 加载回收站记录
+func (v *TrashView) Init() tea.Cmd {
+	v.notice = ""
+	v.confirmDelete = false
+	v.jsonViewer.Hide()
+	v.reload()
+	return nil
+}
+
+func (v *TrashView) reload() {
+	v.entries, v.err = nil, nil
+	if s := trash.Default(); s != nil {
+		v.entries, v.err = s.List()
+	}
+	v.selected = max(0, min(v.selected, len(v.entries)-1))
+}
+
+// IsCapturingInput 是否有查看器或确认提示在接收按键
+func (v *TrashView) IsCapturingInput() bool {
+	return v.jsonViewer.IsVisible() || v.confirmDelete
+}
+
+// Update 处理消息
+func (v *TrashView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case trashRunCommandMsg:
+		if msg.err != nil {
+			v.notice = auditErrorStyle.Render("❌ " + msg.err.Error())
+			return v, nil
+		}
+		v.jsonViewer.SetSize(v.width, v.height)
+		v.jsonViewer.Show("docker run: "+msg.name, msg.command)
+		if msg.copied.Err == nil {
+			v.notice = msg.copied.Message("docker run command")
+		}
+		return v, nil
+
+	case tea.KeyMsg:
+		if v.jsonViewer.IsVisible() {
+			v.jsonViewer.Update(msg)
+			return v, nil
+		}
+		if v.confirmDelete {
+			v.confirmDelete = false
+			v.notice = ""
+			if s := msg.String(); s == "y" || s == "Y" {
+				v.deleteSelected()
+			}
+			return v, nil
+		}
+		switch msg.String() {
+		case "esc", "b":
+			return v, func() tea.Msg { return GoBackMsg{} }
+		case "j", "down":
+			if v.selected < len(v.entries)-1 {
+				v.selected++
+			}
+		case "k", "up":
+			if v.selected > 0 {
+				v.selected--
+			}
+		case "g":
+			v.selected = 0
+		case "G":
+			v.selected = max(0, len(v.entries)-1)
+		case "r", "f5":
+			v.reload()
+		case "enter", "i":
+			if e := v.selectedEntry(); e != nil {
+				v.jsonViewer.SetSize(v.width, v.height)
+				v.jsonViewer.Show("Removed container: "+e.Name, indentJSON(e.Inspect))
+			}
+		case "C":
+			return v, v.runCommand()
+		case "ctrl+d":
+			if e := v.selectedEntry(); e != nil {
+				v.confirmDelete = true
+				v.notice = "⚠️ Permanently delete the saved config of " + e.Name + "? (y/n)"
+			}
+		}
+	}
+	return v, nil
+}
+
+// SetSize 设置视图尺寸
+func (v *TrashView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.jsonViewer.SetSize(width, height)
+}
+
+func (v *TrashView) selectedEntry() *trash.Entry {
+	if v.selected < 0 || v.selected >= len(v.entries) {
+		return nil
+	}
+	return &v.entries[v.selected]
+}
+
+// runCommand 从保存的 inspect 数据还原 docker run 命令，复制到剪贴板并在查看器中显示
+func (v *TrashView) runCommand() tea.Cmd {
+	e := v.selectedEntry()
+	if e == nil || v.dockerClient == nil {
+		return nil
+	}
+	name, inspect := e.Name, e.Inspect
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		command, err := v.dockerClient.RunCommandFromInspect(ctx, inspect)
+		if err != nil {
+			return trashRunCommandMsg{name: name, err: err}
+		}
+		return trashRunCommandMsg{name: name, command: command, copied: components.CopyText("run-command", command)}
+	}
+}
+
+// deleteSelected 永久删除选中的记录
+func (v *TrashView) deleteSelected() {
+	e := v.selectedEntry()
+	s := trash.Default()
+	if e == nil || s == nil {
+		return
+	}
+	if err := s.Delete(*e); err != nil {
+		v.notice = auditErrorStyle.Render("❌ " + err.Error())
+		return
+	}
+	v.notice = "🗑️ Deleted the saved config of " + e.Name
+	v.reload()
+}
+
+// indentJSON 格式化 inspect JSON，无法解析时原样返回
+func indentJSON(data []byte) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return buf.String()
+}
+
+// View 渲染视图
+func (v *TrashView) View() string {
+	if v.jsonViewer.IsVisible() {
+		return v.jsonViewer.View()
+	}
+
+	var b strings.Builder
+	s := trash.Default()
+	b.WriteString("\n  " + auditTitleStyle.Render("🗑️  Trash"))
+	if s != nil {
+		b.WriteString("  " + auditMutedStyle.Render(fmt.Sprintf("removed containers are kept for %d days in %s", s.Days(), s.Dir())))
+	}
+	b.WriteString("\n\n")
+
+	switch {
+	case s == nil:
+		b.WriteString("  " + auditMutedStyle.Render("The trash is off; set trash.days in config.yaml to keep removed containers") + "\n")
+	case v.err != nil:
+		b.WriteString("  " + auditErrorStyle.Render("❌ "+v.err.Error()) + "\n")
+	case len(v.entries) == 0:
+		b.WriteString("  " + auditMutedStyle.Render("No removed containers") + "\n")
+	default:
+		header := fmt.Sprintf("  %-16s  %-12s  %-28s  %-36s  %s", "REMOVED", "ID", "NAME", "IMAGE", "EXPIRES")
+		b.WriteString(auditHeaderStyle.Render(header) + "\n")
+
+		pageSize := max(5, v.height-10)
+		start := 0
+		if v.selected >= pageSize {
+			start = v.selected - pageSize + 1
+		}
+		end := min(len(v.entries), start+pageSize)
+		for i := start; i < end; i++ {
+			e := v.entries[i]
+			shortID := e.ID
+			if len(shortID) > 12 {
+				shortID = shortID[:12]
+			}
+			expires := "in " + format.Duration(time.Until(e.ExpiresAt(s.Days())))
+			line := fmt.Sprintf("%-16s  %-12s  %-28s  %-36s  %s", e.RemovedAt.Format("2006-01-02 15:04"), shortID,
+				format.Truncate(e.Name, 28), format.Truncate(e.Image, 36), auditMutedStyle.Render(expires))
+			if i == v.selected {
+				line = auditKeyStyle.Render("▶ ") + line
+			} else {
+				line = "  " + line
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n  " + auditMutedStyle.Render(fmt.Sprintf("%d of %d", v.selected+1, len(v.entries))) + "\n")
+	}
+
+	if v.notice != "" {
+		b.WriteString("\n  " + v.notice + "\n")
+	}
+
+	keys := []string{
+		auditKeyStyle.Render("j/k") + " Select",
+		auditKeyStyle.Render("Enter") + " Inspect",
+		auditKeyStyle.Render("C") + " Run command (copy)",
+		auditKeyStyle.Render("Ctrl+D") + " Delete",
+		auditKeyStyle.Render("r") + " Refresh",
+		auditKeyStyle.Render("Esc") + " Back",
+	}
+	b.WriteString("\n  " + auditMutedStyle.Render(strings.Join(keys, "  ")))
+	return b.String()
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/docker"
+)
+
+// diagnosticsMsg 连接诊断结果
+type diagnosticsMsg struct {
+	results []docker.Diagnostic
+}
+
+// TroubleshootView 连接诊断视图：逐项检查 socket、权限、DOCKER_HOST、TLS 证书和守护进程，并给出处理建议
+type TroubleshootView struct {
+	width  int
+	height int
+
+	lastError string // 触发诊断的错误信息
+	results   []docker.Diagnostic
+	running   bool
+	scrollPos int
+}
+
+// NewTroubleshootView 创建诊断视图
+func NewTroubleshootView() *TroubleshootView {
+	return &TroubleshootView{}
+}
+
+// SetError 设置触发诊断的错误信息
+func (v *TroubleshootView) SetError(errMsg string) {
+	v.lastError = errMsg
+}
+
+// Init 运行诊断
+func (v *TroubleshootView) Init() tea.Cmd {
+	v.running = true
+	v.scrollPos = 0
+	return func() tea.Msg {
+		return diagnosticsMsg{results: docker.Diagnose(context.Background())}
+	}
+}
+
+// Update 处理消息
+func (v *TroubleshootView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case diagnosticsMsg:
+		v.running = false
+		v.results = msg.results
+		return v, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "b":
+			return v, func() tea.Msg { return GoBackMsg{} }
+		case "r", "f5":
+			if !v.running {
+				return v, v.Init()
+			}
+		case "j", "down":
+			v.scrollPos++
+		case "k", "up":
+			if v.scrollPos > 0 {
+				v.scrollPos--
+			}
+		}
+	}
+	return v, nil
+}
+
+// SetSize 设置视图尺寸
+func (v *TroubleshootView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// View 渲染视图
+func (v *TroubleshootView) View() string {
+	var lines []string
+	if v.lastError != "" {
+		lines = append(lines, "  "+auditErrorStyle.Render("❌ "+v.lastError), "")
+	}
+
+	switch {
+	case v.running:
+		lines = append(lines, "  "+auditMutedStyle.Render("⏳ Running checks..."))
+	case len(v.results) == 0:
+		lines = append(lines, "  "+auditMutedStyle.Render("No checks run"))
+	default:
+		failed := 0
+		for _, d := range v.results {
+			if d.Status == docker.DiagFail {
+				failed++
+			}
+			lines = append(lines, v.renderDiagnostic(d)...)
+		}
+		lines = append(lines, "")
+		if failed == 0 {
+			lines = append(lines, "  "+auditOKStyle.Render("✅ All checks passed"))
+		} else {
+			lines = append(lines, "  "+auditErrorStyle.Render("Fix the first failing check, then press r to run again"))
+		}
+	}
+
+	// 内容超出屏幕时滚动
+	visible := v.height - 8
+	if visible < 5 {
+		visible = 5
+	}
+	if v.scrollPos > len(lines)-visible {
+		v.scrollPos = len(lines) - visible
+	}
+	if v.scrollPos < 0 {
+		v.scrollPos = 0
+	}
+	end := v.scrollPos + visible
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	var b strings.Builder
+	b.WriteString("\n  " + auditTitleStyle.Render("🩺 Connection Troubleshooting") + "\n\n")
+	b.WriteString(strings.Join(lines[v.scrollPos:end], "\n"))
+
+	keys := []string{
+		auditKeyStyle.Render("r") + " Run again",
+		auditKeyStyle.Render("j/k") + " Scroll",
+		auditKeyStyle.Render("Esc") + " Back",
+	}
+	b.WriteString("\n\n  " + auditMutedStyle.Render(strings.Join(keys, "  ")))
+	return b.String()
+}
+
+// renderDiagnostic 渲染单项检查结果及处理建议
+func (v *TroubleshootView) renderDiagnostic(d docker.Diagnostic) []string {
+	mark := auditOKStyle.Render("●")
+	switch d.Status {
+	case docker.DiagWarn:
+		mark = lipgloss.NewStyle().Foreground(ThemeWarning).Render("●")
+	case docker.DiagFail:
+		mark = auditErrorStyle.Render("●")
+	}
+	lines := []string{"  " + mark + " " + auditHeaderStyle.Render(fmt.Sprintf("%-14s", d.Check)) + " " + d.Detail}
+	for _, s := range d.Suggestions {
+		lines = append(lines, "      "+auditKeyStyle.Render("→ ")+auditMutedStyle.Render(s))
+	}
+	return lines
+}
@@ -9,17 +9,25 @@ import (
 	"strings"
 	"time"
 	
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	sdk "github.com/docker/docker/client"
 
+	"docktui/internal/audit"
 	"docktui/internal/compose"
 	"docktui/internal/docker"
+	"docktui/internal/favorites"
+	"docktui/internal/format"
+	"docktui/internal/plugin"
+	"docktui/internal/policy"
+	"docktui/internal/recording"
+	"docktui/internal/task"
 	"docktui/internal/ui/components"
 	composeui "docktui/internal/ui/compose"
 	containerui "docktui/internal/ui/container"
 	imageui "docktui/internal/ui/image"
 	networkui "docktui/internal/ui/network"
+	"docktui/internal/ui/styles"
 )
 
 // Global theme colors - using adaptive colors, not hardcoding background
@@ -79,6 +87,32 @@ const (
 	ViewNetworkDetail
 	// ViewComposeDetail Compose 项目详情视图
 	ViewComposeDetail
+	// ViewAuditLog 审计日志视图
+	ViewAuditLog
+	
+	// ViewRecipes 容器模板视图
+	ViewRecipes
+	
+	// ViewServices Swarm 服务视图
+	ViewServices
+
+	// ViewTroubleshoot 连接诊断视图
+	ViewTroubleshoot
+
+	// ViewTasks 后台任务历史视图
+	ViewTasks
+
+	// ViewFleet 多主机概览视图
+	ViewFleet
+
+	// ViewStacks 容器栈视图
+	ViewStacks
+
+	// ViewTrash 回收站视图
+	ViewTrash
+
+	// ViewEvents Docker 事件视图
+	ViewEvents
 )
 
 // View 接口定义所有视图必须实现的方法
@@ -118,6 +152,15 @@ type Model struct {
 	networkDetailView   *networkui.DetailView // 网络详情视图
 	composeDetailView   *composeui.DetailView // Compose 项目详情视图
 	shellSelector       *components.ShellSelector // Shell 选择器
+	auditView           *AuditView            // 审计日志视图
+	recipesView         *RecipesView          // 容器模板视图
+	troubleshootView    *TroubleshootView     // 连接诊断视图
+	servicesView        *ServicesView         // Swarm 服务视图
+	tasksView           *TasksView            // 后台任务历史视图
+	fleetView           *FleetView            // 多主机概览视图
+	stacksView          *StacksView           // 容器栈视图
+	trashView           *TrashView            // 回收站视图
+	eventsView          *EventsView           // Docker 事件视图
 	
 	// 全局状态字段
 	selectedContainerID string   // 当前选中的容器 ID
@@ -132,6 +175,32 @@ type Model struct {
 	msgExpireTime   time.Time // 消息过期时间
 	ready           bool      // 是否初始化完成
 	dockerConnected bool      // Docker 是否已连接
+	recordDir       string    // shell 会话录制目录（为空表示不录制）
+	dockerCLI       string    // docker 可执行文件路径（为空表示自动查找）
+	policyNotice    string    // 被策略拒绝的操作（非空时显示说明弹窗）
+	history         *HistoryOverlay // 本次运行的操作历史弹窗（Ctrl+Z）
+	configProblems  []string  // 启动时加载失败而被停用的配置，在首页自检中显示
+	updateCheck     bool      // 启动时在后台检查新版本（配置 updates.check）
+	quit            quitState // 退出流程（有后台任务时先确认）
+	quitDeadline    time.Time // 取消任务后最晚的退出时间
+	keys            components.KeyMap // 全局快捷键（应用 keybindings 中的 quit、help、debug_dump 等）
+	pendingTasks    []task.Spec // 上次退出时未完成、等待确认是否恢复的任务
+	
+	// 固定容器：同名容器重建后日志/详情视图自动重新附加
+	eventHub       *docker.EventHub
+	pinnedName     string
+	pinEvents      <-chan docker.ContainerEvent
+	pinUnsubscribe func()
+	// 日志视图等待启动的容器
+	logsWaitID          string
+	logsWaitEvents      <-chan docker.ContainerEvent
+	logsWaitUnsubscribe func()
+	
+	customActions []plugin.Action // 配置文件中定义的自定义操作
+	
+	composeDiscovery *compose.Discovery // Compose 项目发现器（首页和 Compose 列表共用）
+	
+	startupCmd tea.Cmd // 命令行指定的启动视图的初始化命令
 	
 	// 窗口尺寸（用于响应式布局）
 	width  int
@@ -139,8 +208,14 @@ type Model struct {
 }
 
 func NewModel(dockerClient docker.Client) Model {
+	// Compose 项目发现器，首页和 Compose 列表共用缓存
+	var discovery *compose.Discovery
+	if localClient, ok := dockerClient.(*docker.LocalClient); ok && localClient.GetSDKClient() != nil {
+		discovery = compose.NewDiscovery(localClient.GetSDKClient())
+	}
+
 	// 初始化所有视图
-	homeView := NewHomeView(dockerClient)
+	homeView := NewHomeView(dockerClient, discovery)
 	containerListView := containerui.NewListView(dockerClient)
 	containerDetailView := containerui.NewDetailView(dockerClient)
 	logsView := containerui.NewLogsView(dockerClient)
@@ -153,13 +228,8 @@ func NewModel(dockerClient docker.Client) Model {
 	var composeDetailView *composeui.DetailView
 	composeClient, err := compose.NewClient()
 	if err == nil {
-		// 获取 Docker SDK 客户端用于项目发现
-		var sdkClient *sdk.Client
-		if localClient, ok := dockerClient.(*docker.LocalClient); ok {
-			sdkClient = localClient.GetSDKClient()
-		}
-		composeListView = composeui.NewListView(composeClient, sdkClient)
-		composeDetailView = composeui.NewDetailView(composeClient)
+		composeListView = composeui.NewListView(composeClient, discovery)
+		composeDetailView = composeui.NewDetailView(composeClient, dockerClient)
 	}
 	
 	// 初始化 Shell 选择器
@@ -178,6 +248,19 @@ func NewModel(dockerClient docker.Client) Model {
 		imageListView:       imageListView,
 		networkListView:     networkListView,
 		shellSelector:       shellSelector,
+		auditView:           NewAuditView(),
+		history:             NewHistoryOverlay(),
+		recipesView:         NewRecipesView(dockerClient),
+		troubleshootView:    NewTroubleshootView(),
+		servicesView:        NewServicesView(dockerClient),
+		tasksView:           NewTasksView(),
+		fleetView:           NewFleetView(),
+		stacksView:          NewStacksView(dockerClient),
+		trashView:           NewTrashView(dockerClient),
+		eventsView:          NewEventsView(dockerClient),
+		eventHub:            docker.NewEventHub(dockerClient),
+		composeDiscovery:    discovery,
+		keys:                components.DefaultKeyMap(),
 		ready:               false,
 		dockerConnected:     true, // 默认假设已连接
 	}
@@ -187,6 +270,95 @@ func NewModel(dockerClient docker.Client) Model {
 func SetDockerError(m Model, errMsg string) Model {
 	m.dockerConnected = false
 	m.errorMsg = errMsg
+	// 启动时直接进入诊断视图，而不是只显示连接失败
+	m.troubleshootView.SetError(errMsg)
+	m.currentView = ViewTroubleshoot
+	m.startupCmd = m.troubleshootView.Init()
+	return m
+}
+
+// SetDockerCLI 设置交互式 shell 使用的 docker 可执行文件
+func SetDockerCLI(m Model, path string) Model {
+	m.dockerCLI = path
+	return m
+}
+
+// SetRecordDir 设置 shell 会话录制目录
+func SetRecordDir(m Model, dir string) Model {
+	m.recordDir = dir
+	if m.shellSelector != nil {
+		m.shellSelector.SetRecording(true)
+	}
+	return m
+}
+
+// SetEventHub 使用外部创建的事件分发器，与通知等功能共享同一个 Docker 事件订阅
+func SetEventHub(m Model, hub *docker.EventHub) Model {
+	m.eventHub = hub
+	return m
+}
+
+// composeCacheTTL 由容器事件使 Compose 项目缓存失效时的缓存有效期
+var composeCacheTTL = 5 * time.Minute
+
+// RefreshIntervals 各视图的自动刷新间隔和 Compose 项目缓存时间（配置文件 refresh 节）
+type RefreshIntervals struct {
+	Services     time.Duration
+	ComposeCache time.Duration
+	Home         time.Duration
+	Containers   time.Duration // 事件流不可用时容器列表的轮询间隔
+	Stats        time.Duration
+	Processes    time.Duration
+}
+
+// SetRefreshIntervals 设置各视图的自动刷新间隔，为 0 的保持默认值
+func SetRefreshIntervals(m Model, r RefreshIntervals) Model {
+	if r.Services > 0 {
+		servicesRefreshInterval = r.Services
+	}
+	if r.Home > 0 {
+		homeRefreshInterval = r.Home
+	}
+	if r.ComposeCache > 0 {
+		composeCacheTTL = r.ComposeCache
+		if m.composeDiscovery != nil {
+			m.composeDiscovery.SetCacheTTL(r.ComposeCache)
+		}
+	}
+	containerui.SetFallbackRefresh(r.Containers)
+	components.SetRefreshIntervals(r.Stats, r.Processes)
+	return m
+}
+
+// SetComposeHealthTimeout 设置 Compose 滚动重启时每个服务等待恢复健康的时长
+func SetComposeHealthTimeout(m Model, timeout time.Duration) Model {
+	if timeout > 0 && m.composeDetailView != nil {
+		m.composeDetailView.SetHealthTimeout(timeout)
+	}
+	return m
+}
+
+// watchComposeEvents 属于 Compose 项目的容器发生变化时使项目缓存失效，
+// 这样缓存可以保留更久，重新进入 Compose 列表时直接使用缓存
+func (m Model) watchComposeEvents() {
+	if m.composeDiscovery == nil || m.eventHub == nil {
+		return
+	}
+	discovery := m.composeDiscovery
+	discovery.SetCacheTTL(composeCacheTTL)
+	events, _ := m.eventHub.Subscribe()
+	go func() {
+		for event := range events {
+			if event.Project != "" {
+				discovery.InvalidateCache()
+			}
+		}
+	}()
+}
+
+// SetStartupInfo 设置启动时的提示信息（显示在首页，切换视图后清除）
+func SetStartupInfo(m Model, text string) Model {
+	m.infoMsg = text
 	return m
 }
 
@@ -234,7 +406,8 @@ type clearMessageMsg struct{}
 
 // shellExitedMsg shell 退出消息类型
 type shellExitedMsg struct {
-	err error
+	err           error
+	recordingPath string // 会话录制文件路径（未录制时为空）
 }
 
 // execShellMsg 执行 shell 消息类型
@@ -250,10 +423,33 @@ type execShellCmd struct {
 	containerID   string
 	containerName string
 	shell         string // 指定的 Shell 路径
+	dockerCLI     string // 配置的 docker 可执行文件，为空时自动查找
+
+	// 会话录制
+	recordDir     string
+	width         int
+	height        int
+	recordingPath *string // 录制完成后回填文件路径
+
+	removeAfter bool // 退出后删除容器（调试运行的临时容器）
 }
 
 // Run 实现 tea.ExecCommand 接口
 func (e execShellCmd) Run() error {
+	err := e.run()
+	if !e.removeAfter {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	if rmErr := e.dockerClient.RemoveDebugContainer(ctx, e.containerID); rmErr != nil && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// run 进入容器 shell，直到 shell 退出
+func (e execShellCmd) run() error {
 	// 清屏（进入 shell 前）
 	fmt.Print("\033[2J\033[H")
 	
@@ -278,46 +474,60 @@ func (e execShellCmd) Run() error {
 	fmt.Println("\033[90m━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\033[0m")
 	fmt.Println()
 	
-	// 尝试查找 docker 可执行文件
-	dockerPath, err := exec.LookPath("docker")
-	if err != nil {
-		// 尝试常见的 Docker 安装路径
-		possiblePaths := []string{
-			"C:\\Program Files\\Docker\\Docker\\resources\\bin\\docker.exe",
-			"C:\\Program Files\\Docker\\Docker\\docker.exe",
-		}
-		for _, p := range possiblePaths {
-			if _, err := os.Stat(p); err == nil {
-				dockerPath = p
-				break
-			}
-		}
-	}
-	
+	// 查找 docker 可执行文件（配置的路径、PATH、Docker Desktop 默认安装位置）
+	dockerPath := docker.FindDockerCLI(e.dockerCLI)
 	if dockerPath == "" {
 		// 如果找不到 docker，回退到 Docker SDK
 		fmt.Printf("\033[33m%s\033[0m\n", "Using Docker SDK mode...")
-		ctx := context.Background()
-		err := e.dockerClient.ExecShell(ctx, e.containerID, e.shell)
+		// 策略禁止时由 ExecShell 报错和记录，不创建空的录制文件
+		var recorder *recording.Recorder
+		if policy.Check("container.exec") == nil {
+			recorder = e.startRecording()
+		}
+		var output io.Writer = os.Stdout
+		if recorder != nil {
+			output = io.MultiWriter(os.Stdout, recorder)
+		}
+		err := e.dockerClient.ExecShell(context.Background(), e.containerID, e.shell, output)
+		e.finishRecording(recorder)
 		fmt.Print("\033[2J\033[H")
 		return err
 	}
 	
+	// SDK 模式由客户端自行检查和记录，CLI 模式在这里处理
+	if err := policy.Check("container.exec"); err != nil {
+		audit.Record("container.exec", e.containerName, err)
+		return err
+	}
+	audit.Record("container.exec", e.containerName, nil)
+
+	// 开启录制时输出会经过管道，docker 无法探测终端尺寸，通过环境变量传入
+	args := []string{"exec", "-it"}
+	recorder := e.startRecording()
+	if recorder != nil && e.width > 0 && e.height > 0 {
+		args = append(args, "-e", fmt.Sprintf("COLUMNS=%d", e.width), "-e", fmt.Sprintf("LINES=%d", e.height))
+	}
+
 	// 构建 docker exec 命令
 	var cmd *exec.Cmd
 	if e.shell != "" {
 		// 使用指定的 Shell
-		cmd = exec.Command(dockerPath, "exec", "-it", e.containerID, e.shell)
+		cmd = exec.Command(dockerPath, append(args, e.containerID, e.shell)...)
 	} else {
 		// 自动检测 Shell
-		cmd = exec.Command(dockerPath, "exec", "-it", e.containerID, "/bin/sh", "-c", 
-			"if [ -x /bin/bash ]; then exec /bin/bash; elif [ -x /bin/ash ]; then exec /bin/ash; else exec /bin/sh; fi")
+		cmd = exec.Command(dockerPath, append(args, e.containerID, "/bin/sh", "-c",
+			"if [ -x /bin/bash ]; then exec /bin/bash; elif [ -x /bin/ash ]; then exec /bin/ash; else exec /bin/sh; fi")...)
 	}
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
+	if recorder != nil {
+		// TTY 模式下 stderr 已合并到 stdout
+		cmd.Stdout = io.MultiWriter(os.Stdout, recorder)
+	}
 	
-	err = cmd.Run()
+	err := cmd.Run()
+	e.finishRecording(recorder)
 	
 	// 清屏（退出 shell 后）
 	fmt.Print("\033[2J\033[H")
@@ -335,6 +545,31 @@ func (e execShellCmd) Run() error {
 	return nil
 }
 
+// startRecording 开启会话录制时创建录制文件，未开启或创建失败时返回 nil
+func (e execShellCmd) startRecording() *recording.Recorder {
+	if e.recordDir == "" {
+		return nil
+	}
+	recorder, err := recording.New(e.recordDir, e.containerName, e.width, e.height)
+	if err != nil {
+		fmt.Printf("\033[33m⚠️  Recording disabled: %v\033[0m\n\n", err)
+		return nil
+	}
+	fmt.Printf("\033[1;31m● REC\033[0m \033[90m%s\033[0m\n\n", recorder.Path())
+	return recorder
+}
+
+// finishRecording 结束录制并回填录制文件路径
+func (e execShellCmd) finishRecording(recorder *recording.Recorder) {
+	if recorder == nil {
+		return
+	}
+	recorder.Close()
+	if e.recordingPath != nil {
+		*e.recordingPath = recorder.Path()
+	}
+}
+
 // SetStdin 实现 tea.ExecCommand 接口（可选）
 func (e execShellCmd) SetStdin(r io.Reader) {}
 
@@ -355,22 +590,71 @@ func (m Model) execShellWithShell(containerID, containerName, shell string) tea.
 	}
 }
 
+// openContainerDetail 从其他视图跳转到容器详情，返回时回到原视图
+func (m Model) openContainerDetail(containerID, containerName string) (tea.Model, tea.Cmd) {
+	m.selectedContainerID = containerID
+	recordRecent(favorites.KindContainer, containerName, containerName)
+	if m.containerDetailView != nil {
+		m.containerDetailView.SetContainer(containerID, containerName)
+	}
+	m.previousView = m.currentView
+	m.currentView = ViewContainerDetail
+	var initCmd tea.Cmd
+	if m.containerDetailView != nil {
+		initCmd = m.containerDetailView.Init()
+	}
+	return m, initCmd
+}
+
+// openContainerLogs 从其他视图跳转到容器日志，返回时回到原视图
+func (m Model) openContainerLogs(containerID, containerName string) (tea.Model, tea.Cmd) {
+	recordRecent(favorites.KindContainer, containerName, containerName)
+	if m.logsView != nil {
+		m.logsView.SetContainer(containerID, containerName)
+	}
+	m.previousView = m.currentView
+	m.currentView = ViewLogs
+	var initCmd tea.Cmd
+	if m.logsView != nil {
+		initCmd = m.logsView.Init()
+	}
+	return m, initCmd
+}
+
 // createExecShellCmd 创建执行 shell 命令
-func (m Model) createExecShellCmd(containerID, containerName, shell string) tea.ExecCommand {
-	return execShellCmd{
+func (m Model) createExecShellCmd(containerID, containerName, shell string) *execShellCmd {
+	return &execShellCmd{
 		dockerClient:  m.dockerClient,
 		containerID:   containerID,
 		containerName: containerName,
 		shell:         shell,
+		dockerCLI:     m.dockerCLI,
+		recordDir:     m.recordDir,
+		width:         m.width,
+		height:        m.height,
+		recordingPath: new(string),
 	}
 }
 
 func (m Model) Init() tea.Cmd {
 	// 初始化首页视图，加载统计信息
+	var homeCmd tea.Cmd
 	if m.homeView != nil {
-		return m.homeView.Init()
+		homeCmd = m.homeView.Init()
 	}
-	return nil
+	// 连接正常时在首页显示启动自检结果（连接失败时直接进入诊断视图）
+	if m.dockerConnected {
+		homeCmd = tea.Batch(homeCmd, m.runSelfCheck())
+		m.watchComposeEvents()
+	}
+	if m.updateCheck {
+		homeCmd = tea.Batch(homeCmd, m.checkForUpdate())
+	}
+	// 命令行指定了启动视图时同时加载该视图的数据
+	if m.startupCmd != nil {
+		return tea.Batch(homeCmd, m.startupCmd)
+	}
+	return homeCmd
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -378,11 +662,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case GoBackMsg, imageui.GoBackMsg, networkui.GoBackMsg, composeui.GoBackMsg:
 		// 视图请求返回
 		return m.goBack()
+
+	case fleetOpenLocalMsg:
+		// 多主机概览中选中了当前连接的主机
+		return m.enterContainerList()
 	
 	// ========== 视图切换请求消息 ==========
 	case imageui.ViewImageDetailsMsg:
 		// 镜像列表视图请求切换到镜像详情
 		if msg.Image != nil {
+			recordRecent(favorites.KindImage, msg.Image.ID, msg.Image.Repository+":"+msg.Image.Tag)
 			m.imageDetailsView = imageui.NewDetailsView(m.dockerClient, msg.Image)
 			m.imageDetailsView.SetSize(m.width, m.height)
 			m.previousView = m.currentView
@@ -394,6 +683,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case containerui.ViewDetailsMsg:
 		// 容器列表视图请求切换到容器详情
 		m.selectedContainerID = msg.ContainerID
+		recordRecent(favorites.KindContainer, msg.ContainerName, msg.ContainerName)
 		if m.containerDetailView != nil {
 			m.containerDetailView.SetContainer(msg.ContainerID, msg.ContainerName)
 		}
@@ -405,8 +695,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, initCmd
 	
+	case containerui.ViewEventsMsg:
+		// 容器列表视图请求查看该容器的事件
+		return m.enterEvents(docker.EventFocus{Type: "container", ID: msg.ContainerID, Name: msg.ContainerName})
+
+	case imageui.ViewEventsMsg:
+		// 镜像列表视图请求查看该镜像及其容器的事件
+		return m.enterEvents(docker.EventFocus{Type: "image", ID: msg.ImageID, Name: msg.ImageName})
+
 	case containerui.ViewLogsMsg:
 		// 容器列表视图请求切换到日志视图
+		recordRecent(favorites.KindContainer, msg.ContainerName, msg.ContainerName)
 		if m.logsView != nil {
 			m.logsView.SetContainer(msg.ContainerID, msg.ContainerName)
 		}
@@ -433,6 +732,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Compose 列表视图请求切换到项目详情
 		if msg.Project != nil {
 			if project, ok := msg.Project.(*compose.Project); ok {
+				recordRecentProject(project)
 				if m.composeDetailView != nil {
 					m.composeDetailView.SetProject(project)
 					m.composeDetailView.SetSize(m.width, m.height)
@@ -447,6 +747,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case composeui.GoToDetailMsg:
 		// Compose 列表视图请求切换到项目详情（来自 compose 子包）
 		if msg.Project != nil {
+			recordRecentProject(msg.Project)
 			if m.composeDetailView != nil {
 				m.composeDetailView.SetProject(msg.Project)
 				m.composeDetailView.SetSize(m.width, m.height)
@@ -459,22 +760,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	
 	case composeui.GoToContainerDetailMsg:
 		// Compose 详情视图请求跳转到容器详情
-		m.selectedContainerID = msg.ContainerID
-		if m.containerDetailView != nil {
-			m.containerDetailView.SetContainer(msg.ContainerID, msg.ContainerName)
-		}
-		m.previousView = m.currentView
-		m.currentView = ViewContainerDetail
-		var initCmd tea.Cmd
-		if m.containerDetailView != nil {
-			initCmd = m.containerDetailView.Init()
-		}
-		return m, initCmd
+		return m.openContainerDetail(msg.ContainerID, msg.ContainerName)
+	
+	case imageui.GoToContainerDetailMsg:
+		// 镜像的"被谁使用"对话框请求跳转到容器详情
+		return m.openContainerDetail(msg.ContainerID, msg.ContainerName)
 	
 	case composeui.GoToContainerLogsMsg:
 		// Compose 详情视图请求跳转到容器日志
+		return m.openContainerLogs(msg.ContainerID, msg.ContainerName)
+	
+	case imageui.GoToContainerLogsMsg:
+		// 镜像的"被谁使用"对话框请求跳转到容器日志
+		return m.openContainerLogs(msg.ContainerID, msg.ContainerName)
+	
+	case composeui.GoToMergedLogsMsg:
+		// Compose 详情视图请求合并查看项目所有容器的日志
+		sources := make([]containerui.LogSource, len(msg.Containers))
+		for i, c := range msg.Containers {
+			sources[i] = containerui.LogSource{ID: c.ID, Name: c.Name}
+		}
 		if m.logsView != nil {
-			m.logsView.SetContainer(msg.ContainerID, msg.ContainerName)
+			m.logsView.SetMergedContainers(msg.ProjectName, sources)
 		}
 		m.previousView = m.currentView
 		m.currentView = ViewLogs
@@ -492,6 +799,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.shellSelector.SetSize(m.width, m.height)
 		return m, m.shellSelector.Init()
 	
+	case containerui.ExecShellMsg:
+		// 容器列表（Kubernetes 节点对话框）请求进入节点 Shell
+		m.selectedContainerID = msg.ContainerID
+		m.showShellSelector = true
+		m.shellSelector.SetContainer(msg.ContainerID, msg.ContainerName)
+		m.shellSelector.SetSize(m.width, m.height)
+		return m, m.shellSelector.Init()
+	
+	case containerui.DebugShellMsg:
+		// 进入调试运行的临时容器，退出 shell 后删除
+		execCmd := m.createExecShellCmd(msg.ContainerID, msg.ContainerName, "")
+		execCmd.removeAfter = true
+		return m, tea.Exec(execCmd, func(err error) tea.Msg {
+			return shellExitedMsg{err: err, recordingPath: *execCmd.recordingPath}
+		})
+	
 	case containerui.GoBackMsg:
 		// 容器视图请求返回
 		return m.goBack()
@@ -499,24 +822,102 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case execShellMsg:
 		// 执行 shell 命令
 		// 使用 tea.Exec 临时释放终端控制
-		return m, tea.Exec(m.createExecShellCmd(msg.containerID, msg.containerName, msg.shell), func(err error) tea.Msg {
-			return shellExitedMsg{err: err}
+		execCmd := m.createExecShellCmd(msg.containerID, msg.containerName, msg.shell)
+		return m, tea.Exec(execCmd, func(err error) tea.Msg {
+			return shellExitedMsg{err: err, recordingPath: *execCmd.recordingPath}
 		})
 	
 	case shellExitedMsg:
 		// shell 退出后刷新 UI
+		var clearCmd tea.Cmd
 		if msg.err != nil {
 			m.errorMsg = fmt.Sprintf("Shell execution failed: %v", msg.err)
+		} else if msg.recordingPath != "" {
+			clearCmd = m.SetTemporaryMessage(MsgSuccess, "🔴 Session recorded to "+msg.recordingPath, 5)
 		}
 		// 重新进入 alt screen 并刷新
-		return m, tea.Sequence(
+		return m, tea.Batch(tea.Sequence(
 			tea.EnterAltScreen,
 			tea.ClearScreen,
 			func() tea.Msg {
 				return tea.WindowSizeMsg{Width: m.width, Height: m.height}
 			},
-		)
+		), clearCmd)
 		
+	case pinEventMsg:
+		return m.handlePinEvent(msg)
+		
+	case containerui.WaitForStartMsg:
+		return m.startLogsWait(msg)
+		
+	case logsStartMsg:
+		return m.handleLogsStart(msg)
+		
+	case customActionDoneMsg:
+		return m.handleCustomActionDone(msg)
+
+	case tasksResumedMsg:
+		return m.handleTasksResumed(msg)
+
+	case components.ActionDeniedMsg:
+		// 视图中的变更操作被策略拒绝，显示说明
+		m.policyNotice = msg.Action
+		return m, nil
+
+	case stateDumpMsg:
+		return m.handleStateDump(msg)
+		
+	case homeRefreshTickMsg:
+		if m.homeView != nil {
+			return m, m.homeView.RefreshTick(msg, m.currentView == ViewWelcome)
+		}
+		return m, nil
+		
+	case composeui.OperationStreamMsg:
+		// Compose 操作在离开 Compose 视图后继续进行，日志仍交给发起操作的视图（视图按操作流识别自己的消息）
+		var cmds []tea.Cmd
+		if m.composeListView != nil {
+			cmds = append(cmds, m.composeListView.Update(msg))
+		}
+		if m.composeDetailView != nil {
+			cmds = append(cmds, m.composeDetailView.Update(msg))
+		}
+		return m, tea.Batch(cmds...)
+		
+	case homeCardLoadedMsg, homeDiskLoadedMsg, homeSpinnerTickMsg:
+		// 首页数据在离开首页后加载完成时也要更新，否则返回首页时卡片一直显示加载中
+		if m.homeView != nil {
+			_, cmd := m.homeView.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+		
+	case selfCheckMsg:
+		if m.homeView != nil {
+			m.homeView.SetSelfCheck(msg.results)
+		}
+		return m, nil
+
+	case updateCheckMsg:
+		if m.homeView != nil {
+			m.homeView.SetUpdate(msg.update)
+		}
+		return m, nil
+		
+	case favoriteJumpFailedMsg:
+		return m, m.SetTemporaryMessage(MsgWarning, fmt.Sprintf("⚠️ Cannot open %s: %v", msg.name, msg.err), 5)
+
+	case historyUndoneMsg:
+		notice := m.history.handleUndone(msg)
+		if m.history.IsVisible() {
+			return m, nil
+		}
+		// 撤销完成前弹窗已关闭，改用状态栏提示
+		if msg.err != nil {
+			return m, m.SetTemporaryMessage(MsgWarning, notice, 5)
+		}
+		return m, m.SetTemporaryMessage(MsgSuccess, notice, 3)
+
 	case clearMessageMsg:
 		// 检查消息是否已过期
 		if time.Now().After(m.msgExpireTime) {
@@ -565,42 +966,93 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.shellSelector != nil {
 			m.shellSelector.SetSize(msg.Width, msg.Height)
 		}
+		if m.auditView != nil {
+			m.auditView.SetSize(msg.Width, msg.Height)
+		}
+		m.troubleshootView.SetSize(msg.Width, msg.Height)
+		if m.recipesView != nil {
+			m.recipesView.SetSize(msg.Width, msg.Height)
+		}
+		if m.servicesView != nil {
+			m.servicesView.SetSize(msg.Width, msg.Height)
+		}
+		m.tasksView.SetSize(msg.Width, msg.Height)
+		m.trashView.SetSize(msg.Width, msg.Height)
+		m.eventsView.SetSize(msg.Width, msg.Height)
+		m.fleetView.SetSize(msg.Width, msg.Height)
+		m.history.SetSize(msg.Width, msg.Height)
+		m.stacksView.SetSize(msg.Width, msg.Height)
 		return m, nil
 	
 	// 处理 Shell 选择器的消息
 	case components.ShellsDetectedMsg, components.ShellsDetectErrorMsg:
 		if m.showShellSelector && m.shellSelector != nil {
-			cmd := m.shellSelector.Update(msg)
+			_, _, cmd := m.shellSelector.Update(msg)
 			return m, cmd
 		}
 		return m, nil
 		
+	case quitCheckMsg:
+		return m.handleQuitCheck()
+		
 	case tea.KeyMsg:
+		// 调试快捷键在任何视图和弹窗中都保存当前画面
+		if key.Matches(msg, m.keys.DebugDump) {
+			return m, m.dumpState()
+		}
+		// 退出确认弹窗或正在等待任务结束时，只处理退出相关的按键
+		if m.quit != quitNone {
+			return m.handleQuitKeys(msg)
+		}
+		// ctrl+c 在任何视图和输入框中都退出
+		if msg.String() == "ctrl+c" {
+			return m.requestQuit()
+		}
+		// 是否恢复上次未完成任务的弹窗
+		if len(m.pendingTasks) > 0 {
+			return m.handleResumeKeys(msg)
+		}
+		
 		// 如果 Shell 选择器正在显示，优先处理
 		if m.showShellSelector && m.shellSelector != nil {
-			switch msg.String() {
-			case "enter":
-				// 选择 Shell 并执行
-				shell := m.shellSelector.GetSelectedShell()
-				if shell != "" {
-					m.showShellSelector = false
-					// 获取容器信息
-					containerID := m.shellSelector.ContainerID()
-					containerName := m.shellSelector.ContainerName()
-					return m, m.execShellWithShell(containerID, containerName, shell)
-				}
-			case "esc", "q":
-				// 取消选择
+			shell, cancelled, cmd := m.shellSelector.Update(msg)
+			if cancelled {
 				m.showShellSelector = false
 				return m, nil
-			default:
-				// 其他按键传递给选择器
-				cmd := m.shellSelector.Update(msg)
-				return m, cmd
 			}
+			if shell != "" {
+				// 选择 Shell 并执行
+				m.showShellSelector = false
+				return m, m.execShellWithShell(m.shellSelector.ContainerID(), m.shellSelector.ContainerName(), shell)
+			}
+			return m, cmd
+		}
+		
+		// 操作被拒绝的说明弹窗，任意键关闭
+		if m.policyNotice != "" {
+			m.policyNotice = ""
+			return m, nil
+		}
+		
+		// 操作历史弹窗打开时接管所有按键
+		if m.history.IsVisible() {
+			return m, m.history.Update(msg)
+		}
+		if msg.String() == "ctrl+z" {
+			m.history.Show()
 			return m, nil
 		}
 		
+		// 为刷新和导航键配置的按键换成视图处理的内置按键
+		if !m.viewCapturesInput() {
+			msg = components.TranslateKey(msg)
+		}
+		
+		// 用户自定义操作优先于内置快捷键
+		if action := m.customActionFor(msg); action != nil {
+			return m.runCustomAction(action)
+		}
+		
 		// 处理全局快捷键
 		newModel, cmd := m.handleGlobalKeys(msg)
 		if cmd != nil {
@@ -630,26 +1082,88 @@ func (m Model) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// 如果镜像列表视图的拉取输入框或打标签输入框或错误弹窗可见，不处理任何全局快捷键
 	if m.currentView == ViewImageList && m.imageListView != nil {
 		if m.imageListView.IsPullInputVisible() ||
+		   m.imageListView.IsImportInputVisible() ||
 		   m.imageListView.IsTagInputVisible() ||
+		   m.imageListView.IsNoteInputVisible() ||
+		   m.imageListView.IsPushInputVisible() ||
+		   m.imageListView.IsPruneViewVisible() ||
+		   m.imageListView.IsBuildCacheViewVisible() ||
+		   m.imageListView.IsUsedByViewVisible() ||
+		   m.imageListView.IsListExportVisible() ||
+		   m.imageListView.IsTransferPickerVisible() ||
+		   m.imageListView.IsTaskPickerVisible() ||
 		   m.imageListView.HasError() {
 			return m, nil
 		}
 	}
 	
+	// 如果容器列表视图的输入对话框可见，不处理任何全局快捷键（避免输入 q 时退出）
+	if m.currentView == ViewContainerList && m.containerListView != nil {
+		if m.containerListView.IsPruneViewVisible() ||
+		   m.containerListView.IsRestartPolicyViewVisible() ||
+		   m.containerListView.IsDuplicateViewVisible() ||
+		   m.containerListView.IsDebugViewVisible() ||
+		   m.containerListView.IsNoteInputVisible() ||
+		   m.containerListView.IsKubeViewVisible() ||
+		   m.containerListView.IsExecViewVisible() ||
+		   m.containerListView.IsNetTestViewVisible() ||
+		   m.containerListView.IsListExportVisible() {
+			return m, nil
+		}
+	}
+	
 	// 如果网络列表视图的错误弹窗或确认对话框可见，不处理任何全局快捷键
 	if m.currentView == ViewNetworkList && m.networkListView != nil {
-		if m.networkListView.HasError() || m.networkListView.ShowConfirmDialog() || m.networkListView.ShowFilterMenu() || m.networkListView.IsShowingCreateView() {
+		if m.networkListView.HasError() || m.networkListView.ShowConfirmDialog() || m.networkListView.ShowFilterMenu() || m.networkListView.IsShowingCreateView() || m.networkListView.IsListExportVisible() {
 			return m, nil
 		}
 	}
 	
+	// 容器详情正在搜索环境变量或查看 DNS 文件时，不处理全局快捷键
+	if m.currentView == ViewContainerDetail && m.containerDetailView != nil && m.containerDetailView.IsCapturingInput() {
+		return m, nil
+	}
+	
+	// Compose 详情的 profiles / 文件选择对话框可见时，不处理全局快捷键
+	if m.currentView == ViewComposeDetail && m.composeDetailView != nil && m.composeDetailView.IsChecklistVisible() {
+		return m, nil
+	}
+	
+	// Compose 视图的后台任务选择对话框可见时，不处理全局快捷键
+	if m.currentView == ViewComposeDetail && m.composeDetailView != nil && m.composeDetailView.IsTaskPickerVisible() {
+		return m, nil
+	}
+	if m.currentView == ViewComposeList && m.composeListView != nil && m.composeListView.IsTaskPickerVisible() {
+		return m, nil
+	}
+	
+	// Swarm 服务视图正在输入副本数或确认操作时，不处理全局快捷键
+	if m.currentView == ViewServices && m.servicesView.IsCapturingInput() {
+		return m, nil
+	}
+
+	// 容器栈视图正在选择容器、输入名称/等待时长或调整成员时，不处理全局快捷键
+	if m.currentView == ViewStacks && m.stacksView.IsCapturingInput() {
+		return m, nil
+	}
+
+	// 回收站视图正在查看配置或等待确认删除时，不处理全局快捷键
+	if m.currentView == ViewTrash && m.trashView.IsCapturingInput() {
+		return m, nil
+	}
+
+	// 事件视图正在输入过滤关键字或导出路径时，不处理全局快捷键
+	if m.currentView == ViewEvents && m.eventsView.IsCapturingInput() {
+		return m, nil
+	}
+	
 	// 首先处理无条件全局快捷键（这些键在任何视图都优先处理）
-	switch msg.String() {
-	case "q", "ctrl+c":
-		// 退出程序
-		return m, tea.Quit
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		// 退出程序（有后台任务时先确认）
+		return m.requestQuit()
 		
-	case "?":
+	case key.Matches(msg, m.keys.Help):
 		// 显示帮助面板
 		if m.currentView != ViewHelp {
 			m.previousView = m.currentView
@@ -687,7 +1201,10 @@ func (m Model) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleWelcomeKeys 处理欢迎界面快捷键
 func (m Model) handleWelcomeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if !m.dockerConnected {
-		// Docker 未连接，只支持退出
+		// Docker 未连接，只支持诊断和退出
+		if msg.String() == "T" {
+			return m.enterTroubleshoot()
+		}
 		return m, nil
 	}
 	
@@ -708,7 +1225,7 @@ func (m Model) handleWelcomeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "r", "f5":
 		// 刷新
 		if m.homeView != nil {
-			return m, m.homeView.Init()
+			return m, m.homeView.Refresh()
 		}
 		return m, nil
 	}
@@ -716,41 +1233,15 @@ func (m Model) handleWelcomeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
 		// 根据选中的卡片进入对应视图
-		if m.homeView != nil {
-			// 根据选中的资源进入视图
-			if !m.homeView.IsResourceAvailable() {
-				return m, m.SetTemporaryMessage(MsgWarning, "⚠️ This feature is unavailable", 3)
-			}
-			
-			switch m.homeView.GetSelectedResource() {
-			case ResourceContainers:
-				return m.enterContainerList()
-			case ResourceImages:
-				return m.enterImageList()
-			case ResourceCompose:
-				return m.enterComposeList()
-			case ResourceNetworks:
-				return m.enterNetworkList()
-			}
+		return m.enterHomeCard()
+		
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		// 数字键对应卡片上显示的序号（卡片顺序可在首页配置中调整）
+		if m.homeView != nil && m.homeView.SelectCard(int(msg.String()[0]-'1')) {
+			return m.enterHomeCard()
 		}
 		return m, nil
 		
-	case "1":
-		// 直接进入容器列表
-		return m.enterContainerList()
-		
-	case "2":
-		// 进入镜像列表
-		return m.enterImageList()
-	
-	case "3", "4":
-		// 网络和卷管理（开发中）
-		return m, m.SetTemporaryMessage(MsgInfo, "🚧 This feature is in development...", 3)
-	
-	case "5":
-		// 进入 Compose 视图
-		return m.enterComposeList()
-		
 	case "c":
 		// 快捷键进入容器列表
 		return m.enterContainerList()
@@ -770,11 +1261,81 @@ func (m Model) handleWelcomeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "o":
 		// 快捷键进入 Compose 视图
 		return m.enterComposeList()
+
+	case "a":
+		// 查看审计日志
+		return m.enterAuditLog()
+
+	case "R":
+		// 容器模板
+		return m.enterRecipes()
+
+	case "s":
+		// Swarm 服务
+		return m.enterServices()
+
+	case "T":
+		// 连接诊断
+		return m.enterTroubleshoot()
+
+	case "t":
+		// 后台任务历史
+		return m.enterTasks()
+
+	case "f":
+		// 多主机概览
+		return m.enterFleet()
+
+	case "S":
+		// 容器栈
+		return m.enterStacks()
+
+	case "d":
+		// 回收站
+		return m.enterTrash()
+
+	case "e":
+		// Docker 事件
+		return m.enterEvents(docker.EventFocus{})
+	}
+
+	// 收藏和最近访问的资源、最近活跃的 Compose 项目
+	if m.homeView != nil {
+		if ref, ok := m.homeView.JumpTarget(msg.String()); ok {
+			return m.jumpToRef(ref)
+		}
+		if project, ok := m.homeView.ProjectTarget(msg.String()); ok {
+			return m.jumpToProject(project)
+		}
 	}
 	
 	return m, nil
 }
 
+// enterHomeCard 进入首页选中的卡片：资源卡片进入对应列表，快捷操作卡片执行操作
+func (m Model) enterHomeCard() (tea.Model, tea.Cmd) {
+	if m.homeView == nil {
+		return m, nil
+	}
+	if !m.homeView.IsResourceAvailable() {
+		return m, m.SetTemporaryMessage(MsgWarning, "⚠️ This feature is unavailable", 3)
+	}
+	
+	switch m.homeView.GetSelectedResource() {
+	case ResourceContainers:
+		return m.enterContainerList()
+	case ResourceImages:
+		return m.enterImageList()
+	case ResourceCompose:
+		return m.enterComposeList()
+	case ResourceNetworks:
+		return m.enterNetworkList()
+	case ResourceAction:
+		return m.runQuickAction(m.homeView.GetSelectedAction())
+	}
+	return m, nil
+}
+
 // enterContainerList 进入容器列表视图
 func (m Model) enterContainerList() (tea.Model, tea.Cmd) {
 	m.previousView = m.currentView
@@ -789,6 +1350,70 @@ func (m Model) enterContainerList() (tea.Model, tea.Cmd) {
 	return m, initCmd
 }
 
+// enterAuditLog 进入审计日志视图
+func (m Model) enterAuditLog() (tea.Model, tea.Cmd) {
+	m.previousView = m.currentView
+	m.currentView = ViewAuditLog
+	return m, m.auditView.Init()
+}
+
+// enterServices 进入 Swarm 服务视图
+func (m Model) enterServices() (tea.Model, tea.Cmd) {
+	m.previousView = m.currentView
+	m.currentView = ViewServices
+	return m, m.servicesView.Init()
+}
+
+// enterTasks 进入后台任务历史视图
+func (m Model) enterTasks() (tea.Model, tea.Cmd) {
+	m.previousView = m.currentView
+	m.currentView = ViewTasks
+	return m, m.tasksView.Init()
+}
+
+// enterFleet 进入多主机概览视图
+func (m Model) enterFleet() (tea.Model, tea.Cmd) {
+	m.previousView = m.currentView
+	m.currentView = ViewFleet
+	return m, m.fleetView.Init()
+}
+
+// enterStacks 进入容器栈视图
+func (m Model) enterStacks() (tea.Model, tea.Cmd) {
+	m.previousView = m.currentView
+	m.currentView = ViewStacks
+	return m, m.stacksView.Init()
+}
+
+// enterTrash 进入回收站视图
+func (m Model) enterTrash() (tea.Model, tea.Cmd) {
+	m.previousView = m.currentView
+	m.currentView = ViewTrash
+	return m, m.trashView.Init()
+}
+
+// enterEvents 进入 Docker 事件视图，focus 为零值时显示全部资源的事件
+func (m Model) enterEvents(focus docker.EventFocus) (tea.Model, tea.Cmd) {
+	m.previousView = m.currentView
+	m.currentView = ViewEvents
+	m.eventsView.SetFocus(focus)
+	return m, m.eventsView.Init()
+}
+
+// enterTroubleshoot 进入连接诊断视图
+func (m Model) enterTroubleshoot() (tea.Model, tea.Cmd) {
+	m.previousView = m.currentView
+	m.currentView = ViewTroubleshoot
+	return m, m.troubleshootView.Init()
+}
+
+// enterRecipes 进入容器模板视图
+func (m Model) enterRecipes() (tea.Model, tea.Cmd) {
+	m.previousView = m.currentView
+	m.currentView = ViewRecipes
+	return m, m.recipesView.Init()
+}
+
 // enterComposeList 进入 Compose 项目列表视图
 func (m Model) enterComposeList() (tea.Model, tea.Cmd) {
 	if m.composeListView == nil {
@@ -846,15 +1471,18 @@ func (m Model) goBack() (tea.Model, tea.Cmd) {
 	case ViewContainerList:
 		m.currentView = ViewWelcome
 	case ViewContainerDetail:
-		// 如果是从 Compose 详情进入的，返回 Compose 详情
-		if m.previousView == ViewComposeDetail {
-			m.currentView = ViewComposeDetail
+		if m.containerDetailView != nil {
+			m.containerDetailView.Stop()
+		}
+		// 如果是从 Compose 详情或镜像列表进入的，返回原视图
+		if m.previousView == ViewComposeDetail || m.previousView == ViewImageList {
+			m.currentView = m.previousView
 		} else {
 			m.currentView = ViewContainerList
 		}
 	case ViewLogs:
-		// 返回到之前的视图（可能是容器详情、容器列表或 Compose 详情）
-		if m.previousView == ViewContainerDetail || m.previousView == ViewContainerList || m.previousView == ViewComposeDetail {
+		// 返回到之前的视图（可能是容器详情、容器列表、Compose 详情或镜像列表）
+		if m.previousView == ViewContainerDetail || m.previousView == ViewContainerList || m.previousView == ViewComposeDetail || m.previousView == ViewImageList {
 			m.currentView = m.previousView
 		} else {
 			m.currentView = ViewContainerList
@@ -864,6 +1492,9 @@ func (m Model) goBack() (tea.Model, tea.Cmd) {
 	case ViewComposeList:
 		m.currentView = ViewWelcome
 	case ViewComposeDetail:
+		if m.composeDetailView != nil {
+			m.composeDetailView.StopStats()
+		}
 		m.currentView = ViewComposeList
 	case ViewImageList:
 		m.currentView = ViewWelcome
@@ -873,6 +1504,16 @@ func (m Model) goBack() (tea.Model, tea.Cmd) {
 		m.currentView = ViewWelcome
 	case ViewNetworkDetail:
 		m.currentView = ViewNetworkList
+	case ViewAuditLog, ViewRecipes, ViewServices, ViewTroubleshoot, ViewTasks, ViewFleet, ViewStacks, ViewTrash:
+		m.currentView = ViewWelcome
+	case ViewEvents:
+		m.eventsView.Stop()
+		// 从容器或镜像列表聚焦进入的，返回原列表
+		if m.previousView == ViewContainerList || m.previousView == ViewImageList {
+			m.currentView = m.previousView
+		} else {
+			m.currentView = ViewWelcome
+		}
 	default:
 		m.currentView = ViewWelcome
 	}
@@ -882,6 +1523,11 @@ func (m Model) goBack() (tea.Model, tea.Cmd) {
 	m.successMsg = ""
 	m.warningMsg = ""
 	
+	// 回到 Compose 详情时恢复服务资源列的刷新
+	if m.currentView == ViewComposeDetail && m.composeDetailView != nil {
+		return m, m.composeDetailView.ResumeStats()
+	}
+	
 	return m, nil
 }
 
@@ -890,7 +1536,7 @@ func (m Model) goBack() (tea.Model, tea.Cmd) {
 func (m Model) handleContainerListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// 如果处于搜索模式、显示确认对话框、编辑视图、错误弹窗或 JSON 查看器，让视图自己处理
 	if m.containerListView != nil {
-		if m.containerListView.IsSearching() || m.containerListView.IsEditViewVisible() || m.containerListView.HasError() || m.containerListView.IsShowingJSONViewer() {
+		if m.containerListView.IsSearching() || m.containerListView.IsEditViewVisible() || m.containerListView.IsPruneViewVisible() || m.containerListView.IsRestartPolicyViewVisible() || m.containerListView.IsDuplicateViewVisible() || m.containerListView.IsDebugViewVisible() || m.containerListView.IsNoteInputVisible() || m.containerListView.IsKubeViewVisible() || m.containerListView.IsExecViewVisible() || m.containerListView.IsNetTestViewVisible() || m.containerListView.IsListExportVisible() || m.containerListView.HasError() || m.containerListView.IsShowingJSONViewer() {
 			return m, nil  // 返回 nil，让 Update 传递给视图
 		}
 	}
@@ -898,34 +1544,37 @@ func (m Model) handleContainerListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "s":
 		// 进入容器 Shell - 显示 Shell 选择器（需要访问全局 shellSelector）
-		if m.containerListView != nil {
-			if container := m.containerListView.GetSelectedContainer(); container != nil {
-				// 检查容器是否正在运行
-				if container.State != "running" {
-					return m, m.SetTemporaryMessage(MsgWarning, "⚠️ Can only execute shell in running containers", 3)
-				}
-				
-				// 设置选中的容器信息
-				m.selectedContainerID = container.ID
-				
-				// 显示 Shell 选择器
-				m.showShellSelector = true
-				m.shellSelector.SetContainer(container.ID, container.Name)
-				m.shellSelector.SetSize(m.width, m.height)
-				m.shellSelector.SetCallbacks(
-					func(shell string) {
-						// 选择 Shell 后的回调将在 Update 中处理
-					},
-					func() {
-						// 取消回调将在 Update 中处理
-					},
-				)
-				return m, m.shellSelector.Init()
-			} else {
-				return m, m.SetTemporaryMessage(MsgWarning, "⚠️ Please select a container first", 3)
+		cmd := components.RunAction("container.exec", func() tea.Cmd {
+			if m.containerListView == nil {
+				return m.SetTemporaryMessage(MsgError, "❌ View error", 3)
 			}
-		}
-		return m, m.SetTemporaryMessage(MsgError, "❌ View error", 3)
+			container := m.containerListView.GetSelectedContainer()
+			if container == nil {
+				return m.SetTemporaryMessage(MsgWarning, "⚠️ Please select a container first", 3)
+			}
+			// 检查容器是否正在运行
+			if container.State != "running" {
+				return m.SetTemporaryMessage(MsgWarning, "⚠️ Can only execute shell in running containers", 3)
+			}
+			
+			// 设置选中的容器信息
+			m.selectedContainerID = container.ID
+			
+			// 显示 Shell 选择器
+			m.showShellSelector = true
+			m.shellSelector.SetContainer(container.ID, container.Name)
+			m.shellSelector.SetSize(m.width, m.height)
+			m.shellSelector.SetCallbacks(
+				func(shell string) {
+					// 选择 Shell 后的回调将在 Update 中处理
+				},
+				func() {
+					// 取消回调将在 Update 中处理
+				},
+			)
+			return m.shellSelector.Init()
+		})
+		return m, cmd
 	}
 	
 	// 其他按键未处理，返回 nil 让 Update 传递给视图
@@ -936,6 +1585,10 @@ func (m Model) handleContainerListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m Model) handleContainerDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// 只处理特定快捷键，其他让视图处理
 	switch msg.String() {
+	case "W":
+		// 固定/取消固定当前容器
+		return m.togglePin()
+		
 	case "l":
 		// 从详情视图查看容器日志
 		if m.selectedContainerID != "" {
@@ -970,7 +1623,10 @@ func (m Model) handleContainerDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		
 	case "s":
 		// 进入容器 Shell - 显示 Shell 选择器
-		if m.selectedContainerID != "" {
+		cmd := components.RunAction("container.exec", func() tea.Cmd {
+			if m.selectedContainerID == "" {
+				return m.SetTemporaryMessage(MsgWarning, "⚠️ Please select a container first", 3)
+			}
 			// 从详情视图获取容器名称和状态
 			containerName := m.selectedContainerID[:12]
 			containerState := "unknown"
@@ -983,16 +1639,16 @@ func (m Model) handleContainerDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			
 			// 检查容器是否正在运行
 			if containerState != "running" {
-				return m, m.SetTemporaryMessage(MsgWarning, "⚠️ Can only execute shell in running containers", 3)
+				return m.SetTemporaryMessage(MsgWarning, "⚠️ Can only execute shell in running containers", 3)
 			}
 			
 			// 显示 Shell 选择器
 			m.showShellSelector = true
 			m.shellSelector.SetContainer(m.selectedContainerID, containerName)
 			m.shellSelector.SetSize(m.width, m.height)
-			return m, m.shellSelector.Init()
-		}
-		return m, m.SetTemporaryMessage(MsgWarning, "⚠️ Please select a container first", 3)
+			return m.shellSelector.Init()
+		})
+		return m, cmd
 	}
 	
 	// 其他按键未处理，返回 nil 让消息传递给视图
@@ -1001,7 +1657,10 @@ func (m Model) handleContainerDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleLogsKeys 处理日志视图的快捷键
 func (m Model) handleLogsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// 日志视图处理所有自己的按键，这里不拦截任何按键
+	// 日志视图处理自己的按键，这里只处理固定容器
+	if msg.String() == "W" && m.logsView != nil && !m.logsView.IsCapturingInput() {
+		return m.togglePin()
+	}
 	return m, nil
 }
 
@@ -1052,7 +1711,7 @@ func (m Model) fillBackground(content string) string {
 		}
 		
 		// 计算可见字符长度（排除 ANSI 转义码）
-		visibleLen := visibleLength(line)
+		visibleLen := format.Width(line)
 		
 		// 如果行太短，用空格填充到屏幕宽度
 		if visibleLen < m.width {
@@ -1069,27 +1728,13 @@ func (m Model) fillBackground(content string) string {
 	return result.String()
 }
 
-// visibleLength 计算可见字符长度（排除 ANSI 转义码）
-func visibleLength(s string) int {
-	inEscape := false
-	length := 0
-	for _, r := range s {
-		if r == '\x1b' {
-			inEscape = true
-			continue
-		}
-		if inEscape {
-			if r == 'm' {
-				inEscape = false
-			}
-			continue
-		}
-		length++
-	}
-	return length
+func (m Model) View() string {
+	// ASCII 回退模式下统一替换所有视图中的图标和框线
+	return styles.Plain(m.renderView())
 }
 
-func (m Model) View() string {
+// renderView 渲染当前视图
+func (m Model) renderView() string {
 	// 如果 Shell 选择器正在显示，优先渲染它
 	if m.showShellSelector && m.shellSelector != nil {
 		return m.shellSelector.View()
@@ -1113,12 +1758,14 @@ func (m Model) View() string {
 		}
 	case ViewContainerDetail:
 		if m.containerDetailView != nil {
+			m.syncPinBadges()
 			content = m.containerDetailView.View()
 		} else {
 			content = "📋 Container details view not initialized"
 		}
 	case ViewLogs:
 		if m.logsView != nil {
+			m.syncPinBadges()
 			content = m.logsView.View()
 		} else {
 			content = "📜 Logs view not initialized"
@@ -1165,6 +1812,24 @@ func (m Model) View() string {
 		} else {
 			content = "🌐 Network details view not initialized"
 		}
+	case ViewAuditLog:
+		content = m.auditView.View()
+	case ViewRecipes:
+		content = m.recipesView.View()
+	case ViewTroubleshoot:
+		content = m.troubleshootView.View()
+	case ViewServices:
+		content = m.servicesView.View()
+	case ViewTasks:
+		content = m.tasksView.View()
+	case ViewFleet:
+		content = m.fleetView.View()
+	case ViewStacks:
+		content = m.stacksView.View()
+	case ViewTrash:
+		content = m.trashView.View()
+	case ViewEvents:
+		content = m.eventsView.View()
 	default:
 		content = "Unknown view"
 	}
@@ -1189,6 +1854,22 @@ func (m Model) View() string {
 		}
 	}
 	
+	if policy.ReadOnly() || policy.Restricted() {
+		content = m.renderPolicyBadge(content)
+	}
+	if m.history.IsVisible() {
+		content = m.history.View(content)
+	}
+	if m.policyNotice != "" {
+		content = m.overlayPolicyNotice(content)
+	}
+	if len(m.pendingTasks) > 0 {
+		content = m.overlayResumePrompt(content)
+	}
+	if m.quit != quitNone {
+		content = m.overlayQuitPrompt(content)
+	}
+	
 	// 填充每行到屏幕宽度
 	return m.fillBackground(content)
 }
@@ -1200,7 +1881,7 @@ func (m Model) delegateToCurrentView(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.currentView {
 	case ViewWelcome:
 		// ViewWelcome 的按键已经在 handleWelcomeKeys 中处理了
-		// 这里只处理非按键消息（如 homeStatsLoadedMsg）
+		// 这里只处理非按键消息
 		if _, isKeyMsg := msg.(tea.KeyMsg); !isKeyMsg {
 			if m.homeView != nil {
 				_, cmd = m.homeView.Update(msg)
@@ -1242,6 +1923,24 @@ func (m Model) delegateToCurrentView(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.networkDetailView != nil {
 			m.networkDetailView, cmd = m.networkDetailView.Update(msg)
 		}
+	case ViewAuditLog:
+		_, cmd = m.auditView.Update(msg)
+	case ViewRecipes:
+		_, cmd = m.recipesView.Update(msg)
+	case ViewTroubleshoot:
+		_, cmd = m.troubleshootView.Update(msg)
+	case ViewServices:
+		_, cmd = m.servicesView.Update(msg)
+	case ViewTasks:
+		_, cmd = m.tasksView.Update(msg)
+	case ViewFleet:
+		_, cmd = m.fleetView.Update(msg)
+	case ViewStacks:
+		_, cmd = m.stacksView.Update(msg)
+	case ViewTrash:
+		_, cmd = m.trashView.Update(msg)
+	case ViewEvents:
+		_, cmd = m.eventsView.Update(msg)
 	}
 	
 	return m, cmd
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docktui/internal/version"
+)
+
+// updateNotes 首页新版本提示中显示的更新摘要条数
+const updateNotes = 3
+
+// updateCheckMsg 后台检查新版本的结果，查询失败时 update 为 nil（不打扰用户）
+type updateCheckMsg struct {
+	update *version.Update
+}
+
+// SetUpdateCheck 开启启动时的新版本检查（配置 updates.check）
+func SetUpdateCheck(m Model, enabled bool) Model {
+	m.updateCheck = enabled
+	return m
+}
+
+// checkForUpdate 在后台查询 GitHub 上的最新版本（24 小时内使用缓存的结果）
+func (m Model) checkForUpdate() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		update, _ := version.Check(ctx, false)
+		return updateCheckMsg{update: update}
+	}
+}
+
+// SetUpdate 设置新版本检查结果
+func (v *HomeView) SetUpdate(update *version.Update) {
+	v.update = update
+}
+
+// renderUpdateNotice 有新版本时显示一行提示和更新摘要
+func (v *HomeView) renderUpdateNotice() string {
+	if v.update == nil || !v.update.Available {
+		return ""
+	}
+	width := v.width
+	if width < 80 {
+		width = 80
+	}
+
+	release := v.update.Latest
+	noticeStyle := lipgloss.NewStyle().Foreground(ThemeHighlight)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	lines := []string{
+		noticeStyle.Render("⬆ docktui "+release.Tag+" is available (current "+v.update.Current+")") + mutedStyle.Render("  "+release.URL),
+	}
+	for _, note := range release.Notes(updateNotes) {
+		lines = append(lines, mutedStyle.Render("• "+note))
+	}
+
+	for i, line := range lines {
+		if lipgloss.Width(line) > width-4 {
+			line = lipgloss.NewStyle().MaxWidth(width - 4).Render(line)
+		}
+		lines[i] = strings.Repeat(" ", max(2, (width-lipgloss.Width(line))/2)) + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,239 @@
+// Package version 构建版本信息，以及（开启时）检查 GitHub 上是否有更新的 docktui
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version 构建时写入的版本号：go build -ldflags "-X docktui/internal/version.Version=v1.2.3"；
+// 未写入时使用模块版本（go install 安装时），都没有时为 dev
+var Version = ""
+
+// ReleasesURL 查询最新发布版本的 GitHub API 地址
+var ReleasesURL = "https://api.github.com/repos/nwnusun-cool/dockertui/releases/latest"
+
+// cacheTTL 检查结果的缓存时间，避免每次启动都访问 GitHub（未认证的 API 每小时限 60 次）
+const cacheTTL = 24 * time.Hour
+
+// pseudoVersion 本地构建时 go 根据 VCS 生成的伪版本（v0.0.0-20261015005145-fc8eb9dd5768+dirty），不对应任何发布版本
+var pseudoVersion = regexp.MustCompile(`\d{14}-[0-9a-f]{12}(\+dirty)?$`)
+
+// Current 当前版本号
+func Current() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" &&
+		!pseudoVersion.MatchString(info.Main.Version) {
+		return info.Main.Version
+	}
+	return "dev"
+}
+
+// Revision 构建时的 VCS 提交（12 位），工作区有修改时加 "-dirty"；没有 VCS 信息时为空
+func Revision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	var rev string
+	var dirty bool
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			rev = s.Value
+		case "vcs.modified":
+			dirty = s.Value == "true"
+		}
+	}
+	if len(rev) > 12 {
+		rev = rev[:12]
+	}
+	if rev != "" && dirty {
+		rev += "-dirty"
+	}
+	return rev
+}
+
+// String 完整的版本说明，如 "docktui v1.2.3 (3f2a9c1d0b7e, go1.22.5 linux/amd64)"
+func String() string {
+	details := []string{runtime.Version() + " " + runtime.GOOS + "/" + runtime.GOARCH}
+	if rev := Revision(); rev != "" {
+		details = append([]string{rev}, details...)
+	}
+	return fmt.Sprintf("docktui %s (%s)", Current(), strings.Join(details, ", "))
+}
+
+// Release GitHub 上的一个发布版本
+type Release struct {
+	Tag         string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	URL         string    `json:"html_url"`
+	Body        string    `json:"body"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// Notes 更新日志摘要：发布说明中的前 n 个列表项，没有列表项时取前 n 行非空文本
+func (r *Release) Notes(n int) []string {
+	var items, text []string
+	for _, line := range strings.Split(r.Body, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* "):
+			items = append(items, strings.TrimSpace(line[2:]))
+		default:
+			text = append(text, line)
+		}
+	}
+	if len(items) == 0 {
+		items = text
+	}
+	if len(items) > n {
+		items = items[:n]
+	}
+	return items
+}
+
+// Update 检查结果
+type Update struct {
+	Current   string
+	Latest    *Release
+	Available bool      // Latest 比当前版本新
+	CheckedAt time.Time // 查询 GitHub 的时间（可能来自缓存）
+}
+
+// cachedCheck 缓存文件的内容
+type cachedCheck struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Release   *Release  `json:"release"`
+}
+
+// Check 查询最新发布版本并与当前版本比较；24 小时内的结果从缓存读取，force 时总是重新查询
+func Check(ctx context.Context, force bool) (*Update, error) {
+	cachePath := cacheFile()
+	var cached cachedCheck
+	if !force && cachePath != "" {
+		if data, err := os.ReadFile(cachePath); err == nil && json.Unmarshal(data, &cached) == nil &&
+			cached.Release != nil && time.Since(cached.CheckedAt) < cacheTTL {
+			return newUpdate(cached.Release, cached.CheckedAt), nil
+		}
+	}
+
+	release, err := fetchLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if cachePath != "" {
+		if data, err := json.Marshal(cachedCheck{CheckedAt: now, Release: release}); err == nil {
+			if os.MkdirAll(filepath.Dir(cachePath), 0700) == nil {
+				os.WriteFile(cachePath, data, 0600)
+			}
+		}
+	}
+	return newUpdate(release, now), nil
+}
+
+func newUpdate(release *Release, checkedAt time.Time) *Update {
+	current := Current()
+	return &Update{Current: current, Latest: release, Available: Newer(current, release.Tag), CheckedAt: checkedAt}
+}
+
+// fetchLatest 请求 GitHub 的最新发布版本（不包括草稿和预发布版本）
+func fetchLatest(ctx context.Context) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ReleasesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "docktui/"+Current())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to check for updates: GitHub returned %s", resp.Status)
+	}
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+	if release.Tag == "" {
+		return nil, fmt.Errorf("failed to check for updates: release has no tag")
+	}
+	return &release, nil
+}
+
+// cacheFile 检查结果的缓存文件，用户缓存目录不可用时为空（不缓存）
+func cacheFile() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "docktui", "release.json")
+}
+
+// Newer latest 是否比 current 新（按语义化版本比较，预发布版本低于同号的正式版本）；
+// current 不是版本号（如开发构建 dev）时无法比较，返回 false
+func Newer(current, latest string) bool {
+	cur, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	lat, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		if lat.parts[i] != cur.parts[i] {
+			return lat.parts[i] > cur.parts[i]
+		}
+	}
+	// 正式版本比同号的预发布版本新；两个预发布版本按字符串比较
+	switch {
+	case cur.pre == "" || lat.pre == "":
+		return cur.pre != "" && lat.pre == ""
+	default:
+		return lat.pre > cur.pre
+	}
+}
+
+// semver 解析后的版本号
+type semver struct {
+	parts [3]int
+	pre   string
+}
+
+// parseVersion 解析 v1.2.3、1.2、v1.2.3-rc.1（忽略 +build 元数据）
+func parseVersion(s string) (semver, bool) {
+	var v semver
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, v.pre, _ = strings.Cut(s, "-")
+	fields := strings.Split(s, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return v, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.parts[i] = n
+	}
+	return v, true
+}
@@ -0,0 +1,84 @@
+package version
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestNewer 测试版本比较
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"v1.2.3", "v1.2.4", true},
+		{"v1.2.3", "v1.10.0", true},
+		{"v1.2.3", "v1.2.3", false},
+		{"v1.3.0", "v1.2.9", false},
+		{"1.2", "v1.2.1", true},
+		{"v1.2.3-rc.1", "v1.2.3", true},
+		{"v1.2.3", "v1.2.4-rc.1", true},
+		{"v1.2.3", "v1.2.3-rc.1", false},
+		{"v1.2.3-rc.1", "v1.2.3-rc.2", true},
+		{"v1.2.3+build.5", "v1.2.3", false},
+		{"dev", "v9.9.9", false},
+		{"v1.2.3", "nightly", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.current, tt.latest); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
+
+// TestReleaseNotes 测试更新摘要
+func TestReleaseNotes(t *testing.T) {
+	r := &Release{Body: "## What's changed\r\n\r\n- Add build cache view\r\n* Fix logs jump\r\n- Faster startup\r\n"}
+	got := strings.Join(r.Notes(2), "|")
+	if got != "Add build cache view|Fix logs jump" {
+		t.Errorf("Notes(2) = %q", got)
+	}
+
+	r = &Release{Body: "Bug fix release.\n\nSee the changelog."}
+	got = strings.Join(r.Notes(5), "|")
+	if got != "Bug fix release.|See the changelog." {
+		t.Errorf("Notes without list items = %q", got)
+	}
+}
+
+// TestCheck 测试查询最新版本和结果缓存
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", dir)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"tag_name":"v2.0.0","html_url":"https://example.com/v2.0.0","body":"- New"}`))
+	}))
+	defer server.Close()
+
+	oldURL, oldVersion := ReleasesURL, Version
+	ReleasesURL, Version = server.URL, "v1.0.0"
+	defer func() { ReleasesURL, Version = oldURL, oldVersion }()
+
+	update, err := Check(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if !update.Available || update.Latest.Tag != "v2.0.0" || update.Current != "v1.0.0" {
+		t.Errorf("Unexpected update: %+v", update)
+	}
+
+	// 24 小时内使用缓存，force 时重新查询
+	if _, err := Check(context.Background(), false); err != nil || requests != 1 {
+		t.Errorf("Expected cached result, got %d requests (err %v)", requests, err)
+	}
+	if _, err := Check(context.Background(), true); err != nil || requests != 2 {
+		t.Errorf("Expected forced check, got %d requests (err %v)", requests, err)
+	}
+}
@@ -0,0 +1,11 @@
+#!/bin/sh
+# 运行基准测试并保存结果，用 benchstat 与基线比较（见 docs/benchmarks.md）
+#   scripts/bench.sh              结果写入 docs/benchmarks/<日期>-<提交>.txt
+#   scripts/bench.sh out.txt      写入指定文件
+#   COUNT=10 scripts/bench.sh     每项运行的次数（默认 5）
+set -e
+cd "$(dirname "$0")/.."
+out=${1:-docs/benchmarks/$(date +%Y%m%d)-$(git rev-parse --short HEAD).txt}
+mkdir -p "$(dirname "$out")"
+go test -run '^$' -bench . -benchmem -count "${COUNT:-5}" ./internal/... | grep -v '^ok\|no test files\|^PASS$' | tee "$out"
+echo "saved to $out"